@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"siakad-poc/common"
+	"siakad-poc/config"
+	"siakad-poc/db/repositories"
+	"siakad-poc/modules/admin/usecases"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+)
+
+// runImportCourseCompletions implements the `import-course-completions` subcommand, it loads the passed courses
+// exported from the legacy system. The import report is printed to stdout as JSON, the process exits with a
+// non-zero code when nothing was imported.
+//
+//	./main import-course-completions -file nilai-2024.csv
+func runImportCourseCompletions(args []string) int {
+	flags := flag.NewFlagSet("import-course-completions", flag.ExitOnError)
+	filePath := flags.String("file", "", "path to the course completion CSV file (columns: nim, course_code, grade)")
+	_ = flags.Parse(args)
+
+	if *filePath == "" {
+		fmt.Fprintln(os.Stderr, "import-course-completions: -file is required")
+		flags.Usage()
+		return 2
+	}
+
+	ctx := context.Background()
+
+	pool, err := pgxpool.New(ctx, config.CurrentConfig.Database.DSN())
+	if err != nil {
+		log.Error().Err(err).Msg("cannot create database pool")
+		return 1
+	}
+	defer pool.Close()
+
+	file, err := os.Open(*filePath)
+	if err != nil {
+		log.Error().Err(err).Str("file", *filePath).Msg("cannot open import file")
+		return 1
+	}
+	defer file.Close()
+
+	useCase := usecases.NewCourseCompletionImportUseCase(
+		repositories.NewDefaultCourseCompletionRepository(pool),
+		common.NewPgxTransactionExecutor(pool),
+	)
+
+	report, importErr := useCase.Import(ctx, file)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		log.Error().Err(err).Msg("cannot write import report")
+		return 1
+	}
+
+	if importErr != nil {
+		log.Error().Err(importErr).Str("file", *filePath).Msg("course completion import failed")
+		return 1
+	}
+
+	log.Info().Int("imported_rows", report.ImportedRows).Str("file", *filePath).Msg("course completions imported")
+	return 0
+}
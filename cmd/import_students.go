@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"siakad-poc/common"
+	"siakad-poc/config"
+	"siakad-poc/db/repositories"
+	"siakad-poc/modules/admin/usecases"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+)
+
+// runImportStudents implements the `import-students` subcommand. The import report is printed to stdout
+// as JSON, the process exits with a non-zero code when nothing was imported.
+//
+//	./main import-students -file intake-2025.csv [-generate-passwords]
+func runImportStudents(args []string) int {
+	flags := flag.NewFlagSet("import-students", flag.ExitOnError)
+	filePath := flags.String("file", "", "path to the student CSV file (columns: email, name, nim, prodi, password)")
+	generatePasswords := flags.Bool("generate-passwords", false, "generate an initial password for rows without one")
+	_ = flags.Parse(args)
+
+	if *filePath == "" {
+		fmt.Fprintln(os.Stderr, "import-students: -file is required")
+		flags.Usage()
+		return 2
+	}
+
+	ctx := context.Background()
+
+	pool, err := pgxpool.New(ctx, config.CurrentConfig.Database.DSN())
+	if err != nil {
+		log.Error().Err(err).Msg("cannot create database pool")
+		return 1
+	}
+	defer pool.Close()
+
+	file, err := os.Open(*filePath)
+	if err != nil {
+		log.Error().Err(err).Str("file", *filePath).Msg("cannot open import file")
+		return 1
+	}
+	defer file.Close()
+
+	useCase := usecases.NewStudentImportUseCase(
+		repositories.NewDefaultUserRepository(pool),
+		repositories.NewDefaultStudentRepository(pool),
+		common.NewPgxTransactionExecutor(pool),
+	)
+
+	report, importErr := useCase.Import(ctx, file, usecases.StudentImportOptions{
+		GeneratePasswords: *generatePasswords,
+	})
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		log.Error().Err(err).Msg("cannot write import report")
+		return 1
+	}
+
+	if importErr != nil {
+		log.Error().Err(importErr).Str("file", *filePath).Msg("student import failed")
+		return 1
+	}
+
+	log.Info().Int("imported_rows", report.ImportedRows).Str("file", *filePath).Msg("students imported")
+	return 0
+}
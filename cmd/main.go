@@ -4,10 +4,15 @@ import (
 	"context"
 	"os"
 	"os/signal"
+	"siakad-poc/common"
+	"siakad-poc/common/jwtkeys"
 	"siakad-poc/config"
+	"siakad-poc/db/repositories"
 	"siakad-poc/modules"
 	"siakad-poc/modules/academic"
+	"siakad-poc/modules/admin"
 	"siakad-poc/modules/auth"
+	"siakad-poc/modules/curriculum"
 	"syscall"
 	"time"
 
@@ -25,22 +30,56 @@ import (
 
 func init() {
 	zerolog.ErrorStackMarshaler = pkgerrors.MarshalStack
+
+	err := config.LoadConfig()
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot load config")
+	}
 }
 
 func main() {
+	// Subcommands run to completion instead of starting the HTTP server
+	if len(os.Args) > 1 && os.Args[1] == "import-students" {
+		os.Exit(runImportStudents(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "import-course-completions" {
+		os.Exit(runImportCourseCompletions(os.Args[2:]))
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	if minGrade := config.CurrentConfig.Academic.PrerequisiteMinimumGrade(); !common.IsValidGrade(minGrade) {
+		log.Fatal().Str("prerequisite_min_grade", minGrade).Msg("invalid academic.prerequisite_min_grade in config")
+	}
+	if _, err := config.CurrentConfig.Academic.CampusLocation(); err != nil {
+		log.Fatal().Err(err).Str("timezone", config.CurrentConfig.Academic.Timezone).Msg("invalid academic.timezone in config")
+	}
+
 	// Initialize database connection pool
 	pool, err := pgxpool.New(ctx, config.CurrentConfig.Database.DSN())
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot create database pool")
 	}
 
+	// Token revocations are cached in-process, so every module must share the same store
+	tokenRevocationRepository := repositories.NewDefaultTokenRevocationRepository(pool, config.CurrentConfig.JWT.RevocationCacheTTL())
+
+	// Role permissions are cached in-process as well, so a change through the admin API applies to every module at once
+	permissionRepository := repositories.NewDefaultPermissionRepository(pool, config.CurrentConfig.Auth.PermissionCacheTTL())
+
+	// Access tokens are signed and verified with the same keyring across modules
+	keyring, err := jwtkeys.Load(config.CurrentConfig.JWT)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot load jwt keys")
+	}
+
 	// Mapping HTTP route prefix to relevant module
 	routePrefixToModuleMapping := map[string]modules.RoutableModule{
-		"/auth":     auth.NewModule(pool),
-		"/academic": academic.NewModule(pool),
+		"/auth":       auth.NewModule(pool, tokenRevocationRepository, permissionRepository, keyring),
+		"/academic":   academic.NewModule(pool, tokenRevocationRepository, permissionRepository, keyring),
+		"/admin":      admin.NewModule(pool, tokenRevocationRepository, permissionRepository, keyring),
+		"/curriculum": curriculum.NewModule(pool, tokenRevocationRepository, permissionRepository, keyring),
 	}
 
 	// Initialize HTTP handler library
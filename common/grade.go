@@ -0,0 +1,32 @@
+package common
+
+// Grades lists the letter grades (nilai huruf) from best to worst
+var Grades = []string{"A", "AB", "B", "BC", "C", "D", "E"}
+
+// GradePoints maps every letter grade to its grade point (bobot)
+var GradePoints = map[string]float64{
+	"A":  4.0,
+	"AB": 3.5,
+	"B":  3.0,
+	"BC": 2.5,
+	"C":  2.0,
+	"D":  1.0,
+	"E":  0.0,
+}
+
+// IsValidGrade reports whether the grade is one of the letter grades.
+func IsValidGrade(grade string) bool {
+	_, ok := GradePoints[grade]
+	return ok
+}
+
+// GradesAtLeast returns the letter grades equal to or better than the minimum grade, it is empty when the
+// minimum grade is unknown.
+func GradesAtLeast(minGrade string) []string {
+	for i, grade := range Grades {
+		if grade == minGrade {
+			return Grades[:i+1]
+		}
+	}
+	return nil
+}
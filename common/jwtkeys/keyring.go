@@ -0,0 +1,246 @@
+// Package jwtkeys holds the keys used to sign and verify access tokens.
+//
+// Without configured keys the keyring falls back to HS256 with the shared secret. Once asymmetric keys
+// are configured, tokens are signed with the key selected by `signing_key_id` and carry its `kid` header,
+// every configured key stays valid for verification and only public keys are published as JWKS.
+package jwtkeys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"os"
+	"siakad-poc/config"
+	"sort"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+)
+
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+
+	minRSAKeyBits = 2048
+)
+
+type key struct {
+	id         string
+	method     jwt.SigningMethod
+	privateKey crypto.PrivateKey // nil for verification-only keys
+	publicKey  crypto.PublicKey
+}
+
+type Keyring struct {
+	signingKey   *key
+	keys         map[string]*key
+	validMethods []string
+	hmacSecret   []byte // only set in HS256 mode
+}
+
+// JSONWebKey is a public key in RFC 7517 format
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`   // RSA modulus
+	E         string `json:"e,omitempty"`   // RSA exponent
+	Curve     string `json:"crv,omitempty"` // OKP curve
+	X         string `json:"x,omitempty"`   // OKP public key
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// Load builds the keyring from the JWT configuration, reading every configured PEM file.
+func Load(cfg config.JWTConfigParams) (*Keyring, error) {
+	if len(cfg.Keys) == 0 {
+		if cfg.Secret == "" {
+			return nil, errors.New("jwt: either a secret or signing keys must be configured")
+		}
+		return &Keyring{
+			keys:         map[string]*key{},
+			validMethods: []string{jwt.SigningMethodHS256.Alg()},
+			hmacSecret:   []byte(cfg.Secret),
+		}, nil
+	}
+
+	keyring := &Keyring{
+		keys: make(map[string]*key, len(cfg.Keys)),
+	}
+	methods := make(map[string]bool)
+	for _, keyConfig := range cfg.Keys {
+		k, err := loadKey(keyConfig)
+		if err != nil {
+			return nil, errors.Wrapf(err, "jwt: cannot load key %q", keyConfig.ID)
+		}
+		if _, exists := keyring.keys[k.id]; exists {
+			return nil, errors.Errorf("jwt: duplicate key id %q", k.id)
+		}
+
+		keyring.keys[k.id] = k
+		if !methods[k.method.Alg()] {
+			methods[k.method.Alg()] = true
+			keyring.validMethods = append(keyring.validMethods, k.method.Alg())
+		}
+	}
+
+	signingKey, ok := keyring.keys[cfg.SigningKeyID]
+	if !ok {
+		return nil, errors.Errorf("jwt: signing key %q is not configured", cfg.SigningKeyID)
+	}
+	if signingKey.privateKey == nil {
+		return nil, errors.Errorf("jwt: signing key %q has no private key", cfg.SigningKeyID)
+	}
+	keyring.signingKey = signingKey
+
+	return keyring, nil
+}
+
+// Sign signs the claims with the active signing key, setting the `kid` header for asymmetric keys.
+func (k *Keyring) Sign(claims jwt.Claims) (string, error) {
+	if k.signingKey == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(k.hmacSecret)
+	}
+
+	token := jwt.NewWithClaims(k.signingKey.method, claims)
+	token.Header["kid"] = k.signingKey.id
+	return token.SignedString(k.signingKey.privateKey)
+}
+
+// Keyfunc resolves the verification key of a token by its `kid` header, to be used with jwt.Parse.
+func (k *Keyring) Keyfunc(token *jwt.Token) (interface{}, error) {
+	if k.signingKey == nil {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrInvalidKeyType
+		}
+		return k.hmacSecret, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	verificationKey, ok := k.keys[kid]
+	if !ok {
+		return nil, errors.Errorf("unknown key id %q", kid)
+	}
+	// A token must use the algorithm of its key, otherwise the key could be misused with another algorithm
+	if token.Method.Alg() != verificationKey.method.Alg() {
+		return nil, jwt.ErrInvalidKeyType
+	}
+
+	return verificationKey.publicKey, nil
+}
+
+// ValidMethods lists the algorithms accepted for verification, to be passed to jwt.WithValidMethods.
+func (k *Keyring) ValidMethods() []string {
+	return k.validMethods
+}
+
+// JWKS returns the public keys of every configured asymmetric key, it is empty in HS256 mode.
+func (k *Keyring) JWKS() JSONWebKeySet {
+	set := JSONWebKeySet{
+		Keys: make([]JSONWebKey, 0, len(k.keys)),
+	}
+
+	for _, verificationKey := range k.keys {
+		jwk := JSONWebKey{
+			KeyID:     verificationKey.id,
+			Use:       "sig",
+			Algorithm: verificationKey.method.Alg(),
+		}
+
+		switch publicKey := verificationKey.publicKey.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+		}
+
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	sort.Slice(set.Keys, func(i, j int) bool {
+		return set.Keys[i].KeyID < set.Keys[j].KeyID
+	})
+
+	return set
+}
+
+func loadKey(keyConfig config.JWTKeyConfigParams) (*key, error) {
+	if keyConfig.ID == "" {
+		return nil, errors.New("kid is required")
+	}
+	if keyConfig.PrivateKeyFile == "" && keyConfig.PublicKeyFile == "" {
+		return nil, errors.New("either private_key_file or public_key_file is required")
+	}
+
+	k := &key{id: keyConfig.ID}
+
+	switch keyConfig.Algorithm {
+	case AlgorithmRS256:
+		k.method = jwt.SigningMethodRS256
+
+		if keyConfig.PrivateKeyFile != "" {
+			pem, err := os.ReadFile(keyConfig.PrivateKeyFile)
+			if err != nil {
+				return nil, errors.Wrap(err, "cannot read private key")
+			}
+			privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(pem)
+			if err != nil {
+				return nil, errors.Wrap(err, "cannot parse RSA private key")
+			}
+			k.privateKey = privateKey
+			k.publicKey = &privateKey.PublicKey
+		} else {
+			pem, err := os.ReadFile(keyConfig.PublicKeyFile)
+			if err != nil {
+				return nil, errors.Wrap(err, "cannot read public key")
+			}
+			publicKey, err := jwt.ParseRSAPublicKeyFromPEM(pem)
+			if err != nil {
+				return nil, errors.Wrap(err, "cannot parse RSA public key")
+			}
+			k.publicKey = publicKey
+		}
+
+		if bits := k.publicKey.(*rsa.PublicKey).N.BitLen(); bits < minRSAKeyBits {
+			return nil, errors.Errorf("RSA key must be at least %d bits, got %d", minRSAKeyBits, bits)
+		}
+	case AlgorithmEdDSA:
+		k.method = jwt.SigningMethodEdDSA
+
+		if keyConfig.PrivateKeyFile != "" {
+			pem, err := os.ReadFile(keyConfig.PrivateKeyFile)
+			if err != nil {
+				return nil, errors.Wrap(err, "cannot read private key")
+			}
+			privateKey, err := jwt.ParseEdPrivateKeyFromPEM(pem)
+			if err != nil {
+				return nil, errors.Wrap(err, "cannot parse Ed25519 private key")
+			}
+			k.privateKey = privateKey
+			k.publicKey = privateKey.(ed25519.PrivateKey).Public()
+		} else {
+			pem, err := os.ReadFile(keyConfig.PublicKeyFile)
+			if err != nil {
+				return nil, errors.Wrap(err, "cannot read public key")
+			}
+			publicKey, err := jwt.ParseEdPublicKeyFromPEM(pem)
+			if err != nil {
+				return nil, errors.Wrap(err, "cannot parse Ed25519 public key")
+			}
+			k.publicKey = publicKey
+		}
+	default:
+		return nil, errors.Errorf("unsupported algorithm %q, use %s or %s", keyConfig.Algorithm, AlgorithmRS256, AlgorithmEdDSA)
+	}
+
+	return k, nil
+}
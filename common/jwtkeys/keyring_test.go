@@ -0,0 +1,229 @@
+package jwtkeys
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"siakad-poc/config"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// Test Suite
+type KeyringTestSuite struct {
+	suite.Suite
+	rsaKey         *rsa.PrivateKey
+	rsaPrivateFile string
+	rsaPublicFile  string
+	edKey          ed25519.PrivateKey
+	edPrivateFile  string
+	edPublicFile   string
+}
+
+func (suite *KeyringTestSuite) SetupSuite() {
+	dir := suite.T().TempDir()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	suite.Require().NoError(err)
+	suite.rsaKey = rsaKey
+	suite.rsaPrivateFile = suite.writePEM(dir, "rsa.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey))
+	rsaPublic, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	suite.Require().NoError(err)
+	suite.rsaPublicFile = suite.writePEM(dir, "rsa.pub.pem", "PUBLIC KEY", rsaPublic)
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	suite.Require().NoError(err)
+	suite.edKey = edKey
+	edPrivate, err := x509.MarshalPKCS8PrivateKey(edKey)
+	suite.Require().NoError(err)
+	suite.edPrivateFile = suite.writePEM(dir, "ed25519.pem", "PRIVATE KEY", edPrivate)
+	edPublic, err := x509.MarshalPKIXPublicKey(edKey.Public())
+	suite.Require().NoError(err)
+	suite.edPublicFile = suite.writePEM(dir, "ed25519.pub.pem", "PUBLIC KEY", edPublic)
+}
+
+func (suite *KeyringTestSuite) writePEM(dir, name, blockType string, der []byte) string {
+	path := filepath.Join(dir, name)
+	err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600)
+	suite.Require().NoError(err)
+	return path
+}
+
+// load builds a keyring signing with the given key, failing the test on errors
+func (suite *KeyringTestSuite) load(signingKeyID string, keys ...config.JWTKeyConfigParams) *Keyring {
+	keyring, err := Load(config.JWTConfigParams{SigningKeyID: signingKeyID, Keys: keys})
+	suite.Require().NoError(err)
+	return keyring
+}
+
+func (suite *KeyringTestSuite) parse(keyring *Keyring, tokenString string) (*jwt.Token, error) {
+	return jwt.Parse(tokenString, keyring.Keyfunc, jwt.WithValidMethods(keyring.ValidMethods()))
+}
+
+func testClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub": "550e8400-e29b-41d4-a716-446655440001",
+		"exp": time.Now().Add(time.Minute).Unix(),
+	}
+}
+
+// Test tokens signed with either algorithm carry their kid and parse back with the same keyring
+func (suite *KeyringTestSuite) TestSignParse_RoundTrip() {
+	rsaConfig := config.JWTKeyConfigParams{ID: "rsa-2025", Algorithm: AlgorithmRS256, PrivateKeyFile: suite.rsaPrivateFile}
+	edConfig := config.JWTKeyConfigParams{ID: "ed-2025", Algorithm: AlgorithmEdDSA, PrivateKeyFile: suite.edPrivateFile}
+
+	for _, signingKeyID := range []string{"rsa-2025", "ed-2025"} {
+		keyring := suite.load(signingKeyID, rsaConfig, edConfig)
+
+		tokenString, err := keyring.Sign(testClaims())
+		suite.Require().NoError(err)
+
+		token, err := suite.parse(keyring, tokenString)
+		assert.NoError(suite.T(), err, signingKeyID)
+		assert.True(suite.T(), token.Valid, signingKeyID)
+		assert.Equal(suite.T(), signingKeyID, token.Header["kid"])
+	}
+}
+
+// Test a token verifies with a verification-only keyring holding just the public key
+func (suite *KeyringTestSuite) TestSignParse_PublicKeyOnly() {
+	signer := suite.load("rsa-2025", config.JWTKeyConfigParams{ID: "rsa-2025", Algorithm: AlgorithmRS256, PrivateKeyFile: suite.rsaPrivateFile})
+	verifier := suite.load("ed-2025",
+		config.JWTKeyConfigParams{ID: "rsa-2025", Algorithm: AlgorithmRS256, PublicKeyFile: suite.rsaPublicFile},
+		config.JWTKeyConfigParams{ID: "ed-2025", Algorithm: AlgorithmEdDSA, PrivateKeyFile: suite.edPrivateFile},
+	)
+
+	tokenString, err := signer.Sign(testClaims())
+	suite.Require().NoError(err)
+
+	_, err = suite.parse(verifier, tokenString)
+	assert.NoError(suite.T(), err)
+}
+
+// Test a token claiming the kid of a key of another algorithm is rejected, e.g. an EdDSA token with an RSA kid
+func (suite *KeyringTestSuite) TestKeyfunc_AlgorithmMismatch() {
+	keyring := suite.load("rsa-2025",
+		config.JWTKeyConfigParams{ID: "rsa-2025", Algorithm: AlgorithmRS256, PrivateKeyFile: suite.rsaPrivateFile},
+		config.JWTKeyConfigParams{ID: "ed-2025", Algorithm: AlgorithmEdDSA, PrivateKeyFile: suite.edPrivateFile},
+	)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, testClaims())
+	token.Header["kid"] = "rsa-2025"
+	tokenString, err := token.SignedString(suite.edKey)
+	suite.Require().NoError(err)
+
+	_, err = suite.parse(keyring, tokenString)
+	assert.ErrorIs(suite.T(), err, jwt.ErrInvalidKeyType)
+
+	// HS256 signed with the published RSA public key is the classic algorithm confusion attack
+	publicKey, err := os.ReadFile(suite.rsaPublicFile)
+	suite.Require().NoError(err)
+	token = jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims())
+	token.Header["kid"] = "rsa-2025"
+	tokenString, err = token.SignedString(publicKey)
+	suite.Require().NoError(err)
+
+	_, err = suite.parse(keyring, tokenString)
+	assert.Error(suite.T(), err)
+	_, err = keyring.Keyfunc(token)
+	assert.ErrorIs(suite.T(), err, jwt.ErrInvalidKeyType)
+}
+
+// Test tokens of a key removed from the configuration, or without a known kid, are rejected
+func (suite *KeyringTestSuite) TestKeyfunc_RotatedOutKid() {
+	previous := suite.load("rsa-2024", config.JWTKeyConfigParams{ID: "rsa-2024", Algorithm: AlgorithmRS256, PrivateKeyFile: suite.rsaPrivateFile})
+	current := suite.load("ed-2025",
+		config.JWTKeyConfigParams{ID: "ed-2025", Algorithm: AlgorithmEdDSA, PrivateKeyFile: suite.edPrivateFile},
+		config.JWTKeyConfigParams{ID: "rsa-2025", Algorithm: AlgorithmRS256, PublicKeyFile: suite.rsaPublicFile},
+	)
+
+	tokenString, err := previous.Sign(testClaims())
+	suite.Require().NoError(err)
+
+	_, err = suite.parse(current, tokenString)
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), `unknown key id "rsa-2024"`)
+
+	// A token without kid doesn't fall back to the signing key
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, testClaims())
+	tokenString, err = token.SignedString(suite.edKey)
+	suite.Require().NoError(err)
+
+	_, err = suite.parse(current, tokenString)
+	assert.Error(suite.T(), err)
+}
+
+// Test the JWKS publishes every key, sorted by kid, with its public part only
+func (suite *KeyringTestSuite) TestJWKS() {
+	keyring := suite.load("rsa-2025",
+		config.JWTKeyConfigParams{ID: "rsa-2025", Algorithm: AlgorithmRS256, PrivateKeyFile: suite.rsaPrivateFile},
+		config.JWTKeyConfigParams{ID: "ed-2024", Algorithm: AlgorithmEdDSA, PublicKeyFile: suite.edPublicFile},
+	)
+
+	set := keyring.JWKS()
+
+	suite.Require().Len(set.Keys, 2)
+
+	ed := set.Keys[0]
+	assert.Equal(suite.T(), "ed-2024", ed.KeyID)
+	assert.Equal(suite.T(), "OKP", ed.KeyType)
+	assert.Equal(suite.T(), "Ed25519", ed.Curve)
+	assert.Equal(suite.T(), "EdDSA", ed.Algorithm)
+	assert.Equal(suite.T(), "sig", ed.Use)
+	assert.Equal(suite.T(), base64.RawURLEncoding.EncodeToString(suite.edKey.Public().(ed25519.PublicKey)), ed.X)
+
+	rsaJWK := set.Keys[1]
+	assert.Equal(suite.T(), "rsa-2025", rsaJWK.KeyID)
+	assert.Equal(suite.T(), "RSA", rsaJWK.KeyType)
+	assert.Equal(suite.T(), "RS256", rsaJWK.Algorithm)
+	assert.Equal(suite.T(), base64.RawURLEncoding.EncodeToString(suite.rsaKey.N.Bytes()), rsaJWK.N)
+	assert.Equal(suite.T(), base64.RawURLEncoding.EncodeToString(big.NewInt(int64(suite.rsaKey.E)).Bytes()), rsaJWK.E)
+}
+
+// Test the JWKS is empty in HS256 mode, the shared secret is never published
+func (suite *KeyringTestSuite) TestJWKS_HS256() {
+	keyring, err := Load(config.JWTConfigParams{Secret: "shared-secret"})
+	suite.Require().NoError(err)
+
+	assert.Empty(suite.T(), keyring.JWKS().Keys)
+	assert.Equal(suite.T(), []string{"HS256"}, keyring.ValidMethods())
+}
+
+// Test invalid key configurations are rejected on startup
+func (suite *KeyringTestSuite) TestLoad_InvalidConfiguration() {
+	rsaConfig := config.JWTKeyConfigParams{ID: "rsa-2025", Algorithm: AlgorithmRS256, PrivateKeyFile: suite.rsaPrivateFile}
+
+	smallKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	suite.Require().NoError(err)
+	smallKeyFile := suite.writePEM(suite.T().TempDir(), "small.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(smallKey))
+
+	cases := map[string]config.JWTConfigParams{
+		"no secret nor keys":       {},
+		"unknown signing key":      {SigningKeyID: "rsa-2026", Keys: []config.JWTKeyConfigParams{rsaConfig}},
+		"signing key public only":  {SigningKeyID: "rsa-2025", Keys: []config.JWTKeyConfigParams{{ID: "rsa-2025", Algorithm: AlgorithmRS256, PublicKeyFile: suite.rsaPublicFile}}},
+		"duplicate kid":            {SigningKeyID: "rsa-2025", Keys: []config.JWTKeyConfigParams{rsaConfig, rsaConfig}},
+		"RSA key too small":        {SigningKeyID: "rsa-small", Keys: []config.JWTKeyConfigParams{{ID: "rsa-small", Algorithm: AlgorithmRS256, PrivateKeyFile: smallKeyFile}}},
+		"unsupported algorithm":    {SigningKeyID: "rsa-2025", Keys: []config.JWTKeyConfigParams{{ID: "rsa-2025", Algorithm: "HS256", PrivateKeyFile: suite.rsaPrivateFile}}},
+		"algorithm of another key": {SigningKeyID: "ed-2025", Keys: []config.JWTKeyConfigParams{{ID: "ed-2025", Algorithm: AlgorithmEdDSA, PrivateKeyFile: suite.rsaPrivateFile}}},
+	}
+
+	for name, cfg := range cases {
+		_, err := Load(cfg)
+		assert.Error(suite.T(), err, name)
+	}
+}
+
+// Run the test suite
+func TestKeyringTestSuite(t *testing.T) {
+	suite.Run(t, new(KeyringTestSuite))
+}
@@ -0,0 +1,14 @@
+package common
+
+// StudyProgramScope restricts a request to the data of one study program. Users granted the
+// study_program:all permission get a global scope, everyone else is limited to the study program
+// linked to their account.
+type StudyProgramScope struct {
+	All            bool
+	StudyProgramID string // empty when the user isn't linked to any study program
+}
+
+// GlobalStudyProgramScope grants access to every study program.
+func GlobalStudyProgramScope() StudyProgramScope {
+	return StudyProgramScope{All: true}
+}
@@ -0,0 +1,93 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// Base32 of the ASCII secret "12345678901234567890" from the RFC 6238 test vectors
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// Test Suite
+type TOTPTestSuite struct {
+	suite.Suite
+}
+
+// Test codes match the RFC 6238 SHA1 test vectors, truncated to 6 digits
+func (suite *TOTPTestSuite) TestCode_RFCVectors() {
+	vectors := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+
+	for unix, expected := range vectors {
+		code, err := Code(rfcSecret, Step(time.Unix(unix, 0)))
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), expected, code, "unix time %d", unix)
+	}
+}
+
+// Test a code of the adjacent time steps is accepted and the matched step is returned
+func (suite *TOTPTestSuite) TestValidate_ClockDrift() {
+	now := time.Unix(1234567890, 0)
+	previous, _ := Code(rfcSecret, Step(now)-1)
+	next, _ := Code(rfcSecret, Step(now)+1)
+
+	step, ok, err := Validate(rfcSecret, previous, now)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), Step(now)-1, step)
+
+	step, ok, err = Validate(rfcSecret, next, now)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), Step(now)+1, step)
+}
+
+// Test codes outside the drift window and malformed codes are rejected
+func (suite *TOTPTestSuite) TestValidate_Rejected() {
+	now := time.Unix(1234567890, 0)
+	old, _ := Code(rfcSecret, Step(now)-2)
+
+	_, ok, err := Validate(rfcSecret, old, now)
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), ok)
+
+	_, ok, err = Validate(rfcSecret, "12345", now)
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), ok)
+}
+
+// Test generated secrets are valid base32 and produce codes
+func (suite *TOTPTestSuite) TestGenerateSecret() {
+	secret, err := GenerateSecret()
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), secret, 32)
+
+	code, err := Code(secret, Step(time.Now()))
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), code, Digits)
+}
+
+// Test the URI carries the secret and issuer in the format authenticator apps expect
+func (suite *TOTPTestSuite) TestKeyURI() {
+	uri := KeyURI("SIAKAD", "admin@example.com", rfcSecret)
+
+	assert.True(suite.T(), strings.HasPrefix(uri, "otpauth://totp/SIAKAD:admin@example.com?"))
+	assert.Contains(suite.T(), uri, "secret="+rfcSecret)
+	assert.Contains(suite.T(), uri, "issuer=SIAKAD")
+	assert.Contains(suite.T(), uri, "digits=6")
+	assert.Contains(suite.T(), uri, "period=30")
+}
+
+// Test runner
+func TestTOTPTestSuite(t *testing.T) {
+	suite.Run(t, new(TOTPTestSuite))
+}
@@ -68,6 +68,29 @@ func (tc *TxContext) Tx() pgx.Tx {
 // TxContextFunc represents a function that can be executed within a transaction context
 type TxContextFunc func(txCtx *TxContext) error
 
+// WithSavepoint executes a function within a savepoint of the transaction.
+// If the function returns an error, only its changes are rolled back and the transaction carries on.
+func (tc *TxContext) WithSavepoint(fn TxContextFunc) error {
+	savepoint, err := tc.tx.Begin(tc.ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to create savepoint")
+	}
+
+	err = fn(NewTxContext(tc.ctx, savepoint))
+	if err != nil {
+		if rbErr := savepoint.Rollback(tc.ctx); rbErr != nil {
+			return errors.Wrap(rbErr, "failed to roll back to savepoint")
+		}
+		return err
+	}
+
+	if err = savepoint.Commit(tc.ctx); err != nil {
+		return errors.Wrap(err, "failed to release savepoint")
+	}
+
+	return nil
+}
+
 // withTxContext executes a function within a transaction, providing a TxContext
 // that can be shared across multiple repositories.
 func withTxContext(ctx context.Context, pool *pgxpool.Pool, fn TxContextFunc) error {
@@ -39,8 +39,14 @@ func formatValidationError(fe validator.FieldError) string {
 		return fmt.Sprintf("%s must be at least %s characters long", field, fe.Param())
 	case "max":
 		return fmt.Sprintf("%s must be at most %s characters long", field, fe.Param())
+	case "gte":
+		return fmt.Sprintf("%s must be at least %s", field, fe.Param())
+	case "lte":
+		return fmt.Sprintf("%s must be at most %s", field, fe.Param())
 	case "eqfield":
 		return fmt.Sprintf("%s must match %s", field, strings.ToLower(fe.Param()))
+	case "gtfield":
+		return fmt.Sprintf("%s must be after %s", field, strings.ToLower(fe.Param()))
 	default:
 		return fmt.Sprintf("%s is invalid", field)
 	}
@@ -4,9 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
+	_ "time/tzdata" // the campus time zone must resolve on hosts without a zoneinfo database
 
 	"github.com/pkg/errors"
-	"github.com/rs/zerolog/log"
 )
 
 var CurrentConfig Config
@@ -31,8 +32,235 @@ func (c DatabaseConfigParams) DSN() string {
 	)
 }
 
+// JWTKeyConfigParams describes one asymmetric key pair in PEM files. Keys without a private key
+// can only verify tokens, which is how retired keys are kept around during a rotation.
+type JWTKeyConfigParams struct {
+	ID             string `json:"kid"`
+	Algorithm      string `json:"algorithm"` // RS256 or EdDSA
+	PrivateKeyFile string `json:"private_key_file"`
+	PublicKeyFile  string `json:"public_key_file"`
+}
+
 type JWTConfigParams struct {
-	Secret string `json:"secret"`
+	// Secret is only used for HS256 signing, when no asymmetric keys are configured
+	Secret                string `json:"secret"`
+	AccessTokenTTLMinutes int    `json:"access_token_ttl_minutes"`
+	RefreshTokenTTLHours  int    `json:"refresh_token_ttl_hours"`
+
+	RevocationCacheTTLSeconds int `json:"revocation_cache_ttl_seconds"`
+
+	SigningKeyID string               `json:"signing_key_id"`
+	Keys         []JWTKeyConfigParams `json:"keys"`
+}
+
+// AccessTokenTTL returns the lifetime of issued access tokens, defaulting to 15 minutes.
+func (c JWTConfigParams) AccessTokenTTL() time.Duration {
+	if c.AccessTokenTTLMinutes <= 0 {
+		return 15 * time.Minute
+	}
+	return time.Duration(c.AccessTokenTTLMinutes) * time.Minute
+}
+
+// RefreshTokenTTL returns the lifetime of issued refresh tokens, defaulting to 30 days.
+func (c JWTConfigParams) RefreshTokenTTL() time.Duration {
+	if c.RefreshTokenTTLHours <= 0 {
+		return 30 * 24 * time.Hour
+	}
+	return time.Duration(c.RefreshTokenTTLHours) * time.Hour
+}
+
+// RevocationCacheTTL returns how long token revocation lookups are cached in-process, defaulting to 30 seconds.
+func (c JWTConfigParams) RevocationCacheTTL() time.Duration {
+	if c.RevocationCacheTTLSeconds <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(c.RevocationCacheTTLSeconds) * time.Second
+}
+
+type AuthConfigParams struct {
+	PasswordResetTokenTTLMinutes int                       `json:"password_reset_token_ttl_minutes"`
+	LoginThrottle                LoginThrottleConfigParams `json:"login_throttle"`
+	MFA                          MFAConfigParams           `json:"mfa"`
+	PermissionCacheTTLSeconds    int                       `json:"permission_cache_ttl_seconds"`
+}
+
+// PasswordResetTokenTTL returns how long an admin-issued password reset token stays valid, defaulting to 1 hour.
+func (c AuthConfigParams) PasswordResetTokenTTL() time.Duration {
+	if c.PasswordResetTokenTTLMinutes <= 0 {
+		return time.Hour
+	}
+	return time.Duration(c.PasswordResetTokenTTLMinutes) * time.Minute
+}
+
+// PermissionCacheTTL returns how long the role permissions are cached in-process, defaulting to 30 seconds.
+func (c AuthConfigParams) PermissionCacheTTL() time.Duration {
+	if c.PermissionCacheTTLSeconds <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(c.PermissionCacheTTLSeconds) * time.Second
+}
+
+// LoginThrottleConfigParams holds the brute-force protection thresholds of the login endpoint.
+// Zero values fall back to the defaults of the accessor methods.
+type LoginThrottleConfigParams struct {
+	MaxFailedAttemptsPerAccount int `json:"max_failed_attempts_per_account"`
+	MaxFailedAttemptsPerIP      int `json:"max_failed_attempts_per_ip"`
+	FailureWindowMinutes        int `json:"failure_window_minutes"`
+	LockoutMinutes              int `json:"lockout_minutes"`
+	BaseDelaySeconds            int `json:"base_delay_seconds"`
+	MaxDelaySeconds             int `json:"max_delay_seconds"`
+}
+
+// MaxAccountFailures returns how many consecutive failures lock an account, defaulting to 5.
+func (c LoginThrottleConfigParams) MaxAccountFailures() int {
+	if c.MaxFailedAttemptsPerAccount <= 0 {
+		return 5
+	}
+	return c.MaxFailedAttemptsPerAccount
+}
+
+// MaxIPFailures returns how many failures lock a client IP, defaulting to 50. It is kept well above the
+// per-account threshold since many users can share one IP behind a campus NAT.
+func (c LoginThrottleConfigParams) MaxIPFailures() int {
+	if c.MaxFailedAttemptsPerIP <= 0 {
+		return 50
+	}
+	return c.MaxFailedAttemptsPerIP
+}
+
+// FailureWindow returns how long a failure is remembered, defaulting to 15 minutes.
+func (c LoginThrottleConfigParams) FailureWindow() time.Duration {
+	if c.FailureWindowMinutes <= 0 {
+		return 15 * time.Minute
+	}
+	return time.Duration(c.FailureWindowMinutes) * time.Minute
+}
+
+// LockoutDuration returns how long a lockout lasts, defaulting to 15 minutes.
+func (c LoginThrottleConfigParams) LockoutDuration() time.Duration {
+	if c.LockoutMinutes <= 0 {
+		return 15 * time.Minute
+	}
+	return time.Duration(c.LockoutMinutes) * time.Minute
+}
+
+// BaseDelay returns the wait imposed after the first failure of an account, doubled on every further
+// failure, defaulting to 1 second.
+func (c LoginThrottleConfigParams) BaseDelay() time.Duration {
+	if c.BaseDelaySeconds <= 0 {
+		return time.Second
+	}
+	return time.Duration(c.BaseDelaySeconds) * time.Second
+}
+
+// MaxDelay caps the progressive delay, defaulting to 30 seconds.
+func (c LoginThrottleConfigParams) MaxDelay() time.Duration {
+	if c.MaxDelaySeconds <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(c.MaxDelaySeconds) * time.Second
+}
+
+type MFAConfigParams struct {
+	// RequiredForPrivilegedRoles makes TOTP mandatory for admins and koorprodi, they have to enroll on their next login
+	RequiredForPrivilegedRoles bool   `json:"required_for_privileged_roles"`
+	Issuer                     string `json:"issuer"`
+	PendingTokenTTLMinutes     int    `json:"pending_token_ttl_minutes"`
+}
+
+// IssuerName returns the issuer shown by authenticator apps, defaulting to "SIAKAD".
+func (c MFAConfigParams) IssuerName() string {
+	if c.Issuer == "" {
+		return "SIAKAD"
+	}
+	return c.Issuer
+}
+
+// PendingTokenTTL returns how long the second login step can be completed after the password check,
+// defaulting to 5 minutes.
+func (c MFAConfigParams) PendingTokenTTL() time.Duration {
+	if c.PendingTokenTTLMinutes <= 0 {
+		return 5 * time.Minute
+	}
+	return time.Duration(c.PendingTokenTTLMinutes) * time.Minute
+}
+
+// CreditLoadRule lets students whose previous semester GPA (IP) is at least MinGPA take up to MaxCredits
+type CreditLoadRule struct {
+	MinGPA     float64 `json:"min_gpa"`
+	MaxCredits int     `json:"max_credits"`
+}
+
+type AcademicConfigParams struct {
+	// PrerequisiteMinGrade is the lowest letter grade that fulfills a prerequisite
+	PrerequisiteMinGrade string `json:"prerequisite_min_grade"`
+	// MaxTeachingCredits is the most credits (SKS) a lecturer can teach in a semester
+	MaxTeachingCredits int `json:"max_teaching_credits"`
+	// CreditLoad maps the previous semester GPA of a student to the most credits they can take in a semester
+	CreditLoad []CreditLoadRule `json:"credit_load"`
+	// MaxCreditsWithoutGPA is the most credits of students without a previous semester GPA, e.g. new students
+	MaxCreditsWithoutGPA int `json:"max_credits_without_gpa"`
+	// DropPeriodDays is how many days after the start of a semester students can drop registrations, for semesters
+	// without their own drop deadline
+	DropPeriodDays int `json:"drop_period_days"`
+	// Timezone is the IANA time zone of the campus, the days and times of day of weekly schedules are on its clock
+	Timezone string `json:"timezone"`
+}
+
+// PrerequisiteMinimumGrade returns the lowest letter grade that fulfills a prerequisite, defaulting to "C".
+func (c AcademicConfigParams) PrerequisiteMinimumGrade() string {
+	if c.PrerequisiteMinGrade == "" {
+		return "C"
+	}
+	return c.PrerequisiteMinGrade
+}
+
+// MaxTeachingCreditsPerSemester returns the most credits a lecturer can teach in a semester, defaulting to 16.
+func (c AcademicConfigParams) MaxTeachingCreditsPerSemester() int {
+	if c.MaxTeachingCredits <= 0 {
+		return 16
+	}
+	return c.MaxTeachingCredits
+}
+
+// CreditLoadRules returns the GPA to credit load table, defaulting to 24 credits from a GPA of 3.00, 21 from 2.50,
+// 18 from 2.00 and 15 below.
+func (c AcademicConfigParams) CreditLoadRules() []CreditLoadRule {
+	if len(c.CreditLoad) == 0 {
+		return []CreditLoadRule{
+			{MinGPA: 3.00, MaxCredits: 24},
+			{MinGPA: 2.50, MaxCredits: 21},
+			{MinGPA: 2.00, MaxCredits: 18},
+			{MinGPA: 0, MaxCredits: 15},
+		}
+	}
+	return c.CreditLoad
+}
+
+// MaxCreditsWithoutPreviousGPA returns the most credits of students without a previous semester GPA,
+// defaulting to 20.
+func (c AcademicConfigParams) MaxCreditsWithoutPreviousGPA() int {
+	if c.MaxCreditsWithoutGPA <= 0 {
+		return 20
+	}
+	return c.MaxCreditsWithoutGPA
+}
+
+// DropPeriod returns how many days after the start of a semester without a drop deadline students can drop
+// registrations, defaulting to 14.
+func (c AcademicConfigParams) DropPeriod() int {
+	if c.DropPeriodDays <= 0 {
+		return 14
+	}
+	return c.DropPeriodDays
+}
+
+// CampusLocation returns the time zone of the campus, defaulting to Asia/Jakarta (WIB).
+func (c AcademicConfigParams) CampusLocation() (*time.Location, error) {
+	if c.Timezone == "" {
+		return time.LoadLocation("Asia/Jakarta")
+	}
+	return time.LoadLocation(c.Timezone)
 }
 
 type AppConfigParams struct {
@@ -42,16 +270,13 @@ type AppConfigParams struct {
 type Config struct {
 	Database DatabaseConfigParams `json:"database"`
 	JWT      JWTConfigParams      `json:"jwt"`
+	Auth     AuthConfigParams     `json:"auth"`
+	Academic AcademicConfigParams `json:"academic"`
 	App      AppConfigParams      `json:"app"`
 }
 
-func init() {
-	err := LoadConfig()
-	if err != nil {
-		log.Fatal().Err(err).Msg("cannot load config")
-	}
-}
-
+// LoadConfig reads ./config.json into CurrentConfig. It is called by the binary on startup, tests run with the
+// zero config whose accessors fall back to the defaults.
 func LoadConfig() error {
 	file, err := os.ReadFile("./config.json")
 	if err != nil {
@@ -0,0 +1,28 @@
+package constants
+
+// Permissions checked by the routes, the role mapping lives in the role_permissions table
+const (
+	PermissionAcademicCalendarManage = "academic_calendar:manage"
+	PermissionCourseRead             = "course:read"
+	PermissionCourseWrite            = "course:write"
+	PermissionCourseCompletionImport = "course_completion:import"
+	PermissionCourseOfferingRead     = "course_offering:read"
+	PermissionCourseOfferingWrite    = "course_offering:write"
+	PermissionCurriculumManage       = "curriculum:manage"
+	PermissionEnrollmentCreate       = "enrollment:create"
+	PermissionEnrollmentWithdraw     = "enrollment:withdraw"
+	PermissionLecturerManage         = "lecturer:manage"
+	PermissionMFAReset               = "mfa:reset"
+	PermissionPasswordResetIssue     = "password_reset:issue"
+	PermissionRoleManage             = "role:manage"
+	PermissionRoomManage             = "room:manage"
+	PermissionSemesterGPAImport      = "semester_gpa:import"
+	PermissionSessionRevoke          = "session:revoke"
+	PermissionStudentImport          = "student:import"
+	PermissionStudentManage          = "student:manage"
+	PermissionStudyPlanReview        = "study_plan:review"
+	PermissionStudyPlanSubmit        = "study_plan:submit"
+	PermissionStudyProgramAll        = "study_program:all"
+	PermissionStudyProgramManage     = "study_program:manage"
+	PermissionUserManage             = "user:manage"
+)
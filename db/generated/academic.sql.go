@@ -14,7 +14,7 @@ import (
 const checkEnrollmentExists = `-- name: CheckEnrollmentExists :one
 select exists(
     select 1 from course_registrations 
-    where student_id = $1 and course_offering_id = $2
+    where student_id = $1 and course_offering_id = $2 and deleted_at IS NULL
 )
 `
 
@@ -31,7 +31,7 @@ func (q *Queries) CheckEnrollmentExists(ctx context.Context, arg CheckEnrollment
 }
 
 const countCourseOfferingEnrollments = `-- name: CountCourseOfferingEnrollments :one
-select count(*) from course_registrations where course_offering_id = $1
+select count(*) from course_registrations where course_offering_id = $1 and status <> 'rejected' and deleted_at IS NULL
 `
 
 func (q *Queries) CountCourseOfferingEnrollments(ctx context.Context, courseOfferingID pgtype.UUID) (int64, error) {
@@ -54,10 +54,28 @@ func (q *Queries) CountCourseOfferings(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const countCourseOfferingsByStudyProgram = `-- name: CountCourseOfferingsByStudyProgram :one
+select count(*) 
+from course_offerings co
+where co.deleted_at IS NULL
+  and exists(
+      select 1 from curriculum_courses cc
+      join curricula cu on cc.curriculum_id = cu.id
+      where cc.course_id = co.course_id and cu.study_program_id = $1 and cu.deleted_at IS NULL
+  )
+`
+
+func (q *Queries) CountCourseOfferingsByStudyProgram(ctx context.Context, studyProgramID pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countCourseOfferingsByStudyProgram, studyProgramID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createCourseOffering = `-- name: CreateCourseOffering :one
-insert into course_offerings (id, semester_id, course_id, section_code, capacity, start_time, created_at, updated_at)
-values (gen_random_uuid(), $1, $2, $3, $4, $5, now(), now())
-returning id, semester_id, course_id, section_code, capacity, start_time, created_at, updated_at, deleted_at
+insert into course_offerings (id, semester_id, course_id, section_code, capacity, start_time, room_id, created_at, updated_at)
+values (gen_random_uuid(), $1, $2, $3, $4, $5, $6, now(), now())
+returning id, semester_id, course_id, section_code, capacity, start_time, created_at, updated_at, deleted_at, room_id
 `
 
 type CreateCourseOfferingParams struct {
@@ -66,6 +84,7 @@ type CreateCourseOfferingParams struct {
 	SectionCode string
 	Capacity    int32
 	StartTime   pgtype.Timestamptz
+	RoomID      pgtype.UUID
 }
 
 func (q *Queries) CreateCourseOffering(ctx context.Context, arg CreateCourseOfferingParams) (CourseOffering, error) {
@@ -75,6 +94,7 @@ func (q *Queries) CreateCourseOffering(ctx context.Context, arg CreateCourseOffe
 		arg.SectionCode,
 		arg.Capacity,
 		arg.StartTime,
+		arg.RoomID,
 	)
 	var i CourseOffering
 	err := row.Scan(
@@ -87,23 +107,30 @@ func (q *Queries) CreateCourseOffering(ctx context.Context, arg CreateCourseOffe
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.RoomID,
 	)
 	return i, err
 }
 
 const createEnrollment = `-- name: CreateEnrollment :one
-insert into course_registrations (id, student_id, course_offering_id, created_at, updated_at)
-values (gen_random_uuid(), $1, $2, now(), now())
-returning id, student_id, course_offering_id, created_at, updated_at, deleted_at
+insert into course_registrations (id, student_id, course_offering_id, study_plan_id, status, created_at, updated_at)
+values (gen_random_uuid(), $1, $2, $3, 'pending', now(), now())
+returning id, student_id, course_offering_id, created_at, updated_at, deleted_at, study_plan_id, status, dropped_by, drop_reason
 `
 
 type CreateEnrollmentParams struct {
 	StudentID        pgtype.UUID
 	CourseOfferingID pgtype.UUID
+	StudyPlanID      pgtype.UUID
 }
 
+// New registrations are pending lines of the study plan until the academic advisor reviews them
 func (q *Queries) CreateEnrollment(ctx context.Context, arg CreateEnrollmentParams) (CourseRegistration, error) {
-	row := q.db.QueryRow(ctx, createEnrollment, arg.StudentID, arg.CourseOfferingID)
+	row := q.db.QueryRow(ctx, createEnrollment,
+		arg.StudentID,
+		arg.CourseOfferingID,
+		arg.StudyPlanID,
+	)
 	var i CourseRegistration
 	err := row.Scan(
 		&i.ID,
@@ -112,6 +139,10 @@ func (q *Queries) CreateEnrollment(ctx context.Context, arg CreateEnrollmentPara
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.StudyPlanID,
+		&i.Status,
+		&i.DroppedBy,
+		&i.DropReason,
 	)
 	return i, err
 }
@@ -120,7 +151,7 @@ const deleteCourseOffering = `-- name: DeleteCourseOffering :one
 update course_offerings 
 set deleted_at = now(), updated_at = now()
 where id = $1 and deleted_at IS NULL
-returning id, semester_id, course_id, section_code, capacity, start_time, created_at, updated_at, deleted_at
+returning id, semester_id, course_id, section_code, capacity, start_time, created_at, updated_at, deleted_at, room_id
 `
 
 func (q *Queries) DeleteCourseOffering(ctx context.Context, id pgtype.UUID) (CourseOffering, error) {
@@ -136,12 +167,49 @@ func (q *Queries) DeleteCourseOffering(ctx context.Context, id pgtype.UUID) (Cou
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.RoomID,
+	)
+	return i, err
+}
+
+const dropEnrollment = `-- name: DropEnrollment :one
+update course_registrations
+set deleted_at = now(), updated_at = now(), dropped_by = $2, drop_reason = $3
+where id = $1 and deleted_at IS NULL
+returning id, student_id, course_offering_id, created_at, updated_at, deleted_at, study_plan_id, status, dropped_by, drop_reason
+`
+
+type DropEnrollmentParams struct {
+	ID         pgtype.UUID
+	DroppedBy  pgtype.UUID
+	DropReason pgtype.Text
+}
+
+// Dropped registrations are soft deleted, they free their seat and can be registered again
+func (q *Queries) DropEnrollment(ctx context.Context, arg DropEnrollmentParams) (CourseRegistration, error) {
+	row := q.db.QueryRow(ctx, dropEnrollment,
+		arg.ID,
+		arg.DroppedBy,
+		arg.DropReason,
+	)
+	var i CourseRegistration
+	err := row.Scan(
+		&i.ID,
+		&i.StudentID,
+		&i.CourseOfferingID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.StudyPlanID,
+		&i.Status,
+		&i.DroppedBy,
+		&i.DropReason,
 	)
 	return i, err
 }
 
 const getCourse = `-- name: GetCourse :one
-select id, code, name, credit, created_at, updated_at, deleted_at from courses where id = $1
+select id, code, name, credit, created_at, updated_at, deleted_at, minutes_per_credit from courses where id = $1
 `
 
 func (q *Queries) GetCourse(ctx context.Context, id pgtype.UUID) (Course, error) {
@@ -155,12 +223,13 @@ func (q *Queries) GetCourse(ctx context.Context, id pgtype.UUID) (Course, error)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.MinutesPerCredit,
 	)
 	return i, err
 }
 
 const getCourseOffering = `-- name: GetCourseOffering :one
-select id, semester_id, course_id, section_code, capacity, start_time, created_at, updated_at, deleted_at from course_offerings where id = $1
+select id, semester_id, course_id, section_code, capacity, start_time, created_at, updated_at, deleted_at, room_id from course_offerings where id = $1
 `
 
 func (q *Queries) GetCourseOffering(ctx context.Context, id pgtype.UUID) (CourseOffering, error) {
@@ -176,6 +245,7 @@ func (q *Queries) GetCourseOffering(ctx context.Context, id pgtype.UUID) (Course
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.RoomID,
 	)
 	return i, err
 }
@@ -188,6 +258,7 @@ select
     co.section_code,
     co.capacity,
     co.start_time as course_offering_start_time,
+    co.room_id,
     co.created_at as course_offering_created_at,
     co.updated_at as course_offering_updated_at,
     co.deleted_at as course_offering_deleted_at,
@@ -195,6 +266,7 @@ select
     c.code as course_code,
     c.name as course_name,
     c.credit,
+    c.minutes_per_credit,
     c.created_at as course_created_at,
     c.updated_at as course_updated_at,
     c.deleted_at as course_deleted_at
@@ -210,6 +282,7 @@ type GetCourseOfferingByIDWithDetailsRow struct {
 	SectionCode             string
 	Capacity                int32
 	CourseOfferingStartTime pgtype.Timestamptz
+	RoomID                  pgtype.UUID
 	CourseOfferingCreatedAt pgtype.Timestamptz
 	CourseOfferingUpdatedAt pgtype.Timestamptz
 	CourseOfferingDeletedAt pgtype.Timestamptz
@@ -217,6 +290,7 @@ type GetCourseOfferingByIDWithDetailsRow struct {
 	CourseCode              string
 	CourseName              string
 	Credit                  int32
+	MinutesPerCredit        int32
 	CourseCreatedAt         pgtype.Timestamptz
 	CourseUpdatedAt         pgtype.Timestamptz
 	CourseDeletedAt         pgtype.Timestamptz
@@ -232,6 +306,7 @@ func (q *Queries) GetCourseOfferingByIDWithDetails(ctx context.Context, id pgtyp
 		&i.SectionCode,
 		&i.Capacity,
 		&i.CourseOfferingStartTime,
+		&i.RoomID,
 		&i.CourseOfferingCreatedAt,
 		&i.CourseOfferingUpdatedAt,
 		&i.CourseOfferingDeletedAt,
@@ -239,6 +314,7 @@ func (q *Queries) GetCourseOfferingByIDWithDetails(ctx context.Context, id pgtyp
 		&i.CourseCode,
 		&i.CourseName,
 		&i.Credit,
+		&i.MinutesPerCredit,
 		&i.CourseCreatedAt,
 		&i.CourseUpdatedAt,
 		&i.CourseDeletedAt,
@@ -254,6 +330,7 @@ select
     co.section_code,
     co.capacity,
     co.start_time as course_offering_start_time,
+    co.room_id,
     co.created_at as course_offering_created_at,
     co.updated_at as course_offering_updated_at,
     co.deleted_at as course_offering_deleted_at,
@@ -261,11 +338,16 @@ select
     c.code as course_code,
     c.name as course_name,
     c.credit,
+    c.minutes_per_credit,
     c.created_at as course_created_at,
     c.updated_at as course_updated_at,
-    c.deleted_at as course_deleted_at
+    c.deleted_at as course_deleted_at,
+    s.start_time as semester_start_time,
+    s.end_time as semester_end_time,
+    s.drop_deadline as semester_drop_deadline
 from course_offerings co
 join courses c on co.course_id = c.id
+join semesters s on co.semester_id = s.id
 where co.id = $1
 `
 
@@ -276,6 +358,7 @@ type GetCourseOfferingWithCourseRow struct {
 	SectionCode             string
 	Capacity                int32
 	CourseOfferingStartTime pgtype.Timestamptz
+	RoomID                  pgtype.UUID
 	CourseOfferingCreatedAt pgtype.Timestamptz
 	CourseOfferingUpdatedAt pgtype.Timestamptz
 	CourseOfferingDeletedAt pgtype.Timestamptz
@@ -283,9 +366,13 @@ type GetCourseOfferingWithCourseRow struct {
 	CourseCode              string
 	CourseName              string
 	Credit                  int32
+	MinutesPerCredit        int32
 	CourseCreatedAt         pgtype.Timestamptz
 	CourseUpdatedAt         pgtype.Timestamptz
 	CourseDeletedAt         pgtype.Timestamptz
+	SemesterStartTime       pgtype.Timestamptz
+	SemesterEndTime         pgtype.Timestamptz
+	SemesterDropDeadline    pgtype.Timestamptz
 }
 
 func (q *Queries) GetCourseOfferingWithCourse(ctx context.Context, id pgtype.UUID) (GetCourseOfferingWithCourseRow, error) {
@@ -298,6 +385,7 @@ func (q *Queries) GetCourseOfferingWithCourse(ctx context.Context, id pgtype.UUI
 		&i.SectionCode,
 		&i.Capacity,
 		&i.CourseOfferingStartTime,
+		&i.RoomID,
 		&i.CourseOfferingCreatedAt,
 		&i.CourseOfferingUpdatedAt,
 		&i.CourseOfferingDeletedAt,
@@ -305,13 +393,271 @@ func (q *Queries) GetCourseOfferingWithCourse(ctx context.Context, id pgtype.UUI
 		&i.CourseCode,
 		&i.CourseName,
 		&i.Credit,
+		&i.MinutesPerCredit,
 		&i.CourseCreatedAt,
 		&i.CourseUpdatedAt,
 		&i.CourseDeletedAt,
+		&i.SemesterStartTime,
+		&i.SemesterEndTime,
+		&i.SemesterDropDeadline,
 	)
 	return i, err
 }
 
+const getCourseOfferingsByLecturer = `-- name: GetCourseOfferingsByLecturer :many
+select 
+    co.id as course_offering_id,
+    co.semester_id,
+    co.course_id,
+    co.section_code,
+    co.capacity,
+    co.start_time as course_offering_start_time,
+    co.room_id,
+    c.code as course_code,
+    c.name as course_name,
+    c.credit,
+    c.minutes_per_credit,
+    s.start_time as semester_start_time,
+    s.end_time as semester_end_time
+from course_offering_lecturers col
+join course_offerings co on col.course_offering_id = co.id
+join courses c on co.course_id = c.id
+join semesters s on co.semester_id = s.id
+where col.lecturer_id = $1 and co.semester_id = $2 and co.deleted_at IS NULL
+order by co.start_time, c.code, co.section_code
+`
+
+type GetCourseOfferingsByLecturerParams struct {
+	LecturerID pgtype.UUID
+	SemesterID pgtype.UUID
+}
+
+type GetCourseOfferingsByLecturerRow struct {
+	CourseOfferingID        pgtype.UUID
+	SemesterID              pgtype.UUID
+	CourseID                pgtype.UUID
+	SectionCode             string
+	Capacity                int32
+	CourseOfferingStartTime pgtype.Timestamptz
+	RoomID                  pgtype.UUID
+	CourseCode              string
+	CourseName              string
+	Credit                  int32
+	MinutesPerCredit        int32
+	SemesterStartTime       pgtype.Timestamptz
+	SemesterEndTime         pgtype.Timestamptz
+}
+
+// Offerings of the semester taught by the lecturer with their semester bounds, to detect clashes and sum the
+// teaching load
+func (q *Queries) GetCourseOfferingsByLecturer(ctx context.Context, arg GetCourseOfferingsByLecturerParams) ([]GetCourseOfferingsByLecturerRow, error) {
+	rows, err := q.db.Query(ctx, getCourseOfferingsByLecturer,
+		arg.LecturerID,
+		arg.SemesterID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCourseOfferingsByLecturerRow
+	for rows.Next() {
+		var i GetCourseOfferingsByLecturerRow
+		if err := rows.Scan(
+			&i.CourseOfferingID,
+			&i.SemesterID,
+			&i.CourseID,
+			&i.SectionCode,
+			&i.Capacity,
+			&i.CourseOfferingStartTime,
+			&i.RoomID,
+			&i.CourseCode,
+			&i.CourseName,
+			&i.Credit,
+			&i.MinutesPerCredit,
+			&i.SemesterStartTime,
+			&i.SemesterEndTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCourseOfferingsByRoom = `-- name: GetCourseOfferingsByRoom :many
+select 
+    co.id as course_offering_id,
+    co.semester_id,
+    co.course_id,
+    co.section_code,
+    co.capacity,
+    co.start_time as course_offering_start_time,
+    co.room_id,
+    c.code as course_code,
+    c.name as course_name,
+    c.credit,
+    c.minutes_per_credit,
+    s.start_time as semester_start_time,
+    s.end_time as semester_end_time
+from course_offerings co
+join courses c on co.course_id = c.id
+join semesters s on co.semester_id = s.id
+where co.room_id = $1 and co.deleted_at IS NULL
+`
+
+type GetCourseOfferingsByRoomRow struct {
+	CourseOfferingID        pgtype.UUID
+	SemesterID              pgtype.UUID
+	CourseID                pgtype.UUID
+	SectionCode             string
+	Capacity                int32
+	CourseOfferingStartTime pgtype.Timestamptz
+	RoomID                  pgtype.UUID
+	CourseCode              string
+	CourseName              string
+	Credit                  int32
+	MinutesPerCredit        int32
+	SemesterStartTime       pgtype.Timestamptz
+	SemesterEndTime         pgtype.Timestamptz
+}
+
+// Offerings held in the room with their semester bounds, to detect double bookings. Lock the room with
+// GetRoomForUpdate first, the read alone doesn't stop a concurrent offering from taking the same slot
+func (q *Queries) GetCourseOfferingsByRoom(ctx context.Context, roomID pgtype.UUID) ([]GetCourseOfferingsByRoomRow, error) {
+	rows, err := q.db.Query(ctx, getCourseOfferingsByRoom, roomID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCourseOfferingsByRoomRow
+	for rows.Next() {
+		var i GetCourseOfferingsByRoomRow
+		if err := rows.Scan(
+			&i.CourseOfferingID,
+			&i.SemesterID,
+			&i.CourseID,
+			&i.SectionCode,
+			&i.Capacity,
+			&i.CourseOfferingStartTime,
+			&i.RoomID,
+			&i.CourseCode,
+			&i.CourseName,
+			&i.Credit,
+			&i.MinutesPerCredit,
+			&i.SemesterStartTime,
+			&i.SemesterEndTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCourseOfferingsByStudyProgramWithPagination = `-- name: GetCourseOfferingsByStudyProgramWithPagination :many
+select 
+    co.id as course_offering_id,
+    co.semester_id,
+    co.course_id,
+    co.section_code,
+    co.capacity,
+    co.start_time as course_offering_start_time,
+    co.room_id,
+    co.created_at as course_offering_created_at,
+    co.updated_at as course_offering_updated_at,
+    co.deleted_at as course_offering_deleted_at,
+    c.id as course_id,
+    c.code as course_code,
+    c.name as course_name,
+    c.credit,
+    c.minutes_per_credit,
+    c.created_at as course_created_at,
+    c.updated_at as course_updated_at,
+    c.deleted_at as course_deleted_at
+from course_offerings co
+join courses c on co.course_id = c.id
+where co.deleted_at IS NULL
+  and exists(
+      select 1 from curriculum_courses cc
+      join curricula cu on cc.curriculum_id = cu.id
+      where cc.course_id = co.course_id and cu.study_program_id = $1 and cu.deleted_at IS NULL
+  )
+order by co.created_at desc
+limit $2 offset $3
+`
+
+type GetCourseOfferingsByStudyProgramWithPaginationParams struct {
+	StudyProgramID pgtype.UUID
+	Limit          int32
+	Offset         int32
+}
+
+type GetCourseOfferingsByStudyProgramWithPaginationRow struct {
+	CourseOfferingID        pgtype.UUID
+	SemesterID              pgtype.UUID
+	CourseID                pgtype.UUID
+	SectionCode             string
+	Capacity                int32
+	CourseOfferingStartTime pgtype.Timestamptz
+	RoomID                  pgtype.UUID
+	CourseOfferingCreatedAt pgtype.Timestamptz
+	CourseOfferingUpdatedAt pgtype.Timestamptz
+	CourseOfferingDeletedAt pgtype.Timestamptz
+	CourseID_2              pgtype.UUID
+	CourseCode              string
+	CourseName              string
+	Credit                  int32
+	MinutesPerCredit        int32
+	CourseCreatedAt         pgtype.Timestamptz
+	CourseUpdatedAt         pgtype.Timestamptz
+	CourseDeletedAt         pgtype.Timestamptz
+}
+
+func (q *Queries) GetCourseOfferingsByStudyProgramWithPagination(ctx context.Context, arg GetCourseOfferingsByStudyProgramWithPaginationParams) ([]GetCourseOfferingsByStudyProgramWithPaginationRow, error) {
+	rows, err := q.db.Query(ctx, getCourseOfferingsByStudyProgramWithPagination, arg.StudyProgramID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCourseOfferingsByStudyProgramWithPaginationRow
+	for rows.Next() {
+		var i GetCourseOfferingsByStudyProgramWithPaginationRow
+		if err := rows.Scan(
+			&i.CourseOfferingID,
+			&i.SemesterID,
+			&i.CourseID,
+			&i.SectionCode,
+			&i.Capacity,
+			&i.CourseOfferingStartTime,
+			&i.RoomID,
+			&i.CourseOfferingCreatedAt,
+			&i.CourseOfferingUpdatedAt,
+			&i.CourseOfferingDeletedAt,
+			&i.CourseID_2,
+			&i.CourseCode,
+			&i.CourseName,
+			&i.Credit,
+			&i.MinutesPerCredit,
+			&i.CourseCreatedAt,
+			&i.CourseUpdatedAt,
+			&i.CourseDeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getCourseOfferingsWithPagination = `-- name: GetCourseOfferingsWithPagination :many
 select 
     co.id as course_offering_id,
@@ -320,6 +666,7 @@ select
     co.section_code,
     co.capacity,
     co.start_time as course_offering_start_time,
+    co.room_id,
     co.created_at as course_offering_created_at,
     co.updated_at as course_offering_updated_at,
     co.deleted_at as course_offering_deleted_at,
@@ -327,6 +674,7 @@ select
     c.code as course_code,
     c.name as course_name,
     c.credit,
+    c.minutes_per_credit,
     c.created_at as course_created_at,
     c.updated_at as course_updated_at,
     c.deleted_at as course_deleted_at
@@ -349,6 +697,7 @@ type GetCourseOfferingsWithPaginationRow struct {
 	SectionCode             string
 	Capacity                int32
 	CourseOfferingStartTime pgtype.Timestamptz
+	RoomID                  pgtype.UUID
 	CourseOfferingCreatedAt pgtype.Timestamptz
 	CourseOfferingUpdatedAt pgtype.Timestamptz
 	CourseOfferingDeletedAt pgtype.Timestamptz
@@ -356,6 +705,7 @@ type GetCourseOfferingsWithPaginationRow struct {
 	CourseCode              string
 	CourseName              string
 	Credit                  int32
+	MinutesPerCredit        int32
 	CourseCreatedAt         pgtype.Timestamptz
 	CourseUpdatedAt         pgtype.Timestamptz
 	CourseDeletedAt         pgtype.Timestamptz
@@ -377,6 +727,7 @@ func (q *Queries) GetCourseOfferingsWithPagination(ctx context.Context, arg GetC
 			&i.SectionCode,
 			&i.Capacity,
 			&i.CourseOfferingStartTime,
+			&i.RoomID,
 			&i.CourseOfferingCreatedAt,
 			&i.CourseOfferingUpdatedAt,
 			&i.CourseOfferingDeletedAt,
@@ -384,6 +735,7 @@ func (q *Queries) GetCourseOfferingsWithPagination(ctx context.Context, arg GetC
 			&i.CourseCode,
 			&i.CourseName,
 			&i.Credit,
+			&i.MinutesPerCredit,
 			&i.CourseCreatedAt,
 			&i.CourseUpdatedAt,
 			&i.CourseDeletedAt,
@@ -398,27 +750,243 @@ func (q *Queries) GetCourseOfferingsWithPagination(ctx context.Context, arg GetC
 	return items, nil
 }
 
+const getEnrolledExcludedCourses = `-- name: GetEnrolledExcludedCourses :many
+select c.id, c.code, c.name from course_registrations cr
+join course_offerings co on cr.course_offering_id = co.id
+join courses c on co.course_id = c.id
+join course_exclusions ce
+  on (ce.course_id = $1 and ce.excluded_course_id = c.id)
+  or (ce.excluded_course_id = $1 and ce.course_id = c.id)
+where cr.student_id = $2
+  and co.semester_id = $3
+  and co.deleted_at IS NULL
+  and cr.status <> 'rejected'
+  and cr.deleted_at IS NULL
+order by c.code
+`
+
+type GetEnrolledExcludedCoursesParams struct {
+	CourseID   pgtype.UUID
+	StudentID  pgtype.UUID
+	SemesterID pgtype.UUID
+}
+
+type GetEnrolledExcludedCoursesRow struct {
+	ID   pgtype.UUID
+	Code string
+	Name string
+}
+
+// Courses the student takes in the semester that may not be combined with the course
+func (q *Queries) GetEnrolledExcludedCourses(ctx context.Context, arg GetEnrolledExcludedCoursesParams) ([]GetEnrolledExcludedCoursesRow, error) {
+	rows, err := q.db.Query(ctx, getEnrolledExcludedCourses,
+		arg.CourseID,
+		arg.StudentID,
+		arg.SemesterID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetEnrolledExcludedCoursesRow
+	for rows.Next() {
+		var i GetEnrolledExcludedCoursesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Code,
+			&i.Name,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEnrollment = `-- name: GetEnrollment :one
+select id, student_id, course_offering_id, created_at, updated_at, deleted_at, study_plan_id, status, dropped_by, drop_reason from course_registrations
+where student_id = $1 and course_offering_id = $2 and deleted_at IS NULL
+`
+
+type GetEnrollmentParams struct {
+	StudentID        pgtype.UUID
+	CourseOfferingID pgtype.UUID
+}
+
+func (q *Queries) GetEnrollment(ctx context.Context, arg GetEnrollmentParams) (CourseRegistration, error) {
+	row := q.db.QueryRow(ctx, getEnrollment,
+		arg.StudentID,
+		arg.CourseOfferingID,
+	)
+	var i CourseRegistration
+	err := row.Scan(
+		&i.ID,
+		&i.StudentID,
+		&i.CourseOfferingID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.StudyPlanID,
+		&i.Status,
+		&i.DroppedBy,
+		&i.DropReason,
+	)
+	return i, err
+}
+
+const getMissingCorequisites = `-- name: GetMissingCorequisites :many
+select c.id, c.code, c.name from course_corequisites cq
+join courses c on cq.corequisite_course_id = c.id
+where cq.course_id = $1 and c.deleted_at IS NULL
+  and not exists (
+    select 1 from course_registrations cr
+    join course_offerings co on cr.course_offering_id = co.id
+    where cr.student_id = $2
+      and co.course_id = cq.corequisite_course_id
+      and co.semester_id = $3
+      and co.deleted_at IS NULL
+      and cr.status <> 'rejected'
+      and cr.deleted_at IS NULL
+  )
+  and not exists (
+    select 1 from course_completions cc
+    where cc.student_id = $2
+      and cc.course_id = cq.corequisite_course_id
+      and cc.grade = any($4::text[])
+  )
+order by c.code
+`
+
+type GetMissingCorequisitesParams struct {
+	CourseID      pgtype.UUID
+	StudentID     pgtype.UUID
+	SemesterID    pgtype.UUID
+	PassingGrades []string
+}
+
+type GetMissingCorequisitesRow struct {
+	ID   pgtype.UUID
+	Code string
+	Name string
+}
+
+// Co-requisites of the course the student neither takes in the semester nor passed, retired courses are ignored
+func (q *Queries) GetMissingCorequisites(ctx context.Context, arg GetMissingCorequisitesParams) ([]GetMissingCorequisitesRow, error) {
+	rows, err := q.db.Query(ctx, getMissingCorequisites,
+		arg.CourseID,
+		arg.StudentID,
+		arg.SemesterID,
+		arg.PassingGrades,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetMissingCorequisitesRow
+	for rows.Next() {
+		var i GetMissingCorequisitesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Code,
+			&i.Name,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMissingPrerequisites = `-- name: GetMissingPrerequisites :many
+select c.id, c.code, c.name from course_prerequisites cp
+join courses c on cp.prerequisite_course_id = c.id
+where cp.course_id = $1 and c.deleted_at IS NULL
+  and not exists (
+    select 1 from course_completions cc
+    where cc.student_id = $2
+      and cc.course_id = cp.prerequisite_course_id
+      and cc.grade = any($3::text[])
+  )
+order by c.code
+`
+
+type GetMissingPrerequisitesParams struct {
+	CourseID      pgtype.UUID
+	StudentID     pgtype.UUID
+	PassingGrades []string
+}
+
+type GetMissingPrerequisitesRow struct {
+	ID   pgtype.UUID
+	Code string
+	Name string
+}
+
+// Prerequisites of the course the student has no passing grade for, retired prerequisite courses are ignored
+func (q *Queries) GetMissingPrerequisites(ctx context.Context, arg GetMissingPrerequisitesParams) ([]GetMissingPrerequisitesRow, error) {
+	rows, err := q.db.Query(ctx, getMissingPrerequisites,
+		arg.CourseID,
+		arg.StudentID,
+		arg.PassingGrades,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetMissingPrerequisitesRow
+	for rows.Next() {
+		var i GetMissingPrerequisitesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Code,
+			&i.Name,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getStudentEnrollmentsWithDetails = `-- name: GetStudentEnrollmentsWithDetails :many
 select 
     cr.id as registration_id,
     cr.student_id,
     cr.course_offering_id,
+    co.semester_id,
     cr.created_at as registration_created_at,
     co.start_time as course_offering_start_time,
-    c.credit
+    c.credit,
+    c.minutes_per_credit,
+    s.start_time as semester_start_time,
+    s.end_time as semester_end_time
 from course_registrations cr
 join course_offerings co on cr.course_offering_id = co.id
 join courses c on co.course_id = c.id
-where cr.student_id = $1
+join semesters s on co.semester_id = s.id
+where cr.student_id = $1 and cr.status <> 'rejected' and cr.deleted_at IS NULL
 `
 
 type GetStudentEnrollmentsWithDetailsRow struct {
 	RegistrationID          pgtype.UUID
 	StudentID               pgtype.UUID
 	CourseOfferingID        pgtype.UUID
+	SemesterID              pgtype.UUID
 	RegistrationCreatedAt   pgtype.Timestamptz
 	CourseOfferingStartTime pgtype.Timestamptz
 	Credit                  int32
+	MinutesPerCredit        int32
+	SemesterStartTime       pgtype.Timestamptz
+	SemesterEndTime         pgtype.Timestamptz
 }
 
 func (q *Queries) GetStudentEnrollmentsWithDetails(ctx context.Context, studentID pgtype.UUID) ([]GetStudentEnrollmentsWithDetailsRow, error) {
@@ -434,9 +1002,13 @@ func (q *Queries) GetStudentEnrollmentsWithDetails(ctx context.Context, studentI
 			&i.RegistrationID,
 			&i.StudentID,
 			&i.CourseOfferingID,
+			&i.SemesterID,
 			&i.RegistrationCreatedAt,
 			&i.CourseOfferingStartTime,
 			&i.Credit,
+			&i.MinutesPerCredit,
+			&i.SemesterStartTime,
+			&i.SemesterEndTime,
 		); err != nil {
 			return nil, err
 		}
@@ -448,11 +1020,48 @@ func (q *Queries) GetStudentEnrollmentsWithDetails(ctx context.Context, studentI
 	return items, nil
 }
 
+const isCourseInActiveCurriculum = `-- name: IsCourseInActiveCurriculum :one
+select exists(
+    select 1 from curriculum_courses cc
+    join curricula cu on cc.curriculum_id = cu.id
+    where cc.course_id = $1 and cu.is_active and cu.deleted_at IS NULL
+)
+`
+
+// Offerings can only be opened for courses that are part of at least one active curriculum
+func (q *Queries) IsCourseInActiveCurriculum(ctx context.Context, courseID pgtype.UUID) (bool, error) {
+	row := q.db.QueryRow(ctx, isCourseInActiveCurriculum, courseID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const isCourseInStudyProgram = `-- name: IsCourseInStudyProgram :one
+select exists(
+    select 1 from curriculum_courses cc
+    join curricula cu on cc.curriculum_id = cu.id
+    where cc.course_id = $1 and cu.study_program_id = $2 and cu.deleted_at IS NULL
+)
+`
+
+type IsCourseInStudyProgramParams struct {
+	CourseID       pgtype.UUID
+	StudyProgramID pgtype.UUID
+}
+
+// A course belongs to a study program when it is part of one of its curricula
+func (q *Queries) IsCourseInStudyProgram(ctx context.Context, arg IsCourseInStudyProgramParams) (bool, error) {
+	row := q.db.QueryRow(ctx, isCourseInStudyProgram, arg.CourseID, arg.StudyProgramID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
 const updateCourseOffering = `-- name: UpdateCourseOffering :one
 update course_offerings 
-set semester_id = $2, course_id = $3, section_code = $4, capacity = $5, start_time = $6, updated_at = now()
+set semester_id = $2, course_id = $3, section_code = $4, capacity = $5, start_time = $6, room_id = $7, updated_at = now()
 where id = $1 and deleted_at IS NULL
-returning id, semester_id, course_id, section_code, capacity, start_time, created_at, updated_at, deleted_at
+returning id, semester_id, course_id, section_code, capacity, start_time, created_at, updated_at, deleted_at, room_id
 `
 
 type UpdateCourseOfferingParams struct {
@@ -462,6 +1071,7 @@ type UpdateCourseOfferingParams struct {
 	SectionCode string
 	Capacity    int32
 	StartTime   pgtype.Timestamptz
+	RoomID      pgtype.UUID
 }
 
 func (q *Queries) UpdateCourseOffering(ctx context.Context, arg UpdateCourseOfferingParams) (CourseOffering, error) {
@@ -472,6 +1082,7 @@ func (q *Queries) UpdateCourseOffering(ctx context.Context, arg UpdateCourseOffe
 		arg.SectionCode,
 		arg.Capacity,
 		arg.StartTime,
+		arg.RoomID,
 	)
 	var i CourseOffering
 	err := row.Scan(
@@ -484,6 +1095,7 @@ func (q *Queries) UpdateCourseOffering(ctx context.Context, arg UpdateCourseOffe
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.RoomID,
 	)
 	return i, err
 }
@@ -18,16 +18,48 @@ type AcademicYear struct {
 	DeletedAt pgtype.Timestamptz
 }
 
-type Course struct {
+type Building struct {
 	ID        pgtype.UUID
 	Code      string
 	Name      string
-	Credit    int32
 	CreatedAt pgtype.Timestamptz
 	UpdatedAt pgtype.Timestamptz
 	DeletedAt pgtype.Timestamptz
 }
 
+type Course struct {
+	ID               pgtype.UUID
+	Code             string
+	Name             string
+	Credit           int32
+	CreatedAt        pgtype.Timestamptz
+	UpdatedAt        pgtype.Timestamptz
+	DeletedAt        pgtype.Timestamptz
+	MinutesPerCredit int32
+}
+
+type CourseCompletion struct {
+	ID        pgtype.UUID
+	StudentID pgtype.UUID
+	CourseID  pgtype.UUID
+	Grade     string
+	CreatedAt pgtype.Timestamptz
+}
+
+type CourseCorequisite struct {
+	ID                  pgtype.UUID
+	CourseID            pgtype.UUID
+	CorequisiteCourseID pgtype.UUID
+	CreatedAt           pgtype.Timestamptz
+}
+
+type CourseExclusion struct {
+	ID               pgtype.UUID
+	CourseID         pgtype.UUID
+	ExcludedCourseID pgtype.UUID
+	CreatedAt        pgtype.Timestamptz
+}
+
 type CourseOffering struct {
 	ID          pgtype.UUID
 	SemesterID  pgtype.UUID
@@ -38,6 +70,40 @@ type CourseOffering struct {
 	CreatedAt   pgtype.Timestamptz
 	UpdatedAt   pgtype.Timestamptz
 	DeletedAt   pgtype.Timestamptz
+	RoomID      pgtype.UUID
+}
+
+type CourseOfferingLecturer struct {
+	ID               pgtype.UUID
+	CourseOfferingID pgtype.UUID
+	LecturerID       pgtype.UUID
+	CreatedAt        pgtype.Timestamptz
+}
+
+type CourseOfferingSchedule struct {
+	ID               pgtype.UUID
+	CourseOfferingID pgtype.UUID
+	DayOfWeek        int16
+	StartTime        pgtype.Time
+	CreatedAt        pgtype.Timestamptz
+}
+
+type CourseOfferingWaitlist struct {
+	ID                   pgtype.UUID
+	Position             int64
+	CourseOfferingID     pgtype.UUID
+	StudentID            pgtype.UUID
+	Status               string
+	CourseRegistrationID pgtype.UUID
+	CreatedAt            pgtype.Timestamptz
+	UpdatedAt            pgtype.Timestamptz
+}
+
+type CoursePrerequisite struct {
+	ID                   pgtype.UUID
+	CourseID             pgtype.UUID
+	PrerequisiteCourseID pgtype.UUID
+	CreatedAt            pgtype.Timestamptz
 }
 
 type CourseRegistration struct {
@@ -47,6 +113,112 @@ type CourseRegistration struct {
 	CreatedAt        pgtype.Timestamptz
 	UpdatedAt        pgtype.Timestamptz
 	DeletedAt        pgtype.Timestamptz
+	StudyPlanID      pgtype.UUID
+	Status           string
+	DroppedBy        pgtype.UUID
+	DropReason       pgtype.Text
+}
+
+type Curriculum struct {
+	ID             pgtype.UUID
+	StudyProgramID pgtype.UUID
+	Code           string
+	Name           string
+	CreatedAt      pgtype.Timestamptz
+	UpdatedAt      pgtype.Timestamptz
+	DeletedAt      pgtype.Timestamptz
+	IsActive       bool
+}
+
+type CurriculumCourse struct {
+	ID           pgtype.UUID
+	CurriculumID pgtype.UUID
+	CourseID     pgtype.UUID
+	CreatedAt    pgtype.Timestamptz
+}
+
+type Lecturer struct {
+	ID                     pgtype.UUID
+	UserID                 pgtype.UUID
+	HomebaseStudyProgramID pgtype.UUID
+	Nidn                   string
+	Nuptk                  pgtype.Text
+	CreatedAt              pgtype.Timestamptz
+	UpdatedAt              pgtype.Timestamptz
+	DeletedAt              pgtype.Timestamptz
+}
+
+type LoginThrottle struct {
+	Scope          string
+	Key            string
+	FailedAttempts int32
+	LastFailedAt   pgtype.Timestamptz
+	LockedUntil    pgtype.Timestamptz
+}
+
+type MfaRecoveryCode struct {
+	ID        pgtype.UUID
+	UserID    pgtype.UUID
+	CodeHash  string
+	UsedAt    pgtype.Timestamptz
+	CreatedAt pgtype.Timestamptz
+}
+
+type PasswordResetToken struct {
+	ID        pgtype.UUID
+	UserID    pgtype.UUID
+	TokenHash string
+	ExpiresAt pgtype.Timestamptz
+	UsedAt    pgtype.Timestamptz
+	CreatedBy pgtype.UUID
+	CreatedAt pgtype.Timestamptz
+}
+
+type Permission struct {
+	Name        string
+	Description string
+}
+
+type RefreshToken struct {
+	ID         pgtype.UUID
+	UserID     pgtype.UUID
+	FamilyID   pgtype.UUID
+	TokenHash  string
+	ExpiresAt  pgtype.Timestamptz
+	RevokedAt  pgtype.Timestamptz
+	ReplacedBy pgtype.UUID
+	CreatedAt  pgtype.Timestamptz
+	UpdatedAt  pgtype.Timestamptz
+}
+
+type RevokedAccessToken struct {
+	Jti       pgtype.UUID
+	UserID    pgtype.UUID
+	ExpiresAt pgtype.Timestamptz
+	RevokedAt pgtype.Timestamptz
+}
+
+type Role struct {
+	ID        pgtype.Numeric
+	Name      string
+	CreatedAt pgtype.Timestamptz
+}
+
+type RolePermission struct {
+	RoleID     pgtype.Numeric
+	Permission string
+	CreatedAt  pgtype.Timestamptz
+}
+
+type Room struct {
+	ID         pgtype.UUID
+	BuildingID pgtype.UUID
+	Code       string
+	Name       string
+	Capacity   int32
+	CreatedAt  pgtype.Timestamptz
+	UpdatedAt  pgtype.Timestamptz
+	DeletedAt  pgtype.Timestamptz
 }
 
 type Semester struct {
@@ -58,14 +230,88 @@ type Semester struct {
 	CreatedAt      pgtype.Timestamptz
 	UpdatedAt      pgtype.Timestamptz
 	DeletedAt      pgtype.Timestamptz
+	DropDeadline   pgtype.Timestamptz
 }
 
-type User struct {
+type SemesterGpa struct {
+	ID         pgtype.UUID
+	StudentID  pgtype.UUID
+	SemesterID pgtype.UUID
+	Gpa        pgtype.Numeric
+	CreatedAt  pgtype.Timestamptz
+}
+
+type Student struct {
+	ID                pgtype.UUID
+	UserID            pgtype.UUID
+	StudyProgramID    pgtype.UUID
+	Nim               string
+	CreatedAt         pgtype.Timestamptz
+	UpdatedAt         pgtype.Timestamptz
+	DeletedAt         pgtype.Timestamptz
+	AcademicAdvisorID pgtype.UUID
+}
+
+type StudentCreditAllowance struct {
+	StudentID  pgtype.UUID
+	MaxCredits int32
+	SetBy      pgtype.UUID
+	CreatedAt  pgtype.Timestamptz
+	UpdatedAt  pgtype.Timestamptz
+}
+
+type StudyPlan struct {
+	ID         pgtype.UUID
+	StudentID  pgtype.UUID
+	SemesterID pgtype.UUID
+	Status     string
+	CreatedAt  pgtype.Timestamptz
+	UpdatedAt  pgtype.Timestamptz
+}
+
+type StudyPlanHistory struct {
+	ID                   pgtype.UUID
+	StudyPlanID          pgtype.UUID
+	CourseRegistrationID pgtype.UUID
+	StatusBefore         string
+	StatusAfter          string
+	Note                 pgtype.Text
+	ChangedBy            pgtype.UUID
+	CreatedAt            pgtype.Timestamptz
+}
+
+type StudyProgram struct {
 	ID        pgtype.UUID
-	Email     string
-	Password  string
-	Role      pgtype.Numeric
+	Code      string
+	Name      string
+	Level     string
 	CreatedAt pgtype.Timestamptz
 	UpdatedAt pgtype.Timestamptz
 	DeletedAt pgtype.Timestamptz
 }
+
+type User struct {
+	ID             pgtype.UUID
+	Email          string
+	Password       string
+	Role           pgtype.Numeric
+	CreatedAt      pgtype.Timestamptz
+	UpdatedAt      pgtype.Timestamptz
+	DeletedAt      pgtype.Timestamptz
+	DisabledAt     pgtype.Timestamptz
+	Name           pgtype.Text
+	StudyProgramID pgtype.UUID
+}
+
+type UserMfa struct {
+	UserID       pgtype.UUID
+	Secret       string
+	ConfirmedAt  pgtype.Timestamptz
+	LastUsedStep pgtype.Int8
+	CreatedAt    pgtype.Timestamptz
+}
+
+type UserSessionRevocation struct {
+	UserID    pgtype.UUID
+	RevokedAt pgtype.Timestamptz
+}
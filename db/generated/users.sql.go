@@ -11,20 +11,176 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countUsers = `-- name: CountUsers :one
+select count(*) from users
+where ($1::numeric IS NULL OR role = $1)
+  and ($2::text IS NULL OR email ilike '%' || $2 || '%')
+  and ($3::boolean OR deleted_at IS NULL)
+`
+
+type CountUsersParams struct {
+	Role           pgtype.Numeric
+	Email          pgtype.Text
+	IncludeDeleted bool
+}
+
+func (q *Queries) CountUsers(ctx context.Context, arg CountUsersParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countUsers, arg.Role, arg.Email, arg.IncludeDeleted)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createPasswordResetToken = `-- name: CreatePasswordResetToken :one
+insert into password_reset_tokens (id, user_id, token_hash, expires_at, created_by, created_at)
+values (gen_random_uuid(), $1, $2, $3, $4, now())
+returning id, user_id, token_hash, expires_at, used_at, created_by, created_at
+`
+
+type CreatePasswordResetTokenParams struct {
+	UserID    pgtype.UUID
+	TokenHash string
+	ExpiresAt pgtype.Timestamptz
+	CreatedBy pgtype.UUID
+}
+
+func (q *Queries) CreatePasswordResetToken(ctx context.Context, arg CreatePasswordResetTokenParams) (PasswordResetToken, error) {
+	row := q.db.QueryRow(ctx, createPasswordResetToken,
+		arg.UserID,
+		arg.TokenHash,
+		arg.ExpiresAt,
+		arg.CreatedBy,
+	)
+	var i PasswordResetToken
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TokenHash,
+		&i.ExpiresAt,
+		&i.UsedAt,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createRefreshToken = `-- name: CreateRefreshToken :one
+insert into refresh_tokens (id, user_id, family_id, token_hash, expires_at, created_at, updated_at)
+values (gen_random_uuid(), $1, $2, $3, $4, now(), now())
+returning id, user_id, family_id, token_hash, expires_at, revoked_at, replaced_by, created_at, updated_at
+`
+
+type CreateRefreshTokenParams struct {
+	UserID    pgtype.UUID
+	FamilyID  pgtype.UUID
+	TokenHash string
+	ExpiresAt pgtype.Timestamptz
+}
+
+func (q *Queries) CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) (RefreshToken, error) {
+	row := q.db.QueryRow(ctx, createRefreshToken,
+		arg.UserID,
+		arg.FamilyID,
+		arg.TokenHash,
+		arg.ExpiresAt,
+	)
+	var i RefreshToken
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.FamilyID,
+		&i.TokenHash,
+		&i.ExpiresAt,
+		&i.RevokedAt,
+		&i.ReplacedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const createUser = `-- name: CreateUser :one
-insert into users (id, email, password, role, created_at, updated_at)
-values (gen_random_uuid(), $1, $2, $3, now(), now())
-returning id, email, password, role, created_at, updated_at, deleted_at
+insert into users (id, email, password, name, role, study_program_id, created_at, updated_at)
+values (gen_random_uuid(), $1, $2, $3, $4, $5, now(), now())
+returning id, email, password, role, created_at, updated_at, deleted_at, disabled_at, name, study_program_id
 `
 
 type CreateUserParams struct {
-	Email    string
-	Password string
-	Role     pgtype.Numeric
+	Email          string
+	Password       string
+	Name           pgtype.Text
+	Role           pgtype.Numeric
+	StudyProgramID pgtype.UUID
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
-	row := q.db.QueryRow(ctx, createUser, arg.Email, arg.Password, arg.Role)
+	row := q.db.QueryRow(ctx, createUser,
+		arg.Email,
+		arg.Password,
+		arg.Name,
+		arg.Role,
+		arg.StudyProgramID,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Password,
+		&i.Role,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.DisabledAt,
+		&i.Name,
+		&i.StudyProgramID,
+	)
+	return i, err
+}
+
+type CreateUsersParams struct {
+	ID        pgtype.UUID
+	Email     string
+	Password  string
+	Name      pgtype.Text
+	Role      pgtype.Numeric
+	CreatedAt pgtype.Timestamptz
+	UpdatedAt pgtype.Timestamptz
+}
+
+const deleteUser = `-- name: DeleteUser :one
+update users
+set deleted_at = now(), updated_at = now()
+where id = $1 and deleted_at IS NULL
+returning id, email, password, role, created_at, updated_at, deleted_at, disabled_at, name, study_program_id
+`
+
+func (q *Queries) DeleteUser(ctx context.Context, id pgtype.UUID) (User, error) {
+	row := q.db.QueryRow(ctx, deleteUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Password,
+		&i.Role,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.DisabledAt,
+		&i.Name,
+		&i.StudyProgramID,
+	)
+	return i, err
+}
+
+const disableUser = `-- name: DisableUser :one
+update users
+set disabled_at = coalesce(disabled_at, now()), updated_at = now()
+where id = $1 and deleted_at IS NULL
+returning id, email, password, role, created_at, updated_at, deleted_at, disabled_at, name, study_program_id
+`
+
+func (q *Queries) DisableUser(ctx context.Context, id pgtype.UUID) (User, error) {
+	row := q.db.QueryRow(ctx, disableUser, id)
 	var i User
 	err := row.Scan(
 		&i.ID,
@@ -34,12 +190,106 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.DisabledAt,
+		&i.Name,
+		&i.StudyProgramID,
+	)
+	return i, err
+}
+
+const enableUser = `-- name: EnableUser :one
+update users
+set disabled_at = NULL, updated_at = now()
+where id = $1 and deleted_at IS NULL
+returning id, email, password, role, created_at, updated_at, deleted_at, disabled_at, name, study_program_id
+`
+
+func (q *Queries) EnableUser(ctx context.Context, id pgtype.UUID) (User, error) {
+	row := q.db.QueryRow(ctx, enableUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Password,
+		&i.Role,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.DisabledAt,
+		&i.Name,
+		&i.StudyProgramID,
+	)
+	return i, err
+}
+
+const getActiveUserEmails = `-- name: GetActiveUserEmails :many
+select lower(email)::text as email from users
+where lower(email) = any($1::text[]) and deleted_at IS NULL
+`
+
+// Returns the lowercased emails, the emails passed in must be lowercased as well
+func (q *Queries) GetActiveUserEmails(ctx context.Context, emails []string) ([]string, error) {
+	rows, err := q.db.Query(ctx, getActiveUserEmails, emails)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			return nil, err
+		}
+		items = append(items, email)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPasswordResetTokenByHash = `-- name: GetPasswordResetTokenByHash :one
+select id, user_id, token_hash, expires_at, used_at, created_by, created_at from password_reset_tokens where token_hash = $1 for update
+`
+
+func (q *Queries) GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (PasswordResetToken, error) {
+	row := q.db.QueryRow(ctx, getPasswordResetTokenByHash, tokenHash)
+	var i PasswordResetToken
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TokenHash,
+		&i.ExpiresAt,
+		&i.UsedAt,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getRefreshTokenByHash = `-- name: GetRefreshTokenByHash :one
+select id, user_id, family_id, token_hash, expires_at, revoked_at, replaced_by, created_at, updated_at from refresh_tokens where token_hash = $1 for update
+`
+
+func (q *Queries) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (RefreshToken, error) {
+	row := q.db.QueryRow(ctx, getRefreshTokenByHash, tokenHash)
+	var i RefreshToken
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.FamilyID,
+		&i.TokenHash,
+		&i.ExpiresAt,
+		&i.RevokedAt,
+		&i.ReplacedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
 const getUser = `-- name: GetUser :one
-select id, email, password, role, created_at, updated_at, deleted_at from users where id = $1
+select id, email, password, role, created_at, updated_at, deleted_at, disabled_at, name, study_program_id from users where id = $1
 `
 
 func (q *Queries) GetUser(ctx context.Context, id pgtype.UUID) (User, error) {
@@ -53,14 +303,18 @@ func (q *Queries) GetUser(ctx context.Context, id pgtype.UUID) (User, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.DisabledAt,
+		&i.Name,
+		&i.StudyProgramID,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-select id, email, password, role, created_at, updated_at, deleted_at from users where email = $1
+select id, email, password, role, created_at, updated_at, deleted_at, disabled_at, name, study_program_id from users where lower(email) = lower($1) and deleted_at IS NULL
 `
 
+// Emails match case-insensitively, accounts created before emails were lowercased may still have mixed case
 func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
 	row := q.db.QueryRow(ctx, getUserByEmail, email)
 	var i User
@@ -72,6 +326,281 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.DisabledAt,
+		&i.Name,
+		&i.StudyProgramID,
+	)
+	return i, err
+}
+
+const invalidatePasswordResetTokens = `-- name: InvalidatePasswordResetTokens :exec
+update password_reset_tokens
+set used_at = now()
+where user_id = $1 and used_at IS NULL
+`
+
+func (q *Queries) InvalidatePasswordResetTokens(ctx context.Context, userID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, invalidatePasswordResetTokens, userID)
+	return err
+}
+
+const listUsers = `-- name: ListUsers :many
+select id, email, password, role, created_at, updated_at, deleted_at, disabled_at, name, study_program_id from users
+where ($1::numeric IS NULL OR role = $1)
+  and ($2::text IS NULL OR email ilike '%' || $2 || '%')
+  and ($3::boolean OR deleted_at IS NULL)
+order by created_at desc
+limit $4 offset $5
+`
+
+type ListUsersParams struct {
+	Role           pgtype.Numeric
+	Email          pgtype.Text
+	IncludeDeleted bool
+	Limit          int32
+	Offset         int32
+}
+
+func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, listUsers,
+		arg.Role,
+		arg.Email,
+		arg.IncludeDeleted,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Email,
+			&i.Password,
+			&i.Role,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.DisabledAt,
+			&i.Name,
+			&i.StudyProgramID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markPasswordResetTokenUsed = `-- name: MarkPasswordResetTokenUsed :one
+update password_reset_tokens
+set used_at = now()
+where id = $1 and used_at IS NULL
+returning id, user_id, token_hash, expires_at, used_at, created_by, created_at
+`
+
+func (q *Queries) MarkPasswordResetTokenUsed(ctx context.Context, id pgtype.UUID) (PasswordResetToken, error) {
+	row := q.db.QueryRow(ctx, markPasswordResetTokenUsed, id)
+	var i PasswordResetToken
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TokenHash,
+		&i.ExpiresAt,
+		&i.UsedAt,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const restoreUser = `-- name: RestoreUser :one
+update users
+set deleted_at = NULL, updated_at = now()
+where id = $1 and deleted_at IS NOT NULL
+returning id, email, password, role, created_at, updated_at, deleted_at, disabled_at, name, study_program_id
+`
+
+func (q *Queries) RestoreUser(ctx context.Context, id pgtype.UUID) (User, error) {
+	row := q.db.QueryRow(ctx, restoreUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Password,
+		&i.Role,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.DisabledAt,
+		&i.Name,
+		&i.StudyProgramID,
+	)
+	return i, err
+}
+
+const revokeRefreshTokenFamily = `-- name: RevokeRefreshTokenFamily :exec
+update refresh_tokens
+set revoked_at = now(), updated_at = now()
+where family_id = $1 and revoked_at IS NULL
+`
+
+func (q *Queries) RevokeRefreshTokenFamily(ctx context.Context, familyID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, revokeRefreshTokenFamily, familyID)
+	return err
+}
+
+const revokeRefreshTokenFamilyByHash = `-- name: RevokeRefreshTokenFamilyByHash :exec
+update refresh_tokens
+set revoked_at = now(), updated_at = now()
+where family_id = (
+    select rt.family_id from refresh_tokens rt
+    where rt.token_hash = $1 and rt.user_id = $2
+) and revoked_at IS NULL
+`
+
+type RevokeRefreshTokenFamilyByHashParams struct {
+	TokenHash string
+	UserID    pgtype.UUID
+}
+
+func (q *Queries) RevokeRefreshTokenFamilyByHash(ctx context.Context, arg RevokeRefreshTokenFamilyByHashParams) error {
+	_, err := q.db.Exec(ctx, revokeRefreshTokenFamilyByHash, arg.TokenHash, arg.UserID)
+	return err
+}
+
+const revokeUserRefreshTokens = `-- name: RevokeUserRefreshTokens :exec
+update refresh_tokens
+set revoked_at = now(), updated_at = now()
+where user_id = $1 and revoked_at IS NULL
+`
+
+func (q *Queries) RevokeUserRefreshTokens(ctx context.Context, userID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, revokeUserRefreshTokens, userID)
+	return err
+}
+
+const rotateRefreshToken = `-- name: RotateRefreshToken :one
+update refresh_tokens
+set revoked_at = now(), replaced_by = $2, updated_at = now()
+where id = $1 and revoked_at IS NULL
+returning id, user_id, family_id, token_hash, expires_at, revoked_at, replaced_by, created_at, updated_at
+`
+
+type RotateRefreshTokenParams struct {
+	ID         pgtype.UUID
+	ReplacedBy pgtype.UUID
+}
+
+func (q *Queries) RotateRefreshToken(ctx context.Context, arg RotateRefreshTokenParams) (RefreshToken, error) {
+	row := q.db.QueryRow(ctx, rotateRefreshToken, arg.ID, arg.ReplacedBy)
+	var i RefreshToken
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.FamilyID,
+		&i.TokenHash,
+		&i.ExpiresAt,
+		&i.RevokedAt,
+		&i.ReplacedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateUserPassword = `-- name: UpdateUserPassword :one
+update users
+set password = $2, updated_at = now()
+where id = $1 and deleted_at IS NULL
+returning id, email, password, role, created_at, updated_at, deleted_at, disabled_at, name, study_program_id
+`
+
+type UpdateUserPasswordParams struct {
+	ID       pgtype.UUID
+	Password string
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUserPassword, arg.ID, arg.Password)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Password,
+		&i.Role,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.DisabledAt,
+		&i.Name,
+		&i.StudyProgramID,
+	)
+	return i, err
+}
+
+const updateUserRole = `-- name: UpdateUserRole :one
+update users
+set role = $2, updated_at = now()
+where id = $1 and deleted_at IS NULL
+returning id, email, password, role, created_at, updated_at, deleted_at, disabled_at, name, study_program_id
+`
+
+type UpdateUserRoleParams struct {
+	ID   pgtype.UUID
+	Role pgtype.Numeric
+}
+
+func (q *Queries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUserRole, arg.ID, arg.Role)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Password,
+		&i.Role,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.DisabledAt,
+		&i.Name,
+		&i.StudyProgramID,
+	)
+	return i, err
+}
+
+const updateUserStudyProgram = `-- name: UpdateUserStudyProgram :one
+update users
+set study_program_id = $2, updated_at = now()
+where id = $1 and deleted_at IS NULL
+returning id, email, password, role, created_at, updated_at, deleted_at, disabled_at, name, study_program_id
+`
+
+type UpdateUserStudyProgramParams struct {
+	ID             pgtype.UUID
+	StudyProgramID pgtype.UUID
+}
+
+func (q *Queries) UpdateUserStudyProgram(ctx context.Context, arg UpdateUserStudyProgramParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUserStudyProgram, arg.ID, arg.StudyProgramID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Password,
+		&i.Role,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.DisabledAt,
+		&i.Name,
+		&i.StudyProgramID,
 	)
 	return i, err
 }
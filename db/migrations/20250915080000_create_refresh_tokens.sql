@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE refresh_tokens (
+    id uuid not null,
+    user_id uuid not null,
+    family_id uuid not null, -- shared by every token rotated from the same login
+    token_hash varchar(64) not null, -- sha256 hex of the opaque token
+    expires_at timestamptz not null,
+    revoked_at timestamptz null,
+    replaced_by uuid null,
+    created_at timestamptz not null default now(),
+    updated_at timestamptz null,
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (user_id) REFERENCES users (id),
+    FOREIGN KEY (replaced_by) REFERENCES refresh_tokens (id),
+    UNIQUE (token_hash)
+);
+
+CREATE INDEX refresh_tokens_family_id_idx ON refresh_tokens (family_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE refresh_tokens;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE revoked_access_tokens (
+    jti uuid not null,
+    user_id uuid not null,
+    expires_at timestamptz not null, -- rows can be purged once the token itself has expired
+    revoked_at timestamptz not null default now(),
+
+    PRIMARY KEY (jti),
+    FOREIGN KEY (user_id) REFERENCES users (id)
+);
+
+CREATE TABLE user_session_revocations (
+    user_id uuid not null,
+    revoked_at timestamptz not null, -- access tokens issued before this instant are rejected
+
+    PRIMARY KEY (user_id),
+    FOREIGN KEY (user_id) REFERENCES users (id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE user_session_revocations;
+DROP TABLE revoked_access_tokens;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE password_reset_tokens (
+    id uuid not null,
+    user_id uuid not null,
+    token_hash varchar(64) not null, -- sha256 hex of the opaque token
+    expires_at timestamptz not null,
+    used_at timestamptz null, -- set when consumed or superseded by a newer token
+    created_by uuid not null, -- admin who issued the reset
+    created_at timestamptz not null default now(),
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (user_id) REFERENCES users (id),
+    FOREIGN KEY (created_by) REFERENCES users (id),
+    UNIQUE (token_hash)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE password_reset_tokens;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN disabled_at timestamptz null; -- disabled accounts can't log in but are kept visible
+
+-- Emails only have to be unique among accounts that are not soft-deleted, so a deleted email can be reused
+CREATE UNIQUE INDEX users_email_active_key ON users (email) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX users_email_active_key;
+ALTER TABLE users DROP COLUMN disabled_at;
+-- +goose StatementEnd
@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN name varchar(255) null;
+
+CREATE TABLE study_programs (
+    id uuid not null,
+    code varchar(255) not null,
+    name varchar(255) not null,
+    level varchar(255) not null, -- jenjang, e.g. D3, S1, S2
+    created_at timestamptz not null default now(),
+    updated_at timestamptz null,
+    deleted_at timestamptz null,
+
+    PRIMARY KEY (id),
+    UNIQUE (code)
+);
+
+CREATE TABLE students (
+    id uuid not null,
+    user_id uuid not null,
+    study_program_id uuid not null,
+    nim varchar(255) not null, -- student number
+    created_at timestamptz not null default now(),
+    updated_at timestamptz null,
+    deleted_at timestamptz null,
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (user_id) REFERENCES users (id),
+    FOREIGN KEY (study_program_id) REFERENCES study_programs (id),
+    UNIQUE (user_id),
+    UNIQUE (nim)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE students;
+DROP TABLE study_programs;
+ALTER TABLE users DROP COLUMN name;
+-- +goose StatementEnd
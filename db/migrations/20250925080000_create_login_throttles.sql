@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE login_throttles (
+    scope varchar(16) not null, -- 'account' (keyed by email) or 'ip' (keyed by client IP)
+    key varchar(255) not null,
+    failed_attempts int not null default 0, -- consecutive failures within the failure window
+    last_failed_at timestamptz not null,
+    locked_until timestamptz null,
+
+    PRIMARY KEY (scope, key)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE login_throttles;
+-- +goose StatementEnd
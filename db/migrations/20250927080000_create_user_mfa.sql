@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE user_mfa (
+    user_id uuid not null,
+    secret varchar(64) not null, -- base32 TOTP secret
+    confirmed_at timestamptz null, -- null while the enrollment is not confirmed with a first code
+    last_used_step bigint null, -- time step of the last accepted code, older or equal steps are replays
+    created_at timestamptz not null default now(),
+
+    PRIMARY KEY (user_id),
+    FOREIGN KEY (user_id) REFERENCES users (id)
+);
+
+CREATE TABLE mfa_recovery_codes (
+    id uuid not null,
+    user_id uuid not null,
+    code_hash varchar(64) not null, -- sha256 hex digest, the code itself is only shown once
+    used_at timestamptz null,
+    created_at timestamptz not null default now(),
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (user_id) REFERENCES users (id)
+);
+
+CREATE INDEX mfa_recovery_codes_user_id_idx ON mfa_recovery_codes (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE mfa_recovery_codes;
+DROP TABLE user_mfa;
+-- +goose StatementEnd
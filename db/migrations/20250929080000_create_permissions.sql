@@ -0,0 +1,69 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE roles (
+    id numeric(2) not null,
+    name varchar(255) not null,
+    created_at timestamptz not null default now(),
+
+    PRIMARY KEY (id),
+    UNIQUE (name)
+);
+
+INSERT INTO roles (id, name) VALUES
+    (1, 'admin'),
+    (2, 'koorprodi'),
+    (3, 'student');
+
+ALTER TABLE users ADD CONSTRAINT users_role_fkey FOREIGN KEY (role) REFERENCES roles (id);
+
+-- Permissions are checked in code, so the catalog only changes together with a release
+CREATE TABLE permissions (
+    name varchar(64) not null,
+    description varchar(255) not null,
+
+    PRIMARY KEY (name)
+);
+
+INSERT INTO permissions (name, description) VALUES
+    ('course_offering:read', 'List course offerings'),
+    ('course_offering:write', 'Create, update and delete course offerings'),
+    ('enrollment:create', 'Enroll oneself into a course offering'),
+    ('mfa:reset', 'Reset the two-factor authentication of any user'),
+    ('password_reset:issue', 'Issue password reset tokens for any user'),
+    ('role:manage', 'Create roles and edit their permissions'),
+    ('session:revoke', 'Revoke all sessions of any user'),
+    ('student:import', 'Bulk import student accounts'),
+    ('user:manage', 'Create, update, disable, delete and unlock user accounts');
+
+CREATE TABLE role_permissions (
+    role_id numeric(2) not null,
+    permission varchar(64) not null,
+    created_at timestamptz not null default now(),
+
+    PRIMARY KEY (role_id, permission),
+    FOREIGN KEY (role_id) REFERENCES roles (id),
+    FOREIGN KEY (permission) REFERENCES permissions (name)
+);
+
+-- Same access as the former hard-coded role lists
+INSERT INTO role_permissions (role_id, permission) VALUES
+    (1, 'course_offering:read'),
+    (1, 'course_offering:write'),
+    (1, 'mfa:reset'),
+    (1, 'password_reset:issue'),
+    (1, 'role:manage'),
+    (1, 'session:revoke'),
+    (1, 'student:import'),
+    (1, 'user:manage'),
+    (2, 'course_offering:read'),
+    (2, 'course_offering:write'),
+    (3, 'enrollment:create');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE role_permissions;
+DROP TABLE permissions;
+ALTER TABLE users DROP CONSTRAINT users_role_fkey;
+DROP TABLE roles;
+-- +goose StatementEnd
@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Study program a staff user (e.g. a Koorprodi) works for, students are linked through the students table
+ALTER TABLE users ADD COLUMN study_program_id uuid null REFERENCES study_programs (id);
+
+CREATE TABLE curricula (
+    id uuid not null,
+    study_program_id uuid not null,
+    code varchar(255) not null,
+    name varchar(255) not null,
+    created_at timestamptz not null default now(),
+    updated_at timestamptz null,
+    deleted_at timestamptz null,
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (study_program_id) REFERENCES study_programs (id),
+    UNIQUE (study_program_id, code)
+);
+
+CREATE TABLE curriculum_courses (
+    id uuid not null,
+    curriculum_id uuid not null,
+    course_id uuid not null,
+    created_at timestamptz not null default now(),
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (curriculum_id) REFERENCES curricula (id),
+    FOREIGN KEY (course_id) REFERENCES courses (id),
+    UNIQUE (curriculum_id, course_id)
+);
+
+CREATE INDEX curriculum_courses_course_id_idx ON curriculum_courses (course_id);
+
+INSERT INTO permissions (name, description) VALUES
+    ('study_program:all', 'Access the data of every study program instead of only the own one');
+
+INSERT INTO role_permissions (role_id, permission) VALUES
+    (1, 'study_program:all');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM role_permissions WHERE permission = 'study_program:all';
+DELETE FROM permissions WHERE name = 'study_program:all';
+DROP TABLE curriculum_courses;
+DROP TABLE curricula;
+ALTER TABLE users DROP COLUMN study_program_id;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE lecturers (
+    id uuid not null,
+    user_id uuid not null,
+    homebase_study_program_id uuid not null,
+    nidn varchar(255) not null, -- national lecturer number
+    nuptk varchar(255) null, -- national educator number, not every lecturer has one
+    created_at timestamptz not null default now(),
+    updated_at timestamptz null,
+    deleted_at timestamptz null,
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (user_id) REFERENCES users (id),
+    FOREIGN KEY (homebase_study_program_id) REFERENCES study_programs (id),
+    UNIQUE (user_id),
+    UNIQUE (nidn)
+);
+
+-- Dosen PA, the lecturer approving the study plan of the student
+ALTER TABLE students ADD COLUMN academic_advisor_id uuid null REFERENCES lecturers (id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE students DROP COLUMN academic_advisor_id;
+DROP TABLE lecturers;
+-- +goose StatementEnd
@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Registrations used to store the user ID of the student. Every registering user needs a
+-- students record before this migration runs, otherwise adding the foreign key fails.
+ALTER TABLE course_registrations DROP CONSTRAINT course_registrations_student_id_fkey;
+
+UPDATE course_registrations cr
+SET student_id = s.id
+FROM students s
+WHERE s.user_id = cr.student_id;
+
+ALTER TABLE course_registrations ADD CONSTRAINT course_registrations_student_id_fkey
+    FOREIGN KEY (student_id) REFERENCES students (id);
+
+INSERT INTO permissions (name, description) VALUES
+    ('lecturer:manage', 'Create, update and delete lecturer records'),
+    ('student:manage', 'Create, update and delete student records');
+
+INSERT INTO role_permissions (role_id, permission) VALUES
+    (1, 'lecturer:manage'),
+    (1, 'student:manage');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM role_permissions WHERE permission IN ('lecturer:manage', 'student:manage');
+DELETE FROM permissions WHERE name IN ('lecturer:manage', 'student:manage');
+
+ALTER TABLE course_registrations DROP CONSTRAINT course_registrations_student_id_fkey;
+
+UPDATE course_registrations cr
+SET student_id = s.user_id
+FROM students s
+WHERE s.id = cr.student_id;
+
+ALTER TABLE course_registrations ADD CONSTRAINT course_registrations_student_id_fkey
+    FOREIGN KEY (student_id) REFERENCES users (id);
+-- +goose StatementEnd
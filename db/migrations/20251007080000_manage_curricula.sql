@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Course offerings can only be opened for courses of an active curriculum, existing curricula stay in use
+ALTER TABLE curricula ADD COLUMN is_active boolean not null default true;
+
+INSERT INTO permissions (name, description) VALUES
+    ('curriculum:manage', 'Create, update and delete curricula and their courses'),
+    ('study_program:manage', 'Create, update and delete study programs');
+
+INSERT INTO role_permissions (role_id, permission) VALUES
+    (1, 'curriculum:manage'),
+    (1, 'study_program:manage'),
+    (2, 'curriculum:manage');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM role_permissions WHERE permission IN ('curriculum:manage', 'study_program:manage');
+DELETE FROM permissions WHERE name IN ('curriculum:manage', 'study_program:manage');
+ALTER TABLE curricula DROP COLUMN is_active;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Course codes are unique among the courses still in the catalogue, a retired code can be reused
+CREATE UNIQUE INDEX courses_code_key ON courses (code) WHERE deleted_at IS NULL;
+
+INSERT INTO permissions (name, description) VALUES
+    ('course:read', 'List and search the course catalogue'),
+    ('course:write', 'Create, update and retire courses');
+
+INSERT INTO role_permissions (role_id, permission) VALUES
+    (1, 'course:read'),
+    (1, 'course:write'),
+    (2, 'course:read'),
+    (2, 'course:write');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM role_permissions WHERE permission IN ('course:read', 'course:write');
+DELETE FROM permissions WHERE name IN ('course:read', 'course:write');
+DROP INDEX courses_code_key;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Like semester codes within an academic year, academic year codes can't be reused after a soft delete
+CREATE UNIQUE INDEX academic_years_code_key ON academic_years (code);
+CREATE INDEX semesters_academic_year_id_idx ON semesters (academic_year_id);
+
+INSERT INTO permissions (name, description) VALUES
+    ('academic_calendar:manage', 'Create, update and delete academic years and semesters');
+
+INSERT INTO role_permissions (role_id, permission) VALUES
+    (1, 'academic_calendar:manage');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM role_permissions WHERE permission = 'academic_calendar:manage';
+DELETE FROM permissions WHERE name = 'academic_calendar:manage';
+DROP INDEX semesters_academic_year_id_idx;
+DROP INDEX academic_years_code_key;
+-- +goose StatementEnd
@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Prerequisites (prasyarat mata kuliah): a student needs a passing grade in the prerequisite course
+-- before enrolling into the course
+CREATE TABLE course_prerequisites (
+    id uuid not null,
+    course_id uuid not null,
+    prerequisite_course_id uuid not null,
+    created_at timestamptz not null default now(),
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (course_id) REFERENCES courses (id),
+    FOREIGN KEY (prerequisite_course_id) REFERENCES courses (id),
+    UNIQUE (course_id, prerequisite_course_id),
+    CHECK (course_id <> prerequisite_course_id)
+);
+
+CREATE INDEX course_prerequisites_prerequisite_course_id_idx ON course_prerequisites (prerequisite_course_id);
+
+-- Final letter grade of every course a student completed, imported from the legacy system
+CREATE TABLE course_completions (
+    id uuid not null,
+    student_id uuid not null,
+    course_id uuid not null,
+    grade varchar(2) not null, -- A, AB, B, BC, C, D or E
+    created_at timestamptz not null default now(),
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (student_id) REFERENCES students (id),
+    FOREIGN KEY (course_id) REFERENCES courses (id),
+    UNIQUE (student_id, course_id)
+);
+
+INSERT INTO permissions (name, description) VALUES
+    ('course_completion:import', 'Bulk import course completions and grades');
+
+INSERT INTO role_permissions (role_id, permission) VALUES
+    (1, 'course_completion:import');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM role_permissions WHERE permission = 'course_completion:import';
+DELETE FROM permissions WHERE name = 'course_completion:import';
+DROP TABLE course_completions;
+DROP TABLE course_prerequisites;
+-- +goose StatementEnd
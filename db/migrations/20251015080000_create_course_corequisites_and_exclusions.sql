@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Co-requisites: the co-requisite course must be taken in the same semester as the course (e.g. a lab and its
+-- lecture), unless the student already passed it
+CREATE TABLE course_corequisites (
+    id uuid not null,
+    course_id uuid not null,
+    corequisite_course_id uuid not null,
+    created_at timestamptz not null default now(),
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (course_id) REFERENCES courses (id),
+    FOREIGN KEY (corequisite_course_id) REFERENCES courses (id),
+    UNIQUE (course_id, corequisite_course_id),
+    CHECK (course_id <> corequisite_course_id)
+);
+
+CREATE INDEX course_corequisites_corequisite_course_id_idx ON course_corequisites (corequisite_course_id);
+
+-- Exclusions: both courses may never be taken in the same semester (e.g. equivalent courses of an old and a new
+-- curriculum), the relation goes both ways so a pair is stored once
+CREATE TABLE course_exclusions (
+    id uuid not null,
+    course_id uuid not null,
+    excluded_course_id uuid not null,
+    created_at timestamptz not null default now(),
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (course_id) REFERENCES courses (id),
+    FOREIGN KEY (excluded_course_id) REFERENCES courses (id),
+    CHECK (course_id <> excluded_course_id)
+);
+
+CREATE UNIQUE INDEX course_exclusions_pair_key ON course_exclusions (least(course_id, excluded_course_id), greatest(course_id, excluded_course_id));
+CREATE INDEX course_exclusions_excluded_course_id_idx ON course_exclusions (excluded_course_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE course_exclusions;
+DROP TABLE course_corequisites;
+-- +goose StatementEnd
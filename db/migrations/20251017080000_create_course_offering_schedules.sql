@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE course_offering_schedules (
+    id uuid not null,
+    course_offering_id uuid not null,
+    day_of_week smallint not null,
+    start_time time not null,
+    created_at timestamptz not null default now(),
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (course_offering_id) REFERENCES course_offerings (id),
+    UNIQUE (course_offering_id, day_of_week, start_time),
+    -- ISO day of the week (hari_pelaksanaan), 1 is Monday and 7 is Sunday
+    CHECK (day_of_week BETWEEN 1 AND 7)
+);
+
+-- Existing offerings meet every week on the day and at the time of their start time on the campus clock,
+-- independent of the session time zone. The zone must match academic.timezone of the config.
+INSERT INTO course_offering_schedules (id, course_offering_id, day_of_week, start_time)
+SELECT gen_random_uuid(), id,
+       extract(isodow from start_time AT TIME ZONE 'Asia/Jakarta')::smallint,
+       (start_time AT TIME ZONE 'Asia/Jakarta')::time
+FROM course_offerings;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE course_offering_schedules;
+-- +goose StatementEnd
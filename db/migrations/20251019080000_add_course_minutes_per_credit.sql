@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Class time of one credit (SKS) in minutes, practicum and thesis courses have more contact hours than lectures
+ALTER TABLE courses ADD COLUMN minutes_per_credit integer not null default 50 CHECK (minutes_per_credit > 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE courses DROP COLUMN minutes_per_credit;
+-- +goose StatementEnd
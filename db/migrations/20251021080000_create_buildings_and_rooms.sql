@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Buildings (gedung) and their rooms (ruang), codes are unique among the ones not deleted
+CREATE TABLE buildings (
+    id uuid not null,
+    code varchar(255) not null,
+    name varchar(255) not null,
+    created_at timestamptz not null default now(),
+    updated_at timestamptz null,
+    deleted_at timestamptz null,
+
+    PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX buildings_code_key ON buildings (code) WHERE deleted_at IS NULL;
+
+CREATE TABLE rooms (
+    id uuid not null,
+    building_id uuid not null,
+    code varchar(255) not null,
+    name varchar(255) not null,
+    capacity integer not null CHECK (capacity > 0),
+    created_at timestamptz not null default now(),
+    updated_at timestamptz null,
+    deleted_at timestamptz null,
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (building_id) REFERENCES buildings (id)
+);
+
+CREATE UNIQUE INDEX rooms_building_id_code_key ON rooms (building_id, code) WHERE deleted_at IS NULL;
+
+-- Offerings created before rooms existed have no room
+ALTER TABLE course_offerings ADD COLUMN room_id uuid null REFERENCES rooms (id);
+CREATE INDEX course_offerings_room_id_idx ON course_offerings (room_id);
+
+INSERT INTO permissions (name, description) VALUES
+    ('room:manage', 'Create, update and delete buildings and rooms');
+
+INSERT INTO role_permissions (role_id, permission) VALUES
+    (1, 'room:manage');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM role_permissions WHERE permission = 'room:manage';
+DELETE FROM permissions WHERE name = 'room:manage';
+DROP INDEX course_offerings_room_id_idx;
+ALTER TABLE course_offerings DROP COLUMN room_id;
+DROP TABLE rooms;
+DROP TABLE buildings;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Teaching assignments (pengampu mata kuliah), the lecturers teaching a course offering
+CREATE TABLE course_offering_lecturers (
+    id uuid not null,
+    course_offering_id uuid not null,
+    lecturer_id uuid not null,
+    created_at timestamptz not null default now(),
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (course_offering_id) REFERENCES course_offerings (id),
+    FOREIGN KEY (lecturer_id) REFERENCES lecturers (id),
+    UNIQUE (course_offering_id, lecturer_id)
+);
+
+CREATE INDEX course_offering_lecturers_lecturer_id_idx ON course_offering_lecturers (lecturer_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE course_offering_lecturers;
+-- +goose StatementEnd
@@ -0,0 +1,64 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Study plan (rencana studi / KRS) of a student in a semester, reviewed by the academic advisor (dosen PA)
+CREATE TABLE study_plans (
+    id uuid not null,
+    student_id uuid not null,
+    semester_id uuid not null,
+    status varchar(16) not null default 'draft' CHECK (status IN ('draft', 'submitted', 'approved', 'rejected')),
+    created_at timestamptz not null default now(),
+    updated_at timestamptz null,
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (student_id) REFERENCES students (id),
+    FOREIGN KEY (semester_id) REFERENCES semesters (id),
+    UNIQUE (student_id, semester_id)
+);
+
+CREATE INDEX study_plans_semester_id_idx ON study_plans (semester_id);
+
+-- Registrations are the lines (rencana studi detail) of the study plan, registrations made before study plans
+-- existed have no plan and count as approved
+ALTER TABLE course_registrations ADD COLUMN study_plan_id uuid null REFERENCES study_plans (id);
+ALTER TABLE course_registrations ADD COLUMN status varchar(16) not null default 'approved'
+    CHECK (status IN ('pending', 'approved', 'rejected'));
+CREATE INDEX course_registrations_study_plan_id_idx ON course_registrations (study_plan_id);
+
+-- Audit trail of the status transitions of a study plan, and of its lines when course_registration_id is set
+CREATE TABLE study_plan_histories (
+    id uuid not null,
+    study_plan_id uuid not null,
+    course_registration_id uuid null,
+    status_before varchar(16) not null,
+    status_after varchar(16) not null,
+    note text null,
+    changed_by uuid not null,
+    created_at timestamptz not null default now(),
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (study_plan_id) REFERENCES study_plans (id),
+    FOREIGN KEY (course_registration_id) REFERENCES course_registrations (id),
+    FOREIGN KEY (changed_by) REFERENCES users (id)
+);
+
+CREATE INDEX study_plan_histories_study_plan_id_idx ON study_plan_histories (study_plan_id);
+
+INSERT INTO permissions (name, description) VALUES
+    ('study_plan:review', 'Approve or reject the study plans of one''s advisees'),
+    ('study_plan:submit', 'View one''s study plans and submit them for review');
+
+INSERT INTO role_permissions (role_id, permission) VALUES
+    (2, 'study_plan:review'),
+    (3, 'study_plan:submit');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM role_permissions WHERE permission IN ('study_plan:review', 'study_plan:submit');
+DELETE FROM permissions WHERE name IN ('study_plan:review', 'study_plan:submit');
+DROP TABLE study_plan_histories;
+DROP INDEX course_registrations_study_plan_id_idx;
+ALTER TABLE course_registrations DROP COLUMN status;
+ALTER TABLE course_registrations DROP COLUMN study_plan_id;
+DROP TABLE study_plans;
+-- +goose StatementEnd
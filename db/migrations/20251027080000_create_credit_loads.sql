@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Semester GPA (indeks prestasi semester / IP) of a student, imported from the legacy system. The GPA of the
+-- previous semester decides how many credits (SKS) the student can take.
+CREATE TABLE semester_gpas (
+    id uuid not null,
+    student_id uuid not null,
+    semester_id uuid not null,
+    gpa numeric(3, 2) not null CHECK (gpa >= 0 AND gpa <= 4),
+    created_at timestamptz not null default now(),
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (student_id) REFERENCES students (id),
+    FOREIGN KEY (semester_id) REFERENCES semesters (id),
+    UNIQUE (student_id, semester_id)
+);
+
+CREATE INDEX semester_gpas_semester_id_idx ON semester_gpas (semester_id);
+
+-- Credit allowance set by an admin, it replaces the allowance derived from the previous semester GPA
+CREATE TABLE student_credit_allowances (
+    student_id uuid not null,
+    max_credits integer not null CHECK (max_credits > 0),
+    set_by uuid not null,
+    created_at timestamptz not null default now(),
+    updated_at timestamptz null,
+
+    PRIMARY KEY (student_id),
+    FOREIGN KEY (student_id) REFERENCES students (id),
+    FOREIGN KEY (set_by) REFERENCES users (id)
+);
+
+INSERT INTO permissions (name, description) VALUES
+    ('semester_gpa:import', 'Bulk import semester GPAs');
+
+INSERT INTO role_permissions (role_id, permission) VALUES
+    (1, 'semester_gpa:import');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM role_permissions WHERE permission = 'semester_gpa:import';
+DELETE FROM permissions WHERE name = 'semester_gpa:import';
+DROP TABLE student_credit_allowances;
+DROP TABLE semester_gpas;
+-- +goose StatementEnd
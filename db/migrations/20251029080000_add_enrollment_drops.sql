@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Last moment students can drop their registrations of the semester, when null the deadline is
+-- academic.drop_period_days after the start of the semester
+ALTER TABLE semesters ADD COLUMN drop_deadline timestamptz null;
+
+-- Dropped registrations are soft deleted, dropped_by is the student who dropped it or the admin who withdrew the
+-- student, the reason is only required for withdrawals
+ALTER TABLE course_registrations ADD COLUMN dropped_by uuid null REFERENCES users (id);
+ALTER TABLE course_registrations ADD COLUMN drop_reason text null;
+
+-- A student can enroll again into an offering after dropping it
+ALTER TABLE course_registrations DROP CONSTRAINT course_registrations_student_id_course_offering_id_key;
+CREATE UNIQUE INDEX course_registrations_student_id_course_offering_id_key ON course_registrations (student_id, course_offering_id)
+    WHERE deleted_at IS NULL;
+
+UPDATE permissions SET description = 'Enroll oneself into a course offering and drop it' WHERE name = 'enrollment:create';
+
+INSERT INTO permissions (name, description) VALUES
+    ('enrollment:withdraw', 'Withdraw students from course offerings with a reason');
+
+INSERT INTO role_permissions (role_id, permission) VALUES
+    (1, 'enrollment:withdraw');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM role_permissions WHERE permission = 'enrollment:withdraw';
+DELETE FROM permissions WHERE name = 'enrollment:withdraw';
+UPDATE permissions SET description = 'Enroll oneself into a course offering' WHERE name = 'enrollment:create';
+DROP INDEX course_registrations_student_id_course_offering_id_key;
+ALTER TABLE course_registrations ADD CONSTRAINT course_registrations_student_id_course_offering_id_key
+    UNIQUE (student_id, course_offering_id);
+ALTER TABLE course_registrations DROP COLUMN drop_reason;
+ALTER TABLE course_registrations DROP COLUMN dropped_by;
+ALTER TABLE semesters DROP COLUMN drop_deadline;
+-- +goose StatementEnd
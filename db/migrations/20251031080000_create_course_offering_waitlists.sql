@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Students waiting for a seat in a full course offering, served in the order of position. The position only orders
+-- the entries, the place of a student in the waitlist is the number of waiting entries of the offering up to theirs.
+-- Entries are kept once closed: enrolled when the student got a seat, left when they gave up waiting.
+CREATE TABLE course_offering_waitlists (
+    id uuid not null,
+    position bigint not null GENERATED ALWAYS AS IDENTITY,
+    course_offering_id uuid not null,
+    student_id uuid not null,
+    status varchar(16) not null default 'waiting' CHECK (status IN ('waiting', 'enrolled', 'left')),
+    course_registration_id uuid null,
+    created_at timestamptz not null default now(),
+    updated_at timestamptz null,
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (course_offering_id) REFERENCES course_offerings (id),
+    FOREIGN KEY (student_id) REFERENCES students (id),
+    FOREIGN KEY (course_registration_id) REFERENCES course_registrations (id),
+    UNIQUE (position)
+);
+
+-- A student waits at most once for an offering
+CREATE UNIQUE INDEX course_offering_waitlists_course_offering_id_student_id_key ON course_offering_waitlists (course_offering_id, student_id)
+    WHERE status = 'waiting';
+CREATE INDEX course_offering_waitlists_student_id_idx ON course_offering_waitlists (student_id);
+
+UPDATE permissions SET description = 'Enroll oneself into a course offering, drop it and wait for a seat' WHERE name = 'enrollment:create';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE permissions SET description = 'Enroll oneself into a course offering and drop it' WHERE name = 'enrollment:create';
+DROP TABLE course_offering_waitlists;
+-- +goose StatementEnd
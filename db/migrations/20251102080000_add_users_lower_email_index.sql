@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Logins look accounts up by their email case-insensitively
+CREATE INDEX users_lower_email_idx ON users (lower(email)) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX users_lower_email_idx;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Emails are unique case-insensitively among accounts that are not soft-deleted,
+-- the index on lower(email) replaces the case-sensitive one
+DROP INDEX users_lower_email_idx;
+DROP INDEX users_email_active_key;
+CREATE UNIQUE INDEX users_lower_email_active_key ON users (lower(email)) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX users_lower_email_active_key;
+CREATE UNIQUE INDEX users_email_active_key ON users (email) WHERE deleted_at IS NULL;
+CREATE INDEX users_lower_email_idx ON users (lower(email)) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
@@ -18,18 +18,38 @@ type CourseOfferingWithCourse struct {
 	SectionCode             string
 	Capacity                int32
 	CourseOfferingStartTime pgtype.Timestamptz
+	RoomID                  pgtype.UUID
 	CourseCode              string
 	CourseName              string
 	Credit                  int32
+	MinutesPerCredit        int32
+	Schedules               []generated.CourseOfferingSchedule
+	// Semester bounds, only loaded for a single offering and for the offerings of a room or a lecturer
+	SemesterStartTime pgtype.Timestamptz
+	SemesterEndTime   pgtype.Timestamptz
+	// Drop deadline of the semester, only loaded for a single offering and NULL when the policy decides
+	SemesterDropDeadline pgtype.Timestamptz
 }
 
 type StudentEnrollmentWithDetails struct {
 	RegistrationID          pgtype.UUID
 	StudentID               pgtype.UUID
 	CourseOfferingID        pgtype.UUID
+	SemesterID              pgtype.UUID
 	RegistrationCreatedAt   pgtype.Timestamptz
 	CourseOfferingStartTime pgtype.Timestamptz
 	Credit                  int32
+	MinutesPerCredit        int32
+	SemesterStartTime       pgtype.Timestamptz
+	SemesterEndTime         pgtype.Timestamptz
+	Schedules               []generated.CourseOfferingSchedule
+}
+
+// CourseOfferingScheduleAttributes is a weekly meeting of a course offering, DayOfWeek is the ISO day of the week
+// (1 is Monday) and StartTime the time of day
+type CourseOfferingScheduleAttributes struct {
+	DayOfWeek int16
+	StartTime time.Duration
 }
 
 type AcademicRepository interface {
@@ -39,22 +59,45 @@ type AcademicRepository interface {
 	GetStudentEnrollmentsWithDetails(ctx context.Context, studentID string) ([]StudentEnrollmentWithDetails, error)
 	CountCourseOfferingEnrollments(ctx context.Context, courseOfferingID string) (int64, error)
 	CheckEnrollmentExists(ctx context.Context, studentID, courseOfferingID string) (bool, error)
-	CreateEnrollment(ctx context.Context, studentID, courseOfferingID string) (generated.CourseRegistration, error)
+	CreateEnrollment(ctx context.Context, studentID, courseOfferingID, studyPlanID string) (generated.CourseRegistration, error)
 
 	// Course Offering CRUD operations
 	GetCourseOfferingsWithPagination(ctx context.Context, limit, offset int) ([]CourseOfferingWithCourse, error)
 	CountCourseOfferings(ctx context.Context) (int64, error)
-	CreateCourseOffering(ctx context.Context, semesterID, courseID, sectionCode string, capacity int32, startTime time.Time) (generated.CourseOffering, error)
-	UpdateCourseOffering(ctx context.Context, id, semesterID, courseID, sectionCode string, capacity int32, startTime time.Time) (generated.CourseOffering, error)
 	DeleteCourseOffering(ctx context.Context, id string) (generated.CourseOffering, error)
 	GetCourseOfferingByIDWithDetails(ctx context.Context, id string) (CourseOfferingWithCourse, error)
 
+	// Study program scoping, a course belongs to the study programs whose curricula contain it
+	GetCourseOfferingsByStudyProgramWithPagination(ctx context.Context, studyProgramID string, limit, offset int) ([]CourseOfferingWithCourse, error)
+	CountCourseOfferingsByStudyProgram(ctx context.Context, studyProgramID string) (int64, error)
+	IsCourseInStudyProgram(ctx context.Context, courseID, studyProgramID string) (bool, error)
+	IsCourseInActiveCurriculum(ctx context.Context, courseID string) (bool, error)
+
 	// Transaction-aware methods - these methods accept a TxContext for use within transactions
 	GetCourseOfferingWithCourseTx(txCtx *common.TxContext, id string) (CourseOfferingWithCourse, error)
 	GetStudentEnrollmentsWithDetailsTx(txCtx *common.TxContext, studentID string) ([]StudentEnrollmentWithDetails, error)
 	CountCourseOfferingEnrollmentsTx(txCtx *common.TxContext, courseOfferingID string) (int64, error)
 	CheckEnrollmentExistsTx(txCtx *common.TxContext, studentID, courseOfferingID string) (bool, error)
-	CreateEnrollmentTx(txCtx *common.TxContext, studentID, courseOfferingID string) (generated.CourseRegistration, error)
+	CreateEnrollmentTx(txCtx *common.TxContext, studentID, courseOfferingID, studyPlanID string) (generated.CourseRegistration, error)
+	GetEnrollmentTx(txCtx *common.TxContext, studentID, courseOfferingID string) (generated.CourseRegistration, error)
+	DropEnrollmentTx(txCtx *common.TxContext, id, droppedBy, reason string) (generated.CourseRegistration, error)
+	GetMissingPrerequisitesTx(txCtx *common.TxContext, studentID, courseID string, passingGrades []string) ([]generated.GetMissingPrerequisitesRow, error)
+	GetMissingCorequisitesTx(txCtx *common.TxContext, studentID, courseID, semesterID string, passingGrades []string) ([]generated.GetMissingCorequisitesRow, error)
+	GetEnrolledExcludedCoursesTx(txCtx *common.TxContext, studentID, courseID, semesterID string) ([]generated.GetEnrolledExcludedCoursesRow, error)
+
+	// Course offerings are written together with their weekly schedules, an empty room id means no room
+	CreateCourseOfferingTx(txCtx *common.TxContext, semesterID, courseID, sectionCode string, capacity int32, startTime time.Time, roomID string) (generated.CourseOffering, error)
+	UpdateCourseOfferingTx(txCtx *common.TxContext, id, semesterID, courseID, sectionCode string, capacity int32, startTime time.Time, roomID string) (generated.CourseOffering, error)
+	ReplaceCourseOfferingSchedulesTx(txCtx *common.TxContext, courseOfferingID string, schedules []CourseOfferingScheduleAttributes) error
+	GetCourseOfferingsByRoomTx(txCtx *common.TxContext, roomID string) ([]CourseOfferingWithCourse, error)
+
+	// Teaching assignments (pengampu mata kuliah), the lecturers teaching a course offering
+	ListCourseOfferingLecturers(ctx context.Context, courseOfferingID string) ([]generated.ListCourseOfferingLecturersRow, error)
+	DeleteCourseOfferingLecturer(ctx context.Context, courseOfferingID, lecturerID string) (generated.CourseOfferingLecturer, error)
+	GetCourseOfferingsByLecturer(ctx context.Context, lecturerID, semesterID string) ([]CourseOfferingWithCourse, error)
+	ListCourseOfferingLecturersTx(txCtx *common.TxContext, courseOfferingID string) ([]generated.ListCourseOfferingLecturersRow, error)
+	CreateCourseOfferingLecturerTx(txCtx *common.TxContext, id, courseOfferingID, lecturerID string) (generated.CourseOfferingLecturer, error)
+	GetCourseOfferingsByLecturerTx(txCtx *common.TxContext, lecturerID, semesterID string) ([]CourseOfferingWithCourse, error)
 }
 
 type DefaultAcademicRepository struct {
@@ -104,6 +147,11 @@ func (r *DefaultAcademicRepository) GetCourseOfferingWithCourse(ctx context.Cont
 		return CourseOfferingWithCourse{}, err
 	}
 
+	schedules, err := listSchedules(ctx, r.query, []pgtype.UUID{row.CourseOfferingID})
+	if err != nil {
+		return CourseOfferingWithCourse{}, err
+	}
+
 	return CourseOfferingWithCourse{
 		CourseOfferingID:        row.CourseOfferingID,
 		SemesterID:              row.SemesterID,
@@ -111,9 +159,15 @@ func (r *DefaultAcademicRepository) GetCourseOfferingWithCourse(ctx context.Cont
 		SectionCode:             row.SectionCode,
 		Capacity:                row.Capacity,
 		CourseOfferingStartTime: row.CourseOfferingStartTime,
+		RoomID:                  row.RoomID,
 		CourseCode:              row.CourseCode,
 		CourseName:              row.CourseName,
 		Credit:                  row.Credit,
+		MinutesPerCredit:        row.MinutesPerCredit,
+		SemesterStartTime:       row.SemesterStartTime,
+		SemesterEndTime:         row.SemesterEndTime,
+		SemesterDropDeadline:    row.SemesterDropDeadline,
+		Schedules:               schedules[row.CourseOfferingID.Bytes],
 	}, nil
 }
 
@@ -129,15 +183,29 @@ func (r *DefaultAcademicRepository) GetStudentEnrollmentsWithDetails(ctx context
 		return nil, err
 	}
 
+	courseOfferingIDs := make([]pgtype.UUID, 0, len(rows))
+	for _, row := range rows {
+		courseOfferingIDs = append(courseOfferingIDs, row.CourseOfferingID)
+	}
+	schedules, err := listSchedules(ctx, r.query, courseOfferingIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	var enrollments []StudentEnrollmentWithDetails
 	for _, row := range rows {
 		enrollments = append(enrollments, StudentEnrollmentWithDetails{
 			RegistrationID:          row.RegistrationID,
 			StudentID:               row.StudentID,
 			CourseOfferingID:        row.CourseOfferingID,
+			SemesterID:              row.SemesterID,
 			RegistrationCreatedAt:   row.RegistrationCreatedAt,
 			CourseOfferingStartTime: row.CourseOfferingStartTime,
 			Credit:                  row.Credit,
+			MinutesPerCredit:        row.MinutesPerCredit,
+			SemesterStartTime:       row.SemesterStartTime,
+			SemesterEndTime:         row.SemesterEndTime,
+			Schedules:               schedules[row.CourseOfferingID.Bytes],
 		})
 	}
 
@@ -173,8 +241,8 @@ func (r *DefaultAcademicRepository) CheckEnrollmentExists(ctx context.Context, s
 	return r.query.CheckEnrollmentExists(ctx, params)
 }
 
-func (r *DefaultAcademicRepository) CreateEnrollment(ctx context.Context, studentID, courseOfferingID string) (generated.CourseRegistration, error) {
-	var studentUUID, courseOfferingUUID pgtype.UUID
+func (r *DefaultAcademicRepository) CreateEnrollment(ctx context.Context, studentID, courseOfferingID, studyPlanID string) (generated.CourseRegistration, error) {
+	var studentUUID, courseOfferingUUID, studyPlanUUID pgtype.UUID
 	err := studentUUID.Scan(studentID)
 	if err != nil {
 		return generated.CourseRegistration{}, errors.New("can't parse student id as uuid")
@@ -183,10 +251,15 @@ func (r *DefaultAcademicRepository) CreateEnrollment(ctx context.Context, studen
 	if err != nil {
 		return generated.CourseRegistration{}, errors.New("can't parse course offering id as uuid")
 	}
+	err = studyPlanUUID.Scan(studyPlanID)
+	if err != nil {
+		return generated.CourseRegistration{}, errors.New("can't parse study plan id as uuid")
+	}
 
 	params := generated.CreateEnrollmentParams{
 		StudentID:        studentUUID,
 		CourseOfferingID: courseOfferingUUID,
+		StudyPlanID:      studyPlanUUID,
 	}
 
 	return r.query.CreateEnrollment(ctx, params)
@@ -212,6 +285,15 @@ func (r *DefaultAcademicRepository) GetCourseOfferingsWithPagination(ctx context
 		return nil, err
 	}
 
+	courseOfferingIDs := make([]pgtype.UUID, 0, len(rows))
+	for _, row := range rows {
+		courseOfferingIDs = append(courseOfferingIDs, row.CourseOfferingID)
+	}
+	schedules, err := listSchedules(ctx, r.query, courseOfferingIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	var courseOfferings []CourseOfferingWithCourse
 	for _, row := range rows {
 		courseOfferings = append(courseOfferings, CourseOfferingWithCourse{
@@ -221,9 +303,12 @@ func (r *DefaultAcademicRepository) GetCourseOfferingsWithPagination(ctx context
 			SectionCode:             row.SectionCode,
 			Capacity:                row.Capacity,
 			CourseOfferingStartTime: row.CourseOfferingStartTime,
+			RoomID:                  row.RoomID,
 			CourseCode:              row.CourseCode,
 			CourseName:              row.CourseName,
 			Credit:                  row.Credit,
+			MinutesPerCredit:        row.MinutesPerCredit,
+			Schedules:               schedules[row.CourseOfferingID.Bytes],
 		})
 	}
 
@@ -234,7 +319,7 @@ func (r *DefaultAcademicRepository) CountCourseOfferings(ctx context.Context) (i
 	return r.query.CountCourseOfferings(ctx)
 }
 
-func (r *DefaultAcademicRepository) CreateCourseOffering(ctx context.Context, semesterID, courseID, sectionCode string, capacity int32, startTime time.Time) (generated.CourseOffering, error) {
+func (r *DefaultAcademicRepository) CreateCourseOfferingTx(txCtx *common.TxContext, semesterID, courseID, sectionCode string, capacity int32, startTime time.Time, roomID string) (generated.CourseOffering, error) {
 	var semesterUUID, courseUUID pgtype.UUID
 	err := semesterUUID.Scan(semesterID)
 	if err != nil {
@@ -244,6 +329,10 @@ func (r *DefaultAcademicRepository) CreateCourseOffering(ctx context.Context, se
 	if err != nil {
 		return generated.CourseOffering{}, errors.New("can't parse course id as uuid")
 	}
+	roomUUID, err := newOptionalUUID(roomID)
+	if err != nil {
+		return generated.CourseOffering{}, errors.New("can't parse room id as uuid")
+	}
 
 	startTimePg := pgtype.Timestamptz{
 		Time:  startTime,
@@ -256,12 +345,14 @@ func (r *DefaultAcademicRepository) CreateCourseOffering(ctx context.Context, se
 		SectionCode: sectionCode,
 		Capacity:    capacity,
 		StartTime:   startTimePg,
+		RoomID:      roomUUID,
 	}
 
-	return r.query.CreateCourseOffering(ctx, params)
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.CreateCourseOffering(txCtx.Context(), params)
 }
 
-func (r *DefaultAcademicRepository) UpdateCourseOffering(ctx context.Context, id, semesterID, courseID, sectionCode string, capacity int32, startTime time.Time) (generated.CourseOffering, error) {
+func (r *DefaultAcademicRepository) UpdateCourseOfferingTx(txCtx *common.TxContext, id, semesterID, courseID, sectionCode string, capacity int32, startTime time.Time, roomID string) (generated.CourseOffering, error) {
 	var idUUID, semesterUUID, courseUUID pgtype.UUID
 	err := idUUID.Scan(id)
 	if err != nil {
@@ -275,6 +366,10 @@ func (r *DefaultAcademicRepository) UpdateCourseOffering(ctx context.Context, id
 	if err != nil {
 		return generated.CourseOffering{}, errors.New("can't parse course id as uuid")
 	}
+	roomUUID, err := newOptionalUUID(roomID)
+	if err != nil {
+		return generated.CourseOffering{}, errors.New("can't parse room id as uuid")
+	}
 
 	startTimePg := pgtype.Timestamptz{
 		Time:  startTime,
@@ -288,9 +383,11 @@ func (r *DefaultAcademicRepository) UpdateCourseOffering(ctx context.Context, id
 		SectionCode: sectionCode,
 		Capacity:    capacity,
 		StartTime:   startTimePg,
+		RoomID:      roomUUID,
 	}
 
-	return r.query.UpdateCourseOffering(ctx, params)
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.UpdateCourseOffering(txCtx.Context(), params)
 }
 
 func (r *DefaultAcademicRepository) DeleteCourseOffering(ctx context.Context, id string) (generated.CourseOffering, error) {
@@ -315,6 +412,11 @@ func (r *DefaultAcademicRepository) GetCourseOfferingByIDWithDetails(ctx context
 		return CourseOfferingWithCourse{}, err
 	}
 
+	schedules, err := listSchedules(ctx, r.query, []pgtype.UUID{row.CourseOfferingID})
+	if err != nil {
+		return CourseOfferingWithCourse{}, err
+	}
+
 	return CourseOfferingWithCourse{
 		CourseOfferingID:        row.CourseOfferingID,
 		SemesterID:              row.SemesterID,
@@ -322,12 +424,103 @@ func (r *DefaultAcademicRepository) GetCourseOfferingByIDWithDetails(ctx context
 		SectionCode:             row.SectionCode,
 		Capacity:                row.Capacity,
 		CourseOfferingStartTime: row.CourseOfferingStartTime,
+		RoomID:                  row.RoomID,
 		CourseCode:              row.CourseCode,
 		CourseName:              row.CourseName,
 		Credit:                  row.Credit,
+		MinutesPerCredit:        row.MinutesPerCredit,
+		Schedules:               schedules[row.CourseOfferingID.Bytes],
 	}, nil
 }
 
+// Study program scoping implementations
+func (r *DefaultAcademicRepository) GetCourseOfferingsByStudyProgramWithPagination(ctx context.Context, studyProgramID string, limit, offset int) ([]CourseOfferingWithCourse, error) {
+	var studyProgramUUID pgtype.UUID
+	err := studyProgramUUID.Scan(studyProgramID)
+	if err != nil {
+		return nil, errors.New("can't parse study program id as uuid")
+	}
+
+	params := generated.GetCourseOfferingsByStudyProgramWithPaginationParams{
+		StudyProgramID: studyProgramUUID,
+		Limit:          int32(limit),
+		Offset:         int32(offset),
+	}
+
+	rows, err := r.query.GetCourseOfferingsByStudyProgramWithPagination(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	courseOfferingIDs := make([]pgtype.UUID, 0, len(rows))
+	for _, row := range rows {
+		courseOfferingIDs = append(courseOfferingIDs, row.CourseOfferingID)
+	}
+	schedules, err := listSchedules(ctx, r.query, courseOfferingIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var courseOfferings []CourseOfferingWithCourse
+	for _, row := range rows {
+		courseOfferings = append(courseOfferings, CourseOfferingWithCourse{
+			CourseOfferingID:        row.CourseOfferingID,
+			SemesterID:              row.SemesterID,
+			CourseID:                row.CourseID,
+			SectionCode:             row.SectionCode,
+			Capacity:                row.Capacity,
+			CourseOfferingStartTime: row.CourseOfferingStartTime,
+			RoomID:                  row.RoomID,
+			CourseCode:              row.CourseCode,
+			CourseName:              row.CourseName,
+			Credit:                  row.Credit,
+			MinutesPerCredit:        row.MinutesPerCredit,
+			Schedules:               schedules[row.CourseOfferingID.Bytes],
+		})
+	}
+
+	return courseOfferings, nil
+}
+
+func (r *DefaultAcademicRepository) CountCourseOfferingsByStudyProgram(ctx context.Context, studyProgramID string) (int64, error) {
+	var studyProgramUUID pgtype.UUID
+	err := studyProgramUUID.Scan(studyProgramID)
+	if err != nil {
+		return 0, errors.New("can't parse study program id as uuid")
+	}
+
+	return r.query.CountCourseOfferingsByStudyProgram(ctx, studyProgramUUID)
+}
+
+func (r *DefaultAcademicRepository) IsCourseInStudyProgram(ctx context.Context, courseID, studyProgramID string) (bool, error) {
+	var courseUUID, studyProgramUUID pgtype.UUID
+	err := courseUUID.Scan(courseID)
+	if err != nil {
+		return false, errors.New("can't parse course id as uuid")
+	}
+	err = studyProgramUUID.Scan(studyProgramID)
+	if err != nil {
+		return false, errors.New("can't parse study program id as uuid")
+	}
+
+	params := generated.IsCourseInStudyProgramParams{
+		CourseID:       courseUUID,
+		StudyProgramID: studyProgramUUID,
+	}
+
+	return r.query.IsCourseInStudyProgram(ctx, params)
+}
+
+func (r *DefaultAcademicRepository) IsCourseInActiveCurriculum(ctx context.Context, courseID string) (bool, error) {
+	var courseUUID pgtype.UUID
+	err := courseUUID.Scan(courseID)
+	if err != nil {
+		return false, errors.New("can't parse course id as uuid")
+	}
+
+	return r.query.IsCourseInActiveCurriculum(ctx, courseUUID)
+}
+
 // Transaction-aware methods implementation
 
 func (r *DefaultAcademicRepository) GetCourseOfferingWithCourseTx(txCtx *common.TxContext, id string) (CourseOfferingWithCourse, error) {
@@ -344,6 +537,11 @@ func (r *DefaultAcademicRepository) GetCourseOfferingWithCourseTx(txCtx *common.
 		return CourseOfferingWithCourse{}, err
 	}
 
+	schedules, err := listSchedules(txCtx.Context(), txQueries, []pgtype.UUID{row.CourseOfferingID})
+	if err != nil {
+		return CourseOfferingWithCourse{}, err
+	}
+
 	return CourseOfferingWithCourse{
 		CourseOfferingID:        row.CourseOfferingID,
 		SemesterID:              row.SemesterID,
@@ -351,9 +549,15 @@ func (r *DefaultAcademicRepository) GetCourseOfferingWithCourseTx(txCtx *common.
 		SectionCode:             row.SectionCode,
 		Capacity:                row.Capacity,
 		CourseOfferingStartTime: row.CourseOfferingStartTime,
+		RoomID:                  row.RoomID,
 		CourseCode:              row.CourseCode,
 		CourseName:              row.CourseName,
 		Credit:                  row.Credit,
+		MinutesPerCredit:        row.MinutesPerCredit,
+		SemesterStartTime:       row.SemesterStartTime,
+		SemesterEndTime:         row.SemesterEndTime,
+		SemesterDropDeadline:    row.SemesterDropDeadline,
+		Schedules:               schedules[row.CourseOfferingID.Bytes],
 	}, nil
 }
 
@@ -370,15 +574,29 @@ func (r *DefaultAcademicRepository) GetStudentEnrollmentsWithDetailsTx(txCtx *co
 		return nil, err
 	}
 
+	courseOfferingIDs := make([]pgtype.UUID, 0, len(rows))
+	for _, row := range rows {
+		courseOfferingIDs = append(courseOfferingIDs, row.CourseOfferingID)
+	}
+	schedules, err := listSchedules(txCtx.Context(), txQueries, courseOfferingIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	var enrollments []StudentEnrollmentWithDetails
 	for _, row := range rows {
 		enrollments = append(enrollments, StudentEnrollmentWithDetails{
 			RegistrationID:          row.RegistrationID,
 			StudentID:               row.StudentID,
 			CourseOfferingID:        row.CourseOfferingID,
+			SemesterID:              row.SemesterID,
 			RegistrationCreatedAt:   row.RegistrationCreatedAt,
 			CourseOfferingStartTime: row.CourseOfferingStartTime,
 			Credit:                  row.Credit,
+			MinutesPerCredit:        row.MinutesPerCredit,
+			SemesterStartTime:       row.SemesterStartTime,
+			SemesterEndTime:         row.SemesterEndTime,
+			Schedules:               schedules[row.CourseOfferingID.Bytes],
 		})
 	}
 
@@ -416,8 +634,8 @@ func (r *DefaultAcademicRepository) CheckEnrollmentExistsTx(txCtx *common.TxCont
 	return txQueries.CheckEnrollmentExists(txCtx.Context(), params)
 }
 
-func (r *DefaultAcademicRepository) CreateEnrollmentTx(txCtx *common.TxContext, studentID, courseOfferingID string) (generated.CourseRegistration, error) {
-	var studentUUID, courseOfferingUUID pgtype.UUID
+func (r *DefaultAcademicRepository) CreateEnrollmentTx(txCtx *common.TxContext, studentID, courseOfferingID, studyPlanID string) (generated.CourseRegistration, error) {
+	var studentUUID, courseOfferingUUID, studyPlanUUID pgtype.UUID
 	err := studentUUID.Scan(studentID)
 	if err != nil {
 		return generated.CourseRegistration{}, errors.New("can't parse student id as uuid")
@@ -426,12 +644,362 @@ func (r *DefaultAcademicRepository) CreateEnrollmentTx(txCtx *common.TxContext,
 	if err != nil {
 		return generated.CourseRegistration{}, errors.New("can't parse course offering id as uuid")
 	}
+	err = studyPlanUUID.Scan(studyPlanID)
+	if err != nil {
+		return generated.CourseRegistration{}, errors.New("can't parse study plan id as uuid")
+	}
 
 	params := generated.CreateEnrollmentParams{
 		StudentID:        studentUUID,
 		CourseOfferingID: courseOfferingUUID,
+		StudyPlanID:      studyPlanUUID,
 	}
 
 	txQueries := r.query.WithTx(txCtx.Tx())
 	return txQueries.CreateEnrollment(txCtx.Context(), params)
 }
+
+// GetEnrollmentTx returns the registration of the student in the course offering that is not dropped.
+func (r *DefaultAcademicRepository) GetEnrollmentTx(txCtx *common.TxContext, studentID, courseOfferingID string) (generated.CourseRegistration, error) {
+	var studentUUID, courseOfferingUUID pgtype.UUID
+	err := studentUUID.Scan(studentID)
+	if err != nil {
+		return generated.CourseRegistration{}, errors.New("can't parse student id as uuid")
+	}
+	err = courseOfferingUUID.Scan(courseOfferingID)
+	if err != nil {
+		return generated.CourseRegistration{}, errors.New("can't parse course offering id as uuid")
+	}
+
+	params := generated.GetEnrollmentParams{
+		StudentID:        studentUUID,
+		CourseOfferingID: courseOfferingUUID,
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.GetEnrollment(txCtx.Context(), params)
+}
+
+// DropEnrollmentTx soft deletes the registration, droppedBy is the user dropping it and the reason is optional.
+func (r *DefaultAcademicRepository) DropEnrollmentTx(txCtx *common.TxContext, id, droppedBy, reason string) (generated.CourseRegistration, error) {
+	var uuidID, droppedByUUID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.CourseRegistration{}, errors.New("can't parse registration id as uuid")
+	}
+	err = droppedByUUID.Scan(droppedBy)
+	if err != nil {
+		return generated.CourseRegistration{}, errors.New("can't parse user id as uuid")
+	}
+
+	params := generated.DropEnrollmentParams{
+		ID:         uuidID,
+		DroppedBy:  droppedByUUID,
+		DropReason: pgtype.Text{String: reason, Valid: reason != ""},
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.DropEnrollment(txCtx.Context(), params)
+}
+
+// GetMissingPrerequisitesTx returns the prerequisites of the course the student has no completion with one of
+// the passing grades for.
+func (r *DefaultAcademicRepository) GetMissingPrerequisitesTx(txCtx *common.TxContext, studentID, courseID string, passingGrades []string) ([]generated.GetMissingPrerequisitesRow, error) {
+	var studentUUID, courseUUID pgtype.UUID
+	err := studentUUID.Scan(studentID)
+	if err != nil {
+		return nil, errors.New("can't parse student id as uuid")
+	}
+	err = courseUUID.Scan(courseID)
+	if err != nil {
+		return nil, errors.New("can't parse course id as uuid")
+	}
+
+	params := generated.GetMissingPrerequisitesParams{
+		CourseID:      courseUUID,
+		StudentID:     studentUUID,
+		PassingGrades: passingGrades,
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.GetMissingPrerequisites(txCtx.Context(), params)
+}
+
+// GetMissingCorequisitesTx returns the co-requisites of the course the student neither takes in the semester nor
+// passed with one of the passing grades.
+func (r *DefaultAcademicRepository) GetMissingCorequisitesTx(txCtx *common.TxContext, studentID, courseID, semesterID string, passingGrades []string) ([]generated.GetMissingCorequisitesRow, error) {
+	var studentUUID, courseUUID, semesterUUID pgtype.UUID
+	err := studentUUID.Scan(studentID)
+	if err != nil {
+		return nil, errors.New("can't parse student id as uuid")
+	}
+	err = courseUUID.Scan(courseID)
+	if err != nil {
+		return nil, errors.New("can't parse course id as uuid")
+	}
+	err = semesterUUID.Scan(semesterID)
+	if err != nil {
+		return nil, errors.New("can't parse semester id as uuid")
+	}
+
+	params := generated.GetMissingCorequisitesParams{
+		CourseID:      courseUUID,
+		StudentID:     studentUUID,
+		SemesterID:    semesterUUID,
+		PassingGrades: passingGrades,
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.GetMissingCorequisites(txCtx.Context(), params)
+}
+
+// GetEnrolledExcludedCoursesTx returns the courses the student takes in the semester that exclude the course.
+func (r *DefaultAcademicRepository) GetEnrolledExcludedCoursesTx(txCtx *common.TxContext, studentID, courseID, semesterID string) ([]generated.GetEnrolledExcludedCoursesRow, error) {
+	var studentUUID, courseUUID, semesterUUID pgtype.UUID
+	err := studentUUID.Scan(studentID)
+	if err != nil {
+		return nil, errors.New("can't parse student id as uuid")
+	}
+	err = courseUUID.Scan(courseID)
+	if err != nil {
+		return nil, errors.New("can't parse course id as uuid")
+	}
+	err = semesterUUID.Scan(semesterID)
+	if err != nil {
+		return nil, errors.New("can't parse semester id as uuid")
+	}
+
+	params := generated.GetEnrolledExcludedCoursesParams{
+		CourseID:   courseUUID,
+		StudentID:  studentUUID,
+		SemesterID: semesterUUID,
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.GetEnrolledExcludedCourses(txCtx.Context(), params)
+}
+
+// ReplaceCourseOfferingSchedulesTx replaces the weekly schedules of the course offering.
+func (r *DefaultAcademicRepository) ReplaceCourseOfferingSchedulesTx(txCtx *common.TxContext, courseOfferingID string, schedules []CourseOfferingScheduleAttributes) error {
+	var courseOfferingUUID pgtype.UUID
+	err := courseOfferingUUID.Scan(courseOfferingID)
+	if err != nil {
+		return errors.New("can't parse course offering id as uuid")
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	err = txQueries.DeleteCourseOfferingSchedules(txCtx.Context(), courseOfferingUUID)
+	if err != nil {
+		return err
+	}
+
+	for _, schedule := range schedules {
+		params := generated.CreateCourseOfferingScheduleParams{
+			CourseOfferingID: courseOfferingUUID,
+			DayOfWeek:        schedule.DayOfWeek,
+			StartTime:        pgtype.Time{Microseconds: schedule.StartTime.Microseconds(), Valid: true},
+		}
+		err = txQueries.CreateCourseOfferingSchedule(txCtx.Context(), params)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetCourseOfferingsByRoomTx returns the offerings held in the room with their weekly schedules and semester bounds.
+func (r *DefaultAcademicRepository) GetCourseOfferingsByRoomTx(txCtx *common.TxContext, roomID string) ([]CourseOfferingWithCourse, error) {
+	var roomUUID pgtype.UUID
+	err := roomUUID.Scan(roomID)
+	if err != nil {
+		return nil, errors.New("can't parse room id as uuid")
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	rows, err := txQueries.GetCourseOfferingsByRoom(txCtx.Context(), roomUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	courseOfferingIDs := make([]pgtype.UUID, 0, len(rows))
+	for _, row := range rows {
+		courseOfferingIDs = append(courseOfferingIDs, row.CourseOfferingID)
+	}
+	schedules, err := listSchedules(txCtx.Context(), txQueries, courseOfferingIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var courseOfferings []CourseOfferingWithCourse
+	for _, row := range rows {
+		courseOfferings = append(courseOfferings, CourseOfferingWithCourse{
+			CourseOfferingID:        row.CourseOfferingID,
+			SemesterID:              row.SemesterID,
+			CourseID:                row.CourseID,
+			SectionCode:             row.SectionCode,
+			Capacity:                row.Capacity,
+			CourseOfferingStartTime: row.CourseOfferingStartTime,
+			RoomID:                  row.RoomID,
+			CourseCode:              row.CourseCode,
+			CourseName:              row.CourseName,
+			Credit:                  row.Credit,
+			MinutesPerCredit:        row.MinutesPerCredit,
+			SemesterStartTime:       row.SemesterStartTime,
+			SemesterEndTime:         row.SemesterEndTime,
+			Schedules:               schedules[row.CourseOfferingID.Bytes],
+		})
+	}
+
+	return courseOfferings, nil
+}
+
+// ListCourseOfferingLecturers returns the lecturers teaching the course offering ordered by NIDN.
+func (r *DefaultAcademicRepository) ListCourseOfferingLecturers(ctx context.Context, courseOfferingID string) ([]generated.ListCourseOfferingLecturersRow, error) {
+	var courseOfferingUUID pgtype.UUID
+	err := courseOfferingUUID.Scan(courseOfferingID)
+	if err != nil {
+		return nil, errors.New("can't parse course offering id as uuid")
+	}
+
+	return r.query.ListCourseOfferingLecturers(ctx, courseOfferingUUID)
+}
+
+func (r *DefaultAcademicRepository) DeleteCourseOfferingLecturer(ctx context.Context, courseOfferingID, lecturerID string) (generated.CourseOfferingLecturer, error) {
+	var courseOfferingUUID, lecturerUUID pgtype.UUID
+	err := courseOfferingUUID.Scan(courseOfferingID)
+	if err != nil {
+		return generated.CourseOfferingLecturer{}, errors.New("can't parse course offering id as uuid")
+	}
+	err = lecturerUUID.Scan(lecturerID)
+	if err != nil {
+		return generated.CourseOfferingLecturer{}, errors.New("can't parse lecturer id as uuid")
+	}
+
+	params := generated.DeleteCourseOfferingLecturerParams{
+		CourseOfferingID: courseOfferingUUID,
+		LecturerID:       lecturerUUID,
+	}
+
+	return r.query.DeleteCourseOfferingLecturer(ctx, params)
+}
+
+// GetCourseOfferingsByLecturer returns the offerings of the semester taught by the lecturer with their weekly
+// schedules and semester bounds.
+func (r *DefaultAcademicRepository) GetCourseOfferingsByLecturer(ctx context.Context, lecturerID, semesterID string) ([]CourseOfferingWithCourse, error) {
+	return listCourseOfferingsByLecturer(ctx, r.query, lecturerID, semesterID)
+}
+
+// ListCourseOfferingLecturersTx is ListCourseOfferingLecturers within the transaction, it sees the assignments
+// committed before the statement runs and the ones written by the transaction.
+func (r *DefaultAcademicRepository) ListCourseOfferingLecturersTx(txCtx *common.TxContext, courseOfferingID string) ([]generated.ListCourseOfferingLecturersRow, error) {
+	var courseOfferingUUID pgtype.UUID
+	err := courseOfferingUUID.Scan(courseOfferingID)
+	if err != nil {
+		return nil, errors.New("can't parse course offering id as uuid")
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.ListCourseOfferingLecturers(txCtx.Context(), courseOfferingUUID)
+}
+
+func (r *DefaultAcademicRepository) CreateCourseOfferingLecturerTx(txCtx *common.TxContext, id, courseOfferingID, lecturerID string) (generated.CourseOfferingLecturer, error) {
+	var uuidID, courseOfferingUUID, lecturerUUID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.CourseOfferingLecturer{}, errors.New("can't parse teaching assignment id as uuid")
+	}
+	err = courseOfferingUUID.Scan(courseOfferingID)
+	if err != nil {
+		return generated.CourseOfferingLecturer{}, errors.New("can't parse course offering id as uuid")
+	}
+	err = lecturerUUID.Scan(lecturerID)
+	if err != nil {
+		return generated.CourseOfferingLecturer{}, errors.New("can't parse lecturer id as uuid")
+	}
+
+	params := generated.CreateCourseOfferingLecturerParams{
+		ID:               uuidID,
+		CourseOfferingID: courseOfferingUUID,
+		LecturerID:       lecturerUUID,
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.CreateCourseOfferingLecturer(txCtx.Context(), params)
+}
+
+// GetCourseOfferingsByLecturerTx is GetCourseOfferingsByLecturer within the transaction, it sees the assignments
+// written by the transaction.
+func (r *DefaultAcademicRepository) GetCourseOfferingsByLecturerTx(txCtx *common.TxContext, lecturerID, semesterID string) ([]CourseOfferingWithCourse, error) {
+	return listCourseOfferingsByLecturer(txCtx.Context(), r.query.WithTx(txCtx.Tx()), lecturerID, semesterID)
+}
+
+func listCourseOfferingsByLecturer(ctx context.Context, query *generated.Queries, lecturerID, semesterID string) ([]CourseOfferingWithCourse, error) {
+	var lecturerUUID, semesterUUID pgtype.UUID
+	err := lecturerUUID.Scan(lecturerID)
+	if err != nil {
+		return nil, errors.New("can't parse lecturer id as uuid")
+	}
+	err = semesterUUID.Scan(semesterID)
+	if err != nil {
+		return nil, errors.New("can't parse semester id as uuid")
+	}
+
+	params := generated.GetCourseOfferingsByLecturerParams{
+		LecturerID: lecturerUUID,
+		SemesterID: semesterUUID,
+	}
+	rows, err := query.GetCourseOfferingsByLecturer(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	courseOfferingIDs := make([]pgtype.UUID, 0, len(rows))
+	for _, row := range rows {
+		courseOfferingIDs = append(courseOfferingIDs, row.CourseOfferingID)
+	}
+	schedules, err := listSchedules(ctx, query, courseOfferingIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var courseOfferings []CourseOfferingWithCourse
+	for _, row := range rows {
+		courseOfferings = append(courseOfferings, CourseOfferingWithCourse{
+			CourseOfferingID:        row.CourseOfferingID,
+			SemesterID:              row.SemesterID,
+			CourseID:                row.CourseID,
+			SectionCode:             row.SectionCode,
+			Capacity:                row.Capacity,
+			CourseOfferingStartTime: row.CourseOfferingStartTime,
+			RoomID:                  row.RoomID,
+			CourseCode:              row.CourseCode,
+			CourseName:              row.CourseName,
+			Credit:                  row.Credit,
+			MinutesPerCredit:        row.MinutesPerCredit,
+			SemesterStartTime:       row.SemesterStartTime,
+			SemesterEndTime:         row.SemesterEndTime,
+			Schedules:               schedules[row.CourseOfferingID.Bytes],
+		})
+	}
+
+	return courseOfferings, nil
+}
+
+// listSchedules returns the weekly schedules of the course offerings by course offering id.
+func listSchedules(ctx context.Context, query *generated.Queries, courseOfferingIDs []pgtype.UUID) (map[[16]byte][]generated.CourseOfferingSchedule, error) {
+	schedulesByOffering := make(map[[16]byte][]generated.CourseOfferingSchedule)
+	if len(courseOfferingIDs) == 0 {
+		return schedulesByOffering, nil
+	}
+
+	schedules, err := query.ListCourseOfferingSchedules(ctx, courseOfferingIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, schedule := range schedules {
+		schedulesByOffering[schedule.CourseOfferingID.Bytes] = append(schedulesByOffering[schedule.CourseOfferingID.Bytes], schedule)
+	}
+
+	return schedulesByOffering, nil
+}
@@ -0,0 +1,245 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"siakad-poc/db/generated"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AcademicYearAttributes are the editable attributes of an academic year
+type AcademicYearAttributes struct {
+	Code      string
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// SemesterAttributes are the editable attributes of a semester, the academic year can't be changed. A nil
+// DropDeadline leaves the drop deadline to the enrollment policy.
+type SemesterAttributes struct {
+	Code         string
+	StartTime    time.Time
+	EndTime      time.Time
+	DropDeadline *time.Time
+}
+
+type AcademicCalendarRepository interface {
+	ListAcademicYears(ctx context.Context, limit, offset int) ([]generated.AcademicYear, error)
+	CountAcademicYears(ctx context.Context) (int64, error)
+	GetAcademicYear(ctx context.Context, id string) (generated.AcademicYear, error)
+	GetAcademicYearByCode(ctx context.Context, code string) (generated.AcademicYear, error)
+	CreateAcademicYear(ctx context.Context, id string, attributes AcademicYearAttributes) (generated.AcademicYear, error)
+	UpdateAcademicYear(ctx context.Context, id string, attributes AcademicYearAttributes) (generated.AcademicYear, error)
+	DeleteAcademicYear(ctx context.Context, id string) (generated.AcademicYear, error)
+
+	ListSemestersByAcademicYear(ctx context.Context, academicYearID string) ([]generated.Semester, error)
+	GetSemester(ctx context.Context, id string) (generated.Semester, error)
+	GetSemesterByCode(ctx context.Context, academicYearID, code string) (generated.Semester, error)
+	GetCurrentSemester(ctx context.Context) (generated.Semester, error)
+	CreateSemester(ctx context.Context, id, academicYearID string, attributes SemesterAttributes) (generated.Semester, error)
+	UpdateSemester(ctx context.Context, id string, attributes SemesterAttributes) (generated.Semester, error)
+	DeleteSemester(ctx context.Context, id string) (generated.Semester, error)
+	CountCourseOfferingsBySemester(ctx context.Context, semesterID string) (int64, error)
+}
+
+type DefaultAcademicCalendarRepository struct {
+	query *generated.Queries
+	pool  *pgxpool.Pool
+}
+
+// Compile time interface conformance check
+var _ AcademicCalendarRepository = (*DefaultAcademicCalendarRepository)(nil)
+
+func NewDefaultAcademicCalendarRepository(pool *pgxpool.Pool) *DefaultAcademicCalendarRepository {
+	return &DefaultAcademicCalendarRepository{
+		query: generated.New(pool),
+		pool:  pool,
+	}
+}
+
+func (r *DefaultAcademicCalendarRepository) ListAcademicYears(ctx context.Context, limit, offset int) ([]generated.AcademicYear, error) {
+	params := generated.ListAcademicYearsParams{
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	}
+
+	return r.query.ListAcademicYears(ctx, params)
+}
+
+func (r *DefaultAcademicCalendarRepository) CountAcademicYears(ctx context.Context) (int64, error) {
+	return r.query.CountAcademicYears(ctx)
+}
+
+func (r *DefaultAcademicCalendarRepository) GetAcademicYear(ctx context.Context, id string) (generated.AcademicYear, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.AcademicYear{}, errors.New("can't parse academic year id as uuid")
+	}
+
+	return r.query.GetAcademicYear(ctx, uuidID)
+}
+
+// GetAcademicYearByCode includes soft-deleted academic years, their code can't be reused.
+func (r *DefaultAcademicCalendarRepository) GetAcademicYearByCode(ctx context.Context, code string) (generated.AcademicYear, error) {
+	return r.query.GetAcademicYearByCode(ctx, code)
+}
+
+func (r *DefaultAcademicCalendarRepository) CreateAcademicYear(ctx context.Context, id string, attributes AcademicYearAttributes) (generated.AcademicYear, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.AcademicYear{}, errors.New("can't parse academic year id as uuid")
+	}
+
+	params := generated.CreateAcademicYearParams{
+		ID:        uuidID,
+		Code:      attributes.Code,
+		StartTime: pgtype.Timestamptz{Time: attributes.StartTime, Valid: true},
+		EndTime:   pgtype.Timestamptz{Time: attributes.EndTime, Valid: true},
+	}
+
+	return r.query.CreateAcademicYear(ctx, params)
+}
+
+func (r *DefaultAcademicCalendarRepository) UpdateAcademicYear(ctx context.Context, id string, attributes AcademicYearAttributes) (generated.AcademicYear, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.AcademicYear{}, errors.New("can't parse academic year id as uuid")
+	}
+
+	params := generated.UpdateAcademicYearParams{
+		ID:        uuidID,
+		Code:      attributes.Code,
+		StartTime: pgtype.Timestamptz{Time: attributes.StartTime, Valid: true},
+		EndTime:   pgtype.Timestamptz{Time: attributes.EndTime, Valid: true},
+	}
+
+	return r.query.UpdateAcademicYear(ctx, params)
+}
+
+func (r *DefaultAcademicCalendarRepository) DeleteAcademicYear(ctx context.Context, id string) (generated.AcademicYear, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.AcademicYear{}, errors.New("can't parse academic year id as uuid")
+	}
+
+	return r.query.DeleteAcademicYear(ctx, uuidID)
+}
+
+// ListSemestersByAcademicYear returns the semesters of the academic year ordered by start time.
+func (r *DefaultAcademicCalendarRepository) ListSemestersByAcademicYear(ctx context.Context, academicYearID string) ([]generated.Semester, error) {
+	var academicYearUUID pgtype.UUID
+	err := academicYearUUID.Scan(academicYearID)
+	if err != nil {
+		return nil, errors.New("can't parse academic year id as uuid")
+	}
+
+	return r.query.ListSemestersByAcademicYear(ctx, academicYearUUID)
+}
+
+func (r *DefaultAcademicCalendarRepository) GetSemester(ctx context.Context, id string) (generated.Semester, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.Semester{}, errors.New("can't parse semester id as uuid")
+	}
+
+	return r.query.GetSemester(ctx, uuidID)
+}
+
+// GetSemesterByCode includes soft-deleted semesters, their code can't be reused within the academic year.
+func (r *DefaultAcademicCalendarRepository) GetSemesterByCode(ctx context.Context, academicYearID, code string) (generated.Semester, error) {
+	var academicYearUUID pgtype.UUID
+	err := academicYearUUID.Scan(academicYearID)
+	if err != nil {
+		return generated.Semester{}, errors.New("can't parse academic year id as uuid")
+	}
+
+	params := generated.GetSemesterByCodeParams{
+		AcademicYearID: academicYearUUID,
+		Code:           code,
+	}
+
+	return r.query.GetSemesterByCode(ctx, params)
+}
+
+// GetCurrentSemester returns the semester running now, the latest started one if semesters of different
+// academic years overlap.
+func (r *DefaultAcademicCalendarRepository) GetCurrentSemester(ctx context.Context) (generated.Semester, error) {
+	return r.query.GetCurrentSemester(ctx)
+}
+
+func (r *DefaultAcademicCalendarRepository) CreateSemester(ctx context.Context, id, academicYearID string, attributes SemesterAttributes) (generated.Semester, error) {
+	var uuidID, academicYearUUID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.Semester{}, errors.New("can't parse semester id as uuid")
+	}
+	err = academicYearUUID.Scan(academicYearID)
+	if err != nil {
+		return generated.Semester{}, errors.New("can't parse academic year id as uuid")
+	}
+
+	params := generated.CreateSemesterParams{
+		ID:             uuidID,
+		AcademicYearID: academicYearUUID,
+		Code:           attributes.Code,
+		StartTime:      pgtype.Timestamptz{Time: attributes.StartTime, Valid: true},
+		EndTime:        pgtype.Timestamptz{Time: attributes.EndTime, Valid: true},
+		DropDeadline:   newOptionalTimestamptz(attributes.DropDeadline),
+	}
+
+	return r.query.CreateSemester(ctx, params)
+}
+
+func (r *DefaultAcademicCalendarRepository) UpdateSemester(ctx context.Context, id string, attributes SemesterAttributes) (generated.Semester, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.Semester{}, errors.New("can't parse semester id as uuid")
+	}
+
+	params := generated.UpdateSemesterParams{
+		ID:           uuidID,
+		Code:         attributes.Code,
+		StartTime:    pgtype.Timestamptz{Time: attributes.StartTime, Valid: true},
+		EndTime:      pgtype.Timestamptz{Time: attributes.EndTime, Valid: true},
+		DropDeadline: newOptionalTimestamptz(attributes.DropDeadline),
+	}
+
+	return r.query.UpdateSemester(ctx, params)
+}
+
+func (r *DefaultAcademicCalendarRepository) DeleteSemester(ctx context.Context, id string) (generated.Semester, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.Semester{}, errors.New("can't parse semester id as uuid")
+	}
+
+	return r.query.DeleteSemester(ctx, uuidID)
+}
+
+func (r *DefaultAcademicCalendarRepository) CountCourseOfferingsBySemester(ctx context.Context, semesterID string) (int64, error) {
+	var semesterUUID pgtype.UUID
+	err := semesterUUID.Scan(semesterID)
+	if err != nil {
+		return 0, errors.New("can't parse semester id as uuid")
+	}
+
+	return r.query.CountCourseOfferingsBySemester(ctx, semesterUUID)
+}
+
+// newOptionalTimestamptz converts a nullable timestamp column value, nil is NULL
+func newOptionalTimestamptz(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *t, Valid: true}
+}
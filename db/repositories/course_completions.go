@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"siakad-poc/common"
+	"siakad-poc/db/generated"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type NewCourseCompletion struct {
+	ID        string
+	StudentID string
+	CourseID  string
+	Grade     string
+}
+
+type CourseCompletionRepository interface {
+	GetStudentsByNIMs(ctx context.Context, nims []string) ([]generated.GetStudentsByNimsRow, error)
+	GetCoursesByCodes(ctx context.Context, codes []string) ([]generated.Course, error)
+	GetCourseCompletionsByStudents(ctx context.Context, studentIDs []string) ([]generated.CourseCompletion, error)
+
+	// Transaction-aware methods - these methods accept a TxContext for use within transactions
+	CreateCourseCompletionsTx(txCtx *common.TxContext, completions []NewCourseCompletion) (int64, error)
+}
+
+type DefaultCourseCompletionRepository struct {
+	query *generated.Queries
+	pool  *pgxpool.Pool
+}
+
+// Compile time interface conformance check
+var _ CourseCompletionRepository = (*DefaultCourseCompletionRepository)(nil)
+
+func NewDefaultCourseCompletionRepository(pool *pgxpool.Pool) *DefaultCourseCompletionRepository {
+	return &DefaultCourseCompletionRepository{
+		query: generated.New(pool),
+		pool:  pool,
+	}
+}
+
+// GetStudentsByNIMs returns the ID and NIM of the students that are not deleted.
+func (r *DefaultCourseCompletionRepository) GetStudentsByNIMs(ctx context.Context, nims []string) ([]generated.GetStudentsByNimsRow, error) {
+	return r.query.GetStudentsByNims(ctx, nims)
+}
+
+// GetCoursesByCodes returns the courses of the catalogue that are not deleted.
+func (r *DefaultCourseCompletionRepository) GetCoursesByCodes(ctx context.Context, codes []string) ([]generated.Course, error) {
+	return r.query.GetCoursesByCodes(ctx, codes)
+}
+
+func (r *DefaultCourseCompletionRepository) GetCourseCompletionsByStudents(ctx context.Context, studentIDs []string) ([]generated.CourseCompletion, error) {
+	studentUUIDs := make([]pgtype.UUID, 0, len(studentIDs))
+	for _, studentID := range studentIDs {
+		var studentUUID pgtype.UUID
+		err := studentUUID.Scan(studentID)
+		if err != nil {
+			return nil, errors.New("can't parse student id as uuid")
+		}
+		studentUUIDs = append(studentUUIDs, studentUUID)
+	}
+
+	return r.query.GetCourseCompletionsByStudents(ctx, studentUUIDs)
+}
+
+// Transaction-aware methods implementation
+
+// CreateCourseCompletionsTx inserts all completions with a single COPY, either every row is written or none.
+func (r *DefaultCourseCompletionRepository) CreateCourseCompletionsTx(txCtx *common.TxContext, completions []NewCourseCompletion) (int64, error) {
+	now := pgtype.Timestamptz{
+		Time:  time.Now(),
+		Valid: true,
+	}
+
+	params := make([]generated.CreateCourseCompletionsParams, 0, len(completions))
+	for _, completion := range completions {
+		var idUUID, studentUUID, courseUUID pgtype.UUID
+		err := idUUID.Scan(completion.ID)
+		if err != nil {
+			return 0, errors.New("can't parse course completion id as uuid")
+		}
+		err = studentUUID.Scan(completion.StudentID)
+		if err != nil {
+			return 0, errors.New("can't parse student id as uuid")
+		}
+		err = courseUUID.Scan(completion.CourseID)
+		if err != nil {
+			return 0, errors.New("can't parse course id as uuid")
+		}
+
+		params = append(params, generated.CreateCourseCompletionsParams{
+			ID:        idUUID,
+			StudentID: studentUUID,
+			CourseID:  courseUUID,
+			Grade:     completion.Grade,
+			CreatedAt: now,
+		})
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.CreateCourseCompletions(txCtx.Context(), params)
+}
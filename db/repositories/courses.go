@@ -0,0 +1,341 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"siakad-poc/db/generated"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// CourseFilter narrows down course listings, zero values mean "no filter"
+type CourseFilter struct {
+	// Search matches the course code or name, case-insensitive
+	Search string
+}
+
+// CourseAttributes are the editable attributes of a course
+type CourseAttributes struct {
+	Code   string
+	Name   string
+	Credit int
+	// MinutesPerCredit is the class time of one credit
+	MinutesPerCredit int
+}
+
+type CourseRepository interface {
+	ListCourses(ctx context.Context, filter CourseFilter, limit, offset int) ([]generated.Course, error)
+	CountCourses(ctx context.Context, filter CourseFilter) (int64, error)
+	GetCourse(ctx context.Context, id string) (generated.Course, error)
+	GetCourseByCode(ctx context.Context, code string) (generated.Course, error)
+	CreateCourse(ctx context.Context, id string, attributes CourseAttributes) (generated.Course, error)
+	UpdateCourse(ctx context.Context, id string, attributes CourseAttributes) (generated.Course, error)
+	DeleteCourse(ctx context.Context, id string) (generated.Course, error)
+	CountActiveCourseOfferingsByCourse(ctx context.Context, courseID string) (int64, error)
+
+	// Prerequisites (prasyarat mata kuliah) of a course
+	ListCoursePrerequisites(ctx context.Context, courseID string) ([]generated.ListCoursePrerequisitesRow, error)
+	CreateCoursePrerequisite(ctx context.Context, id, courseID, prerequisiteCourseID string) (generated.CoursePrerequisite, error)
+	DeleteCoursePrerequisite(ctx context.Context, courseID, prerequisiteCourseID string) (generated.CoursePrerequisite, error)
+	IsCoursePrerequisiteOf(ctx context.Context, courseID, otherCourseID string) (bool, error)
+
+	// Co-requisites, courses to take in the same semester
+	ListCourseCorequisites(ctx context.Context, courseID string) ([]generated.ListCourseCorequisitesRow, error)
+	CreateCourseCorequisite(ctx context.Context, id, courseID, corequisiteCourseID string) (generated.CourseCorequisite, error)
+	DeleteCourseCorequisite(ctx context.Context, courseID, corequisiteCourseID string) (generated.CourseCorequisite, error)
+
+	// Exclusions, courses that may never be taken in the same semester, in both directions
+	ListCourseExclusions(ctx context.Context, courseID string) ([]generated.ListCourseExclusionsRow, error)
+	CreateCourseExclusion(ctx context.Context, id, courseID, excludedCourseID string) (generated.CourseExclusion, error)
+	DeleteCourseExclusion(ctx context.Context, courseID, excludedCourseID string) (generated.CourseExclusion, error)
+}
+
+type DefaultCourseRepository struct {
+	query *generated.Queries
+	pool  *pgxpool.Pool
+}
+
+// Compile time interface conformance check
+var _ CourseRepository = (*DefaultCourseRepository)(nil)
+
+func NewDefaultCourseRepository(pool *pgxpool.Pool) *DefaultCourseRepository {
+	return &DefaultCourseRepository{
+		query: generated.New(pool),
+		pool:  pool,
+	}
+}
+
+func (r *DefaultCourseRepository) ListCourses(ctx context.Context, filter CourseFilter, limit, offset int) ([]generated.Course, error) {
+	params := generated.ListCoursesParams{
+		Search: newCourseFilterValues(filter),
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	}
+
+	return r.query.ListCourses(ctx, params)
+}
+
+func (r *DefaultCourseRepository) CountCourses(ctx context.Context, filter CourseFilter) (int64, error) {
+	return r.query.CountCourses(ctx, newCourseFilterValues(filter))
+}
+
+// GetCourse includes soft-deleted courses, callers decide whether a retired course is usable.
+func (r *DefaultCourseRepository) GetCourse(ctx context.Context, id string) (generated.Course, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.Course{}, errors.New("can't parse course id as uuid")
+	}
+
+	return r.query.GetCourse(ctx, uuidID)
+}
+
+func (r *DefaultCourseRepository) GetCourseByCode(ctx context.Context, code string) (generated.Course, error) {
+	return r.query.GetCourseByCode(ctx, code)
+}
+
+func (r *DefaultCourseRepository) CreateCourse(ctx context.Context, id string, attributes CourseAttributes) (generated.Course, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.Course{}, errors.New("can't parse course id as uuid")
+	}
+
+	params := generated.CreateCourseParams{
+		ID:               uuidID,
+		Code:             attributes.Code,
+		Name:             attributes.Name,
+		Credit:           int32(attributes.Credit),
+		MinutesPerCredit: int32(attributes.MinutesPerCredit),
+	}
+
+	return r.query.CreateCourse(ctx, params)
+}
+
+func (r *DefaultCourseRepository) UpdateCourse(ctx context.Context, id string, attributes CourseAttributes) (generated.Course, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.Course{}, errors.New("can't parse course id as uuid")
+	}
+
+	params := generated.UpdateCourseParams{
+		ID:               uuidID,
+		Code:             attributes.Code,
+		Name:             attributes.Name,
+		Credit:           int32(attributes.Credit),
+		MinutesPerCredit: int32(attributes.MinutesPerCredit),
+	}
+
+	return r.query.UpdateCourse(ctx, params)
+}
+
+func (r *DefaultCourseRepository) DeleteCourse(ctx context.Context, id string) (generated.Course, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.Course{}, errors.New("can't parse course id as uuid")
+	}
+
+	return r.query.DeleteCourse(ctx, uuidID)
+}
+
+// CountActiveCourseOfferingsByCourse counts the offerings of the course in semesters that have not ended yet.
+func (r *DefaultCourseRepository) CountActiveCourseOfferingsByCourse(ctx context.Context, courseID string) (int64, error) {
+	var courseUUID pgtype.UUID
+	err := courseUUID.Scan(courseID)
+	if err != nil {
+		return 0, errors.New("can't parse course id as uuid")
+	}
+
+	return r.query.CountActiveCourseOfferingsByCourse(ctx, courseUUID)
+}
+
+func (r *DefaultCourseRepository) ListCoursePrerequisites(ctx context.Context, courseID string) ([]generated.ListCoursePrerequisitesRow, error) {
+	var courseUUID pgtype.UUID
+	err := courseUUID.Scan(courseID)
+	if err != nil {
+		return nil, errors.New("can't parse course id as uuid")
+	}
+
+	return r.query.ListCoursePrerequisites(ctx, courseUUID)
+}
+
+func (r *DefaultCourseRepository) CreateCoursePrerequisite(ctx context.Context, id, courseID, prerequisiteCourseID string) (generated.CoursePrerequisite, error) {
+	var uuidID, courseUUID, prerequisiteCourseUUID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.CoursePrerequisite{}, errors.New("can't parse course prerequisite id as uuid")
+	}
+	err = courseUUID.Scan(courseID)
+	if err != nil {
+		return generated.CoursePrerequisite{}, errors.New("can't parse course id as uuid")
+	}
+	err = prerequisiteCourseUUID.Scan(prerequisiteCourseID)
+	if err != nil {
+		return generated.CoursePrerequisite{}, errors.New("can't parse prerequisite course id as uuid")
+	}
+
+	params := generated.CreateCoursePrerequisiteParams{
+		ID:                   uuidID,
+		CourseID:             courseUUID,
+		PrerequisiteCourseID: prerequisiteCourseUUID,
+	}
+
+	return r.query.CreateCoursePrerequisite(ctx, params)
+}
+
+func (r *DefaultCourseRepository) DeleteCoursePrerequisite(ctx context.Context, courseID, prerequisiteCourseID string) (generated.CoursePrerequisite, error) {
+	var courseUUID, prerequisiteCourseUUID pgtype.UUID
+	err := courseUUID.Scan(courseID)
+	if err != nil {
+		return generated.CoursePrerequisite{}, errors.New("can't parse course id as uuid")
+	}
+	err = prerequisiteCourseUUID.Scan(prerequisiteCourseID)
+	if err != nil {
+		return generated.CoursePrerequisite{}, errors.New("can't parse prerequisite course id as uuid")
+	}
+
+	params := generated.DeleteCoursePrerequisiteParams{
+		CourseID:             courseUUID,
+		PrerequisiteCourseID: prerequisiteCourseUUID,
+	}
+
+	return r.query.DeleteCoursePrerequisite(ctx, params)
+}
+
+// IsCoursePrerequisiteOf reports whether the course is already required by the other course, directly or
+// through a chain of prerequisites.
+func (r *DefaultCourseRepository) IsCoursePrerequisiteOf(ctx context.Context, courseID, otherCourseID string) (bool, error) {
+	var courseUUID, otherCourseUUID pgtype.UUID
+	err := courseUUID.Scan(courseID)
+	if err != nil {
+		return false, errors.New("can't parse course id as uuid")
+	}
+	err = otherCourseUUID.Scan(otherCourseID)
+	if err != nil {
+		return false, errors.New("can't parse course id as uuid")
+	}
+
+	params := generated.IsCoursePrerequisiteOfParams{
+		OtherCourseID: otherCourseUUID,
+		CourseID:      courseUUID,
+	}
+
+	return r.query.IsCoursePrerequisiteOf(ctx, params)
+}
+
+func (r *DefaultCourseRepository) ListCourseCorequisites(ctx context.Context, courseID string) ([]generated.ListCourseCorequisitesRow, error) {
+	var courseUUID pgtype.UUID
+	err := courseUUID.Scan(courseID)
+	if err != nil {
+		return nil, errors.New("can't parse course id as uuid")
+	}
+
+	return r.query.ListCourseCorequisites(ctx, courseUUID)
+}
+
+func (r *DefaultCourseRepository) CreateCourseCorequisite(ctx context.Context, id, courseID, corequisiteCourseID string) (generated.CourseCorequisite, error) {
+	var uuidID, courseUUID, corequisiteCourseUUID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.CourseCorequisite{}, errors.New("can't parse course corequisite id as uuid")
+	}
+	err = courseUUID.Scan(courseID)
+	if err != nil {
+		return generated.CourseCorequisite{}, errors.New("can't parse course id as uuid")
+	}
+	err = corequisiteCourseUUID.Scan(corequisiteCourseID)
+	if err != nil {
+		return generated.CourseCorequisite{}, errors.New("can't parse corequisite course id as uuid")
+	}
+
+	params := generated.CreateCourseCorequisiteParams{
+		ID:                  uuidID,
+		CourseID:            courseUUID,
+		CorequisiteCourseID: corequisiteCourseUUID,
+	}
+
+	return r.query.CreateCourseCorequisite(ctx, params)
+}
+
+func (r *DefaultCourseRepository) DeleteCourseCorequisite(ctx context.Context, courseID, corequisiteCourseID string) (generated.CourseCorequisite, error) {
+	var courseUUID, corequisiteCourseUUID pgtype.UUID
+	err := courseUUID.Scan(courseID)
+	if err != nil {
+		return generated.CourseCorequisite{}, errors.New("can't parse course id as uuid")
+	}
+	err = corequisiteCourseUUID.Scan(corequisiteCourseID)
+	if err != nil {
+		return generated.CourseCorequisite{}, errors.New("can't parse corequisite course id as uuid")
+	}
+
+	params := generated.DeleteCourseCorequisiteParams{
+		CourseID:            courseUUID,
+		CorequisiteCourseID: corequisiteCourseUUID,
+	}
+
+	return r.query.DeleteCourseCorequisite(ctx, params)
+}
+
+func (r *DefaultCourseRepository) ListCourseExclusions(ctx context.Context, courseID string) ([]generated.ListCourseExclusionsRow, error) {
+	var courseUUID pgtype.UUID
+	err := courseUUID.Scan(courseID)
+	if err != nil {
+		return nil, errors.New("can't parse course id as uuid")
+	}
+
+	return r.query.ListCourseExclusions(ctx, courseUUID)
+}
+
+func (r *DefaultCourseRepository) CreateCourseExclusion(ctx context.Context, id, courseID, excludedCourseID string) (generated.CourseExclusion, error) {
+	var uuidID, courseUUID, excludedCourseUUID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.CourseExclusion{}, errors.New("can't parse course exclusion id as uuid")
+	}
+	err = courseUUID.Scan(courseID)
+	if err != nil {
+		return generated.CourseExclusion{}, errors.New("can't parse course id as uuid")
+	}
+	err = excludedCourseUUID.Scan(excludedCourseID)
+	if err != nil {
+		return generated.CourseExclusion{}, errors.New("can't parse excluded course id as uuid")
+	}
+
+	params := generated.CreateCourseExclusionParams{
+		ID:               uuidID,
+		CourseID:         courseUUID,
+		ExcludedCourseID: excludedCourseUUID,
+	}
+
+	return r.query.CreateCourseExclusion(ctx, params)
+}
+
+// DeleteCourseExclusion removes the exclusion between both courses, whichever of them it was added to.
+func (r *DefaultCourseRepository) DeleteCourseExclusion(ctx context.Context, courseID, excludedCourseID string) (generated.CourseExclusion, error) {
+	var courseUUID, excludedCourseUUID pgtype.UUID
+	err := courseUUID.Scan(courseID)
+	if err != nil {
+		return generated.CourseExclusion{}, errors.New("can't parse course id as uuid")
+	}
+	err = excludedCourseUUID.Scan(excludedCourseID)
+	if err != nil {
+		return generated.CourseExclusion{}, errors.New("can't parse excluded course id as uuid")
+	}
+
+	params := generated.DeleteCourseExclusionParams{
+		CourseID:         courseUUID,
+		ExcludedCourseID: excludedCourseUUID,
+	}
+
+	return r.query.DeleteCourseExclusion(ctx, params)
+}
+
+func newCourseFilterValues(filter CourseFilter) pgtype.Text {
+	return pgtype.Text{
+		String: filter.Search,
+		Valid:  filter.Search != "",
+	}
+}
@@ -0,0 +1,191 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"siakad-poc/common"
+	"siakad-poc/db/generated"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// NewSemesterGPA is a semester GPA row for bulk inserts, the ID is generated by the caller and the GPA is a
+// decimal string such as "3.25"
+type NewSemesterGPA struct {
+	ID         string
+	StudentID  string
+	SemesterID string
+	GPA        string
+}
+
+// CreditLoadRepository holds what decides the credit load (beban SKS) of a student in a semester: the imported
+// semester GPAs and the allowances set by admins.
+type CreditLoadRepository interface {
+	GetStudentsByNIMs(ctx context.Context, nims []string) ([]generated.GetStudentsByNimsRow, error)
+	GetSemestersByAcademicYearCodes(ctx context.Context, academicYearCodes []string) ([]generated.GetSemestersByAcademicYearCodesRow, error)
+	GetSemesterGPAsByStudents(ctx context.Context, studentIDs []string) ([]generated.SemesterGpa, error)
+
+	GetStudentCreditAllowance(ctx context.Context, studentID string) (generated.StudentCreditAllowance, error)
+	SetStudentCreditAllowance(ctx context.Context, studentID string, maxCredits int32, setBy string) (generated.StudentCreditAllowance, error)
+	DeleteStudentCreditAllowance(ctx context.Context, studentID string) (generated.StudentCreditAllowance, error)
+
+	// Transaction-aware methods - these methods accept a TxContext for use within transactions
+	CreateSemesterGPAsTx(txCtx *common.TxContext, gpas []NewSemesterGPA) (int64, error)
+	GetStudentCreditAllowanceTx(txCtx *common.TxContext, studentID string) (generated.StudentCreditAllowance, error)
+	GetPreviousSemesterGPATx(txCtx *common.TxContext, studentID, semesterID string) (generated.GetPreviousSemesterGPARow, error)
+}
+
+type DefaultCreditLoadRepository struct {
+	query *generated.Queries
+	pool  *pgxpool.Pool
+}
+
+// Compile time interface conformance check
+var _ CreditLoadRepository = (*DefaultCreditLoadRepository)(nil)
+
+func NewDefaultCreditLoadRepository(pool *pgxpool.Pool) *DefaultCreditLoadRepository {
+	return &DefaultCreditLoadRepository{
+		query: generated.New(pool),
+		pool:  pool,
+	}
+}
+
+// GetStudentsByNIMs returns the ID and NIM of the students that are not deleted.
+func (r *DefaultCreditLoadRepository) GetStudentsByNIMs(ctx context.Context, nims []string) ([]generated.GetStudentsByNimsRow, error) {
+	return r.query.GetStudentsByNims(ctx, nims)
+}
+
+// GetSemestersByAcademicYearCodes returns the semesters of the academic years that are not deleted, semester codes
+// are only unique within their academic year.
+func (r *DefaultCreditLoadRepository) GetSemestersByAcademicYearCodes(ctx context.Context, academicYearCodes []string) ([]generated.GetSemestersByAcademicYearCodesRow, error) {
+	return r.query.GetSemestersByAcademicYearCodes(ctx, academicYearCodes)
+}
+
+func (r *DefaultCreditLoadRepository) GetSemesterGPAsByStudents(ctx context.Context, studentIDs []string) ([]generated.SemesterGpa, error) {
+	studentUUIDs := make([]pgtype.UUID, 0, len(studentIDs))
+	for _, studentID := range studentIDs {
+		var studentUUID pgtype.UUID
+		err := studentUUID.Scan(studentID)
+		if err != nil {
+			return nil, errors.New("can't parse student id as uuid")
+		}
+		studentUUIDs = append(studentUUIDs, studentUUID)
+	}
+
+	return r.query.GetSemesterGPAsByStudents(ctx, studentUUIDs)
+}
+
+func (r *DefaultCreditLoadRepository) GetStudentCreditAllowance(ctx context.Context, studentID string) (generated.StudentCreditAllowance, error) {
+	return getStudentCreditAllowance(ctx, r.query, studentID)
+}
+
+// SetStudentCreditAllowance creates or replaces the allowance of the student.
+func (r *DefaultCreditLoadRepository) SetStudentCreditAllowance(ctx context.Context, studentID string, maxCredits int32, setBy string) (generated.StudentCreditAllowance, error) {
+	var studentUUID, setByUUID pgtype.UUID
+	err := studentUUID.Scan(studentID)
+	if err != nil {
+		return generated.StudentCreditAllowance{}, errors.New("can't parse student id as uuid")
+	}
+	err = setByUUID.Scan(setBy)
+	if err != nil {
+		return generated.StudentCreditAllowance{}, errors.New("can't parse user id as uuid")
+	}
+
+	params := generated.UpsertStudentCreditAllowanceParams{
+		StudentID:  studentUUID,
+		MaxCredits: maxCredits,
+		SetBy:      setByUUID,
+	}
+
+	return r.query.UpsertStudentCreditAllowance(ctx, params)
+}
+
+func (r *DefaultCreditLoadRepository) DeleteStudentCreditAllowance(ctx context.Context, studentID string) (generated.StudentCreditAllowance, error) {
+	var studentUUID pgtype.UUID
+	err := studentUUID.Scan(studentID)
+	if err != nil {
+		return generated.StudentCreditAllowance{}, errors.New("can't parse student id as uuid")
+	}
+
+	return r.query.DeleteStudentCreditAllowance(ctx, studentUUID)
+}
+
+// Transaction-aware methods implementation
+
+// CreateSemesterGPAsTx inserts all GPAs with a single COPY, either every row is written or none.
+func (r *DefaultCreditLoadRepository) CreateSemesterGPAsTx(txCtx *common.TxContext, gpas []NewSemesterGPA) (int64, error) {
+	now := pgtype.Timestamptz{
+		Time:  time.Now(),
+		Valid: true,
+	}
+
+	params := make([]generated.CreateSemesterGPAsParams, 0, len(gpas))
+	for _, gpa := range gpas {
+		var idUUID, studentUUID, semesterUUID pgtype.UUID
+		err := idUUID.Scan(gpa.ID)
+		if err != nil {
+			return 0, errors.New("can't parse semester gpa id as uuid")
+		}
+		err = studentUUID.Scan(gpa.StudentID)
+		if err != nil {
+			return 0, errors.New("can't parse student id as uuid")
+		}
+		err = semesterUUID.Scan(gpa.SemesterID)
+		if err != nil {
+			return 0, errors.New("can't parse semester id as uuid")
+		}
+		var value pgtype.Numeric
+		err = value.Scan(gpa.GPA)
+		if err != nil {
+			return 0, errors.New("can't parse gpa as numeric")
+		}
+
+		params = append(params, generated.CreateSemesterGPAsParams{
+			ID:         idUUID,
+			StudentID:  studentUUID,
+			SemesterID: semesterUUID,
+			Gpa:        value,
+			CreatedAt:  now,
+		})
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.CreateSemesterGPAs(txCtx.Context(), params)
+}
+
+func (r *DefaultCreditLoadRepository) GetStudentCreditAllowanceTx(txCtx *common.TxContext, studentID string) (generated.StudentCreditAllowance, error) {
+	return getStudentCreditAllowance(txCtx.Context(), r.query.WithTx(txCtx.Tx()), studentID)
+}
+
+// GetPreviousSemesterGPATx returns the latest GPA of the student in a semester that started before the given one.
+func (r *DefaultCreditLoadRepository) GetPreviousSemesterGPATx(txCtx *common.TxContext, studentID, semesterID string) (generated.GetPreviousSemesterGPARow, error) {
+	var studentUUID, semesterUUID pgtype.UUID
+	err := studentUUID.Scan(studentID)
+	if err != nil {
+		return generated.GetPreviousSemesterGPARow{}, errors.New("can't parse student id as uuid")
+	}
+	err = semesterUUID.Scan(semesterID)
+	if err != nil {
+		return generated.GetPreviousSemesterGPARow{}, errors.New("can't parse semester id as uuid")
+	}
+
+	params := generated.GetPreviousSemesterGPAParams{
+		StudentID: studentUUID,
+		ID:        semesterUUID,
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.GetPreviousSemesterGPA(txCtx.Context(), params)
+}
+
+func getStudentCreditAllowance(ctx context.Context, query *generated.Queries, studentID string) (generated.StudentCreditAllowance, error) {
+	var studentUUID pgtype.UUID
+	err := studentUUID.Scan(studentID)
+	if err != nil {
+		return generated.StudentCreditAllowance{}, errors.New("can't parse student id as uuid")
+	}
+
+	return query.GetStudentCreditAllowance(ctx, studentUUID)
+}
@@ -0,0 +1,340 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"siakad-poc/db/generated"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// StudyProgramFilter narrows down study program listings, zero values mean "no filter"
+type StudyProgramFilter struct {
+	Level string
+}
+
+// StudyProgramAttributes are the editable attributes of a study program
+type StudyProgramAttributes struct {
+	Code  string
+	Name  string
+	Level string
+}
+
+// CurriculumFilter narrows down curriculum listings, zero values mean "no filter"
+type CurriculumFilter struct {
+	StudyProgramID string
+	IsActive       *bool
+}
+
+// CurriculumAttributes are the editable attributes of a curriculum, the study program can't be changed
+type CurriculumAttributes struct {
+	Code     string
+	Name     string
+	IsActive bool
+}
+
+type CurriculumRepository interface {
+	ListStudyPrograms(ctx context.Context, filter StudyProgramFilter, limit, offset int) ([]generated.StudyProgram, error)
+	CountStudyPrograms(ctx context.Context, filter StudyProgramFilter) (int64, error)
+	GetStudyProgram(ctx context.Context, id string) (generated.StudyProgram, error)
+	GetStudyProgramByCode(ctx context.Context, code string) (generated.StudyProgram, error)
+	CreateStudyProgram(ctx context.Context, id string, attributes StudyProgramAttributes) (generated.StudyProgram, error)
+	UpdateStudyProgram(ctx context.Context, id string, attributes StudyProgramAttributes) (generated.StudyProgram, error)
+	DeleteStudyProgram(ctx context.Context, id string) (generated.StudyProgram, error)
+	IsStudyProgramInUse(ctx context.Context, id string) (bool, error)
+
+	ListCurricula(ctx context.Context, filter CurriculumFilter, limit, offset int) ([]generated.Curriculum, error)
+	CountCurricula(ctx context.Context, filter CurriculumFilter) (int64, error)
+	GetCurriculum(ctx context.Context, id string) (generated.Curriculum, error)
+	GetCurriculumByCode(ctx context.Context, studyProgramID, code string) (generated.Curriculum, error)
+	CreateCurriculum(ctx context.Context, id, studyProgramID string, attributes CurriculumAttributes) (generated.Curriculum, error)
+	UpdateCurriculum(ctx context.Context, id string, attributes CurriculumAttributes) (generated.Curriculum, error)
+	DeleteCurriculum(ctx context.Context, id string) (generated.Curriculum, error)
+
+	// Courses assigned to a curriculum (kurikulum mata kuliah)
+	ListCurriculumCourses(ctx context.Context, curriculumID string) ([]generated.ListCurriculumCoursesRow, error)
+	CreateCurriculumCourse(ctx context.Context, id, curriculumID, courseID string) (generated.CurriculumCourse, error)
+	DeleteCurriculumCourse(ctx context.Context, curriculumID, courseID string) (generated.CurriculumCourse, error)
+}
+
+type DefaultCurriculumRepository struct {
+	query *generated.Queries
+	pool  *pgxpool.Pool
+}
+
+// Compile time interface conformance check
+var _ CurriculumRepository = (*DefaultCurriculumRepository)(nil)
+
+func NewDefaultCurriculumRepository(pool *pgxpool.Pool) *DefaultCurriculumRepository {
+	return &DefaultCurriculumRepository{
+		query: generated.New(pool),
+		pool:  pool,
+	}
+}
+
+func (r *DefaultCurriculumRepository) ListStudyPrograms(ctx context.Context, filter StudyProgramFilter, limit, offset int) ([]generated.StudyProgram, error) {
+	params := generated.ListStudyProgramsParams{
+		Level:  newStudyProgramFilterValues(filter),
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	}
+
+	return r.query.ListStudyPrograms(ctx, params)
+}
+
+func (r *DefaultCurriculumRepository) CountStudyPrograms(ctx context.Context, filter StudyProgramFilter) (int64, error) {
+	return r.query.CountStudyPrograms(ctx, newStudyProgramFilterValues(filter))
+}
+
+func (r *DefaultCurriculumRepository) GetStudyProgram(ctx context.Context, id string) (generated.StudyProgram, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.StudyProgram{}, errors.New("can't parse study program id as uuid")
+	}
+
+	return r.query.GetStudyProgram(ctx, uuidID)
+}
+
+// GetStudyProgramByCode includes soft-deleted study programs, their code can't be reused.
+func (r *DefaultCurriculumRepository) GetStudyProgramByCode(ctx context.Context, code string) (generated.StudyProgram, error) {
+	return r.query.GetStudyProgramByCode(ctx, code)
+}
+
+func (r *DefaultCurriculumRepository) CreateStudyProgram(ctx context.Context, id string, attributes StudyProgramAttributes) (generated.StudyProgram, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.StudyProgram{}, errors.New("can't parse study program id as uuid")
+	}
+
+	params := generated.CreateStudyProgramParams{
+		ID:    uuidID,
+		Code:  attributes.Code,
+		Name:  attributes.Name,
+		Level: attributes.Level,
+	}
+
+	return r.query.CreateStudyProgram(ctx, params)
+}
+
+func (r *DefaultCurriculumRepository) UpdateStudyProgram(ctx context.Context, id string, attributes StudyProgramAttributes) (generated.StudyProgram, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.StudyProgram{}, errors.New("can't parse study program id as uuid")
+	}
+
+	params := generated.UpdateStudyProgramParams{
+		ID:    uuidID,
+		Code:  attributes.Code,
+		Name:  attributes.Name,
+		Level: attributes.Level,
+	}
+
+	return r.query.UpdateStudyProgram(ctx, params)
+}
+
+func (r *DefaultCurriculumRepository) DeleteStudyProgram(ctx context.Context, id string) (generated.StudyProgram, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.StudyProgram{}, errors.New("can't parse study program id as uuid")
+	}
+
+	return r.query.DeleteStudyProgram(ctx, uuidID)
+}
+
+// IsStudyProgramInUse reports whether curricula, students, lecturers or staff users still reference the study program.
+func (r *DefaultCurriculumRepository) IsStudyProgramInUse(ctx context.Context, id string) (bool, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return false, errors.New("can't parse study program id as uuid")
+	}
+
+	return r.query.IsStudyProgramInUse(ctx, uuidID)
+}
+
+func (r *DefaultCurriculumRepository) ListCurricula(ctx context.Context, filter CurriculumFilter, limit, offset int) ([]generated.Curriculum, error) {
+	studyProgramID, isActive, err := newCurriculumFilterValues(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	params := generated.ListCurriculaParams{
+		StudyProgramID: studyProgramID,
+		IsActive:       isActive,
+		Limit:          int32(limit),
+		Offset:         int32(offset),
+	}
+
+	return r.query.ListCurricula(ctx, params)
+}
+
+func (r *DefaultCurriculumRepository) CountCurricula(ctx context.Context, filter CurriculumFilter) (int64, error) {
+	studyProgramID, isActive, err := newCurriculumFilterValues(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	params := generated.CountCurriculaParams{
+		StudyProgramID: studyProgramID,
+		IsActive:       isActive,
+	}
+
+	return r.query.CountCurricula(ctx, params)
+}
+
+func (r *DefaultCurriculumRepository) GetCurriculum(ctx context.Context, id string) (generated.Curriculum, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.Curriculum{}, errors.New("can't parse curriculum id as uuid")
+	}
+
+	return r.query.GetCurriculum(ctx, uuidID)
+}
+
+// GetCurriculumByCode includes soft-deleted curricula, their code can't be reused within the study program.
+func (r *DefaultCurriculumRepository) GetCurriculumByCode(ctx context.Context, studyProgramID, code string) (generated.Curriculum, error) {
+	var studyProgramUUID pgtype.UUID
+	err := studyProgramUUID.Scan(studyProgramID)
+	if err != nil {
+		return generated.Curriculum{}, errors.New("can't parse study program id as uuid")
+	}
+
+	params := generated.GetCurriculumByCodeParams{
+		StudyProgramID: studyProgramUUID,
+		Code:           code,
+	}
+
+	return r.query.GetCurriculumByCode(ctx, params)
+}
+
+func (r *DefaultCurriculumRepository) CreateCurriculum(ctx context.Context, id, studyProgramID string, attributes CurriculumAttributes) (generated.Curriculum, error) {
+	var idUUID, studyProgramUUID pgtype.UUID
+	err := idUUID.Scan(id)
+	if err != nil {
+		return generated.Curriculum{}, errors.New("can't parse curriculum id as uuid")
+	}
+	err = studyProgramUUID.Scan(studyProgramID)
+	if err != nil {
+		return generated.Curriculum{}, errors.New("can't parse study program id as uuid")
+	}
+
+	params := generated.CreateCurriculumParams{
+		ID:             idUUID,
+		StudyProgramID: studyProgramUUID,
+		Code:           attributes.Code,
+		Name:           attributes.Name,
+		IsActive:       attributes.IsActive,
+	}
+
+	return r.query.CreateCurriculum(ctx, params)
+}
+
+func (r *DefaultCurriculumRepository) UpdateCurriculum(ctx context.Context, id string, attributes CurriculumAttributes) (generated.Curriculum, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.Curriculum{}, errors.New("can't parse curriculum id as uuid")
+	}
+
+	params := generated.UpdateCurriculumParams{
+		ID:       uuidID,
+		Code:     attributes.Code,
+		Name:     attributes.Name,
+		IsActive: attributes.IsActive,
+	}
+
+	return r.query.UpdateCurriculum(ctx, params)
+}
+
+func (r *DefaultCurriculumRepository) DeleteCurriculum(ctx context.Context, id string) (generated.Curriculum, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.Curriculum{}, errors.New("can't parse curriculum id as uuid")
+	}
+
+	return r.query.DeleteCurriculum(ctx, uuidID)
+}
+
+func (r *DefaultCurriculumRepository) ListCurriculumCourses(ctx context.Context, curriculumID string) ([]generated.ListCurriculumCoursesRow, error) {
+	var curriculumUUID pgtype.UUID
+	err := curriculumUUID.Scan(curriculumID)
+	if err != nil {
+		return nil, errors.New("can't parse curriculum id as uuid")
+	}
+
+	return r.query.ListCurriculumCourses(ctx, curriculumUUID)
+}
+
+func (r *DefaultCurriculumRepository) CreateCurriculumCourse(ctx context.Context, id, curriculumID, courseID string) (generated.CurriculumCourse, error) {
+	var idUUID, curriculumUUID, courseUUID pgtype.UUID
+	err := idUUID.Scan(id)
+	if err != nil {
+		return generated.CurriculumCourse{}, errors.New("can't parse curriculum course id as uuid")
+	}
+	err = curriculumUUID.Scan(curriculumID)
+	if err != nil {
+		return generated.CurriculumCourse{}, errors.New("can't parse curriculum id as uuid")
+	}
+	err = courseUUID.Scan(courseID)
+	if err != nil {
+		return generated.CurriculumCourse{}, errors.New("can't parse course id as uuid")
+	}
+
+	params := generated.CreateCurriculumCourseParams{
+		ID:           idUUID,
+		CurriculumID: curriculumUUID,
+		CourseID:     courseUUID,
+	}
+
+	return r.query.CreateCurriculumCourse(ctx, params)
+}
+
+func (r *DefaultCurriculumRepository) DeleteCurriculumCourse(ctx context.Context, curriculumID, courseID string) (generated.CurriculumCourse, error) {
+	var curriculumUUID, courseUUID pgtype.UUID
+	err := curriculumUUID.Scan(curriculumID)
+	if err != nil {
+		return generated.CurriculumCourse{}, errors.New("can't parse curriculum id as uuid")
+	}
+	err = courseUUID.Scan(courseID)
+	if err != nil {
+		return generated.CurriculumCourse{}, errors.New("can't parse course id as uuid")
+	}
+
+	params := generated.DeleteCurriculumCourseParams{
+		CurriculumID: curriculumUUID,
+		CourseID:     courseUUID,
+	}
+
+	return r.query.DeleteCurriculumCourse(ctx, params)
+}
+
+func newStudyProgramFilterValues(filter StudyProgramFilter) pgtype.Text {
+	return pgtype.Text{
+		String: filter.Level,
+		Valid:  filter.Level != "",
+	}
+}
+
+func newCurriculumFilterValues(filter CurriculumFilter) (pgtype.UUID, pgtype.Bool, error) {
+	studyProgramUUID, err := newOptionalUUID(filter.StudyProgramID)
+	if err != nil {
+		return pgtype.UUID{}, pgtype.Bool{}, errors.New("can't parse study program id as uuid")
+	}
+
+	var isActive pgtype.Bool
+	if filter.IsActive != nil {
+		isActive = pgtype.Bool{
+			Bool:  *filter.IsActive,
+			Valid: true,
+		}
+	}
+
+	return studyProgramUUID, isActive, nil
+}
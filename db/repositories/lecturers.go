@@ -0,0 +1,219 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"siakad-poc/common"
+	"siakad-poc/db/generated"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// LecturerFilter narrows down lecturer listings, zero values mean "no filter"
+type LecturerFilter struct {
+	HomebaseStudyProgramID string
+	NIDN                   string // case-insensitive substring match
+}
+
+// LecturerAttributes are the editable attributes of a lecturer, an empty NUPTK is stored as NULL
+type LecturerAttributes struct {
+	HomebaseStudyProgramID string
+	NIDN                   string
+	NUPTK                  string
+}
+
+type LecturerRepository interface {
+	GetLecturerProfileByUserID(ctx context.Context, userID string) (generated.GetLecturerProfileByUserIDRow, error)
+
+	ListLecturers(ctx context.Context, filter LecturerFilter, limit, offset int) ([]generated.Lecturer, error)
+	CountLecturers(ctx context.Context, filter LecturerFilter) (int64, error)
+	GetLecturer(ctx context.Context, id string) (generated.Lecturer, error)
+	GetLecturerByNIDN(ctx context.Context, nidn string) (generated.Lecturer, error)
+	GetLecturerByUserID(ctx context.Context, userID string) (generated.Lecturer, error)
+	CreateLecturer(ctx context.Context, id, userID string, attributes LecturerAttributes) (generated.Lecturer, error)
+	UpdateLecturer(ctx context.Context, id string, attributes LecturerAttributes) (generated.Lecturer, error)
+	DeleteLecturer(ctx context.Context, id string) (generated.Lecturer, error)
+
+	// Transaction-aware methods - these methods accept a TxContext for use within transactions
+	LockLecturersTx(txCtx *common.TxContext, ids []string) error
+}
+
+type DefaultLecturerRepository struct {
+	query *generated.Queries
+	pool  *pgxpool.Pool
+}
+
+// Compile time interface conformance check
+var _ LecturerRepository = (*DefaultLecturerRepository)(nil)
+
+func NewDefaultLecturerRepository(pool *pgxpool.Pool) *DefaultLecturerRepository {
+	return &DefaultLecturerRepository{
+		query: generated.New(pool),
+		pool:  pool,
+	}
+}
+
+// GetLecturerProfileByUserID returns the lecturer record of the user with its homebase study program.
+func (r *DefaultLecturerRepository) GetLecturerProfileByUserID(ctx context.Context, userID string) (generated.GetLecturerProfileByUserIDRow, error) {
+	var userUUID pgtype.UUID
+	err := userUUID.Scan(userID)
+	if err != nil {
+		return generated.GetLecturerProfileByUserIDRow{}, errors.New("can't parse user id as uuid")
+	}
+
+	return r.query.GetLecturerProfileByUserID(ctx, userUUID)
+}
+
+func (r *DefaultLecturerRepository) ListLecturers(ctx context.Context, filter LecturerFilter, limit, offset int) ([]generated.Lecturer, error) {
+	homebaseStudyProgramID, nidn, err := newLecturerFilterValues(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	params := generated.ListLecturersParams{
+		HomebaseStudyProgramID: homebaseStudyProgramID,
+		Nidn:                   nidn,
+		Limit:                  int32(limit),
+		Offset:                 int32(offset),
+	}
+
+	return r.query.ListLecturers(ctx, params)
+}
+
+func (r *DefaultLecturerRepository) CountLecturers(ctx context.Context, filter LecturerFilter) (int64, error) {
+	homebaseStudyProgramID, nidn, err := newLecturerFilterValues(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	params := generated.CountLecturersParams{
+		HomebaseStudyProgramID: homebaseStudyProgramID,
+		Nidn:                   nidn,
+	}
+
+	return r.query.CountLecturers(ctx, params)
+}
+
+func (r *DefaultLecturerRepository) GetLecturer(ctx context.Context, id string) (generated.Lecturer, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.Lecturer{}, errors.New("can't parse lecturer id as uuid")
+	}
+
+	return r.query.GetLecturer(ctx, uuidID)
+}
+
+// GetLecturerByNIDN includes soft-deleted lecturers, their NIDN can't be reused.
+func (r *DefaultLecturerRepository) GetLecturerByNIDN(ctx context.Context, nidn string) (generated.Lecturer, error) {
+	return r.query.GetLecturerByNIDN(ctx, nidn)
+}
+
+// GetLecturerByUserID includes soft-deleted lecturers, callers check DeletedAt when they need an active record.
+func (r *DefaultLecturerRepository) GetLecturerByUserID(ctx context.Context, userID string) (generated.Lecturer, error) {
+	var userUUID pgtype.UUID
+	err := userUUID.Scan(userID)
+	if err != nil {
+		return generated.Lecturer{}, errors.New("can't parse user id as uuid")
+	}
+
+	return r.query.GetLecturerByUserID(ctx, userUUID)
+}
+
+func (r *DefaultLecturerRepository) CreateLecturer(ctx context.Context, id, userID string, attributes LecturerAttributes) (generated.Lecturer, error) {
+	var idUUID, userUUID, homebaseStudyProgramUUID pgtype.UUID
+	err := idUUID.Scan(id)
+	if err != nil {
+		return generated.Lecturer{}, errors.New("can't parse lecturer id as uuid")
+	}
+	err = userUUID.Scan(userID)
+	if err != nil {
+		return generated.Lecturer{}, errors.New("can't parse user id as uuid")
+	}
+	err = homebaseStudyProgramUUID.Scan(attributes.HomebaseStudyProgramID)
+	if err != nil {
+		return generated.Lecturer{}, errors.New("can't parse homebase study program id as uuid")
+	}
+
+	params := generated.CreateLecturerParams{
+		ID:                     idUUID,
+		UserID:                 userUUID,
+		HomebaseStudyProgramID: homebaseStudyProgramUUID,
+		Nidn:                   attributes.NIDN,
+		Nuptk: pgtype.Text{
+			String: attributes.NUPTK,
+			Valid:  attributes.NUPTK != "",
+		},
+	}
+
+	return r.query.CreateLecturer(ctx, params)
+}
+
+func (r *DefaultLecturerRepository) UpdateLecturer(ctx context.Context, id string, attributes LecturerAttributes) (generated.Lecturer, error) {
+	var idUUID, homebaseStudyProgramUUID pgtype.UUID
+	err := idUUID.Scan(id)
+	if err != nil {
+		return generated.Lecturer{}, errors.New("can't parse lecturer id as uuid")
+	}
+	err = homebaseStudyProgramUUID.Scan(attributes.HomebaseStudyProgramID)
+	if err != nil {
+		return generated.Lecturer{}, errors.New("can't parse homebase study program id as uuid")
+	}
+
+	params := generated.UpdateLecturerParams{
+		ID:   idUUID,
+		Nidn: attributes.NIDN,
+		Nuptk: pgtype.Text{
+			String: attributes.NUPTK,
+			Valid:  attributes.NUPTK != "",
+		},
+		HomebaseStudyProgramID: homebaseStudyProgramUUID,
+	}
+
+	return r.query.UpdateLecturer(ctx, params)
+}
+
+func (r *DefaultLecturerRepository) DeleteLecturer(ctx context.Context, id string) (generated.Lecturer, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.Lecturer{}, errors.New("can't parse lecturer id as uuid")
+	}
+
+	return r.query.DeleteLecturer(ctx, uuidID)
+}
+
+// Transaction-aware methods implementation
+
+// LockLecturersTx locks the active lecturers until the transaction ends, always in id order whatever the order of
+// ids.
+func (r *DefaultLecturerRepository) LockLecturersTx(txCtx *common.TxContext, ids []string) error {
+	uuidIDs := make([]pgtype.UUID, 0, len(ids))
+	for _, id := range ids {
+		var uuidID pgtype.UUID
+		err := uuidID.Scan(id)
+		if err != nil {
+			return errors.New("can't parse lecturer id as uuid")
+		}
+		uuidIDs = append(uuidIDs, uuidID)
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	_, err := txQueries.ListLecturersForUpdate(txCtx.Context(), uuidIDs)
+	return err
+}
+
+func newLecturerFilterValues(filter LecturerFilter) (pgtype.UUID, pgtype.Text, error) {
+	homebaseStudyProgramUUID, err := newOptionalUUID(filter.HomebaseStudyProgramID)
+	if err != nil {
+		return pgtype.UUID{}, pgtype.Text{}, errors.New("can't parse homebase study program id as uuid")
+	}
+
+	nidn := pgtype.Text{
+		String: filter.NIDN,
+		Valid:  filter.NIDN != "",
+	}
+
+	return homebaseStudyProgramUUID, nidn, nil
+}
@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"context"
+	"siakad-poc/db/generated"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Login failures are tracked per account (keyed by the lowercased email, whether or not the account exists)
+// and per client IP
+const (
+	LoginThrottleScopeAccount = "account"
+	LoginThrottleScopeIP      = "ip"
+)
+
+// LoginThrottleAccountKey normalizes an email into the key of its account throttle.
+func LoginThrottleAccountKey(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+type LoginThrottleRepository interface {
+	GetLoginThrottle(ctx context.Context, scope, key string) (generated.LoginThrottle, error)
+
+	// RecordLoginFailure counts a failed attempt, the count restarts when the previous failure is older than windowStart.
+	RecordLoginFailure(ctx context.Context, scope, key string, failedAt, windowStart time.Time) (generated.LoginThrottle, error)
+	LockLoginThrottle(ctx context.Context, scope, key string, lockedUntil time.Time) error
+	DeleteLoginThrottle(ctx context.Context, scope, key string) error
+}
+
+type DefaultLoginThrottleRepository struct {
+	query *generated.Queries
+	pool  *pgxpool.Pool
+}
+
+// Compile time interface conformance check
+var _ LoginThrottleRepository = (*DefaultLoginThrottleRepository)(nil)
+
+func NewDefaultLoginThrottleRepository(pool *pgxpool.Pool) *DefaultLoginThrottleRepository {
+	return &DefaultLoginThrottleRepository{
+		query: generated.New(pool),
+		pool:  pool,
+	}
+}
+
+func (r *DefaultLoginThrottleRepository) GetLoginThrottle(ctx context.Context, scope, key string) (generated.LoginThrottle, error) {
+	return r.query.GetLoginThrottle(ctx, generated.GetLoginThrottleParams{
+		Scope: scope,
+		Key:   key,
+	})
+}
+
+func (r *DefaultLoginThrottleRepository) RecordLoginFailure(ctx context.Context, scope, key string, failedAt, windowStart time.Time) (generated.LoginThrottle, error) {
+	return r.query.RecordLoginFailure(ctx, generated.RecordLoginFailureParams{
+		Scope: scope,
+		Key:   key,
+		FailedAt: pgtype.Timestamptz{
+			Time:  failedAt,
+			Valid: true,
+		},
+		WindowStart: pgtype.Timestamptz{
+			Time:  windowStart,
+			Valid: true,
+		},
+	})
+}
+
+func (r *DefaultLoginThrottleRepository) LockLoginThrottle(ctx context.Context, scope, key string, lockedUntil time.Time) error {
+	return r.query.LockLoginThrottle(ctx, generated.LockLoginThrottleParams{
+		Scope: scope,
+		Key:   key,
+		LockedUntil: pgtype.Timestamptz{
+			Time:  lockedUntil,
+			Valid: true,
+		},
+	})
+}
+
+func (r *DefaultLoginThrottleRepository) DeleteLoginThrottle(ctx context.Context, scope, key string) error {
+	return r.query.DeleteLoginThrottle(ctx, generated.DeleteLoginThrottleParams{
+		Scope: scope,
+		Key:   key,
+	})
+}
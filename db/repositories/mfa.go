@@ -0,0 +1,179 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"siakad-poc/common"
+	"siakad-poc/db/generated"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type MFARepository interface {
+	GetUserMFA(ctx context.Context, userID string) (generated.UserMfa, error)
+
+	// StartUserMFAEnrollment stores a new unconfirmed secret, it returns pgx.ErrNoRows when MFA is already confirmed.
+	StartUserMFAEnrollment(ctx context.Context, userID, secret string) (generated.UserMfa, error)
+
+	// UseUserMFAStep records the time step of an accepted code, it returns pgx.ErrNoRows when the step was already used.
+	UseUserMFAStep(ctx context.Context, userID string, step int64) (generated.UserMfa, error)
+	UseMFARecoveryCode(ctx context.Context, userID, codeHash string) (generated.MfaRecoveryCode, error)
+	CountUnusedMFARecoveryCodes(ctx context.Context, userID string) (int64, error)
+
+	// Transaction-aware methods - these methods accept a TxContext for use within transactions
+	ConfirmUserMFATx(txCtx *common.TxContext, userID string, step int64) (generated.UserMfa, error)
+	ReplaceMFARecoveryCodesTx(txCtx *common.TxContext, userID string, codeHashes []string) error
+	DeleteUserMFATx(txCtx *common.TxContext, userID string) error
+}
+
+type DefaultMFARepository struct {
+	query *generated.Queries
+	pool  *pgxpool.Pool
+}
+
+// Compile time interface conformance check
+var _ MFARepository = (*DefaultMFARepository)(nil)
+
+func NewDefaultMFARepository(pool *pgxpool.Pool) *DefaultMFARepository {
+	return &DefaultMFARepository{
+		query: generated.New(pool),
+		pool:  pool,
+	}
+}
+
+func (r *DefaultMFARepository) GetUserMFA(ctx context.Context, userID string) (generated.UserMfa, error) {
+	var userUUID pgtype.UUID
+	err := userUUID.Scan(userID)
+	if err != nil {
+		return generated.UserMfa{}, errors.New("can't parse user id as uuid")
+	}
+
+	return r.query.GetUserMFA(ctx, userUUID)
+}
+
+func (r *DefaultMFARepository) StartUserMFAEnrollment(ctx context.Context, userID, secret string) (generated.UserMfa, error) {
+	var userUUID pgtype.UUID
+	err := userUUID.Scan(userID)
+	if err != nil {
+		return generated.UserMfa{}, errors.New("can't parse user id as uuid")
+	}
+
+	return r.query.UpsertPendingUserMFA(ctx, generated.UpsertPendingUserMFAParams{
+		UserID: userUUID,
+		Secret: secret,
+	})
+}
+
+func (r *DefaultMFARepository) UseUserMFAStep(ctx context.Context, userID string, step int64) (generated.UserMfa, error) {
+	params, err := newUseUserMFAStepParams(userID, step)
+	if err != nil {
+		return generated.UserMfa{}, err
+	}
+
+	return r.query.UseUserMFAStep(ctx, params)
+}
+
+func (r *DefaultMFARepository) UseMFARecoveryCode(ctx context.Context, userID, codeHash string) (generated.MfaRecoveryCode, error) {
+	var userUUID pgtype.UUID
+	err := userUUID.Scan(userID)
+	if err != nil {
+		return generated.MfaRecoveryCode{}, errors.New("can't parse user id as uuid")
+	}
+
+	return r.query.UseMFARecoveryCode(ctx, generated.UseMFARecoveryCodeParams{
+		UserID:   userUUID,
+		CodeHash: codeHash,
+	})
+}
+
+func (r *DefaultMFARepository) CountUnusedMFARecoveryCodes(ctx context.Context, userID string) (int64, error) {
+	var userUUID pgtype.UUID
+	err := userUUID.Scan(userID)
+	if err != nil {
+		return 0, errors.New("can't parse user id as uuid")
+	}
+
+	return r.query.CountUnusedMFARecoveryCodes(ctx, userUUID)
+}
+
+// Transaction-aware methods implementation
+
+func (r *DefaultMFARepository) ConfirmUserMFATx(txCtx *common.TxContext, userID string, step int64) (generated.UserMfa, error) {
+	var userUUID pgtype.UUID
+	err := userUUID.Scan(userID)
+	if err != nil {
+		return generated.UserMfa{}, errors.New("can't parse user id as uuid")
+	}
+
+	params := generated.ConfirmUserMFAParams{
+		UserID: userUUID,
+		LastUsedStep: pgtype.Int8{
+			Int64: step,
+			Valid: true,
+		},
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.ConfirmUserMFA(txCtx.Context(), params)
+}
+
+// ReplaceMFARecoveryCodesTx discards every recovery code of the user, used or not, and stores the new ones.
+func (r *DefaultMFARepository) ReplaceMFARecoveryCodesTx(txCtx *common.TxContext, userID string, codeHashes []string) error {
+	var userUUID pgtype.UUID
+	err := userUUID.Scan(userID)
+	if err != nil {
+		return errors.New("can't parse user id as uuid")
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	err = txQueries.DeleteMFARecoveryCodes(txCtx.Context(), userUUID)
+	if err != nil {
+		return err
+	}
+
+	for _, codeHash := range codeHashes {
+		err = txQueries.CreateMFARecoveryCode(txCtx.Context(), generated.CreateMFARecoveryCodeParams{
+			UserID:   userUUID,
+			CodeHash: codeHash,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteUserMFATx removes the TOTP secret and the recovery codes of the user.
+func (r *DefaultMFARepository) DeleteUserMFATx(txCtx *common.TxContext, userID string) error {
+	var userUUID pgtype.UUID
+	err := userUUID.Scan(userID)
+	if err != nil {
+		return errors.New("can't parse user id as uuid")
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	err = txQueries.DeleteMFARecoveryCodes(txCtx.Context(), userUUID)
+	if err != nil {
+		return err
+	}
+
+	return txQueries.DeleteUserMFA(txCtx.Context(), userUUID)
+}
+
+func newUseUserMFAStepParams(userID string, step int64) (generated.UseUserMFAStepParams, error) {
+	var userUUID pgtype.UUID
+	err := userUUID.Scan(userID)
+	if err != nil {
+		return generated.UseUserMFAStepParams{}, errors.New("can't parse user id as uuid")
+	}
+
+	return generated.UseUserMFAStepParams{
+		UserID: userUUID,
+		LastUsedStep: pgtype.Int8{
+			Int64: step,
+			Valid: true,
+		},
+	}, nil
+}
@@ -0,0 +1,150 @@
+package repositories
+
+import (
+	"context"
+	"math/big"
+	"siakad-poc/common"
+	"siakad-poc/db/generated"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PermissionRepository interface {
+	ListPermissions(ctx context.Context) ([]generated.Permission, error)
+	ListRoles(ctx context.Context) ([]generated.Role, error)
+	GetRole(ctx context.Context, id int64) (generated.Role, error)
+	CreateRole(ctx context.Context, id int64, name string) (generated.Role, error)
+	ListRolePermissions(ctx context.Context) ([]generated.RolePermission, error)
+
+	// HasPermission reports whether the role is granted the permission, it is checked on every
+	// protected request so the mapping is served from an in-process cache.
+	HasPermission(ctx context.Context, role int64, permission string) (bool, error)
+
+	// InvalidatePermissionCache drops the cached mapping, to be called once a change is committed
+	InvalidatePermissionCache()
+
+	// Transaction-aware methods - these methods accept a TxContext for use within transactions
+	ReplaceRolePermissionsTx(txCtx *common.TxContext, roleID int64, permissions []string) error
+}
+
+// DefaultPermissionRepository caches the whole role to permission mapping, it only has a few dozen rows.
+// Changes made through this instance are visible immediately; changes made by other instances become
+// visible once the cache expires.
+type DefaultPermissionRepository struct {
+	query    *generated.Queries
+	pool     *pgxpool.Pool
+	cacheTTL time.Duration
+
+	mu              sync.RWMutex
+	rolePermissions map[int64]map[string]bool
+	cachedUntil     time.Time
+}
+
+// Compile time interface conformance check
+var _ PermissionRepository = (*DefaultPermissionRepository)(nil)
+
+func NewDefaultPermissionRepository(pool *pgxpool.Pool, cacheTTL time.Duration) *DefaultPermissionRepository {
+	return &DefaultPermissionRepository{
+		query:    generated.New(pool),
+		pool:     pool,
+		cacheTTL: cacheTTL,
+	}
+}
+
+func (r *DefaultPermissionRepository) ListPermissions(ctx context.Context) ([]generated.Permission, error) {
+	return r.query.ListPermissions(ctx)
+}
+
+func (r *DefaultPermissionRepository) ListRoles(ctx context.Context) ([]generated.Role, error) {
+	return r.query.ListRoles(ctx)
+}
+
+func (r *DefaultPermissionRepository) GetRole(ctx context.Context, id int64) (generated.Role, error) {
+	return r.query.GetRole(ctx, newRoleNumeric(id))
+}
+
+func (r *DefaultPermissionRepository) CreateRole(ctx context.Context, id int64, name string) (generated.Role, error) {
+	params := generated.CreateRoleParams{
+		ID:   newRoleNumeric(id),
+		Name: name,
+	}
+
+	return r.query.CreateRole(ctx, params)
+}
+
+func (r *DefaultPermissionRepository) ListRolePermissions(ctx context.Context) ([]generated.RolePermission, error) {
+	return r.query.ListRolePermissions(ctx)
+}
+
+func (r *DefaultPermissionRepository) HasPermission(ctx context.Context, role int64, permission string) (bool, error) {
+	now := time.Now()
+
+	r.mu.RLock()
+	rolePermissions, cachedUntil := r.rolePermissions, r.cachedUntil
+	r.mu.RUnlock()
+	if rolePermissions != nil && now.Before(cachedUntil) {
+		return rolePermissions[role][permission], nil
+	}
+
+	rows, err := r.query.ListRolePermissions(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	rolePermissions = make(map[int64]map[string]bool)
+	for _, row := range rows {
+		roleID := row.RoleID.Int.Int64()
+		if rolePermissions[roleID] == nil {
+			rolePermissions[roleID] = make(map[string]bool)
+		}
+		rolePermissions[roleID][row.Permission] = true
+	}
+
+	r.mu.Lock()
+	r.rolePermissions = rolePermissions
+	r.cachedUntil = now.Add(r.cacheTTL)
+	r.mu.Unlock()
+
+	return rolePermissions[role][permission], nil
+}
+
+func (r *DefaultPermissionRepository) InvalidatePermissionCache() {
+	r.mu.Lock()
+	r.rolePermissions = nil
+	r.mu.Unlock()
+}
+
+// Transaction-aware methods implementation
+
+func (r *DefaultPermissionRepository) ReplaceRolePermissionsTx(txCtx *common.TxContext, roleID int64, permissions []string) error {
+	queryTx := r.query.WithTx(txCtx.Tx())
+	role := newRoleNumeric(roleID)
+
+	err := queryTx.DeleteRolePermissions(txCtx.Context(), role)
+	if err != nil {
+		return err
+	}
+
+	for _, permission := range permissions {
+		params := generated.CreateRolePermissionParams{
+			RoleID:     role,
+			Permission: permission,
+		}
+		err = queryTx.CreateRolePermission(txCtx.Context(), params)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newRoleNumeric(role int64) pgtype.Numeric {
+	return pgtype.Numeric{
+		Int:   big.NewInt(role),
+		Valid: true,
+	}
+}
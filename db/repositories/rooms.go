@@ -0,0 +1,304 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"siakad-poc/common"
+	"siakad-poc/db/generated"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// BuildingAttributes are the editable attributes of a building
+type BuildingAttributes struct {
+	Code string
+	Name string
+}
+
+// RoomAttributes are the editable attributes of a room, the building can't be changed
+type RoomAttributes struct {
+	Code     string
+	Name     string
+	Capacity int
+}
+
+// RoomFilter narrows down room listings, zero values are ignored
+type RoomFilter struct {
+	BuildingID  string
+	MinCapacity int
+}
+
+type RoomRepository interface {
+	ListBuildings(ctx context.Context, limit, offset int) ([]generated.Building, error)
+	CountBuildings(ctx context.Context) (int64, error)
+	GetBuilding(ctx context.Context, id string) (generated.Building, error)
+	GetBuildingByCode(ctx context.Context, code string) (generated.Building, error)
+	CreateBuilding(ctx context.Context, id string, attributes BuildingAttributes) (generated.Building, error)
+	UpdateBuilding(ctx context.Context, id string, attributes BuildingAttributes) (generated.Building, error)
+	DeleteBuilding(ctx context.Context, id string) (generated.Building, error)
+
+	ListRooms(ctx context.Context, filter RoomFilter, limit, offset int) ([]generated.Room, error)
+	CountRooms(ctx context.Context, filter RoomFilter) (int64, error)
+	ListRoomsByBuilding(ctx context.Context, buildingID string) ([]generated.Room, error)
+	GetRoom(ctx context.Context, id string) (generated.Room, error)
+
+	// GetRoomForUpdateTx returns the room and locks it until the transaction ends, so double bookings of the room
+	// are checked one transaction at a time.
+	GetRoomForUpdateTx(txCtx *common.TxContext, id string) (generated.Room, error)
+	GetRoomByCode(ctx context.Context, buildingID, code string) (generated.Room, error)
+	CreateRoom(ctx context.Context, id, buildingID string, attributes RoomAttributes) (generated.Room, error)
+	UpdateRoom(ctx context.Context, id string, attributes RoomAttributes) (generated.Room, error)
+	DeleteRoom(ctx context.Context, id string) (generated.Room, error)
+	GetLargestActiveCourseOfferingCapacityByRoom(ctx context.Context, roomID string) (int32, error)
+	CountActiveCourseOfferingsByRoom(ctx context.Context, roomID string) (int64, error)
+}
+
+type DefaultRoomRepository struct {
+	query *generated.Queries
+	pool  *pgxpool.Pool
+}
+
+// Compile time interface conformance check
+var _ RoomRepository = (*DefaultRoomRepository)(nil)
+
+func NewDefaultRoomRepository(pool *pgxpool.Pool) *DefaultRoomRepository {
+	return &DefaultRoomRepository{
+		query: generated.New(pool),
+		pool:  pool,
+	}
+}
+
+func (r *DefaultRoomRepository) ListBuildings(ctx context.Context, limit, offset int) ([]generated.Building, error) {
+	params := generated.ListBuildingsParams{
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	}
+
+	return r.query.ListBuildings(ctx, params)
+}
+
+func (r *DefaultRoomRepository) CountBuildings(ctx context.Context) (int64, error) {
+	return r.query.CountBuildings(ctx)
+}
+
+func (r *DefaultRoomRepository) GetBuilding(ctx context.Context, id string) (generated.Building, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.Building{}, errors.New("can't parse building id as uuid")
+	}
+
+	return r.query.GetBuilding(ctx, uuidID)
+}
+
+func (r *DefaultRoomRepository) GetBuildingByCode(ctx context.Context, code string) (generated.Building, error) {
+	return r.query.GetBuildingByCode(ctx, code)
+}
+
+func (r *DefaultRoomRepository) CreateBuilding(ctx context.Context, id string, attributes BuildingAttributes) (generated.Building, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.Building{}, errors.New("can't parse building id as uuid")
+	}
+
+	params := generated.CreateBuildingParams{
+		ID:   uuidID,
+		Code: attributes.Code,
+		Name: attributes.Name,
+	}
+
+	return r.query.CreateBuilding(ctx, params)
+}
+
+func (r *DefaultRoomRepository) UpdateBuilding(ctx context.Context, id string, attributes BuildingAttributes) (generated.Building, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.Building{}, errors.New("can't parse building id as uuid")
+	}
+
+	params := generated.UpdateBuildingParams{
+		ID:   uuidID,
+		Code: attributes.Code,
+		Name: attributes.Name,
+	}
+
+	return r.query.UpdateBuilding(ctx, params)
+}
+
+func (r *DefaultRoomRepository) DeleteBuilding(ctx context.Context, id string) (generated.Building, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.Building{}, errors.New("can't parse building id as uuid")
+	}
+
+	return r.query.DeleteBuilding(ctx, uuidID)
+}
+
+func (r *DefaultRoomRepository) ListRooms(ctx context.Context, filter RoomFilter, limit, offset int) ([]generated.Room, error) {
+	buildingUUID, minCapacity, err := newRoomFilterValues(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	params := generated.ListRoomsParams{
+		BuildingID:  buildingUUID,
+		MinCapacity: minCapacity,
+		Limit:       int32(limit),
+		Offset:      int32(offset),
+	}
+
+	return r.query.ListRooms(ctx, params)
+}
+
+func (r *DefaultRoomRepository) CountRooms(ctx context.Context, filter RoomFilter) (int64, error) {
+	buildingUUID, minCapacity, err := newRoomFilterValues(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	params := generated.CountRoomsParams{
+		BuildingID:  buildingUUID,
+		MinCapacity: minCapacity,
+	}
+
+	return r.query.CountRooms(ctx, params)
+}
+
+// ListRoomsByBuilding returns the rooms of the building ordered by code.
+func (r *DefaultRoomRepository) ListRoomsByBuilding(ctx context.Context, buildingID string) ([]generated.Room, error) {
+	var buildingUUID pgtype.UUID
+	err := buildingUUID.Scan(buildingID)
+	if err != nil {
+		return nil, errors.New("can't parse building id as uuid")
+	}
+
+	return r.query.ListRoomsByBuilding(ctx, buildingUUID)
+}
+
+func (r *DefaultRoomRepository) GetRoom(ctx context.Context, id string) (generated.Room, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.Room{}, errors.New("can't parse room id as uuid")
+	}
+
+	return r.query.GetRoom(ctx, uuidID)
+}
+
+func (r *DefaultRoomRepository) GetRoomForUpdateTx(txCtx *common.TxContext, id string) (generated.Room, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.Room{}, errors.New("can't parse room id as uuid")
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.GetRoomForUpdate(txCtx.Context(), uuidID)
+}
+
+// GetRoomByCode looks the code up within the building, room codes are only unique per building.
+func (r *DefaultRoomRepository) GetRoomByCode(ctx context.Context, buildingID, code string) (generated.Room, error) {
+	var buildingUUID pgtype.UUID
+	err := buildingUUID.Scan(buildingID)
+	if err != nil {
+		return generated.Room{}, errors.New("can't parse building id as uuid")
+	}
+
+	params := generated.GetRoomByCodeParams{
+		BuildingID: buildingUUID,
+		Code:       code,
+	}
+
+	return r.query.GetRoomByCode(ctx, params)
+}
+
+func (r *DefaultRoomRepository) CreateRoom(ctx context.Context, id, buildingID string, attributes RoomAttributes) (generated.Room, error) {
+	var uuidID, buildingUUID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.Room{}, errors.New("can't parse room id as uuid")
+	}
+	err = buildingUUID.Scan(buildingID)
+	if err != nil {
+		return generated.Room{}, errors.New("can't parse building id as uuid")
+	}
+
+	params := generated.CreateRoomParams{
+		ID:         uuidID,
+		BuildingID: buildingUUID,
+		Code:       attributes.Code,
+		Name:       attributes.Name,
+		Capacity:   int32(attributes.Capacity),
+	}
+
+	return r.query.CreateRoom(ctx, params)
+}
+
+func (r *DefaultRoomRepository) UpdateRoom(ctx context.Context, id string, attributes RoomAttributes) (generated.Room, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.Room{}, errors.New("can't parse room id as uuid")
+	}
+
+	params := generated.UpdateRoomParams{
+		ID:       uuidID,
+		Code:     attributes.Code,
+		Name:     attributes.Name,
+		Capacity: int32(attributes.Capacity),
+	}
+
+	return r.query.UpdateRoom(ctx, params)
+}
+
+func (r *DefaultRoomRepository) DeleteRoom(ctx context.Context, id string) (generated.Room, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.Room{}, errors.New("can't parse room id as uuid")
+	}
+
+	return r.query.DeleteRoom(ctx, uuidID)
+}
+
+// GetLargestActiveCourseOfferingCapacityByRoom returns the largest capacity of the room's offerings whose semester
+// hasn't ended, 0 without such offerings.
+func (r *DefaultRoomRepository) GetLargestActiveCourseOfferingCapacityByRoom(ctx context.Context, roomID string) (int32, error) {
+	var roomUUID pgtype.UUID
+	err := roomUUID.Scan(roomID)
+	if err != nil {
+		return 0, errors.New("can't parse room id as uuid")
+	}
+
+	return r.query.GetLargestActiveCourseOfferingCapacityByRoom(ctx, roomUUID)
+}
+
+// CountActiveCourseOfferingsByRoom counts the room's offerings whose semester hasn't ended.
+func (r *DefaultRoomRepository) CountActiveCourseOfferingsByRoom(ctx context.Context, roomID string) (int64, error) {
+	var roomUUID pgtype.UUID
+	err := roomUUID.Scan(roomID)
+	if err != nil {
+		return 0, errors.New("can't parse room id as uuid")
+	}
+
+	return r.query.CountActiveCourseOfferingsByRoom(ctx, roomUUID)
+}
+
+// newRoomFilterValues converts the filter to nullable query arguments
+func newRoomFilterValues(filter RoomFilter) (pgtype.UUID, pgtype.Int4, error) {
+	buildingUUID, err := newOptionalUUID(filter.BuildingID)
+	if err != nil {
+		return pgtype.UUID{}, pgtype.Int4{}, errors.New("can't parse building id as uuid")
+	}
+
+	var minCapacity pgtype.Int4
+	if filter.MinCapacity > 0 {
+		minCapacity = pgtype.Int4{Int32: int32(filter.MinCapacity), Valid: true}
+	}
+
+	return buildingUUID, minCapacity, nil
+}
@@ -0,0 +1,287 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"siakad-poc/common"
+	"siakad-poc/db/generated"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// NewStudent is a student row for bulk inserts, the ID is generated by the caller
+type NewStudent struct {
+	ID             string
+	UserID         string
+	StudyProgramID string
+	NIM            string
+}
+
+// StudentFilter narrows down student listings, zero values mean "no filter"
+type StudentFilter struct {
+	StudyProgramID string
+	NIM            string // case-insensitive substring match
+}
+
+// StudentAttributes are the editable attributes of a student, an empty advisor ID is stored as NULL
+type StudentAttributes struct {
+	StudyProgramID    string
+	NIM               string
+	AcademicAdvisorID string
+}
+
+type StudentRepository interface {
+	GetStudyProgram(ctx context.Context, id string) (generated.StudyProgram, error)
+	GetStudyProgramsByCodes(ctx context.Context, codes []string) ([]generated.StudyProgram, error)
+	GetExistingStudentNIMs(ctx context.Context, nims []string) ([]string, error)
+	GetStudentProfileByUserID(ctx context.Context, userID string) (generated.GetStudentProfileByUserIDRow, error)
+
+	ListStudents(ctx context.Context, filter StudentFilter, limit, offset int) ([]generated.Student, error)
+	CountStudents(ctx context.Context, filter StudentFilter) (int64, error)
+	GetStudent(ctx context.Context, id string) (generated.Student, error)
+	GetStudentByNIM(ctx context.Context, nim string) (generated.Student, error)
+	GetStudentByUserID(ctx context.Context, userID string) (generated.Student, error)
+	CreateStudent(ctx context.Context, id, userID string, attributes StudentAttributes) (generated.Student, error)
+	UpdateStudent(ctx context.Context, id string, attributes StudentAttributes) (generated.Student, error)
+	DeleteStudent(ctx context.Context, id string) (generated.Student, error)
+	CountStudentsByAcademicAdvisor(ctx context.Context, lecturerID string) (int64, error)
+
+	// Transaction-aware methods - these methods accept a TxContext for use within transactions
+	CreateStudentsTx(txCtx *common.TxContext, students []NewStudent) (int64, error)
+}
+
+type DefaultStudentRepository struct {
+	query *generated.Queries
+	pool  *pgxpool.Pool
+}
+
+// Compile time interface conformance check
+var _ StudentRepository = (*DefaultStudentRepository)(nil)
+
+func NewDefaultStudentRepository(pool *pgxpool.Pool) *DefaultStudentRepository {
+	return &DefaultStudentRepository{
+		query: generated.New(pool),
+		pool:  pool,
+	}
+}
+
+func (r *DefaultStudentRepository) GetStudyProgram(ctx context.Context, id string) (generated.StudyProgram, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.StudyProgram{}, errors.New("can't parse study program id as uuid")
+	}
+
+	return r.query.GetStudyProgram(ctx, uuidID)
+}
+
+func (r *DefaultStudentRepository) GetStudyProgramsByCodes(ctx context.Context, codes []string) ([]generated.StudyProgram, error) {
+	return r.query.GetStudyProgramsByCodes(ctx, codes)
+}
+
+func (r *DefaultStudentRepository) GetExistingStudentNIMs(ctx context.Context, nims []string) ([]string, error) {
+	return r.query.GetExistingStudentNims(ctx, nims)
+}
+
+// GetStudentProfileByUserID returns the student record of the user with its study program and academic advisor.
+func (r *DefaultStudentRepository) GetStudentProfileByUserID(ctx context.Context, userID string) (generated.GetStudentProfileByUserIDRow, error) {
+	var userUUID pgtype.UUID
+	err := userUUID.Scan(userID)
+	if err != nil {
+		return generated.GetStudentProfileByUserIDRow{}, errors.New("can't parse user id as uuid")
+	}
+
+	return r.query.GetStudentProfileByUserID(ctx, userUUID)
+}
+
+func (r *DefaultStudentRepository) ListStudents(ctx context.Context, filter StudentFilter, limit, offset int) ([]generated.Student, error) {
+	studyProgramID, nim, err := newStudentFilterValues(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	params := generated.ListStudentsParams{
+		StudyProgramID: studyProgramID,
+		Nim:            nim,
+		Limit:          int32(limit),
+		Offset:         int32(offset),
+	}
+
+	return r.query.ListStudents(ctx, params)
+}
+
+func (r *DefaultStudentRepository) CountStudents(ctx context.Context, filter StudentFilter) (int64, error) {
+	studyProgramID, nim, err := newStudentFilterValues(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	params := generated.CountStudentsParams{
+		StudyProgramID: studyProgramID,
+		Nim:            nim,
+	}
+
+	return r.query.CountStudents(ctx, params)
+}
+
+func (r *DefaultStudentRepository) GetStudent(ctx context.Context, id string) (generated.Student, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.Student{}, errors.New("can't parse student id as uuid")
+	}
+
+	return r.query.GetStudent(ctx, uuidID)
+}
+
+// GetStudentByNIM includes soft-deleted students, their NIM can't be reused.
+func (r *DefaultStudentRepository) GetStudentByNIM(ctx context.Context, nim string) (generated.Student, error) {
+	return r.query.GetStudentByNIM(ctx, nim)
+}
+
+// GetStudentByUserID includes soft-deleted students, callers check DeletedAt when they need an active record.
+func (r *DefaultStudentRepository) GetStudentByUserID(ctx context.Context, userID string) (generated.Student, error) {
+	var userUUID pgtype.UUID
+	err := userUUID.Scan(userID)
+	if err != nil {
+		return generated.Student{}, errors.New("can't parse user id as uuid")
+	}
+
+	return r.query.GetStudentByUserID(ctx, userUUID)
+}
+
+func (r *DefaultStudentRepository) CreateStudent(ctx context.Context, id, userID string, attributes StudentAttributes) (generated.Student, error) {
+	var idUUID, userUUID pgtype.UUID
+	err := idUUID.Scan(id)
+	if err != nil {
+		return generated.Student{}, errors.New("can't parse student id as uuid")
+	}
+	err = userUUID.Scan(userID)
+	if err != nil {
+		return generated.Student{}, errors.New("can't parse user id as uuid")
+	}
+	studyProgramUUID, academicAdvisorUUID, err := newStudentAttributeValues(attributes)
+	if err != nil {
+		return generated.Student{}, err
+	}
+
+	params := generated.CreateStudentParams{
+		ID:                idUUID,
+		UserID:            userUUID,
+		StudyProgramID:    studyProgramUUID,
+		Nim:               attributes.NIM,
+		AcademicAdvisorID: academicAdvisorUUID,
+	}
+
+	return r.query.CreateStudent(ctx, params)
+}
+
+func (r *DefaultStudentRepository) UpdateStudent(ctx context.Context, id string, attributes StudentAttributes) (generated.Student, error) {
+	var idUUID pgtype.UUID
+	err := idUUID.Scan(id)
+	if err != nil {
+		return generated.Student{}, errors.New("can't parse student id as uuid")
+	}
+	studyProgramUUID, academicAdvisorUUID, err := newStudentAttributeValues(attributes)
+	if err != nil {
+		return generated.Student{}, err
+	}
+
+	params := generated.UpdateStudentParams{
+		ID:                idUUID,
+		Nim:               attributes.NIM,
+		StudyProgramID:    studyProgramUUID,
+		AcademicAdvisorID: academicAdvisorUUID,
+	}
+
+	return r.query.UpdateStudent(ctx, params)
+}
+
+func (r *DefaultStudentRepository) DeleteStudent(ctx context.Context, id string) (generated.Student, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.Student{}, errors.New("can't parse student id as uuid")
+	}
+
+	return r.query.DeleteStudent(ctx, uuidID)
+}
+
+func (r *DefaultStudentRepository) CountStudentsByAcademicAdvisor(ctx context.Context, lecturerID string) (int64, error) {
+	var lecturerUUID pgtype.UUID
+	err := lecturerUUID.Scan(lecturerID)
+	if err != nil {
+		return 0, errors.New("can't parse lecturer id as uuid")
+	}
+
+	return r.query.CountStudentsByAcademicAdvisor(ctx, lecturerUUID)
+}
+
+// Transaction-aware methods implementation
+
+// CreateStudentsTx inserts all students with a single COPY, either every row is written or none.
+func (r *DefaultStudentRepository) CreateStudentsTx(txCtx *common.TxContext, students []NewStudent) (int64, error) {
+	now := pgtype.Timestamptz{
+		Time:  time.Now(),
+		Valid: true,
+	}
+
+	params := make([]generated.CreateStudentsParams, 0, len(students))
+	for _, student := range students {
+		var idUUID, userUUID, studyProgramUUID pgtype.UUID
+		err := idUUID.Scan(student.ID)
+		if err != nil {
+			return 0, errors.New("can't parse student id as uuid")
+		}
+		err = userUUID.Scan(student.UserID)
+		if err != nil {
+			return 0, errors.New("can't parse user id as uuid")
+		}
+		err = studyProgramUUID.Scan(student.StudyProgramID)
+		if err != nil {
+			return 0, errors.New("can't parse study program id as uuid")
+		}
+
+		params = append(params, generated.CreateStudentsParams{
+			ID:             idUUID,
+			UserID:         userUUID,
+			StudyProgramID: studyProgramUUID,
+			Nim:            student.NIM,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		})
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.CreateStudents(txCtx.Context(), params)
+}
+
+func newStudentFilterValues(filter StudentFilter) (pgtype.UUID, pgtype.Text, error) {
+	studyProgramUUID, err := newOptionalUUID(filter.StudyProgramID)
+	if err != nil {
+		return pgtype.UUID{}, pgtype.Text{}, errors.New("can't parse study program id as uuid")
+	}
+
+	nim := pgtype.Text{
+		String: filter.NIM,
+		Valid:  filter.NIM != "",
+	}
+
+	return studyProgramUUID, nim, nil
+}
+
+func newStudentAttributeValues(attributes StudentAttributes) (pgtype.UUID, pgtype.UUID, error) {
+	var studyProgramUUID pgtype.UUID
+	err := studyProgramUUID.Scan(attributes.StudyProgramID)
+	if err != nil {
+		return pgtype.UUID{}, pgtype.UUID{}, errors.New("can't parse study program id as uuid")
+	}
+	academicAdvisorUUID, err := newOptionalUUID(attributes.AcademicAdvisorID)
+	if err != nil {
+		return pgtype.UUID{}, pgtype.UUID{}, errors.New("can't parse academic advisor id as uuid")
+	}
+
+	return studyProgramUUID, academicAdvisorUUID, nil
+}
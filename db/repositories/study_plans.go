@@ -0,0 +1,278 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"siakad-poc/common"
+	"siakad-poc/db/generated"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// StudyPlanFilter narrows down study plan listings, zero values are ignored
+type StudyPlanFilter struct {
+	Status string
+}
+
+// StudyPlanTransition is a status change of a study plan, or of one of its lines when CourseRegistrationID is set.
+// An empty Note is stored as NULL.
+type StudyPlanTransition struct {
+	StudyPlanID          string
+	CourseRegistrationID string
+	StatusBefore         string
+	StatusAfter          string
+	Note                 string
+	ChangedBy            string
+}
+
+type StudyPlanRepository interface {
+	GetStudyPlanWithStudent(ctx context.Context, id string) (generated.GetStudyPlanWithStudentRow, error)
+	ListStudyPlansByStudent(ctx context.Context, studentID string) ([]generated.ListStudyPlansByStudentRow, error)
+	ListStudyPlansByAcademicAdvisor(ctx context.Context, lecturerID string, filter StudyPlanFilter) ([]generated.ListStudyPlansByAcademicAdvisorRow, error)
+	ListStudyPlanLines(ctx context.Context, id string) ([]generated.ListStudyPlanLinesRow, error)
+	ListStudyPlanHistories(ctx context.Context, id string) ([]generated.ListStudyPlanHistoriesRow, error)
+
+	// Transaction-aware methods - these methods accept a TxContext for use within transactions
+	GetOrCreateStudyPlanTx(txCtx *common.TxContext, id, studentID, semesterID string) (generated.StudyPlan, error)
+	GetStudyPlanForUpdateTx(txCtx *common.TxContext, id string) (generated.StudyPlan, error)
+	UpdateStudyPlanStatusTx(txCtx *common.TxContext, id, status string) (generated.StudyPlan, error)
+	ListStudyPlanLinesTx(txCtx *common.TxContext, id string) ([]generated.ListStudyPlanLinesRow, error)
+	ListStudyPlanWaitlistedCourseOfferingsTx(txCtx *common.TxContext, id string) ([]string, error)
+	GetStudyPlanLineTx(txCtx *common.TxContext, id, lineID string) (generated.CourseRegistration, error)
+	UpdateStudyPlanLineStatusTx(txCtx *common.TxContext, lineID, status string) (generated.CourseRegistration, error)
+	CreateStudyPlanHistoryTx(txCtx *common.TxContext, id string, transition StudyPlanTransition) (generated.StudyPlanHistory, error)
+}
+
+type DefaultStudyPlanRepository struct {
+	query *generated.Queries
+	pool  *pgxpool.Pool
+}
+
+// Compile time interface conformance check
+var _ StudyPlanRepository = (*DefaultStudyPlanRepository)(nil)
+
+func NewDefaultStudyPlanRepository(pool *pgxpool.Pool) *DefaultStudyPlanRepository {
+	return &DefaultStudyPlanRepository{
+		query: generated.New(pool),
+		pool:  pool,
+	}
+}
+
+// GetStudyPlanWithStudent returns the study plan with its student and the semester code.
+func (r *DefaultStudyPlanRepository) GetStudyPlanWithStudent(ctx context.Context, id string) (generated.GetStudyPlanWithStudentRow, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.GetStudyPlanWithStudentRow{}, errors.New("can't parse study plan id as uuid")
+	}
+
+	return r.query.GetStudyPlanWithStudent(ctx, uuidID)
+}
+
+// ListStudyPlansByStudent returns the study plans of the student, the latest semester first.
+func (r *DefaultStudyPlanRepository) ListStudyPlansByStudent(ctx context.Context, studentID string) ([]generated.ListStudyPlansByStudentRow, error) {
+	var studentUUID pgtype.UUID
+	err := studentUUID.Scan(studentID)
+	if err != nil {
+		return nil, errors.New("can't parse student id as uuid")
+	}
+
+	return r.query.ListStudyPlansByStudent(ctx, studentUUID)
+}
+
+// ListStudyPlansByAcademicAdvisor returns the study plans of the active students advised by the lecturer, the
+// latest semester first.
+func (r *DefaultStudyPlanRepository) ListStudyPlansByAcademicAdvisor(ctx context.Context, lecturerID string, filter StudyPlanFilter) ([]generated.ListStudyPlansByAcademicAdvisorRow, error) {
+	var lecturerUUID pgtype.UUID
+	err := lecturerUUID.Scan(lecturerID)
+	if err != nil {
+		return nil, errors.New("can't parse lecturer id as uuid")
+	}
+
+	params := generated.ListStudyPlansByAcademicAdvisorParams{
+		AcademicAdvisorID: lecturerUUID,
+		Status:            pgtype.Text{String: filter.Status, Valid: filter.Status != ""},
+	}
+
+	return r.query.ListStudyPlansByAcademicAdvisor(ctx, params)
+}
+
+// ListStudyPlanLines returns the registrations of the study plan ordered by course code and section.
+func (r *DefaultStudyPlanRepository) ListStudyPlanLines(ctx context.Context, id string) ([]generated.ListStudyPlanLinesRow, error) {
+	return listStudyPlanLines(ctx, r.query, id)
+}
+
+// ListStudyPlanHistories returns the status transitions of the study plan and its lines, oldest first.
+func (r *DefaultStudyPlanRepository) ListStudyPlanHistories(ctx context.Context, id string) ([]generated.ListStudyPlanHistoriesRow, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return nil, errors.New("can't parse study plan id as uuid")
+	}
+
+	return r.query.ListStudyPlanHistories(ctx, uuidID)
+}
+
+// GetOrCreateStudyPlanTx returns the plan of the student in the semester, creating it as a draft with the given id
+// when there is none. The plan stays locked until the transaction ends.
+func (r *DefaultStudyPlanRepository) GetOrCreateStudyPlanTx(txCtx *common.TxContext, id, studentID, semesterID string) (generated.StudyPlan, error) {
+	var uuidID, studentUUID, semesterUUID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.StudyPlan{}, errors.New("can't parse study plan id as uuid")
+	}
+	err = studentUUID.Scan(studentID)
+	if err != nil {
+		return generated.StudyPlan{}, errors.New("can't parse student id as uuid")
+	}
+	err = semesterUUID.Scan(semesterID)
+	if err != nil {
+		return generated.StudyPlan{}, errors.New("can't parse semester id as uuid")
+	}
+
+	params := generated.GetOrCreateStudyPlanParams{
+		ID:         uuidID,
+		StudentID:  studentUUID,
+		SemesterID: semesterUUID,
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.GetOrCreateStudyPlan(txCtx.Context(), params)
+}
+
+// GetStudyPlanForUpdateTx returns the study plan and locks it until the transaction ends.
+func (r *DefaultStudyPlanRepository) GetStudyPlanForUpdateTx(txCtx *common.TxContext, id string) (generated.StudyPlan, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.StudyPlan{}, errors.New("can't parse study plan id as uuid")
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.GetStudyPlanForUpdate(txCtx.Context(), uuidID)
+}
+
+func (r *DefaultStudyPlanRepository) UpdateStudyPlanStatusTx(txCtx *common.TxContext, id, status string) (generated.StudyPlan, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.StudyPlan{}, errors.New("can't parse study plan id as uuid")
+	}
+
+	params := generated.UpdateStudyPlanStatusParams{
+		ID:     uuidID,
+		Status: status,
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.UpdateStudyPlanStatus(txCtx.Context(), params)
+}
+
+func (r *DefaultStudyPlanRepository) ListStudyPlanLinesTx(txCtx *common.TxContext, id string) ([]generated.ListStudyPlanLinesRow, error) {
+	return listStudyPlanLines(txCtx.Context(), r.query.WithTx(txCtx.Tx()), id)
+}
+
+// ListStudyPlanWaitlistedCourseOfferingsTx returns the IDs of the offerings of the semester of the study plan
+// that its student is on the waitlist of.
+func (r *DefaultStudyPlanRepository) ListStudyPlanWaitlistedCourseOfferingsTx(txCtx *common.TxContext, id string) ([]string, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return nil, errors.New("can't parse study plan id as uuid")
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	courseOfferingIDs, err := txQueries.ListStudyPlanWaitlistedCourseOfferings(txCtx.Context(), uuidID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(courseOfferingIDs))
+	for _, courseOfferingID := range courseOfferingIDs {
+		ids = append(ids, courseOfferingID.String())
+	}
+	return ids, nil
+}
+
+// GetStudyPlanLineTx returns the registration when it is a line of the study plan.
+func (r *DefaultStudyPlanRepository) GetStudyPlanLineTx(txCtx *common.TxContext, id, lineID string) (generated.CourseRegistration, error) {
+	var uuidID, lineUUID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.CourseRegistration{}, errors.New("can't parse study plan id as uuid")
+	}
+	err = lineUUID.Scan(lineID)
+	if err != nil {
+		return generated.CourseRegistration{}, errors.New("can't parse study plan line id as uuid")
+	}
+
+	params := generated.GetStudyPlanLineParams{
+		ID:          lineUUID,
+		StudyPlanID: uuidID,
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.GetStudyPlanLine(txCtx.Context(), params)
+}
+
+func (r *DefaultStudyPlanRepository) UpdateStudyPlanLineStatusTx(txCtx *common.TxContext, lineID, status string) (generated.CourseRegistration, error) {
+	var lineUUID pgtype.UUID
+	err := lineUUID.Scan(lineID)
+	if err != nil {
+		return generated.CourseRegistration{}, errors.New("can't parse study plan line id as uuid")
+	}
+
+	params := generated.UpdateStudyPlanLineStatusParams{
+		ID:     lineUUID,
+		Status: status,
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.UpdateStudyPlanLineStatus(txCtx.Context(), params)
+}
+
+func (r *DefaultStudyPlanRepository) CreateStudyPlanHistoryTx(txCtx *common.TxContext, id string, transition StudyPlanTransition) (generated.StudyPlanHistory, error) {
+	var uuidID, studyPlanUUID, lineUUID, changedByUUID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.StudyPlanHistory{}, errors.New("can't parse study plan history id as uuid")
+	}
+	err = studyPlanUUID.Scan(transition.StudyPlanID)
+	if err != nil {
+		return generated.StudyPlanHistory{}, errors.New("can't parse study plan id as uuid")
+	}
+	if transition.CourseRegistrationID != "" {
+		err = lineUUID.Scan(transition.CourseRegistrationID)
+		if err != nil {
+			return generated.StudyPlanHistory{}, errors.New("can't parse study plan line id as uuid")
+		}
+	}
+	err = changedByUUID.Scan(transition.ChangedBy)
+	if err != nil {
+		return generated.StudyPlanHistory{}, errors.New("can't parse user id as uuid")
+	}
+
+	params := generated.CreateStudyPlanHistoryParams{
+		ID:                   uuidID,
+		StudyPlanID:          studyPlanUUID,
+		CourseRegistrationID: lineUUID,
+		StatusBefore:         transition.StatusBefore,
+		StatusAfter:          transition.StatusAfter,
+		Note:                 pgtype.Text{String: transition.Note, Valid: transition.Note != ""},
+		ChangedBy:            changedByUUID,
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.CreateStudyPlanHistory(txCtx.Context(), params)
+}
+
+func listStudyPlanLines(ctx context.Context, query *generated.Queries, id string) ([]generated.ListStudyPlanLinesRow, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return nil, errors.New("can't parse study plan id as uuid")
+	}
+
+	return query.ListStudyPlanLines(ctx, uuidID)
+}
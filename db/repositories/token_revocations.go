@@ -0,0 +1,218 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"siakad-poc/db/generated"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TokenRevocationRepository interface {
+	RevokeAccessToken(ctx context.Context, jti, userID string, expiresAt time.Time) error
+	RevokeUserSessions(ctx context.Context, userID string) (generated.UserSessionRevocation, error)
+
+	// IsTokenRevoked reports whether an access token was revoked on its own (logout)
+	// or was issued before all sessions of its user were revoked, or within the same second.
+	IsTokenRevoked(ctx context.Context, jti, userID string, issuedAt time.Time) (bool, error)
+}
+
+type revokedTokenCacheEntry struct {
+	revoked     bool
+	cachedUntil time.Time
+}
+
+type sessionRevocationCacheEntry struct {
+	revokedAt   time.Time // zero when the user's sessions were never revoked
+	cachedUntil time.Time
+}
+
+// DefaultTokenRevocationRepository stores revocations in Postgres and keeps an in-process cache
+// in front of it, so the JWT middleware doesn't hit the database on every request.
+// Revocations written through this instance are visible immediately; revocations written by
+// other instances become visible once the cached entry expires.
+type DefaultTokenRevocationRepository struct {
+	query    *generated.Queries
+	pool     *pgxpool.Pool
+	cacheTTL time.Duration
+
+	mu                 sync.RWMutex
+	revokedTokens      map[string]revokedTokenCacheEntry
+	sessionRevocations map[string]sessionRevocationCacheEntry
+	lastEviction       time.Time
+}
+
+// Compile time interface conformance check
+var _ TokenRevocationRepository = (*DefaultTokenRevocationRepository)(nil)
+
+func NewDefaultTokenRevocationRepository(pool *pgxpool.Pool, cacheTTL time.Duration) *DefaultTokenRevocationRepository {
+	return &DefaultTokenRevocationRepository{
+		query:              generated.New(pool),
+		pool:               pool,
+		cacheTTL:           cacheTTL,
+		revokedTokens:      make(map[string]revokedTokenCacheEntry),
+		sessionRevocations: make(map[string]sessionRevocationCacheEntry),
+	}
+}
+
+func (r *DefaultTokenRevocationRepository) RevokeAccessToken(ctx context.Context, jti, userID string, expiresAt time.Time) error {
+	var jtiUUID, userUUID pgtype.UUID
+	err := jtiUUID.Scan(jti)
+	if err != nil {
+		return errors.New("can't parse token id as uuid")
+	}
+	err = userUUID.Scan(userID)
+	if err != nil {
+		return errors.New("can't parse user id as uuid")
+	}
+
+	params := generated.RevokeAccessTokenParams{
+		Jti:    jtiUUID,
+		UserID: userUUID,
+		ExpiresAt: pgtype.Timestamptz{
+			Time:  expiresAt,
+			Valid: true,
+		},
+	}
+
+	err = r.query.RevokeAccessToken(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	// A revoked token never becomes valid again, keep it cached until it expires anyway
+	r.mu.Lock()
+	r.revokedTokens[jti] = revokedTokenCacheEntry{revoked: true, cachedUntil: expiresAt}
+	r.mu.Unlock()
+
+	return nil
+}
+
+func (r *DefaultTokenRevocationRepository) RevokeUserSessions(ctx context.Context, userID string) (generated.UserSessionRevocation, error) {
+	var userUUID pgtype.UUID
+	err := userUUID.Scan(userID)
+	if err != nil {
+		return generated.UserSessionRevocation{}, errors.New("can't parse user id as uuid")
+	}
+
+	revocation, err := r.query.RevokeUserSessions(ctx, userUUID)
+	if err != nil {
+		return generated.UserSessionRevocation{}, err
+	}
+
+	r.mu.Lock()
+	r.sessionRevocations[userID] = sessionRevocationCacheEntry{
+		revokedAt:   revocation.RevokedAt.Time,
+		cachedUntil: time.Now().Add(r.cacheTTL),
+	}
+	r.mu.Unlock()
+
+	return revocation, nil
+}
+
+func (r *DefaultTokenRevocationRepository) IsTokenRevoked(ctx context.Context, jti, userID string, issuedAt time.Time) (bool, error) {
+	// Tokens issued before jti existed can only be revoked through their user's sessions
+	if jti != "" {
+		revoked, err := r.isAccessTokenRevoked(ctx, jti)
+		if err != nil {
+			return false, err
+		}
+		if revoked {
+			return true, nil
+		}
+	}
+
+	sessionsRevokedAt, err := r.getUserSessionsRevokedAt(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	// JWT timestamps have second precision, so both sides are compared in seconds and a token issued within the
+	// same second as the revocation is treated as revoked, it may have been issued before it
+	return !sessionsRevokedAt.IsZero() && !issuedAt.Truncate(time.Second).After(sessionsRevokedAt.Truncate(time.Second)), nil
+}
+
+func (r *DefaultTokenRevocationRepository) isAccessTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	now := time.Now()
+
+	r.mu.RLock()
+	entry, ok := r.revokedTokens[jti]
+	r.mu.RUnlock()
+	if ok && now.Before(entry.cachedUntil) {
+		return entry.revoked, nil
+	}
+
+	var jtiUUID pgtype.UUID
+	err := jtiUUID.Scan(jti)
+	if err != nil {
+		return false, errors.New("can't parse token id as uuid")
+	}
+
+	revoked, err := r.query.IsAccessTokenRevoked(ctx, jtiUUID)
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.Lock()
+	r.evictExpiredLocked(now)
+	r.revokedTokens[jti] = revokedTokenCacheEntry{revoked: revoked, cachedUntil: now.Add(r.cacheTTL)}
+	r.mu.Unlock()
+
+	return revoked, nil
+}
+
+func (r *DefaultTokenRevocationRepository) getUserSessionsRevokedAt(ctx context.Context, userID string) (time.Time, error) {
+	now := time.Now()
+
+	r.mu.RLock()
+	entry, ok := r.sessionRevocations[userID]
+	r.mu.RUnlock()
+	if ok && now.Before(entry.cachedUntil) {
+		return entry.revokedAt, nil
+	}
+
+	var userUUID pgtype.UUID
+	err := userUUID.Scan(userID)
+	if err != nil {
+		return time.Time{}, errors.New("can't parse user id as uuid")
+	}
+
+	var revokedAt time.Time
+	revocation, err := r.query.GetUserSessionRevocation(ctx, userUUID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return time.Time{}, err
+	}
+	if err == nil && revocation.RevokedAt.Valid {
+		revokedAt = revocation.RevokedAt.Time
+	}
+
+	r.mu.Lock()
+	r.sessionRevocations[userID] = sessionRevocationCacheEntry{revokedAt: revokedAt, cachedUntil: now.Add(r.cacheTTL)}
+	r.mu.Unlock()
+
+	return revokedAt, nil
+}
+
+// evictExpiredLocked drops stale entries, at most once per cache TTL, so the cache doesn't grow
+// with every token ever seen. Must be called with the write lock held.
+func (r *DefaultTokenRevocationRepository) evictExpiredLocked(now time.Time) {
+	if now.Sub(r.lastEviction) < r.cacheTTL {
+		return
+	}
+	r.lastEviction = now
+
+	for userID, entry := range r.sessionRevocations {
+		if !now.Before(entry.cachedUntil) {
+			delete(r.sessionRevocations, userID)
+		}
+	}
+	for jti, entry := range r.revokedTokens {
+		if !now.Before(entry.cachedUntil) {
+			delete(r.revokedTokens, jti)
+		}
+	}
+}
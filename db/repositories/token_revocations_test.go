@@ -0,0 +1,252 @@
+package repositories
+
+import (
+	"context"
+	"reflect"
+	"siakad-poc/db/generated"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	revocationUserID  = "550e8400-e29b-41d4-a716-446655440031"
+	revocationTokenID = "550e8400-e29b-41d4-a716-446655440032"
+)
+
+// fakeRevocationDB stands in for Postgres behind the generated queries, it keeps the revocation
+// tables in memory and counts the queries run by name.
+type fakeRevocationDB struct {
+	revokedTokens      map[pgtype.UUID]bool
+	sessionRevocations map[pgtype.UUID]time.Time
+	revokeSessionsAt   time.Time
+	calls              map[string]int
+}
+
+func newFakeRevocationDB() *fakeRevocationDB {
+	return &fakeRevocationDB{
+		revokedTokens:      make(map[pgtype.UUID]bool),
+		sessionRevocations: make(map[pgtype.UUID]time.Time),
+		calls:              make(map[string]int),
+	}
+}
+
+// queryName extracts the sqlc query name from the leading "-- name: X :kind" comment
+func queryName(sql string) string {
+	return strings.Fields(sql)[2]
+}
+
+func (db *fakeRevocationDB) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	name := queryName(sql)
+	db.calls[name]++
+
+	switch name {
+	case "RevokeAccessToken":
+		db.revokedTokens[args[0].(pgtype.UUID)] = true
+		return pgconn.NewCommandTag("INSERT 0 1"), nil
+	}
+	panic("unexpected exec " + name)
+}
+
+func (db *fakeRevocationDB) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	panic("unexpected query " + queryName(sql))
+}
+
+func (db *fakeRevocationDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	name := queryName(sql)
+	db.calls[name]++
+
+	switch name {
+	case "IsAccessTokenRevoked":
+		return fakeRow{values: []interface{}{db.revokedTokens[args[0].(pgtype.UUID)]}}
+	case "GetUserSessionRevocation":
+		userID := args[0].(pgtype.UUID)
+		revokedAt, ok := db.sessionRevocations[userID]
+		if !ok {
+			return fakeRow{err: pgx.ErrNoRows}
+		}
+		return fakeRow{values: []interface{}{userID, pgtype.Timestamptz{Time: revokedAt, Valid: true}}}
+	case "RevokeUserSessions":
+		userID := args[0].(pgtype.UUID)
+		db.sessionRevocations[userID] = db.revokeSessionsAt
+		return fakeRow{values: []interface{}{userID, pgtype.Timestamptz{Time: db.revokeSessionsAt, Valid: true}}}
+	}
+	panic("unexpected query row " + name)
+}
+
+func (db *fakeRevocationDB) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	panic("unexpected copy into " + tableName.Sanitize())
+}
+
+type fakeRow struct {
+	values []interface{}
+	err    error
+}
+
+func (r fakeRow) Scan(dest ...interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	for i, d := range dest {
+		reflect.ValueOf(d).Elem().Set(reflect.ValueOf(r.values[i]))
+	}
+	return nil
+}
+
+// Test Suite
+type TokenRevocationRepositoryTestSuite struct {
+	suite.Suite
+	db       *fakeRevocationDB
+	ctx      context.Context
+	userUUID pgtype.UUID
+	jtiUUID  pgtype.UUID
+}
+
+func (suite *TokenRevocationRepositoryTestSuite) SetupTest() {
+	suite.db = newFakeRevocationDB()
+	suite.ctx = context.Background()
+	suite.Require().NoError(suite.userUUID.Scan(revocationUserID))
+	suite.Require().NoError(suite.jtiUUID.Scan(revocationTokenID))
+}
+
+// newRepository builds the repository on top of the fake database, the revocation queries never use the pool
+func (suite *TokenRevocationRepositoryTestSuite) newRepository(cacheTTL time.Duration) *DefaultTokenRevocationRepository {
+	return &DefaultTokenRevocationRepository{
+		query:              generated.New(suite.db),
+		cacheTTL:           cacheTTL,
+		revokedTokens:      make(map[string]revokedTokenCacheEntry),
+		sessionRevocations: make(map[string]sessionRevocationCacheEntry),
+	}
+}
+
+// Test a token that was never revoked is looked up once and then served from the cache
+func (suite *TokenRevocationRepositoryTestSuite) TestIsTokenRevoked_NotRevokedIsCached() {
+	repo := suite.newRepository(time.Hour)
+	issuedAt := time.Now()
+
+	for i := 0; i < 3; i++ {
+		revoked, err := repo.IsTokenRevoked(suite.ctx, revocationTokenID, revocationUserID, issuedAt)
+
+		assert.NoError(suite.T(), err)
+		assert.False(suite.T(), revoked)
+	}
+	assert.Equal(suite.T(), 1, suite.db.calls["IsAccessTokenRevoked"])
+	assert.Equal(suite.T(), 1, suite.db.calls["GetUserSessionRevocation"])
+}
+
+// Test a logout through this instance is visible immediately, even over a cached "not revoked"
+func (suite *TokenRevocationRepositoryTestSuite) TestRevokeAccessToken_VisibleImmediately() {
+	repo := suite.newRepository(time.Hour)
+	issuedAt := time.Now()
+
+	revoked, err := repo.IsTokenRevoked(suite.ctx, revocationTokenID, revocationUserID, issuedAt)
+	suite.Require().NoError(err)
+	suite.Require().False(revoked)
+
+	err = repo.RevokeAccessToken(suite.ctx, revocationTokenID, revocationUserID, issuedAt.Add(15*time.Minute))
+	suite.Require().NoError(err)
+
+	revoked, err = repo.IsTokenRevoked(suite.ctx, revocationTokenID, revocationUserID, issuedAt)
+
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), revoked)
+	assert.True(suite.T(), suite.db.revokedTokens[suite.jtiUUID])
+	assert.Equal(suite.T(), 1, suite.db.calls["IsAccessTokenRevoked"])
+}
+
+// Test a revoked jti is rejected without looking at the user's sessions
+func (suite *TokenRevocationRepositoryTestSuite) TestIsTokenRevoked_RevokedToken() {
+	suite.db.revokedTokens[suite.jtiUUID] = true
+	repo := suite.newRepository(time.Hour)
+
+	revoked, err := repo.IsTokenRevoked(suite.ctx, revocationTokenID, revocationUserID, time.Now())
+
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), revoked)
+	assert.Zero(suite.T(), suite.db.calls["GetUserSessionRevocation"])
+}
+
+// Test a revocation written by another instance is only seen once the cached entry expires
+func (suite *TokenRevocationRepositoryTestSuite) TestIsTokenRevoked_CacheExpiry() {
+	issuedAt := time.Now()
+	cached := suite.newRepository(time.Hour)
+	expiring := suite.newRepository(0)
+
+	for _, repo := range []*DefaultTokenRevocationRepository{cached, expiring} {
+		revoked, err := repo.IsTokenRevoked(suite.ctx, revocationTokenID, revocationUserID, issuedAt)
+		suite.Require().NoError(err)
+		suite.Require().False(revoked)
+	}
+
+	// Another instance logs the token out
+	suite.db.revokedTokens[suite.jtiUUID] = true
+
+	revoked, err := cached.IsTokenRevoked(suite.ctx, revocationTokenID, revocationUserID, issuedAt)
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), revoked)
+
+	revoked, err = expiring.IsTokenRevoked(suite.ctx, revocationTokenID, revocationUserID, issuedAt)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), revoked)
+}
+
+// Test revoking all sessions rejects tokens issued before or within the same second as the revocation,
+// and accepts tokens issued after it
+func (suite *TokenRevocationRepositoryTestSuite) TestRevokeUserSessions_IssuedAt() {
+	revokedAt := time.Date(2025, 11, 4, 8, 0, 0, 500_000_000, time.UTC)
+	suite.db.revokeSessionsAt = revokedAt
+	repo := suite.newRepository(time.Hour)
+
+	_, err := repo.RevokeUserSessions(suite.ctx, revocationUserID)
+	suite.Require().NoError(err)
+
+	testCases := []struct {
+		name     string
+		issuedAt time.Time
+		revoked  bool
+	}{
+		{"issued before the revocation", revokedAt.Add(-time.Minute), true},
+		{"issued earlier in the revocation second", revokedAt.Truncate(time.Second), true},
+		{"issued later in the revocation second", revokedAt.Add(200 * time.Millisecond), true},
+		{"issued in the next second", revokedAt.Truncate(time.Second).Add(time.Second), false},
+	}
+	for _, tc := range testCases {
+		// Tokens issued before jti existed only go through the session check
+		revoked, err := repo.IsTokenRevoked(suite.ctx, "", revocationUserID, tc.issuedAt)
+
+		assert.NoError(suite.T(), err, tc.name)
+		assert.Equal(suite.T(), tc.revoked, revoked, tc.name)
+	}
+
+	// The revocation written through this instance is cached, the database is never read back
+	assert.Zero(suite.T(), suite.db.calls["GetUserSessionRevocation"])
+	assert.Zero(suite.T(), suite.db.calls["IsAccessTokenRevoked"])
+}
+
+// Test the sessions revocation of a user is read from the database when it isn't cached yet
+func (suite *TokenRevocationRepositoryTestSuite) TestIsTokenRevoked_StoredSessionRevocation() {
+	revokedAt := time.Now().Add(-time.Minute)
+	suite.db.sessionRevocations[suite.userUUID] = revokedAt
+	repo := suite.newRepository(time.Hour)
+
+	revoked, err := repo.IsTokenRevoked(suite.ctx, revocationTokenID, revocationUserID, revokedAt.Add(-time.Hour))
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), revoked)
+
+	revoked, err = repo.IsTokenRevoked(suite.ctx, revocationTokenID, revocationUserID, revokedAt.Add(time.Second))
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), revoked)
+
+	assert.Equal(suite.T(), 1, suite.db.calls["GetUserSessionRevocation"])
+}
+
+// Run the test suite
+func TestTokenRevocationRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(TokenRevocationRepositoryTestSuite))
+}
@@ -4,16 +4,62 @@ import (
 	"context"
 	"errors"
 	"math/big"
+	"siakad-poc/common"
 	"siakad-poc/db/generated"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// UserFilter narrows down user listings, zero values mean "no filter"
+type UserFilter struct {
+	Role           int64
+	Email          string // case-insensitive substring match
+	IncludeDeleted bool
+}
+
+// NewUser is a user row for bulk inserts, the ID is generated by the caller
+type NewUser struct {
+	ID       string
+	Email    string
+	Password string // already hashed
+	Name     string
+	Role     int64
+}
+
 type UserRepository interface {
 	GetUser(ctx context.Context, id string) (generated.User, error)
 	GetUserByEmail(ctx context.Context, email string) (generated.User, error)
-	CreateUser(ctx context.Context, email, password string, role int64) (generated.User, error)
+	CreateUser(ctx context.Context, email, password, name string, role int64, studyProgramID string) (generated.User, error)
+	GetActiveUserEmails(ctx context.Context, emails []string) ([]string, error)
+	ListUsers(ctx context.Context, filter UserFilter, limit, offset int) ([]generated.User, error)
+	CountUsers(ctx context.Context, filter UserFilter) (int64, error)
+	UpdateUserRole(ctx context.Context, id string, role int64) (generated.User, error)
+	UpdateUserStudyProgram(ctx context.Context, id, studyProgramID string) (generated.User, error)
+	GetUserStudyProgramID(ctx context.Context, id string) (string, error)
+	DisableUser(ctx context.Context, id string) (generated.User, error)
+	EnableUser(ctx context.Context, id string) (generated.User, error)
+	DeleteUser(ctx context.Context, id string) (generated.User, error)
+	RestoreUser(ctx context.Context, id string) (generated.User, error)
+	CreateRefreshToken(ctx context.Context, userID, familyID, tokenHash string, expiresAt time.Time) (generated.RefreshToken, error)
+	RevokeRefreshTokenFamilyByHash(ctx context.Context, userID, tokenHash string) error
+	RevokeUserRefreshTokens(ctx context.Context, userID string) error
+	UpdateUserPassword(ctx context.Context, id, password string) (generated.User, error)
+
+	// Transaction-aware methods - these methods accept a TxContext for use within transactions
+	GetUserTx(txCtx *common.TxContext, id string) (generated.User, error)
+	CreateUsersTx(txCtx *common.TxContext, users []NewUser) (int64, error)
+	GetRefreshTokenByHashTx(txCtx *common.TxContext, tokenHash string) (generated.RefreshToken, error)
+	CreateRefreshTokenTx(txCtx *common.TxContext, userID, familyID, tokenHash string, expiresAt time.Time) (generated.RefreshToken, error)
+	RotateRefreshTokenTx(txCtx *common.TxContext, id, replacedByID string) (generated.RefreshToken, error)
+	RevokeRefreshTokenFamilyTx(txCtx *common.TxContext, familyID string) error
+	UpdateUserPasswordTx(txCtx *common.TxContext, id, password string) (generated.User, error)
+	CreatePasswordResetTokenTx(txCtx *common.TxContext, userID, tokenHash string, expiresAt time.Time, createdBy string) (generated.PasswordResetToken, error)
+	InvalidatePasswordResetTokensTx(txCtx *common.TxContext, userID string) error
+	GetPasswordResetTokenByHashTx(txCtx *common.TxContext, tokenHash string) (generated.PasswordResetToken, error)
+	MarkPasswordResetTokenUsedTx(txCtx *common.TxContext, id string) (generated.PasswordResetToken, error)
 }
 
 type DefaultUserRepository struct {
@@ -45,15 +91,413 @@ func (r *DefaultUserRepository) GetUserByEmail(ctx context.Context, email string
 	return r.query.GetUserByEmail(ctx, email)
 }
 
-func (r *DefaultUserRepository) CreateUser(ctx context.Context, email, password string, role int64) (generated.User, error) {
+func (r *DefaultUserRepository) CreateUser(ctx context.Context, email, password, name string, role int64, studyProgramID string) (generated.User, error) {
+	studyProgramUUID, err := newOptionalUUID(studyProgramID)
+	if err != nil {
+		return generated.User{}, errors.New("can't parse study program id as uuid")
+	}
+
 	params := generated.CreateUserParams{
 		Email:    email,
 		Password: password,
+		Name: pgtype.Text{
+			String: name,
+			Valid:  name != "",
+		},
 		Role: pgtype.Numeric{
 			Int:   big.NewInt(role),
 			Valid: true,
 		},
+		StudyProgramID: studyProgramUUID,
 	}
 
 	return r.query.CreateUser(ctx, params)
 }
+
+func (r *DefaultUserRepository) GetActiveUserEmails(ctx context.Context, emails []string) ([]string, error) {
+	return r.query.GetActiveUserEmails(ctx, emails)
+}
+
+func (r *DefaultUserRepository) ListUsers(ctx context.Context, filter UserFilter, limit, offset int) ([]generated.User, error) {
+	role, email := newUserFilterValues(filter)
+	params := generated.ListUsersParams{
+		Role:           role,
+		Email:          email,
+		IncludeDeleted: filter.IncludeDeleted,
+		Limit:          int32(limit),
+		Offset:         int32(offset),
+	}
+
+	return r.query.ListUsers(ctx, params)
+}
+
+func (r *DefaultUserRepository) CountUsers(ctx context.Context, filter UserFilter) (int64, error) {
+	role, email := newUserFilterValues(filter)
+	params := generated.CountUsersParams{
+		Role:           role,
+		Email:          email,
+		IncludeDeleted: filter.IncludeDeleted,
+	}
+
+	return r.query.CountUsers(ctx, params)
+}
+
+func (r *DefaultUserRepository) UpdateUserRole(ctx context.Context, id string, role int64) (generated.User, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.User{}, errors.New("can't parse id as uuid")
+	}
+
+	params := generated.UpdateUserRoleParams{
+		ID: uuidID,
+		Role: pgtype.Numeric{
+			Int:   big.NewInt(role),
+			Valid: true,
+		},
+	}
+
+	return r.query.UpdateUserRole(ctx, params)
+}
+
+// UpdateUserStudyProgram links the user to a study program, an empty ID removes the link.
+func (r *DefaultUserRepository) UpdateUserStudyProgram(ctx context.Context, id, studyProgramID string) (generated.User, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.User{}, errors.New("can't parse id as uuid")
+	}
+	studyProgramUUID, err := newOptionalUUID(studyProgramID)
+	if err != nil {
+		return generated.User{}, errors.New("can't parse study program id as uuid")
+	}
+
+	params := generated.UpdateUserStudyProgramParams{
+		ID:             uuidID,
+		StudyProgramID: studyProgramUUID,
+	}
+
+	return r.query.UpdateUserStudyProgram(ctx, params)
+}
+
+// GetUserStudyProgramID returns the study program linked to the user, empty when there is none.
+func (r *DefaultUserRepository) GetUserStudyProgramID(ctx context.Context, id string) (string, error) {
+	user, err := r.GetUser(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", err
+	}
+	if !user.StudyProgramID.Valid {
+		return "", nil
+	}
+
+	return user.StudyProgramID.String(), nil
+}
+
+func (r *DefaultUserRepository) DisableUser(ctx context.Context, id string) (generated.User, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.User{}, errors.New("can't parse id as uuid")
+	}
+
+	return r.query.DisableUser(ctx, uuidID)
+}
+
+func (r *DefaultUserRepository) EnableUser(ctx context.Context, id string) (generated.User, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.User{}, errors.New("can't parse id as uuid")
+	}
+
+	return r.query.EnableUser(ctx, uuidID)
+}
+
+func (r *DefaultUserRepository) DeleteUser(ctx context.Context, id string) (generated.User, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.User{}, errors.New("can't parse id as uuid")
+	}
+
+	return r.query.DeleteUser(ctx, uuidID)
+}
+
+func (r *DefaultUserRepository) RestoreUser(ctx context.Context, id string) (generated.User, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.User{}, errors.New("can't parse id as uuid")
+	}
+
+	return r.query.RestoreUser(ctx, uuidID)
+}
+
+func (r *DefaultUserRepository) CreateRefreshToken(ctx context.Context, userID, familyID, tokenHash string, expiresAt time.Time) (generated.RefreshToken, error) {
+	params, err := newCreateRefreshTokenParams(userID, familyID, tokenHash, expiresAt)
+	if err != nil {
+		return generated.RefreshToken{}, err
+	}
+
+	return r.query.CreateRefreshToken(ctx, params)
+}
+
+func (r *DefaultUserRepository) RevokeRefreshTokenFamilyByHash(ctx context.Context, userID, tokenHash string) error {
+	var userUUID pgtype.UUID
+	err := userUUID.Scan(userID)
+	if err != nil {
+		return errors.New("can't parse user id as uuid")
+	}
+
+	params := generated.RevokeRefreshTokenFamilyByHashParams{
+		TokenHash: tokenHash,
+		UserID:    userUUID,
+	}
+
+	return r.query.RevokeRefreshTokenFamilyByHash(ctx, params)
+}
+
+func (r *DefaultUserRepository) RevokeUserRefreshTokens(ctx context.Context, userID string) error {
+	var userUUID pgtype.UUID
+	err := userUUID.Scan(userID)
+	if err != nil {
+		return errors.New("can't parse user id as uuid")
+	}
+
+	return r.query.RevokeUserRefreshTokens(ctx, userUUID)
+}
+
+func (r *DefaultUserRepository) UpdateUserPassword(ctx context.Context, id, password string) (generated.User, error) {
+	params, err := newUpdateUserPasswordParams(id, password)
+	if err != nil {
+		return generated.User{}, err
+	}
+
+	return r.query.UpdateUserPassword(ctx, params)
+}
+
+// Transaction-aware methods implementation
+
+func (r *DefaultUserRepository) GetUserTx(txCtx *common.TxContext, id string) (generated.User, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.User{}, errors.New("can't parse id as uuid")
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.GetUser(txCtx.Context(), uuidID)
+}
+
+// CreateUsersTx inserts all users with a single COPY, either every row is written or none.
+func (r *DefaultUserRepository) CreateUsersTx(txCtx *common.TxContext, users []NewUser) (int64, error) {
+	now := pgtype.Timestamptz{
+		Time:  time.Now(),
+		Valid: true,
+	}
+
+	params := make([]generated.CreateUsersParams, 0, len(users))
+	for _, user := range users {
+		var uuidID pgtype.UUID
+		err := uuidID.Scan(user.ID)
+		if err != nil {
+			return 0, errors.New("can't parse id as uuid")
+		}
+
+		params = append(params, generated.CreateUsersParams{
+			ID:       uuidID,
+			Email:    user.Email,
+			Password: user.Password,
+			Name: pgtype.Text{
+				String: user.Name,
+				Valid:  user.Name != "",
+			},
+			Role: pgtype.Numeric{
+				Int:   big.NewInt(user.Role),
+				Valid: true,
+			},
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.CreateUsers(txCtx.Context(), params)
+}
+
+func (r *DefaultUserRepository) GetRefreshTokenByHashTx(txCtx *common.TxContext, tokenHash string) (generated.RefreshToken, error) {
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.GetRefreshTokenByHash(txCtx.Context(), tokenHash)
+}
+
+func (r *DefaultUserRepository) CreateRefreshTokenTx(txCtx *common.TxContext, userID, familyID, tokenHash string, expiresAt time.Time) (generated.RefreshToken, error) {
+	params, err := newCreateRefreshTokenParams(userID, familyID, tokenHash, expiresAt)
+	if err != nil {
+		return generated.RefreshToken{}, err
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.CreateRefreshToken(txCtx.Context(), params)
+}
+
+func (r *DefaultUserRepository) RotateRefreshTokenTx(txCtx *common.TxContext, id, replacedByID string) (generated.RefreshToken, error) {
+	var idUUID, replacedByUUID pgtype.UUID
+	err := idUUID.Scan(id)
+	if err != nil {
+		return generated.RefreshToken{}, errors.New("can't parse refresh token id as uuid")
+	}
+	err = replacedByUUID.Scan(replacedByID)
+	if err != nil {
+		return generated.RefreshToken{}, errors.New("can't parse replacing refresh token id as uuid")
+	}
+
+	params := generated.RotateRefreshTokenParams{
+		ID:         idUUID,
+		ReplacedBy: replacedByUUID,
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.RotateRefreshToken(txCtx.Context(), params)
+}
+
+func (r *DefaultUserRepository) RevokeRefreshTokenFamilyTx(txCtx *common.TxContext, familyID string) error {
+	var familyUUID pgtype.UUID
+	err := familyUUID.Scan(familyID)
+	if err != nil {
+		return errors.New("can't parse refresh token family id as uuid")
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.RevokeRefreshTokenFamily(txCtx.Context(), familyUUID)
+}
+
+func (r *DefaultUserRepository) UpdateUserPasswordTx(txCtx *common.TxContext, id, password string) (generated.User, error) {
+	params, err := newUpdateUserPasswordParams(id, password)
+	if err != nil {
+		return generated.User{}, err
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.UpdateUserPassword(txCtx.Context(), params)
+}
+
+func (r *DefaultUserRepository) CreatePasswordResetTokenTx(txCtx *common.TxContext, userID, tokenHash string, expiresAt time.Time, createdBy string) (generated.PasswordResetToken, error) {
+	var userUUID, createdByUUID pgtype.UUID
+	err := userUUID.Scan(userID)
+	if err != nil {
+		return generated.PasswordResetToken{}, errors.New("can't parse user id as uuid")
+	}
+	err = createdByUUID.Scan(createdBy)
+	if err != nil {
+		return generated.PasswordResetToken{}, errors.New("can't parse issuer id as uuid")
+	}
+
+	params := generated.CreatePasswordResetTokenParams{
+		UserID:    userUUID,
+		TokenHash: tokenHash,
+		ExpiresAt: pgtype.Timestamptz{
+			Time:  expiresAt,
+			Valid: true,
+		},
+		CreatedBy: createdByUUID,
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.CreatePasswordResetToken(txCtx.Context(), params)
+}
+
+func (r *DefaultUserRepository) InvalidatePasswordResetTokensTx(txCtx *common.TxContext, userID string) error {
+	var userUUID pgtype.UUID
+	err := userUUID.Scan(userID)
+	if err != nil {
+		return errors.New("can't parse user id as uuid")
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.InvalidatePasswordResetTokens(txCtx.Context(), userUUID)
+}
+
+func (r *DefaultUserRepository) GetPasswordResetTokenByHashTx(txCtx *common.TxContext, tokenHash string) (generated.PasswordResetToken, error) {
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.GetPasswordResetTokenByHash(txCtx.Context(), tokenHash)
+}
+
+func (r *DefaultUserRepository) MarkPasswordResetTokenUsedTx(txCtx *common.TxContext, id string) (generated.PasswordResetToken, error) {
+	var idUUID pgtype.UUID
+	err := idUUID.Scan(id)
+	if err != nil {
+		return generated.PasswordResetToken{}, errors.New("can't parse password reset token id as uuid")
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.MarkPasswordResetTokenUsed(txCtx.Context(), idUUID)
+}
+
+func newUserFilterValues(filter UserFilter) (pgtype.Numeric, pgtype.Text) {
+	var role pgtype.Numeric
+	if filter.Role != 0 {
+		role = pgtype.Numeric{
+			Int:   big.NewInt(filter.Role),
+			Valid: true,
+		}
+	}
+
+	var email pgtype.Text
+	if filter.Email != "" {
+		email = pgtype.Text{
+			String: filter.Email,
+			Valid:  true,
+		}
+	}
+
+	return role, email
+}
+
+func newUpdateUserPasswordParams(id, password string) (generated.UpdateUserPasswordParams, error) {
+	var uuidID pgtype.UUID
+	err := uuidID.Scan(id)
+	if err != nil {
+		return generated.UpdateUserPasswordParams{}, errors.New("can't parse id as uuid")
+	}
+
+	return generated.UpdateUserPasswordParams{
+		ID:       uuidID,
+		Password: password,
+	}, nil
+}
+
+func newCreateRefreshTokenParams(userID, familyID, tokenHash string, expiresAt time.Time) (generated.CreateRefreshTokenParams, error) {
+	var userUUID, familyUUID pgtype.UUID
+	err := userUUID.Scan(userID)
+	if err != nil {
+		return generated.CreateRefreshTokenParams{}, errors.New("can't parse user id as uuid")
+	}
+	err = familyUUID.Scan(familyID)
+	if err != nil {
+		return generated.CreateRefreshTokenParams{}, errors.New("can't parse refresh token family id as uuid")
+	}
+
+	return generated.CreateRefreshTokenParams{
+		UserID:    userUUID,
+		FamilyID:  familyUUID,
+		TokenHash: tokenHash,
+		ExpiresAt: pgtype.Timestamptz{
+			Time:  expiresAt,
+			Valid: true,
+		},
+	}, nil
+}
+
+// newOptionalUUID parses a nullable UUID column value, an empty string is NULL
+func newOptionalUUID(id string) (pgtype.UUID, error) {
+	var uuidID pgtype.UUID
+	if id == "" {
+		return uuidID, nil
+	}
+
+	err := uuidID.Scan(id)
+	return uuidID, err
+}
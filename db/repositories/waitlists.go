@@ -0,0 +1,164 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"siakad-poc/common"
+	"siakad-poc/db/generated"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// WaitlistRepository holds the students waiting for a seat in full course offerings. Only waiting entries are read,
+// closed entries are kept as a record of who got a seat and who gave up.
+type WaitlistRepository interface {
+	GetWaitlistEntry(ctx context.Context, courseOfferingID, studentID string) (generated.CourseOfferingWaitlist, error)
+	GetWaitlistPosition(ctx context.Context, courseOfferingID string, position int64) (generated.GetWaitlistPositionRow, error)
+	CloseWaitlistEntry(ctx context.Context, courseOfferingID, studentID, status string) (generated.CourseOfferingWaitlist, error)
+
+	// Transaction-aware methods - these methods accept a TxContext for use within transactions
+	GetWaitlistEntryTx(txCtx *common.TxContext, courseOfferingID, studentID string) (generated.CourseOfferingWaitlist, error)
+	CreateWaitlistEntryTx(txCtx *common.TxContext, id, courseOfferingID, studentID string) (generated.CourseOfferingWaitlist, error)
+	ListWaitlistEntriesForUpdateTx(txCtx *common.TxContext, courseOfferingID string) ([]generated.CourseOfferingWaitlist, error)
+	CloseWaitlistEntryTx(txCtx *common.TxContext, courseOfferingID, studentID, status, courseRegistrationID string) (generated.CourseOfferingWaitlist, error)
+}
+
+type DefaultWaitlistRepository struct {
+	query *generated.Queries
+	pool  *pgxpool.Pool
+}
+
+// Compile time interface conformance check
+var _ WaitlistRepository = (*DefaultWaitlistRepository)(nil)
+
+func NewDefaultWaitlistRepository(pool *pgxpool.Pool) *DefaultWaitlistRepository {
+	return &DefaultWaitlistRepository{
+		query: generated.New(pool),
+		pool:  pool,
+	}
+}
+
+func (r *DefaultWaitlistRepository) GetWaitlistEntry(ctx context.Context, courseOfferingID, studentID string) (generated.CourseOfferingWaitlist, error) {
+	return getWaitlistEntry(ctx, r.query, courseOfferingID, studentID)
+}
+
+// GetWaitlistPosition returns the place of the entry at the given position among the waiting entries of the
+// offering, counting from 1, and how many students are waiting.
+func (r *DefaultWaitlistRepository) GetWaitlistPosition(ctx context.Context, courseOfferingID string, position int64) (generated.GetWaitlistPositionRow, error) {
+	var courseOfferingUUID pgtype.UUID
+	err := courseOfferingUUID.Scan(courseOfferingID)
+	if err != nil {
+		return generated.GetWaitlistPositionRow{}, errors.New("can't parse course offering id as uuid")
+	}
+
+	params := generated.GetWaitlistPositionParams{
+		Position:         position,
+		CourseOfferingID: courseOfferingUUID,
+	}
+
+	return r.query.GetWaitlistPosition(ctx, params)
+}
+
+// CloseWaitlistEntry closes the waiting entry of the student without a registration, e.g. when they leave the
+// waitlist.
+func (r *DefaultWaitlistRepository) CloseWaitlistEntry(ctx context.Context, courseOfferingID, studentID, status string) (generated.CourseOfferingWaitlist, error) {
+	return closeWaitlistEntry(ctx, r.query, courseOfferingID, studentID, status, "")
+}
+
+// Transaction-aware methods implementation
+
+func (r *DefaultWaitlistRepository) GetWaitlistEntryTx(txCtx *common.TxContext, courseOfferingID, studentID string) (generated.CourseOfferingWaitlist, error) {
+	return getWaitlistEntry(txCtx.Context(), r.query.WithTx(txCtx.Tx()), courseOfferingID, studentID)
+}
+
+// CreateWaitlistEntryTx puts the student at the end of the waitlist of the offering.
+func (r *DefaultWaitlistRepository) CreateWaitlistEntryTx(txCtx *common.TxContext, id, courseOfferingID, studentID string) (generated.CourseOfferingWaitlist, error) {
+	var idUUID, courseOfferingUUID, studentUUID pgtype.UUID
+	err := idUUID.Scan(id)
+	if err != nil {
+		return generated.CourseOfferingWaitlist{}, errors.New("can't parse waitlist entry id as uuid")
+	}
+	err = courseOfferingUUID.Scan(courseOfferingID)
+	if err != nil {
+		return generated.CourseOfferingWaitlist{}, errors.New("can't parse course offering id as uuid")
+	}
+	err = studentUUID.Scan(studentID)
+	if err != nil {
+		return generated.CourseOfferingWaitlist{}, errors.New("can't parse student id as uuid")
+	}
+
+	params := generated.CreateWaitlistEntryParams{
+		ID:               idUUID,
+		CourseOfferingID: courseOfferingUUID,
+		StudentID:        studentUUID,
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.CreateWaitlistEntry(txCtx.Context(), params)
+}
+
+// ListWaitlistEntriesForUpdateTx returns the waiting entries of the offering first come first served, they stay
+// locked until the transaction ends.
+func (r *DefaultWaitlistRepository) ListWaitlistEntriesForUpdateTx(txCtx *common.TxContext, courseOfferingID string) ([]generated.CourseOfferingWaitlist, error) {
+	var courseOfferingUUID pgtype.UUID
+	err := courseOfferingUUID.Scan(courseOfferingID)
+	if err != nil {
+		return nil, errors.New("can't parse course offering id as uuid")
+	}
+
+	txQueries := r.query.WithTx(txCtx.Tx())
+	return txQueries.ListWaitlistEntriesForUpdate(txCtx.Context(), courseOfferingUUID)
+}
+
+// CloseWaitlistEntryTx closes the waiting entry of the student, with the registration it got when enrolled.
+func (r *DefaultWaitlistRepository) CloseWaitlistEntryTx(txCtx *common.TxContext, courseOfferingID, studentID, status, courseRegistrationID string) (generated.CourseOfferingWaitlist, error) {
+	return closeWaitlistEntry(txCtx.Context(), r.query.WithTx(txCtx.Tx()), courseOfferingID, studentID, status, courseRegistrationID)
+}
+
+func getWaitlistEntry(ctx context.Context, query *generated.Queries, courseOfferingID, studentID string) (generated.CourseOfferingWaitlist, error) {
+	var courseOfferingUUID, studentUUID pgtype.UUID
+	err := courseOfferingUUID.Scan(courseOfferingID)
+	if err != nil {
+		return generated.CourseOfferingWaitlist{}, errors.New("can't parse course offering id as uuid")
+	}
+	err = studentUUID.Scan(studentID)
+	if err != nil {
+		return generated.CourseOfferingWaitlist{}, errors.New("can't parse student id as uuid")
+	}
+
+	params := generated.GetWaitlistEntryParams{
+		CourseOfferingID: courseOfferingUUID,
+		StudentID:        studentUUID,
+	}
+
+	return query.GetWaitlistEntry(ctx, params)
+}
+
+// closeWaitlistEntry sets the status of the waiting entry of the student, an empty registration id is stored as NULL.
+func closeWaitlistEntry(ctx context.Context, query *generated.Queries, courseOfferingID, studentID, status, courseRegistrationID string) (generated.CourseOfferingWaitlist, error) {
+	var courseOfferingUUID, studentUUID, courseRegistrationUUID pgtype.UUID
+	err := courseOfferingUUID.Scan(courseOfferingID)
+	if err != nil {
+		return generated.CourseOfferingWaitlist{}, errors.New("can't parse course offering id as uuid")
+	}
+	err = studentUUID.Scan(studentID)
+	if err != nil {
+		return generated.CourseOfferingWaitlist{}, errors.New("can't parse student id as uuid")
+	}
+	if courseRegistrationID != "" {
+		err = courseRegistrationUUID.Scan(courseRegistrationID)
+		if err != nil {
+			return generated.CourseOfferingWaitlist{}, errors.New("can't parse course registration id as uuid")
+		}
+	}
+
+	params := generated.CloseWaitlistEntryParams{
+		CourseOfferingID:     courseOfferingUUID,
+		StudentID:            studentUUID,
+		Status:               status,
+		CourseRegistrationID: courseRegistrationUUID,
+	}
+
+	return query.CloseWaitlistEntry(ctx, params)
+}
@@ -12,6 +12,7 @@ select
     co.section_code,
     co.capacity,
     co.start_time as course_offering_start_time,
+    co.room_id,
     co.created_at as course_offering_created_at,
     co.updated_at as course_offering_updated_at,
     co.deleted_at as course_offering_deleted_at,
@@ -19,38 +20,106 @@ select
     c.code as course_code,
     c.name as course_name,
     c.credit,
+    c.minutes_per_credit,
     c.created_at as course_created_at,
     c.updated_at as course_updated_at,
-    c.deleted_at as course_deleted_at
+    c.deleted_at as course_deleted_at,
+    s.start_time as semester_start_time,
+    s.end_time as semester_end_time,
+    s.drop_deadline as semester_drop_deadline
 from course_offerings co
 join courses c on co.course_id = c.id
+join semesters s on co.semester_id = s.id
 where co.id = $1;
 
+-- name: GetCourseOfferingsByRoom :many
+-- Offerings held in the room with their semester bounds, to detect double bookings. Lock the room with
+-- GetRoomForUpdate first, the read alone doesn't stop a concurrent offering from taking the same slot
+select 
+    co.id as course_offering_id,
+    co.semester_id,
+    co.course_id,
+    co.section_code,
+    co.capacity,
+    co.start_time as course_offering_start_time,
+    co.room_id,
+    c.code as course_code,
+    c.name as course_name,
+    c.credit,
+    c.minutes_per_credit,
+    s.start_time as semester_start_time,
+    s.end_time as semester_end_time
+from course_offerings co
+join courses c on co.course_id = c.id
+join semesters s on co.semester_id = s.id
+where co.room_id = $1 and co.deleted_at IS NULL;
+
+-- name: GetCourseOfferingsByLecturer :many
+-- Offerings of the semester taught by the lecturer with their semester bounds, to detect clashes and sum the
+-- teaching load
+select 
+    co.id as course_offering_id,
+    co.semester_id,
+    co.course_id,
+    co.section_code,
+    co.capacity,
+    co.start_time as course_offering_start_time,
+    co.room_id,
+    c.code as course_code,
+    c.name as course_name,
+    c.credit,
+    c.minutes_per_credit,
+    s.start_time as semester_start_time,
+    s.end_time as semester_end_time
+from course_offering_lecturers col
+join course_offerings co on col.course_offering_id = co.id
+join courses c on co.course_id = c.id
+join semesters s on co.semester_id = s.id
+where col.lecturer_id = $1 and co.semester_id = $2 and co.deleted_at IS NULL
+order by co.start_time, c.code, co.section_code;
+
 -- name: GetStudentEnrollmentsWithDetails :many
 select 
     cr.id as registration_id,
     cr.student_id,
     cr.course_offering_id,
+    co.semester_id,
     cr.created_at as registration_created_at,
     co.start_time as course_offering_start_time,
-    c.credit
+    c.credit,
+    c.minutes_per_credit,
+    s.start_time as semester_start_time,
+    s.end_time as semester_end_time
 from course_registrations cr
 join course_offerings co on cr.course_offering_id = co.id
 join courses c on co.course_id = c.id
-where cr.student_id = $1;
+join semesters s on co.semester_id = s.id
+where cr.student_id = $1 and cr.status <> 'rejected' and cr.deleted_at IS NULL;
 
 -- name: CountCourseOfferingEnrollments :one
-select count(*) from course_registrations where course_offering_id = $1;
+select count(*) from course_registrations where course_offering_id = $1 and status <> 'rejected' and deleted_at IS NULL;
 
 -- name: CheckEnrollmentExists :one
 select exists(
     select 1 from course_registrations 
-    where student_id = $1 and course_offering_id = $2
+    where student_id = $1 and course_offering_id = $2 and deleted_at IS NULL
 );
 
+-- name: GetEnrollment :one
+select * from course_registrations
+where student_id = $1 and course_offering_id = $2 and deleted_at IS NULL;
+
+-- name: DropEnrollment :one
+-- Dropped registrations are soft deleted, they free their seat and can be registered again
+update course_registrations
+set deleted_at = now(), updated_at = now(), dropped_by = $2, drop_reason = $3
+where id = $1 and deleted_at IS NULL
+returning *;
+
 -- name: CreateEnrollment :one
-insert into course_registrations (id, student_id, course_offering_id, created_at, updated_at)
-values (gen_random_uuid(), $1, $2, now(), now())
+-- New registrations are pending lines of the study plan until the academic advisor reviews them
+insert into course_registrations (id, student_id, course_offering_id, study_plan_id, status, created_at, updated_at)
+values (gen_random_uuid(), $1, $2, $3, 'pending', now(), now())
 returning *;
 
 -- name: GetCourseOfferingsWithPagination :many
@@ -61,6 +130,7 @@ select
     co.section_code,
     co.capacity,
     co.start_time as course_offering_start_time,
+    co.room_id,
     co.created_at as course_offering_created_at,
     co.updated_at as course_offering_updated_at,
     co.deleted_at as course_offering_deleted_at,
@@ -68,6 +138,7 @@ select
     c.code as course_code,
     c.name as course_name,
     c.credit,
+    c.minutes_per_credit,
     c.created_at as course_created_at,
     c.updated_at as course_updated_at,
     c.deleted_at as course_deleted_at
@@ -83,13 +154,13 @@ from course_offerings co
 where co.deleted_at IS NULL;
 
 -- name: CreateCourseOffering :one
-insert into course_offerings (id, semester_id, course_id, section_code, capacity, start_time, created_at, updated_at)
-values (gen_random_uuid(), $1, $2, $3, $4, $5, now(), now())
+insert into course_offerings (id, semester_id, course_id, section_code, capacity, start_time, room_id, created_at, updated_at)
+values (gen_random_uuid(), $1, $2, $3, $4, $5, $6, now(), now())
 returning *;
 
 -- name: UpdateCourseOffering :one
 update course_offerings 
-set semester_id = $2, course_id = $3, section_code = $4, capacity = $5, start_time = $6, updated_at = now()
+set semester_id = $2, course_id = $3, section_code = $4, capacity = $5, start_time = $6, room_id = $7, updated_at = now()
 where id = $1 and deleted_at IS NULL
 returning *;
 
@@ -107,6 +178,7 @@ select
     co.section_code,
     co.capacity,
     co.start_time as course_offering_start_time,
+    co.room_id,
     co.created_at as course_offering_created_at,
     co.updated_at as course_offering_updated_at,
     co.deleted_at as course_offering_deleted_at,
@@ -114,9 +186,118 @@ select
     c.code as course_code,
     c.name as course_name,
     c.credit,
+    c.minutes_per_credit,
     c.created_at as course_created_at,
     c.updated_at as course_updated_at,
     c.deleted_at as course_deleted_at
 from course_offerings co
 join courses c on co.course_id = c.id
-where co.id = $1 and co.deleted_at IS NULL;
\ No newline at end of file
+where co.id = $1 and co.deleted_at IS NULL;
+
+-- name: GetCourseOfferingsByStudyProgramWithPagination :many
+select 
+    co.id as course_offering_id,
+    co.semester_id,
+    co.course_id,
+    co.section_code,
+    co.capacity,
+    co.start_time as course_offering_start_time,
+    co.room_id,
+    co.created_at as course_offering_created_at,
+    co.updated_at as course_offering_updated_at,
+    co.deleted_at as course_offering_deleted_at,
+    c.id as course_id,
+    c.code as course_code,
+    c.name as course_name,
+    c.credit,
+    c.minutes_per_credit,
+    c.created_at as course_created_at,
+    c.updated_at as course_updated_at,
+    c.deleted_at as course_deleted_at
+from course_offerings co
+join courses c on co.course_id = c.id
+where co.deleted_at IS NULL
+  and exists(
+      select 1 from curriculum_courses cc
+      join curricula cu on cc.curriculum_id = cu.id
+      where cc.course_id = co.course_id and cu.study_program_id = sqlc.arg('study_program_id') and cu.deleted_at IS NULL
+  )
+order by co.created_at desc
+limit sqlc.arg('limit') offset sqlc.arg('offset');
+
+-- name: CountCourseOfferingsByStudyProgram :one
+select count(*) 
+from course_offerings co
+where co.deleted_at IS NULL
+  and exists(
+      select 1 from curriculum_courses cc
+      join curricula cu on cc.curriculum_id = cu.id
+      where cc.course_id = co.course_id and cu.study_program_id = $1 and cu.deleted_at IS NULL
+  );
+
+-- name: IsCourseInStudyProgram :one
+-- A course belongs to a study program when it is part of one of its curricula
+select exists(
+    select 1 from curriculum_courses cc
+    join curricula cu on cc.curriculum_id = cu.id
+    where cc.course_id = $1 and cu.study_program_id = $2 and cu.deleted_at IS NULL
+);
+
+-- name: IsCourseInActiveCurriculum :one
+-- Offerings can only be opened for courses that are part of at least one active curriculum
+select exists(
+    select 1 from curriculum_courses cc
+    join curricula cu on cc.curriculum_id = cu.id
+    where cc.course_id = $1 and cu.is_active and cu.deleted_at IS NULL
+);
+
+-- name: GetMissingPrerequisites :many
+-- Prerequisites of the course the student has no passing grade for, retired prerequisite courses are ignored
+select c.id, c.code, c.name from course_prerequisites cp
+join courses c on cp.prerequisite_course_id = c.id
+where cp.course_id = sqlc.arg('course_id') and c.deleted_at IS NULL
+  and not exists (
+    select 1 from course_completions cc
+    where cc.student_id = sqlc.arg('student_id')
+      and cc.course_id = cp.prerequisite_course_id
+      and cc.grade = any(sqlc.arg('passing_grades')::text[])
+  )
+order by c.code;
+
+-- name: GetMissingCorequisites :many
+-- Co-requisites of the course the student neither takes in the semester nor passed, retired courses are ignored
+select c.id, c.code, c.name from course_corequisites cq
+join courses c on cq.corequisite_course_id = c.id
+where cq.course_id = sqlc.arg('course_id') and c.deleted_at IS NULL
+  and not exists (
+    select 1 from course_registrations cr
+    join course_offerings co on cr.course_offering_id = co.id
+    where cr.student_id = sqlc.arg('student_id')
+      and co.course_id = cq.corequisite_course_id
+      and co.semester_id = sqlc.arg('semester_id')
+      and co.deleted_at IS NULL
+      and cr.status <> 'rejected'
+      and cr.deleted_at IS NULL
+  )
+  and not exists (
+    select 1 from course_completions cc
+    where cc.student_id = sqlc.arg('student_id')
+      and cc.course_id = cq.corequisite_course_id
+      and cc.grade = any(sqlc.arg('passing_grades')::text[])
+  )
+order by c.code;
+
+-- name: GetEnrolledExcludedCourses :many
+-- Courses the student takes in the semester that may not be combined with the course
+select c.id, c.code, c.name from course_registrations cr
+join course_offerings co on cr.course_offering_id = co.id
+join courses c on co.course_id = c.id
+join course_exclusions ce
+  on (ce.course_id = sqlc.arg('course_id') and ce.excluded_course_id = c.id)
+  or (ce.excluded_course_id = sqlc.arg('course_id') and ce.course_id = c.id)
+where cr.student_id = sqlc.arg('student_id')
+  and co.semester_id = sqlc.arg('semester_id')
+  and co.deleted_at IS NULL
+  and cr.status <> 'rejected'
+  and cr.deleted_at IS NULL
+order by c.code;
@@ -0,0 +1,78 @@
+-- name: ListAcademicYears :many
+select * from academic_years
+where deleted_at IS NULL
+order by start_time desc
+limit $1 offset $2;
+
+-- name: CountAcademicYears :one
+select count(*) from academic_years
+where deleted_at IS NULL;
+
+-- name: GetAcademicYear :one
+select * from academic_years
+where id = $1 and deleted_at IS NULL;
+
+-- name: GetAcademicYearByCode :one
+-- Includes soft-deleted academic years, their code can't be reused
+select * from academic_years
+where code = $1;
+
+-- name: CreateAcademicYear :one
+insert into academic_years (id, code, start_time, end_time)
+values ($1, $2, $3, $4)
+returning *;
+
+-- name: UpdateAcademicYear :one
+update academic_years
+set code = $2, start_time = $3, end_time = $4, updated_at = now()
+where id = $1 and deleted_at IS NULL
+returning *;
+
+-- name: DeleteAcademicYear :one
+update academic_years
+set deleted_at = now(), updated_at = now()
+where id = $1 and deleted_at IS NULL
+returning *;
+
+-- name: ListSemestersByAcademicYear :many
+select * from semesters
+where academic_year_id = $1 and deleted_at IS NULL
+order by start_time;
+
+-- name: GetSemester :one
+select * from semesters
+where id = $1 and deleted_at IS NULL;
+
+-- name: GetSemesterByCode :one
+-- Includes soft-deleted semesters, the code is unique within the academic year
+select * from semesters
+where academic_year_id = $1 and code = $2;
+
+-- name: GetCurrentSemester :one
+select s.* from semesters s
+join academic_years ay on s.academic_year_id = ay.id
+where s.start_time <= now() and s.end_time > now()
+  and s.deleted_at IS NULL and ay.deleted_at IS NULL
+order by s.start_time desc
+limit 1;
+
+-- name: CreateSemester :one
+insert into semesters (id, academic_year_id, code, start_time, end_time, drop_deadline)
+values ($1, $2, $3, $4, $5, $6)
+returning *;
+
+-- name: UpdateSemester :one
+update semesters
+set code = $2, start_time = $3, end_time = $4, drop_deadline = $5, updated_at = now()
+where id = $1 and deleted_at IS NULL
+returning *;
+
+-- name: DeleteSemester :one
+update semesters
+set deleted_at = now(), updated_at = now()
+where id = $1 and deleted_at IS NULL
+returning *;
+
+-- name: CountCourseOfferingsBySemester :one
+select count(*) from course_offerings
+where semester_id = $1 and deleted_at IS NULL;
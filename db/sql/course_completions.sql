@@ -0,0 +1,15 @@
+-- name: CreateCourseCompletions :copyfrom
+insert into course_completions (id, student_id, course_id, grade, created_at)
+values ($1, $2, $3, $4, $5);
+
+-- name: GetStudentsByNims :many
+select id, nim from students
+where nim = any(sqlc.arg('nims')::text[]) and deleted_at IS NULL;
+
+-- name: GetCoursesByCodes :many
+select * from courses
+where code = any(sqlc.arg('codes')::text[]) and deleted_at IS NULL;
+
+-- name: GetCourseCompletionsByStudents :many
+select * from course_completions
+where student_id = any(sqlc.arg('student_ids')::uuid[]);
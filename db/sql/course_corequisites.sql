@@ -0,0 +1,23 @@
+-- name: ListCourseCorequisites :many
+select
+    cq.id,
+    cq.course_id,
+    cq.corequisite_course_id,
+    c.code as corequisite_course_code,
+    c.name as corequisite_course_name,
+    c.credit as corequisite_course_credit,
+    cq.created_at
+from course_corequisites cq
+join courses c on cq.corequisite_course_id = c.id
+where cq.course_id = $1
+order by c.code;
+
+-- name: CreateCourseCorequisite :one
+insert into course_corequisites (id, course_id, corequisite_course_id)
+values ($1, $2, $3)
+returning *;
+
+-- name: DeleteCourseCorequisite :one
+delete from course_corequisites
+where course_id = $1 and corequisite_course_id = $2
+returning *;
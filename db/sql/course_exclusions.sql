@@ -0,0 +1,24 @@
+-- name: ListCourseExclusions :many
+-- Exclusions go both ways, excluded_course_id is the other course of the pair
+select
+    ce.id,
+    c.id as excluded_course_id,
+    c.code as excluded_course_code,
+    c.name as excluded_course_name,
+    c.credit as excluded_course_credit,
+    ce.created_at
+from course_exclusions ce
+join courses c on c.id = case when ce.course_id = sqlc.arg('course_id') then ce.excluded_course_id else ce.course_id end
+where ce.course_id = sqlc.arg('course_id') or ce.excluded_course_id = sqlc.arg('course_id')
+order by c.code;
+
+-- name: CreateCourseExclusion :one
+insert into course_exclusions (id, course_id, excluded_course_id)
+values ($1, $2, $3)
+returning *;
+
+-- name: DeleteCourseExclusion :one
+delete from course_exclusions
+where (course_id = sqlc.arg('course_id') and excluded_course_id = sqlc.arg('excluded_course_id'))
+   or (course_id = sqlc.arg('excluded_course_id') and excluded_course_id = sqlc.arg('course_id'))
+returning *;
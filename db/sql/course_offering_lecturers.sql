@@ -0,0 +1,23 @@
+-- name: ListCourseOfferingLecturers :many
+select
+    col.id,
+    col.course_offering_id,
+    col.lecturer_id,
+    l.nidn,
+    u.name as lecturer_name,
+    col.created_at
+from course_offering_lecturers col
+join lecturers l on col.lecturer_id = l.id
+join users u on l.user_id = u.id
+where col.course_offering_id = $1
+order by l.nidn;
+
+-- name: CreateCourseOfferingLecturer :one
+insert into course_offering_lecturers (id, course_offering_id, lecturer_id)
+values ($1, $2, $3)
+returning *;
+
+-- name: DeleteCourseOfferingLecturer :one
+delete from course_offering_lecturers
+where course_offering_id = $1 and lecturer_id = $2
+returning *;
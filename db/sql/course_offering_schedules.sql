@@ -0,0 +1,12 @@
+-- name: ListCourseOfferingSchedules :many
+-- Weekly meetings of the offerings, ordered by day and time
+select * from course_offering_schedules
+where course_offering_id = any(sqlc.arg('course_offering_ids')::uuid[])
+order by course_offering_id, day_of_week, start_time;
+
+-- name: CreateCourseOfferingSchedule :exec
+insert into course_offering_schedules (id, course_offering_id, day_of_week, start_time)
+values (gen_random_uuid(), $1, $2, $3);
+
+-- name: DeleteCourseOfferingSchedules :exec
+delete from course_offering_schedules where course_offering_id = $1;
@@ -0,0 +1,34 @@
+-- name: ListCoursePrerequisites :many
+select
+    cp.id,
+    cp.course_id,
+    cp.prerequisite_course_id,
+    c.code as prerequisite_course_code,
+    c.name as prerequisite_course_name,
+    c.credit as prerequisite_course_credit,
+    cp.created_at
+from course_prerequisites cp
+join courses c on cp.prerequisite_course_id = c.id
+where cp.course_id = $1
+order by c.code;
+
+-- name: CreateCoursePrerequisite :one
+insert into course_prerequisites (id, course_id, prerequisite_course_id)
+values ($1, $2, $3)
+returning *;
+
+-- name: DeleteCoursePrerequisite :one
+delete from course_prerequisites
+where course_id = $1 and prerequisite_course_id = $2
+returning *;
+
+-- name: IsCoursePrerequisiteOf :one
+-- Whether the course is already required by the other course, directly or through a chain of prerequisites
+with recursive chain (course_id) as (
+    select cp.prerequisite_course_id from course_prerequisites cp
+    where cp.course_id = sqlc.arg('other_course_id')
+    union
+    select cp.prerequisite_course_id from course_prerequisites cp
+    join chain on cp.course_id = chain.course_id
+)
+select exists(select 1 from chain where chain.course_id = sqlc.arg('course_id'));
@@ -0,0 +1,38 @@
+-- name: ListCourses :many
+select * from courses
+where (sqlc.narg('search')::text IS NULL OR code ilike '%' || sqlc.narg('search') || '%' OR name ilike '%' || sqlc.narg('search') || '%')
+  and deleted_at IS NULL
+order by code
+limit sqlc.arg('limit') offset sqlc.arg('offset');
+
+-- name: CountCourses :one
+select count(*) from courses
+where (sqlc.narg('search')::text IS NULL OR code ilike '%' || sqlc.narg('search') || '%' OR name ilike '%' || sqlc.narg('search') || '%')
+  and deleted_at IS NULL;
+
+-- name: GetCourseByCode :one
+select * from courses
+where code = $1 and deleted_at IS NULL;
+
+-- name: CreateCourse :one
+insert into courses (id, code, name, credit, minutes_per_credit)
+values ($1, $2, $3, $4, $5)
+returning *;
+
+-- name: UpdateCourse :one
+update courses
+set code = $2, name = $3, credit = $4, minutes_per_credit = $5, updated_at = now()
+where id = $1 and deleted_at IS NULL
+returning *;
+
+-- name: DeleteCourse :one
+update courses
+set deleted_at = now(), updated_at = now()
+where id = $1 and deleted_at IS NULL
+returning *;
+
+-- name: CountActiveCourseOfferingsByCourse :one
+-- Offerings are active until their semester ends, offerings of past semesters are history
+select count(*) from course_offerings co
+join semesters s on co.semester_id = s.id
+where co.course_id = $1 and co.deleted_at IS NULL and s.end_time > now();
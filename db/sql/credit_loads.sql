@@ -0,0 +1,41 @@
+-- name: CreateSemesterGPAs :copyfrom
+insert into semester_gpas (id, student_id, semester_id, gpa, created_at)
+values ($1, $2, $3, $4, $5);
+
+-- name: GetSemestersByAcademicYearCodes :many
+select s.id, ay.code as academic_year_code, s.code
+from semesters s
+join academic_years ay on s.academic_year_id = ay.id
+where ay.code = any(sqlc.arg('academic_year_codes')::text[])
+  and s.deleted_at IS NULL and ay.deleted_at IS NULL;
+
+-- name: GetSemesterGPAsByStudents :many
+select * from semester_gpas
+where student_id = any(sqlc.arg('student_ids')::uuid[]);
+
+-- name: GetPreviousSemesterGPA :one
+-- The latest GPA of a semester that started before the given one, semesters without a GPA (e.g. on leave) are skipped
+select g.gpa, s.code as semester_code, ay.code as academic_year_code
+from semester_gpas g
+join semesters s on g.semester_id = s.id
+join academic_years ay on s.academic_year_id = ay.id
+where g.student_id = $1
+  and s.start_time < (select start_time from semesters where id = $2)
+order by s.start_time desc
+limit 1;
+
+-- name: GetStudentCreditAllowance :one
+select * from student_credit_allowances
+where student_id = $1;
+
+-- name: UpsertStudentCreditAllowance :one
+insert into student_credit_allowances (student_id, max_credits, set_by)
+values ($1, $2, $3)
+on conflict (student_id) do update
+set max_credits = excluded.max_credits, set_by = excluded.set_by, updated_at = now()
+returning *;
+
+-- name: DeleteStudentCreditAllowance :one
+delete from student_credit_allowances
+where student_id = $1
+returning *;
@@ -0,0 +1,104 @@
+-- name: ListStudyPrograms :many
+select * from study_programs
+where (sqlc.narg('level')::text IS NULL OR level = sqlc.narg('level'))
+  and deleted_at IS NULL
+order by code
+limit sqlc.arg('limit') offset sqlc.arg('offset');
+
+-- name: CountStudyPrograms :one
+select count(*) from study_programs
+where (sqlc.narg('level')::text IS NULL OR level = sqlc.narg('level'))
+  and deleted_at IS NULL;
+
+-- name: GetStudyProgramByCode :one
+-- Soft-deleted study programs are included, their code stays reserved by the unique constraint
+select * from study_programs
+where code = $1;
+
+-- name: CreateStudyProgram :one
+insert into study_programs (id, code, name, level)
+values ($1, $2, $3, $4)
+returning *;
+
+-- name: UpdateStudyProgram :one
+update study_programs
+set code = $2, name = $3, level = $4, updated_at = now()
+where id = $1 and deleted_at IS NULL
+returning *;
+
+-- name: DeleteStudyProgram :one
+update study_programs
+set deleted_at = now(), updated_at = now()
+where id = $1 and deleted_at IS NULL
+returning *;
+
+-- name: IsStudyProgramInUse :one
+-- A study program is in use while curricula, students, lecturers or staff users still reference it
+select exists(select 1 from curricula where study_program_id = $1 and deleted_at IS NULL)
+    or exists(select 1 from students where study_program_id = $1 and deleted_at IS NULL)
+    or exists(select 1 from lecturers where homebase_study_program_id = $1 and deleted_at IS NULL)
+    or exists(select 1 from users where study_program_id = $1 and deleted_at IS NULL);
+
+-- name: ListCurricula :many
+select * from curricula
+where (sqlc.narg('study_program_id')::uuid IS NULL OR study_program_id = sqlc.narg('study_program_id'))
+  and (sqlc.narg('is_active')::boolean IS NULL OR is_active = sqlc.narg('is_active'))
+  and deleted_at IS NULL
+order by code
+limit sqlc.arg('limit') offset sqlc.arg('offset');
+
+-- name: CountCurricula :one
+select count(*) from curricula
+where (sqlc.narg('study_program_id')::uuid IS NULL OR study_program_id = sqlc.narg('study_program_id'))
+  and (sqlc.narg('is_active')::boolean IS NULL OR is_active = sqlc.narg('is_active'))
+  and deleted_at IS NULL;
+
+-- name: GetCurriculum :one
+select * from curricula
+where id = $1 and deleted_at IS NULL;
+
+-- name: GetCurriculumByCode :one
+-- Soft-deleted curricula are included, their code stays reserved within the study program
+select * from curricula
+where study_program_id = $1 and code = $2;
+
+-- name: CreateCurriculum :one
+insert into curricula (id, study_program_id, code, name, is_active)
+values ($1, $2, $3, $4, $5)
+returning *;
+
+-- name: UpdateCurriculum :one
+update curricula
+set code = $2, name = $3, is_active = $4, updated_at = now()
+where id = $1 and deleted_at IS NULL
+returning *;
+
+-- name: DeleteCurriculum :one
+update curricula
+set deleted_at = now(), updated_at = now()
+where id = $1 and deleted_at IS NULL
+returning *;
+
+-- name: ListCurriculumCourses :many
+select
+    cc.id,
+    cc.curriculum_id,
+    cc.course_id,
+    c.code as course_code,
+    c.name as course_name,
+    c.credit,
+    cc.created_at
+from curriculum_courses cc
+join courses c on cc.course_id = c.id
+where cc.curriculum_id = $1
+order by c.code;
+
+-- name: CreateCurriculumCourse :one
+insert into curriculum_courses (id, curriculum_id, course_id)
+values ($1, $2, $3)
+returning *;
+
+-- name: DeleteCurriculumCourse :one
+delete from curriculum_courses
+where curriculum_id = $1 and course_id = $2
+returning *;
@@ -0,0 +1,65 @@
+-- name: GetLecturerProfileByUserID :one
+select
+    l.id,
+    l.nidn,
+    l.nuptk,
+    sp.id as homebase_study_program_id,
+    sp.code as homebase_study_program_code,
+    sp.name as homebase_study_program_name,
+    sp.level as homebase_study_program_level
+from lecturers l
+join study_programs sp on l.homebase_study_program_id = sp.id
+where l.user_id = $1 and l.deleted_at IS NULL;
+
+-- name: ListLecturers :many
+select * from lecturers
+where (sqlc.narg('homebase_study_program_id')::uuid IS NULL OR homebase_study_program_id = sqlc.narg('homebase_study_program_id'))
+  and (sqlc.narg('nidn')::text IS NULL OR nidn ilike '%' || sqlc.narg('nidn') || '%')
+  and deleted_at IS NULL
+order by nidn
+limit sqlc.arg('limit') offset sqlc.arg('offset');
+
+-- name: CountLecturers :one
+select count(*) from lecturers
+where (sqlc.narg('homebase_study_program_id')::uuid IS NULL OR homebase_study_program_id = sqlc.narg('homebase_study_program_id'))
+  and (sqlc.narg('nidn')::text IS NULL OR nidn ilike '%' || sqlc.narg('nidn') || '%')
+  and deleted_at IS NULL;
+
+-- name: GetLecturer :one
+select * from lecturers
+where id = $1 and deleted_at IS NULL;
+
+-- name: ListLecturersForUpdate :many
+-- Locks the active lecturers until the end of the transaction, in id order so concurrent transactions locking the same
+-- lecturers can't deadlock
+select * from lecturers
+where id = any($1::uuid[]) and deleted_at IS NULL
+order by id
+for update;
+
+-- name: GetLecturerByNIDN :one
+-- Soft-deleted lecturers are included, their NIDN stays reserved by the unique constraint
+select * from lecturers
+where nidn = $1;
+
+-- name: GetLecturerByUserID :one
+-- Soft-deleted lecturers are included, the user stays reserved by the unique constraint
+select * from lecturers
+where user_id = $1;
+
+-- name: CreateLecturer :one
+insert into lecturers (id, user_id, homebase_study_program_id, nidn, nuptk)
+values ($1, $2, $3, $4, $5)
+returning *;
+
+-- name: UpdateLecturer :one
+update lecturers
+set nidn = $2, nuptk = $3, homebase_study_program_id = $4, updated_at = now()
+where id = $1 and deleted_at IS NULL
+returning *;
+
+-- name: DeleteLecturer :one
+update lecturers
+set deleted_at = now(), updated_at = now()
+where id = $1 and deleted_at IS NULL
+returning *;
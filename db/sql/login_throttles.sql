@@ -0,0 +1,22 @@
+-- name: GetLoginThrottle :one
+select * from login_throttles where scope = $1 and key = $2;
+
+-- name: RecordLoginFailure :one
+insert into login_throttles (scope, key, failed_attempts, last_failed_at)
+values (sqlc.arg(scope), sqlc.arg(key), 1, sqlc.arg(failed_at))
+on conflict (scope, key) do update
+set failed_attempts = case
+        when login_throttles.last_failed_at < sqlc.arg(window_start) then 1
+        else login_throttles.failed_attempts + 1
+    end,
+    last_failed_at = excluded.last_failed_at
+returning *;
+
+-- name: LockLoginThrottle :exec
+update login_throttles
+set locked_until = $3,
+    failed_attempts = 0
+where scope = $1 and key = $2;
+
+-- name: DeleteLoginThrottle :exec
+delete from login_throttles where scope = $1 and key = $2;
@@ -0,0 +1,45 @@
+-- name: GetUserMFA :one
+select * from user_mfa where user_id = $1;
+
+-- name: UpsertPendingUserMFA :one
+-- Starts or restarts an enrollment, a confirmed enrollment is left untouched and no row is returned
+insert into user_mfa (user_id, secret, confirmed_at, last_used_step, created_at)
+values ($1, $2, null, null, now())
+on conflict (user_id) do update
+set secret = excluded.secret,
+    last_used_step = null,
+    created_at = excluded.created_at
+where user_mfa.confirmed_at is null
+returning *;
+
+-- name: ConfirmUserMFA :one
+update user_mfa
+set confirmed_at = now(),
+    last_used_step = $2
+where user_id = $1 and confirmed_at is null
+returning *;
+
+-- name: UseUserMFAStep :one
+update user_mfa
+set last_used_step = $2
+where user_id = $1 and (last_used_step is null or last_used_step < $2)
+returning *;
+
+-- name: DeleteUserMFA :exec
+delete from user_mfa where user_id = $1;
+
+-- name: CreateMFARecoveryCode :exec
+insert into mfa_recovery_codes (id, user_id, code_hash, created_at)
+values (gen_random_uuid(), $1, $2, now());
+
+-- name: DeleteMFARecoveryCodes :exec
+delete from mfa_recovery_codes where user_id = $1;
+
+-- name: UseMFARecoveryCode :one
+update mfa_recovery_codes
+set used_at = now()
+where user_id = $1 and code_hash = $2 and used_at is null
+returning *;
+
+-- name: CountUnusedMFARecoveryCodes :one
+select count(*) from mfa_recovery_codes where user_id = $1 and used_at is null;
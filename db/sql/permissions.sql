@@ -0,0 +1,23 @@
+-- name: ListPermissions :many
+select * from permissions order by name;
+
+-- name: ListRoles :many
+select * from roles order by id;
+
+-- name: GetRole :one
+select * from roles where id = $1;
+
+-- name: CreateRole :one
+insert into roles (id, name, created_at)
+values ($1, $2, now())
+returning *;
+
+-- name: ListRolePermissions :many
+select * from role_permissions order by role_id, permission;
+
+-- name: DeleteRolePermissions :exec
+delete from role_permissions where role_id = $1;
+
+-- name: CreateRolePermission :exec
+insert into role_permissions (role_id, permission, created_at)
+values ($1, $2, now());
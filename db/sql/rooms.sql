@@ -0,0 +1,97 @@
+-- name: ListBuildings :many
+select * from buildings
+where deleted_at IS NULL
+order by code
+limit $1 offset $2;
+
+-- name: CountBuildings :one
+select count(*) from buildings
+where deleted_at IS NULL;
+
+-- name: GetBuilding :one
+select * from buildings
+where id = $1 and deleted_at IS NULL;
+
+-- name: GetBuildingByCode :one
+select * from buildings
+where code = $1 and deleted_at IS NULL;
+
+-- name: CreateBuilding :one
+insert into buildings (id, code, name)
+values ($1, $2, $3)
+returning *;
+
+-- name: UpdateBuilding :one
+update buildings
+set code = $2, name = $3, updated_at = now()
+where id = $1 and deleted_at IS NULL
+returning *;
+
+-- name: DeleteBuilding :one
+update buildings
+set deleted_at = now(), updated_at = now()
+where id = $1 and deleted_at IS NULL
+returning *;
+
+-- name: ListRooms :many
+select * from rooms
+where (sqlc.narg('building_id')::uuid IS NULL OR building_id = sqlc.narg('building_id'))
+  and (sqlc.narg('min_capacity')::integer IS NULL OR capacity >= sqlc.narg('min_capacity'))
+  and deleted_at IS NULL
+order by code
+limit sqlc.arg('limit') offset sqlc.arg('offset');
+
+-- name: CountRooms :one
+select count(*) from rooms
+where (sqlc.narg('building_id')::uuid IS NULL OR building_id = sqlc.narg('building_id'))
+  and (sqlc.narg('min_capacity')::integer IS NULL OR capacity >= sqlc.narg('min_capacity'))
+  and deleted_at IS NULL;
+
+-- name: ListRoomsByBuilding :many
+select * from rooms
+where building_id = $1 and deleted_at IS NULL
+order by code;
+
+-- name: GetRoom :one
+select * from rooms
+where id = $1 and deleted_at IS NULL;
+
+-- name: GetRoomForUpdate :one
+-- Locks the room until the end of the transaction, offerings assigned to the room at the same time are checked for
+-- double bookings one after the other
+select * from rooms
+where id = $1 and deleted_at IS NULL
+for update;
+
+-- name: GetRoomByCode :one
+-- Room codes are unique within their building
+select * from rooms
+where building_id = $1 and code = $2 and deleted_at IS NULL;
+
+-- name: CreateRoom :one
+insert into rooms (id, building_id, code, name, capacity)
+values ($1, $2, $3, $4, $5)
+returning *;
+
+-- name: UpdateRoom :one
+update rooms
+set code = $2, name = $3, capacity = $4, updated_at = now()
+where id = $1 and deleted_at IS NULL
+returning *;
+
+-- name: DeleteRoom :one
+update rooms
+set deleted_at = now(), updated_at = now()
+where id = $1 and deleted_at IS NULL
+returning *;
+
+-- name: GetLargestActiveCourseOfferingCapacityByRoom :one
+-- Offerings are active until their semester ends, the room must seat the largest of them
+select coalesce(max(co.capacity), 0)::integer as capacity from course_offerings co
+join semesters s on co.semester_id = s.id
+where co.room_id = $1 and co.deleted_at IS NULL and s.end_time > now();
+
+-- name: CountActiveCourseOfferingsByRoom :one
+select count(*) from course_offerings co
+join semesters s on co.semester_id = s.id
+where co.room_id = $1 and co.deleted_at IS NULL and s.end_time > now();
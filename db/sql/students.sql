@@ -0,0 +1,81 @@
+-- name: CreateStudents :copyfrom
+insert into students (id, user_id, study_program_id, nim, created_at, updated_at)
+values ($1, $2, $3, $4, $5, $6);
+
+-- name: GetExistingStudentNims :many
+select nim from students
+where nim = any(sqlc.arg('nims')::text[]);
+
+-- name: GetStudyProgramsByCodes :many
+select * from study_programs
+where code = any(sqlc.arg('codes')::text[]) and deleted_at IS NULL;
+
+-- name: GetStudyProgram :one
+select * from study_programs
+where id = $1 and deleted_at IS NULL;
+
+-- name: GetStudentProfileByUserID :one
+select
+    s.id,
+    s.nim,
+    sp.id as study_program_id,
+    sp.code as study_program_code,
+    sp.name as study_program_name,
+    sp.level as study_program_level,
+    l.id as academic_advisor_id,
+    l.nidn as academic_advisor_nidn,
+    u.name as academic_advisor_name
+from students s
+join study_programs sp on s.study_program_id = sp.id
+left join lecturers l on s.academic_advisor_id = l.id
+left join users u on l.user_id = u.id
+where s.user_id = $1 and s.deleted_at IS NULL;
+
+-- name: ListStudents :many
+select * from students
+where (sqlc.narg('study_program_id')::uuid IS NULL OR study_program_id = sqlc.narg('study_program_id'))
+  and (sqlc.narg('nim')::text IS NULL OR nim ilike '%' || sqlc.narg('nim') || '%')
+  and deleted_at IS NULL
+order by nim
+limit sqlc.arg('limit') offset sqlc.arg('offset');
+
+-- name: CountStudents :one
+select count(*) from students
+where (sqlc.narg('study_program_id')::uuid IS NULL OR study_program_id = sqlc.narg('study_program_id'))
+  and (sqlc.narg('nim')::text IS NULL OR nim ilike '%' || sqlc.narg('nim') || '%')
+  and deleted_at IS NULL;
+
+-- name: GetStudent :one
+select * from students
+where id = $1 and deleted_at IS NULL;
+
+-- name: GetStudentByNIM :one
+-- Soft-deleted students are included, their NIM stays reserved by the unique constraint
+select * from students
+where nim = $1;
+
+-- name: GetStudentByUserID :one
+-- Soft-deleted students are included, the user stays reserved by the unique constraint
+select * from students
+where user_id = $1;
+
+-- name: CreateStudent :one
+insert into students (id, user_id, study_program_id, nim, academic_advisor_id)
+values ($1, $2, $3, $4, $5)
+returning *;
+
+-- name: UpdateStudent :one
+update students
+set nim = $2, study_program_id = $3, academic_advisor_id = $4, updated_at = now()
+where id = $1 and deleted_at IS NULL
+returning *;
+
+-- name: DeleteStudent :one
+update students
+set deleted_at = now(), updated_at = now()
+where id = $1 and deleted_at IS NULL
+returning *;
+
+-- name: CountStudentsByAcademicAdvisor :one
+select count(*) from students
+where academic_advisor_id = $1 and deleted_at IS NULL;
@@ -0,0 +1,132 @@
+-- name: GetOrCreateStudyPlan :one
+-- Returns the plan of the student in the semester, created as a draft on the first registration. The row stays
+-- locked until the end of the transaction so registrations and reviews of the plan are serialized.
+insert into study_plans (id, student_id, semester_id)
+values ($1, $2, $3)
+on conflict (student_id, semester_id) do update set student_id = excluded.student_id
+returning *;
+
+-- name: GetStudyPlanForUpdate :one
+select * from study_plans
+where id = $1
+for update;
+
+-- name: GetStudyPlanWithStudent :one
+select
+    sp.id,
+    sp.student_id,
+    st.nim,
+    u.name as student_name,
+    st.academic_advisor_id,
+    sp.semester_id,
+    s.code as semester_code,
+    sp.status,
+    sp.created_at,
+    sp.updated_at
+from study_plans sp
+join students st on sp.student_id = st.id
+join users u on st.user_id = u.id
+join semesters s on sp.semester_id = s.id
+where sp.id = $1;
+
+-- name: ListStudyPlansByStudent :many
+select
+    sp.id,
+    sp.student_id,
+    st.nim,
+    u.name as student_name,
+    st.academic_advisor_id,
+    sp.semester_id,
+    s.code as semester_code,
+    sp.status,
+    sp.created_at,
+    sp.updated_at
+from study_plans sp
+join students st on sp.student_id = st.id
+join users u on st.user_id = u.id
+join semesters s on sp.semester_id = s.id
+where sp.student_id = $1
+order by s.start_time desc;
+
+-- name: ListStudyPlansByAcademicAdvisor :many
+select
+    sp.id,
+    sp.student_id,
+    st.nim,
+    u.name as student_name,
+    st.academic_advisor_id,
+    sp.semester_id,
+    s.code as semester_code,
+    sp.status,
+    sp.created_at,
+    sp.updated_at
+from study_plans sp
+join students st on sp.student_id = st.id
+join users u on st.user_id = u.id
+join semesters s on sp.semester_id = s.id
+where st.academic_advisor_id = sqlc.arg('academic_advisor_id')
+  and st.deleted_at IS NULL
+  and (sqlc.narg('status')::text IS NULL OR sp.status = sqlc.narg('status'))
+order by s.start_time desc, st.nim;
+
+-- name: UpdateStudyPlanStatus :one
+update study_plans
+set status = $2, updated_at = now()
+where id = $1
+returning *;
+
+-- name: ListStudyPlanLines :many
+select
+    cr.id,
+    cr.course_offering_id,
+    co.section_code,
+    c.code as course_code,
+    c.name as course_name,
+    c.credit,
+    cr.status,
+    cr.created_at,
+    cr.updated_at
+from course_registrations cr
+join course_offerings co on cr.course_offering_id = co.id
+join courses c on co.course_id = c.id
+where cr.study_plan_id = $1 and cr.deleted_at IS NULL
+order by c.code, co.section_code;
+
+-- name: ListStudyPlanWaitlistedCourseOfferings :many
+-- The offerings of the semester of the plan that its student is waiting for, in the order they joined the waitlists
+select w.course_offering_id
+from study_plans sp
+join course_offering_waitlists w on w.student_id = sp.student_id and w.status = 'waiting'
+join course_offerings co on w.course_offering_id = co.id and co.semester_id = sp.semester_id
+where sp.id = $1
+order by w.position;
+
+-- name: GetStudyPlanLine :one
+select * from course_registrations
+where id = $1 and study_plan_id = $2 and deleted_at IS NULL;
+
+-- name: UpdateStudyPlanLineStatus :one
+update course_registrations
+set status = $2, updated_at = now()
+where id = $1
+returning *;
+
+-- name: ListStudyPlanHistories :many
+select
+    h.id,
+    h.course_registration_id,
+    h.status_before,
+    h.status_after,
+    h.note,
+    h.changed_by,
+    u.name as changed_by_name,
+    h.created_at
+from study_plan_histories h
+join users u on h.changed_by = u.id
+where h.study_plan_id = $1
+order by h.created_at, h.id;
+
+-- name: CreateStudyPlanHistory :one
+insert into study_plan_histories (id, study_plan_id, course_registration_id, status_before, status_after, note, changed_by)
+values ($1, $2, $3, $4, $5, $6, $7)
+returning *;
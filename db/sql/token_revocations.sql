@@ -0,0 +1,18 @@
+-- name: RevokeAccessToken :exec
+insert into revoked_access_tokens (jti, user_id, expires_at, revoked_at)
+values ($1, $2, $3, now())
+on conflict (jti) do nothing;
+
+-- name: IsAccessTokenRevoked :one
+select exists(
+    select 1 from revoked_access_tokens where jti = $1
+);
+
+-- name: RevokeUserSessions :one
+insert into user_session_revocations (user_id, revoked_at)
+values ($1, now())
+on conflict (user_id) do update set revoked_at = excluded.revoked_at
+returning *;
+
+-- name: GetUserSessionRevocation :one
+select * from user_session_revocations where user_id = $1;
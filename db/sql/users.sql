@@ -2,9 +2,126 @@
 select * from users where id = $1;
 
 -- name: GetUserByEmail :one
-select * from users where email = $1;
+-- Emails match case-insensitively, accounts created before emails were lowercased may still have mixed case
+select * from users where lower(email) = lower($1) and deleted_at IS NULL;
 
 -- name: CreateUser :one
-insert into users (id, email, password, role, created_at, updated_at)
-values (gen_random_uuid(), $1, $2, $3, now(), now())
-returning *;
\ No newline at end of file
+insert into users (id, email, password, name, role, study_program_id, created_at, updated_at)
+values (gen_random_uuid(), $1, $2, $3, $4, $5, now(), now())
+returning *;
+
+-- name: CreateUsers :copyfrom
+insert into users (id, email, password, name, role, created_at, updated_at)
+values ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: GetActiveUserEmails :many
+-- Returns the lowercased emails, the emails passed in must be lowercased as well
+select lower(email)::text as email from users
+where lower(email) = any(sqlc.arg('emails')::text[]) and deleted_at IS NULL;
+
+-- name: CreateRefreshToken :one
+insert into refresh_tokens (id, user_id, family_id, token_hash, expires_at, created_at, updated_at)
+values (gen_random_uuid(), $1, $2, $3, $4, now(), now())
+returning *;
+
+-- name: GetRefreshTokenByHash :one
+select * from refresh_tokens where token_hash = $1 for update;
+
+-- name: RotateRefreshToken :one
+update refresh_tokens
+set revoked_at = now(), replaced_by = $2, updated_at = now()
+where id = $1 and revoked_at IS NULL
+returning *;
+
+-- name: RevokeRefreshTokenFamily :exec
+update refresh_tokens
+set revoked_at = now(), updated_at = now()
+where family_id = $1 and revoked_at IS NULL;
+
+-- name: RevokeRefreshTokenFamilyByHash :exec
+update refresh_tokens
+set revoked_at = now(), updated_at = now()
+where family_id = (
+    select rt.family_id from refresh_tokens rt
+    where rt.token_hash = $1 and rt.user_id = $2
+) and revoked_at IS NULL;
+
+-- name: RevokeUserRefreshTokens :exec
+update refresh_tokens
+set revoked_at = now(), updated_at = now()
+where user_id = $1 and revoked_at IS NULL;
+
+-- name: UpdateUserPassword :one
+update users
+set password = $2, updated_at = now()
+where id = $1 and deleted_at IS NULL
+returning *;
+
+-- name: CreatePasswordResetToken :one
+insert into password_reset_tokens (id, user_id, token_hash, expires_at, created_by, created_at)
+values (gen_random_uuid(), $1, $2, $3, $4, now())
+returning *;
+
+-- name: InvalidatePasswordResetTokens :exec
+update password_reset_tokens
+set used_at = now()
+where user_id = $1 and used_at IS NULL;
+
+-- name: GetPasswordResetTokenByHash :one
+select * from password_reset_tokens where token_hash = $1 for update;
+
+-- name: MarkPasswordResetTokenUsed :one
+update password_reset_tokens
+set used_at = now()
+where id = $1 and used_at IS NULL
+returning *;
+
+-- name: ListUsers :many
+select * from users
+where (sqlc.narg('role')::numeric IS NULL OR role = sqlc.narg('role'))
+  and (sqlc.narg('email')::text IS NULL OR email ilike '%' || sqlc.narg('email') || '%')
+  and (sqlc.arg('include_deleted')::boolean OR deleted_at IS NULL)
+order by created_at desc
+limit sqlc.arg('limit') offset sqlc.arg('offset');
+
+-- name: CountUsers :one
+select count(*) from users
+where (sqlc.narg('role')::numeric IS NULL OR role = sqlc.narg('role'))
+  and (sqlc.narg('email')::text IS NULL OR email ilike '%' || sqlc.narg('email') || '%')
+  and (sqlc.arg('include_deleted')::boolean OR deleted_at IS NULL);
+
+-- name: UpdateUserRole :one
+update users
+set role = $2, updated_at = now()
+where id = $1 and deleted_at IS NULL
+returning *;
+
+-- name: UpdateUserStudyProgram :one
+update users
+set study_program_id = $2, updated_at = now()
+where id = $1 and deleted_at IS NULL
+returning *;
+
+-- name: DisableUser :one
+update users
+set disabled_at = coalesce(disabled_at, now()), updated_at = now()
+where id = $1 and deleted_at IS NULL
+returning *;
+
+-- name: EnableUser :one
+update users
+set disabled_at = NULL, updated_at = now()
+where id = $1 and deleted_at IS NULL
+returning *;
+
+-- name: DeleteUser :one
+update users
+set deleted_at = now(), updated_at = now()
+where id = $1 and deleted_at IS NULL
+returning *;
+
+-- name: RestoreUser :one
+update users
+set deleted_at = NULL, updated_at = now()
+where id = $1 and deleted_at IS NOT NULL
+returning *;
@@ -0,0 +1,30 @@
+-- name: CreateWaitlistEntry :one
+insert into course_offering_waitlists (id, course_offering_id, student_id)
+values ($1, $2, $3)
+returning *;
+
+-- name: GetWaitlistEntry :one
+select * from course_offering_waitlists
+where course_offering_id = $1 and student_id = $2 and status = 'waiting';
+
+-- name: GetWaitlistPosition :one
+-- The place of the entry at the given position among the waiting entries of the offering, and how many are waiting
+select
+    count(*) filter (where position <= sqlc.arg('position')) as position,
+    count(*) as waiting
+from course_offering_waitlists
+where course_offering_id = sqlc.arg('course_offering_id') and status = 'waiting';
+
+-- name: ListWaitlistEntriesForUpdate :many
+-- The waiting entries of the offering in the order they are served, locked until the end of the transaction so
+-- concurrent promotions of the offering are serialized
+select * from course_offering_waitlists
+where course_offering_id = $1 and status = 'waiting'
+order by position
+for update;
+
+-- name: CloseWaitlistEntry :one
+update course_offering_waitlists
+set status = $3, course_registration_id = $4, updated_at = now()
+where course_offering_id = $1 and student_id = $2 and status = 'waiting'
+returning *;
@@ -1,26 +1,46 @@
 package middlewares
 
 import (
+	"context"
 	"siakad-poc/common"
 	"siakad-poc/constants"
-	"slices"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func ShouldBeAccessedByRoles(expectedRoles []constants.RoleType) fiber.Handler {
+// PermissionChecker resolves the role to permission mapping, see PermissionRepository
+type PermissionChecker interface {
+	HasPermission(ctx context.Context, role int64, permission string) (bool, error)
+}
+
+// RequirePermission only lets requests through when the role of the token is granted the permission.
+// It must be placed after the JWT middleware.
+func RequirePermission(checker PermissionChecker, permission string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		role := c.Locals(UserRoleKey).(constants.RoleType)
-		if slices.Contains(expectedRoles, role) {
+
+		allowed, err := checker.HasPermission(c.Context(), role, permission)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(common.BaseResponse[any]{
+				Status: common.StatusError,
+				Error: &common.BaseResponseError{
+					Message:   "Cannot verify permissions",
+					Details:   []string{err.Error()},
+					Timestamp: time.Now().UTC().Format(time.RFC3339),
+					Path:      c.OriginalURL(),
+				},
+			})
+		}
+		if allowed {
 			return c.Next()
 		}
 
-		return c.Status(fiber.StatusUnauthorized).JSON(common.BaseResponse[any]{
+		return c.Status(fiber.StatusForbidden).JSON(common.BaseResponse[any]{
 			Status: common.StatusError,
 			Error: &common.BaseResponseError{
-				Message:   "Invalid role",
-				Details:   []string{},
+				Message:   "Permission denied",
+				Details:   []string{"missing permission " + permission},
 				Timestamp: time.Now().UTC().Format(time.RFC3339),
 				Path:      c.OriginalURL(),
 			},
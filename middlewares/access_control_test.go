@@ -0,0 +1,103 @@
+package middlewares
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"siakad-poc/common"
+	"siakad-poc/constants"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// Mock permission checker
+type MockPermissionChecker struct {
+	mock.Mock
+}
+
+func (m *MockPermissionChecker) HasPermission(ctx context.Context, role int64, permission string) (bool, error) {
+	args := m.Called(ctx, role, permission)
+	return args.Bool(0), args.Error(1)
+}
+
+// Test Suite
+type AccessControlTestSuite struct {
+	suite.Suite
+	app         *fiber.App
+	mockChecker *MockPermissionChecker
+}
+
+func (suite *AccessControlTestSuite) SetupTest() {
+	suite.mockChecker = new(MockPermissionChecker)
+	suite.app = fiber.New()
+
+	// Stands in for the JWT middleware, which stores the role of the token
+	suite.app.Use(func(c *fiber.Ctx) error {
+		c.Locals(UserRoleKey, constants.RoleStudent)
+		return c.Next()
+	})
+	suite.app.Get("/courses", RequirePermission(suite.mockChecker, constants.PermissionCourseWrite), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+}
+
+func (suite *AccessControlTestSuite) TearDownTest() {
+	suite.mockChecker.AssertExpectations(suite.T())
+}
+
+// request calls the protected route and decodes the error response, if any
+func (suite *AccessControlTestSuite) request() (int, common.BaseResponse[any]) {
+	resp, err := suite.app.Test(httptest.NewRequest(fiber.MethodGet, "/courses", nil))
+	suite.Require().NoError(err)
+	defer resp.Body.Close()
+
+	var body common.BaseResponse[any]
+	if resp.StatusCode != fiber.StatusNoContent {
+		suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&body))
+	}
+	return resp.StatusCode, body
+}
+
+// Test a role granted the permission reaches the handler
+func (suite *AccessControlTestSuite) TestRequirePermission_Granted() {
+	suite.mockChecker.On("HasPermission", mock.Anything, constants.RoleStudent, constants.PermissionCourseWrite).Return(true, nil)
+
+	status, _ := suite.request()
+
+	assert.Equal(suite.T(), fiber.StatusNoContent, status)
+}
+
+// Test a role without the permission is rejected with 403 and the missing permission
+func (suite *AccessControlTestSuite) TestRequirePermission_Denied() {
+	suite.mockChecker.On("HasPermission", mock.Anything, constants.RoleStudent, constants.PermissionCourseWrite).Return(false, nil)
+
+	status, body := suite.request()
+
+	assert.Equal(suite.T(), fiber.StatusForbidden, status)
+	assert.Equal(suite.T(), common.StatusError, body.Status)
+	suite.Require().NotNil(body.Error)
+	assert.Equal(suite.T(), "Permission denied", body.Error.Message)
+	assert.Equal(suite.T(), []string{"missing permission " + constants.PermissionCourseWrite}, body.Error.Details)
+	assert.Equal(suite.T(), "/courses", body.Error.Path)
+}
+
+// Test a failing permission lookup is a server error and never lets the request through
+func (suite *AccessControlTestSuite) TestRequirePermission_RepositoryError() {
+	suite.mockChecker.On("HasPermission", mock.Anything, constants.RoleStudent, constants.PermissionCourseWrite).Return(false, errors.New("connection refused"))
+
+	status, body := suite.request()
+
+	assert.Equal(suite.T(), fiber.StatusInternalServerError, status)
+	suite.Require().NotNil(body.Error)
+	assert.Equal(suite.T(), "Cannot verify permissions", body.Error.Message)
+}
+
+// Run the test suite
+func TestAccessControlTestSuite(t *testing.T) {
+	suite.Run(t, new(AccessControlTestSuite))
+}
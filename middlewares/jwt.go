@@ -1,8 +1,9 @@
 package middlewares
 
 import (
+	"context"
 	"siakad-poc/common"
-	"siakad-poc/config"
+	"siakad-poc/common/jwtkeys"
 	"strings"
 	"time"
 
@@ -10,18 +11,30 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// JWTClaims carries the token ID as the registered `jti` claim (RegisteredClaims.ID)
 type JWTClaims struct {
 	UserID string `json:"user_id"`
 	Role   int64  `json:"role"`
+
+	// MFAPending is only set on tokens proving the password step of a login, they are never access tokens
+	MFAPending bool `json:"mfa_pending,omitempty"`
 	jwt.RegisteredClaims
 }
 
 const (
-	StudentIDKey = "student_id"
-	UserRoleKey  = "user_role"
+	UserIDKey         = "user_id"
+	UserRoleKey       = "user_role"
+	TokenIDKey        = "token_id"
+	TokenExpiresAtKey = "token_expires_at"
 )
 
-func JWT() fiber.Handler {
+// TokenRevocationChecker is consulted for every valid token so that logged out or
+// administratively revoked tokens are rejected before they expire.
+type TokenRevocationChecker interface {
+	IsTokenRevoked(ctx context.Context, jti, userID string, issuedAt time.Time) (bool, error)
+}
+
+func JWT(keyring *jwtkeys.Keyring, revocationChecker TokenRevocationChecker) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
@@ -52,14 +65,8 @@ func JWT() fiber.Handler {
 
 		tokenString := tokenParts[1]
 
-		// Parse and validate token
-		token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-			// Validate signing method
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrInvalidKeyType
-			}
-			return []byte(config.CurrentConfig.JWT.Secret), nil
-		})
+		// Parse and validate token, the keyring picks the verification key by the `kid` header
+		token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, keyring.Keyfunc, jwt.WithValidMethods(keyring.ValidMethods()))
 
 		if err != nil {
 			return c.Status(fiber.StatusUnauthorized).JSON(common.BaseResponse[any]{
@@ -86,7 +93,7 @@ func JWT() fiber.Handler {
 		}
 
 		claims, ok := token.Claims.(*JWTClaims)
-		if !ok {
+		if !ok || claims.MFAPending {
 			return c.Status(fiber.StatusUnauthorized).JSON(common.BaseResponse[any]{
 				Status: common.StatusError,
 				Error: &common.BaseResponseError{
@@ -98,9 +105,42 @@ func JWT() fiber.Handler {
 			})
 		}
 
+		var issuedAt time.Time
+		if claims.IssuedAt != nil {
+			issuedAt = claims.IssuedAt.Time
+		}
+
+		revoked, err := revocationChecker.IsTokenRevoked(c.Context(), claims.ID, claims.UserID, issuedAt)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(common.BaseResponse[any]{
+				Status: common.StatusError,
+				Error: &common.BaseResponseError{
+					Message:   "Cannot verify token",
+					Details:   []string{err.Error()},
+					Timestamp: time.Now().UTC().Format(time.RFC3339),
+					Path:      c.OriginalURL(),
+				},
+			})
+		}
+		if revoked {
+			return c.Status(fiber.StatusUnauthorized).JSON(common.BaseResponse[any]{
+				Status: common.StatusError,
+				Error: &common.BaseResponseError{
+					Message:   "Token has been revoked",
+					Details:   []string{"token is no longer valid, please log in again"},
+					Timestamp: time.Now().UTC().Format(time.RFC3339),
+					Path:      c.OriginalURL(),
+				},
+			})
+		}
+
 		// Add user information to context
-		c.Locals(StudentIDKey, claims.UserID)
+		c.Locals(UserIDKey, claims.UserID)
 		c.Locals(UserRoleKey, claims.Role)
+		c.Locals(TokenIDKey, claims.ID)
+		if claims.ExpiresAt != nil {
+			c.Locals(TokenExpiresAtKey, claims.ExpiresAt.Time)
+		}
 
 		return c.Next()
 	}
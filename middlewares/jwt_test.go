@@ -0,0 +1,168 @@
+package middlewares
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"siakad-poc/common"
+	"siakad-poc/common/jwtkeys"
+	"siakad-poc/config"
+	"siakad-poc/constants"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	jwtTestUserID  = "550e8400-e29b-41d4-a716-446655440041"
+	jwtTestTokenID = "550e8400-e29b-41d4-a716-446655440042"
+)
+
+// Mock token revocation checker
+type MockTokenRevocationChecker struct {
+	mock.Mock
+}
+
+func (m *MockTokenRevocationChecker) IsTokenRevoked(ctx context.Context, jti, userID string, issuedAt time.Time) (bool, error) {
+	args := m.Called(ctx, jti, userID, issuedAt)
+	return args.Bool(0), args.Error(1)
+}
+
+// Test Suite
+type JWTTestSuite struct {
+	suite.Suite
+	app         *fiber.App
+	keyring     *jwtkeys.Keyring
+	mockChecker *MockTokenRevocationChecker
+	issuedAt    time.Time
+	expiresAt   time.Time
+}
+
+func (suite *JWTTestSuite) SetupTest() {
+	keyring, err := jwtkeys.Load(config.JWTConfigParams{Secret: "test-secret"})
+	suite.Require().NoError(err)
+
+	suite.keyring = keyring
+	suite.mockChecker = new(MockTokenRevocationChecker)
+	suite.issuedAt = time.Now().Truncate(time.Second)
+	suite.expiresAt = suite.issuedAt.Add(15 * time.Minute)
+
+	suite.app = fiber.New()
+	suite.app.Get("/me", JWT(suite.keyring, suite.mockChecker), func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{
+			"user_id":    c.Locals(UserIDKey),
+			"role":       c.Locals(UserRoleKey),
+			"token_id":   c.Locals(TokenIDKey),
+			"expires_at": c.Locals(TokenExpiresAtKey),
+		})
+	})
+}
+
+func (suite *JWTTestSuite) TearDownTest() {
+	suite.mockChecker.AssertExpectations(suite.T())
+}
+
+func (suite *JWTTestSuite) claims() JWTClaims {
+	return JWTClaims{
+		UserID: jwtTestUserID,
+		Role:   constants.RoleStudent,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jwtTestTokenID,
+			IssuedAt:  jwt.NewNumericDate(suite.issuedAt),
+			ExpiresAt: jwt.NewNumericDate(suite.expiresAt),
+		},
+	}
+}
+
+// request calls the protected route with the signed claims and decodes the response body
+func (suite *JWTTestSuite) request(claims JWTClaims) (int, map[string]any) {
+	token, err := suite.keyring.Sign(claims)
+	suite.Require().NoError(err)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := suite.app.Test(req)
+	suite.Require().NoError(err)
+	defer resp.Body.Close()
+
+	var body map[string]any
+	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&body))
+	return resp.StatusCode, body
+}
+
+// errorMessage returns the message of an error response
+func errorMessage(body map[string]any) string {
+	errorBody, _ := body["error"].(map[string]any)
+	message, _ := errorBody["message"].(string)
+	return message
+}
+
+// Test a token that isn't revoked reaches the handler with the token's details in the locals
+func (suite *JWTTestSuite) TestJWT_NotRevoked() {
+	suite.mockChecker.On("IsTokenRevoked", mock.Anything, jwtTestTokenID, jwtTestUserID, suite.issuedAt).Return(false, nil)
+
+	status, body := suite.request(suite.claims())
+
+	assert.Equal(suite.T(), fiber.StatusOK, status)
+	assert.Equal(suite.T(), jwtTestUserID, body["user_id"])
+	assert.EqualValues(suite.T(), constants.RoleStudent, body["role"])
+	assert.Equal(suite.T(), jwtTestTokenID, body["token_id"])
+	assert.NotEmpty(suite.T(), body["expires_at"])
+}
+
+// Test a revoked jti is rejected with 401
+func (suite *JWTTestSuite) TestJWT_Revoked() {
+	suite.mockChecker.On("IsTokenRevoked", mock.Anything, jwtTestTokenID, jwtTestUserID, suite.issuedAt).Return(true, nil)
+
+	status, body := suite.request(suite.claims())
+
+	assert.Equal(suite.T(), fiber.StatusUnauthorized, status)
+	assert.Equal(suite.T(), common.StatusError, body["status"])
+	assert.Equal(suite.T(), "Token has been revoked", errorMessage(body))
+}
+
+// Test a token issued within the second the user's sessions were revoked is passed on with its
+// issue time, so the checker can reject it
+func (suite *JWTTestSuite) TestJWT_IssuedInRevocationSecond() {
+	claims := suite.claims()
+	claims.IssuedAt = jwt.NewNumericDate(suite.issuedAt.Add(700 * time.Millisecond))
+	suite.mockChecker.On("IsTokenRevoked", mock.Anything, jwtTestTokenID, jwtTestUserID, suite.issuedAt).Return(true, nil)
+
+	status, body := suite.request(claims)
+
+	assert.Equal(suite.T(), fiber.StatusUnauthorized, status)
+	assert.Equal(suite.T(), "Token has been revoked", errorMessage(body))
+}
+
+// Test a failing revocation lookup is a server error and never lets the request through
+func (suite *JWTTestSuite) TestJWT_RevocationCheckError() {
+	suite.mockChecker.On("IsTokenRevoked", mock.Anything, jwtTestTokenID, jwtTestUserID, suite.issuedAt).Return(false, errors.New("connection refused"))
+
+	status, body := suite.request(suite.claims())
+
+	assert.Equal(suite.T(), fiber.StatusInternalServerError, status)
+	assert.Equal(suite.T(), "Cannot verify token", errorMessage(body))
+}
+
+// Test a token only proving the password step of an MFA login is never accepted as an access token
+func (suite *JWTTestSuite) TestJWT_MFAPendingToken() {
+	claims := suite.claims()
+	claims.MFAPending = true
+
+	status, body := suite.request(claims)
+
+	assert.Equal(suite.T(), fiber.StatusUnauthorized, status)
+	assert.Equal(suite.T(), "Invalid token claims", errorMessage(body))
+	suite.mockChecker.AssertNotCalled(suite.T(), "IsTokenRevoked", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Run the test suite
+func TestJWTTestSuite(t *testing.T) {
+	suite.Run(t, new(JWTTestSuite))
+}
@@ -0,0 +1,55 @@
+package middlewares
+
+import (
+	"context"
+	"siakad-poc/common"
+	"siakad-poc/constants"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const StudyProgramScopeKey = "study_program_scope"
+
+// UserStudyProgramResolver returns the study program linked to a user, empty when there is none
+type UserStudyProgramResolver interface {
+	GetUserStudyProgramID(ctx context.Context, userID string) (string, error)
+}
+
+// StudyProgramScope stores the common.StudyProgramScope of the user under StudyProgramScopeKey.
+// It must be placed after the JWT middleware.
+func StudyProgramScope(checker PermissionChecker, resolver UserStudyProgramResolver) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		role := c.Locals(UserRoleKey).(constants.RoleType)
+		userID := c.Locals(UserIDKey).(string)
+
+		all, err := checker.HasPermission(c.Context(), role, constants.PermissionStudyProgramAll)
+		if err != nil {
+			return respondScopeError(c, err)
+		}
+		if all {
+			c.Locals(StudyProgramScopeKey, common.GlobalStudyProgramScope())
+			return c.Next()
+		}
+
+		studyProgramID, err := resolver.GetUserStudyProgramID(c.Context(), userID)
+		if err != nil {
+			return respondScopeError(c, err)
+		}
+
+		c.Locals(StudyProgramScopeKey, common.StudyProgramScope{StudyProgramID: studyProgramID})
+		return c.Next()
+	}
+}
+
+func respondScopeError(c *fiber.Ctx, err error) error {
+	return c.Status(fiber.StatusInternalServerError).JSON(common.BaseResponse[any]{
+		Status: common.StatusError,
+		Error: &common.BaseResponseError{
+			Message:   "Cannot resolve study program",
+			Details:   []string{err.Error()},
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Path:      c.OriginalURL(),
+		},
+	})
+}
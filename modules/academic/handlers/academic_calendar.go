@@ -0,0 +1,305 @@
+package handlers
+
+import (
+	"siakad-poc/common"
+	"siakad-poc/modules/academic/usecases"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type AcademicCalendarHandler struct {
+	useCase *usecases.AcademicCalendarUseCase
+}
+
+func NewAcademicCalendarHandler(useCase *usecases.AcademicCalendarUseCase) *AcademicCalendarHandler {
+	return &AcademicCalendarHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *AcademicCalendarHandler) HandleListAcademicYears(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	page := 1
+	pageSize := 10
+
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	if ps, err := strconv.Atoi(c.Query("page_size")); err == nil && ps > 0 {
+		pageSize = ps
+	}
+
+	academicYears, pagination, err := h.useCase.ListAcademicYears(c.Context(), page, pageSize)
+	if err != nil {
+		return respondAcademicCalendarError(c, requestID, clientIP, "", "Failed to get academic years", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.PaginatedBaseResponse[[]usecases.AcademicYearResponse]{
+		BaseResponse: common.BaseResponse[[]usecases.AcademicYearResponse]{
+			Status: common.StatusSuccess,
+			Data:   &academicYears,
+		},
+		Paging: pagination,
+	})
+}
+
+func (h *AcademicCalendarHandler) HandleGetAcademicYear(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	academicYear, err := h.useCase.GetAcademicYear(c.Context(), id)
+	if err != nil {
+		return respondAcademicCalendarError(c, requestID, clientIP, id, "Failed to get academic year", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.AcademicYearResponse]{
+		Status: common.StatusSuccess,
+		Data:   &academicYear,
+	})
+}
+
+func (h *AcademicCalendarHandler) HandleCreateAcademicYear(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	var req usecases.AcademicYearRequest
+	if handled, err := parseRequest(c, &req, "academic year"); handled {
+		return err
+	}
+
+	academicYear, err := h.useCase.CreateAcademicYear(c.Context(), req)
+	if err != nil {
+		return respondAcademicCalendarError(c, requestID, clientIP, "", "Failed to create academic year", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("academic_year_id", academicYear.ID).
+		Str("code", academicYear.Code).
+		Str("created_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Academic year created")
+
+	return c.Status(fiber.StatusCreated).JSON(common.BaseResponse[usecases.AcademicYearResponse]{
+		Status: common.StatusSuccess,
+		Data:   &academicYear,
+	})
+}
+
+func (h *AcademicCalendarHandler) HandleUpdateAcademicYear(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	var req usecases.AcademicYearRequest
+	if handled, err := parseRequest(c, &req, "academic year"); handled {
+		return err
+	}
+
+	academicYear, err := h.useCase.UpdateAcademicYear(c.Context(), id, req)
+	if err != nil {
+		return respondAcademicCalendarError(c, requestID, clientIP, id, "Failed to update academic year", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("academic_year_id", id).
+		Str("code", academicYear.Code).
+		Str("updated_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Academic year updated")
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.AcademicYearResponse]{
+		Status: common.StatusSuccess,
+		Data:   &academicYear,
+	})
+}
+
+func (h *AcademicCalendarHandler) HandleDeleteAcademicYear(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	err := h.useCase.DeleteAcademicYear(c.Context(), id)
+	if err != nil {
+		return respondAcademicCalendarError(c, requestID, clientIP, id, "Failed to delete academic year", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("academic_year_id", id).
+		Str("deleted_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Academic year soft-deleted")
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *AcademicCalendarHandler) HandleGetCurrentSemester(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	semester, err := h.useCase.GetCurrentSemester(c.Context())
+	if err != nil {
+		return respondAcademicCalendarError(c, requestID, clientIP, "", "Failed to get current semester", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.SemesterResponse]{
+		Status: common.StatusSuccess,
+		Data:   &semester,
+	})
+}
+
+func (h *AcademicCalendarHandler) HandleGetSemester(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	semester, err := h.useCase.GetSemester(c.Context(), id)
+	if err != nil {
+		return respondAcademicCalendarError(c, requestID, clientIP, id, "Failed to get semester", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.SemesterResponse]{
+		Status: common.StatusSuccess,
+		Data:   &semester,
+	})
+}
+
+func (h *AcademicCalendarHandler) HandleCreateSemester(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	academicYearID := c.Params("id")
+
+	var req usecases.SemesterRequest
+	if handled, err := parseRequest(c, &req, "semester"); handled {
+		return err
+	}
+
+	semester, err := h.useCase.CreateSemester(c.Context(), academicYearID, req)
+	if err != nil {
+		return respondAcademicCalendarError(c, requestID, clientIP, academicYearID, "Failed to create semester", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("academic_year_id", academicYearID).
+		Str("semester_id", semester.ID).
+		Str("code", semester.Code).
+		Str("created_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Semester created")
+
+	return c.Status(fiber.StatusCreated).JSON(common.BaseResponse[usecases.SemesterResponse]{
+		Status: common.StatusSuccess,
+		Data:   &semester,
+	})
+}
+
+func (h *AcademicCalendarHandler) HandleUpdateSemester(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	var req usecases.SemesterRequest
+	if handled, err := parseRequest(c, &req, "semester"); handled {
+		return err
+	}
+
+	semester, err := h.useCase.UpdateSemester(c.Context(), id, req)
+	if err != nil {
+		return respondAcademicCalendarError(c, requestID, clientIP, id, "Failed to update semester", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("semester_id", id).
+		Str("code", semester.Code).
+		Str("updated_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Semester updated")
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.SemesterResponse]{
+		Status: common.StatusSuccess,
+		Data:   &semester,
+	})
+}
+
+func (h *AcademicCalendarHandler) HandleDeleteSemester(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	err := h.useCase.DeleteSemester(c.Context(), id)
+	if err != nil {
+		return respondAcademicCalendarError(c, requestID, clientIP, id, "Failed to delete semester", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("semester_id", id).
+		Str("deleted_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Semester soft-deleted")
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// respondAcademicCalendarError maps academic year and semester errors to HTTP status codes, unknown errors become 500.
+func respondAcademicCalendarError(c *fiber.Ctx, requestID, clientIP, resourceID, message string, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, usecases.ErrAcademicYearNotFound), errors.Is(err, usecases.ErrSemesterNotFound),
+		errors.Is(err, usecases.ErrNoCurrentSemester):
+		status = fiber.StatusNotFound
+	case errors.Is(err, usecases.ErrAcademicYearCodeAlreadyUsed), errors.Is(err, usecases.ErrAcademicYearHasSemesters),
+		errors.Is(err, usecases.ErrSemesterCodeAlreadyUsed), errors.Is(err, usecases.ErrSemesterOverlap),
+		errors.Is(err, usecases.ErrSemesterHasOfferings):
+		status = fiber.StatusConflict
+	case errors.Is(err, usecases.ErrAcademicYearExcludesSemesters), errors.Is(err, usecases.ErrSemesterOutsideAcademicYear),
+		errors.Is(err, usecases.ErrDropDeadlineOutsideSemester):
+		status = fiber.StatusUnprocessableEntity
+	}
+
+	if status == fiber.StatusInternalServerError {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("resource_id", resourceID).
+			Str("path", c.OriginalURL()).
+			Msg(message)
+	} else {
+		log.Warn().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("resource_id", resourceID).
+			Str("path", c.OriginalURL()).
+			Msg(message)
+	}
+
+	return c.Status(status).JSON(common.BaseResponse[any]{
+		Status: common.StatusError,
+		Error: &common.BaseResponseError{
+			Message:   message,
+			Details:   []string{err.Error()},
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Path:      c.OriginalURL(),
+		},
+	})
+}
@@ -0,0 +1,461 @@
+package handlers
+
+import (
+	"siakad-poc/common"
+	"siakad-poc/db/repositories"
+	"siakad-poc/middlewares"
+	"siakad-poc/modules/academic/usecases"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type CourseHandler struct {
+	useCase *usecases.CourseUseCase
+}
+
+func NewCourseHandler(useCase *usecases.CourseUseCase) *CourseHandler {
+	return &CourseHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *CourseHandler) HandleListCourses(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	page := 1
+	pageSize := 10
+
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	if ps, err := strconv.Atoi(c.Query("page_size")); err == nil && ps > 0 {
+		pageSize = ps
+	}
+
+	filter := repositories.CourseFilter{
+		Search: c.Query("search"),
+	}
+
+	courses, pagination, err := h.useCase.ListCourses(c.Context(), filter, page, pageSize)
+	if err != nil {
+		return respondCourseError(c, requestID, clientIP, "", "Failed to get courses", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.PaginatedBaseResponse[[]usecases.CourseResponse]{
+		BaseResponse: common.BaseResponse[[]usecases.CourseResponse]{
+			Status: common.StatusSuccess,
+			Data:   &courses,
+		},
+		Paging: pagination,
+	})
+}
+
+func (h *CourseHandler) HandleGetCourse(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	course, err := h.useCase.GetCourse(c.Context(), id)
+	if err != nil {
+		return respondCourseError(c, requestID, clientIP, id, "Failed to get course", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.CourseResponse]{
+		Status: common.StatusSuccess,
+		Data:   &course,
+	})
+}
+
+func (h *CourseHandler) HandleCreateCourse(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	var req usecases.CourseRequest
+	if handled, err := parseRequest(c, &req, "course"); handled {
+		return err
+	}
+
+	course, err := h.useCase.CreateCourse(c.Context(), req)
+	if err != nil {
+		return respondCourseError(c, requestID, clientIP, "", "Failed to create course", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("course_id", course.ID).
+		Str("code", course.Code).
+		Int("credit", course.Credit).
+		Str("created_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Course created")
+
+	return c.Status(fiber.StatusCreated).JSON(common.BaseResponse[usecases.CourseResponse]{
+		Status: common.StatusSuccess,
+		Data:   &course,
+	})
+}
+
+func (h *CourseHandler) HandleUpdateCourse(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	var req usecases.CourseRequest
+	if handled, err := parseRequest(c, &req, "course"); handled {
+		return err
+	}
+
+	course, err := h.useCase.UpdateCourse(c.Context(), id, req)
+	if err != nil {
+		return respondCourseError(c, requestID, clientIP, id, "Failed to update course", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("course_id", id).
+		Str("code", course.Code).
+		Str("updated_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Course updated")
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.CourseResponse]{
+		Status: common.StatusSuccess,
+		Data:   &course,
+	})
+}
+
+func (h *CourseHandler) HandleDeleteCourse(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	err := h.useCase.DeleteCourse(c.Context(), id)
+	if err != nil {
+		return respondCourseError(c, requestID, clientIP, id, "Failed to delete course", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("course_id", id).
+		Str("deleted_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Course soft-deleted")
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *CourseHandler) HandleListCoursePrerequisites(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	prerequisites, err := h.useCase.ListPrerequisites(c.Context(), id)
+	if err != nil {
+		return respondCourseError(c, requestID, clientIP, id, "Failed to get course prerequisites", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[[]usecases.RelatedCourseResponse]{
+		Status: common.StatusSuccess,
+		Data:   &prerequisites,
+	})
+}
+
+func (h *CourseHandler) HandleAddCoursePrerequisite(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	var req usecases.AddCoursePrerequisiteRequest
+	if handled, err := parseRequest(c, &req, "add course prerequisite"); handled {
+		return err
+	}
+
+	prerequisite, err := h.useCase.AddPrerequisite(c.Context(), id, req)
+	if err != nil {
+		return respondCourseError(c, requestID, clientIP, id, "Failed to add course prerequisite", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("course_id", id).
+		Str("prerequisite_course_id", prerequisite.CourseID).
+		Str("added_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Course prerequisite added")
+
+	return c.Status(fiber.StatusCreated).JSON(common.BaseResponse[usecases.RelatedCourseResponse]{
+		Status: common.StatusSuccess,
+		Data:   &prerequisite,
+	})
+}
+
+func (h *CourseHandler) HandleRemoveCoursePrerequisite(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+	prerequisiteCourseID := c.Params("prerequisiteId")
+
+	err := h.useCase.RemovePrerequisite(c.Context(), id, prerequisiteCourseID)
+	if err != nil {
+		return respondCourseError(c, requestID, clientIP, id, "Failed to remove course prerequisite", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("course_id", id).
+		Str("prerequisite_course_id", prerequisiteCourseID).
+		Str("removed_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Course prerequisite removed")
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *CourseHandler) HandleListCourseCorequisites(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	corequisites, err := h.useCase.ListCorequisites(c.Context(), id)
+	if err != nil {
+		return respondCourseError(c, requestID, clientIP, id, "Failed to get course co-requisites", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[[]usecases.RelatedCourseResponse]{
+		Status: common.StatusSuccess,
+		Data:   &corequisites,
+	})
+}
+
+func (h *CourseHandler) HandleAddCourseCorequisite(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	var req usecases.AddCourseCorequisiteRequest
+	if handled, err := parseRequest(c, &req, "add course co-requisite"); handled {
+		return err
+	}
+
+	corequisite, err := h.useCase.AddCorequisite(c.Context(), id, req)
+	if err != nil {
+		return respondCourseError(c, requestID, clientIP, id, "Failed to add course co-requisite", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("course_id", id).
+		Str("corequisite_course_id", corequisite.CourseID).
+		Str("added_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Course co-requisite added")
+
+	return c.Status(fiber.StatusCreated).JSON(common.BaseResponse[usecases.RelatedCourseResponse]{
+		Status: common.StatusSuccess,
+		Data:   &corequisite,
+	})
+}
+
+func (h *CourseHandler) HandleRemoveCourseCorequisite(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+	corequisiteCourseID := c.Params("corequisiteId")
+
+	err := h.useCase.RemoveCorequisite(c.Context(), id, corequisiteCourseID)
+	if err != nil {
+		return respondCourseError(c, requestID, clientIP, id, "Failed to remove course co-requisite", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("course_id", id).
+		Str("corequisite_course_id", corequisiteCourseID).
+		Str("removed_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Course co-requisite removed")
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *CourseHandler) HandleListCourseExclusions(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	exclusions, err := h.useCase.ListExclusions(c.Context(), id)
+	if err != nil {
+		return respondCourseError(c, requestID, clientIP, id, "Failed to get course exclusions", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[[]usecases.RelatedCourseResponse]{
+		Status: common.StatusSuccess,
+		Data:   &exclusions,
+	})
+}
+
+func (h *CourseHandler) HandleAddCourseExclusion(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	var req usecases.AddCourseExclusionRequest
+	if handled, err := parseRequest(c, &req, "add course exclusion"); handled {
+		return err
+	}
+
+	exclusion, err := h.useCase.AddExclusion(c.Context(), id, req)
+	if err != nil {
+		return respondCourseError(c, requestID, clientIP, id, "Failed to add course exclusion", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("course_id", id).
+		Str("excluded_course_id", exclusion.CourseID).
+		Str("added_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Course exclusion added")
+
+	return c.Status(fiber.StatusCreated).JSON(common.BaseResponse[usecases.RelatedCourseResponse]{
+		Status: common.StatusSuccess,
+		Data:   &exclusion,
+	})
+}
+
+func (h *CourseHandler) HandleRemoveCourseExclusion(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+	excludedCourseID := c.Params("excludedId")
+
+	err := h.useCase.RemoveExclusion(c.Context(), id, excludedCourseID)
+	if err != nil {
+		return respondCourseError(c, requestID, clientIP, id, "Failed to remove course exclusion", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("course_id", id).
+		Str("excluded_course_id", excludedCourseID).
+		Str("removed_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Course exclusion removed")
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// respondCourseError maps course errors to HTTP status codes, unknown errors become 500.
+func respondCourseError(c *fiber.Ctx, requestID, clientIP, courseID, message string, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, usecases.ErrCourseNotFound), errors.Is(err, usecases.ErrPrerequisiteNotFound),
+		errors.Is(err, usecases.ErrCorequisiteNotFound), errors.Is(err, usecases.ErrExclusionNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, usecases.ErrCourseCodeAlreadyUsed), errors.Is(err, usecases.ErrCourseHasActiveOfferings),
+		errors.Is(err, usecases.ErrPrerequisiteAlreadyAdded), errors.Is(err, usecases.ErrCorequisiteAlreadyAdded),
+		errors.Is(err, usecases.ErrExclusionAlreadyAdded):
+		status = fiber.StatusConflict
+	case errors.Is(err, usecases.ErrUnknownPrerequisiteCourse), errors.Is(err, usecases.ErrPrerequisiteCycle),
+		errors.Is(err, usecases.ErrUnknownCorequisiteCourse), errors.Is(err, usecases.ErrCorequisiteCycle),
+		errors.Is(err, usecases.ErrUnknownExcludedCourse), errors.Is(err, usecases.ErrCourseRelatedToItself),
+		errors.Is(err, usecases.ErrCourseRelationConflict):
+		status = fiber.StatusUnprocessableEntity
+	}
+
+	if status == fiber.StatusInternalServerError {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("course_id", courseID).
+			Str("path", c.OriginalURL()).
+			Msg(message)
+	} else {
+		log.Warn().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("course_id", courseID).
+			Str("path", c.OriginalURL()).
+			Msg(message)
+	}
+
+	return c.Status(status).JSON(common.BaseResponse[any]{
+		Status: common.StatusError,
+		Error: &common.BaseResponseError{
+			Message:   message,
+			Details:   []string{err.Error()},
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Path:      c.OriginalURL(),
+		},
+	})
+}
+
+// parseRequest parses and validates the request body, it reports whether an error response was sent.
+func parseRequest(c *fiber.Ctx, data any, requestName string) (bool, error) {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	if err := c.BodyParser(data); err != nil {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("path", c.OriginalURL()).
+			Str("method", c.Method()).
+			Msgf("Failed to parse %s request body", requestName)
+
+		return true, c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Cannot parse request body",
+				Details:   []string{err.Error()},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	if validationErrors := common.ValidateStruct(data); validationErrors != nil {
+		log.Warn().
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Strs("validation_errors", validationErrors).
+			Str("path", c.OriginalURL()).
+			Msgf("%s validation failed", requestName)
+
+		return true, c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Validation failed",
+				Details:   validationErrors,
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	return false, nil
+}
+
+func actorID(c *fiber.Ctx) string {
+	id, _ := c.Locals(middlewares.UserIDKey).(string)
+	return id
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"fmt"
 	"siakad-poc/common"
 	"siakad-poc/middlewares"
 	"siakad-poc/modules/academic/usecases"
@@ -53,42 +54,42 @@ func (h *CourseEnrollmentHandler) HandleCourseEnrollment(c *fiber.Ctx) error {
 		})
 	}
 
-	// Extract student ID from JWT context (set by middleware)
-	studentIDInterface := c.Locals(middlewares.StudentIDKey)
-	if studentIDInterface == nil {
+	// Extract user ID from JWT context (set by middleware), the use case resolves the student record
+	userIDInterface := c.Locals(middlewares.UserIDKey)
+	if userIDInterface == nil {
 		log.Error().
 			Str("request_id", requestID).
 			Str("client_ip", clientIP).
 			Str("course_offering_id", courseOfferingID).
 			Str("path", c.OriginalURL()).
-			Msg("Student ID not found in JWT token context")
+			Msg("User ID not found in JWT token context")
 
 		return c.Status(fiber.StatusUnauthorized).JSON(common.BaseResponse[any]{
 			Status: common.StatusError,
 			Error: &common.BaseResponseError{
-				Message:   "Student ID not found in token",
-				Details:   []string{"authentication token does not contain student ID"},
+				Message:   "User ID not found in token",
+				Details:   []string{"authentication token does not contain user ID"},
 				Timestamp: time.Now().UTC().Format(time.RFC3339),
 				Path:      c.OriginalURL(),
 			},
 		})
 	}
 
-	studentID, ok := studentIDInterface.(string)
+	userID, ok := userIDInterface.(string)
 	if !ok {
 		log.Error().
 			Str("request_id", requestID).
 			Str("client_ip", clientIP).
 			Str("course_offering_id", courseOfferingID).
-			Interface("student_id_raw", studentIDInterface).
+			Interface("user_id_raw", userIDInterface).
 			Str("path", c.OriginalURL()).
-			Msg("Student ID from JWT token is not in valid string format")
+			Msg("User ID from JWT token is not in valid string format")
 
 		return c.Status(fiber.StatusInternalServerError).JSON(common.BaseResponse[any]{
 			Status: common.StatusError,
 			Error: &common.BaseResponseError{
-				Message:   "Invalid student ID format",
-				Details:   []string{"student ID from token is not in valid format"},
+				Message:   "Invalid user ID format",
+				Details:   []string{"user ID from token is not in valid format"},
 				Timestamp: time.Now().UTC().Format(time.RFC3339),
 				Path:      c.OriginalURL(),
 			},
@@ -96,57 +97,10 @@ func (h *CourseEnrollmentHandler) HandleCourseEnrollment(c *fiber.Ctx) error {
 	}
 
 	// Call use case to enroll student
-	err := h.enrollmentUseCase.EnrollStudent(c.Context(), studentID, courseOfferingID)
+	studentID, err := h.enrollmentUseCase.EnrollUser(c.Context(), userID, courseOfferingID)
 	if err != nil {
 		// Determine appropriate HTTP status code and user-friendly message based on error type
-		statusCode := fiber.StatusBadRequest
-		userMessage := "Enrollment failed"
-		errorDetails := []string{err.Error()}
-
-		// Handle domain-specific errors with better UX
-		if enrollmentErr, ok := err.(*usecases.EnrollmentError); ok {
-			switch enrollmentErr.Type {
-			case usecases.ErrDuplicateEnrollment:
-				statusCode = fiber.StatusConflict
-				userMessage = "You are already enrolled in this course"
-				errorDetails = []string{"Duplicate enrollment detected. You cannot enroll in the same course offering twice."}
-
-			case usecases.ErrCapacityExceeded:
-				statusCode = fiber.StatusConflict
-				userMessage = "Course is full"
-				errorDetails = []string{"This course offering has reached its maximum capacity. Please try a different section or contact the academic office."}
-
-			case usecases.ErrScheduleConflict:
-				statusCode = fiber.StatusConflict
-				userMessage = "Schedule conflict detected"
-				errorDetails = []string{"The selected course conflicts with your existing class schedule. Please choose a different time slot."}
-
-			case usecases.ErrCourseOfferingNotFound:
-				statusCode = fiber.StatusNotFound
-				userMessage = "Course offering not found"
-				errorDetails = []string{"The requested course offering does not exist or may have been cancelled."}
-
-			case usecases.ErrInvalidCourseData:
-				statusCode = fiber.StatusBadRequest
-				userMessage = "Invalid course information"
-				errorDetails = []string{"There is an issue with the course offering data. Please contact the academic office."}
-
-			case usecases.ErrDatabaseOperation:
-				statusCode = fiber.StatusInternalServerError
-				userMessage = "System temporarily unavailable"
-				errorDetails = []string{"A technical issue occurred. Please try again later or contact support if the problem persists."}
-
-			case usecases.ErrTransactionFailed:
-				statusCode = fiber.StatusInternalServerError
-				userMessage = "Enrollment could not be processed"
-				errorDetails = []string{"A system error prevented enrollment completion. Please try again."}
-
-			default:
-				// Keep default values for unknown enrollment errors
-				userMessage = "Enrollment failed"
-				errorDetails = []string{enrollmentErr.Error()}
-			}
-		}
+		statusCode, userMessage, errorDetails := enrollmentErrorResponse(err)
 
 		// Log the enrollment failure with structured context
 		logEvent := log.Error().
@@ -154,6 +108,7 @@ func (h *CourseEnrollmentHandler) HandleCourseEnrollment(c *fiber.Ctx) error {
 			Err(err).
 			Str("request_id", requestID).
 			Str("client_ip", clientIP).
+			Str("user_id", userID).
 			Str("student_id", studentID).
 			Str("course_offering_id", courseOfferingID).
 			Str("path", c.OriginalURL()).
@@ -186,6 +141,7 @@ func (h *CourseEnrollmentHandler) HandleCourseEnrollment(c *fiber.Ctx) error {
 	log.Info().
 		Str("request_id", requestID).
 		Str("client_ip", clientIP).
+		Str("user_id", userID).
 		Str("student_id", studentID).
 		Str("course_offering_id", courseOfferingID).
 		Str("path", c.OriginalURL()).
@@ -204,3 +160,203 @@ func (h *CourseEnrollmentHandler) HandleCourseEnrollment(c *fiber.Ctx) error {
 		},
 	})
 }
+
+// HandleDropEnrollment drops the registration of the student linked to the token before the drop deadline
+func (h *CourseEnrollmentHandler) HandleDropEnrollment(c *fiber.Ctx) error {
+	courseOfferingID := c.Params("id")
+	userID := actorID(c)
+
+	studentID, err := h.enrollmentUseCase.DropUserEnrollment(c.Context(), userID, courseOfferingID)
+	if err != nil {
+		return respondEnrollmentError(c, err, studentID, courseOfferingID, "Course enrollment drop failed")
+	}
+
+	log.Info().
+		Str("request_id", c.Get(fiber.HeaderXRequestID)).
+		Str("user_id", userID).
+		Str("student_id", studentID).
+		Str("course_offering_id", courseOfferingID).
+		Msg("Course enrollment dropped")
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// HandleWithdrawEnrollment withdraws a student from a course offering with a reason, whatever the drop deadline
+func (h *CourseEnrollmentHandler) HandleWithdrawEnrollment(c *fiber.Ctx) error {
+	courseOfferingID := c.Params("id")
+	studentID := c.Params("studentId")
+
+	var req usecases.WithdrawEnrollmentRequest
+	if handled, err := parseRequest(c, &req, "withdraw enrollment"); handled {
+		return err
+	}
+
+	err := h.enrollmentUseCase.WithdrawStudent(c.Context(), actorID(c), studentID, courseOfferingID, req)
+	if err != nil {
+		return respondEnrollmentError(c, err, studentID, courseOfferingID, "Course enrollment withdrawal failed")
+	}
+
+	log.Info().
+		Str("request_id", c.Get(fiber.HeaderXRequestID)).
+		Str("actor_id", actorID(c)).
+		Str("student_id", studentID).
+		Str("course_offering_id", courseOfferingID).
+		Str("reason", req.Reason).
+		Msg("Student withdrawn from course offering")
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func respondEnrollmentError(c *fiber.Ctx, err error, studentID, courseOfferingID, logMessage string) error {
+	statusCode, userMessage, errorDetails := enrollmentErrorResponse(err)
+
+	logEvent := log.Error().
+		Err(err).
+		Str("request_id", c.Get(fiber.HeaderXRequestID)).
+		Str("user_id", actorID(c)).
+		Str("student_id", studentID).
+		Str("course_offering_id", courseOfferingID).
+		Str("path", c.OriginalURL()).
+		Int("http_status", statusCode)
+	if enrollmentErr, ok := err.(*usecases.EnrollmentError); ok {
+		logEvent = logEvent.
+			Str("error_type", string(enrollmentErr.Type)).
+			Interface("error_details", enrollmentErr.Details)
+	}
+	logEvent.Msg(logMessage)
+
+	return c.Status(statusCode).JSON(common.BaseResponse[any]{
+		Status: common.StatusError,
+		Error: &common.BaseResponseError{
+			Message:   userMessage,
+			Details:   errorDetails,
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Path:      c.OriginalURL(),
+		},
+	})
+}
+
+// enrollmentErrorResponse maps an enrollment error to the HTTP status, message and details shown to the user
+func enrollmentErrorResponse(err error) (int, string, []string) {
+	statusCode := fiber.StatusBadRequest
+	userMessage := "Enrollment failed"
+	errorDetails := []string{err.Error()}
+
+	// Handle domain-specific errors with better UX
+	if enrollmentErr, ok := err.(*usecases.EnrollmentError); ok {
+		switch enrollmentErr.Type {
+		case usecases.ErrDuplicateEnrollment:
+			statusCode = fiber.StatusConflict
+			userMessage = "You are already enrolled in this course"
+			errorDetails = []string{"Duplicate enrollment detected. You cannot enroll in the same course offering twice."}
+
+		case usecases.ErrCapacityExceeded:
+			statusCode = fiber.StatusConflict
+			userMessage = "Course is full"
+			errorDetails = []string{"This course offering has reached its maximum capacity. Join its waitlist to be enrolled when a seat frees up, or try a different section."}
+
+		case usecases.ErrScheduleConflict:
+			statusCode = fiber.StatusConflict
+			userMessage = "Schedule conflict detected"
+			errorDetails = []string{"The selected course conflicts with your existing class schedule. Please choose a different time slot."}
+			if newCourseTime, ok := enrollmentErr.Details["new_course_time"].(string); ok {
+				errorDetails = append(errorDetails, fmt.Sprintf("Selected course: %s", newCourseTime))
+			}
+			if existingCourseTime, ok := enrollmentErr.Details["existing_course_time"].(string); ok {
+				errorDetails = append(errorDetails, fmt.Sprintf("Existing class: %s", existingCourseTime))
+			}
+
+		case usecases.ErrPrerequisiteNotMet:
+			statusCode = fiber.StatusUnprocessableEntity
+			userMessage = "Prerequisites not met"
+			errorDetails = []string{fmt.Sprintf("Pass these courses with grade %s or better first.", enrollmentErr.Details["minimum_grade"])}
+			if missingCourses, ok := enrollmentErr.Details["missing_courses"].([]string); ok {
+				errorDetails = append(errorDetails, missingCourses...)
+			}
+
+		case usecases.ErrCorequisiteNotMet:
+			statusCode = fiber.StatusUnprocessableEntity
+			userMessage = "Co-requisites not met"
+			errorDetails = []string{"Enroll in these courses in the same semester first."}
+			if missingCourses, ok := enrollmentErr.Details["missing_courses"].([]string); ok {
+				errorDetails = append(errorDetails, missingCourses...)
+			}
+
+		case usecases.ErrExcludedCourseConflict:
+			statusCode = fiber.StatusConflict
+			userMessage = "Course excluded"
+			errorDetails = []string{"This course can't be taken together with these courses in the same semester."}
+			if conflictingCourses, ok := enrollmentErr.Details["conflicting_courses"].([]string); ok {
+				errorDetails = append(errorDetails, conflictingCourses...)
+			}
+
+		case usecases.ErrStudyPlanLocked:
+			statusCode = fiber.StatusConflict
+			userMessage = "Study plan locked"
+			errorDetails = []string{"Your study plan of this semester has been submitted or approved. Ask your academic advisor to return it before changing it."}
+
+		case usecases.ErrCreditLimitExceeded:
+			statusCode = fiber.StatusUnprocessableEntity
+			userMessage = "Credit limit exceeded"
+			errorDetails = []string{fmt.Sprintf("You have %v credits this semester, this course adds %v and your limit is %v credits.",
+				enrollmentErr.Details["semester_credits"], enrollmentErr.Details["course_credits"], enrollmentErr.Details["max_credits"])}
+
+		case usecases.ErrDropDeadlinePassed:
+			statusCode = fiber.StatusConflict
+			userMessage = "Drop deadline passed"
+			errorDetails = []string{fmt.Sprintf("The drop deadline of this semester was %v. Please contact the academic office.", enrollmentErr.Details["drop_deadline"])}
+
+		case usecases.ErrSeatsAvailable:
+			statusCode = fiber.StatusConflict
+			userMessage = "Course has free seats"
+			errorDetails = []string{"This course offering still has free seats, enroll in it directly instead of joining the waitlist."}
+
+		case usecases.ErrAlreadyWaitlisted:
+			statusCode = fiber.StatusConflict
+			userMessage = "You are already on the waitlist"
+			errorDetails = []string{"You are already waiting for a seat in this course offering."}
+
+		case usecases.ErrWaitlistEntryNotFound:
+			statusCode = fiber.StatusNotFound
+			userMessage = "Not on the waitlist"
+			errorDetails = []string{"You are not waiting for a seat in this course offering, you may have been enrolled already."}
+
+		case usecases.ErrEnrollmentNotFound:
+			statusCode = fiber.StatusNotFound
+			userMessage = "Enrollment not found"
+			errorDetails = []string{"There is no enrollment in this course offering, it may have been dropped already."}
+
+		case usecases.ErrCourseOfferingNotFound:
+			statusCode = fiber.StatusNotFound
+			userMessage = "Course offering not found"
+			errorDetails = []string{"The requested course offering does not exist or may have been cancelled."}
+
+		case usecases.ErrStudentNotFound:
+			statusCode = fiber.StatusForbidden
+			userMessage = "Student record not found"
+			errorDetails = []string{"Your account is not linked to a student record. Please contact the academic office."}
+
+		case usecases.ErrInvalidCourseData:
+			statusCode = fiber.StatusBadRequest
+			userMessage = "Invalid course information"
+			errorDetails = []string{"There is an issue with the course offering data. Please contact the academic office."}
+
+		case usecases.ErrDatabaseOperation:
+			statusCode = fiber.StatusInternalServerError
+			userMessage = "System temporarily unavailable"
+			errorDetails = []string{"A technical issue occurred. Please try again later or contact support if the problem persists."}
+
+		case usecases.ErrTransactionFailed:
+			statusCode = fiber.StatusInternalServerError
+			userMessage = "Enrollment could not be processed"
+			errorDetails = []string{"A system error prevented enrollment completion. Please try again."}
+
+		default:
+			// Keep default values for unknown enrollment errors
+			userMessage = "Enrollment failed"
+			errorDetails = []string{enrollmentErr.Error()}
+		}
+	}
+
+	return statusCode, userMessage, errorDetails
+}
@@ -2,11 +2,13 @@ package handlers
 
 import (
 	"siakad-poc/common"
+	"siakad-poc/middlewares"
 	"siakad-poc/modules/academic/usecases"
 	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 )
 
@@ -42,8 +44,12 @@ func (h *CourseOfferingHandler) HandleListCourseOfferings(c *fiber.Ctx) error {
 		}
 	}
 
-	courseOfferings, pagination, err := h.useCase.GetCourseOfferingsWithPagination(c.Context(), page, pageSize)
+	courseOfferings, pagination, err := h.useCase.GetCourseOfferingsWithPagination(c.Context(), studyProgramScope(c), page, pageSize)
 	if err != nil {
+		if isScopeError(err) {
+			return respondScopeError(c, requestID, clientIP, "Cannot list course offerings", err)
+		}
+
 		log.Error().
 			Stack().
 			Err(err).
@@ -75,6 +81,62 @@ func (h *CourseOfferingHandler) HandleListCourseOfferings(c *fiber.Ctx) error {
 	})
 }
 
+func (h *CourseOfferingHandler) HandleGetCourseOffering(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	courseOffering, err := h.useCase.GetCourseOffering(c.Context(), studyProgramScope(c), id)
+	if err != nil {
+		if isScopeError(err) {
+			return respondScopeError(c, requestID, clientIP, "Cannot get course offering", err)
+		}
+
+		if errors.Is(err, usecases.ErrOfferingNotFound) {
+			log.Warn().
+				Str("request_id", requestID).
+				Str("client_ip", clientIP).
+				Str("course_offering_id", id).
+				Str("path", c.OriginalURL()).
+				Msg("Course offering not found")
+
+			return c.Status(fiber.StatusNotFound).JSON(common.BaseResponse[any]{
+				Status: common.StatusError,
+				Error: &common.BaseResponseError{
+					Message:   "Course offering not found",
+					Details:   []string{err.Error()},
+					Timestamp: time.Now().UTC().Format(time.RFC3339),
+					Path:      c.OriginalURL(),
+				},
+			})
+		}
+
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("course_offering_id", id).
+			Str("path", c.OriginalURL()).
+			Msg("Failed to get course offering")
+
+		return c.Status(fiber.StatusInternalServerError).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Internal server error",
+				Details:   []string{err.Error()},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.CourseOfferingResponse]{
+		Status: common.StatusSuccess,
+		Data:   &courseOffering,
+	})
+}
+
 func (h *CourseOfferingHandler) HandleCreateCourseOffering(c *fiber.Ctx) error {
 	requestID := c.Get(fiber.HeaderXRequestID)
 	clientIP := c.IP()
@@ -124,8 +186,37 @@ func (h *CourseOfferingHandler) HandleCreateCourseOffering(c *fiber.Ctx) error {
 		})
 	}
 
-	response, err := h.useCase.CreateCourseOffering(c.Context(), req)
+	response, err := h.useCase.CreateCourseOffering(c.Context(), studyProgramScope(c), req)
 	if err != nil {
+		if isScopeError(err) {
+			return respondScopeError(c, requestID, clientIP, "Cannot create course offering", err)
+		}
+		if isSemesterError(err) {
+			return respondSemesterError(c, requestID, clientIP, "Cannot create course offering", err)
+		}
+		if isRoomError(err) {
+			return respondRoomAssignmentError(c, requestID, clientIP, "Cannot create course offering", err)
+		}
+		if errors.Is(err, usecases.ErrCourseNotInActiveCurriculum) {
+			log.Warn().
+				Err(err).
+				Str("request_id", requestID).
+				Str("client_ip", clientIP).
+				Str("course_id", req.CourseID).
+				Str("path", c.OriginalURL()).
+				Msg("Course offering rejected, course is not part of an active curriculum")
+
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(common.BaseResponse[any]{
+				Status: common.StatusError,
+				Error: &common.BaseResponseError{
+					Message:   "Cannot create course offering",
+					Details:   []string{err.Error()},
+					Timestamp: time.Now().UTC().Format(time.RFC3339),
+					Path:      c.OriginalURL(),
+				},
+			})
+		}
+
 		log.Error().
 			Stack().
 			Err(err).
@@ -226,9 +317,22 @@ func (h *CourseOfferingHandler) HandleUpdateCourseOffering(c *fiber.Ctx) error {
 		})
 	}
 
-	response, err := h.useCase.UpdateCourseOffering(c.Context(), id, req)
+	response, err := h.useCase.UpdateCourseOffering(c.Context(), studyProgramScope(c), id, req)
 	if err != nil {
-		if err.Error() == "course offering not found" {
+		if isScopeError(err) {
+			return respondScopeError(c, requestID, clientIP, "Cannot update course offering", err)
+		}
+		if isSemesterError(err) {
+			return respondSemesterError(c, requestID, clientIP, "Cannot update course offering", err)
+		}
+		if isRoomError(err) {
+			return respondRoomAssignmentError(c, requestID, clientIP, "Cannot update course offering", err)
+		}
+		if isLecturerAvailabilityError(err) {
+			return respondTeachingAssignmentError(c, requestID, clientIP, id, "Cannot update course offering", err)
+		}
+
+		if errors.Is(err, usecases.ErrOfferingNotFound) {
 			log.Warn().
 				Str("request_id", requestID).
 				Str("client_ip", clientIP).
@@ -301,9 +405,13 @@ func (h *CourseOfferingHandler) HandleDeleteCourseOffering(c *fiber.Ctx) error {
 		})
 	}
 
-	err := h.useCase.DeleteCourseOffering(c.Context(), id)
+	err := h.useCase.DeleteCourseOffering(c.Context(), studyProgramScope(c), id)
 	if err != nil {
-		if err.Error() == "course offering not found" {
+		if isScopeError(err) {
+			return respondScopeError(c, requestID, clientIP, "Cannot delete course offering", err)
+		}
+
+		if errors.Is(err, usecases.ErrOfferingNotFound) {
 			log.Warn().
 				Str("request_id", requestID).
 				Str("client_ip", clientIP).
@@ -344,3 +452,87 @@ func (h *CourseOfferingHandler) HandleDeleteCourseOffering(c *fiber.Ctx) error {
 
 	return c.SendStatus(fiber.StatusNoContent)
 }
+
+// studyProgramScope reads the scope resolved by the StudyProgramScope middleware
+func studyProgramScope(c *fiber.Ctx) common.StudyProgramScope {
+	return c.Locals(middlewares.StudyProgramScopeKey).(common.StudyProgramScope)
+}
+
+func isScopeError(err error) bool {
+	return errors.Is(err, usecases.ErrCourseOutOfScope) || errors.Is(err, usecases.ErrNoStudyProgram)
+}
+
+// respondScopeError answers 403 when the caller tries to reach data outside of their study program
+func respondScopeError(c *fiber.Ctx, requestID, clientIP, message string, err error) error {
+	log.Warn().
+		Err(err).
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("path", c.OriginalURL()).
+		Msg(message)
+
+	return c.Status(fiber.StatusForbidden).JSON(common.BaseResponse[any]{
+		Status: common.StatusError,
+		Error: &common.BaseResponseError{
+			Message:   message,
+			Details:   []string{err.Error()},
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Path:      c.OriginalURL(),
+		},
+	})
+}
+
+func isSemesterError(err error) bool {
+	return errors.Is(err, usecases.ErrUnknownSemester) || errors.Is(err, usecases.ErrOfferingOutsideSemester)
+}
+
+// respondSemesterError answers 422 when the semester does not exist or the offering starts outside its dates
+func respondSemesterError(c *fiber.Ctx, requestID, clientIP, message string, err error) error {
+	log.Warn().
+		Err(err).
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("path", c.OriginalURL()).
+		Msg(message)
+
+	return c.Status(fiber.StatusUnprocessableEntity).JSON(common.BaseResponse[any]{
+		Status: common.StatusError,
+		Error: &common.BaseResponseError{
+			Message:   message,
+			Details:   []string{err.Error()},
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Path:      c.OriginalURL(),
+		},
+	})
+}
+
+func isRoomError(err error) bool {
+	return errors.Is(err, usecases.ErrUnknownRoom) || errors.Is(err, usecases.ErrRoomCapacityTooSmall) ||
+		errors.Is(err, usecases.ErrRoomDoubleBooked)
+}
+
+// respondRoomAssignmentError answers 409 when the room is taken at the offering's time, 422 when the room does
+// not exist or is too small
+func respondRoomAssignmentError(c *fiber.Ctx, requestID, clientIP, message string, err error) error {
+	status := fiber.StatusUnprocessableEntity
+	if errors.Is(err, usecases.ErrRoomDoubleBooked) {
+		status = fiber.StatusConflict
+	}
+
+	log.Warn().
+		Err(err).
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("path", c.OriginalURL()).
+		Msg(message)
+
+	return c.Status(status).JSON(common.BaseResponse[any]{
+		Status: common.StatusError,
+		Error: &common.BaseResponseError{
+			Message:   message,
+			Details:   []string{err.Error()},
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Path:      c.OriginalURL(),
+		},
+	})
+}
@@ -0,0 +1,324 @@
+package handlers
+
+import (
+	"siakad-poc/common"
+	"siakad-poc/db/repositories"
+	"siakad-poc/modules/academic/usecases"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type RoomHandler struct {
+	useCase *usecases.RoomUseCase
+}
+
+func NewRoomHandler(useCase *usecases.RoomUseCase) *RoomHandler {
+	return &RoomHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *RoomHandler) HandleListBuildings(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	page := 1
+	pageSize := 10
+
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	if ps, err := strconv.Atoi(c.Query("page_size")); err == nil && ps > 0 {
+		pageSize = ps
+	}
+
+	buildings, pagination, err := h.useCase.ListBuildings(c.Context(), page, pageSize)
+	if err != nil {
+		return respondRoomError(c, requestID, clientIP, "", "Failed to get buildings", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.PaginatedBaseResponse[[]usecases.BuildingResponse]{
+		BaseResponse: common.BaseResponse[[]usecases.BuildingResponse]{
+			Status: common.StatusSuccess,
+			Data:   &buildings,
+		},
+		Paging: pagination,
+	})
+}
+
+func (h *RoomHandler) HandleGetBuilding(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	building, err := h.useCase.GetBuilding(c.Context(), id)
+	if err != nil {
+		return respondRoomError(c, requestID, clientIP, id, "Failed to get building", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.BuildingResponse]{
+		Status: common.StatusSuccess,
+		Data:   &building,
+	})
+}
+
+func (h *RoomHandler) HandleCreateBuilding(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	var req usecases.BuildingRequest
+	if handled, err := parseRequest(c, &req, "building"); handled {
+		return err
+	}
+
+	building, err := h.useCase.CreateBuilding(c.Context(), req)
+	if err != nil {
+		return respondRoomError(c, requestID, clientIP, "", "Failed to create building", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("building_id", building.ID).
+		Str("code", building.Code).
+		Str("created_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Building created")
+
+	return c.Status(fiber.StatusCreated).JSON(common.BaseResponse[usecases.BuildingResponse]{
+		Status: common.StatusSuccess,
+		Data:   &building,
+	})
+}
+
+func (h *RoomHandler) HandleUpdateBuilding(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	var req usecases.BuildingRequest
+	if handled, err := parseRequest(c, &req, "building"); handled {
+		return err
+	}
+
+	building, err := h.useCase.UpdateBuilding(c.Context(), id, req)
+	if err != nil {
+		return respondRoomError(c, requestID, clientIP, id, "Failed to update building", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("building_id", id).
+		Str("code", building.Code).
+		Str("updated_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Building updated")
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.BuildingResponse]{
+		Status: common.StatusSuccess,
+		Data:   &building,
+	})
+}
+
+func (h *RoomHandler) HandleDeleteBuilding(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	err := h.useCase.DeleteBuilding(c.Context(), id)
+	if err != nil {
+		return respondRoomError(c, requestID, clientIP, id, "Failed to delete building", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("building_id", id).
+		Str("deleted_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Building soft-deleted")
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *RoomHandler) HandleListRooms(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	page := 1
+	pageSize := 10
+
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	if ps, err := strconv.Atoi(c.Query("page_size")); err == nil && ps > 0 {
+		pageSize = ps
+	}
+
+	filter := repositories.RoomFilter{
+		BuildingID: c.Query("building_id"),
+	}
+	if minCapacity, err := strconv.Atoi(c.Query("min_capacity")); err == nil && minCapacity > 0 {
+		filter.MinCapacity = minCapacity
+	}
+
+	rooms, pagination, err := h.useCase.ListRooms(c.Context(), filter, page, pageSize)
+	if err != nil {
+		return respondRoomError(c, requestID, clientIP, "", "Failed to get rooms", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.PaginatedBaseResponse[[]usecases.RoomResponse]{
+		BaseResponse: common.BaseResponse[[]usecases.RoomResponse]{
+			Status: common.StatusSuccess,
+			Data:   &rooms,
+		},
+		Paging: pagination,
+	})
+}
+
+func (h *RoomHandler) HandleGetRoom(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	room, err := h.useCase.GetRoom(c.Context(), id)
+	if err != nil {
+		return respondRoomError(c, requestID, clientIP, id, "Failed to get room", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.RoomResponse]{
+		Status: common.StatusSuccess,
+		Data:   &room,
+	})
+}
+
+func (h *RoomHandler) HandleCreateRoom(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	buildingID := c.Params("id")
+
+	var req usecases.RoomRequest
+	if handled, err := parseRequest(c, &req, "room"); handled {
+		return err
+	}
+
+	room, err := h.useCase.CreateRoom(c.Context(), buildingID, req)
+	if err != nil {
+		return respondRoomError(c, requestID, clientIP, buildingID, "Failed to create room", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("building_id", buildingID).
+		Str("room_id", room.ID).
+		Str("code", room.Code).
+		Int("capacity", room.Capacity).
+		Str("created_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Room created")
+
+	return c.Status(fiber.StatusCreated).JSON(common.BaseResponse[usecases.RoomResponse]{
+		Status: common.StatusSuccess,
+		Data:   &room,
+	})
+}
+
+func (h *RoomHandler) HandleUpdateRoom(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	var req usecases.RoomRequest
+	if handled, err := parseRequest(c, &req, "room"); handled {
+		return err
+	}
+
+	room, err := h.useCase.UpdateRoom(c.Context(), id, req)
+	if err != nil {
+		return respondRoomError(c, requestID, clientIP, id, "Failed to update room", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("room_id", id).
+		Str("code", room.Code).
+		Int("capacity", room.Capacity).
+		Str("updated_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Room updated")
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.RoomResponse]{
+		Status: common.StatusSuccess,
+		Data:   &room,
+	})
+}
+
+func (h *RoomHandler) HandleDeleteRoom(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	err := h.useCase.DeleteRoom(c.Context(), id)
+	if err != nil {
+		return respondRoomError(c, requestID, clientIP, id, "Failed to delete room", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("room_id", id).
+		Str("deleted_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Room soft-deleted")
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// respondRoomError maps building and room errors to HTTP status codes, unknown errors become 500.
+func respondRoomError(c *fiber.Ctx, requestID, clientIP, resourceID, message string, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, usecases.ErrBuildingNotFound), errors.Is(err, usecases.ErrRoomNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, usecases.ErrBuildingCodeAlreadyUsed), errors.Is(err, usecases.ErrBuildingHasRooms),
+		errors.Is(err, usecases.ErrRoomCodeAlreadyUsed), errors.Is(err, usecases.ErrRoomHasActiveOfferings),
+		errors.Is(err, usecases.ErrRoomCapacityBelowOfferings):
+		status = fiber.StatusConflict
+	}
+
+	if status == fiber.StatusInternalServerError {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("resource_id", resourceID).
+			Str("path", c.OriginalURL()).
+			Msg(message)
+	} else {
+		log.Warn().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("resource_id", resourceID).
+			Str("path", c.OriginalURL()).
+			Msg(message)
+	}
+
+	return c.Status(status).JSON(common.BaseResponse[any]{
+		Status: common.StatusError,
+		Error: &common.BaseResponseError{
+			Message:   message,
+			Details:   []string{err.Error()},
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Path:      c.OriginalURL(),
+		},
+	})
+}
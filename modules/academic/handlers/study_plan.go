@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"siakad-poc/common"
+	"siakad-poc/modules/academic/usecases"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type StudyPlanHandler struct {
+	useCase *usecases.StudyPlanUseCase
+}
+
+func NewStudyPlanHandler(useCase *usecases.StudyPlanUseCase) *StudyPlanHandler {
+	return &StudyPlanHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *StudyPlanHandler) HandleListOwnStudyPlans(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	userID := actorID(c)
+
+	studyPlans, err := h.useCase.ListOwnStudyPlans(c.Context(), userID)
+	if err != nil {
+		return respondStudyPlanError(c, requestID, clientIP, userID, "Failed to get study plans", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[[]usecases.StudyPlanResponse]{
+		Status: common.StatusSuccess,
+		Data:   &studyPlans,
+	})
+}
+
+func (h *StudyPlanHandler) HandleGetOwnStudyPlan(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	studyPlan, err := h.useCase.GetOwnStudyPlan(c.Context(), actorID(c), id)
+	if err != nil {
+		return respondStudyPlanError(c, requestID, clientIP, id, "Failed to get study plan", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.StudyPlanDetailResponse]{
+		Status: common.StatusSuccess,
+		Data:   &studyPlan,
+	})
+}
+
+func (h *StudyPlanHandler) HandleSubmitStudyPlan(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	studyPlan, err := h.useCase.SubmitStudyPlan(c.Context(), actorID(c), id)
+	if err != nil {
+		return respondStudyPlanError(c, requestID, clientIP, id, "Failed to submit study plan", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("study_plan_id", id).
+		Str("submitted_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Study plan submitted for review")
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.StudyPlanDetailResponse]{
+		Status: common.StatusSuccess,
+		Data:   &studyPlan,
+	})
+}
+
+func (h *StudyPlanHandler) HandleListAdviseeStudyPlans(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	userID := actorID(c)
+
+	studyPlans, err := h.useCase.ListAdviseeStudyPlans(c.Context(), userID, c.Query("status"))
+	if err != nil {
+		return respondStudyPlanError(c, requestID, clientIP, userID, "Failed to get study plans of the advisees", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[[]usecases.StudyPlanResponse]{
+		Status: common.StatusSuccess,
+		Data:   &studyPlans,
+	})
+}
+
+func (h *StudyPlanHandler) HandleGetAdviseeStudyPlan(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	studyPlan, err := h.useCase.GetAdviseeStudyPlan(c.Context(), actorID(c), id)
+	if err != nil {
+		return respondStudyPlanError(c, requestID, clientIP, id, "Failed to get study plan", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.StudyPlanDetailResponse]{
+		Status: common.StatusSuccess,
+		Data:   &studyPlan,
+	})
+}
+
+func (h *StudyPlanHandler) HandleApproveStudyPlan(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	// The note of an approval is optional, so is the body
+	var req usecases.ReviewStudyPlanRequest
+	if len(c.Body()) > 0 {
+		if handled, err := parseRequest(c, &req, "approve study plan"); handled {
+			return err
+		}
+	}
+
+	studyPlan, err := h.useCase.ApproveStudyPlan(c.Context(), actorID(c), id, req)
+	if err != nil {
+		return respondStudyPlanError(c, requestID, clientIP, id, "Failed to approve study plan", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("study_plan_id", id).
+		Str("approved_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Study plan approved")
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.StudyPlanDetailResponse]{
+		Status: common.StatusSuccess,
+		Data:   &studyPlan,
+	})
+}
+
+func (h *StudyPlanHandler) HandleRejectStudyPlan(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	var req usecases.RejectStudyPlanRequest
+	if handled, err := parseRequest(c, &req, "reject study plan"); handled {
+		return err
+	}
+
+	studyPlan, err := h.useCase.RejectStudyPlan(c.Context(), actorID(c), id, req)
+	if err != nil {
+		return respondStudyPlanError(c, requestID, clientIP, id, "Failed to reject study plan", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("study_plan_id", id).
+		Str("rejected_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Study plan rejected")
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.StudyPlanDetailResponse]{
+		Status: common.StatusSuccess,
+		Data:   &studyPlan,
+	})
+}
+
+func (h *StudyPlanHandler) HandleApproveStudyPlanLine(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+	lineID := c.Params("lineId")
+
+	var req usecases.ReviewStudyPlanRequest
+	if len(c.Body()) > 0 {
+		if handled, err := parseRequest(c, &req, "approve study plan line"); handled {
+			return err
+		}
+	}
+
+	studyPlan, err := h.useCase.ApproveStudyPlanLine(c.Context(), actorID(c), id, lineID, req)
+	if err != nil {
+		return respondStudyPlanError(c, requestID, clientIP, lineID, "Failed to approve study plan line", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("study_plan_id", id).
+		Str("line_id", lineID).
+		Str("approved_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Study plan line approved")
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.StudyPlanDetailResponse]{
+		Status: common.StatusSuccess,
+		Data:   &studyPlan,
+	})
+}
+
+func (h *StudyPlanHandler) HandleRejectStudyPlanLine(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+	lineID := c.Params("lineId")
+
+	var req usecases.RejectStudyPlanRequest
+	if handled, err := parseRequest(c, &req, "reject study plan line"); handled {
+		return err
+	}
+
+	studyPlan, err := h.useCase.RejectStudyPlanLine(c.Context(), actorID(c), id, lineID, req)
+	if err != nil {
+		return respondStudyPlanError(c, requestID, clientIP, lineID, "Failed to reject study plan line", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("study_plan_id", id).
+		Str("line_id", lineID).
+		Str("rejected_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Study plan line rejected")
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.StudyPlanDetailResponse]{
+		Status: common.StatusSuccess,
+		Data:   &studyPlan,
+	})
+}
+
+// respondStudyPlanError maps study plan errors to HTTP status codes, unknown errors become 500.
+func respondStudyPlanError(c *fiber.Ctx, requestID, clientIP, resourceID, message string, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, usecases.ErrNotAStudent), errors.Is(err, usecases.ErrNotALecturer):
+		status = fiber.StatusForbidden
+	case errors.Is(err, usecases.ErrStudyPlanNotFound), errors.Is(err, usecases.ErrStudyPlanLineNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, usecases.ErrInvalidStudyPlanStatus):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, usecases.ErrStudyPlanEmpty):
+		status = fiber.StatusUnprocessableEntity
+	case errors.Is(err, usecases.ErrStudyPlanNotSubmittable), errors.Is(err, usecases.ErrStudyPlanNotSubmitted),
+		errors.Is(err, usecases.ErrStudyPlanLineAlreadyReviewed):
+		status = fiber.StatusConflict
+	}
+
+	if status == fiber.StatusInternalServerError {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("resource_id", resourceID).
+			Str("path", c.OriginalURL()).
+			Msg(message)
+	} else {
+		log.Warn().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("resource_id", resourceID).
+			Str("path", c.OriginalURL()).
+			Msg(message)
+	}
+
+	return c.Status(status).JSON(common.BaseResponse[any]{
+		Status: common.StatusError,
+		Error: &common.BaseResponseError{
+			Message:   message,
+			Details:   []string{err.Error()},
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Path:      c.OriginalURL(),
+		},
+	})
+}
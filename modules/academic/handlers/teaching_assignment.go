@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"siakad-poc/common"
+	"siakad-poc/modules/academic/usecases"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+func (h *CourseOfferingHandler) HandleListCourseOfferingLecturers(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	lecturers, err := h.useCase.ListCourseOfferingLecturers(c.Context(), studyProgramScope(c), id)
+	if err != nil {
+		return respondTeachingAssignmentError(c, requestID, clientIP, id, "Failed to get course offering lecturers", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[[]usecases.CourseOfferingLecturerResponse]{
+		Status: common.StatusSuccess,
+		Data:   &lecturers,
+	})
+}
+
+func (h *CourseOfferingHandler) HandleAssignLecturers(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	var req usecases.AssignLecturersRequest
+	if handled, err := parseRequest(c, &req, "assign lecturers"); handled {
+		return err
+	}
+
+	lecturers, err := h.useCase.AssignLecturers(c.Context(), studyProgramScope(c), id, req)
+	if err != nil {
+		return respondTeachingAssignmentError(c, requestID, clientIP, id, "Failed to assign lecturers", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("course_offering_id", id).
+		Strs("lecturer_ids", req.LecturerIDs).
+		Str("assigned_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Lecturers assigned to course offering")
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[[]usecases.CourseOfferingLecturerResponse]{
+		Status: common.StatusSuccess,
+		Data:   &lecturers,
+	})
+}
+
+func (h *CourseOfferingHandler) HandleRemoveLecturer(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+	lecturerID := c.Params("lecturerId")
+
+	err := h.useCase.RemoveLecturer(c.Context(), studyProgramScope(c), id, lecturerID)
+	if err != nil {
+		return respondTeachingAssignmentError(c, requestID, clientIP, id, "Failed to remove lecturer", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("course_offering_id", id).
+		Str("lecturer_id", lecturerID).
+		Str("removed_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Lecturer removed from course offering")
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *CourseOfferingHandler) HandleGetLecturerSchedule(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	semesterID := c.Query("semester_id")
+	if semesterID == "" {
+		log.Warn().
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("lecturer_id", id).
+			Str("path", c.OriginalURL()).
+			Msg("Semester ID missing from query parameter")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Semester ID is required",
+				Details:   []string{"semester_id query parameter is missing"},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	schedule, err := h.useCase.GetLecturerSchedule(c.Context(), id, semesterID)
+	if err != nil {
+		return respondTeachingAssignmentError(c, requestID, clientIP, id, "Failed to get lecturer schedule", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.LecturerScheduleResponse]{
+		Status: common.StatusSuccess,
+		Data:   &schedule,
+	})
+}
+
+func isLecturerAvailabilityError(err error) bool {
+	return errors.Is(err, usecases.ErrLecturerScheduleClash) || errors.Is(err, usecases.ErrTeachingLoadExceeded)
+}
+
+// respondTeachingAssignmentError maps teaching assignment errors to HTTP status codes, unknown errors become 500.
+func respondTeachingAssignmentError(c *fiber.Ctx, requestID, clientIP, resourceID, message string, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case isScopeError(err):
+		status = fiber.StatusForbidden
+	case errors.Is(err, usecases.ErrOfferingNotFound), errors.Is(err, usecases.ErrLecturerNotFound),
+		errors.Is(err, usecases.ErrLecturerNotAssigned):
+		status = fiber.StatusNotFound
+	case errors.Is(err, usecases.ErrUnknownLecturer):
+		status = fiber.StatusUnprocessableEntity
+	case errors.Is(err, usecases.ErrLecturerAlreadyAssigned), isLecturerAvailabilityError(err):
+		status = fiber.StatusConflict
+	}
+
+	if status == fiber.StatusInternalServerError {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("resource_id", resourceID).
+			Str("path", c.OriginalURL()).
+			Msg(message)
+	} else {
+		log.Warn().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("resource_id", resourceID).
+			Str("path", c.OriginalURL()).
+			Msg(message)
+	}
+
+	return c.Status(status).JSON(common.BaseResponse[any]{
+		Status: common.StatusError,
+		Error: &common.BaseResponseError{
+			Message:   message,
+			Details:   []string{err.Error()},
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Path:      c.OriginalURL(),
+		},
+	})
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"siakad-poc/common"
+	"siakad-poc/modules/academic/usecases"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+// HandleJoinWaitlist puts the student linked to the token on the waitlist of a full course offering
+func (h *CourseEnrollmentHandler) HandleJoinWaitlist(c *fiber.Ctx) error {
+	courseOfferingID := c.Params("id")
+	userID := actorID(c)
+
+	position, err := h.enrollmentUseCase.JoinUserWaitlist(c.Context(), userID, courseOfferingID)
+	if err != nil {
+		return respondEnrollmentError(c, err, position.StudentID, courseOfferingID, "Joining course offering waitlist failed")
+	}
+
+	log.Info().
+		Str("request_id", c.Get(fiber.HeaderXRequestID)).
+		Str("user_id", userID).
+		Str("student_id", position.StudentID).
+		Str("course_offering_id", courseOfferingID).
+		Int64("position", position.Position).
+		Msg("Student joined course offering waitlist")
+
+	return c.Status(fiber.StatusCreated).JSON(common.BaseResponse[usecases.WaitlistPositionResponse]{
+		Status: common.StatusSuccess,
+		Data:   &position,
+	})
+}
+
+// HandleGetWaitlistPosition returns the place of the student linked to the token in the waitlist
+func (h *CourseEnrollmentHandler) HandleGetWaitlistPosition(c *fiber.Ctx) error {
+	courseOfferingID := c.Params("id")
+
+	position, err := h.enrollmentUseCase.GetUserWaitlistPosition(c.Context(), actorID(c), courseOfferingID)
+	if err != nil {
+		return respondEnrollmentError(c, err, position.StudentID, courseOfferingID, "Getting course offering waitlist position failed")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.WaitlistPositionResponse]{
+		Status: common.StatusSuccess,
+		Data:   &position,
+	})
+}
+
+// HandleLeaveWaitlist takes the student linked to the token off the waitlist
+func (h *CourseEnrollmentHandler) HandleLeaveWaitlist(c *fiber.Ctx) error {
+	courseOfferingID := c.Params("id")
+	userID := actorID(c)
+
+	studentID, err := h.enrollmentUseCase.LeaveUserWaitlist(c.Context(), userID, courseOfferingID)
+	if err != nil {
+		return respondEnrollmentError(c, err, studentID, courseOfferingID, "Leaving course offering waitlist failed")
+	}
+
+	log.Info().
+		Str("request_id", c.Get(fiber.HeaderXRequestID)).
+		Str("user_id", userID).
+		Str("student_id", studentID).
+		Str("course_offering_id", courseOfferingID).
+		Msg("Student left course offering waitlist")
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -2,6 +2,8 @@ package academic
 
 import (
 	"siakad-poc/common"
+	"siakad-poc/common/jwtkeys"
+	"siakad-poc/config"
 	"siakad-poc/constants"
 	"siakad-poc/db/repositories"
 	"siakad-poc/middlewares"
@@ -14,63 +16,376 @@ import (
 )
 
 type AcademicModule struct {
-	academicRepository      repositories.AcademicRepository
-	courseOfferingUseCase   *usecases.CourseOfferingUseCase
-	courseEnrollmentUseCase *usecases.CourseEnrollmentUseCase
-	courseOfferingHandler   *handlers.CourseOfferingHandler
-	courseEnrollmentHandler *handlers.CourseEnrollmentHandler
+	academicRepository        repositories.AcademicRepository
+	userRepository            repositories.UserRepository
+	tokenRevocationRepository repositories.TokenRevocationRepository
+	permissionRepository      repositories.PermissionRepository
+	keyring                   *jwtkeys.Keyring
+	academicCalendarUseCase   *usecases.AcademicCalendarUseCase
+	courseUseCase             *usecases.CourseUseCase
+	courseOfferingUseCase     *usecases.CourseOfferingUseCase
+	courseEnrollmentUseCase   *usecases.CourseEnrollmentUseCase
+	roomUseCase               *usecases.RoomUseCase
+	studyPlanUseCase          *usecases.StudyPlanUseCase
+	academicCalendarHandler   *handlers.AcademicCalendarHandler
+	courseHandler             *handlers.CourseHandler
+	courseOfferingHandler     *handlers.CourseOfferingHandler
+	courseEnrollmentHandler   *handlers.CourseEnrollmentHandler
+	roomHandler               *handlers.RoomHandler
+	studyPlanHandler          *handlers.StudyPlanHandler
 }
 
 // Compile time interface conformance check
 var _ modules.RoutableModule = (*AcademicModule)(nil)
 
-func NewModule(pool *pgxpool.Pool) *AcademicModule {
+func NewModule(pool *pgxpool.Pool, tokenRevocationRepository repositories.TokenRevocationRepository, permissionRepository repositories.PermissionRepository, keyring *jwtkeys.Keyring) *AcademicModule {
 	txExecutor := common.NewPgxTransactionExecutor(pool)
 	academicRepository := repositories.NewDefaultAcademicRepository(pool)
+	userRepository := repositories.NewDefaultUserRepository(pool)
+	studentRepository := repositories.NewDefaultStudentRepository(pool)
+	courseRepository := repositories.NewDefaultCourseRepository(pool)
+	calendarRepository := repositories.NewDefaultAcademicCalendarRepository(pool)
+	roomRepository := repositories.NewDefaultRoomRepository(pool)
+	lecturerRepository := repositories.NewDefaultLecturerRepository(pool)
+	studyPlanRepository := repositories.NewDefaultStudyPlanRepository(pool)
+	creditLoadRepository := repositories.NewDefaultCreditLoadRepository(pool)
+	waitlistRepository := repositories.NewDefaultWaitlistRepository(pool)
 
-	courseOfferingUseCase := usecases.NewCourseOfferingUseCase(academicRepository)
-	courseEnrollmentUseCase := usecases.NewCourseEnrollmentUseCase(academicRepository, txExecutor)
+	academicCalendarUseCase := usecases.NewAcademicCalendarUseCase(calendarRepository)
+	courseUseCase := usecases.NewCourseUseCase(courseRepository)
+	campusLocation, _ := config.CurrentConfig.Academic.CampusLocation() // validated on startup
+	enrollmentPolicy := usecases.EnrollmentPolicy{
+		PrerequisiteMinGrade: config.CurrentConfig.Academic.PrerequisiteMinimumGrade(),
+		MaxCreditsWithoutGPA: int32(config.CurrentConfig.Academic.MaxCreditsWithoutPreviousGPA()),
+		DropPeriodDays:       config.CurrentConfig.Academic.DropPeriod(),
+		CampusLocation:       campusLocation,
+	}
+	for _, rule := range config.CurrentConfig.Academic.CreditLoadRules() {
+		enrollmentPolicy.CreditLoad = append(enrollmentPolicy.CreditLoad, usecases.CreditLoadRule{
+			MinGPA:     rule.MinGPA,
+			MaxCredits: int32(rule.MaxCredits),
+		})
+	}
+	courseEnrollmentUseCase := usecases.NewCourseEnrollmentUseCase(academicRepository, studentRepository, studyPlanRepository, creditLoadRepository, waitlistRepository, txExecutor, enrollmentPolicy)
+	teachingPolicy := usecases.TeachingPolicy{
+		MaxTeachingCredits: config.CurrentConfig.Academic.MaxTeachingCreditsPerSemester(),
+		CampusLocation:     campusLocation,
+	}
+	courseOfferingUseCase := usecases.NewCourseOfferingUseCase(academicRepository, roomRepository, lecturerRepository, calendarRepository, courseEnrollmentUseCase, txExecutor, teachingPolicy)
+	roomUseCase := usecases.NewRoomUseCase(roomRepository)
+	studyPlanUseCase := usecases.NewStudyPlanUseCase(studyPlanRepository, studentRepository, lecturerRepository, courseEnrollmentUseCase, txExecutor)
 
+	academicCalendarHandler := handlers.NewAcademicCalendarHandler(academicCalendarUseCase)
+	courseHandler := handlers.NewCourseHandler(courseUseCase)
 	courseOfferingHandler := handlers.NewCourseOfferingHandler(courseOfferingUseCase)
 	courseEnrollmentHandler := handlers.NewEnrollmentHandler(courseEnrollmentUseCase)
+	roomHandler := handlers.NewRoomHandler(roomUseCase)
+	studyPlanHandler := handlers.NewStudyPlanHandler(studyPlanUseCase)
 
 	return &AcademicModule{
-		academicRepository:      academicRepository,
-		courseOfferingUseCase:   courseOfferingUseCase,
-		courseEnrollmentUseCase: courseEnrollmentUseCase,
-		courseOfferingHandler:   courseOfferingHandler,
-		courseEnrollmentHandler: courseEnrollmentHandler,
+		academicRepository:        academicRepository,
+		userRepository:            userRepository,
+		tokenRevocationRepository: tokenRevocationRepository,
+		permissionRepository:      permissionRepository,
+		keyring:                   keyring,
+		academicCalendarUseCase:   academicCalendarUseCase,
+		courseUseCase:             courseUseCase,
+		courseOfferingUseCase:     courseOfferingUseCase,
+		courseEnrollmentUseCase:   courseEnrollmentUseCase,
+		roomUseCase:               roomUseCase,
+		studyPlanUseCase:          studyPlanUseCase,
+		academicCalendarHandler:   academicCalendarHandler,
+		courseHandler:             courseHandler,
+		courseOfferingHandler:     courseOfferingHandler,
+		courseEnrollmentHandler:   courseEnrollmentHandler,
+		roomHandler:               roomHandler,
+		studyPlanHandler:          studyPlanHandler,
 	}
 }
 
 func (m *AcademicModule) SetupRoutes(fiberApp *fiber.App, prefix string) {
 	academicGroup := fiberApp.Group(prefix)
-	academicGroup.Use(middlewares.JWT())
+	academicGroup.Use(middlewares.JWT(m.keyring, m.tokenRevocationRepository))
 	academicGroup.Post(
 		"/course-offering/:id/enroll",
-		middlewares.ShouldBeAccessedByRoles([]constants.RoleType{constants.RoleStudent}),
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionEnrollmentCreate),
 		m.courseEnrollmentHandler.HandleCourseEnrollment,
 	)
+	academicGroup.Delete(
+		"/course-offering/:id/enroll",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionEnrollmentCreate),
+		m.courseEnrollmentHandler.HandleDropEnrollment,
+	)
+	academicGroup.Post(
+		"/course-offering/:id/enrollments/:studentId/withdraw",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionEnrollmentWithdraw),
+		m.courseEnrollmentHandler.HandleWithdrawEnrollment,
+	)
+
+	// Waitlist routes, students wait for a seat in a full course offering and are enrolled when one frees up
+	academicGroup.Get(
+		"/course-offering/:id/waitlist",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionEnrollmentCreate),
+		m.courseEnrollmentHandler.HandleGetWaitlistPosition,
+	)
+	academicGroup.Post(
+		"/course-offering/:id/waitlist",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionEnrollmentCreate),
+		m.courseEnrollmentHandler.HandleJoinWaitlist,
+	)
+	academicGroup.Delete(
+		"/course-offering/:id/waitlist",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionEnrollmentCreate),
+		m.courseEnrollmentHandler.HandleLeaveWaitlist,
+	)
 
-	// Course offering CRUD routes (Admin and Koorprodi only)
+	// Study plan (KRS) routes, students see and submit their own plans, academic advisors review the plans of their
+	// advisees
+	academicGroup.Get(
+		"/study-plans",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionStudyPlanSubmit),
+		m.studyPlanHandler.HandleListOwnStudyPlans,
+	)
+	academicGroup.Get(
+		"/study-plans/:id",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionStudyPlanSubmit),
+		m.studyPlanHandler.HandleGetOwnStudyPlan,
+	)
+	academicGroup.Post(
+		"/study-plans/:id/submit",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionStudyPlanSubmit),
+		m.studyPlanHandler.HandleSubmitStudyPlan,
+	)
+	academicGroup.Get(
+		"/advisees/study-plans",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionStudyPlanReview),
+		m.studyPlanHandler.HandleListAdviseeStudyPlans,
+	)
+	academicGroup.Get(
+		"/advisees/study-plans/:id",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionStudyPlanReview),
+		m.studyPlanHandler.HandleGetAdviseeStudyPlan,
+	)
+	academicGroup.Post(
+		"/advisees/study-plans/:id/approve",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionStudyPlanReview),
+		m.studyPlanHandler.HandleApproveStudyPlan,
+	)
+	academicGroup.Post(
+		"/advisees/study-plans/:id/reject",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionStudyPlanReview),
+		m.studyPlanHandler.HandleRejectStudyPlan,
+	)
+	academicGroup.Post(
+		"/advisees/study-plans/:id/lines/:lineId/approve",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionStudyPlanReview),
+		m.studyPlanHandler.HandleApproveStudyPlanLine,
+	)
+	academicGroup.Post(
+		"/advisees/study-plans/:id/lines/:lineId/reject",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionStudyPlanReview),
+		m.studyPlanHandler.HandleRejectStudyPlanLine,
+	)
+
+	// Academic calendar routes, reads are open to every authenticated user
+	academicGroup.Get("/academic-years", m.academicCalendarHandler.HandleListAcademicYears)
+	academicGroup.Get("/academic-years/:id", m.academicCalendarHandler.HandleGetAcademicYear)
+	academicGroup.Post(
+		"/academic-years",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionAcademicCalendarManage),
+		m.academicCalendarHandler.HandleCreateAcademicYear,
+	)
+	academicGroup.Put(
+		"/academic-years/:id",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionAcademicCalendarManage),
+		m.academicCalendarHandler.HandleUpdateAcademicYear,
+	)
+	academicGroup.Delete(
+		"/academic-years/:id",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionAcademicCalendarManage),
+		m.academicCalendarHandler.HandleDeleteAcademicYear,
+	)
+	academicGroup.Post(
+		"/academic-years/:id/semesters",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionAcademicCalendarManage),
+		m.academicCalendarHandler.HandleCreateSemester,
+	)
+	academicGroup.Get("/semesters/current", m.academicCalendarHandler.HandleGetCurrentSemester)
+	academicGroup.Get("/semesters/:id", m.academicCalendarHandler.HandleGetSemester)
+	academicGroup.Put(
+		"/semesters/:id",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionAcademicCalendarManage),
+		m.academicCalendarHandler.HandleUpdateSemester,
+	)
+	academicGroup.Delete(
+		"/semesters/:id",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionAcademicCalendarManage),
+		m.academicCalendarHandler.HandleDeleteSemester,
+	)
+
+	// Building (gedung) and room (ruang) routes, reads are open to every authenticated user
+	academicGroup.Get("/buildings", m.roomHandler.HandleListBuildings)
+	academicGroup.Get("/buildings/:id", m.roomHandler.HandleGetBuilding)
+	academicGroup.Post(
+		"/buildings",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionRoomManage),
+		m.roomHandler.HandleCreateBuilding,
+	)
+	academicGroup.Put(
+		"/buildings/:id",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionRoomManage),
+		m.roomHandler.HandleUpdateBuilding,
+	)
+	academicGroup.Delete(
+		"/buildings/:id",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionRoomManage),
+		m.roomHandler.HandleDeleteBuilding,
+	)
+	academicGroup.Post(
+		"/buildings/:id/rooms",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionRoomManage),
+		m.roomHandler.HandleCreateRoom,
+	)
+	academicGroup.Get("/rooms", m.roomHandler.HandleListRooms)
+	academicGroup.Get("/rooms/:id", m.roomHandler.HandleGetRoom)
+	academicGroup.Put(
+		"/rooms/:id",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionRoomManage),
+		m.roomHandler.HandleUpdateRoom,
+	)
+	academicGroup.Delete(
+		"/rooms/:id",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionRoomManage),
+		m.roomHandler.HandleDeleteRoom,
+	)
+
+	// Course catalogue (mata kuliah) CRUD routes
+	academicGroup.Get(
+		"/courses",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionCourseRead),
+		m.courseHandler.HandleListCourses,
+	)
+	academicGroup.Get(
+		"/courses/:id",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionCourseRead),
+		m.courseHandler.HandleGetCourse,
+	)
+	academicGroup.Post(
+		"/courses",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionCourseWrite),
+		m.courseHandler.HandleCreateCourse,
+	)
+	academicGroup.Put(
+		"/courses/:id",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionCourseWrite),
+		m.courseHandler.HandleUpdateCourse,
+	)
+	academicGroup.Delete(
+		"/courses/:id",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionCourseWrite),
+		m.courseHandler.HandleDeleteCourse,
+	)
+	academicGroup.Get(
+		"/courses/:id/prerequisites",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionCourseRead),
+		m.courseHandler.HandleListCoursePrerequisites,
+	)
+	academicGroup.Post(
+		"/courses/:id/prerequisites",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionCourseWrite),
+		m.courseHandler.HandleAddCoursePrerequisite,
+	)
+	academicGroup.Delete(
+		"/courses/:id/prerequisites/:prerequisiteId",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionCourseWrite),
+		m.courseHandler.HandleRemoveCoursePrerequisite,
+	)
+	academicGroup.Get(
+		"/courses/:id/corequisites",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionCourseRead),
+		m.courseHandler.HandleListCourseCorequisites,
+	)
+	academicGroup.Post(
+		"/courses/:id/corequisites",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionCourseWrite),
+		m.courseHandler.HandleAddCourseCorequisite,
+	)
+	academicGroup.Delete(
+		"/courses/:id/corequisites/:corequisiteId",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionCourseWrite),
+		m.courseHandler.HandleRemoveCourseCorequisite,
+	)
+	academicGroup.Get(
+		"/courses/:id/exclusions",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionCourseRead),
+		m.courseHandler.HandleListCourseExclusions,
+	)
+	academicGroup.Post(
+		"/courses/:id/exclusions",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionCourseWrite),
+		m.courseHandler.HandleAddCourseExclusion,
+	)
+	academicGroup.Delete(
+		"/courses/:id/exclusions/:excludedId",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionCourseWrite),
+		m.courseHandler.HandleRemoveCourseExclusion,
+	)
+
+	// Course offering CRUD routes, scoped to the study program of the caller
+	studyProgramScope := middlewares.StudyProgramScope(m.permissionRepository, m.userRepository)
 	academicGroup.Get(
 		"/course-offerings",
-		middlewares.ShouldBeAccessedByRoles([]constants.RoleType{constants.RoleAdmin, constants.RoleKoorprodi}),
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionCourseOfferingRead),
+		studyProgramScope,
 		m.courseOfferingHandler.HandleListCourseOfferings,
 	)
+	academicGroup.Get(
+		"/course-offering/:id",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionCourseOfferingRead),
+		studyProgramScope,
+		m.courseOfferingHandler.HandleGetCourseOffering,
+	)
 	academicGroup.Post(
 		"/course-offering",
-		middlewares.ShouldBeAccessedByRoles([]constants.RoleType{constants.RoleAdmin, constants.RoleKoorprodi}),
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionCourseOfferingWrite),
+		studyProgramScope,
 		m.courseOfferingHandler.HandleCreateCourseOffering,
 	)
 	academicGroup.Put(
 		"/course-offering/:id",
-		middlewares.ShouldBeAccessedByRoles([]constants.RoleType{constants.RoleAdmin, constants.RoleKoorprodi}),
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionCourseOfferingWrite),
+		studyProgramScope,
 		m.courseOfferingHandler.HandleUpdateCourseOffering,
 	)
 	academicGroup.Delete(
 		"/course-offering/:id",
-		middlewares.ShouldBeAccessedByRoles([]constants.RoleType{constants.RoleAdmin, constants.RoleKoorprodi}),
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionCourseOfferingWrite),
+		studyProgramScope,
 		m.courseOfferingHandler.HandleDeleteCourseOffering,
 	)
+
+	// Teaching assignment (pengampu mata kuliah) routes, assignments follow the scope of the course offering
+	academicGroup.Get(
+		"/course-offering/:id/lecturers",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionCourseOfferingRead),
+		studyProgramScope,
+		m.courseOfferingHandler.HandleListCourseOfferingLecturers,
+	)
+	academicGroup.Post(
+		"/course-offering/:id/lecturers",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionCourseOfferingWrite),
+		studyProgramScope,
+		m.courseOfferingHandler.HandleAssignLecturers,
+	)
+	academicGroup.Delete(
+		"/course-offering/:id/lecturers/:lecturerId",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionCourseOfferingWrite),
+		studyProgramScope,
+		m.courseOfferingHandler.HandleRemoveLecturer,
+	)
+	academicGroup.Get(
+		"/lecturers/:id/schedule",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionCourseOfferingRead),
+		m.courseOfferingHandler.HandleGetLecturerSchedule,
+	)
 }
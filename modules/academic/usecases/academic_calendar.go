@@ -0,0 +1,425 @@
+package usecases
+
+import (
+	"context"
+	"math"
+	"siakad-poc/common"
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+type AcademicYearResponse struct {
+	ID        string             `json:"id"`
+	Code      string             `json:"code"`
+	StartTime time.Time          `json:"start_time"`
+	EndTime   time.Time          `json:"end_time"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt *time.Time         `json:"updated_at"`
+	Semesters []SemesterResponse `json:"semesters,omitempty"`
+}
+
+type SemesterResponse struct {
+	ID             string     `json:"id"`
+	AcademicYearID string     `json:"academic_year_id"`
+	Code           string     `json:"code"`
+	StartTime      time.Time  `json:"start_time"`
+	EndTime        time.Time  `json:"end_time"`
+	DropDeadline   *time.Time `json:"drop_deadline"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      *time.Time `json:"updated_at"`
+}
+
+// AcademicYearRequest is the payload to create or update an academic year (tahun akademik), e.g. "2025/2026"
+type AcademicYearRequest struct {
+	Code      string    `json:"code" validate:"required,max=255"`
+	StartTime time.Time `json:"start_time" validate:"required"`
+	EndTime   time.Time `json:"end_time" validate:"required,gtfield=StartTime"`
+}
+
+// SemesterRequest is the payload to create or update a semester, e.g. "Ganjil" or "Genap". Without a drop
+// deadline students can drop their registrations until the drop period of the enrollment policy ends.
+type SemesterRequest struct {
+	Code         string     `json:"code" validate:"required,max=255"`
+	StartTime    time.Time  `json:"start_time" validate:"required"`
+	EndTime      time.Time  `json:"end_time" validate:"required,gtfield=StartTime"`
+	DropDeadline *time.Time `json:"drop_deadline"`
+}
+
+type AcademicCalendarUseCase struct {
+	calendarRepository repositories.AcademicCalendarRepository
+}
+
+func NewAcademicCalendarUseCase(calendarRepository repositories.AcademicCalendarRepository) *AcademicCalendarUseCase {
+	return &AcademicCalendarUseCase{
+		calendarRepository: calendarRepository,
+	}
+}
+
+func (uc *AcademicCalendarUseCase) ListAcademicYears(ctx context.Context, page, pageSize int) ([]AcademicYearResponse, *common.PaginationMetadata, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+
+	academicYears, err := uc.calendarRepository.ListAcademicYears(ctx, pageSize, offset)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot get academic years")
+	}
+
+	totalRecords, err := uc.calendarRepository.CountAcademicYears(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot count academic years")
+	}
+
+	responses := make([]AcademicYearResponse, 0, len(academicYears))
+	for _, academicYear := range academicYears {
+		responses = append(responses, toAcademicYearResponse(academicYear))
+	}
+
+	pagination := &common.PaginationMetadata{
+		Page:         page,
+		PageSize:     pageSize,
+		TotalRecords: int(totalRecords),
+		TotalPages:   int(math.Ceil(float64(totalRecords) / float64(pageSize))),
+	}
+
+	return responses, pagination, nil
+}
+
+// GetAcademicYear returns the academic year with its semesters.
+func (uc *AcademicCalendarUseCase) GetAcademicYear(ctx context.Context, id string) (AcademicYearResponse, error) {
+	academicYear, err := uc.getAcademicYear(ctx, id)
+	if err != nil {
+		return AcademicYearResponse{}, err
+	}
+
+	semesters, err := uc.calendarRepository.ListSemestersByAcademicYear(ctx, id)
+	if err != nil {
+		return AcademicYearResponse{}, errors.Wrap(err, "cannot get semesters")
+	}
+
+	response := toAcademicYearResponse(academicYear)
+	response.Semesters = make([]SemesterResponse, 0, len(semesters))
+	for _, semester := range semesters {
+		response.Semesters = append(response.Semesters, toSemesterResponse(semester))
+	}
+
+	return response, nil
+}
+
+// CreateAcademicYear adds an academic year, codes are unique, soft-deleted academic years included.
+func (uc *AcademicCalendarUseCase) CreateAcademicYear(ctx context.Context, req AcademicYearRequest) (AcademicYearResponse, error) {
+	err := uc.ensureAcademicYearCodeAvailable(ctx, "", req.Code)
+	if err != nil {
+		return AcademicYearResponse{}, err
+	}
+
+	academicYear, err := uc.calendarRepository.CreateAcademicYear(ctx, uuid.NewString(), toAcademicYearAttributes(req))
+	if err != nil {
+		return AcademicYearResponse{}, errors.Wrap(err, "cannot create academic year")
+	}
+
+	return toAcademicYearResponse(academicYear), nil
+}
+
+// UpdateAcademicYear changes an academic year, its new dates must still cover all of its semesters.
+func (uc *AcademicCalendarUseCase) UpdateAcademicYear(ctx context.Context, id string, req AcademicYearRequest) (AcademicYearResponse, error) {
+	_, err := uc.getAcademicYear(ctx, id)
+	if err != nil {
+		return AcademicYearResponse{}, err
+	}
+
+	err = uc.ensureAcademicYearCodeAvailable(ctx, id, req.Code)
+	if err != nil {
+		return AcademicYearResponse{}, err
+	}
+
+	semesters, err := uc.calendarRepository.ListSemestersByAcademicYear(ctx, id)
+	if err != nil {
+		return AcademicYearResponse{}, errors.Wrap(err, "cannot get semesters")
+	}
+	for _, semester := range semesters {
+		if !isWithin(semester.StartTime.Time, semester.EndTime.Time, req.StartTime, req.EndTime) {
+			return AcademicYearResponse{}, ErrAcademicYearExcludesSemesters
+		}
+	}
+
+	academicYear, err := uc.calendarRepository.UpdateAcademicYear(ctx, id, toAcademicYearAttributes(req))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return AcademicYearResponse{}, ErrAcademicYearNotFound
+		}
+		return AcademicYearResponse{}, errors.Wrap(err, "cannot update academic year")
+	}
+
+	return toAcademicYearResponse(academicYear), nil
+}
+
+// DeleteAcademicYear soft-deletes an academic year that has no semesters left.
+func (uc *AcademicCalendarUseCase) DeleteAcademicYear(ctx context.Context, id string) error {
+	_, err := uc.getAcademicYear(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	semesters, err := uc.calendarRepository.ListSemestersByAcademicYear(ctx, id)
+	if err != nil {
+		return errors.Wrap(err, "cannot get semesters")
+	}
+	if len(semesters) > 0 {
+		return ErrAcademicYearHasSemesters
+	}
+
+	_, err = uc.calendarRepository.DeleteAcademicYear(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrAcademicYearNotFound
+		}
+		return errors.Wrap(err, "cannot delete academic year")
+	}
+
+	return nil
+}
+
+func (uc *AcademicCalendarUseCase) GetSemester(ctx context.Context, id string) (SemesterResponse, error) {
+	semester, err := uc.getSemester(ctx, id)
+	if err != nil {
+		return SemesterResponse{}, err
+	}
+
+	return toSemesterResponse(semester), nil
+}
+
+// GetCurrentSemester returns the semester running now.
+func (uc *AcademicCalendarUseCase) GetCurrentSemester(ctx context.Context) (SemesterResponse, error) {
+	semester, err := uc.calendarRepository.GetCurrentSemester(ctx)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return SemesterResponse{}, ErrNoCurrentSemester
+		}
+		return SemesterResponse{}, errors.Wrap(err, "cannot get current semester")
+	}
+
+	return toSemesterResponse(semester), nil
+}
+
+// CreateSemester adds a semester to an academic year, it must fall within the academic year and must not
+// overlap its other semesters.
+func (uc *AcademicCalendarUseCase) CreateSemester(ctx context.Context, academicYearID string, req SemesterRequest) (SemesterResponse, error) {
+	academicYear, err := uc.getAcademicYear(ctx, academicYearID)
+	if err != nil {
+		return SemesterResponse{}, err
+	}
+
+	err = uc.ensureSemesterAvailable(ctx, academicYear, "", req)
+	if err != nil {
+		return SemesterResponse{}, err
+	}
+
+	semester, err := uc.calendarRepository.CreateSemester(ctx, uuid.NewString(), academicYearID, toSemesterAttributes(req))
+	if err != nil {
+		return SemesterResponse{}, errors.Wrap(err, "cannot create semester")
+	}
+
+	return toSemesterResponse(semester), nil
+}
+
+func (uc *AcademicCalendarUseCase) UpdateSemester(ctx context.Context, id string, req SemesterRequest) (SemesterResponse, error) {
+	semester, err := uc.getSemester(ctx, id)
+	if err != nil {
+		return SemesterResponse{}, err
+	}
+
+	academicYear, err := uc.getAcademicYear(ctx, semester.AcademicYearID.String())
+	if err != nil {
+		return SemesterResponse{}, err
+	}
+
+	err = uc.ensureSemesterAvailable(ctx, academicYear, id, req)
+	if err != nil {
+		return SemesterResponse{}, err
+	}
+
+	semester, err = uc.calendarRepository.UpdateSemester(ctx, id, toSemesterAttributes(req))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return SemesterResponse{}, ErrSemesterNotFound
+		}
+		return SemesterResponse{}, errors.Wrap(err, "cannot update semester")
+	}
+
+	return toSemesterResponse(semester), nil
+}
+
+// DeleteSemester soft-deletes a semester that has no course offerings.
+func (uc *AcademicCalendarUseCase) DeleteSemester(ctx context.Context, id string) error {
+	_, err := uc.getSemester(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	offerings, err := uc.calendarRepository.CountCourseOfferingsBySemester(ctx, id)
+	if err != nil {
+		return errors.Wrap(err, "cannot count course offerings")
+	}
+	if offerings > 0 {
+		return ErrSemesterHasOfferings
+	}
+
+	_, err = uc.calendarRepository.DeleteSemester(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrSemesterNotFound
+		}
+		return errors.Wrap(err, "cannot delete semester")
+	}
+
+	return nil
+}
+
+func (uc *AcademicCalendarUseCase) getAcademicYear(ctx context.Context, id string) (generated.AcademicYear, error) {
+	academicYear, err := uc.calendarRepository.GetAcademicYear(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return generated.AcademicYear{}, ErrAcademicYearNotFound
+		}
+		return generated.AcademicYear{}, errors.Wrap(err, "cannot get academic year")
+	}
+
+	return academicYear, nil
+}
+
+func (uc *AcademicCalendarUseCase) getSemester(ctx context.Context, id string) (generated.Semester, error) {
+	semester, err := uc.calendarRepository.GetSemester(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return generated.Semester{}, ErrSemesterNotFound
+		}
+		return generated.Semester{}, errors.Wrap(err, "cannot get semester")
+	}
+
+	return semester, nil
+}
+
+// ensureAcademicYearCodeAvailable checks the code uniqueness, id is the academic year being updated, if any.
+func (uc *AcademicCalendarUseCase) ensureAcademicYearCodeAvailable(ctx context.Context, id, code string) error {
+	existing, err := uc.calendarRepository.GetAcademicYearByCode(ctx, code)
+	if err == nil && existing.ID.String() != id {
+		return ErrAcademicYearCodeAlreadyUsed
+	}
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return errors.Wrap(err, "cannot check academic year code availability")
+	}
+
+	return nil
+}
+
+// ensureSemesterAvailable checks the code and dates of a semester against its academic year and sibling
+// semesters, id is the semester being updated, if any.
+func (uc *AcademicCalendarUseCase) ensureSemesterAvailable(ctx context.Context, academicYear generated.AcademicYear, id string, req SemesterRequest) error {
+	academicYearID := academicYear.ID.String()
+
+	existing, err := uc.calendarRepository.GetSemesterByCode(ctx, academicYearID, req.Code)
+	if err == nil && existing.ID.String() != id {
+		return ErrSemesterCodeAlreadyUsed
+	}
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return errors.Wrap(err, "cannot check semester code availability")
+	}
+
+	if !isWithin(req.StartTime, req.EndTime, academicYear.StartTime.Time, academicYear.EndTime.Time) {
+		return ErrSemesterOutsideAcademicYear
+	}
+	if req.DropDeadline != nil && !isWithin(*req.DropDeadline, *req.DropDeadline, req.StartTime, req.EndTime) {
+		return ErrDropDeadlineOutsideSemester
+	}
+
+	siblings, err := uc.calendarRepository.ListSemestersByAcademicYear(ctx, academicYearID)
+	if err != nil {
+		return errors.Wrap(err, "cannot get semesters")
+	}
+	for _, sibling := range siblings {
+		if sibling.ID.String() == id {
+			continue
+		}
+		if req.StartTime.Before(sibling.EndTime.Time) && sibling.StartTime.Time.Before(req.EndTime) {
+			return ErrSemesterOverlap
+		}
+	}
+
+	return nil
+}
+
+// isWithin reports whether the period [start, end) lies inside [outerStart, outerEnd).
+func isWithin(start, end, outerStart, outerEnd time.Time) bool {
+	return !start.Before(outerStart) && !end.After(outerEnd)
+}
+
+func toAcademicYearAttributes(req AcademicYearRequest) repositories.AcademicYearAttributes {
+	return repositories.AcademicYearAttributes{
+		Code:      req.Code,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+	}
+}
+
+func toSemesterAttributes(req SemesterRequest) repositories.SemesterAttributes {
+	return repositories.SemesterAttributes{
+		Code:         req.Code,
+		StartTime:    req.StartTime,
+		EndTime:      req.EndTime,
+		DropDeadline: req.DropDeadline,
+	}
+}
+
+func toAcademicYearResponse(academicYear generated.AcademicYear) AcademicYearResponse {
+	response := AcademicYearResponse{
+		ID:        academicYear.ID.String(),
+		Code:      academicYear.Code,
+		StartTime: academicYear.StartTime.Time,
+		EndTime:   academicYear.EndTime.Time,
+	}
+
+	if academicYear.CreatedAt.Valid {
+		response.CreatedAt = academicYear.CreatedAt.Time
+	}
+	if academicYear.UpdatedAt.Valid {
+		updatedAt := academicYear.UpdatedAt.Time
+		response.UpdatedAt = &updatedAt
+	}
+
+	return response
+}
+
+func toSemesterResponse(semester generated.Semester) SemesterResponse {
+	response := SemesterResponse{
+		ID:             semester.ID.String(),
+		AcademicYearID: semester.AcademicYearID.String(),
+		Code:           semester.Code,
+		StartTime:      semester.StartTime.Time,
+		EndTime:        semester.EndTime.Time,
+	}
+
+	if semester.DropDeadline.Valid {
+		dropDeadline := semester.DropDeadline.Time
+		response.DropDeadline = &dropDeadline
+	}
+	if semester.CreatedAt.Valid {
+		response.CreatedAt = semester.CreatedAt.Time
+	}
+	if semester.UpdatedAt.Valid {
+		updatedAt := semester.UpdatedAt.Time
+		response.UpdatedAt = &updatedAt
+	}
+
+	return response
+}
@@ -0,0 +1,283 @@
+package usecases
+
+import (
+	"context"
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// Mock repository for academic year and semester tests
+type MockAcademicCalendarRepository struct {
+	mock.Mock
+}
+
+func (m *MockAcademicCalendarRepository) ListAcademicYears(ctx context.Context, limit, offset int) ([]generated.AcademicYear, error) {
+	args := m.Called(ctx, limit, offset)
+	return args.Get(0).([]generated.AcademicYear), args.Error(1)
+}
+
+func (m *MockAcademicCalendarRepository) CountAcademicYears(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockAcademicCalendarRepository) GetAcademicYear(ctx context.Context, id string) (generated.AcademicYear, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.AcademicYear), args.Error(1)
+}
+
+func (m *MockAcademicCalendarRepository) GetAcademicYearByCode(ctx context.Context, code string) (generated.AcademicYear, error) {
+	args := m.Called(ctx, code)
+	return args.Get(0).(generated.AcademicYear), args.Error(1)
+}
+
+func (m *MockAcademicCalendarRepository) CreateAcademicYear(ctx context.Context, id string, attributes repositories.AcademicYearAttributes) (generated.AcademicYear, error) {
+	args := m.Called(ctx, id, attributes)
+	return args.Get(0).(generated.AcademicYear), args.Error(1)
+}
+
+func (m *MockAcademicCalendarRepository) UpdateAcademicYear(ctx context.Context, id string, attributes repositories.AcademicYearAttributes) (generated.AcademicYear, error) {
+	args := m.Called(ctx, id, attributes)
+	return args.Get(0).(generated.AcademicYear), args.Error(1)
+}
+
+func (m *MockAcademicCalendarRepository) DeleteAcademicYear(ctx context.Context, id string) (generated.AcademicYear, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.AcademicYear), args.Error(1)
+}
+
+func (m *MockAcademicCalendarRepository) ListSemestersByAcademicYear(ctx context.Context, academicYearID string) ([]generated.Semester, error) {
+	args := m.Called(ctx, academicYearID)
+	return args.Get(0).([]generated.Semester), args.Error(1)
+}
+
+func (m *MockAcademicCalendarRepository) GetSemester(ctx context.Context, id string) (generated.Semester, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.Semester), args.Error(1)
+}
+
+func (m *MockAcademicCalendarRepository) GetSemesterByCode(ctx context.Context, academicYearID, code string) (generated.Semester, error) {
+	args := m.Called(ctx, academicYearID, code)
+	return args.Get(0).(generated.Semester), args.Error(1)
+}
+
+func (m *MockAcademicCalendarRepository) GetCurrentSemester(ctx context.Context) (generated.Semester, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(generated.Semester), args.Error(1)
+}
+
+func (m *MockAcademicCalendarRepository) CreateSemester(ctx context.Context, id, academicYearID string, attributes repositories.SemesterAttributes) (generated.Semester, error) {
+	args := m.Called(ctx, id, academicYearID, attributes)
+	return args.Get(0).(generated.Semester), args.Error(1)
+}
+
+func (m *MockAcademicCalendarRepository) UpdateSemester(ctx context.Context, id string, attributes repositories.SemesterAttributes) (generated.Semester, error) {
+	args := m.Called(ctx, id, attributes)
+	return args.Get(0).(generated.Semester), args.Error(1)
+}
+
+func (m *MockAcademicCalendarRepository) DeleteSemester(ctx context.Context, id string) (generated.Semester, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.Semester), args.Error(1)
+}
+
+func (m *MockAcademicCalendarRepository) CountCourseOfferingsBySemester(ctx context.Context, semesterID string) (int64, error) {
+	args := m.Called(ctx, semesterID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// Test Suite
+type AcademicCalendarUseCaseTestSuite struct {
+	suite.Suite
+	useCase          *AcademicCalendarUseCase
+	mockRepo         *MockAcademicCalendarRepository
+	ctx              context.Context
+	academicYearUUID pgtype.UUID
+	semesterUUID     pgtype.UUID
+	academicYear     generated.AcademicYear
+	ganjil           generated.Semester
+}
+
+func (suite *AcademicCalendarUseCaseTestSuite) SetupTest() {
+	suite.mockRepo = new(MockAcademicCalendarRepository)
+	suite.useCase = NewAcademicCalendarUseCase(suite.mockRepo)
+	suite.ctx = context.Background()
+
+	suite.academicYearUUID = pgtype.UUID{
+		Bytes: [16]byte{4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19},
+		Valid: true,
+	}
+	suite.semesterUUID = pgtype.UUID{
+		Bytes: [16]byte{5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20},
+		Valid: true,
+	}
+
+	// Academic year 2025/2026 with its odd semester (Ganjil)
+	suite.academicYear = generated.AcademicYear{
+		ID:        suite.academicYearUUID,
+		Code:      "2025/2026",
+		StartTime: pgtype.Timestamptz{Time: date(2025, time.August, 1), Valid: true},
+		EndTime:   pgtype.Timestamptz{Time: date(2026, time.August, 1), Valid: true},
+	}
+	suite.ganjil = generated.Semester{
+		ID:             suite.semesterUUID,
+		AcademicYearID: suite.academicYearUUID,
+		Code:           "Ganjil",
+		StartTime:      pgtype.Timestamptz{Time: date(2025, time.September, 1), Valid: true},
+		EndTime:        pgtype.Timestamptz{Time: date(2026, time.February, 1), Valid: true},
+	}
+}
+
+func (suite *AcademicCalendarUseCaseTestSuite) TearDownTest() {
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// Test successful semester creation next to an existing semester
+func (suite *AcademicCalendarUseCaseTestSuite) TestCreateSemester_Success() {
+	academicYearID := suite.academicYearUUID.String()
+	req := SemesterRequest{Code: "Genap", StartTime: date(2026, time.February, 1), EndTime: date(2026, time.July, 1)}
+
+	suite.mockRepo.On("GetAcademicYear", suite.ctx, academicYearID).Return(suite.academicYear, nil)
+	suite.mockRepo.On("GetSemesterByCode", suite.ctx, academicYearID, req.Code).Return(generated.Semester{}, pgx.ErrNoRows)
+	suite.mockRepo.On("ListSemestersByAcademicYear", suite.ctx, academicYearID).Return([]generated.Semester{suite.ganjil}, nil)
+	suite.mockRepo.On("CreateSemester", suite.ctx, mock.AnythingOfType("string"), academicYearID, toSemesterAttributes(req)).Return(generated.Semester{
+		ID:             suite.semesterUUID,
+		AcademicYearID: suite.academicYearUUID,
+		Code:           req.Code,
+		StartTime:      pgtype.Timestamptz{Time: req.StartTime, Valid: true},
+		EndTime:        pgtype.Timestamptz{Time: req.EndTime, Valid: true},
+	}, nil)
+
+	response, err := suite.useCase.CreateSemester(suite.ctx, academicYearID, req)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Genap", response.Code)
+	assert.Equal(suite.T(), academicYearID, response.AcademicYearID)
+}
+
+// Test creating a semester that ends after its academic year
+func (suite *AcademicCalendarUseCaseTestSuite) TestCreateSemester_OutsideAcademicYear() {
+	academicYearID := suite.academicYearUUID.String()
+	req := SemesterRequest{Code: "Genap", StartTime: date(2026, time.February, 1), EndTime: date(2026, time.September, 1)}
+
+	suite.mockRepo.On("GetAcademicYear", suite.ctx, academicYearID).Return(suite.academicYear, nil)
+	suite.mockRepo.On("GetSemesterByCode", suite.ctx, academicYearID, req.Code).Return(generated.Semester{}, pgx.ErrNoRows)
+
+	_, err := suite.useCase.CreateSemester(suite.ctx, academicYearID, req)
+
+	assert.ErrorIs(suite.T(), err, ErrSemesterOutsideAcademicYear)
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateSemester")
+}
+
+// Test creating a semester whose drop deadline falls after its end
+func (suite *AcademicCalendarUseCaseTestSuite) TestCreateSemester_DropDeadlineOutsideSemester() {
+	academicYearID := suite.academicYearUUID.String()
+	dropDeadline := date(2026, time.July, 15)
+	req := SemesterRequest{Code: "Genap", StartTime: date(2026, time.February, 1), EndTime: date(2026, time.July, 1), DropDeadline: &dropDeadline}
+
+	suite.mockRepo.On("GetAcademicYear", suite.ctx, academicYearID).Return(suite.academicYear, nil)
+	suite.mockRepo.On("GetSemesterByCode", suite.ctx, academicYearID, req.Code).Return(generated.Semester{}, pgx.ErrNoRows)
+
+	_, err := suite.useCase.CreateSemester(suite.ctx, academicYearID, req)
+
+	assert.ErrorIs(suite.T(), err, ErrDropDeadlineOutsideSemester)
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateSemester")
+}
+
+// Test creating a semester that overlaps a sibling semester
+func (suite *AcademicCalendarUseCaseTestSuite) TestCreateSemester_Overlap() {
+	academicYearID := suite.academicYearUUID.String()
+	req := SemesterRequest{Code: "Genap", StartTime: date(2026, time.January, 15), EndTime: date(2026, time.July, 1)}
+
+	suite.mockRepo.On("GetAcademicYear", suite.ctx, academicYearID).Return(suite.academicYear, nil)
+	suite.mockRepo.On("GetSemesterByCode", suite.ctx, academicYearID, req.Code).Return(generated.Semester{}, pgx.ErrNoRows)
+	suite.mockRepo.On("ListSemestersByAcademicYear", suite.ctx, academicYearID).Return([]generated.Semester{suite.ganjil}, nil)
+
+	_, err := suite.useCase.CreateSemester(suite.ctx, academicYearID, req)
+
+	assert.ErrorIs(suite.T(), err, ErrSemesterOverlap)
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateSemester")
+}
+
+// Test updating a semester does not compare it with itself
+func (suite *AcademicCalendarUseCaseTestSuite) TestUpdateSemester_IgnoresItself() {
+	id := suite.semesterUUID.String()
+	academicYearID := suite.academicYearUUID.String()
+	req := SemesterRequest{Code: "Ganjil", StartTime: date(2025, time.August, 15), EndTime: date(2026, time.January, 15)}
+
+	suite.mockRepo.On("GetSemester", suite.ctx, id).Return(suite.ganjil, nil)
+	suite.mockRepo.On("GetAcademicYear", suite.ctx, academicYearID).Return(suite.academicYear, nil)
+	suite.mockRepo.On("GetSemesterByCode", suite.ctx, academicYearID, req.Code).Return(suite.ganjil, nil)
+	suite.mockRepo.On("ListSemestersByAcademicYear", suite.ctx, academicYearID).Return([]generated.Semester{suite.ganjil}, nil)
+	suite.mockRepo.On("UpdateSemester", suite.ctx, id, toSemesterAttributes(req)).Return(suite.ganjil, nil)
+
+	_, err := suite.useCase.UpdateSemester(suite.ctx, id, req)
+
+	assert.NoError(suite.T(), err)
+}
+
+// Test shrinking an academic year so that it no longer covers a semester
+func (suite *AcademicCalendarUseCaseTestSuite) TestUpdateAcademicYear_ExcludesSemesters() {
+	id := suite.academicYearUUID.String()
+	req := AcademicYearRequest{Code: "2025/2026", StartTime: date(2025, time.October, 1), EndTime: date(2026, time.August, 1)}
+
+	suite.mockRepo.On("GetAcademicYear", suite.ctx, id).Return(suite.academicYear, nil)
+	suite.mockRepo.On("GetAcademicYearByCode", suite.ctx, req.Code).Return(suite.academicYear, nil)
+	suite.mockRepo.On("ListSemestersByAcademicYear", suite.ctx, id).Return([]generated.Semester{suite.ganjil}, nil)
+
+	_, err := suite.useCase.UpdateAcademicYear(suite.ctx, id, req)
+
+	assert.ErrorIs(suite.T(), err, ErrAcademicYearExcludesSemesters)
+	suite.mockRepo.AssertNotCalled(suite.T(), "UpdateAcademicYear")
+}
+
+// Test deleting an academic year that still has semesters
+func (suite *AcademicCalendarUseCaseTestSuite) TestDeleteAcademicYear_HasSemesters() {
+	id := suite.academicYearUUID.String()
+
+	suite.mockRepo.On("GetAcademicYear", suite.ctx, id).Return(suite.academicYear, nil)
+	suite.mockRepo.On("ListSemestersByAcademicYear", suite.ctx, id).Return([]generated.Semester{suite.ganjil}, nil)
+
+	err := suite.useCase.DeleteAcademicYear(suite.ctx, id)
+
+	assert.ErrorIs(suite.T(), err, ErrAcademicYearHasSemesters)
+	suite.mockRepo.AssertNotCalled(suite.T(), "DeleteAcademicYear")
+}
+
+// Test deleting a semester that still has course offerings
+func (suite *AcademicCalendarUseCaseTestSuite) TestDeleteSemester_HasOfferings() {
+	id := suite.semesterUUID.String()
+
+	suite.mockRepo.On("GetSemester", suite.ctx, id).Return(suite.ganjil, nil)
+	suite.mockRepo.On("CountCourseOfferingsBySemester", suite.ctx, id).Return(int64(3), nil)
+
+	err := suite.useCase.DeleteSemester(suite.ctx, id)
+
+	assert.ErrorIs(suite.T(), err, ErrSemesterHasOfferings)
+	suite.mockRepo.AssertNotCalled(suite.T(), "DeleteSemester")
+}
+
+// Test the current semester lookup when no semester is running
+func (suite *AcademicCalendarUseCaseTestSuite) TestGetCurrentSemester_None() {
+	suite.mockRepo.On("GetCurrentSemester", suite.ctx).Return(generated.Semester{}, pgx.ErrNoRows)
+
+	_, err := suite.useCase.GetCurrentSemester(suite.ctx)
+
+	assert.ErrorIs(suite.T(), err, ErrNoCurrentSemester)
+}
+
+func TestAcademicCalendarUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(AcademicCalendarUseCaseTestSuite))
+}
@@ -0,0 +1,213 @@
+package usecases
+
+import (
+	"context"
+	"math"
+	"siakad-poc/common"
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+type CourseResponse struct {
+	ID               string     `json:"id"`
+	Code             string     `json:"code"`
+	Name             string     `json:"name"`
+	Credit           int        `json:"credit"`
+	MinutesPerCredit int        `json:"minutes_per_credit"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        *time.Time `json:"updated_at"`
+}
+
+// CourseRequest is the payload to create or update a course (mata kuliah), credit is in SKS.
+// MinutesPerCredit is the class time of one credit, DefaultMinutesPerCredit when omitted.
+type CourseRequest struct {
+	Code             string `json:"code" validate:"required,max=255"`
+	Name             string `json:"name" validate:"required,max=255"`
+	Credit           int    `json:"credit" validate:"gte=1,lte=6"`
+	MinutesPerCredit int    `json:"minutes_per_credit" validate:"omitempty,gte=1,lte=300"`
+}
+
+type CourseUseCase struct {
+	courseRepository repositories.CourseRepository
+}
+
+func NewCourseUseCase(courseRepository repositories.CourseRepository) *CourseUseCase {
+	return &CourseUseCase{
+		courseRepository: courseRepository,
+	}
+}
+
+func (uc *CourseUseCase) ListCourses(ctx context.Context, filter repositories.CourseFilter, page, pageSize int) ([]CourseResponse, *common.PaginationMetadata, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+
+	courses, err := uc.courseRepository.ListCourses(ctx, filter, pageSize, offset)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot get courses")
+	}
+
+	totalRecords, err := uc.courseRepository.CountCourses(ctx, filter)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot count courses")
+	}
+
+	responses := make([]CourseResponse, 0, len(courses))
+	for _, course := range courses {
+		responses = append(responses, toCourseResponse(course))
+	}
+
+	pagination := &common.PaginationMetadata{
+		Page:         page,
+		PageSize:     pageSize,
+		TotalRecords: int(totalRecords),
+		TotalPages:   int(math.Ceil(float64(totalRecords) / float64(pageSize))),
+	}
+
+	return responses, pagination, nil
+}
+
+func (uc *CourseUseCase) GetCourse(ctx context.Context, id string) (CourseResponse, error) {
+	course, err := uc.getCourse(ctx, id)
+	if err != nil {
+		return CourseResponse{}, err
+	}
+
+	return toCourseResponse(course), nil
+}
+
+// CreateCourse adds a course to the catalogue, codes are unique among the courses not deleted.
+func (uc *CourseUseCase) CreateCourse(ctx context.Context, req CourseRequest) (CourseResponse, error) {
+	err := uc.ensureCodeAvailable(ctx, "", req.Code)
+	if err != nil {
+		return CourseResponse{}, err
+	}
+
+	course, err := uc.courseRepository.CreateCourse(ctx, uuid.NewString(), toCourseAttributes(req))
+	if err != nil {
+		return CourseResponse{}, errors.Wrap(err, "cannot create course")
+	}
+
+	return toCourseResponse(course), nil
+}
+
+func (uc *CourseUseCase) UpdateCourse(ctx context.Context, id string, req CourseRequest) (CourseResponse, error) {
+	_, err := uc.getCourse(ctx, id)
+	if err != nil {
+		return CourseResponse{}, err
+	}
+
+	err = uc.ensureCodeAvailable(ctx, id, req.Code)
+	if err != nil {
+		return CourseResponse{}, err
+	}
+
+	course, err := uc.courseRepository.UpdateCourse(ctx, id, toCourseAttributes(req))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return CourseResponse{}, ErrCourseNotFound
+		}
+		return CourseResponse{}, errors.Wrap(err, "cannot update course")
+	}
+
+	return toCourseResponse(course), nil
+}
+
+// DeleteCourse soft-deletes a course, it is refused while the course is offered in a semester that has not ended.
+func (uc *CourseUseCase) DeleteCourse(ctx context.Context, id string) error {
+	_, err := uc.getCourse(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	activeOfferings, err := uc.courseRepository.CountActiveCourseOfferingsByCourse(ctx, id)
+	if err != nil {
+		return errors.Wrap(err, "cannot count active course offerings")
+	}
+	if activeOfferings > 0 {
+		return ErrCourseHasActiveOfferings
+	}
+
+	_, err = uc.courseRepository.DeleteCourse(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrCourseNotFound
+		}
+		return errors.Wrap(err, "cannot delete course")
+	}
+
+	return nil
+}
+
+// getCourse returns the course unless it is missing or soft-deleted.
+func (uc *CourseUseCase) getCourse(ctx context.Context, id string) (generated.Course, error) {
+	course, err := uc.courseRepository.GetCourse(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return generated.Course{}, ErrCourseNotFound
+		}
+		return generated.Course{}, errors.Wrap(err, "cannot get course")
+	}
+	if course.DeletedAt.Valid {
+		return generated.Course{}, ErrCourseNotFound
+	}
+
+	return course, nil
+}
+
+// ensureCodeAvailable checks the code uniqueness, id is the course being updated, if any.
+func (uc *CourseUseCase) ensureCodeAvailable(ctx context.Context, id, code string) error {
+	existing, err := uc.courseRepository.GetCourseByCode(ctx, code)
+	if err == nil && existing.ID.String() != id {
+		return ErrCourseCodeAlreadyUsed
+	}
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return errors.Wrap(err, "cannot check course code availability")
+	}
+
+	return nil
+}
+
+func toCourseAttributes(req CourseRequest) repositories.CourseAttributes {
+	minutesPerCredit := req.MinutesPerCredit
+	if minutesPerCredit == 0 {
+		minutesPerCredit = DefaultMinutesPerCredit
+	}
+
+	return repositories.CourseAttributes{
+		Code:             req.Code,
+		Name:             req.Name,
+		Credit:           req.Credit,
+		MinutesPerCredit: minutesPerCredit,
+	}
+}
+
+func toCourseResponse(course generated.Course) CourseResponse {
+	response := CourseResponse{
+		ID:               course.ID.String(),
+		Code:             course.Code,
+		Name:             course.Name,
+		Credit:           int(course.Credit),
+		MinutesPerCredit: int(course.MinutesPerCredit),
+	}
+
+	if course.CreatedAt.Valid {
+		response.CreatedAt = course.CreatedAt.Time
+	}
+	if course.UpdatedAt.Valid {
+		updatedAt := course.UpdatedAt.Time
+		response.UpdatedAt = &updatedAt
+	}
+
+	return response
+}
@@ -7,34 +7,105 @@ import (
 	"siakad-poc/db/repositories"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/pkg/errors"
 )
 
+// DefaultPrerequisiteMinGrade is the lowest grade that passes a prerequisite when the policy doesn't set one
+const DefaultPrerequisiteMinGrade = "C"
+
+// DefaultMinutesPerCredit is the class time of one credit hour for courses that don't set their own
+const DefaultMinutesPerCredit = 50
+
+// EnrollmentPolicy holds the configurable enrollment rules, the zero value uses the defaults
+type EnrollmentPolicy struct {
+	PrerequisiteMinGrade string
+	// CreditLoad maps the previous semester GPA to the most credits of a semester
+	CreditLoad []CreditLoadRule
+	// MaxCreditsWithoutGPA is the credit allowance of students without a previous semester GPA
+	MaxCreditsWithoutGPA int32
+	// DropPeriodDays is how many days after the start of a semester without a drop deadline students can drop
+	DropPeriodDays int
+	// CampusLocation is the time zone weekly schedules are compared in, nil means UTC
+	CampusLocation *time.Location
+}
+
 type CourseEnrollmentUseCase struct {
-	academicRepo repositories.AcademicRepository
-	txExecutor   common.TransactionExecutor
+	academicRepo   repositories.AcademicRepository
+	studentRepo    repositories.StudentRepository
+	studyPlanRepo  repositories.StudyPlanRepository
+	creditLoadRepo repositories.CreditLoadRepository
+	waitlistRepo   repositories.WaitlistRepository
+	txExecutor     common.TransactionExecutor
+	policy         EnrollmentPolicy
 }
 
-func NewCourseEnrollmentUseCase(academicRepo repositories.AcademicRepository, txExecutor common.TransactionExecutor) *CourseEnrollmentUseCase {
+func NewCourseEnrollmentUseCase(academicRepo repositories.AcademicRepository, studentRepo repositories.StudentRepository, studyPlanRepo repositories.StudyPlanRepository, creditLoadRepo repositories.CreditLoadRepository, waitlistRepo repositories.WaitlistRepository, txExecutor common.TransactionExecutor, policy EnrollmentPolicy) *CourseEnrollmentUseCase {
 	return &CourseEnrollmentUseCase{
-		academicRepo: academicRepo,
-		txExecutor:   txExecutor,
+		academicRepo:   academicRepo,
+		studentRepo:    studentRepo,
+		studyPlanRepo:  studyPlanRepo,
+		creditLoadRepo: creditLoadRepo,
+		waitlistRepo:   waitlistRepo,
+		txExecutor:     txExecutor,
+		policy:         policy,
+	}
+}
+
+// EnrollUser enrolls the student record linked to the user of the token, see EnrollStudent.
+// It returns the ID of the student record the registration was made for.
+func (u *CourseEnrollmentUseCase) EnrollUser(ctx context.Context, userID, courseOfferingID string) (string, error) {
+	studentID, err := u.studentIDOfUser(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	return studentID, u.EnrollStudent(ctx, studentID, courseOfferingID)
+}
+
+// studentIDOfUser returns the ID of the active student record linked to the user.
+func (u *CourseEnrollmentUseCase) studentIDOfUser(ctx context.Context, userID string) (string, error) {
+	student, err := u.studentRepo.GetStudentByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", NewStudentNotFoundError(userID)
+		}
+		return "", NewDatabaseOperationError("get student record", err)
+	}
+	if student.DeletedAt.Valid {
+		return "", NewStudentNotFoundError(userID)
 	}
+
+	return uuidToString(student.ID), nil
 }
 
 // EnrollStudent enrolls a student in a course offering after validating business rules.
 // Business Rules Validated:
-// 1. No duplicate enrollment - student cannot enroll twice in the same course offering
-// 2. Capacity check - enrollment count must be less than course offering capacity
-// 3. Schedule conflict detection - new course cannot overlap with existing enrollments
-//    - Each credit = 50 minutes of class time
-//    - Schedule overlap is calculated based on start_time + (credit * 50 minutes)
+//  1. No duplicate enrollment - student cannot enroll twice in the same course offering
+//  2. Capacity check - enrollment count must be less than course offering capacity
+//  3. Schedule conflict detection - new course cannot overlap with existing enrollments. Each credit lasts the
+//     minutes_per_credit of the course, 50 minutes by default, so each weekly slot lasts from its start_time to
+//     start_time + (credit * minutes_per_credit). Weekly slots repeat within the semester, slots of overlapping
+//     semesters are compared day by day
+//  4. Prerequisite check - every prerequisite course must be completed with the policy's minimum grade or better
+//  5. Co-requisite check - every co-requisite course must be taken in the same semester or already passed
+//  6. Exclusion check - student cannot take a course that is mutually exclusive with one taken in the same semester
+//  7. Study plan check - the study plan of the semester must be a draft or rejected, the registration is added to it
+//     as a pending line for the academic advisor to review
+//  8. Credit load check - the credits of the semester can't exceed the allowance of the student, set by an admin or
+//     derived from the previous semester GPA
 func (u *CourseEnrollmentUseCase) EnrollStudent(ctx context.Context, studentID, courseOfferingID string) error {
 	// Execute all enrollment operations within a transaction to ensure ACID properties
 	// This prevents race conditions and ensures data consistency across all validation steps
-	return u.txExecutor.WithTxContext(ctx, func(txCtx *common.TxContext) error {
+	return u.txExecutor.WithTxContext(ctx, u.enrollTx(studentID, courseOfferingID))
+}
+
+// enrollTx validates the business rules of EnrollStudent and creates the registration within a transaction.
+// A student waiting for a seat in the course offering leaves its waitlist with the registration.
+func (u *CourseEnrollmentUseCase) enrollTx(studentID, courseOfferingID string) common.TxContextFunc {
+	return func(txCtx *common.TxContext) error {
 		// Business Rule 1: No Enrollment Duplication
 		// Check if student is already enrolled in this course offering (with transaction)
 		exists, err := u.academicRepo.CheckEnrollmentExistsTx(txCtx, studentID, courseOfferingID)
@@ -83,13 +154,12 @@ func (u *CourseEnrollmentUseCase) EnrollStudent(ctx context.Context, studentID,
 			return NewDatabaseOperationError("get student's existing enrollments", err)
 		}
 
-		// Calculate the time range for the new course offering
-		// Formula: end_time = start_time + (credit * 50 minutes)
-		newCourseStartTime, err := convertPgTimestamp(courseOfferingWithCourse.CourseOfferingStartTime)
+		// Build the weekly timetable of the new course offering within its semester
+		// Each meeting lasts from start_time to start_time + (credit * minutes per credit of the course)
+		newCourseSchedule, err := newOfferingSchedule(courseOfferingWithCourse.CourseOfferingStartTime, courseOfferingWithCourse.SemesterStartTime, courseOfferingWithCourse.SemesterEndTime, courseOfferingWithCourse.Schedules, classDuration(courseOfferingWithCourse.Credit, courseOfferingWithCourse.MinutesPerCredit), u.policy.CampusLocation)
 		if err != nil {
 			return NewInvalidTimestampError("new course start time")
 		}
-		newCourseEndTime := calculateCourseEndTime(newCourseStartTime, courseOfferingWithCourse.Credit)
 
 		// Validate against all existing enrollments for schedule conflicts
 		for _, enrollment := range existingEnrollments {
@@ -98,47 +168,138 @@ func (u *CourseEnrollmentUseCase) EnrollStudent(ctx context.Context, studentID,
 				continue
 			}
 
-			existingStartTime, err := convertPgTimestamp(enrollment.CourseOfferingStartTime)
+			existingSchedule, err := newOfferingSchedule(enrollment.CourseOfferingStartTime, enrollment.SemesterStartTime, enrollment.SemesterEndTime, enrollment.Schedules, classDuration(enrollment.Credit, enrollment.MinutesPerCredit), u.policy.CampusLocation)
 			if err != nil {
 				return NewInvalidTimestampError("existing course start time")
 			}
-			existingEndTime := calculateCourseEndTime(existingStartTime, enrollment.Credit)
 
-			// Check for time overlap using inclusive boundary logic
-			if hasTimeOverlap(newCourseStartTime, newCourseEndTime, existingStartTime, existingEndTime) {
-				newCourseTime := fmt.Sprintf("%s-%s", newCourseStartTime.Format("15:04"), newCourseEndTime.Format("15:04"))
-				existingCourseTime := fmt.Sprintf("%s-%s", existingStartTime.Format("15:04"), existingEndTime.Format("15:04"))
-				return NewScheduleConflictError(newCourseTime, existingCourseTime)
+			// Check for a weekly slot meeting at the same time on a day both timetables run
+			if newSlot, existingSlot, overlaps := findScheduleOverlap(newCourseSchedule, existingSchedule); overlaps {
+				return NewScheduleConflictError(newSlot.String(), existingSlot.String())
+			}
+		}
+
+		// Business Rule 4: Prerequisite Check
+		// Every prerequisite course needs a completion with a passing grade (with transaction)
+		minGrade := u.prerequisiteMinGrade()
+		missingPrerequisites, err := u.academicRepo.GetMissingPrerequisitesTx(txCtx, studentID, uuidToString(courseOfferingWithCourse.CourseID), common.GradesAtLeast(minGrade))
+		if err != nil {
+			return NewDatabaseOperationError("get missing prerequisites", err)
+		}
+		if len(missingPrerequisites) > 0 {
+			missingCourses := make([]string, 0, len(missingPrerequisites))
+			for _, prerequisite := range missingPrerequisites {
+				missingCourses = append(missingCourses, fmt.Sprintf("%s %s", prerequisite.Code, prerequisite.Name))
+			}
+			return NewPrerequisiteNotMetError(missingCourses, minGrade)
+		}
+
+		// Business Rule 5: Co-requisite Check
+		// Co-requisites are satisfied by a registration in the offering's semester or by a passing completion
+		semesterID := uuidToString(courseOfferingWithCourse.SemesterID)
+		missingCorequisites, err := u.academicRepo.GetMissingCorequisitesTx(txCtx, studentID, uuidToString(courseOfferingWithCourse.CourseID), semesterID, common.GradesAtLeast(minGrade))
+		if err != nil {
+			return NewDatabaseOperationError("get missing co-requisites", err)
+		}
+		if len(missingCorequisites) > 0 {
+			missingCourses := make([]string, 0, len(missingCorequisites))
+			for _, corequisite := range missingCorequisites {
+				missingCourses = append(missingCourses, fmt.Sprintf("%s %s", corequisite.Code, corequisite.Name))
 			}
+			return NewCorequisiteNotMetError(missingCourses)
+		}
+
+		// Business Rule 6: Exclusion Check
+		// Mutually exclusive courses can't be taken in the same semester
+		excludedCourses, err := u.academicRepo.GetEnrolledExcludedCoursesTx(txCtx, studentID, uuidToString(courseOfferingWithCourse.CourseID), semesterID)
+		if err != nil {
+			return NewDatabaseOperationError("get enrolled excluded courses", err)
+		}
+		if len(excludedCourses) > 0 {
+			conflictingCourses := make([]string, 0, len(excludedCourses))
+			for _, excluded := range excludedCourses {
+				conflictingCourses = append(conflictingCourses, fmt.Sprintf("%s %s", excluded.Code, excluded.Name))
+			}
+			return NewExcludedCourseConflictError(conflictingCourses)
+		}
+
+		// Business Rule 7: Study Plan Check
+		// The plan of the semester is created on the first registration and locked until the transaction ends,
+		// a plan under review or approved can't get new lines
+		studyPlan, err := u.studyPlanRepo.GetOrCreateStudyPlanTx(txCtx, uuid.NewString(), studentID, semesterID)
+		if err != nil {
+			return NewDatabaseOperationError("get study plan", err)
+		}
+		if !isStudyPlanEditable(studyPlan.Status) {
+			return NewStudyPlanLockedError(uuidToString(studyPlan.ID), studyPlan.Status)
+		}
+
+		// Business Rule 8: Credit Load Check
+		// The credits are summed after the study plan lock, so concurrent registrations of the student in the
+		// semester can't both take the last credits of the allowance
+		semesterEnrollments, err := u.academicRepo.GetStudentEnrollmentsWithDetailsTx(txCtx, studentID)
+		if err != nil {
+			return NewDatabaseOperationError("get student's existing enrollments", err)
+		}
+		allowance, err := u.creditAllowanceTx(txCtx, studentID, semesterID)
+		if err != nil {
+			return NewDatabaseOperationError("get credit allowance", err)
+		}
+		semesterCredits := sumSemesterCredits(semesterEnrollments, courseOfferingWithCourse.SemesterID)
+		if semesterCredits+courseOfferingWithCourse.Credit > allowance.MaxCredits {
+			return NewCreditLimitExceededError(semesterCredits, courseOfferingWithCourse.Credit, allowance.MaxCredits, allowance.Source)
 		}
 
 		// All business rules validated successfully - create the enrollment
 		// This operation is within the transaction to ensure atomic behavior
-		_, err = u.academicRepo.CreateEnrollmentTx(txCtx, studentID, courseOfferingID)
+		registration, err := u.academicRepo.CreateEnrollmentTx(txCtx, studentID, courseOfferingID, uuidToString(studyPlan.ID))
 		if err != nil {
 			return NewDatabaseOperationError("create enrollment", err)
 		}
 
+		_, err = u.waitlistRepo.CloseWaitlistEntryTx(txCtx, courseOfferingID, studentID, WaitlistStatusEnrolled, uuidToString(registration.ID))
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return NewDatabaseOperationError("close waitlist entry", err)
+		}
+
 		return nil
-	})
+	}
+}
+
+// prerequisiteMinGrade returns the lowest passing grade of a prerequisite, DefaultPrerequisiteMinGrade if unset.
+func (u *CourseEnrollmentUseCase) prerequisiteMinGrade() string {
+	if u.policy.PrerequisiteMinGrade == "" {
+		return DefaultPrerequisiteMinGrade
+	}
+	return u.policy.PrerequisiteMinGrade
 }
 
 // calculateCourseEndTime calculates the end time of a course based on its start time and credit hours.
-// Business Rule: Each credit hour equals 50 minutes of class time.
-// Formula: end_time = start_time + (credits * 50 minutes)
-// Example: 3-credit course starting at 9:00 AM ends at 11:30 AM (9:00 + 150 minutes)
-func calculateCourseEndTime(startTime time.Time, credits int32) time.Time {
+// Business Rule: Each credit hour equals the minutes per credit of the course, 50 minutes by default.
+// Formula: end_time = start_time + (credits * minutes per credit)
+// Example: 3-credit course starting at 9:00 AM ends at 11:30 AM (9:00 + 150 minutes),
+// a 1-credit practicum of 170 minutes per credit starting at 13:00 ends at 15:50
+func calculateCourseEndTime(startTime time.Time, credits, minutesPerCredit int32) time.Time {
+	return startTime.Add(classDuration(credits, minutesPerCredit))
+}
+
+// classDuration returns the class time of a meeting, DefaultMinutesPerCredit per credit hour when the course
+// doesn't set its minutes per credit.
+func classDuration(credits, minutesPerCredit int32) time.Duration {
 	if credits <= 0 {
-		return startTime // No duration if invalid credits
+		return 0 // No duration if invalid credits
+	}
+	if minutesPerCredit <= 0 {
+		minutesPerCredit = DefaultMinutesPerCredit
 	}
-	durationMinutes := int(credits) * 50
-	return startTime.Add(time.Duration(durationMinutes) * time.Minute)
+	durationMinutes := int(credits) * int(minutesPerCredit)
+	return time.Duration(durationMinutes) * time.Minute
 }
 
 // hasTimeOverlap checks if two time ranges overlap using inclusive boundary logic.
 // Two time ranges overlap if: start1 < end2 AND start2 < end1
 // This handles all overlap scenarios including:
-// - Partial overlaps (start1 < start2 < end1 < end2)  
+// - Partial overlaps (start1 < start2 < end1 < end2)
 // - Complete containment (start1 <= start2 && end2 <= end1)
 // - Adjacent ranges are NOT considered overlapping (end1 == start2)
 // Example: [9:00-11:00] and [10:00-12:00] overlap, but [9:00-11:00] and [11:00-13:00] do not
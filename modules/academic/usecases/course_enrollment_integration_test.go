@@ -5,13 +5,11 @@ package usecases
 import (
 	"context"
 	"siakad-poc/common"
-	"siakad-poc/db/generated"
 	"siakad-poc/db/repositories"
 	"sync"
 	"testing"
 	"time"
 
-	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -43,7 +41,7 @@ func (suite *EnrollmentIntegrationTestSuite) SetupSuite() {
 	// For demonstration, we'll use mock setup
 	suite.repo = repositories.NewDefaultAcademicRepository(suite.pool)
 	suite.txExecutor = common.NewPgxTransactionExecutor(suite.pool)
-	suite.useCase = NewCourseEnrollmentUseCase(suite.repo, suite.txExecutor)
+	suite.useCase = NewCourseEnrollmentUseCase(suite.repo, repositories.NewDefaultStudentRepository(suite.pool), repositories.NewDefaultStudyPlanRepository(suite.pool), repositories.NewDefaultCreditLoadRepository(suite.pool), repositories.NewDefaultWaitlistRepository(suite.pool), suite.txExecutor, EnrollmentPolicy{})
 	
 	// Test data IDs (would be generated from test data setup)
 	suite.testStudentID = "550e8400-e29b-41d4-a716-446655440001"
@@ -51,8 +51,8 @@ func (m *MockAcademicRepository) CheckEnrollmentExists(ctx context.Context, stud
 	return args.Get(0).(bool), args.Error(1)
 }
 
-func (m *MockAcademicRepository) CreateEnrollment(ctx context.Context, studentID, courseOfferingID string) (generated.CourseRegistration, error) {
-	args := m.Called(ctx, studentID, courseOfferingID)
+func (m *MockAcademicRepository) CreateEnrollment(ctx context.Context, studentID, courseOfferingID, studyPlanID string) (generated.CourseRegistration, error) {
+	args := m.Called(ctx, studentID, courseOfferingID, studyPlanID)
 	return args.Get(0).(generated.CourseRegistration), args.Error(1)
 }
 
@@ -67,13 +67,13 @@ func (m *MockAcademicRepository) CountCourseOfferings(ctx context.Context) (int6
 	return args.Get(0).(int64), args.Error(1)
 }
 
-func (m *MockAcademicRepository) CreateCourseOffering(ctx context.Context, semesterID, courseID, sectionCode string, capacity int32, startTime time.Time) (generated.CourseOffering, error) {
-	args := m.Called(ctx, semesterID, courseID, sectionCode, capacity, startTime)
+func (m *MockAcademicRepository) CreateCourseOfferingTx(txCtx *common.TxContext, semesterID, courseID, sectionCode string, capacity int32, startTime time.Time, roomID string) (generated.CourseOffering, error) {
+	args := m.Called(txCtx, semesterID, courseID, sectionCode, capacity, startTime, roomID)
 	return args.Get(0).(generated.CourseOffering), args.Error(1)
 }
 
-func (m *MockAcademicRepository) UpdateCourseOffering(ctx context.Context, id, semesterID, courseID, sectionCode string, capacity int32, startTime time.Time) (generated.CourseOffering, error) {
-	args := m.Called(ctx, id, semesterID, courseID, sectionCode, capacity, startTime)
+func (m *MockAcademicRepository) UpdateCourseOfferingTx(txCtx *common.TxContext, id, semesterID, courseID, sectionCode string, capacity int32, startTime time.Time, roomID string) (generated.CourseOffering, error) {
+	args := m.Called(txCtx, id, semesterID, courseID, sectionCode, capacity, startTime, roomID)
 	return args.Get(0).(generated.CourseOffering), args.Error(1)
 }
 
@@ -87,6 +87,26 @@ func (m *MockAcademicRepository) GetCourseOfferingByIDWithDetails(ctx context.Co
 	return args.Get(0).(repositories.CourseOfferingWithCourse), args.Error(1)
 }
 
+func (m *MockAcademicRepository) GetCourseOfferingsByStudyProgramWithPagination(ctx context.Context, studyProgramID string, limit, offset int) ([]repositories.CourseOfferingWithCourse, error) {
+	args := m.Called(ctx, studyProgramID, limit, offset)
+	return args.Get(0).([]repositories.CourseOfferingWithCourse), args.Error(1)
+}
+
+func (m *MockAcademicRepository) CountCourseOfferingsByStudyProgram(ctx context.Context, studyProgramID string) (int64, error) {
+	args := m.Called(ctx, studyProgramID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockAcademicRepository) IsCourseInStudyProgram(ctx context.Context, courseID, studyProgramID string) (bool, error) {
+	args := m.Called(ctx, courseID, studyProgramID)
+	return args.Get(0).(bool), args.Error(1)
+}
+
+func (m *MockAcademicRepository) IsCourseInActiveCurriculum(ctx context.Context, courseID string) (bool, error) {
+	args := m.Called(ctx, courseID)
+	return args.Get(0).(bool), args.Error(1)
+}
+
 // Transaction-aware methods (required by interface)
 func (m *MockAcademicRepository) GetCourseOfferingWithCourseTx(txCtx *common.TxContext, id string) (repositories.CourseOfferingWithCourse, error) {
 	args := m.Called(txCtx, id)
@@ -108,38 +128,269 @@ func (m *MockAcademicRepository) CheckEnrollmentExistsTx(txCtx *common.TxContext
 	return args.Get(0).(bool), args.Error(1)
 }
 
-func (m *MockAcademicRepository) CreateEnrollmentTx(txCtx *common.TxContext, studentID, courseOfferingID string) (generated.CourseRegistration, error) {
+func (m *MockAcademicRepository) CreateEnrollmentTx(txCtx *common.TxContext, studentID, courseOfferingID, studyPlanID string) (generated.CourseRegistration, error) {
+	args := m.Called(txCtx, studentID, courseOfferingID, studyPlanID)
+	return args.Get(0).(generated.CourseRegistration), args.Error(1)
+}
+
+func (m *MockAcademicRepository) GetEnrollmentTx(txCtx *common.TxContext, studentID, courseOfferingID string) (generated.CourseRegistration, error) {
 	args := m.Called(txCtx, studentID, courseOfferingID)
 	return args.Get(0).(generated.CourseRegistration), args.Error(1)
 }
 
+func (m *MockAcademicRepository) DropEnrollmentTx(txCtx *common.TxContext, id, droppedBy, reason string) (generated.CourseRegistration, error) {
+	args := m.Called(txCtx, id, droppedBy, reason)
+	return args.Get(0).(generated.CourseRegistration), args.Error(1)
+}
+
+func (m *MockAcademicRepository) GetMissingPrerequisitesTx(txCtx *common.TxContext, studentID, courseID string, passingGrades []string) ([]generated.GetMissingPrerequisitesRow, error) {
+	args := m.Called(txCtx, studentID, courseID, passingGrades)
+	return args.Get(0).([]generated.GetMissingPrerequisitesRow), args.Error(1)
+}
+
+func (m *MockAcademicRepository) GetMissingCorequisitesTx(txCtx *common.TxContext, studentID, courseID, semesterID string, passingGrades []string) ([]generated.GetMissingCorequisitesRow, error) {
+	args := m.Called(txCtx, studentID, courseID, semesterID, passingGrades)
+	return args.Get(0).([]generated.GetMissingCorequisitesRow), args.Error(1)
+}
+
+func (m *MockAcademicRepository) GetEnrolledExcludedCoursesTx(txCtx *common.TxContext, studentID, courseID, semesterID string) ([]generated.GetEnrolledExcludedCoursesRow, error) {
+	args := m.Called(txCtx, studentID, courseID, semesterID)
+	return args.Get(0).([]generated.GetEnrolledExcludedCoursesRow), args.Error(1)
+}
+
+func (m *MockAcademicRepository) ReplaceCourseOfferingSchedulesTx(txCtx *common.TxContext, courseOfferingID string, schedules []repositories.CourseOfferingScheduleAttributes) error {
+	args := m.Called(txCtx, courseOfferingID, schedules)
+	return args.Error(0)
+}
+
+func (m *MockAcademicRepository) GetCourseOfferingsByRoomTx(txCtx *common.TxContext, roomID string) ([]repositories.CourseOfferingWithCourse, error) {
+	args := m.Called(txCtx, roomID)
+	return args.Get(0).([]repositories.CourseOfferingWithCourse), args.Error(1)
+}
+
+func (m *MockAcademicRepository) ListCourseOfferingLecturers(ctx context.Context, courseOfferingID string) ([]generated.ListCourseOfferingLecturersRow, error) {
+	args := m.Called(ctx, courseOfferingID)
+	return args.Get(0).([]generated.ListCourseOfferingLecturersRow), args.Error(1)
+}
+
+func (m *MockAcademicRepository) DeleteCourseOfferingLecturer(ctx context.Context, courseOfferingID, lecturerID string) (generated.CourseOfferingLecturer, error) {
+	args := m.Called(ctx, courseOfferingID, lecturerID)
+	return args.Get(0).(generated.CourseOfferingLecturer), args.Error(1)
+}
+
+func (m *MockAcademicRepository) GetCourseOfferingsByLecturer(ctx context.Context, lecturerID, semesterID string) ([]repositories.CourseOfferingWithCourse, error) {
+	args := m.Called(ctx, lecturerID, semesterID)
+	return args.Get(0).([]repositories.CourseOfferingWithCourse), args.Error(1)
+}
+
+func (m *MockAcademicRepository) ListCourseOfferingLecturersTx(txCtx *common.TxContext, courseOfferingID string) ([]generated.ListCourseOfferingLecturersRow, error) {
+	args := m.Called(txCtx, courseOfferingID)
+	return args.Get(0).([]generated.ListCourseOfferingLecturersRow), args.Error(1)
+}
+
+func (m *MockAcademicRepository) CreateCourseOfferingLecturerTx(txCtx *common.TxContext, id, courseOfferingID, lecturerID string) (generated.CourseOfferingLecturer, error) {
+	args := m.Called(txCtx, id, courseOfferingID, lecturerID)
+	return args.Get(0).(generated.CourseOfferingLecturer), args.Error(1)
+}
+
+func (m *MockAcademicRepository) GetCourseOfferingsByLecturerTx(txCtx *common.TxContext, lecturerID, semesterID string) ([]repositories.CourseOfferingWithCourse, error) {
+	args := m.Called(txCtx, lecturerID, semesterID)
+	return args.Get(0).([]repositories.CourseOfferingWithCourse), args.Error(1)
+}
+
+// Mock student repository, only GetStudentByUserID is used by the enrollment use case
+type MockStudentRepository struct {
+	mock.Mock
+}
+
+func (m *MockStudentRepository) GetStudyProgram(ctx context.Context, id string) (generated.StudyProgram, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.StudyProgram), args.Error(1)
+}
+
+func (m *MockStudentRepository) GetStudyProgramsByCodes(ctx context.Context, codes []string) ([]generated.StudyProgram, error) {
+	args := m.Called(ctx, codes)
+	return args.Get(0).([]generated.StudyProgram), args.Error(1)
+}
+
+func (m *MockStudentRepository) GetExistingStudentNIMs(ctx context.Context, nims []string) ([]string, error) {
+	args := m.Called(ctx, nims)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockStudentRepository) GetStudentProfileByUserID(ctx context.Context, userID string) (generated.GetStudentProfileByUserIDRow, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(generated.GetStudentProfileByUserIDRow), args.Error(1)
+}
+
+func (m *MockStudentRepository) ListStudents(ctx context.Context, filter repositories.StudentFilter, limit, offset int) ([]generated.Student, error) {
+	args := m.Called(ctx, filter, limit, offset)
+	return args.Get(0).([]generated.Student), args.Error(1)
+}
+
+func (m *MockStudentRepository) CountStudents(ctx context.Context, filter repositories.StudentFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockStudentRepository) GetStudent(ctx context.Context, id string) (generated.Student, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.Student), args.Error(1)
+}
+
+func (m *MockStudentRepository) GetStudentByNIM(ctx context.Context, nim string) (generated.Student, error) {
+	args := m.Called(ctx, nim)
+	return args.Get(0).(generated.Student), args.Error(1)
+}
+
+func (m *MockStudentRepository) GetStudentByUserID(ctx context.Context, userID string) (generated.Student, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(generated.Student), args.Error(1)
+}
+
+func (m *MockStudentRepository) CreateStudent(ctx context.Context, id, userID string, attributes repositories.StudentAttributes) (generated.Student, error) {
+	args := m.Called(ctx, id, userID, attributes)
+	return args.Get(0).(generated.Student), args.Error(1)
+}
+
+func (m *MockStudentRepository) UpdateStudent(ctx context.Context, id string, attributes repositories.StudentAttributes) (generated.Student, error) {
+	args := m.Called(ctx, id, attributes)
+	return args.Get(0).(generated.Student), args.Error(1)
+}
+
+func (m *MockStudentRepository) DeleteStudent(ctx context.Context, id string) (generated.Student, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.Student), args.Error(1)
+}
+
+func (m *MockStudentRepository) CountStudentsByAcademicAdvisor(ctx context.Context, lecturerID string) (int64, error) {
+	args := m.Called(ctx, lecturerID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockStudentRepository) CreateStudentsTx(txCtx *common.TxContext, students []repositories.NewStudent) (int64, error) {
+	args := m.Called(txCtx, students)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// passingGrades are the grades that pass a prerequisite with the default minimum grade
+var passingGrades = []string{"A", "AB", "B", "BC", "C"}
+
 // Test Suite
 type EnrollmentUseCaseTestSuite struct {
 	suite.Suite
-	useCase        *CourseEnrollmentUseCase
-	mockRepo       *MockAcademicRepository
-	mockTxExecutor *common.MockTransactionExecutor
-	ctx            context.Context
-	studentID      string
-	courseID       string
+	useCase            *CourseEnrollmentUseCase
+	mockRepo           *MockAcademicRepository
+	mockStudentRepo    *MockStudentRepository
+	mockStudyPlanRepo  *MockStudyPlanRepository
+	mockCreditLoadRepo *MockCreditLoadRepository
+	mockWaitlistRepo   *MockWaitlistRepository
+	mockTxExecutor     *common.MockTransactionExecutor
+	ctx                context.Context
+	studentID          string
+	courseID           string
+	studyPlanID        string
+	studyPlan          generated.StudyPlan
 }
 
 func (suite *EnrollmentUseCaseTestSuite) SetupTest() {
 	suite.mockRepo = new(MockAcademicRepository)
+	suite.mockStudentRepo = new(MockStudentRepository)
+	suite.mockStudyPlanRepo = new(MockStudyPlanRepository)
+	suite.mockCreditLoadRepo = new(MockCreditLoadRepository)
+	suite.mockWaitlistRepo = new(MockWaitlistRepository)
 	suite.mockTxExecutor = new(common.MockTransactionExecutor)
 
 	suite.useCase = &CourseEnrollmentUseCase{
-		academicRepo: suite.mockRepo,
-		txExecutor:   suite.mockTxExecutor,
+		academicRepo:   suite.mockRepo,
+		studentRepo:    suite.mockStudentRepo,
+		studyPlanRepo:  suite.mockStudyPlanRepo,
+		creditLoadRepo: suite.mockCreditLoadRepo,
+		waitlistRepo:   suite.mockWaitlistRepo,
+		txExecutor:     suite.mockTxExecutor,
 	}
 
 	suite.ctx = context.Background()
 	suite.studentID = "550e8400-e29b-41d4-a716-446655440001"
 	suite.courseID = "550e8400-e29b-41d4-a716-446655440002"
+	suite.studyPlanID = "550e8400-e29b-41d4-a716-446655440003"
+
+	// Draft study plan the registrations are added to
+	var studyPlanUUID pgtype.UUID
+	_ = studyPlanUUID.Scan(suite.studyPlanID)
+	suite.studyPlan = generated.StudyPlan{ID: studyPlanUUID, Status: StudyPlanStatusDraft}
 }
 
 func (suite *EnrollmentUseCaseTestSuite) TearDownTest() {
 	suite.mockRepo.AssertExpectations(suite.T())
+	suite.mockStudentRepo.AssertExpectations(suite.T())
+	suite.mockStudyPlanRepo.AssertExpectations(suite.T())
+	suite.mockCreditLoadRepo.AssertExpectations(suite.T())
+	suite.mockWaitlistRepo.AssertExpectations(suite.T())
+}
+
+// expectStudyPlan expects the registration to go into the draft study plan of the semester
+func (suite *EnrollmentUseCaseTestSuite) expectStudyPlan() {
+	suite.mockStudyPlanRepo.On("GetOrCreateStudyPlanTx", mock.AnythingOfType("*common.TxContext"), mock.AnythingOfType("string"), suite.studentID, mock.AnythingOfType("string")).Return(suite.studyPlan, nil)
+}
+
+// expectCreditAllowance expects a student without an admin allowance nor a previous semester GPA, who can take
+// DefaultMaxCreditsWithoutGPA credits
+func (suite *EnrollmentUseCaseTestSuite) expectCreditAllowance() {
+	suite.mockCreditLoadRepo.On("GetStudentCreditAllowanceTx", mock.AnythingOfType("*common.TxContext"), suite.studentID).Return(generated.StudentCreditAllowance{}, pgx.ErrNoRows)
+	suite.mockCreditLoadRepo.On("GetPreviousSemesterGPATx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.AnythingOfType("string")).Return(generated.GetPreviousSemesterGPARow{}, pgx.ErrNoRows)
+}
+
+// expectNotWaitlisted expects the enrolled student not to be waiting for a seat in the course offering
+func (suite *EnrollmentUseCaseTestSuite) expectNotWaitlisted() {
+	suite.mockWaitlistRepo.On("CloseWaitlistEntryTx", mock.AnythingOfType("*common.TxContext"), suite.courseID, suite.studentID, WaitlistStatusEnrolled, mock.AnythingOfType("string")).Return(generated.CourseOfferingWaitlist{}, pgx.ErrNoRows)
+}
+
+// Test enrollment resolving the student record of the token user
+func (suite *EnrollmentUseCaseTestSuite) TestEnrollUser_ResolvesStudentRecord() {
+	userID := "550e8400-e29b-41d4-a716-446655440009"
+	var studentUUID pgtype.UUID
+	_ = studentUUID.Scan(suite.studentID)
+
+	suite.mockStudentRepo.On("GetStudentByUserID", suite.ctx, userID).Return(generated.Student{ID: studentUUID}, nil)
+	suite.mockRepo.On("CheckEnrollmentExistsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID).Return(true, nil)
+
+	studentID, err := suite.useCase.EnrollUser(suite.ctx, userID, suite.courseID)
+
+	// The registration is checked against the student record, not the user
+	assert.Equal(suite.T(), suite.studentID, studentID)
+	errorType, ok := GetEnrollmentErrorType(err)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), ErrDuplicateEnrollment, errorType)
+}
+
+// Test enrollment by a user without a student record
+func (suite *EnrollmentUseCaseTestSuite) TestEnrollUser_StudentNotFound() {
+	userID := "550e8400-e29b-41d4-a716-446655440009"
+	suite.mockStudentRepo.On("GetStudentByUserID", suite.ctx, userID).Return(generated.Student{}, pgx.ErrNoRows)
+
+	_, err := suite.useCase.EnrollUser(suite.ctx, userID, suite.courseID)
+
+	errorType, ok := GetEnrollmentErrorType(err)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), ErrStudentNotFound, errorType)
+	assert.True(suite.T(), IsDataValidationError(err))
+	suite.mockRepo.AssertNotCalled(suite.T(), "CheckEnrollmentExistsTx")
+}
+
+// Test enrollment by a user whose student record is soft-deleted
+func (suite *EnrollmentUseCaseTestSuite) TestEnrollUser_DeletedStudent() {
+	userID := "550e8400-e29b-41d4-a716-446655440009"
+	deletedStudent := generated.Student{
+		DeletedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}
+	suite.mockStudentRepo.On("GetStudentByUserID", suite.ctx, userID).Return(deletedStudent, nil)
+
+	_, err := suite.useCase.EnrollUser(suite.ctx, userID, suite.courseID)
+
+	errorType, ok := GetEnrollmentErrorType(err)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), ErrStudentNotFound, errorType)
 }
 
 // Test successful enrollment
@@ -159,7 +410,13 @@ func (suite *EnrollmentUseCaseTestSuite) TestEnrollStudent_Success() {
 	suite.mockRepo.On("GetCourseOfferingWithCourseTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(courseOfferingWithCourse, nil)
 	suite.mockRepo.On("CountCourseOfferingEnrollmentsTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(int64(10), nil)
 	suite.mockRepo.On("GetStudentEnrollmentsWithDetailsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID).Return([]repositories.StudentEnrollmentWithDetails{}, nil)
-	suite.mockRepo.On("CreateEnrollmentTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID).Return(generated.CourseRegistration{}, nil)
+	suite.mockRepo.On("GetMissingPrerequisitesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, passingGrades).Return([]generated.GetMissingPrerequisitesRow{}, nil)
+	suite.mockRepo.On("GetMissingCorequisitesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, mock.Anything, mock.Anything).Return([]generated.GetMissingCorequisitesRow{}, nil)
+	suite.mockRepo.On("GetEnrolledExcludedCoursesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, mock.Anything).Return([]generated.GetEnrolledExcludedCoursesRow{}, nil)
+	suite.expectStudyPlan()
+	suite.expectCreditAllowance()
+	suite.mockRepo.On("CreateEnrollmentTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID, suite.studyPlanID).Return(generated.CourseRegistration{}, nil)
+	suite.expectNotWaitlisted()
 
 	// Execute
 	err := suite.useCase.EnrollStudent(suite.ctx, suite.studentID, suite.courseID)
@@ -168,6 +425,121 @@ func (suite *EnrollmentUseCaseTestSuite) TestEnrollStudent_Success() {
 	assert.NoError(suite.T(), err)
 }
 
+// Test enrolling while the study plan of the semester is under review
+func (suite *EnrollmentUseCaseTestSuite) TestEnrollStudent_StudyPlanLocked() {
+	courseOfferingWithCourse := repositories.CourseOfferingWithCourse{
+		Capacity: 30,
+		CourseOfferingStartTime: pgtype.Timestamptz{
+			Time:  time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC),
+			Valid: true,
+		},
+		Credit: 3,
+	}
+	suite.studyPlan.Status = StudyPlanStatusSubmitted
+
+	suite.mockRepo.On("CheckEnrollmentExistsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID).Return(false, nil)
+	suite.mockRepo.On("GetCourseOfferingWithCourseTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(courseOfferingWithCourse, nil)
+	suite.mockRepo.On("CountCourseOfferingEnrollmentsTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(int64(10), nil)
+	suite.mockRepo.On("GetStudentEnrollmentsWithDetailsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID).Return([]repositories.StudentEnrollmentWithDetails{}, nil)
+	suite.mockRepo.On("GetMissingPrerequisitesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, passingGrades).Return([]generated.GetMissingPrerequisitesRow{}, nil)
+	suite.mockRepo.On("GetMissingCorequisitesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, mock.Anything, mock.Anything).Return([]generated.GetMissingCorequisitesRow{}, nil)
+	suite.mockRepo.On("GetEnrolledExcludedCoursesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, mock.Anything).Return([]generated.GetEnrolledExcludedCoursesRow{}, nil)
+	suite.expectStudyPlan()
+
+	err := suite.useCase.EnrollStudent(suite.ctx, suite.studentID, suite.courseID)
+
+	assert.Error(suite.T(), err)
+	enrollmentErr, ok := err.(*EnrollmentError)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), ErrStudyPlanLocked, enrollmentErr.Type)
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateEnrollmentTx")
+}
+
+// expectRulesBeforeCreditLoad expects the checks before the credit load check to pass for the course offering
+func (suite *EnrollmentUseCaseTestSuite) expectRulesBeforeCreditLoad(courseOfferingWithCourse repositories.CourseOfferingWithCourse, existingEnrollments []repositories.StudentEnrollmentWithDetails) {
+	suite.mockRepo.On("CheckEnrollmentExistsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID).Return(false, nil)
+	suite.mockRepo.On("GetCourseOfferingWithCourseTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(courseOfferingWithCourse, nil)
+	suite.mockRepo.On("CountCourseOfferingEnrollmentsTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(int64(10), nil)
+	suite.mockRepo.On("GetStudentEnrollmentsWithDetailsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID).Return(existingEnrollments, nil)
+	suite.mockRepo.On("GetMissingPrerequisitesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, passingGrades).Return([]generated.GetMissingPrerequisitesRow{}, nil)
+	suite.mockRepo.On("GetMissingCorequisitesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, mock.Anything, mock.Anything).Return([]generated.GetMissingCorequisitesRow{}, nil)
+	suite.mockRepo.On("GetEnrolledExcludedCoursesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, mock.Anything).Return([]generated.GetEnrolledExcludedCoursesRow{}, nil)
+	suite.expectStudyPlan()
+}
+
+// creditLoadFixture returns a 3 credit course offering of the semester and existing enrollments worth the given
+// credits in it, plus 6 credits in another semester that don't count
+func (suite *EnrollmentUseCaseTestSuite) creditLoadFixture(semesterCredits int32) (repositories.CourseOfferingWithCourse, []repositories.StudentEnrollmentWithDetails) {
+	semesterID := pgtype.UUID{Bytes: [16]byte{7}, Valid: true}
+	otherSemesterID := pgtype.UUID{Bytes: [16]byte{8}, Valid: true}
+	courseOfferingWithCourse := repositories.CourseOfferingWithCourse{
+		SemesterID: semesterID,
+		Capacity:   30,
+		CourseOfferingStartTime: pgtype.Timestamptz{
+			Time:  time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC),
+			Valid: true,
+		},
+		Credit: 3,
+	}
+	// Enrollments without a start time are skipped by the schedule check, their credits still count
+	existingEnrollments := []repositories.StudentEnrollmentWithDetails{
+		{SemesterID: semesterID, Credit: semesterCredits},
+		{SemesterID: otherSemesterID, Credit: 6},
+	}
+	return courseOfferingWithCourse, existingEnrollments
+}
+
+// Test enrolling over the allowance of students without a previous semester GPA
+func (suite *EnrollmentUseCaseTestSuite) TestEnrollStudent_CreditLimitExceeded() {
+	courseOfferingWithCourse, existingEnrollments := suite.creditLoadFixture(18)
+	suite.expectRulesBeforeCreditLoad(courseOfferingWithCourse, existingEnrollments)
+	suite.expectCreditAllowance()
+
+	err := suite.useCase.EnrollStudent(suite.ctx, suite.studentID, suite.courseID)
+
+	enrollmentErr, ok := err.(*EnrollmentError)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), ErrCreditLimitExceeded, enrollmentErr.Type)
+	assert.Equal(suite.T(), int32(18), enrollmentErr.Details["semester_credits"])
+	assert.Equal(suite.T(), int32(DefaultMaxCreditsWithoutGPA), enrollmentErr.Details["max_credits"])
+	assert.Equal(suite.T(), CreditAllowanceSourceDefault, enrollmentErr.Details["source"])
+	assert.True(suite.T(), IsBusinessRuleViolation(err))
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateEnrollmentTx")
+}
+
+// Test the previous semester GPA raises the allowance, the limit itself can be reached
+func (suite *EnrollmentUseCaseTestSuite) TestEnrollStudent_CreditLimitFromPreviousGPA() {
+	courseOfferingWithCourse, existingEnrollments := suite.creditLoadFixture(21)
+	suite.expectRulesBeforeCreditLoad(courseOfferingWithCourse, existingEnrollments)
+	var gpa pgtype.Numeric
+	_ = gpa.Scan("3.25")
+	suite.mockCreditLoadRepo.On("GetStudentCreditAllowanceTx", mock.AnythingOfType("*common.TxContext"), suite.studentID).Return(generated.StudentCreditAllowance{}, pgx.ErrNoRows)
+	suite.mockCreditLoadRepo.On("GetPreviousSemesterGPATx", mock.AnythingOfType("*common.TxContext"), suite.studentID, courseOfferingWithCourse.SemesterID.String()).Return(generated.GetPreviousSemesterGPARow{Gpa: gpa}, nil)
+	suite.mockRepo.On("CreateEnrollmentTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID, suite.studyPlanID).Return(generated.CourseRegistration{}, nil)
+	suite.expectNotWaitlisted()
+
+	err := suite.useCase.EnrollStudent(suite.ctx, suite.studentID, suite.courseID)
+
+	assert.NoError(suite.T(), err)
+}
+
+// Test the allowance set by an admin replaces the one of the previous semester GPA
+func (suite *EnrollmentUseCaseTestSuite) TestEnrollStudent_CreditLimitSetByAdmin() {
+	courseOfferingWithCourse, existingEnrollments := suite.creditLoadFixture(10)
+	suite.expectRulesBeforeCreditLoad(courseOfferingWithCourse, existingEnrollments)
+	suite.mockCreditLoadRepo.On("GetStudentCreditAllowanceTx", mock.AnythingOfType("*common.TxContext"), suite.studentID).Return(generated.StudentCreditAllowance{MaxCredits: 12}, nil)
+
+	err := suite.useCase.EnrollStudent(suite.ctx, suite.studentID, suite.courseID)
+
+	enrollmentErr, ok := err.(*EnrollmentError)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), ErrCreditLimitExceeded, enrollmentErr.Type)
+	assert.Equal(suite.T(), int32(12), enrollmentErr.Details["max_credits"])
+	assert.Equal(suite.T(), CreditAllowanceSourceAdmin, enrollmentErr.Details["source"])
+	suite.mockCreditLoadRepo.AssertNotCalled(suite.T(), "GetPreviousSemesterGPATx")
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateEnrollmentTx")
+}
+
 // Test duplicate enrollment
 func (suite *EnrollmentUseCaseTestSuite) TestEnrollStudent_DuplicateEnrollment() {
 	// Mock expectations
@@ -301,7 +673,163 @@ func (suite *EnrollmentUseCaseTestSuite) TestEnrollStudent_NoScheduleOverlap() {
 	suite.mockRepo.On("GetCourseOfferingWithCourseTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(courseOfferingWithCourse, nil)
 	suite.mockRepo.On("CountCourseOfferingEnrollmentsTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(int64(10), nil)
 	suite.mockRepo.On("GetStudentEnrollmentsWithDetailsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID).Return(existingEnrollments, nil)
-	suite.mockRepo.On("CreateEnrollmentTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID).Return(generated.CourseRegistration{}, nil)
+	suite.mockRepo.On("GetMissingPrerequisitesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, passingGrades).Return([]generated.GetMissingPrerequisitesRow{}, nil)
+	suite.mockRepo.On("GetMissingCorequisitesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, mock.Anything, mock.Anything).Return([]generated.GetMissingCorequisitesRow{}, nil)
+	suite.mockRepo.On("GetEnrolledExcludedCoursesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, mock.Anything).Return([]generated.GetEnrolledExcludedCoursesRow{}, nil)
+	suite.expectStudyPlan()
+	suite.expectCreditAllowance()
+	suite.mockRepo.On("CreateEnrollmentTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID, suite.studyPlanID).Return(generated.CourseRegistration{}, nil)
+	suite.expectNotWaitlisted()
+
+	// Execute
+	err := suite.useCase.EnrollStudent(suite.ctx, suite.studentID, suite.courseID)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+}
+
+// Test schedule overlap caused by the class time per credit of a practicum course
+func (suite *EnrollmentUseCaseTestSuite) TestEnrollStudent_ScheduleOverlapMinutesPerCredit() {
+	// Setup - new practicum from 13:00-15:50 (1 credit * 170 min), it would end at 13:50 with 50 minutes per credit
+	courseOfferingWithCourse := repositories.CourseOfferingWithCourse{
+		Capacity: 30,
+		CourseOfferingStartTime: pgtype.Timestamptz{
+			Time:  time.Date(2025, 1, 15, 13, 0, 0, 0, time.UTC),
+			Valid: true,
+		},
+		Credit:           1,
+		MinutesPerCredit: 170,
+	}
+
+	// Existing enrollment from 15:00-16:40 (2 credits * 50 min = 100 min) - overlaps with the practicum
+	existingEnrollments := []repositories.StudentEnrollmentWithDetails{
+		{
+			CourseOfferingStartTime: pgtype.Timestamptz{
+				Time:  time.Date(2025, 1, 15, 15, 0, 0, 0, time.UTC),
+				Valid: true,
+			},
+			Credit:           2,
+			MinutesPerCredit: 50,
+		},
+	}
+
+	// Mock expectations
+	suite.mockRepo.On("CheckEnrollmentExistsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID).Return(false, nil)
+	suite.mockRepo.On("GetCourseOfferingWithCourseTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(courseOfferingWithCourse, nil)
+	suite.mockRepo.On("CountCourseOfferingEnrollmentsTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(int64(10), nil)
+	suite.mockRepo.On("GetStudentEnrollmentsWithDetailsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID).Return(existingEnrollments, nil)
+
+	// Execute
+	err := suite.useCase.EnrollStudent(suite.ctx, suite.studentID, suite.courseID)
+
+	// Assert
+	assert.Error(suite.T(), err)
+	errorType, ok := GetEnrollmentErrorType(err)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), ErrScheduleConflict, errorType)
+	assert.Equal(suite.T(), "Wednesday 13:00-15:50", err.(*EnrollmentError).Details["new_course_time"])
+}
+
+// Test weekly schedules overlapping within the same semester
+func (suite *EnrollmentUseCaseTestSuite) TestEnrollStudent_WeeklyScheduleOverlap() {
+	semesterStart := pgtype.Timestamptz{Time: time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC), Valid: true}
+	semesterEnd := pgtype.Timestamptz{Time: time.Date(2025, 6, 27, 0, 0, 0, 0, time.UTC), Valid: true}
+
+	// New course meets on Monday 9:00-11:30 and Thursday 13:00-15:30
+	courseOfferingWithCourse := repositories.CourseOfferingWithCourse{
+		Capacity: 30,
+		CourseOfferingStartTime: pgtype.Timestamptz{
+			Time:  time.Date(2025, 2, 3, 9, 0, 0, 0, time.UTC),
+			Valid: true,
+		},
+		Credit:            3,
+		SemesterStartTime: semesterStart,
+		SemesterEndTime:   semesterEnd,
+		Schedules: []generated.CourseOfferingSchedule{
+			{DayOfWeek: 1, StartTime: pgtype.Time{Microseconds: int64(9 * time.Hour / time.Microsecond), Valid: true}},
+			{DayOfWeek: 4, StartTime: pgtype.Time{Microseconds: int64(13 * time.Hour / time.Microsecond), Valid: true}},
+		},
+	}
+
+	// Existing enrollment starts on a Tuesday but also meets on Thursday 14:00-15:40
+	existingEnrollments := []repositories.StudentEnrollmentWithDetails{
+		{
+			CourseOfferingStartTime: pgtype.Timestamptz{
+				Time:  time.Date(2025, 2, 4, 8, 0, 0, 0, time.UTC),
+				Valid: true,
+			},
+			Credit:            2,
+			SemesterStartTime: semesterStart,
+			SemesterEndTime:   semesterEnd,
+			Schedules: []generated.CourseOfferingSchedule{
+				{DayOfWeek: 2, StartTime: pgtype.Time{Microseconds: int64(8 * time.Hour / time.Microsecond), Valid: true}},
+				{DayOfWeek: 4, StartTime: pgtype.Time{Microseconds: int64(14 * time.Hour / time.Microsecond), Valid: true}},
+			},
+		},
+	}
+
+	// Mock expectations
+	suite.mockRepo.On("CheckEnrollmentExistsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID).Return(false, nil)
+	suite.mockRepo.On("GetCourseOfferingWithCourseTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(courseOfferingWithCourse, nil)
+	suite.mockRepo.On("CountCourseOfferingEnrollmentsTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(int64(10), nil)
+	suite.mockRepo.On("GetStudentEnrollmentsWithDetailsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID).Return(existingEnrollments, nil)
+
+	// Execute
+	err := suite.useCase.EnrollStudent(suite.ctx, suite.studentID, suite.courseID)
+
+	// Assert
+	assert.Error(suite.T(), err)
+	errorType, ok := GetEnrollmentErrorType(err)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), ErrScheduleConflict, errorType)
+	assert.Equal(suite.T(), "Thursday 13:00-15:30", err.(*EnrollmentError).Details["new_course_time"])
+	assert.Equal(suite.T(), "Thursday 14:00-15:40", err.(*EnrollmentError).Details["existing_course_time"])
+}
+
+// Test the same weekly slot in different semesters
+func (suite *EnrollmentUseCaseTestSuite) TestEnrollStudent_WeeklyScheduleDifferentSemester() {
+	schedules := []generated.CourseOfferingSchedule{
+		{DayOfWeek: 1, StartTime: pgtype.Time{Microseconds: int64(9 * time.Hour / time.Microsecond), Valid: true}},
+	}
+
+	courseOfferingWithCourse := repositories.CourseOfferingWithCourse{
+		Capacity: 30,
+		CourseOfferingStartTime: pgtype.Timestamptz{
+			Time:  time.Date(2025, 9, 1, 9, 0, 0, 0, time.UTC),
+			Valid: true,
+		},
+		Credit:            3,
+		SemesterStartTime: pgtype.Timestamptz{Time: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		SemesterEndTime:   pgtype.Timestamptz{Time: time.Date(2026, 1, 23, 0, 0, 0, 0, time.UTC), Valid: true},
+		Schedules:         schedules,
+	}
+
+	// Existing enrollment meets on Monday 9:00 as well, in the previous semester
+	existingEnrollments := []repositories.StudentEnrollmentWithDetails{
+		{
+			CourseOfferingStartTime: pgtype.Timestamptz{
+				Time:  time.Date(2025, 2, 3, 9, 0, 0, 0, time.UTC),
+				Valid: true,
+			},
+			Credit:            3,
+			SemesterStartTime: pgtype.Timestamptz{Time: time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC), Valid: true},
+			SemesterEndTime:   pgtype.Timestamptz{Time: time.Date(2025, 6, 27, 0, 0, 0, 0, time.UTC), Valid: true},
+			Schedules:         schedules,
+		},
+	}
+
+	// Mock expectations
+	suite.mockRepo.On("CheckEnrollmentExistsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID).Return(false, nil)
+	suite.mockRepo.On("GetCourseOfferingWithCourseTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(courseOfferingWithCourse, nil)
+	suite.mockRepo.On("CountCourseOfferingEnrollmentsTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(int64(10), nil)
+	suite.mockRepo.On("GetStudentEnrollmentsWithDetailsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID).Return(existingEnrollments, nil)
+	suite.mockRepo.On("GetMissingPrerequisitesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, passingGrades).Return([]generated.GetMissingPrerequisitesRow{}, nil)
+	suite.mockRepo.On("GetMissingCorequisitesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, mock.Anything, mock.Anything).Return([]generated.GetMissingCorequisitesRow{}, nil)
+	suite.mockRepo.On("GetEnrolledExcludedCoursesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, mock.Anything).Return([]generated.GetEnrolledExcludedCoursesRow{}, nil)
+	suite.expectStudyPlan()
+	suite.expectCreditAllowance()
+	suite.mockRepo.On("CreateEnrollmentTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID, suite.studyPlanID).Return(generated.CourseRegistration{}, nil)
+	suite.expectNotWaitlisted()
 
 	// Execute
 	err := suite.useCase.EnrollStudent(suite.ctx, suite.studentID, suite.courseID)
@@ -417,7 +945,13 @@ func (suite *EnrollmentUseCaseTestSuite) TestEnrollStudent_CapacityBoundaryCondi
 	suite.mockRepo.On("GetCourseOfferingWithCourseTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(courseOffering, nil)
 	suite.mockRepo.On("CountCourseOfferingEnrollmentsTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(int64(9), nil)
 	suite.mockRepo.On("GetStudentEnrollmentsWithDetailsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID).Return([]repositories.StudentEnrollmentWithDetails{}, nil)
-	suite.mockRepo.On("CreateEnrollmentTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID).Return(generated.CourseRegistration{}, nil)
+	suite.mockRepo.On("GetMissingPrerequisitesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, passingGrades).Return([]generated.GetMissingPrerequisitesRow{}, nil)
+	suite.mockRepo.On("GetMissingCorequisitesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, mock.Anything, mock.Anything).Return([]generated.GetMissingCorequisitesRow{}, nil)
+	suite.mockRepo.On("GetEnrolledExcludedCoursesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, mock.Anything).Return([]generated.GetEnrolledExcludedCoursesRow{}, nil)
+	suite.expectStudyPlan()
+	suite.expectCreditAllowance()
+	suite.mockRepo.On("CreateEnrollmentTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID, suite.studyPlanID).Return(generated.CourseRegistration{}, nil)
+	suite.expectNotWaitlisted()
 
 	err := suite.useCase.EnrollStudent(suite.ctx, suite.studentID, suite.courseID)
 	assert.NoError(suite.T(), err)
@@ -464,7 +998,13 @@ func (suite *EnrollmentUseCaseTestSuite) TestEnrollStudent_ScheduleOverlapEdgeCa
 	suite.mockRepo.On("GetCourseOfferingWithCourseTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(courseOfferingWithCourse, nil)
 	suite.mockRepo.On("CountCourseOfferingEnrollmentsTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(int64(5), nil)
 	suite.mockRepo.On("GetStudentEnrollmentsWithDetailsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID).Return(existingEnrollment, nil)
-	suite.mockRepo.On("CreateEnrollmentTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID).Return(generated.CourseRegistration{}, nil)
+	suite.mockRepo.On("GetMissingPrerequisitesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, passingGrades).Return([]generated.GetMissingPrerequisitesRow{}, nil)
+	suite.mockRepo.On("GetMissingCorequisitesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, mock.Anything, mock.Anything).Return([]generated.GetMissingCorequisitesRow{}, nil)
+	suite.mockRepo.On("GetEnrolledExcludedCoursesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, mock.Anything).Return([]generated.GetEnrolledExcludedCoursesRow{}, nil)
+	suite.expectStudyPlan()
+	suite.expectCreditAllowance()
+	suite.mockRepo.On("CreateEnrollmentTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID, suite.studyPlanID).Return(generated.CourseRegistration{}, nil)
+	suite.expectNotWaitlisted()
 
 	err := suite.useCase.EnrollStudent(suite.ctx, suite.studentID, suite.courseID)
 	assert.NoError(suite.T(), err) // Should succeed - no overlap
@@ -542,39 +1082,195 @@ func (suite *EnrollmentUseCaseTestSuite) TestEnrollStudent_InvalidExistingEnroll
 	suite.mockRepo.On("GetCourseOfferingWithCourseTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(courseOfferingWithCourse, nil)
 	suite.mockRepo.On("CountCourseOfferingEnrollmentsTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(int64(5), nil)
 	suite.mockRepo.On("GetStudentEnrollmentsWithDetailsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID).Return(existingEnrollments, nil)
-	suite.mockRepo.On("CreateEnrollmentTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID).Return(generated.CourseRegistration{}, nil)
+	suite.mockRepo.On("GetMissingPrerequisitesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, passingGrades).Return([]generated.GetMissingPrerequisitesRow{}, nil)
+	suite.mockRepo.On("GetMissingCorequisitesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, mock.Anything, mock.Anything).Return([]generated.GetMissingCorequisitesRow{}, nil)
+	suite.mockRepo.On("GetEnrolledExcludedCoursesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, mock.Anything).Return([]generated.GetEnrolledExcludedCoursesRow{}, nil)
+	suite.expectStudyPlan()
+	suite.expectCreditAllowance()
+	suite.mockRepo.On("CreateEnrollmentTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID, suite.studyPlanID).Return(generated.CourseRegistration{}, nil)
+	suite.expectNotWaitlisted()
 
 	err := suite.useCase.EnrollStudent(suite.ctx, suite.studentID, suite.courseID)
 	assert.NoError(suite.T(), err) // Should succeed - invalid enrollments are skipped
 
 }
 
+// Test enrollment rejected because of prerequisites the student has not passed
+func (suite *EnrollmentUseCaseTestSuite) TestEnrollStudent_PrerequisiteNotMet() {
+	var courseUUID pgtype.UUID
+	_ = courseUUID.Scan("550e8400-e29b-41d4-a716-446655440003")
+	courseOfferingWithCourse := repositories.CourseOfferingWithCourse{
+		CourseID: courseUUID,
+		Capacity: 30,
+		CourseOfferingStartTime: pgtype.Timestamptz{
+			Time:  time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC),
+			Valid: true,
+		},
+		Credit: 3,
+	}
+	missingPrerequisites := []generated.GetMissingPrerequisitesRow{
+		{Code: "IF101", Name: "Pemrograman Dasar"},
+		{Code: "IF102", Name: "Matematika Diskrit"},
+	}
+
+	suite.mockRepo.On("CheckEnrollmentExistsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID).Return(false, nil)
+	suite.mockRepo.On("GetCourseOfferingWithCourseTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(courseOfferingWithCourse, nil)
+	suite.mockRepo.On("CountCourseOfferingEnrollmentsTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(int64(5), nil)
+	suite.mockRepo.On("GetStudentEnrollmentsWithDetailsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID).Return([]repositories.StudentEnrollmentWithDetails{}, nil)
+	suite.mockRepo.On("GetMissingPrerequisitesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, "550e8400-e29b-41d4-a716-446655440003", passingGrades).Return(missingPrerequisites, nil)
+
+	err := suite.useCase.EnrollStudent(suite.ctx, suite.studentID, suite.courseID)
+
+	assert.Error(suite.T(), err)
+	errorType, ok := GetEnrollmentErrorType(err)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), ErrPrerequisiteNotMet, errorType)
+	assert.True(suite.T(), IsBusinessRuleViolation(err))
+	assert.Equal(suite.T(), []string{"IF101 Pemrograman Dasar", "IF102 Matematika Diskrit"}, err.(*EnrollmentError).Details["missing_courses"])
+	assert.Equal(suite.T(), "C", err.(*EnrollmentError).Details["minimum_grade"])
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateEnrollmentTx")
+}
+
+// Test the minimum grade of the enrollment policy
+func (suite *EnrollmentUseCaseTestSuite) TestEnrollStudent_PrerequisiteMinGradeFromPolicy() {
+	suite.useCase.policy = EnrollmentPolicy{PrerequisiteMinGrade: "B"}
+	courseOfferingWithCourse := repositories.CourseOfferingWithCourse{
+		Capacity: 30,
+		CourseOfferingStartTime: pgtype.Timestamptz{
+			Time:  time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC),
+			Valid: true,
+		},
+		Credit: 3,
+	}
+
+	suite.mockRepo.On("CheckEnrollmentExistsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID).Return(false, nil)
+	suite.mockRepo.On("GetCourseOfferingWithCourseTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(courseOfferingWithCourse, nil)
+	suite.mockRepo.On("CountCourseOfferingEnrollmentsTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(int64(5), nil)
+	suite.mockRepo.On("GetStudentEnrollmentsWithDetailsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID).Return([]repositories.StudentEnrollmentWithDetails{}, nil)
+	suite.mockRepo.On("GetMissingPrerequisitesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, []string{"A", "AB", "B"}).Return([]generated.GetMissingPrerequisitesRow{}, nil)
+	suite.mockRepo.On("GetMissingCorequisitesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, mock.Anything, mock.Anything).Return([]generated.GetMissingCorequisitesRow{}, nil)
+	suite.mockRepo.On("GetEnrolledExcludedCoursesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, mock.Anything).Return([]generated.GetEnrolledExcludedCoursesRow{}, nil)
+	suite.expectStudyPlan()
+	suite.expectCreditAllowance()
+	suite.mockRepo.On("CreateEnrollmentTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID, suite.studyPlanID).Return(generated.CourseRegistration{}, nil)
+	suite.expectNotWaitlisted()
+
+	err := suite.useCase.EnrollStudent(suite.ctx, suite.studentID, suite.courseID)
+
+	assert.NoError(suite.T(), err)
+}
+
+// Test enrollment rejected because a co-requisite is neither taken in the semester nor passed
+func (suite *EnrollmentUseCaseTestSuite) TestEnrollStudent_CorequisiteNotMet() {
+	var courseUUID, semesterUUID pgtype.UUID
+	_ = courseUUID.Scan("550e8400-e29b-41d4-a716-446655440003")
+	_ = semesterUUID.Scan("550e8400-e29b-41d4-a716-446655440004")
+	courseOfferingWithCourse := repositories.CourseOfferingWithCourse{
+		CourseID:   courseUUID,
+		SemesterID: semesterUUID,
+		Capacity:   30,
+		CourseOfferingStartTime: pgtype.Timestamptz{
+			Time:  time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC),
+			Valid: true,
+		},
+		Credit: 1,
+	}
+	missingCorequisites := []generated.GetMissingCorequisitesRow{
+		{Code: "IF201", Name: "Basis Data"},
+	}
+
+	suite.mockRepo.On("CheckEnrollmentExistsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID).Return(false, nil)
+	suite.mockRepo.On("GetCourseOfferingWithCourseTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(courseOfferingWithCourse, nil)
+	suite.mockRepo.On("CountCourseOfferingEnrollmentsTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(int64(5), nil)
+	suite.mockRepo.On("GetStudentEnrollmentsWithDetailsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID).Return([]repositories.StudentEnrollmentWithDetails{}, nil)
+	suite.mockRepo.On("GetMissingPrerequisitesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, passingGrades).Return([]generated.GetMissingPrerequisitesRow{}, nil)
+	suite.mockRepo.On("GetMissingCorequisitesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, "550e8400-e29b-41d4-a716-446655440003", "550e8400-e29b-41d4-a716-446655440004", passingGrades).Return(missingCorequisites, nil)
+
+	err := suite.useCase.EnrollStudent(suite.ctx, suite.studentID, suite.courseID)
+
+	assert.Error(suite.T(), err)
+	errorType, ok := GetEnrollmentErrorType(err)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), ErrCorequisiteNotMet, errorType)
+	assert.True(suite.T(), IsBusinessRuleViolation(err))
+	assert.Equal(suite.T(), []string{"IF201 Basis Data"}, err.(*EnrollmentError).Details["missing_courses"])
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateEnrollmentTx")
+}
+
+// Test enrollment rejected because a mutually exclusive course is taken in the same semester
+func (suite *EnrollmentUseCaseTestSuite) TestEnrollStudent_ExcludedCourseConflict() {
+	var courseUUID, semesterUUID pgtype.UUID
+	_ = courseUUID.Scan("550e8400-e29b-41d4-a716-446655440003")
+	_ = semesterUUID.Scan("550e8400-e29b-41d4-a716-446655440004")
+	courseOfferingWithCourse := repositories.CourseOfferingWithCourse{
+		CourseID:   courseUUID,
+		SemesterID: semesterUUID,
+		Capacity:   30,
+		CourseOfferingStartTime: pgtype.Timestamptz{
+			Time:  time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC),
+			Valid: true,
+		},
+		Credit: 3,
+	}
+	excludedCourses := []generated.GetEnrolledExcludedCoursesRow{
+		{Code: "IF305", Name: "Kalkulus Terapan"},
+	}
+
+	suite.mockRepo.On("CheckEnrollmentExistsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID).Return(false, nil)
+	suite.mockRepo.On("GetCourseOfferingWithCourseTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(courseOfferingWithCourse, nil)
+	suite.mockRepo.On("CountCourseOfferingEnrollmentsTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(int64(5), nil)
+	suite.mockRepo.On("GetStudentEnrollmentsWithDetailsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID).Return([]repositories.StudentEnrollmentWithDetails{}, nil)
+	suite.mockRepo.On("GetMissingPrerequisitesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, passingGrades).Return([]generated.GetMissingPrerequisitesRow{}, nil)
+	suite.mockRepo.On("GetMissingCorequisitesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, mock.Anything, passingGrades).Return([]generated.GetMissingCorequisitesRow{}, nil)
+	suite.mockRepo.On("GetEnrolledExcludedCoursesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, "550e8400-e29b-41d4-a716-446655440003", "550e8400-e29b-41d4-a716-446655440004").Return(excludedCourses, nil)
+
+	err := suite.useCase.EnrollStudent(suite.ctx, suite.studentID, suite.courseID)
+
+	assert.Error(suite.T(), err)
+	errorType, ok := GetEnrollmentErrorType(err)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), ErrExcludedCourseConflict, errorType)
+	assert.True(suite.T(), IsBusinessRuleViolation(err))
+	assert.Equal(suite.T(), []string{"IF305 Kalkulus Terapan"}, err.(*EnrollmentError).Details["conflicting_courses"])
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateEnrollmentTx")
+}
+
 // Helper function tests
 func TestCalculateCourseEndTime(t *testing.T) {
 	startTime := time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC)
 
 	// Test 1 credit (50 minutes)
-	endTime := calculateCourseEndTime(startTime, 1)
+	endTime := calculateCourseEndTime(startTime, 1, DefaultMinutesPerCredit)
 	expected := time.Date(2025, 1, 15, 9, 50, 0, 0, time.UTC)
 	assert.Equal(t, expected, endTime)
 
 	// Test 3 credits (150 minutes = 2.5 hours)
-	endTime = calculateCourseEndTime(startTime, 3)
+	endTime = calculateCourseEndTime(startTime, 3, DefaultMinutesPerCredit)
 	expected = time.Date(2025, 1, 15, 11, 30, 0, 0, time.UTC)
 	assert.Equal(t, expected, endTime)
 
 	// Test edge case: 0 credits (should return start time unchanged)
-	endTime = calculateCourseEndTime(startTime, 0)
+	endTime = calculateCourseEndTime(startTime, 0, DefaultMinutesPerCredit)
 	assert.Equal(t, startTime, endTime)
 
 	// Test edge case: negative credits (should return start time unchanged)
-	endTime = calculateCourseEndTime(startTime, -1)
+	endTime = calculateCourseEndTime(startTime, -1, DefaultMinutesPerCredit)
 	assert.Equal(t, startTime, endTime)
 
 	// Test large credit value (6 credits = 300 minutes = 5 hours)
-	endTime = calculateCourseEndTime(startTime, 6)
+	endTime = calculateCourseEndTime(startTime, 6, DefaultMinutesPerCredit)
 	expected = time.Date(2025, 1, 15, 14, 0, 0, 0, time.UTC)
 	assert.Equal(t, expected, endTime)
+
+	// Test practicum course (1 credit * 170 minutes)
+	endTime = calculateCourseEndTime(startTime, 1, 170)
+	expected = time.Date(2025, 1, 15, 11, 50, 0, 0, time.UTC)
+	assert.Equal(t, expected, endTime)
+
+	// Test unset minutes per credit (falls back to 50 minutes)
+	endTime = calculateCourseEndTime(startTime, 2, 0)
+	expected = time.Date(2025, 1, 15, 10, 40, 0, 0, time.UTC)
+	assert.Equal(t, expected, endTime)
 }
 
 func TestHasTimeOverlap(t *testing.T) {
@@ -639,6 +1335,50 @@ func TestHasTimeOverlap(t *testing.T) {
 	assert.True(t, hasTimeOverlap(start1, end1, start9, end9))
 }
 
+func TestFindScheduleOverlap(t *testing.T) {
+	semester := func(start, end time.Time, slots ...weeklySlot) recurringSchedule {
+		return recurringSchedule{firstDay: start, lastDay: end, slots: slots}
+	}
+	spring1 := time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC)
+	spring2 := time.Date(2025, 6, 27, 0, 0, 0, 0, time.UTC)
+
+	// Monday 9:00-11:30 and Monday 10:00-11:40 in the same semester
+	monday := weeklySlot{day: 1, start: 9 * time.Hour, end: 11*time.Hour + 30*time.Minute}
+	mondayLater := weeklySlot{day: 1, start: 10 * time.Hour, end: 11*time.Hour + 40*time.Minute}
+	slot1, slot2, ok := findScheduleOverlap(semester(spring1, spring2, monday), semester(spring1, spring2, mondayLater))
+	assert.True(t, ok)
+	assert.Equal(t, "Monday 09:00-11:30", slot1.String())
+	assert.Equal(t, "Monday 10:00-11:40", slot2.String())
+
+	// Same times on another day of the week
+	tuesday := weeklySlot{day: 2, start: 10 * time.Hour, end: 11*time.Hour + 40*time.Minute}
+	_, _, ok = findScheduleOverlap(semester(spring1, spring2, monday), semester(spring1, spring2, tuesday))
+	assert.False(t, ok)
+
+	// Adjacent slots on the same day
+	mondayAfter := weeklySlot{day: 1, start: 11*time.Hour + 30*time.Minute, end: 13 * time.Hour}
+	_, _, ok = findScheduleOverlap(semester(spring1, spring2, monday), semester(spring1, spring2, mondayAfter))
+	assert.False(t, ok)
+
+	// Same slot in semesters that don't share any day
+	fall1 := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	fall2 := time.Date(2026, 1, 23, 0, 0, 0, 0, time.UTC)
+	_, _, ok = findScheduleOverlap(semester(spring1, spring2, monday), semester(fall1, fall2, monday))
+	assert.False(t, ok)
+
+	// One-off session on a Wednesday within the semester doesn't meet the Monday slot
+	wednesday := time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)
+	session := weeklySlot{day: 3, start: 9 * time.Hour, end: 11 * time.Hour}
+	_, _, ok = findScheduleOverlap(semester(spring1, spring2, monday), semester(wednesday, wednesday, session))
+	assert.False(t, ok)
+
+	// One-off session on a Monday within the semester does
+	mondaySession := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	session = weeklySlot{day: 1, start: 10 * time.Hour, end: 12 * time.Hour}
+	_, _, ok = findScheduleOverlap(semester(spring1, spring2, monday), semester(mondaySession, mondaySession, session))
+	assert.True(t, ok)
+}
+
 func TestConvertPgTimestamp(t *testing.T) {
 	// Test valid timestamp
 	validTime := time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC)
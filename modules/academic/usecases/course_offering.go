@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"siakad-poc/common"
+	"siakad-poc/db/generated"
 	"siakad-poc/db/repositories"
 	"time"
 
@@ -14,29 +15,61 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+// CourseOfferingResponse is a course offering, start_time and end_time bound its first meeting.
+// Every meeting lasts the credits of the course times its minutes per credit.
 type CourseOfferingResponse struct {
-	ID          string    `json:"id"`
-	CourseName  string    `json:"course_name"`
-	CourseCode  string    `json:"course_code"`
-	SectionCode string    `json:"section_code"`
-	Capacity    int32     `json:"capacity"`
-	StartTime   time.Time `json:"start_time"`
+	ID          string                           `json:"id"`
+	CourseName  string                           `json:"course_name"`
+	CourseCode  string                           `json:"course_code"`
+	SectionCode string                           `json:"section_code"`
+	Capacity    int32                            `json:"capacity"`
+	RoomID      *string                          `json:"room_id"`
+	StartTime   time.Time                        `json:"start_time"`
+	EndTime     time.Time                        `json:"end_time"`
+	Schedules   []CourseOfferingScheduleResponse `json:"schedules"`
 }
 
+// CourseOfferingScheduleResponse is a weekly meeting, day is the ISO day of the week (1 is Monday)
+type CourseOfferingScheduleResponse struct {
+	Day       int16  `json:"day"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+}
+
+// CourseOfferingScheduleRequest is a weekly meeting (hari and waktu pelaksanaan), day is the ISO day of the week
+// (1 is Monday) and start_time the time of day as "15:04"
+type CourseOfferingScheduleRequest struct {
+	Day       int16  `json:"day" validate:"required,gte=1,lte=7"`
+	StartTime string `json:"start_time" validate:"required,datetime=15:04"`
+}
+
+// CreateCourseOfferingRequest is the payload to create a course offering, start_time is the first meeting.
+// Without schedules the offering meets weekly on the day and at the time of its start time. The room is optional.
 type CreateCourseOfferingRequest struct {
-	CourseID    string    `json:"course_id" validate:"required"`
-	SemesterID  string    `json:"semester_id" validate:"required"`
-	SectionCode string    `json:"section_code" validate:"required"`
-	Capacity    int32     `json:"capacity" validate:"required,min=1"`
-	StartTime   time.Time `json:"start_time" validate:"required"`
+	CourseID    string                          `json:"course_id" validate:"required"`
+	SemesterID  string                          `json:"semester_id" validate:"required"`
+	SectionCode string                          `json:"section_code" validate:"required"`
+	Capacity    int32                           `json:"capacity" validate:"required,min=1"`
+	StartTime   time.Time                       `json:"start_time" validate:"required"`
+	RoomID      string                          `json:"room_id" validate:"omitempty,uuid"`
+	Schedules   []CourseOfferingScheduleRequest `json:"schedules" validate:"omitempty,unique,dive"`
 }
 
+// UpdateCourseOfferingRequest replaces the offering and its weekly schedules, see CreateCourseOfferingRequest.
 type UpdateCourseOfferingRequest struct {
-	CourseID    string    `json:"course_id" validate:"required"`
-	SemesterID  string    `json:"semester_id" validate:"required"`
-	SectionCode string    `json:"section_code" validate:"required"`
-	Capacity    int32     `json:"capacity" validate:"required,min=1"`
-	StartTime   time.Time `json:"start_time" validate:"required"`
+	CourseID    string                          `json:"course_id" validate:"required"`
+	SemesterID  string                          `json:"semester_id" validate:"required"`
+	SectionCode string                          `json:"section_code" validate:"required"`
+	Capacity    int32                           `json:"capacity" validate:"required,min=1"`
+	StartTime   time.Time                       `json:"start_time" validate:"required"`
+	RoomID      string                          `json:"room_id" validate:"omitempty,uuid"`
+	Schedules   []CourseOfferingScheduleRequest `json:"schedules" validate:"omitempty,unique,dive"`
+}
+
+// WaitlistPromoter fills the free seats of a course offering from its waitlist within a transaction, see
+// CourseEnrollmentUseCase.PromoteWaitlistTx
+type WaitlistPromoter interface {
+	PromoteWaitlistTx(txCtx *common.TxContext, courseOfferingID string) error
 }
 
 type CourseOfferingIDResponse struct {
@@ -44,50 +77,66 @@ type CourseOfferingIDResponse struct {
 }
 
 type CourseOfferingUseCase struct {
-	repo repositories.AcademicRepository
+	repo         repositories.AcademicRepository
+	roomRepo     repositories.RoomRepository
+	lecturerRepo repositories.LecturerRepository
+	calendarRepo repositories.AcademicCalendarRepository
+	waitlist     WaitlistPromoter
+	txExecutor   common.TransactionExecutor
+	policy       TeachingPolicy
 }
 
-func NewCourseOfferingUseCase(repo repositories.AcademicRepository) *CourseOfferingUseCase {
+func NewCourseOfferingUseCase(repo repositories.AcademicRepository, roomRepo repositories.RoomRepository, lecturerRepo repositories.LecturerRepository, calendarRepo repositories.AcademicCalendarRepository, waitlist WaitlistPromoter, txExecutor common.TransactionExecutor, policy TeachingPolicy) *CourseOfferingUseCase {
 	return &CourseOfferingUseCase{
-		repo: repo,
+		repo:         repo,
+		roomRepo:     roomRepo,
+		lecturerRepo: lecturerRepo,
+		calendarRepo: calendarRepo,
+		waitlist:     waitlist,
+		txExecutor:   txExecutor,
+		policy:       policy,
 	}
 }
 
-func (uc *CourseOfferingUseCase) GetCourseOfferingsWithPagination(ctx context.Context, page, pageSize int) ([]CourseOfferingResponse, *common.PaginationMetadata, error) {
+// GetCourseOfferingsWithPagination lists the offerings within the scope, a scoped user only sees
+// offerings of courses that are part of a curriculum of their study program.
+func (uc *CourseOfferingUseCase) GetCourseOfferingsWithPagination(ctx context.Context, scope common.StudyProgramScope, page, pageSize int) ([]CourseOfferingResponse, *common.PaginationMetadata, error) {
 	if page < 1 {
 		page = 1
 	}
 	if pageSize < 1 {
 		pageSize = 10
 	}
+	if !scope.All && scope.StudyProgramID == "" {
+		return nil, nil, ErrNoStudyProgram
+	}
 
 	offset := (page - 1) * pageSize
 
-	courseOfferings, err := uc.repo.GetCourseOfferingsWithPagination(ctx, pageSize, offset)
+	var courseOfferings []repositories.CourseOfferingWithCourse
+	var err error
+	if scope.All {
+		courseOfferings, err = uc.repo.GetCourseOfferingsWithPagination(ctx, pageSize, offset)
+	} else {
+		courseOfferings, err = uc.repo.GetCourseOfferingsByStudyProgramWithPagination(ctx, scope.StudyProgramID, pageSize, offset)
+	}
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "cannot get course offerings")
 	}
 
-	totalRecords, err := uc.repo.CountCourseOfferings(ctx)
+	var totalRecords int64
+	if scope.All {
+		totalRecords, err = uc.repo.CountCourseOfferings(ctx)
+	} else {
+		totalRecords, err = uc.repo.CountCourseOfferingsByStudyProgram(ctx, scope.StudyProgramID)
+	}
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "cannot count course offerings")
 	}
 
 	var responses []CourseOfferingResponse
 	for _, co := range courseOfferings {
-		startTime := time.Time{}
-		if co.CourseOfferingStartTime.Valid {
-			startTime = co.CourseOfferingStartTime.Time
-		}
-
-		responses = append(responses, CourseOfferingResponse{
-			ID:          uuidToString(co.CourseOfferingID),
-			CourseName:  co.CourseName,
-			CourseCode:  co.CourseCode,
-			SectionCode: co.SectionCode,
-			Capacity:    co.Capacity,
-			StartTime:   startTime,
-		})
+		responses = append(responses, toCourseOfferingResponse(co))
 	}
 
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
@@ -102,10 +151,76 @@ func (uc *CourseOfferingUseCase) GetCourseOfferingsWithPagination(ctx context.Co
 	return responses, pagination, nil
 }
 
-func (uc *CourseOfferingUseCase) CreateCourseOffering(ctx context.Context, req CreateCourseOfferingRequest) (CourseOfferingIDResponse, error) {
-	courseOffering, err := uc.repo.CreateCourseOffering(ctx, req.SemesterID, req.CourseID, req.SectionCode, req.Capacity, req.StartTime)
+// GetCourseOffering returns the offering with its weekly schedules, a scoped user only sees offerings of courses
+// that are part of a curriculum of their study program.
+func (uc *CourseOfferingUseCase) GetCourseOffering(ctx context.Context, scope common.StudyProgramScope, id string) (CourseOfferingResponse, error) {
+	if !scope.All && scope.StudyProgramID == "" {
+		return CourseOfferingResponse{}, ErrNoStudyProgram
+	}
+
+	courseOffering, err := uc.repo.GetCourseOfferingByIDWithDetails(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return CourseOfferingResponse{}, ErrOfferingNotFound
+		}
+		return CourseOfferingResponse{}, errors.Wrap(err, "cannot get course offering")
+	}
+
+	err = uc.ensureCourseInScope(ctx, scope, uuidToString(courseOffering.CourseID))
+	if err != nil {
+		return CourseOfferingResponse{}, err
+	}
+
+	return toCourseOfferingResponse(courseOffering), nil
+}
+
+// CreateCourseOffering only opens offerings for courses that are part of an active curriculum, starting within the
+// dates of their semester. The room must seat the offering capacity and must not be used by another offering at the
+// same time.
+func (uc *CourseOfferingUseCase) CreateCourseOffering(ctx context.Context, scope common.StudyProgramScope, req CreateCourseOfferingRequest) (CourseOfferingIDResponse, error) {
+	err := uc.ensureCourseInScope(ctx, scope, req.CourseID)
+	if err != nil {
+		return CourseOfferingIDResponse{}, err
+	}
+
+	inActiveCurriculum, err := uc.repo.IsCourseInActiveCurriculum(ctx, req.CourseID)
+	if err != nil {
+		return CourseOfferingIDResponse{}, errors.Wrap(err, "cannot check course curriculum")
+	}
+	if !inActiveCurriculum {
+		return CourseOfferingIDResponse{}, ErrCourseNotInActiveCurriculum
+	}
+
+	err = uc.ensureStartsWithinSemester(ctx, req.SemesterID, req.StartTime)
 	if err != nil {
-		return CourseOfferingIDResponse{}, errors.Wrap(err, "cannot create course offering")
+		return CourseOfferingIDResponse{}, err
+	}
+
+	err = uc.ensureRoomSeatsOffering(ctx, req.RoomID, req.Capacity)
+	if err != nil {
+		return CourseOfferingIDResponse{}, err
+	}
+
+	schedules, err := toCourseOfferingScheduleAttributes(req.Schedules, req.StartTime, uc.policy.CampusLocation)
+	if err != nil {
+		return CourseOfferingIDResponse{}, err
+	}
+
+	var courseOffering generated.CourseOffering
+	err = uc.txExecutor.WithTxContext(ctx, func(txCtx *common.TxContext) error {
+		courseOffering, err = uc.repo.CreateCourseOfferingTx(txCtx, req.SemesterID, req.CourseID, req.SectionCode, req.Capacity, req.StartTime, req.RoomID)
+		if err != nil {
+			return errors.Wrap(err, "cannot create course offering")
+		}
+
+		err = uc.repo.ReplaceCourseOfferingSchedulesTx(txCtx, uuidToString(courseOffering.ID), schedules)
+		if err != nil {
+			return errors.Wrap(err, "cannot save course offering schedules")
+		}
+		return uc.ensureRoomAvailableTx(txCtx, uuidToString(courseOffering.ID), req.RoomID)
+	})
+	if err != nil {
+		return CourseOfferingIDResponse{}, err
 	}
 
 	return CourseOfferingIDResponse{
@@ -113,13 +228,71 @@ func (uc *CourseOfferingUseCase) CreateCourseOffering(ctx context.Context, req C
 	}, nil
 }
 
-func (uc *CourseOfferingUseCase) UpdateCourseOffering(ctx context.Context, id string, req UpdateCourseOfferingRequest) (CourseOfferingIDResponse, error) {
-	courseOffering, err := uc.repo.UpdateCourseOffering(ctx, id, req.SemesterID, req.CourseID, req.SectionCode, req.Capacity, req.StartTime)
+// UpdateCourseOffering requires both the current and the new course of the offering to be within the scope.
+// The semester dates and the room are checked like in CreateCourseOffering, the lecturers teaching the offering must still be free at its
+// new time and within their teaching load. Seats added by a larger capacity go to the waitlist.
+func (uc *CourseOfferingUseCase) UpdateCourseOffering(ctx context.Context, scope common.StudyProgramScope, id string, req UpdateCourseOfferingRequest) (CourseOfferingIDResponse, error) {
+	err := uc.ensureCourseOfferingInScope(ctx, scope, id)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return CourseOfferingIDResponse{}, errors.New("course offering not found")
+		return CourseOfferingIDResponse{}, err
+	}
+	err = uc.ensureCourseInScope(ctx, scope, req.CourseID)
+	if err != nil {
+		return CourseOfferingIDResponse{}, err
+	}
+
+	err = uc.ensureStartsWithinSemester(ctx, req.SemesterID, req.StartTime)
+	if err != nil {
+		return CourseOfferingIDResponse{}, err
+	}
+
+	err = uc.ensureRoomSeatsOffering(ctx, req.RoomID, req.Capacity)
+	if err != nil {
+		return CourseOfferingIDResponse{}, err
+	}
+
+	schedules, err := toCourseOfferingScheduleAttributes(req.Schedules, req.StartTime, uc.policy.CampusLocation)
+	if err != nil {
+		return CourseOfferingIDResponse{}, err
+	}
+
+	lecturers, err := uc.repo.ListCourseOfferingLecturers(ctx, id)
+	if err != nil {
+		return CourseOfferingIDResponse{}, errors.Wrap(err, "cannot get course offering lecturers")
+	}
+	assignedLecturers := toAssignedLecturers(lecturers)
+
+	var courseOffering generated.CourseOffering
+	err = uc.txExecutor.WithTxContext(ctx, func(txCtx *common.TxContext) error {
+		err = uc.lockLecturersTx(txCtx, assignedLecturers)
+		if err != nil {
+			return err
+		}
+
+		courseOffering, err = uc.repo.UpdateCourseOfferingTx(txCtx, id, req.SemesterID, req.CourseID, req.SectionCode, req.Capacity, req.StartTime, req.RoomID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrOfferingNotFound
+			}
+			return errors.Wrap(err, "cannot update course offering")
+		}
+
+		err = uc.repo.ReplaceCourseOfferingSchedulesTx(txCtx, id, schedules)
+		if err != nil {
+			return errors.Wrap(err, "cannot save course offering schedules")
 		}
-		return CourseOfferingIDResponse{}, errors.Wrap(err, "cannot update course offering")
+		err = uc.ensureRoomAvailableTx(txCtx, id, req.RoomID)
+		if err != nil {
+			return err
+		}
+		err = uc.ensureLecturersAvailableTx(txCtx, id, assignedLecturers)
+		if err != nil {
+			return err
+		}
+		return uc.waitlist.PromoteWaitlistTx(txCtx, id)
+	})
+	if err != nil {
+		return CourseOfferingIDResponse{}, err
 	}
 
 	return CourseOfferingIDResponse{
@@ -127,17 +300,207 @@ func (uc *CourseOfferingUseCase) UpdateCourseOffering(ctx context.Context, id st
 	}, nil
 }
 
-func (uc *CourseOfferingUseCase) DeleteCourseOffering(ctx context.Context, id string) error {
-	_, err := uc.repo.DeleteCourseOffering(ctx, id)
+func (uc *CourseOfferingUseCase) DeleteCourseOffering(ctx context.Context, scope common.StudyProgramScope, id string) error {
+	err := uc.ensureCourseOfferingInScope(ctx, scope, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = uc.repo.DeleteCourseOffering(ctx, id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return errors.New("course offering not found")
+			return ErrOfferingNotFound
 		}
 		return errors.Wrap(err, "cannot delete course offering")
 	}
 	return nil
 }
 
+func (uc *CourseOfferingUseCase) ensureCourseInScope(ctx context.Context, scope common.StudyProgramScope, courseID string) error {
+	if scope.All {
+		return nil
+	}
+	if scope.StudyProgramID == "" {
+		return ErrNoStudyProgram
+	}
+
+	inScope, err := uc.repo.IsCourseInStudyProgram(ctx, courseID, scope.StudyProgramID)
+	if err != nil {
+		return errors.Wrap(err, "cannot check course study program")
+	}
+	if !inScope {
+		return ErrCourseOutOfScope
+	}
+	return nil
+}
+
+func (uc *CourseOfferingUseCase) ensureCourseOfferingInScope(ctx context.Context, scope common.StudyProgramScope, id string) error {
+	if scope.All {
+		return nil
+	}
+
+	courseOffering, err := uc.repo.GetCourseOfferingByIDWithDetails(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrOfferingNotFound
+		}
+		return errors.Wrap(err, "cannot get course offering")
+	}
+
+	return uc.ensureCourseInScope(ctx, scope, uuidToString(courseOffering.CourseID))
+}
+
+// ensureStartsWithinSemester checks the semester exists and the first meeting of the offering falls within its
+// dates, the semester end is exclusive.
+func (uc *CourseOfferingUseCase) ensureStartsWithinSemester(ctx context.Context, semesterID string, startTime time.Time) error {
+	semester, err := uc.calendarRepo.GetSemester(ctx, semesterID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrUnknownSemester
+		}
+		return errors.Wrap(err, "cannot get semester")
+	}
+	if startTime.Before(semester.StartTime.Time) || !startTime.Before(semester.EndTime.Time) {
+		return errors.Wrapf(ErrOfferingOutsideSemester, "semester %s runs from %s until %s", semester.Code,
+			semester.StartTime.Time.Format(time.RFC3339), semester.EndTime.Time.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// ensureRoomSeatsOffering checks the room exists and has at least as many seats as the offering, an empty room id
+// means no room.
+func (uc *CourseOfferingUseCase) ensureRoomSeatsOffering(ctx context.Context, roomID string, capacity int32) error {
+	if roomID == "" {
+		return nil
+	}
+
+	room, err := uc.roomRepo.GetRoom(ctx, roomID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrUnknownRoom
+		}
+		return errors.Wrap(err, "cannot get room")
+	}
+	if room.Capacity < capacity {
+		return errors.Wrapf(ErrRoomCapacityTooSmall, "room %s seats %d", room.Code, room.Capacity)
+	}
+
+	return nil
+}
+
+// ensureRoomAvailableTx rejects the room when another offering held there meets at the same time. The offering
+// and its weekly schedules must already be saved in the transaction, they are compared like enrollments are.
+// The room row is locked first, so two offerings assigned to the room concurrently can't both miss each other.
+func (uc *CourseOfferingUseCase) ensureRoomAvailableTx(txCtx *common.TxContext, id, roomID string) error {
+	if roomID == "" {
+		return nil
+	}
+
+	_, err := uc.roomRepo.GetRoomForUpdateTx(txCtx, roomID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrUnknownRoom
+		}
+		return errors.Wrap(err, "cannot lock room")
+	}
+
+	courseOffering, err := uc.repo.GetCourseOfferingWithCourseTx(txCtx, id)
+	if err != nil {
+		return errors.Wrap(err, "cannot get course offering")
+	}
+	schedule, err := newOfferingSchedule(courseOffering.CourseOfferingStartTime, courseOffering.SemesterStartTime,
+		courseOffering.SemesterEndTime, courseOffering.Schedules, classDuration(courseOffering.Credit, courseOffering.MinutesPerCredit), uc.policy.CampusLocation)
+	if err != nil {
+		return err
+	}
+
+	roomOfferings, err := uc.repo.GetCourseOfferingsByRoomTx(txCtx, roomID)
+	if err != nil {
+		return errors.Wrap(err, "cannot get course offerings of the room")
+	}
+	for _, other := range roomOfferings {
+		if other.CourseOfferingID == courseOffering.CourseOfferingID {
+			continue
+		}
+
+		otherSchedule, err := newOfferingSchedule(other.CourseOfferingStartTime, other.SemesterStartTime,
+			other.SemesterEndTime, other.Schedules, classDuration(other.Credit, other.MinutesPerCredit), uc.policy.CampusLocation)
+		if err != nil {
+			return err
+		}
+		if _, otherSlot, overlap := findScheduleOverlap(schedule, otherSchedule); overlap {
+			return errors.Wrapf(ErrRoomDoubleBooked, "%s section %s meets %s", other.CourseCode, other.SectionCode, otherSlot)
+		}
+	}
+
+	return nil
+}
+
+// toCourseOfferingScheduleAttributes parses the weekly schedules of the request, without schedules the offering
+// meets weekly on the day and at the time of its start time on the campus clock, whatever offset the client sent.
+func toCourseOfferingScheduleAttributes(schedules []CourseOfferingScheduleRequest, startTime time.Time, loc *time.Location) ([]repositories.CourseOfferingScheduleAttributes, error) {
+	if len(schedules) == 0 {
+		startTime = inLocation(startTime, loc)
+		return []repositories.CourseOfferingScheduleAttributes{{
+			DayOfWeek: int16(isoDay(startTime)),
+			StartTime: startTime.Sub(startOfDay(startTime)),
+		}}, nil
+	}
+
+	attributes := make([]repositories.CourseOfferingScheduleAttributes, 0, len(schedules))
+	for _, schedule := range schedules {
+		timeOfDay, err := time.Parse("15:04", schedule.StartTime)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot parse schedule start time")
+		}
+
+		attributes = append(attributes, repositories.CourseOfferingScheduleAttributes{
+			DayOfWeek: schedule.Day,
+			StartTime: timeOfDay.Sub(startOfDay(timeOfDay)),
+		})
+	}
+
+	return attributes, nil
+}
+
+func toCourseOfferingResponse(co repositories.CourseOfferingWithCourse) CourseOfferingResponse {
+	duration := classDuration(co.Credit, co.MinutesPerCredit)
+
+	response := CourseOfferingResponse{
+		ID:          uuidToString(co.CourseOfferingID),
+		CourseName:  co.CourseName,
+		CourseCode:  co.CourseCode,
+		SectionCode: co.SectionCode,
+		Capacity:    co.Capacity,
+		Schedules:   toCourseOfferingScheduleResponses(co.Schedules, duration),
+	}
+	if co.RoomID.Valid {
+		roomID := uuidToString(co.RoomID)
+		response.RoomID = &roomID
+	}
+	if co.CourseOfferingStartTime.Valid {
+		response.StartTime = co.CourseOfferingStartTime.Time
+		response.EndTime = calculateCourseEndTime(co.CourseOfferingStartTime.Time, co.Credit, co.MinutesPerCredit)
+	}
+
+	return response
+}
+
+// toCourseOfferingScheduleResponses formats the weekly schedules, every meeting lasting the duration
+func toCourseOfferingScheduleResponses(schedules []generated.CourseOfferingSchedule, duration time.Duration) []CourseOfferingScheduleResponse {
+	responses := make([]CourseOfferingScheduleResponse, 0, len(schedules))
+	for _, schedule := range schedules {
+		startTime := time.Duration(schedule.StartTime.Microseconds) * time.Microsecond
+		responses = append(responses, CourseOfferingScheduleResponse{
+			Day:       schedule.DayOfWeek,
+			StartTime: formatTimeOfDay(startTime),
+			EndTime:   formatTimeOfDay(startTime + duration),
+		})
+	}
+	return responses
+}
+
 func uuidToString(uuid pgtype.UUID) string {
 	if !uuid.Valid {
 		return ""
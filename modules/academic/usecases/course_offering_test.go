@@ -51,8 +51,8 @@ func (m *MockCourseOfferingRepository) CheckEnrollmentExists(ctx context.Context
 	return args.Get(0).(bool), args.Error(1)
 }
 
-func (m *MockCourseOfferingRepository) CreateEnrollment(ctx context.Context, studentID, courseOfferingID string) (generated.CourseRegistration, error) {
-	args := m.Called(ctx, studentID, courseOfferingID)
+func (m *MockCourseOfferingRepository) CreateEnrollment(ctx context.Context, studentID, courseOfferingID, studyPlanID string) (generated.CourseRegistration, error) {
+	args := m.Called(ctx, studentID, courseOfferingID, studyPlanID)
 	return args.Get(0).(generated.CourseRegistration), args.Error(1)
 }
 
@@ -66,13 +66,13 @@ func (m *MockCourseOfferingRepository) CountCourseOfferings(ctx context.Context)
 	return args.Get(0).(int64), args.Error(1)
 }
 
-func (m *MockCourseOfferingRepository) CreateCourseOffering(ctx context.Context, semesterID, courseID, sectionCode string, capacity int32, startTime time.Time) (generated.CourseOffering, error) {
-	args := m.Called(ctx, semesterID, courseID, sectionCode, capacity, startTime)
+func (m *MockCourseOfferingRepository) CreateCourseOfferingTx(txCtx *common.TxContext, semesterID, courseID, sectionCode string, capacity int32, startTime time.Time, roomID string) (generated.CourseOffering, error) {
+	args := m.Called(txCtx, semesterID, courseID, sectionCode, capacity, startTime, roomID)
 	return args.Get(0).(generated.CourseOffering), args.Error(1)
 }
 
-func (m *MockCourseOfferingRepository) UpdateCourseOffering(ctx context.Context, id, semesterID, courseID, sectionCode string, capacity int32, startTime time.Time) (generated.CourseOffering, error) {
-	args := m.Called(ctx, id, semesterID, courseID, sectionCode, capacity, startTime)
+func (m *MockCourseOfferingRepository) UpdateCourseOfferingTx(txCtx *common.TxContext, id, semesterID, courseID, sectionCode string, capacity int32, startTime time.Time, roomID string) (generated.CourseOffering, error) {
+	args := m.Called(txCtx, id, semesterID, courseID, sectionCode, capacity, startTime, roomID)
 	return args.Get(0).(generated.CourseOffering), args.Error(1)
 }
 
@@ -86,6 +86,26 @@ func (m *MockCourseOfferingRepository) GetCourseOfferingByIDWithDetails(ctx cont
 	return args.Get(0).(repositories.CourseOfferingWithCourse), args.Error(1)
 }
 
+func (m *MockCourseOfferingRepository) GetCourseOfferingsByStudyProgramWithPagination(ctx context.Context, studyProgramID string, limit, offset int) ([]repositories.CourseOfferingWithCourse, error) {
+	args := m.Called(ctx, studyProgramID, limit, offset)
+	return args.Get(0).([]repositories.CourseOfferingWithCourse), args.Error(1)
+}
+
+func (m *MockCourseOfferingRepository) CountCourseOfferingsByStudyProgram(ctx context.Context, studyProgramID string) (int64, error) {
+	args := m.Called(ctx, studyProgramID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCourseOfferingRepository) IsCourseInStudyProgram(ctx context.Context, courseID, studyProgramID string) (bool, error) {
+	args := m.Called(ctx, courseID, studyProgramID)
+	return args.Get(0).(bool), args.Error(1)
+}
+
+func (m *MockCourseOfferingRepository) IsCourseInActiveCurriculum(ctx context.Context, courseID string) (bool, error) {
+	args := m.Called(ctx, courseID)
+	return args.Get(0).(bool), args.Error(1)
+}
+
 // Transaction-aware methods (required by interface)
 func (m *MockCourseOfferingRepository) GetCourseOfferingWithCourseTx(txCtx *common.TxContext, id string) (repositories.CourseOfferingWithCourse, error) {
 	args := m.Called(txCtx, id)
@@ -107,26 +127,109 @@ func (m *MockCourseOfferingRepository) CheckEnrollmentExistsTx(txCtx *common.TxC
 	return args.Get(0).(bool), args.Error(1)
 }
 
-func (m *MockCourseOfferingRepository) CreateEnrollmentTx(txCtx *common.TxContext, studentID, courseOfferingID string) (generated.CourseRegistration, error) {
+func (m *MockCourseOfferingRepository) CreateEnrollmentTx(txCtx *common.TxContext, studentID, courseOfferingID, studyPlanID string) (generated.CourseRegistration, error) {
+	args := m.Called(txCtx, studentID, courseOfferingID, studyPlanID)
+	return args.Get(0).(generated.CourseRegistration), args.Error(1)
+}
+
+func (m *MockCourseOfferingRepository) GetEnrollmentTx(txCtx *common.TxContext, studentID, courseOfferingID string) (generated.CourseRegistration, error) {
 	args := m.Called(txCtx, studentID, courseOfferingID)
 	return args.Get(0).(generated.CourseRegistration), args.Error(1)
 }
 
+func (m *MockCourseOfferingRepository) DropEnrollmentTx(txCtx *common.TxContext, id, droppedBy, reason string) (generated.CourseRegistration, error) {
+	args := m.Called(txCtx, id, droppedBy, reason)
+	return args.Get(0).(generated.CourseRegistration), args.Error(1)
+}
+
+func (m *MockCourseOfferingRepository) GetMissingPrerequisitesTx(txCtx *common.TxContext, studentID, courseID string, passingGrades []string) ([]generated.GetMissingPrerequisitesRow, error) {
+	args := m.Called(txCtx, studentID, courseID, passingGrades)
+	return args.Get(0).([]generated.GetMissingPrerequisitesRow), args.Error(1)
+}
+
+func (m *MockCourseOfferingRepository) GetMissingCorequisitesTx(txCtx *common.TxContext, studentID, courseID, semesterID string, passingGrades []string) ([]generated.GetMissingCorequisitesRow, error) {
+	args := m.Called(txCtx, studentID, courseID, semesterID, passingGrades)
+	return args.Get(0).([]generated.GetMissingCorequisitesRow), args.Error(1)
+}
+
+func (m *MockCourseOfferingRepository) GetEnrolledExcludedCoursesTx(txCtx *common.TxContext, studentID, courseID, semesterID string) ([]generated.GetEnrolledExcludedCoursesRow, error) {
+	args := m.Called(txCtx, studentID, courseID, semesterID)
+	return args.Get(0).([]generated.GetEnrolledExcludedCoursesRow), args.Error(1)
+}
+
+func (m *MockCourseOfferingRepository) ReplaceCourseOfferingSchedulesTx(txCtx *common.TxContext, courseOfferingID string, schedules []repositories.CourseOfferingScheduleAttributes) error {
+	args := m.Called(txCtx, courseOfferingID, schedules)
+	return args.Error(0)
+}
+
+func (m *MockCourseOfferingRepository) GetCourseOfferingsByRoomTx(txCtx *common.TxContext, roomID string) ([]repositories.CourseOfferingWithCourse, error) {
+	args := m.Called(txCtx, roomID)
+	return args.Get(0).([]repositories.CourseOfferingWithCourse), args.Error(1)
+}
+
+func (m *MockCourseOfferingRepository) ListCourseOfferingLecturers(ctx context.Context, courseOfferingID string) ([]generated.ListCourseOfferingLecturersRow, error) {
+	args := m.Called(ctx, courseOfferingID)
+	return args.Get(0).([]generated.ListCourseOfferingLecturersRow), args.Error(1)
+}
+
+func (m *MockCourseOfferingRepository) DeleteCourseOfferingLecturer(ctx context.Context, courseOfferingID, lecturerID string) (generated.CourseOfferingLecturer, error) {
+	args := m.Called(ctx, courseOfferingID, lecturerID)
+	return args.Get(0).(generated.CourseOfferingLecturer), args.Error(1)
+}
+
+func (m *MockCourseOfferingRepository) GetCourseOfferingsByLecturer(ctx context.Context, lecturerID, semesterID string) ([]repositories.CourseOfferingWithCourse, error) {
+	args := m.Called(ctx, lecturerID, semesterID)
+	return args.Get(0).([]repositories.CourseOfferingWithCourse), args.Error(1)
+}
+
+func (m *MockCourseOfferingRepository) ListCourseOfferingLecturersTx(txCtx *common.TxContext, courseOfferingID string) ([]generated.ListCourseOfferingLecturersRow, error) {
+	args := m.Called(txCtx, courseOfferingID)
+	return args.Get(0).([]generated.ListCourseOfferingLecturersRow), args.Error(1)
+}
+
+func (m *MockCourseOfferingRepository) CreateCourseOfferingLecturerTx(txCtx *common.TxContext, id, courseOfferingID, lecturerID string) (generated.CourseOfferingLecturer, error) {
+	args := m.Called(txCtx, id, courseOfferingID, lecturerID)
+	return args.Get(0).(generated.CourseOfferingLecturer), args.Error(1)
+}
+
+func (m *MockCourseOfferingRepository) GetCourseOfferingsByLecturerTx(txCtx *common.TxContext, lecturerID, semesterID string) ([]repositories.CourseOfferingWithCourse, error) {
+	args := m.Called(txCtx, lecturerID, semesterID)
+	return args.Get(0).([]repositories.CourseOfferingWithCourse), args.Error(1)
+}
+
+// MockWaitlistPromoter is a mock implementation of WaitlistPromoter
+type MockWaitlistPromoter struct {
+	mock.Mock
+}
+
+func (m *MockWaitlistPromoter) PromoteWaitlistTx(txCtx *common.TxContext, courseOfferingID string) error {
+	args := m.Called(txCtx, courseOfferingID)
+	return args.Error(0)
+}
+
 // Test Suite
 type CourseOfferingUseCaseTestSuite struct {
 	suite.Suite
-	useCase         *CourseOfferingUseCase
-	mockRepo        *MockCourseOfferingRepository
-	ctx             context.Context
-	testTime        time.Time
-	courseOfferUUID pgtype.UUID
-	semesterUUID    pgtype.UUID
-	courseUUID      pgtype.UUID
+	useCase          *CourseOfferingUseCase
+	mockRepo         *MockCourseOfferingRepository
+	mockRoomRepo     *MockRoomRepository
+	mockLecturerRepo *MockLecturerRepository
+	mockCalendarRepo *MockAcademicCalendarRepository
+	mockWaitlist     *MockWaitlistPromoter
+	ctx              context.Context
+	testTime         time.Time
+	courseOfferUUID  pgtype.UUID
+	semesterUUID     pgtype.UUID
+	courseUUID       pgtype.UUID
 }
 
 func (suite *CourseOfferingUseCaseTestSuite) SetupTest() {
 	suite.mockRepo = new(MockCourseOfferingRepository)
-	suite.useCase = NewCourseOfferingUseCase(suite.mockRepo)
+	suite.mockRoomRepo = new(MockRoomRepository)
+	suite.mockLecturerRepo = new(MockLecturerRepository)
+	suite.mockCalendarRepo = new(MockAcademicCalendarRepository)
+	suite.mockWaitlist = new(MockWaitlistPromoter)
+	suite.useCase = NewCourseOfferingUseCase(suite.mockRepo, suite.mockRoomRepo, suite.mockLecturerRepo, suite.mockCalendarRepo, suite.mockWaitlist, new(common.MockTransactionExecutor), TeachingPolicy{MaxTeachingCredits: 12})
 	suite.ctx = context.Background()
 	suite.testTime = time.Now()
 
@@ -147,6 +250,19 @@ func (suite *CourseOfferingUseCaseTestSuite) SetupTest() {
 
 func (suite *CourseOfferingUseCaseTestSuite) TearDownTest() {
 	suite.mockRepo.AssertExpectations(suite.T())
+	suite.mockRoomRepo.AssertExpectations(suite.T())
+	suite.mockLecturerRepo.AssertExpectations(suite.T())
+	suite.mockCalendarRepo.AssertExpectations(suite.T())
+	suite.mockWaitlist.AssertExpectations(suite.T())
+}
+
+// expectSemester returns a semester running from a month before until four months after the start time
+func (suite *CourseOfferingUseCaseTestSuite) expectSemester(semesterID string, startTime time.Time) {
+	suite.mockCalendarRepo.On("GetSemester", suite.ctx, semesterID).Return(generated.Semester{
+		Code:      "2024-2",
+		StartTime: pgtype.Timestamptz{Time: startTime.AddDate(0, -1, 0), Valid: true},
+		EndTime:   pgtype.Timestamptz{Time: startTime.AddDate(0, 4, 0), Valid: true},
+	}, nil)
 }
 
 // Test successful pagination
@@ -168,13 +284,16 @@ func (suite *CourseOfferingUseCaseTestSuite) TestGetCourseOfferingsWithPaginatio
 			CourseCode:              "CS101",
 			CourseName:              "Introduction to Computer Science",
 			Credit:                  3,
+			Schedules: []generated.CourseOfferingSchedule{
+				{DayOfWeek: 2, StartTime: pgtype.Time{Microseconds: int64(13 * time.Hour / time.Microsecond), Valid: true}},
+			},
 		},
 	}
 
 	suite.mockRepo.On("GetCourseOfferingsWithPagination", suite.ctx, limit, offset).Return(mockCourseOfferings, nil)
 	suite.mockRepo.On("CountCourseOfferings", suite.ctx).Return(totalRecords, nil)
 
-	results, pagination, err := suite.useCase.GetCourseOfferingsWithPagination(suite.ctx, page, pageSize)
+	results, pagination, err := suite.useCase.GetCourseOfferingsWithPagination(suite.ctx, common.GlobalStudyProgramScope(), page, pageSize)
 
 	assert.NoError(suite.T(), err)
 	assert.Len(suite.T(), results, 1)
@@ -183,6 +302,8 @@ func (suite *CourseOfferingUseCaseTestSuite) TestGetCourseOfferingsWithPaginatio
 	assert.Equal(suite.T(), "A1", results[0].SectionCode)
 	assert.Equal(suite.T(), int32(30), results[0].Capacity)
 	assert.Equal(suite.T(), suite.testTime, results[0].StartTime)
+	assert.Equal(suite.T(), suite.testTime.Add(150*time.Minute), results[0].EndTime)
+	assert.Equal(suite.T(), []CourseOfferingScheduleResponse{{Day: 2, StartTime: "13:00", EndTime: "15:30"}}, results[0].Schedules)
 
 	assert.NotNil(suite.T(), pagination)
 	assert.Equal(suite.T(), page, pagination.Page)
@@ -202,7 +323,7 @@ func (suite *CourseOfferingUseCaseTestSuite) TestGetCourseOfferingsWithPaginatio
 	suite.mockRepo.On("GetCourseOfferingsWithPagination", suite.ctx, expectedLimit, expectedOffset).Return([]repositories.CourseOfferingWithCourse{}, nil)
 	suite.mockRepo.On("CountCourseOfferings", suite.ctx).Return(totalRecords, nil)
 
-	results, pagination, err := suite.useCase.GetCourseOfferingsWithPagination(suite.ctx, page, pageSize)
+	results, pagination, err := suite.useCase.GetCourseOfferingsWithPagination(suite.ctx, common.GlobalStudyProgramScope(), page, pageSize)
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), pagination)
@@ -219,7 +340,7 @@ func (suite *CourseOfferingUseCaseTestSuite) TestGetCourseOfferingsWithPaginatio
 
 	suite.mockRepo.On("GetCourseOfferingsWithPagination", suite.ctx, pageSize, 0).Return([]repositories.CourseOfferingWithCourse{}, expectedError)
 
-	results, pagination, err := suite.useCase.GetCourseOfferingsWithPagination(suite.ctx, page, pageSize)
+	results, pagination, err := suite.useCase.GetCourseOfferingsWithPagination(suite.ctx, common.GlobalStudyProgramScope(), page, pageSize)
 
 	assert.Error(suite.T(), err)
 	assert.Contains(suite.T(), err.Error(), "database connection error")
@@ -227,6 +348,45 @@ func (suite *CourseOfferingUseCaseTestSuite) TestGetCourseOfferingsWithPaginatio
 	assert.Nil(suite.T(), pagination)
 }
 
+// Test getting a course offering of a course with its own class time per credit
+func (suite *CourseOfferingUseCaseTestSuite) TestGetCourseOffering_MinutesPerCredit() {
+	id := "course-offer-123"
+	startTime := time.Date(2025, 2, 4, 13, 0, 0, 0, time.UTC)
+
+	suite.mockRepo.On("GetCourseOfferingByIDWithDetails", suite.ctx, id).Return(repositories.CourseOfferingWithCourse{
+		CourseOfferingID:        suite.courseOfferUUID,
+		CourseID:                suite.courseUUID,
+		SectionCode:             "P1",
+		Capacity:                20,
+		CourseOfferingStartTime: pgtype.Timestamptz{Time: startTime, Valid: true},
+		CourseCode:              "CS101P",
+		CourseName:              "Introduction to Computer Science Practicum",
+		Credit:                  1,
+		MinutesPerCredit:        170,
+		Schedules: []generated.CourseOfferingSchedule{
+			{DayOfWeek: 2, StartTime: pgtype.Time{Microseconds: int64(13 * time.Hour / time.Microsecond), Valid: true}},
+		},
+	}, nil)
+
+	response, err := suite.useCase.GetCourseOffering(suite.ctx, common.GlobalStudyProgramScope(), id)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), startTime, response.StartTime)
+	assert.Equal(suite.T(), time.Date(2025, 2, 4, 15, 50, 0, 0, time.UTC), response.EndTime)
+	assert.Equal(suite.T(), []CourseOfferingScheduleResponse{{Day: 2, StartTime: "13:00", EndTime: "15:50"}}, response.Schedules)
+}
+
+// Test getting a course offering that doesn't exist
+func (suite *CourseOfferingUseCaseTestSuite) TestGetCourseOffering_NotFound() {
+	id := "course-offer-123"
+
+	suite.mockRepo.On("GetCourseOfferingByIDWithDetails", suite.ctx, id).Return(repositories.CourseOfferingWithCourse{}, pgx.ErrNoRows)
+
+	_, err := suite.useCase.GetCourseOffering(suite.ctx, common.GlobalStudyProgramScope(), id)
+
+	assert.ErrorIs(suite.T(), err, ErrOfferingNotFound)
+}
+
 // Test successful course offering creation
 func (suite *CourseOfferingUseCaseTestSuite) TestCreateCourseOffering_Success() {
 	req := CreateCourseOfferingRequest{
@@ -241,14 +401,118 @@ func (suite *CourseOfferingUseCaseTestSuite) TestCreateCourseOffering_Success()
 		ID: suite.courseOfferUUID,
 	}
 
-	suite.mockRepo.On("CreateCourseOffering", suite.ctx, req.SemesterID, req.CourseID, req.SectionCode, req.Capacity, req.StartTime).Return(expectedCourseOffering, nil)
+	suite.expectSemester(req.SemesterID, req.StartTime)
+	suite.mockRepo.On("IsCourseInActiveCurriculum", suite.ctx, req.CourseID).Return(true, nil)
+	suite.mockRepo.On("CreateCourseOfferingTx", mock.AnythingOfType("*common.TxContext"), req.SemesterID, req.CourseID, req.SectionCode, req.Capacity, req.StartTime, req.RoomID).Return(expectedCourseOffering, nil)
+	suite.mockRepo.On("ReplaceCourseOfferingSchedulesTx", mock.AnythingOfType("*common.TxContext"), mock.AnythingOfType("string"), mock.Anything).Return(nil)
 
-	response, err := suite.useCase.CreateCourseOffering(suite.ctx, req)
+	response, err := suite.useCase.CreateCourseOffering(suite.ctx, common.GlobalStudyProgramScope(), req)
 
 	assert.NoError(suite.T(), err)
 	assert.NotEmpty(suite.T(), response.ID)
 }
 
+// Test creating a course offering with weekly schedules
+func (suite *CourseOfferingUseCaseTestSuite) TestCreateCourseOffering_WithSchedules() {
+	req := CreateCourseOfferingRequest{
+		CourseID:    "course-123",
+		SemesterID:  "semester-456",
+		SectionCode: "A1",
+		Capacity:    30,
+		StartTime:   time.Date(2025, 2, 3, 9, 0, 0, 0, time.UTC),
+		Schedules: []CourseOfferingScheduleRequest{
+			{Day: 1, StartTime: "09:00"},
+			{Day: 4, StartTime: "13:30"},
+		},
+	}
+
+	expectedSchedules := []repositories.CourseOfferingScheduleAttributes{
+		{DayOfWeek: 1, StartTime: 9 * time.Hour},
+		{DayOfWeek: 4, StartTime: 13*time.Hour + 30*time.Minute},
+	}
+
+	suite.expectSemester(req.SemesterID, req.StartTime)
+	suite.mockRepo.On("IsCourseInActiveCurriculum", suite.ctx, req.CourseID).Return(true, nil)
+	suite.mockRepo.On("CreateCourseOfferingTx", mock.AnythingOfType("*common.TxContext"), req.SemesterID, req.CourseID, req.SectionCode, req.Capacity, req.StartTime, req.RoomID).Return(generated.CourseOffering{ID: suite.courseOfferUUID}, nil)
+	suite.mockRepo.On("ReplaceCourseOfferingSchedulesTx", mock.AnythingOfType("*common.TxContext"), uuidToString(suite.courseOfferUUID), expectedSchedules).Return(nil)
+
+	response, err := suite.useCase.CreateCourseOffering(suite.ctx, common.GlobalStudyProgramScope(), req)
+
+	assert.NoError(suite.T(), err)
+	assert.NotEmpty(suite.T(), response.ID)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// Test the weekly schedule derived from the start time
+func (suite *CourseOfferingUseCaseTestSuite) TestCreateCourseOffering_DefaultSchedule() {
+	req := CreateCourseOfferingRequest{
+		CourseID:    "course-123",
+		SemesterID:  "semester-456",
+		SectionCode: "A1",
+		Capacity:    30,
+		// Wednesday
+		StartTime: time.Date(2025, 2, 5, 10, 15, 0, 0, time.UTC),
+	}
+
+	expectedSchedules := []repositories.CourseOfferingScheduleAttributes{
+		{DayOfWeek: 3, StartTime: 10*time.Hour + 15*time.Minute},
+	}
+
+	suite.expectSemester(req.SemesterID, req.StartTime)
+	suite.mockRepo.On("IsCourseInActiveCurriculum", suite.ctx, req.CourseID).Return(true, nil)
+	suite.mockRepo.On("CreateCourseOfferingTx", mock.AnythingOfType("*common.TxContext"), req.SemesterID, req.CourseID, req.SectionCode, req.Capacity, req.StartTime, req.RoomID).Return(generated.CourseOffering{ID: suite.courseOfferUUID}, nil)
+	suite.mockRepo.On("ReplaceCourseOfferingSchedulesTx", mock.AnythingOfType("*common.TxContext"), uuidToString(suite.courseOfferUUID), expectedSchedules).Return(nil)
+
+	_, err := suite.useCase.CreateCourseOffering(suite.ctx, common.GlobalStudyProgramScope(), req)
+
+	assert.NoError(suite.T(), err)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// Test the weekly schedule derived from the start time is taken on the campus clock, not the offset sent
+func (suite *CourseOfferingUseCaseTestSuite) TestCreateCourseOffering_DefaultScheduleCampusTime() {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	suite.Require().NoError(err)
+	suite.useCase.policy.CampusLocation = jakarta
+
+	req := CreateCourseOfferingRequest{
+		CourseID:    "course-123",
+		SemesterID:  "semester-456",
+		SectionCode: "A1",
+		Capacity:    30,
+		// Wednesday 20:00 UTC is Thursday 03:00 in Jakarta
+		StartTime: time.Date(2025, 2, 5, 20, 0, 0, 0, time.UTC),
+	}
+
+	expectedSchedules := []repositories.CourseOfferingScheduleAttributes{
+		{DayOfWeek: 4, StartTime: 3 * time.Hour},
+	}
+
+	suite.expectSemester(req.SemesterID, req.StartTime)
+	suite.mockRepo.On("IsCourseInActiveCurriculum", suite.ctx, req.CourseID).Return(true, nil)
+	suite.mockRepo.On("CreateCourseOfferingTx", mock.AnythingOfType("*common.TxContext"), req.SemesterID, req.CourseID, req.SectionCode, req.Capacity, req.StartTime, req.RoomID).Return(generated.CourseOffering{ID: suite.courseOfferUUID}, nil)
+	suite.mockRepo.On("ReplaceCourseOfferingSchedulesTx", mock.AnythingOfType("*common.TxContext"), uuidToString(suite.courseOfferUUID), expectedSchedules).Return(nil)
+
+	_, err = suite.useCase.CreateCourseOffering(suite.ctx, common.GlobalStudyProgramScope(), req)
+
+	assert.NoError(suite.T(), err)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// Test an offering without weekly schedules meets on the campus day of its start time
+func (suite *CourseOfferingUseCaseTestSuite) TestNewOfferingSchedule_CampusTime() {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	suite.Require().NoError(err)
+	// Sunday 18:30 UTC is Monday 01:30 in Jakarta
+	startTime := pgtype.Timestamptz{Time: time.Date(2025, 2, 2, 18, 30, 0, 0, time.UTC), Valid: true}
+
+	schedule, err := newOfferingSchedule(startTime, pgtype.Timestamptz{}, pgtype.Timestamptz{}, nil, time.Hour, jakarta)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []weeklySlot{{day: 1, start: 90 * time.Minute, end: 150 * time.Minute}}, schedule.slots)
+	assert.Equal(suite.T(), time.Date(2025, 2, 3, 0, 0, 0, 0, jakarta), schedule.firstDay)
+}
+
 // Test create course offering with repository error
 func (suite *CourseOfferingUseCaseTestSuite) TestCreateCourseOffering_RepositoryError() {
 	req := CreateCourseOfferingRequest{
@@ -259,16 +523,268 @@ func (suite *CourseOfferingUseCaseTestSuite) TestCreateCourseOffering_Repository
 		StartTime:   suite.testTime,
 	}
 
+	suite.expectSemester(req.SemesterID, req.StartTime)
+	suite.mockRepo.On("IsCourseInActiveCurriculum", suite.ctx, req.CourseID).Return(true, nil)
 	expectedError := errors.New("duplicate key violation")
-	suite.mockRepo.On("CreateCourseOffering", suite.ctx, req.SemesterID, req.CourseID, req.SectionCode, req.Capacity, req.StartTime).Return(generated.CourseOffering{}, expectedError)
+	suite.mockRepo.On("CreateCourseOfferingTx", mock.AnythingOfType("*common.TxContext"), req.SemesterID, req.CourseID, req.SectionCode, req.Capacity, req.StartTime, req.RoomID).Return(generated.CourseOffering{}, expectedError)
 
-	response, err := suite.useCase.CreateCourseOffering(suite.ctx, req)
+	response, err := suite.useCase.CreateCourseOffering(suite.ctx, common.GlobalStudyProgramScope(), req)
 
 	assert.Error(suite.T(), err)
 	assert.Contains(suite.T(), err.Error(), "duplicate key violation")
 	assert.Empty(suite.T(), response.ID)
 }
 
+// Test creating an offering for a course without an active curriculum
+func (suite *CourseOfferingUseCaseTestSuite) TestCreateCourseOffering_CourseNotInActiveCurriculum() {
+	req := CreateCourseOfferingRequest{
+		CourseID:    "course-123",
+		SemesterID:  "semester-456",
+		SectionCode: "A1",
+		Capacity:    30,
+		StartTime:   suite.testTime,
+	}
+
+	suite.mockRepo.On("IsCourseInActiveCurriculum", suite.ctx, req.CourseID).Return(false, nil)
+
+	response, err := suite.useCase.CreateCourseOffering(suite.ctx, common.GlobalStudyProgramScope(), req)
+
+	assert.ErrorIs(suite.T(), err, ErrCourseNotInActiveCurriculum)
+	assert.Empty(suite.T(), response.ID)
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateCourseOfferingTx")
+}
+
+// Test an offering must start within its semester, the semester end is exclusive
+func (suite *CourseOfferingUseCaseTestSuite) TestCreateCourseOffering_OutsideSemester() {
+	semesterStart := time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC)
+	semesterEnd := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+	suite.mockRepo.On("IsCourseInActiveCurriculum", suite.ctx, "course-123").Return(true, nil)
+	suite.mockCalendarRepo.On("GetSemester", suite.ctx, "semester-456").Return(generated.Semester{
+		Code:      "2024-2",
+		StartTime: pgtype.Timestamptz{Time: semesterStart, Valid: true},
+		EndTime:   pgtype.Timestamptz{Time: semesterEnd, Valid: true},
+	}, nil)
+
+	for _, startTime := range []time.Time{semesterStart.Add(-time.Hour), semesterEnd, semesterEnd.AddDate(0, 1, 0)} {
+		req := CreateCourseOfferingRequest{
+			CourseID:    "course-123",
+			SemesterID:  "semester-456",
+			SectionCode: "A1",
+			Capacity:    30,
+			StartTime:   startTime,
+		}
+
+		_, err := suite.useCase.CreateCourseOffering(suite.ctx, common.GlobalStudyProgramScope(), req)
+
+		assert.ErrorIs(suite.T(), err, ErrOfferingOutsideSemester, startTime.String())
+	}
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateCourseOfferingTx")
+}
+
+// Test an offering can start at the very start of its semester
+func (suite *CourseOfferingUseCaseTestSuite) TestCreateCourseOffering_AtSemesterStart() {
+	semesterStart := time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC)
+	req := CreateCourseOfferingRequest{
+		CourseID:    "course-123",
+		SemesterID:  "semester-456",
+		SectionCode: "A1",
+		Capacity:    30,
+		StartTime:   semesterStart,
+	}
+
+	suite.mockRepo.On("IsCourseInActiveCurriculum", suite.ctx, req.CourseID).Return(true, nil)
+	suite.mockCalendarRepo.On("GetSemester", suite.ctx, req.SemesterID).Return(generated.Semester{
+		StartTime: pgtype.Timestamptz{Time: semesterStart, Valid: true},
+		EndTime:   pgtype.Timestamptz{Time: time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC), Valid: true},
+	}, nil)
+	suite.mockRepo.On("CreateCourseOfferingTx", mock.AnythingOfType("*common.TxContext"), req.SemesterID, req.CourseID, req.SectionCode, req.Capacity, req.StartTime, req.RoomID).Return(generated.CourseOffering{ID: suite.courseOfferUUID}, nil)
+	suite.mockRepo.On("ReplaceCourseOfferingSchedulesTx", mock.AnythingOfType("*common.TxContext"), uuidToString(suite.courseOfferUUID), mock.Anything).Return(nil)
+
+	_, err := suite.useCase.CreateCourseOffering(suite.ctx, common.GlobalStudyProgramScope(), req)
+
+	assert.NoError(suite.T(), err)
+}
+
+// Test creating an offering in a semester that does not exist
+func (suite *CourseOfferingUseCaseTestSuite) TestCreateCourseOffering_UnknownSemester() {
+	req := CreateCourseOfferingRequest{
+		CourseID:    "course-123",
+		SemesterID:  "semester-456",
+		SectionCode: "A1",
+		Capacity:    30,
+		StartTime:   suite.testTime,
+	}
+
+	suite.mockRepo.On("IsCourseInActiveCurriculum", suite.ctx, req.CourseID).Return(true, nil)
+	suite.mockCalendarRepo.On("GetSemester", suite.ctx, req.SemesterID).Return(generated.Semester{}, pgx.ErrNoRows)
+
+	_, err := suite.useCase.CreateCourseOffering(suite.ctx, common.GlobalStudyProgramScope(), req)
+
+	assert.ErrorIs(suite.T(), err, ErrUnknownSemester)
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateCourseOfferingTx")
+}
+
+// Test assigning a room that does not exist
+func (suite *CourseOfferingUseCaseTestSuite) TestCreateCourseOffering_UnknownRoom() {
+	req := CreateCourseOfferingRequest{
+		CourseID:    "course-123",
+		SemesterID:  "semester-456",
+		SectionCode: "A1",
+		Capacity:    30,
+		StartTime:   suite.testTime,
+		RoomID:      "room-789",
+	}
+
+	suite.expectSemester(req.SemesterID, req.StartTime)
+	suite.mockRepo.On("IsCourseInActiveCurriculum", suite.ctx, req.CourseID).Return(true, nil)
+	suite.mockRoomRepo.On("GetRoom", suite.ctx, req.RoomID).Return(generated.Room{}, pgx.ErrNoRows)
+
+	_, err := suite.useCase.CreateCourseOffering(suite.ctx, common.GlobalStudyProgramScope(), req)
+
+	assert.ErrorIs(suite.T(), err, ErrUnknownRoom)
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateCourseOfferingTx")
+}
+
+// Test assigning a room with fewer seats than the offering capacity
+func (suite *CourseOfferingUseCaseTestSuite) TestCreateCourseOffering_RoomCapacityTooSmall() {
+	req := CreateCourseOfferingRequest{
+		CourseID:    "course-123",
+		SemesterID:  "semester-456",
+		SectionCode: "A1",
+		Capacity:    30,
+		StartTime:   suite.testTime,
+		RoomID:      "room-789",
+	}
+
+	suite.expectSemester(req.SemesterID, req.StartTime)
+	suite.mockRepo.On("IsCourseInActiveCurriculum", suite.ctx, req.CourseID).Return(true, nil)
+	suite.mockRoomRepo.On("GetRoom", suite.ctx, req.RoomID).Return(generated.Room{Code: "GKB-101", Capacity: 25}, nil)
+
+	_, err := suite.useCase.CreateCourseOffering(suite.ctx, common.GlobalStudyProgramScope(), req)
+
+	assert.ErrorIs(suite.T(), err, ErrRoomCapacityTooSmall)
+	assert.Contains(suite.T(), err.Error(), "room GKB-101 seats 25")
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateCourseOfferingTx")
+}
+
+// Test assigning a room used by another offering at an overlapping weekly slot
+func (suite *CourseOfferingUseCaseTestSuite) TestCreateCourseOffering_RoomDoubleBooked() {
+	semesterStart := pgtype.Timestamptz{Time: time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC), Valid: true}
+	semesterEnd := pgtype.Timestamptz{Time: time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC), Valid: true}
+	otherOfferingUUID := pgtype.UUID{Bytes: [16]byte{9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9}, Valid: true}
+	req := CreateCourseOfferingRequest{
+		CourseID:    "course-123",
+		SemesterID:  "semester-456",
+		SectionCode: "A1",
+		Capacity:    30,
+		StartTime:   time.Date(2025, 2, 3, 10, 0, 0, 0, time.UTC),
+		RoomID:      "room-789",
+		Schedules:   []CourseOfferingScheduleRequest{{Day: 1, StartTime: "10:00"}},
+	}
+
+	// The new offering meets Monday 10:00-12:30, the other one Monday 09:00-11:30 in the same room
+	suite.expectSemester(req.SemesterID, req.StartTime)
+	suite.mockRepo.On("IsCourseInActiveCurriculum", suite.ctx, req.CourseID).Return(true, nil)
+	suite.mockRoomRepo.On("GetRoom", suite.ctx, req.RoomID).Return(generated.Room{Code: "GKB-101", Capacity: 40}, nil)
+	suite.mockRepo.On("CreateCourseOfferingTx", mock.AnythingOfType("*common.TxContext"), req.SemesterID, req.CourseID, req.SectionCode, req.Capacity, req.StartTime, req.RoomID).Return(generated.CourseOffering{ID: suite.courseOfferUUID}, nil)
+	suite.mockRepo.On("ReplaceCourseOfferingSchedulesTx", mock.AnythingOfType("*common.TxContext"), uuidToString(suite.courseOfferUUID), mock.Anything).Return(nil)
+	newOffering := repositories.CourseOfferingWithCourse{
+		CourseOfferingID:        suite.courseOfferUUID,
+		CourseOfferingStartTime: pgtype.Timestamptz{Time: req.StartTime, Valid: true},
+		CourseCode:              "CS101",
+		SectionCode:             "A1",
+		Credit:                  3,
+		SemesterStartTime:       semesterStart,
+		SemesterEndTime:         semesterEnd,
+		Schedules: []generated.CourseOfferingSchedule{
+			{DayOfWeek: 1, StartTime: pgtype.Time{Microseconds: int64(10 * time.Hour / time.Microsecond), Valid: true}},
+		},
+	}
+	suite.mockRoomRepo.On("GetRoomForUpdateTx", mock.AnythingOfType("*common.TxContext"), req.RoomID).Return(generated.Room{Code: "GKB-101", Capacity: 40}, nil)
+	suite.mockRepo.On("GetCourseOfferingWithCourseTx", mock.AnythingOfType("*common.TxContext"), uuidToString(suite.courseOfferUUID)).Return(newOffering, nil)
+	suite.mockRepo.On("GetCourseOfferingsByRoomTx", mock.AnythingOfType("*common.TxContext"), req.RoomID).Return([]repositories.CourseOfferingWithCourse{
+		newOffering,
+		{
+			CourseOfferingID:        otherOfferingUUID,
+			CourseOfferingStartTime: pgtype.Timestamptz{Time: time.Date(2025, 2, 3, 9, 0, 0, 0, time.UTC), Valid: true},
+			CourseCode:              "MA101",
+			SectionCode:             "B1",
+			Credit:                  3,
+			SemesterStartTime:       semesterStart,
+			SemesterEndTime:         semesterEnd,
+			Schedules: []generated.CourseOfferingSchedule{
+				{DayOfWeek: 1, StartTime: pgtype.Time{Microseconds: int64(9 * time.Hour / time.Microsecond), Valid: true}},
+			},
+		},
+	}, nil)
+
+	_, err := suite.useCase.CreateCourseOffering(suite.ctx, common.GlobalStudyProgramScope(), req)
+
+	assert.ErrorIs(suite.T(), err, ErrRoomDoubleBooked)
+	assert.Contains(suite.T(), err.Error(), "MA101 section B1 meets Monday 09:00-11:30")
+}
+
+// Test a room deleted after the seat check is rejected once locked in the transaction
+func (suite *CourseOfferingUseCaseTestSuite) TestCreateCourseOffering_RoomDeletedBeforeLock() {
+	req := CreateCourseOfferingRequest{
+		CourseID:    "course-123",
+		SemesterID:  "semester-456",
+		SectionCode: "A1",
+		Capacity:    30,
+		StartTime:   suite.testTime,
+		RoomID:      "room-789",
+	}
+
+	suite.expectSemester(req.SemesterID, req.StartTime)
+	suite.mockRepo.On("IsCourseInActiveCurriculum", suite.ctx, req.CourseID).Return(true, nil)
+	suite.mockRoomRepo.On("GetRoom", suite.ctx, req.RoomID).Return(generated.Room{Code: "GKB-101", Capacity: 40}, nil)
+	suite.mockRepo.On("CreateCourseOfferingTx", mock.AnythingOfType("*common.TxContext"), req.SemesterID, req.CourseID, req.SectionCode, req.Capacity, req.StartTime, req.RoomID).Return(generated.CourseOffering{ID: suite.courseOfferUUID}, nil)
+	suite.mockRepo.On("ReplaceCourseOfferingSchedulesTx", mock.AnythingOfType("*common.TxContext"), uuidToString(suite.courseOfferUUID), mock.Anything).Return(nil)
+	suite.mockRoomRepo.On("GetRoomForUpdateTx", mock.AnythingOfType("*common.TxContext"), req.RoomID).Return(generated.Room{}, pgx.ErrNoRows)
+
+	_, err := suite.useCase.CreateCourseOffering(suite.ctx, common.GlobalStudyProgramScope(), req)
+
+	assert.ErrorIs(suite.T(), err, ErrUnknownRoom)
+	suite.mockRepo.AssertNotCalled(suite.T(), "GetCourseOfferingsByRoomTx")
+}
+
+// Test updating an offering in its own room does not compare it with itself
+func (suite *CourseOfferingUseCaseTestSuite) TestUpdateCourseOffering_SameRoom() {
+	id := uuidToString(suite.courseOfferUUID)
+	req := UpdateCourseOfferingRequest{
+		CourseID:    "course-123",
+		SemesterID:  "semester-456",
+		SectionCode: "B2",
+		Capacity:    25,
+		StartTime:   time.Date(2025, 2, 3, 10, 0, 0, 0, time.UTC),
+		RoomID:      "room-789",
+	}
+	offering := repositories.CourseOfferingWithCourse{
+		CourseOfferingID:        suite.courseOfferUUID,
+		CourseOfferingStartTime: pgtype.Timestamptz{Time: req.StartTime, Valid: true},
+		Credit:                  3,
+	}
+
+	suite.expectSemester(req.SemesterID, req.StartTime)
+	suite.mockRoomRepo.On("GetRoom", suite.ctx, req.RoomID).Return(generated.Room{Code: "GKB-101", Capacity: 25}, nil)
+	suite.mockRepo.On("ListCourseOfferingLecturers", suite.ctx, id).Return([]generated.ListCourseOfferingLecturersRow{}, nil)
+	suite.mockRepo.On("UpdateCourseOfferingTx", mock.AnythingOfType("*common.TxContext"), id, req.SemesterID, req.CourseID, req.SectionCode, req.Capacity, req.StartTime, req.RoomID).Return(generated.CourseOffering{ID: suite.courseOfferUUID}, nil)
+	suite.mockRepo.On("ReplaceCourseOfferingSchedulesTx", mock.AnythingOfType("*common.TxContext"), id, mock.Anything).Return(nil)
+	suite.mockRoomRepo.On("GetRoomForUpdateTx", mock.AnythingOfType("*common.TxContext"), req.RoomID).Return(generated.Room{Code: "GKB-101", Capacity: 25}, nil)
+	suite.mockRepo.On("GetCourseOfferingWithCourseTx", mock.AnythingOfType("*common.TxContext"), id).Return(offering, nil)
+	suite.mockRepo.On("GetCourseOfferingsByRoomTx", mock.AnythingOfType("*common.TxContext"), req.RoomID).Return([]repositories.CourseOfferingWithCourse{offering}, nil).
+		Run(func(mock.Arguments) {
+			// The offerings of the room are only read once the room is locked
+			suite.mockRoomRepo.AssertCalled(suite.T(), "GetRoomForUpdateTx", mock.AnythingOfType("*common.TxContext"), req.RoomID)
+		})
+	suite.mockWaitlist.On("PromoteWaitlistTx", mock.AnythingOfType("*common.TxContext"), id).Return(nil)
+
+	response, err := suite.useCase.UpdateCourseOffering(suite.ctx, common.GlobalStudyProgramScope(), id, req)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), id, response.ID)
+}
+
 // Test successful course offering update
 func (suite *CourseOfferingUseCaseTestSuite) TestUpdateCourseOffering_Success() {
 	id := "course-offer-123"
@@ -284,9 +800,13 @@ func (suite *CourseOfferingUseCaseTestSuite) TestUpdateCourseOffering_Success()
 		ID: suite.courseOfferUUID,
 	}
 
-	suite.mockRepo.On("UpdateCourseOffering", suite.ctx, id, req.SemesterID, req.CourseID, req.SectionCode, req.Capacity, req.StartTime).Return(expectedCourseOffering, nil)
+	suite.expectSemester(req.SemesterID, req.StartTime)
+	suite.mockRepo.On("ListCourseOfferingLecturers", suite.ctx, id).Return([]generated.ListCourseOfferingLecturersRow{}, nil)
+	suite.mockRepo.On("UpdateCourseOfferingTx", mock.AnythingOfType("*common.TxContext"), id, req.SemesterID, req.CourseID, req.SectionCode, req.Capacity, req.StartTime, req.RoomID).Return(expectedCourseOffering, nil)
+	suite.mockRepo.On("ReplaceCourseOfferingSchedulesTx", mock.AnythingOfType("*common.TxContext"), mock.AnythingOfType("string"), mock.Anything).Return(nil)
+	suite.mockWaitlist.On("PromoteWaitlistTx", mock.AnythingOfType("*common.TxContext"), id).Return(nil)
 
-	response, err := suite.useCase.UpdateCourseOffering(suite.ctx, id, req)
+	response, err := suite.useCase.UpdateCourseOffering(suite.ctx, common.GlobalStudyProgramScope(), id, req)
 
 	assert.NoError(suite.T(), err)
 	assert.NotEmpty(suite.T(), response.ID)
@@ -303,15 +823,41 @@ func (suite *CourseOfferingUseCaseTestSuite) TestUpdateCourseOffering_NotFound()
 		StartTime:   suite.testTime,
 	}
 
-	suite.mockRepo.On("UpdateCourseOffering", suite.ctx, id, req.SemesterID, req.CourseID, req.SectionCode, req.Capacity, req.StartTime).Return(generated.CourseOffering{}, pgx.ErrNoRows)
+	suite.expectSemester(req.SemesterID, req.StartTime)
+	suite.mockRepo.On("ListCourseOfferingLecturers", suite.ctx, id).Return([]generated.ListCourseOfferingLecturersRow{}, nil)
+	suite.mockRepo.On("UpdateCourseOfferingTx", mock.AnythingOfType("*common.TxContext"), id, req.SemesterID, req.CourseID, req.SectionCode, req.Capacity, req.StartTime, req.RoomID).Return(generated.CourseOffering{}, pgx.ErrNoRows)
 
-	response, err := suite.useCase.UpdateCourseOffering(suite.ctx, id, req)
+	response, err := suite.useCase.UpdateCourseOffering(suite.ctx, common.GlobalStudyProgramScope(), id, req)
 
 	assert.Error(suite.T(), err)
 	assert.Equal(suite.T(), "course offering not found", err.Error())
 	assert.Empty(suite.T(), response.ID)
 }
 
+// Test moving an offering outside of its semester
+func (suite *CourseOfferingUseCaseTestSuite) TestUpdateCourseOffering_OutsideSemester() {
+	id := "course-offer-123"
+	req := UpdateCourseOfferingRequest{
+		CourseID:    "course-123",
+		SemesterID:  "semester-456",
+		SectionCode: "B2",
+		Capacity:    25,
+		StartTime:   time.Date(2025, 7, 7, 9, 0, 0, 0, time.UTC),
+	}
+
+	suite.mockCalendarRepo.On("GetSemester", suite.ctx, req.SemesterID).Return(generated.Semester{
+		Code:      "2024-2",
+		StartTime: pgtype.Timestamptz{Time: time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC), Valid: true},
+		EndTime:   pgtype.Timestamptz{Time: time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC), Valid: true},
+	}, nil)
+
+	_, err := suite.useCase.UpdateCourseOffering(suite.ctx, common.GlobalStudyProgramScope(), id, req)
+
+	assert.ErrorIs(suite.T(), err, ErrOfferingOutsideSemester)
+	assert.Contains(suite.T(), err.Error(), "semester 2024-2 runs from 2025-02-03T00:00:00Z until 2025-06-30T00:00:00Z")
+	suite.mockRepo.AssertNotCalled(suite.T(), "UpdateCourseOfferingTx")
+}
+
 // Test successful course offering deletion
 func (suite *CourseOfferingUseCaseTestSuite) TestDeleteCourseOffering_Success() {
 	id := "course-offer-123"
@@ -322,7 +868,7 @@ func (suite *CourseOfferingUseCaseTestSuite) TestDeleteCourseOffering_Success()
 
 	suite.mockRepo.On("DeleteCourseOffering", suite.ctx, id).Return(expectedCourseOffering, nil)
 
-	err := suite.useCase.DeleteCourseOffering(suite.ctx, id)
+	err := suite.useCase.DeleteCourseOffering(suite.ctx, common.GlobalStudyProgramScope(), id)
 
 	assert.NoError(suite.T(), err)
 }
@@ -333,12 +879,104 @@ func (suite *CourseOfferingUseCaseTestSuite) TestDeleteCourseOffering_NotFound()
 
 	suite.mockRepo.On("DeleteCourseOffering", suite.ctx, id).Return(generated.CourseOffering{}, pgx.ErrNoRows)
 
-	err := suite.useCase.DeleteCourseOffering(suite.ctx, id)
+	err := suite.useCase.DeleteCourseOffering(suite.ctx, common.GlobalStudyProgramScope(), id)
 
 	assert.Error(suite.T(), err)
 	assert.Equal(suite.T(), "course offering not found", err.Error())
 }
 
+// Test pagination scoped to a study program
+func (suite *CourseOfferingUseCaseTestSuite) TestGetCourseOfferingsWithPagination_StudyProgramScope() {
+	scope := common.StudyProgramScope{StudyProgramID: "prodi-123"}
+
+	suite.mockRepo.On("GetCourseOfferingsByStudyProgramWithPagination", suite.ctx, "prodi-123", 10, 0).Return([]repositories.CourseOfferingWithCourse{}, nil)
+	suite.mockRepo.On("CountCourseOfferingsByStudyProgram", suite.ctx, "prodi-123").Return(int64(0), nil)
+
+	results, pagination, err := suite.useCase.GetCourseOfferingsWithPagination(suite.ctx, scope, 1, 10)
+
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), results)
+	assert.Equal(suite.T(), 0, pagination.TotalRecords)
+}
+
+// Test scoped user without a study program
+func (suite *CourseOfferingUseCaseTestSuite) TestGetCourseOfferingsWithPagination_NoStudyProgram() {
+	results, pagination, err := suite.useCase.GetCourseOfferingsWithPagination(suite.ctx, common.StudyProgramScope{}, 1, 10)
+
+	assert.ErrorIs(suite.T(), err, ErrNoStudyProgram)
+	assert.Nil(suite.T(), results)
+	assert.Nil(suite.T(), pagination)
+}
+
+// Test creating an offering for a course outside the study program
+func (suite *CourseOfferingUseCaseTestSuite) TestCreateCourseOffering_CourseOutOfScope() {
+	scope := common.StudyProgramScope{StudyProgramID: "prodi-123"}
+	req := CreateCourseOfferingRequest{
+		CourseID:    "course-123",
+		SemesterID:  "semester-456",
+		SectionCode: "A1",
+		Capacity:    30,
+		StartTime:   suite.testTime,
+	}
+
+	suite.mockRepo.On("IsCourseInStudyProgram", suite.ctx, req.CourseID, "prodi-123").Return(false, nil)
+
+	response, err := suite.useCase.CreateCourseOffering(suite.ctx, scope, req)
+
+	assert.ErrorIs(suite.T(), err, ErrCourseOutOfScope)
+	assert.Empty(suite.T(), response.ID)
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateCourseOfferingTx")
+}
+
+// Test updating an offering within the study program
+func (suite *CourseOfferingUseCaseTestSuite) TestUpdateCourseOffering_StudyProgramScope() {
+	scope := common.StudyProgramScope{StudyProgramID: "prodi-123"}
+	id := "course-offer-123"
+	req := UpdateCourseOfferingRequest{
+		CourseID:    "course-123",
+		SemesterID:  "semester-456",
+		SectionCode: "B2",
+		Capacity:    25,
+		StartTime:   suite.testTime,
+	}
+
+	existing := repositories.CourseOfferingWithCourse{
+		CourseOfferingID: suite.courseOfferUUID,
+		CourseID:         suite.courseUUID,
+	}
+	suite.expectSemester(req.SemesterID, req.StartTime)
+	suite.mockRepo.On("GetCourseOfferingByIDWithDetails", suite.ctx, id).Return(existing, nil)
+	suite.mockRepo.On("IsCourseInStudyProgram", suite.ctx, uuidToString(suite.courseUUID), "prodi-123").Return(true, nil)
+	suite.mockRepo.On("IsCourseInStudyProgram", suite.ctx, req.CourseID, "prodi-123").Return(true, nil)
+	suite.mockRepo.On("ListCourseOfferingLecturers", suite.ctx, id).Return([]generated.ListCourseOfferingLecturersRow{}, nil)
+	suite.mockRepo.On("UpdateCourseOfferingTx", mock.AnythingOfType("*common.TxContext"), id, req.SemesterID, req.CourseID, req.SectionCode, req.Capacity, req.StartTime, req.RoomID).Return(generated.CourseOffering{ID: suite.courseOfferUUID}, nil)
+	suite.mockRepo.On("ReplaceCourseOfferingSchedulesTx", mock.AnythingOfType("*common.TxContext"), mock.AnythingOfType("string"), mock.Anything).Return(nil)
+	suite.mockWaitlist.On("PromoteWaitlistTx", mock.AnythingOfType("*common.TxContext"), id).Return(nil)
+
+	response, err := suite.useCase.UpdateCourseOffering(suite.ctx, scope, id, req)
+
+	assert.NoError(suite.T(), err)
+	assert.NotEmpty(suite.T(), response.ID)
+}
+
+// Test deleting an offering of another study program
+func (suite *CourseOfferingUseCaseTestSuite) TestDeleteCourseOffering_CourseOutOfScope() {
+	scope := common.StudyProgramScope{StudyProgramID: "prodi-123"}
+	id := "course-offer-123"
+
+	existing := repositories.CourseOfferingWithCourse{
+		CourseOfferingID: suite.courseOfferUUID,
+		CourseID:         suite.courseUUID,
+	}
+	suite.mockRepo.On("GetCourseOfferingByIDWithDetails", suite.ctx, id).Return(existing, nil)
+	suite.mockRepo.On("IsCourseInStudyProgram", suite.ctx, uuidToString(suite.courseUUID), "prodi-123").Return(false, nil)
+
+	err := suite.useCase.DeleteCourseOffering(suite.ctx, scope, id)
+
+	assert.ErrorIs(suite.T(), err, ErrCourseOutOfScope)
+	suite.mockRepo.AssertNotCalled(suite.T(), "DeleteCourseOffering")
+}
+
 // Test UUID to string conversion
 func (suite *CourseOfferingUseCaseTestSuite) TestUuidToString() {
 	uuid := pgtype.UUID{
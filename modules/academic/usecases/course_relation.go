@@ -0,0 +1,356 @@
+package usecases
+
+import (
+	"context"
+	"siakad-poc/db/generated"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+)
+
+// RelatedCourseResponse is a prerequisite, co-requisite or excluded course of a course
+type RelatedCourseResponse struct {
+	CourseID   string    `json:"course_id"`
+	CourseCode string    `json:"course_code"`
+	CourseName string    `json:"course_name"`
+	Credit     int32     `json:"credit"`
+	AddedAt    time.Time `json:"added_at"`
+}
+
+// AddCoursePrerequisiteRequest is the payload to require a course (prasyarat) before enrolling in another one
+type AddCoursePrerequisiteRequest struct {
+	PrerequisiteCourseID string `json:"prerequisite_course_id" validate:"required,uuid"`
+}
+
+// AddCourseCorequisiteRequest is the payload to require a course to be taken in the same semester as another one
+type AddCourseCorequisiteRequest struct {
+	CorequisiteCourseID string `json:"corequisite_course_id" validate:"required,uuid"`
+}
+
+// AddCourseExclusionRequest is the payload to forbid taking two courses in the same semester
+type AddCourseExclusionRequest struct {
+	ExcludedCourseID string `json:"excluded_course_id" validate:"required,uuid"`
+}
+
+func (uc *CourseUseCase) ListPrerequisites(ctx context.Context, courseID string) ([]RelatedCourseResponse, error) {
+	_, err := uc.getCourse(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	prerequisites, err := uc.courseRepository.ListCoursePrerequisites(ctx, courseID)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get course prerequisites")
+	}
+
+	responses := make([]RelatedCourseResponse, 0, len(prerequisites))
+	for _, prerequisite := range prerequisites {
+		response := RelatedCourseResponse{
+			CourseID:   prerequisite.PrerequisiteCourseID.String(),
+			CourseCode: prerequisite.PrerequisiteCourseCode,
+			CourseName: prerequisite.PrerequisiteCourseName,
+			Credit:     prerequisite.PrerequisiteCourseCredit,
+		}
+		if prerequisite.CreatedAt.Valid {
+			response.AddedAt = prerequisite.CreatedAt.Time
+		}
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
+
+// AddPrerequisite requires the prerequisite course before enrolling in the course, prerequisites can't form a cycle.
+func (uc *CourseUseCase) AddPrerequisite(ctx context.Context, courseID string, req AddCoursePrerequisiteRequest) (RelatedCourseResponse, error) {
+	_, err := uc.getCourse(ctx, courseID)
+	if err != nil {
+		return RelatedCourseResponse{}, err
+	}
+
+	prerequisiteCourse, err := uc.getRelatedCourse(ctx, req.PrerequisiteCourseID, ErrUnknownPrerequisiteCourse)
+	if err != nil {
+		return RelatedCourseResponse{}, err
+	}
+	if prerequisiteCourse.ID.String() == courseID {
+		return RelatedCourseResponse{}, ErrPrerequisiteCycle
+	}
+
+	prerequisites, err := uc.courseRepository.ListCoursePrerequisites(ctx, courseID)
+	if err != nil {
+		return RelatedCourseResponse{}, errors.Wrap(err, "cannot get course prerequisites")
+	}
+	for _, existing := range prerequisites {
+		if existing.PrerequisiteCourseID == prerequisiteCourse.ID {
+			return RelatedCourseResponse{}, ErrPrerequisiteAlreadyAdded
+		}
+	}
+
+	// The course must not already be required, directly or not, by its new prerequisite
+	isCycle, err := uc.courseRepository.IsCoursePrerequisiteOf(ctx, courseID, req.PrerequisiteCourseID)
+	if err != nil {
+		return RelatedCourseResponse{}, errors.Wrap(err, "cannot check course prerequisite chain")
+	}
+	if isCycle {
+		return RelatedCourseResponse{}, ErrPrerequisiteCycle
+	}
+
+	prerequisite, err := uc.courseRepository.CreateCoursePrerequisite(ctx, uuid.NewString(), courseID, req.PrerequisiteCourseID)
+	if err != nil {
+		return RelatedCourseResponse{}, errors.Wrap(err, "cannot add course prerequisite")
+	}
+
+	return toRelatedCourseResponse(prerequisiteCourse, prerequisite.CreatedAt), nil
+}
+
+func (uc *CourseUseCase) RemovePrerequisite(ctx context.Context, courseID, prerequisiteCourseID string) error {
+	_, err := uc.getCourse(ctx, courseID)
+	if err != nil {
+		return err
+	}
+
+	_, err = uc.courseRepository.DeleteCoursePrerequisite(ctx, courseID, prerequisiteCourseID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrPrerequisiteNotFound
+		}
+		return errors.Wrap(err, "cannot remove course prerequisite")
+	}
+
+	return nil
+}
+
+func (uc *CourseUseCase) ListCorequisites(ctx context.Context, courseID string) ([]RelatedCourseResponse, error) {
+	_, err := uc.getCourse(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	corequisites, err := uc.courseRepository.ListCourseCorequisites(ctx, courseID)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get course corequisites")
+	}
+
+	responses := make([]RelatedCourseResponse, 0, len(corequisites))
+	for _, corequisite := range corequisites {
+		response := RelatedCourseResponse{
+			CourseID:   corequisite.CorequisiteCourseID.String(),
+			CourseCode: corequisite.CorequisiteCourseCode,
+			CourseName: corequisite.CorequisiteCourseName,
+			Credit:     corequisite.CorequisiteCourseCredit,
+		}
+		if corequisite.CreatedAt.Valid {
+			response.AddedAt = corequisite.CreatedAt.Time
+		}
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
+
+// AddCorequisite requires the co-requisite course to be taken in the same semester as the course, unless it was
+// passed before. The relation goes one way: a lab requires its lecture, so students enroll in the lecture first.
+func (uc *CourseUseCase) AddCorequisite(ctx context.Context, courseID string, req AddCourseCorequisiteRequest) (RelatedCourseResponse, error) {
+	_, err := uc.getCourse(ctx, courseID)
+	if err != nil {
+		return RelatedCourseResponse{}, err
+	}
+
+	corequisiteCourse, err := uc.getRelatedCourse(ctx, req.CorequisiteCourseID, ErrUnknownCorequisiteCourse)
+	if err != nil {
+		return RelatedCourseResponse{}, err
+	}
+	if corequisiteCourse.ID.String() == courseID {
+		return RelatedCourseResponse{}, ErrCourseRelatedToItself
+	}
+
+	corequisites, err := uc.courseRepository.ListCourseCorequisites(ctx, courseID)
+	if err != nil {
+		return RelatedCourseResponse{}, errors.Wrap(err, "cannot get course corequisites")
+	}
+	for _, existing := range corequisites {
+		if existing.CorequisiteCourseID == corequisiteCourse.ID {
+			return RelatedCourseResponse{}, ErrCorequisiteAlreadyAdded
+		}
+	}
+
+	// Courses requiring each other could never be enrolled in one at a time
+	reverseCorequisites, err := uc.courseRepository.ListCourseCorequisites(ctx, req.CorequisiteCourseID)
+	if err != nil {
+		return RelatedCourseResponse{}, errors.Wrap(err, "cannot get course corequisites")
+	}
+	for _, existing := range reverseCorequisites {
+		if existing.CorequisiteCourseID.String() == courseID {
+			return RelatedCourseResponse{}, ErrCorequisiteCycle
+		}
+	}
+
+	excluded, err := uc.isExcluded(ctx, courseID, req.CorequisiteCourseID)
+	if err != nil {
+		return RelatedCourseResponse{}, err
+	}
+	if excluded {
+		return RelatedCourseResponse{}, ErrCourseRelationConflict
+	}
+
+	corequisite, err := uc.courseRepository.CreateCourseCorequisite(ctx, uuid.NewString(), courseID, req.CorequisiteCourseID)
+	if err != nil {
+		return RelatedCourseResponse{}, errors.Wrap(err, "cannot add course corequisite")
+	}
+
+	return toRelatedCourseResponse(corequisiteCourse, corequisite.CreatedAt), nil
+}
+
+func (uc *CourseUseCase) RemoveCorequisite(ctx context.Context, courseID, corequisiteCourseID string) error {
+	_, err := uc.getCourse(ctx, courseID)
+	if err != nil {
+		return err
+	}
+
+	_, err = uc.courseRepository.DeleteCourseCorequisite(ctx, courseID, corequisiteCourseID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrCorequisiteNotFound
+		}
+		return errors.Wrap(err, "cannot remove course corequisite")
+	}
+
+	return nil
+}
+
+// ListExclusions returns the courses that may not be taken in the same semester as the course, exclusions
+// added to either of both courses are listed.
+func (uc *CourseUseCase) ListExclusions(ctx context.Context, courseID string) ([]RelatedCourseResponse, error) {
+	_, err := uc.getCourse(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	exclusions, err := uc.courseRepository.ListCourseExclusions(ctx, courseID)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get course exclusions")
+	}
+
+	responses := make([]RelatedCourseResponse, 0, len(exclusions))
+	for _, exclusion := range exclusions {
+		response := RelatedCourseResponse{
+			CourseID:   exclusion.ExcludedCourseID.String(),
+			CourseCode: exclusion.ExcludedCourseCode,
+			CourseName: exclusion.ExcludedCourseName,
+			Credit:     exclusion.ExcludedCourseCredit,
+		}
+		if exclusion.CreatedAt.Valid {
+			response.AddedAt = exclusion.CreatedAt.Time
+		}
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
+
+// AddExclusion forbids taking both courses in the same semester, the relation applies to both courses.
+func (uc *CourseUseCase) AddExclusion(ctx context.Context, courseID string, req AddCourseExclusionRequest) (RelatedCourseResponse, error) {
+	_, err := uc.getCourse(ctx, courseID)
+	if err != nil {
+		return RelatedCourseResponse{}, err
+	}
+
+	excludedCourse, err := uc.getRelatedCourse(ctx, req.ExcludedCourseID, ErrUnknownExcludedCourse)
+	if err != nil {
+		return RelatedCourseResponse{}, err
+	}
+	if excludedCourse.ID.String() == courseID {
+		return RelatedCourseResponse{}, ErrCourseRelatedToItself
+	}
+
+	excluded, err := uc.isExcluded(ctx, courseID, req.ExcludedCourseID)
+	if err != nil {
+		return RelatedCourseResponse{}, err
+	}
+	if excluded {
+		return RelatedCourseResponse{}, ErrExclusionAlreadyAdded
+	}
+
+	// Co-requisites must be taken together, so they can't exclude each other
+	for _, pair := range [][2]string{{courseID, req.ExcludedCourseID}, {req.ExcludedCourseID, courseID}} {
+		corequisites, err := uc.courseRepository.ListCourseCorequisites(ctx, pair[0])
+		if err != nil {
+			return RelatedCourseResponse{}, errors.Wrap(err, "cannot get course corequisites")
+		}
+		for _, corequisite := range corequisites {
+			if corequisite.CorequisiteCourseID.String() == pair[1] {
+				return RelatedCourseResponse{}, ErrCourseRelationConflict
+			}
+		}
+	}
+
+	exclusion, err := uc.courseRepository.CreateCourseExclusion(ctx, uuid.NewString(), courseID, req.ExcludedCourseID)
+	if err != nil {
+		return RelatedCourseResponse{}, errors.Wrap(err, "cannot add course exclusion")
+	}
+
+	return toRelatedCourseResponse(excludedCourse, exclusion.CreatedAt), nil
+}
+
+// RemoveExclusion removes the exclusion between both courses, whichever of them it was added to.
+func (uc *CourseUseCase) RemoveExclusion(ctx context.Context, courseID, excludedCourseID string) error {
+	_, err := uc.getCourse(ctx, courseID)
+	if err != nil {
+		return err
+	}
+
+	_, err = uc.courseRepository.DeleteCourseExclusion(ctx, courseID, excludedCourseID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrExclusionNotFound
+		}
+		return errors.Wrap(err, "cannot remove course exclusion")
+	}
+
+	return nil
+}
+
+// getRelatedCourse returns the other course of a relation, unknownErr is returned when it is missing or deleted.
+func (uc *CourseUseCase) getRelatedCourse(ctx context.Context, id string, unknownErr error) (generated.Course, error) {
+	course, err := uc.getCourse(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrCourseNotFound) {
+			return generated.Course{}, unknownErr
+		}
+		return generated.Course{}, err
+	}
+
+	return course, nil
+}
+
+// isExcluded reports whether both courses exclude each other.
+func (uc *CourseUseCase) isExcluded(ctx context.Context, courseID, otherCourseID string) (bool, error) {
+	exclusions, err := uc.courseRepository.ListCourseExclusions(ctx, courseID)
+	if err != nil {
+		return false, errors.Wrap(err, "cannot get course exclusions")
+	}
+	for _, exclusion := range exclusions {
+		if exclusion.ExcludedCourseID.String() == otherCourseID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func toRelatedCourseResponse(course generated.Course, addedAt pgtype.Timestamptz) RelatedCourseResponse {
+	response := RelatedCourseResponse{
+		CourseID:   course.ID.String(),
+		CourseCode: course.Code,
+		CourseName: course.Name,
+		Credit:     course.Credit,
+	}
+
+	if addedAt.Valid {
+		response.AddedAt = addedAt.Time
+	}
+
+	return response
+}
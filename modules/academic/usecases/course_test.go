@@ -0,0 +1,457 @@
+package usecases
+
+import (
+	"context"
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// Mock repository for course catalogue tests
+type MockCourseRepository struct {
+	mock.Mock
+}
+
+func (m *MockCourseRepository) ListCourses(ctx context.Context, filter repositories.CourseFilter, limit, offset int) ([]generated.Course, error) {
+	args := m.Called(ctx, filter, limit, offset)
+	return args.Get(0).([]generated.Course), args.Error(1)
+}
+
+func (m *MockCourseRepository) CountCourses(ctx context.Context, filter repositories.CourseFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetCourse(ctx context.Context, id string) (generated.Course, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.Course), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetCourseByCode(ctx context.Context, code string) (generated.Course, error) {
+	args := m.Called(ctx, code)
+	return args.Get(0).(generated.Course), args.Error(1)
+}
+
+func (m *MockCourseRepository) CreateCourse(ctx context.Context, id string, attributes repositories.CourseAttributes) (generated.Course, error) {
+	args := m.Called(ctx, id, attributes)
+	return args.Get(0).(generated.Course), args.Error(1)
+}
+
+func (m *MockCourseRepository) UpdateCourse(ctx context.Context, id string, attributes repositories.CourseAttributes) (generated.Course, error) {
+	args := m.Called(ctx, id, attributes)
+	return args.Get(0).(generated.Course), args.Error(1)
+}
+
+func (m *MockCourseRepository) DeleteCourse(ctx context.Context, id string) (generated.Course, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.Course), args.Error(1)
+}
+
+func (m *MockCourseRepository) CountActiveCourseOfferingsByCourse(ctx context.Context, courseID string) (int64, error) {
+	args := m.Called(ctx, courseID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCourseRepository) ListCoursePrerequisites(ctx context.Context, courseID string) ([]generated.ListCoursePrerequisitesRow, error) {
+	args := m.Called(ctx, courseID)
+	return args.Get(0).([]generated.ListCoursePrerequisitesRow), args.Error(1)
+}
+
+func (m *MockCourseRepository) CreateCoursePrerequisite(ctx context.Context, id, courseID, prerequisiteCourseID string) (generated.CoursePrerequisite, error) {
+	args := m.Called(ctx, id, courseID, prerequisiteCourseID)
+	return args.Get(0).(generated.CoursePrerequisite), args.Error(1)
+}
+
+func (m *MockCourseRepository) DeleteCoursePrerequisite(ctx context.Context, courseID, prerequisiteCourseID string) (generated.CoursePrerequisite, error) {
+	args := m.Called(ctx, courseID, prerequisiteCourseID)
+	return args.Get(0).(generated.CoursePrerequisite), args.Error(1)
+}
+
+func (m *MockCourseRepository) IsCoursePrerequisiteOf(ctx context.Context, courseID, otherCourseID string) (bool, error) {
+	args := m.Called(ctx, courseID, otherCourseID)
+	return args.Get(0).(bool), args.Error(1)
+}
+
+func (m *MockCourseRepository) ListCourseCorequisites(ctx context.Context, courseID string) ([]generated.ListCourseCorequisitesRow, error) {
+	args := m.Called(ctx, courseID)
+	return args.Get(0).([]generated.ListCourseCorequisitesRow), args.Error(1)
+}
+
+func (m *MockCourseRepository) CreateCourseCorequisite(ctx context.Context, id, courseID, corequisiteCourseID string) (generated.CourseCorequisite, error) {
+	args := m.Called(ctx, id, courseID, corequisiteCourseID)
+	return args.Get(0).(generated.CourseCorequisite), args.Error(1)
+}
+
+func (m *MockCourseRepository) DeleteCourseCorequisite(ctx context.Context, courseID, corequisiteCourseID string) (generated.CourseCorequisite, error) {
+	args := m.Called(ctx, courseID, corequisiteCourseID)
+	return args.Get(0).(generated.CourseCorequisite), args.Error(1)
+}
+
+func (m *MockCourseRepository) ListCourseExclusions(ctx context.Context, courseID string) ([]generated.ListCourseExclusionsRow, error) {
+	args := m.Called(ctx, courseID)
+	return args.Get(0).([]generated.ListCourseExclusionsRow), args.Error(1)
+}
+
+func (m *MockCourseRepository) CreateCourseExclusion(ctx context.Context, id, courseID, excludedCourseID string) (generated.CourseExclusion, error) {
+	args := m.Called(ctx, id, courseID, excludedCourseID)
+	return args.Get(0).(generated.CourseExclusion), args.Error(1)
+}
+
+func (m *MockCourseRepository) DeleteCourseExclusion(ctx context.Context, courseID, excludedCourseID string) (generated.CourseExclusion, error) {
+	args := m.Called(ctx, courseID, excludedCourseID)
+	return args.Get(0).(generated.CourseExclusion), args.Error(1)
+}
+
+// Test Suite
+type CourseUseCaseTestSuite struct {
+	suite.Suite
+	useCase    *CourseUseCase
+	mockRepo   *MockCourseRepository
+	ctx        context.Context
+	courseUUID pgtype.UUID
+}
+
+func (suite *CourseUseCaseTestSuite) SetupTest() {
+	suite.mockRepo = new(MockCourseRepository)
+	suite.useCase = NewCourseUseCase(suite.mockRepo)
+	suite.ctx = context.Background()
+
+	suite.courseUUID = pgtype.UUID{
+		Bytes: [16]byte{3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18},
+		Valid: true,
+	}
+}
+
+func (suite *CourseUseCaseTestSuite) TearDownTest() {
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// Test listing courses with a search term
+func (suite *CourseUseCaseTestSuite) TestListCourses_Search() {
+	filter := repositories.CourseFilter{Search: "pemrograman"}
+	courses := []generated.Course{
+		{ID: suite.courseUUID, Code: "151000", Name: "Pemrograman Dasar", Credit: 3},
+	}
+
+	suite.mockRepo.On("ListCourses", suite.ctx, filter, 10, 10).Return(courses, nil)
+	suite.mockRepo.On("CountCourses", suite.ctx, filter).Return(int64(11), nil)
+
+	responses, pagination, err := suite.useCase.ListCourses(suite.ctx, filter, 2, 10)
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), responses, 1)
+	assert.Equal(suite.T(), 3, responses[0].Credit)
+	assert.Equal(suite.T(), 2, pagination.TotalPages)
+}
+
+// Test successful course creation
+func (suite *CourseUseCaseTestSuite) TestCreateCourse_Success() {
+	req := CourseRequest{Code: "151000", Name: "Pemrograman Dasar", Credit: 3}
+	attributes := repositories.CourseAttributes{Code: "151000", Name: "Pemrograman Dasar", Credit: 3, MinutesPerCredit: DefaultMinutesPerCredit}
+
+	suite.mockRepo.On("GetCourseByCode", suite.ctx, req.Code).Return(generated.Course{}, pgx.ErrNoRows)
+	suite.mockRepo.On("CreateCourse", suite.ctx, mock.AnythingOfType("string"), attributes).Return(generated.Course{
+		ID:     suite.courseUUID,
+		Code:   req.Code,
+		Name:   req.Name,
+		Credit: int32(req.Credit),
+	}, nil)
+
+	response, err := suite.useCase.CreateCourse(suite.ctx, req)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), suite.courseUUID.String(), response.ID)
+	assert.Equal(suite.T(), 3, response.Credit)
+}
+
+// Test creating a practicum course with its own class time per credit
+func (suite *CourseUseCaseTestSuite) TestCreateCourse_MinutesPerCredit() {
+	req := CourseRequest{Code: "151001", Name: "Praktikum Pemrograman Dasar", Credit: 1, MinutesPerCredit: 170}
+	attributes := repositories.CourseAttributes{Code: "151001", Name: "Praktikum Pemrograman Dasar", Credit: 1, MinutesPerCredit: 170}
+
+	suite.mockRepo.On("GetCourseByCode", suite.ctx, req.Code).Return(generated.Course{}, pgx.ErrNoRows)
+	suite.mockRepo.On("CreateCourse", suite.ctx, mock.AnythingOfType("string"), attributes).Return(generated.Course{
+		ID:               suite.courseUUID,
+		Code:             req.Code,
+		Name:             req.Name,
+		Credit:           int32(req.Credit),
+		MinutesPerCredit: int32(req.MinutesPerCredit),
+	}, nil)
+
+	response, err := suite.useCase.CreateCourse(suite.ctx, req)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 170, response.MinutesPerCredit)
+}
+
+// Test creating a course with a code already used
+func (suite *CourseUseCaseTestSuite) TestCreateCourse_CodeAlreadyUsed() {
+	req := CourseRequest{Code: "151000", Name: "Pemrograman Dasar", Credit: 3}
+
+	suite.mockRepo.On("GetCourseByCode", suite.ctx, req.Code).Return(generated.Course{ID: suite.courseUUID}, nil)
+
+	_, err := suite.useCase.CreateCourse(suite.ctx, req)
+
+	assert.ErrorIs(suite.T(), err, ErrCourseCodeAlreadyUsed)
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateCourse")
+}
+
+// Test updating a course while keeping its own code
+func (suite *CourseUseCaseTestSuite) TestUpdateCourse_KeepsOwnCode() {
+	id := suite.courseUUID.String()
+	req := CourseRequest{Code: "151000", Name: "Algoritma dan Pemrograman", Credit: 4}
+	existing := generated.Course{ID: suite.courseUUID, Code: "151000", Name: "Pemrograman Dasar", Credit: 3}
+
+	suite.mockRepo.On("GetCourse", suite.ctx, id).Return(existing, nil)
+	suite.mockRepo.On("GetCourseByCode", suite.ctx, req.Code).Return(existing, nil)
+	suite.mockRepo.On("UpdateCourse", suite.ctx, id, toCourseAttributes(req)).Return(generated.Course{
+		ID:     suite.courseUUID,
+		Code:   req.Code,
+		Name:   req.Name,
+		Credit: int32(req.Credit),
+	}, nil)
+
+	response, err := suite.useCase.UpdateCourse(suite.ctx, id, req)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Algoritma dan Pemrograman", response.Name)
+	assert.Equal(suite.T(), 4, response.Credit)
+}
+
+// Test updating a course that was soft-deleted
+func (suite *CourseUseCaseTestSuite) TestUpdateCourse_Deleted() {
+	id := suite.courseUUID.String()
+	req := CourseRequest{Code: "151000", Name: "Pemrograman Dasar", Credit: 3}
+
+	suite.mockRepo.On("GetCourse", suite.ctx, id).Return(generated.Course{
+		ID:        suite.courseUUID,
+		DeletedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}, nil)
+
+	_, err := suite.useCase.UpdateCourse(suite.ctx, id, req)
+
+	assert.ErrorIs(suite.T(), err, ErrCourseNotFound)
+	suite.mockRepo.AssertNotCalled(suite.T(), "UpdateCourse")
+}
+
+// Test deleting a course that is still offered in a running semester
+func (suite *CourseUseCaseTestSuite) TestDeleteCourse_HasActiveOfferings() {
+	id := suite.courseUUID.String()
+
+	suite.mockRepo.On("GetCourse", suite.ctx, id).Return(generated.Course{ID: suite.courseUUID}, nil)
+	suite.mockRepo.On("CountActiveCourseOfferingsByCourse", suite.ctx, id).Return(int64(2), nil)
+
+	err := suite.useCase.DeleteCourse(suite.ctx, id)
+
+	assert.ErrorIs(suite.T(), err, ErrCourseHasActiveOfferings)
+	suite.mockRepo.AssertNotCalled(suite.T(), "DeleteCourse")
+}
+
+// Test deleting a course without active offerings
+func (suite *CourseUseCaseTestSuite) TestDeleteCourse_Success() {
+	id := suite.courseUUID.String()
+
+	suite.mockRepo.On("GetCourse", suite.ctx, id).Return(generated.Course{ID: suite.courseUUID}, nil)
+	suite.mockRepo.On("CountActiveCourseOfferingsByCourse", suite.ctx, id).Return(int64(0), nil)
+	suite.mockRepo.On("DeleteCourse", suite.ctx, id).Return(generated.Course{ID: suite.courseUUID}, nil)
+
+	err := suite.useCase.DeleteCourse(suite.ctx, id)
+
+	assert.NoError(suite.T(), err)
+}
+
+// Test deleting a course that does not exist
+func (suite *CourseUseCaseTestSuite) TestDeleteCourse_NotFound() {
+	id := suite.courseUUID.String()
+
+	suite.mockRepo.On("GetCourse", suite.ctx, id).Return(generated.Course{}, pgx.ErrNoRows)
+
+	err := suite.useCase.DeleteCourse(suite.ctx, id)
+
+	assert.ErrorIs(suite.T(), err, ErrCourseNotFound)
+}
+
+// Test adding a prerequisite to a course
+func (suite *CourseUseCaseTestSuite) TestAddPrerequisite_Success() {
+	id := suite.courseUUID.String()
+	prerequisiteUUID := pgtype.UUID{Bytes: [16]byte{9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9}, Valid: true}
+	prerequisiteID := prerequisiteUUID.String()
+
+	suite.mockRepo.On("GetCourse", suite.ctx, id).Return(generated.Course{ID: suite.courseUUID}, nil)
+	suite.mockRepo.On("GetCourse", suite.ctx, prerequisiteID).Return(generated.Course{ID: prerequisiteUUID, Code: "151000", Name: "Pemrograman Dasar", Credit: 3}, nil)
+	suite.mockRepo.On("ListCoursePrerequisites", suite.ctx, id).Return([]generated.ListCoursePrerequisitesRow{}, nil)
+	suite.mockRepo.On("IsCoursePrerequisiteOf", suite.ctx, id, prerequisiteID).Return(false, nil)
+	suite.mockRepo.On("CreateCoursePrerequisite", suite.ctx, mock.AnythingOfType("string"), id, prerequisiteID).Return(generated.CoursePrerequisite{
+		CreatedAt: pgtype.Timestamptz{Time: time.Date(2025, 10, 13, 8, 0, 0, 0, time.UTC), Valid: true},
+	}, nil)
+
+	response, err := suite.useCase.AddPrerequisite(suite.ctx, id, AddCoursePrerequisiteRequest{PrerequisiteCourseID: prerequisiteID})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), prerequisiteID, response.CourseID)
+	assert.Equal(suite.T(), "151000", response.CourseCode)
+	assert.Equal(suite.T(), int32(3), response.Credit)
+}
+
+// Test a course can't be its own prerequisite
+func (suite *CourseUseCaseTestSuite) TestAddPrerequisite_Self() {
+	id := suite.courseUUID.String()
+
+	suite.mockRepo.On("GetCourse", suite.ctx, id).Return(generated.Course{ID: suite.courseUUID}, nil)
+
+	_, err := suite.useCase.AddPrerequisite(suite.ctx, id, AddCoursePrerequisiteRequest{PrerequisiteCourseID: id})
+
+	assert.ErrorIs(suite.T(), err, ErrPrerequisiteCycle)
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateCoursePrerequisite")
+}
+
+// Test a prerequisite that already requires the course, directly or not, is refused
+func (suite *CourseUseCaseTestSuite) TestAddPrerequisite_Cycle() {
+	id := suite.courseUUID.String()
+	prerequisiteUUID := pgtype.UUID{Bytes: [16]byte{9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9}, Valid: true}
+	prerequisiteID := prerequisiteUUID.String()
+
+	suite.mockRepo.On("GetCourse", suite.ctx, id).Return(generated.Course{ID: suite.courseUUID}, nil)
+	suite.mockRepo.On("GetCourse", suite.ctx, prerequisiteID).Return(generated.Course{ID: prerequisiteUUID}, nil)
+	suite.mockRepo.On("ListCoursePrerequisites", suite.ctx, id).Return([]generated.ListCoursePrerequisitesRow{}, nil)
+	suite.mockRepo.On("IsCoursePrerequisiteOf", suite.ctx, id, prerequisiteID).Return(true, nil)
+
+	_, err := suite.useCase.AddPrerequisite(suite.ctx, id, AddCoursePrerequisiteRequest{PrerequisiteCourseID: prerequisiteID})
+
+	assert.ErrorIs(suite.T(), err, ErrPrerequisiteCycle)
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateCoursePrerequisite")
+}
+
+// Test adding a prerequisite twice
+func (suite *CourseUseCaseTestSuite) TestAddPrerequisite_AlreadyAdded() {
+	id := suite.courseUUID.String()
+	prerequisiteUUID := pgtype.UUID{Bytes: [16]byte{9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9}, Valid: true}
+	prerequisiteID := prerequisiteUUID.String()
+
+	suite.mockRepo.On("GetCourse", suite.ctx, id).Return(generated.Course{ID: suite.courseUUID}, nil)
+	suite.mockRepo.On("GetCourse", suite.ctx, prerequisiteID).Return(generated.Course{ID: prerequisiteUUID}, nil)
+	suite.mockRepo.On("ListCoursePrerequisites", suite.ctx, id).Return([]generated.ListCoursePrerequisitesRow{
+		{PrerequisiteCourseID: prerequisiteUUID},
+	}, nil)
+
+	_, err := suite.useCase.AddPrerequisite(suite.ctx, id, AddCoursePrerequisiteRequest{PrerequisiteCourseID: prerequisiteID})
+
+	assert.ErrorIs(suite.T(), err, ErrPrerequisiteAlreadyAdded)
+}
+
+// Test adding a deleted course as prerequisite
+func (suite *CourseUseCaseTestSuite) TestAddPrerequisite_UnknownCourse() {
+	id := suite.courseUUID.String()
+	prerequisiteID := "550e8400-e29b-41d4-a716-446655440099"
+
+	suite.mockRepo.On("GetCourse", suite.ctx, id).Return(generated.Course{ID: suite.courseUUID}, nil)
+	suite.mockRepo.On("GetCourse", suite.ctx, prerequisiteID).Return(generated.Course{
+		DeletedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}, nil)
+
+	_, err := suite.useCase.AddPrerequisite(suite.ctx, id, AddCoursePrerequisiteRequest{PrerequisiteCourseID: prerequisiteID})
+
+	assert.ErrorIs(suite.T(), err, ErrUnknownPrerequisiteCourse)
+}
+
+// Test removing a course that is not a prerequisite
+func (suite *CourseUseCaseTestSuite) TestRemovePrerequisite_NotFound() {
+	id := suite.courseUUID.String()
+	prerequisiteID := "550e8400-e29b-41d4-a716-446655440099"
+
+	suite.mockRepo.On("GetCourse", suite.ctx, id).Return(generated.Course{ID: suite.courseUUID}, nil)
+	suite.mockRepo.On("DeleteCoursePrerequisite", suite.ctx, id, prerequisiteID).Return(generated.CoursePrerequisite{}, pgx.ErrNoRows)
+
+	err := suite.useCase.RemovePrerequisite(suite.ctx, id, prerequisiteID)
+
+	assert.ErrorIs(suite.T(), err, ErrPrerequisiteNotFound)
+}
+
+// Test adding a co-requisite to a course
+func (suite *CourseUseCaseTestSuite) TestAddCorequisite_Success() {
+	id := suite.courseUUID.String()
+	corequisiteUUID := pgtype.UUID{Bytes: [16]byte{9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9}, Valid: true}
+	corequisiteID := corequisiteUUID.String()
+
+	suite.mockRepo.On("GetCourse", suite.ctx, id).Return(generated.Course{ID: suite.courseUUID}, nil)
+	suite.mockRepo.On("GetCourse", suite.ctx, corequisiteID).Return(generated.Course{ID: corequisiteUUID, Code: "152000", Name: "Basis Data", Credit: 3}, nil)
+	suite.mockRepo.On("ListCourseCorequisites", suite.ctx, id).Return([]generated.ListCourseCorequisitesRow{}, nil)
+	suite.mockRepo.On("ListCourseCorequisites", suite.ctx, corequisiteID).Return([]generated.ListCourseCorequisitesRow{}, nil)
+	suite.mockRepo.On("ListCourseExclusions", suite.ctx, id).Return([]generated.ListCourseExclusionsRow{}, nil)
+	suite.mockRepo.On("CreateCourseCorequisite", suite.ctx, mock.AnythingOfType("string"), id, corequisiteID).Return(generated.CourseCorequisite{
+		CreatedAt: pgtype.Timestamptz{Time: time.Date(2025, 10, 15, 8, 0, 0, 0, time.UTC), Valid: true},
+	}, nil)
+
+	response, err := suite.useCase.AddCorequisite(suite.ctx, id, AddCourseCorequisiteRequest{CorequisiteCourseID: corequisiteID})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), corequisiteID, response.CourseID)
+	assert.Equal(suite.T(), "152000", response.CourseCode)
+}
+
+// Test a course that already requires the course as co-requisite is refused
+func (suite *CourseUseCaseTestSuite) TestAddCorequisite_Cycle() {
+	id := suite.courseUUID.String()
+	corequisiteUUID := pgtype.UUID{Bytes: [16]byte{9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9}, Valid: true}
+	corequisiteID := corequisiteUUID.String()
+
+	suite.mockRepo.On("GetCourse", suite.ctx, id).Return(generated.Course{ID: suite.courseUUID}, nil)
+	suite.mockRepo.On("GetCourse", suite.ctx, corequisiteID).Return(generated.Course{ID: corequisiteUUID}, nil)
+	suite.mockRepo.On("ListCourseCorequisites", suite.ctx, id).Return([]generated.ListCourseCorequisitesRow{}, nil)
+	suite.mockRepo.On("ListCourseCorequisites", suite.ctx, corequisiteID).Return([]generated.ListCourseCorequisitesRow{
+		{CorequisiteCourseID: suite.courseUUID},
+	}, nil)
+
+	_, err := suite.useCase.AddCorequisite(suite.ctx, id, AddCourseCorequisiteRequest{CorequisiteCourseID: corequisiteID})
+
+	assert.ErrorIs(suite.T(), err, ErrCorequisiteCycle)
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateCourseCorequisite")
+}
+
+// Test a co-requisite can't be excluded
+func (suite *CourseUseCaseTestSuite) TestAddExclusion_CorequisiteConflict() {
+	id := suite.courseUUID.String()
+	excludedUUID := pgtype.UUID{Bytes: [16]byte{9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9}, Valid: true}
+	excludedID := excludedUUID.String()
+
+	suite.mockRepo.On("GetCourse", suite.ctx, id).Return(generated.Course{ID: suite.courseUUID}, nil)
+	suite.mockRepo.On("GetCourse", suite.ctx, excludedID).Return(generated.Course{ID: excludedUUID}, nil)
+	suite.mockRepo.On("ListCourseExclusions", suite.ctx, id).Return([]generated.ListCourseExclusionsRow{}, nil)
+	suite.mockRepo.On("ListCourseCorequisites", suite.ctx, id).Return([]generated.ListCourseCorequisitesRow{}, nil)
+	suite.mockRepo.On("ListCourseCorequisites", suite.ctx, excludedID).Return([]generated.ListCourseCorequisitesRow{
+		{CorequisiteCourseID: suite.courseUUID},
+	}, nil)
+
+	_, err := suite.useCase.AddExclusion(suite.ctx, id, AddCourseExclusionRequest{ExcludedCourseID: excludedID})
+
+	assert.ErrorIs(suite.T(), err, ErrCourseRelationConflict)
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateCourseExclusion")
+}
+
+// Test adding an exclusion that was already added from the other course
+func (suite *CourseUseCaseTestSuite) TestAddExclusion_AlreadyAdded() {
+	id := suite.courseUUID.String()
+	excludedUUID := pgtype.UUID{Bytes: [16]byte{9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9}, Valid: true}
+	excludedID := excludedUUID.String()
+
+	suite.mockRepo.On("GetCourse", suite.ctx, id).Return(generated.Course{ID: suite.courseUUID}, nil)
+	suite.mockRepo.On("GetCourse", suite.ctx, excludedID).Return(generated.Course{ID: excludedUUID}, nil)
+	suite.mockRepo.On("ListCourseExclusions", suite.ctx, id).Return([]generated.ListCourseExclusionsRow{
+		{ExcludedCourseID: excludedUUID},
+	}, nil)
+
+	_, err := suite.useCase.AddExclusion(suite.ctx, id, AddCourseExclusionRequest{ExcludedCourseID: excludedID})
+
+	assert.ErrorIs(suite.T(), err, ErrExclusionAlreadyAdded)
+}
+
+func TestCourseUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(CourseUseCaseTestSuite))
+}
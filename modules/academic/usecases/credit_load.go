@@ -0,0 +1,114 @@
+package usecases
+
+import (
+	"siakad-poc/common"
+	"siakad-poc/db/repositories"
+	"sort"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+)
+
+// DefaultMaxCreditsWithoutGPA is the credit allowance of students without a previous semester GPA when the policy
+// doesn't set one
+const DefaultMaxCreditsWithoutGPA = 20
+
+// DefaultCreditLoad is the GPA to credit load table used when the policy doesn't set one
+var DefaultCreditLoad = []CreditLoadRule{
+	{MinGPA: 3.00, MaxCredits: 24},
+	{MinGPA: 2.50, MaxCredits: 21},
+	{MinGPA: 2.00, MaxCredits: 18},
+	{MinGPA: 0, MaxCredits: 15},
+}
+
+// Sources of the credit allowance of a student
+const (
+	CreditAllowanceSourceAdmin   = "admin"
+	CreditAllowanceSourceGPA     = "gpa"
+	CreditAllowanceSourceDefault = "default"
+)
+
+// CreditLoadRule lets students whose previous semester GPA (IP) is at least MinGPA take up to MaxCredits
+type CreditLoadRule struct {
+	MinGPA     float64
+	MaxCredits int32
+}
+
+// creditAllowance is the most credits a student can take in a semester and where the limit comes from
+type creditAllowance struct {
+	MaxCredits int32
+	Source     string
+}
+
+// creditAllowanceTx returns the credit allowance of the student in the semester: the one set by an admin, else the
+// one the policy table gives the previous semester GPA, else the allowance of students without a GPA.
+func (u *CourseEnrollmentUseCase) creditAllowanceTx(txCtx *common.TxContext, studentID, semesterID string) (creditAllowance, error) {
+	allowance, err := u.creditLoadRepo.GetStudentCreditAllowanceTx(txCtx, studentID)
+	if err == nil {
+		return creditAllowance{MaxCredits: allowance.MaxCredits, Source: CreditAllowanceSourceAdmin}, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return creditAllowance{}, errors.Wrap(err, "cannot get credit allowance")
+	}
+
+	previous, err := u.creditLoadRepo.GetPreviousSemesterGPATx(txCtx, studentID, semesterID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return creditAllowance{MaxCredits: u.maxCreditsWithoutGPA(), Source: CreditAllowanceSourceDefault}, nil
+		}
+		return creditAllowance{}, errors.Wrap(err, "cannot get previous semester gpa")
+	}
+
+	gpa, err := previous.Gpa.Float64Value()
+	if err != nil || !gpa.Valid {
+		return creditAllowance{}, errors.New("cannot read previous semester gpa")
+	}
+
+	return creditAllowance{MaxCredits: maxCreditsForGPA(u.creditLoad(), gpa.Float64), Source: CreditAllowanceSourceGPA}, nil
+}
+
+// creditLoad returns the GPA to credit load table of the policy, DefaultCreditLoad if unset.
+func (u *CourseEnrollmentUseCase) creditLoad() []CreditLoadRule {
+	if len(u.policy.CreditLoad) == 0 {
+		return DefaultCreditLoad
+	}
+	return u.policy.CreditLoad
+}
+
+// maxCreditsWithoutGPA returns the allowance of students without a previous semester GPA,
+// DefaultMaxCreditsWithoutGPA if unset.
+func (u *CourseEnrollmentUseCase) maxCreditsWithoutGPA() int32 {
+	if u.policy.MaxCreditsWithoutGPA <= 0 {
+		return DefaultMaxCreditsWithoutGPA
+	}
+	return u.policy.MaxCreditsWithoutGPA
+}
+
+// maxCreditsForGPA returns the credits of the rule with the highest MinGPA the GPA reaches, a GPA below every rule
+// gets the credits of the lowest one.
+func maxCreditsForGPA(rules []CreditLoadRule, gpa float64) int32 {
+	sorted := make([]CreditLoadRule, len(rules))
+	copy(sorted, rules)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].MinGPA > sorted[j].MinGPA
+	})
+
+	for _, rule := range sorted {
+		if gpa >= rule.MinGPA {
+			return rule.MaxCredits
+		}
+	}
+	return sorted[len(sorted)-1].MaxCredits
+}
+
+// sumSemesterCredits returns the credits of the enrollments held in the semester.
+func sumSemesterCredits(enrollments []repositories.StudentEnrollmentWithDetails, semesterID pgtype.UUID) int32 {
+	var credits int32
+	for _, enrollment := range enrollments {
+		if enrollment.SemesterID == semesterID {
+			credits += enrollment.Credit
+		}
+	}
+	return credits
+}
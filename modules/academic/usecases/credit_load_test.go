@@ -0,0 +1,80 @@
+package usecases
+
+import (
+	"context"
+	"siakad-poc/common"
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock repository for credit load tests
+type MockCreditLoadRepository struct {
+	mock.Mock
+}
+
+func (m *MockCreditLoadRepository) GetStudentsByNIMs(ctx context.Context, nims []string) ([]generated.GetStudentsByNimsRow, error) {
+	args := m.Called(ctx, nims)
+	return args.Get(0).([]generated.GetStudentsByNimsRow), args.Error(1)
+}
+
+func (m *MockCreditLoadRepository) GetSemestersByAcademicYearCodes(ctx context.Context, academicYearCodes []string) ([]generated.GetSemestersByAcademicYearCodesRow, error) {
+	args := m.Called(ctx, academicYearCodes)
+	return args.Get(0).([]generated.GetSemestersByAcademicYearCodesRow), args.Error(1)
+}
+
+func (m *MockCreditLoadRepository) GetSemesterGPAsByStudents(ctx context.Context, studentIDs []string) ([]generated.SemesterGpa, error) {
+	args := m.Called(ctx, studentIDs)
+	return args.Get(0).([]generated.SemesterGpa), args.Error(1)
+}
+
+func (m *MockCreditLoadRepository) GetStudentCreditAllowance(ctx context.Context, studentID string) (generated.StudentCreditAllowance, error) {
+	args := m.Called(ctx, studentID)
+	return args.Get(0).(generated.StudentCreditAllowance), args.Error(1)
+}
+
+func (m *MockCreditLoadRepository) SetStudentCreditAllowance(ctx context.Context, studentID string, maxCredits int32, setBy string) (generated.StudentCreditAllowance, error) {
+	args := m.Called(ctx, studentID, maxCredits, setBy)
+	return args.Get(0).(generated.StudentCreditAllowance), args.Error(1)
+}
+
+func (m *MockCreditLoadRepository) DeleteStudentCreditAllowance(ctx context.Context, studentID string) (generated.StudentCreditAllowance, error) {
+	args := m.Called(ctx, studentID)
+	return args.Get(0).(generated.StudentCreditAllowance), args.Error(1)
+}
+
+func (m *MockCreditLoadRepository) CreateSemesterGPAsTx(txCtx *common.TxContext, gpas []repositories.NewSemesterGPA) (int64, error) {
+	args := m.Called(txCtx, gpas)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCreditLoadRepository) GetStudentCreditAllowanceTx(txCtx *common.TxContext, studentID string) (generated.StudentCreditAllowance, error) {
+	args := m.Called(txCtx, studentID)
+	return args.Get(0).(generated.StudentCreditAllowance), args.Error(1)
+}
+
+func (m *MockCreditLoadRepository) GetPreviousSemesterGPATx(txCtx *common.TxContext, studentID, semesterID string) (generated.GetPreviousSemesterGPARow, error) {
+	args := m.Called(txCtx, studentID, semesterID)
+	return args.Get(0).(generated.GetPreviousSemesterGPARow), args.Error(1)
+}
+
+func TestMaxCreditsForGPA(t *testing.T) {
+	// Boundaries of the default table are inclusive
+	assert.Equal(t, int32(24), maxCreditsForGPA(DefaultCreditLoad, 4.00))
+	assert.Equal(t, int32(24), maxCreditsForGPA(DefaultCreditLoad, 3.00))
+	assert.Equal(t, int32(21), maxCreditsForGPA(DefaultCreditLoad, 2.99))
+	assert.Equal(t, int32(18), maxCreditsForGPA(DefaultCreditLoad, 2.00))
+	assert.Equal(t, int32(15), maxCreditsForGPA(DefaultCreditLoad, 0))
+
+	// Configured tables don't have to be sorted, a GPA below every rule gets the lowest one
+	rules := []CreditLoadRule{
+		{MinGPA: 2.00, MaxCredits: 18},
+		{MinGPA: 3.50, MaxCredits: 24},
+	}
+	assert.Equal(t, int32(24), maxCreditsForGPA(rules, 3.75))
+	assert.Equal(t, int32(18), maxCreditsForGPA(rules, 3.25))
+	assert.Equal(t, int32(18), maxCreditsForGPA(rules, 1.50))
+}
@@ -0,0 +1,135 @@
+package usecases
+
+import (
+	"context"
+	"siakad-poc/common"
+	"siakad-poc/db/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+// DefaultDropPeriodDays is how many days after the start of a semester students can drop registrations when neither
+// the semester nor the policy sets a deadline
+const DefaultDropPeriodDays = 14
+
+// Statuses recorded in the study plan history when a line is dropped, the registration keeps its status and is soft
+// deleted
+const (
+	StudyPlanLineStatusDropped   = "dropped"
+	StudyPlanLineStatusWithdrawn = "withdrawn"
+)
+
+// WithdrawEnrollmentRequest is the payload to withdraw a student from a course offering, the student is told why
+type WithdrawEnrollmentRequest struct {
+	Reason string `json:"reason" validate:"required,max=1000"`
+}
+
+// DropUserEnrollment drops the registration of the student record linked to the user, see DropEnrollment.
+// It returns the ID of the student record the registration was dropped for.
+func (u *CourseEnrollmentUseCase) DropUserEnrollment(ctx context.Context, userID, courseOfferingID string) (string, error) {
+	studentID, err := u.studentIDOfUser(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	return studentID, u.DropEnrollment(ctx, userID, studentID, courseOfferingID)
+}
+
+// DropEnrollment lets the student undo a registration until the drop deadline of the semester. The registration is
+// soft deleted and its seat goes to the waitlist, it can't be dropped while its study plan is waiting for the
+// academic advisor.
+func (u *CourseEnrollmentUseCase) DropEnrollment(ctx context.Context, userID, studentID, courseOfferingID string) error {
+	return u.dropEnrollment(ctx, userID, studentID, courseOfferingID, "", false)
+}
+
+// WithdrawStudent drops the registration of the student on behalf of an admin, whatever the drop deadline and the
+// status of the study plan. The reason is kept with the registration and in the study plan history.
+func (u *CourseEnrollmentUseCase) WithdrawStudent(ctx context.Context, actorID, studentID, courseOfferingID string, req WithdrawEnrollmentRequest) error {
+	return u.dropEnrollment(ctx, actorID, studentID, courseOfferingID, req.Reason, true)
+}
+
+func (u *CourseEnrollmentUseCase) dropEnrollment(ctx context.Context, userID, studentID, courseOfferingID, reason string, withdrawal bool) error {
+	return u.txExecutor.WithTxContext(ctx, func(txCtx *common.TxContext) error {
+		courseOffering, err := u.academicRepo.GetCourseOfferingWithCourseTx(txCtx, courseOfferingID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return NewCourseOfferingNotFoundError(courseOfferingID)
+			}
+			return NewDatabaseOperationError("get course offering details", err)
+		}
+
+		registration, err := u.academicRepo.GetEnrollmentTx(txCtx, studentID, courseOfferingID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return NewEnrollmentNotFoundError(studentID, courseOfferingID)
+			}
+			return NewDatabaseOperationError("get enrollment", err)
+		}
+
+		if !withdrawal {
+			dropDeadline := u.dropDeadline(courseOffering)
+			if time.Now().After(dropDeadline) {
+				return NewDropDeadlinePassedError(dropDeadline)
+			}
+		}
+
+		// Lock the study plan before the line, like registrations and reviews do
+		studyPlanID := ""
+		if registration.StudyPlanID.Valid {
+			studyPlanID = uuidToString(registration.StudyPlanID)
+			studyPlan, err := u.studyPlanRepo.GetStudyPlanForUpdateTx(txCtx, studyPlanID)
+			if err != nil {
+				return NewDatabaseOperationError("get study plan", err)
+			}
+			if !withdrawal && studyPlan.Status == StudyPlanStatusSubmitted {
+				return NewStudyPlanLockedError(studyPlanID, studyPlan.Status)
+			}
+		}
+
+		dropped, err := u.academicRepo.DropEnrollmentTx(txCtx, uuidToString(registration.ID), userID, reason)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return NewEnrollmentNotFoundError(studentID, courseOfferingID)
+			}
+			return NewDatabaseOperationError("drop enrollment", err)
+		}
+
+		if studyPlanID != "" {
+			statusAfter := StudyPlanLineStatusDropped
+			if withdrawal {
+				statusAfter = StudyPlanLineStatusWithdrawn
+			}
+			_, err = u.studyPlanRepo.CreateStudyPlanHistoryTx(txCtx, uuid.NewString(), repositories.StudyPlanTransition{
+				StudyPlanID:          studyPlanID,
+				CourseRegistrationID: uuidToString(dropped.ID),
+				StatusBefore:         dropped.Status,
+				StatusAfter:          statusAfter,
+				Note:                 reason,
+				ChangedBy:            userID,
+			})
+			if err != nil {
+				return NewDatabaseOperationError("record study plan history", err)
+			}
+		}
+
+		// The freed seat goes to the first eligible student of the waitlist
+		return u.PromoteWaitlistTx(txCtx, courseOfferingID)
+	})
+}
+
+// dropDeadline returns the drop deadline of the semester of the course offering, or the end of the drop period
+// after the start of the semester when it has none.
+func (u *CourseEnrollmentUseCase) dropDeadline(courseOffering repositories.CourseOfferingWithCourse) time.Time {
+	if courseOffering.SemesterDropDeadline.Valid {
+		return courseOffering.SemesterDropDeadline.Time
+	}
+
+	dropPeriodDays := u.policy.DropPeriodDays
+	if dropPeriodDays <= 0 {
+		dropPeriodDays = DefaultDropPeriodDays
+	}
+	return courseOffering.SemesterStartTime.Time.AddDate(0, 0, dropPeriodDays)
+}
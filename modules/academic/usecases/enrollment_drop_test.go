@@ -0,0 +1,169 @@
+package usecases
+
+import (
+	"time"
+
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	dropUserID       = "550e8400-e29b-41d4-a716-446655440009"
+	dropAdminID      = "550e8400-e29b-41d4-a716-446655440010"
+	dropRegistration = "550e8400-e29b-41d4-a716-446655440011"
+)
+
+// dropFixture returns a course offering whose semester started the given time ago, without a drop deadline of its
+// own, and the registration of the student in the study plan of the suite
+func (suite *EnrollmentUseCaseTestSuite) dropFixture(sinceSemesterStart time.Duration) (repositories.CourseOfferingWithCourse, generated.CourseRegistration) {
+	courseOffering := repositories.CourseOfferingWithCourse{
+		SemesterStartTime: pgtype.Timestamptz{Time: time.Now().Add(-sinceSemesterStart), Valid: true},
+	}
+
+	var registrationUUID pgtype.UUID
+	_ = registrationUUID.Scan(dropRegistration)
+	registration := generated.CourseRegistration{
+		ID:          registrationUUID,
+		StudyPlanID: suite.studyPlan.ID,
+		Status:      StudyPlanLineStatusApproved,
+	}
+
+	return courseOffering, registration
+}
+
+// expectEmptyWaitlist expects nobody to be waiting for the seat freed by the drop
+func (suite *EnrollmentUseCaseTestSuite) expectEmptyWaitlist() {
+	suite.mockWaitlistRepo.On("ListWaitlistEntriesForUpdateTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return([]generated.CourseOfferingWaitlist{}, nil)
+}
+
+// Test dropping a registration within the drop period
+func (suite *EnrollmentUseCaseTestSuite) TestDropEnrollment_Success() {
+	courseOffering, registration := suite.dropFixture(72 * time.Hour)
+	suite.studyPlan.Status = StudyPlanStatusApproved
+
+	suite.mockRepo.On("GetCourseOfferingWithCourseTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(courseOffering, nil)
+	suite.mockRepo.On("GetEnrollmentTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID).Return(registration, nil)
+	suite.mockStudyPlanRepo.On("GetStudyPlanForUpdateTx", mock.AnythingOfType("*common.TxContext"), suite.studyPlanID).Return(suite.studyPlan, nil)
+	suite.mockRepo.On("DropEnrollmentTx", mock.AnythingOfType("*common.TxContext"), dropRegistration, dropUserID, "").Return(registration, nil)
+	suite.expectEmptyWaitlist()
+	suite.mockStudyPlanRepo.On("CreateStudyPlanHistoryTx", mock.AnythingOfType("*common.TxContext"), mock.AnythingOfType("string"), repositories.StudyPlanTransition{
+		StudyPlanID:          suite.studyPlanID,
+		CourseRegistrationID: dropRegistration,
+		StatusBefore:         StudyPlanLineStatusApproved,
+		StatusAfter:          StudyPlanLineStatusDropped,
+		ChangedBy:            dropUserID,
+	}).Return(generated.StudyPlanHistory{}, nil)
+
+	err := suite.useCase.DropEnrollment(suite.ctx, dropUserID, suite.studentID, suite.courseID)
+
+	assert.NoError(suite.T(), err)
+}
+
+// Test dropping after the default drop period of the semester
+func (suite *EnrollmentUseCaseTestSuite) TestDropEnrollment_DeadlinePassed() {
+	courseOffering, registration := suite.dropFixture(time.Duration(DefaultDropPeriodDays+1) * 24 * time.Hour)
+
+	suite.mockRepo.On("GetCourseOfferingWithCourseTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(courseOffering, nil)
+	suite.mockRepo.On("GetEnrollmentTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID).Return(registration, nil)
+
+	err := suite.useCase.DropEnrollment(suite.ctx, dropUserID, suite.studentID, suite.courseID)
+
+	errorType, ok := GetEnrollmentErrorType(err)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), ErrDropDeadlinePassed, errorType)
+	assert.True(suite.T(), IsBusinessRuleViolation(err))
+	suite.mockRepo.AssertNotCalled(suite.T(), "DropEnrollmentTx")
+}
+
+// Test the drop deadline of the semester replaces the drop period of the policy
+func (suite *EnrollmentUseCaseTestSuite) TestDropEnrollment_SemesterDeadline() {
+	courseOffering, registration := suite.dropFixture(72 * time.Hour)
+	courseOffering.SemesterDropDeadline = pgtype.Timestamptz{Time: time.Now().Add(-time.Hour), Valid: true}
+
+	suite.mockRepo.On("GetCourseOfferingWithCourseTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(courseOffering, nil)
+	suite.mockRepo.On("GetEnrollmentTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID).Return(registration, nil)
+
+	err := suite.useCase.DropEnrollment(suite.ctx, dropUserID, suite.studentID, suite.courseID)
+
+	errorType, ok := GetEnrollmentErrorType(err)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), ErrDropDeadlinePassed, errorType)
+}
+
+// Test dropping while the study plan is waiting for the academic advisor
+func (suite *EnrollmentUseCaseTestSuite) TestDropEnrollment_StudyPlanSubmitted() {
+	courseOffering, registration := suite.dropFixture(72 * time.Hour)
+	suite.studyPlan.Status = StudyPlanStatusSubmitted
+
+	suite.mockRepo.On("GetCourseOfferingWithCourseTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(courseOffering, nil)
+	suite.mockRepo.On("GetEnrollmentTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID).Return(registration, nil)
+	suite.mockStudyPlanRepo.On("GetStudyPlanForUpdateTx", mock.AnythingOfType("*common.TxContext"), suite.studyPlanID).Return(suite.studyPlan, nil)
+
+	err := suite.useCase.DropEnrollment(suite.ctx, dropUserID, suite.studentID, suite.courseID)
+
+	errorType, ok := GetEnrollmentErrorType(err)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), ErrStudyPlanLocked, errorType)
+	suite.mockRepo.AssertNotCalled(suite.T(), "DropEnrollmentTx")
+}
+
+// Test dropping a registration that doesn't exist or is already dropped
+func (suite *EnrollmentUseCaseTestSuite) TestDropEnrollment_NotEnrolled() {
+	courseOffering, _ := suite.dropFixture(72 * time.Hour)
+
+	suite.mockRepo.On("GetCourseOfferingWithCourseTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(courseOffering, nil)
+	suite.mockRepo.On("GetEnrollmentTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID).Return(generated.CourseRegistration{}, pgx.ErrNoRows)
+
+	err := suite.useCase.DropEnrollment(suite.ctx, dropUserID, suite.studentID, suite.courseID)
+
+	errorType, ok := GetEnrollmentErrorType(err)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), ErrEnrollmentNotFound, errorType)
+	assert.True(suite.T(), IsDataValidationError(err))
+}
+
+// Test an admin withdrawal ignores the drop deadline and the study plan review, and records the reason
+func (suite *EnrollmentUseCaseTestSuite) TestWithdrawStudent_IgnoresDeadline() {
+	courseOffering, registration := suite.dropFixture(90 * 24 * time.Hour)
+	suite.studyPlan.Status = StudyPlanStatusSubmitted
+	reason := "Academic leave approved by the faculty"
+
+	suite.mockRepo.On("GetCourseOfferingWithCourseTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(courseOffering, nil)
+	suite.mockRepo.On("GetEnrollmentTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID).Return(registration, nil)
+	suite.mockStudyPlanRepo.On("GetStudyPlanForUpdateTx", mock.AnythingOfType("*common.TxContext"), suite.studyPlanID).Return(suite.studyPlan, nil)
+	suite.mockRepo.On("DropEnrollmentTx", mock.AnythingOfType("*common.TxContext"), dropRegistration, dropAdminID, reason).Return(registration, nil)
+	suite.expectEmptyWaitlist()
+	suite.mockStudyPlanRepo.On("CreateStudyPlanHistoryTx", mock.AnythingOfType("*common.TxContext"), mock.AnythingOfType("string"), repositories.StudyPlanTransition{
+		StudyPlanID:          suite.studyPlanID,
+		CourseRegistrationID: dropRegistration,
+		StatusBefore:         StudyPlanLineStatusApproved,
+		StatusAfter:          StudyPlanLineStatusWithdrawn,
+		Note:                 reason,
+		ChangedBy:            dropAdminID,
+	}).Return(generated.StudyPlanHistory{}, nil)
+
+	err := suite.useCase.WithdrawStudent(suite.ctx, dropAdminID, suite.studentID, suite.courseID, WithdrawEnrollmentRequest{Reason: reason})
+
+	assert.NoError(suite.T(), err)
+}
+
+// Test registrations made before study plans existed are dropped without a study plan history
+func (suite *EnrollmentUseCaseTestSuite) TestDropEnrollment_WithoutStudyPlan() {
+	courseOffering, registration := suite.dropFixture(72 * time.Hour)
+	registration.StudyPlanID = pgtype.UUID{}
+
+	suite.mockRepo.On("GetCourseOfferingWithCourseTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(courseOffering, nil)
+	suite.mockRepo.On("GetEnrollmentTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID).Return(registration, nil)
+	suite.mockRepo.On("DropEnrollmentTx", mock.AnythingOfType("*common.TxContext"), dropRegistration, dropUserID, "").Return(registration, nil)
+	suite.expectEmptyWaitlist()
+
+	err := suite.useCase.DropEnrollment(suite.ctx, dropUserID, suite.studentID, suite.courseID)
+
+	assert.NoError(suite.T(), err)
+	suite.mockStudyPlanRepo.AssertNotCalled(suite.T(), "CreateStudyPlanHistoryTx")
+}
@@ -1,6 +1,10 @@
 package usecases
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 // EnrollmentError represents domain-specific errors in the course enrollment process
 type EnrollmentError struct {
@@ -22,9 +26,20 @@ const (
 	ErrDuplicateEnrollment      EnrollmentErrorType = "DUPLICATE_ENROLLMENT"
 	ErrCapacityExceeded         EnrollmentErrorType = "CAPACITY_EXCEEDED"
 	ErrScheduleConflict         EnrollmentErrorType = "SCHEDULE_CONFLICT"
+	ErrPrerequisiteNotMet       EnrollmentErrorType = "PREREQUISITE_NOT_MET"
+	ErrCorequisiteNotMet        EnrollmentErrorType = "COREQUISITE_NOT_MET"
+	ErrExcludedCourseConflict   EnrollmentErrorType = "EXCLUDED_COURSE_CONFLICT"
+	ErrStudyPlanLocked          EnrollmentErrorType = "STUDY_PLAN_LOCKED"
+	ErrCreditLimitExceeded      EnrollmentErrorType = "CREDIT_LIMIT_EXCEEDED"
+	ErrDropDeadlinePassed       EnrollmentErrorType = "DROP_DEADLINE_PASSED"
+	ErrSeatsAvailable           EnrollmentErrorType = "SEATS_AVAILABLE"
+	ErrAlreadyWaitlisted        EnrollmentErrorType = "ALREADY_WAITLISTED"
 	
 	// Data validation errors
 	ErrCourseOfferingNotFound   EnrollmentErrorType = "COURSE_OFFERING_NOT_FOUND"
+	ErrStudentNotFound          EnrollmentErrorType = "STUDENT_NOT_FOUND"
+	ErrEnrollmentNotFound       EnrollmentErrorType = "ENROLLMENT_NOT_FOUND"
+	ErrWaitlistEntryNotFound    EnrollmentErrorType = "WAITLIST_ENTRY_NOT_FOUND"
 	ErrInvalidCourseData        EnrollmentErrorType = "INVALID_COURSE_DATA"
 	ErrInvalidTimestamp         EnrollmentErrorType = "INVALID_TIMESTAMP"
 	
@@ -69,6 +84,105 @@ func NewScheduleConflictError(newCourseTime, existingCourseTime string) *Enrollm
 	}
 }
 
+// NewPrerequisiteNotMetError creates an error for prerequisite courses the student has not passed,
+// missingCourses are formatted as "code name"
+func NewPrerequisiteNotMetError(missingCourses []string, minimumGrade string) *EnrollmentError {
+	return &EnrollmentError{
+		Type:    ErrPrerequisiteNotMet,
+		Message: fmt.Sprintf("Prerequisites not met: %s must be passed with grade %s or better", strings.Join(missingCourses, ", "), minimumGrade),
+		Details: map[string]interface{}{
+			"missing_courses": missingCourses,
+			"minimum_grade":   minimumGrade,
+		},
+	}
+}
+
+// NewCorequisiteNotMetError creates an error for co-requisite courses the student neither takes
+// in the same semester nor has passed, missingCourses are formatted as "code name"
+func NewCorequisiteNotMetError(missingCourses []string) *EnrollmentError {
+	return &EnrollmentError{
+		Type:    ErrCorequisiteNotMet,
+		Message: fmt.Sprintf("Co-requisites not met: %s must be taken in the same semester", strings.Join(missingCourses, ", ")),
+		Details: map[string]interface{}{
+			"missing_courses": missingCourses,
+		},
+	}
+}
+
+// NewExcludedCourseConflictError creates an error for mutually exclusive courses the student
+// already takes in the same semester, conflictingCourses are formatted as "code name"
+func NewExcludedCourseConflictError(conflictingCourses []string) *EnrollmentError {
+	return &EnrollmentError{
+		Type:    ErrExcludedCourseConflict,
+		Message: fmt.Sprintf("Course is mutually exclusive with %s taken in the same semester", strings.Join(conflictingCourses, ", ")),
+		Details: map[string]interface{}{
+			"conflicting_courses": conflictingCourses,
+		},
+	}
+}
+
+// NewStudyPlanLockedError creates an error for registrations into a study plan that is under review or approved
+func NewStudyPlanLockedError(studyPlanID, status string) *EnrollmentError {
+	return &EnrollmentError{
+		Type:    ErrStudyPlanLocked,
+		Message: fmt.Sprintf("Study plan of the semester is %s and can't be changed", status),
+		Details: map[string]interface{}{
+			"study_plan_id": studyPlanID,
+			"status":        status,
+		},
+	}
+}
+
+// NewCreditLimitExceededError creates an error for registrations that take the credits of the semester over the
+// allowance of the student
+func NewCreditLimitExceededError(semesterCredits, courseCredits, maxCredits int32, source string) *EnrollmentError {
+	return &EnrollmentError{
+		Type:    ErrCreditLimitExceeded,
+		Message: fmt.Sprintf("Credit limit of the semester exceeded (%d + %d > %d credits)", semesterCredits, courseCredits, maxCredits),
+		Details: map[string]interface{}{
+			"semester_credits": semesterCredits,
+			"course_credits":   courseCredits,
+			"max_credits":      maxCredits,
+			"source":           source,
+		},
+	}
+}
+
+// NewDropDeadlinePassedError creates an error for drops after the drop deadline of the semester
+func NewDropDeadlinePassedError(dropDeadline time.Time) *EnrollmentError {
+	return &EnrollmentError{
+		Type:    ErrDropDeadlinePassed,
+		Message: fmt.Sprintf("Drop deadline of the semester passed on %s", dropDeadline.Format(time.RFC3339)),
+		Details: map[string]interface{}{
+			"drop_deadline": dropDeadline.Format(time.RFC3339),
+		},
+	}
+}
+
+// NewSeatsAvailableError creates an error for joining the waitlist of a course offering that still has free seats
+func NewSeatsAvailableError(currentCount, maxCapacity int64) *EnrollmentError {
+	return &EnrollmentError{
+		Type:    ErrSeatsAvailable,
+		Message: fmt.Sprintf("Course offering has free seats (%d/%d)", currentCount, maxCapacity),
+		Details: map[string]interface{}{
+			"current_enrollment": currentCount,
+			"max_capacity":       maxCapacity,
+		},
+	}
+}
+
+// NewAlreadyWaitlistedError creates an error for students joining a waitlist they are already waiting in
+func NewAlreadyWaitlistedError(studentID, courseOfferingID string) *EnrollmentError {
+	return &EnrollmentError{
+		Type:    ErrAlreadyWaitlisted,
+		Message: "Student is already on the waitlist of this course offering",
+		Details: map[string]interface{}{
+			"student_id":         studentID,
+			"course_offering_id": courseOfferingID,
+		},
+	}
+}
+
 // NewCourseOfferingNotFoundError creates an error for missing course offerings
 func NewCourseOfferingNotFoundError(courseOfferingID string) *EnrollmentError {
 	return &EnrollmentError{
@@ -80,6 +194,41 @@ func NewCourseOfferingNotFoundError(courseOfferingID string) *EnrollmentError {
 	}
 }
 
+// NewStudentNotFoundError creates an error for users without an active student record
+func NewStudentNotFoundError(userID string) *EnrollmentError {
+	return &EnrollmentError{
+		Type:    ErrStudentNotFound,
+		Message: "No student record is linked to this account",
+		Details: map[string]interface{}{
+			"user_id": userID,
+		},
+	}
+}
+
+// NewEnrollmentNotFoundError creates an error for drops of registrations that don't exist or are already dropped
+func NewEnrollmentNotFoundError(studentID, courseOfferingID string) *EnrollmentError {
+	return &EnrollmentError{
+		Type:    ErrEnrollmentNotFound,
+		Message: "Student is not enrolled in this course offering",
+		Details: map[string]interface{}{
+			"student_id":         studentID,
+			"course_offering_id": courseOfferingID,
+		},
+	}
+}
+
+// NewWaitlistEntryNotFoundError creates an error for students who are not waiting for a seat in the course offering
+func NewWaitlistEntryNotFoundError(studentID, courseOfferingID string) *EnrollmentError {
+	return &EnrollmentError{
+		Type:    ErrWaitlistEntryNotFound,
+		Message: "Student is not on the waitlist of this course offering",
+		Details: map[string]interface{}{
+			"student_id":         studentID,
+			"course_offering_id": courseOfferingID,
+		},
+	}
+}
+
 // NewInvalidCourseDataError creates an error for invalid course offering data
 func NewInvalidCourseDataError(field, reason string) *EnrollmentError {
 	return &EnrollmentError{
@@ -144,7 +293,9 @@ func GetEnrollmentErrorType(err error) (EnrollmentErrorType, bool) {
 func IsBusinessRuleViolation(err error) bool {
 	if enrollmentErr, ok := err.(*EnrollmentError); ok {
 		switch enrollmentErr.Type {
-		case ErrDuplicateEnrollment, ErrCapacityExceeded, ErrScheduleConflict:
+		case ErrDuplicateEnrollment, ErrCapacityExceeded, ErrScheduleConflict, ErrPrerequisiteNotMet,
+			ErrCorequisiteNotMet, ErrExcludedCourseConflict, ErrStudyPlanLocked, ErrCreditLimitExceeded, ErrDropDeadlinePassed,
+			ErrSeatsAvailable, ErrAlreadyWaitlisted:
 			return true
 		}
 	}
@@ -155,7 +306,8 @@ func IsBusinessRuleViolation(err error) bool {
 func IsDataValidationError(err error) bool {
 	if enrollmentErr, ok := err.(*EnrollmentError); ok {
 		switch enrollmentErr.Type {
-		case ErrCourseOfferingNotFound, ErrInvalidCourseData, ErrInvalidTimestamp:
+		case ErrCourseOfferingNotFound, ErrStudentNotFound, ErrEnrollmentNotFound, ErrWaitlistEntryNotFound, ErrInvalidCourseData,
+			ErrInvalidTimestamp:
 			return true
 		}
 	}
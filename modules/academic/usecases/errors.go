@@ -0,0 +1,78 @@
+package usecases
+
+import "github.com/pkg/errors"
+
+var (
+	ErrOfferingNotFound = errors.New("course offering not found")
+	ErrCourseOutOfScope = errors.New("course is not part of a curriculum of your study program")
+	ErrNoStudyProgram   = errors.New("your account is not linked to a study program")
+
+	ErrCourseNotInActiveCurriculum = errors.New("course is not part of an active curriculum")
+	ErrUnknownSemester             = errors.New("semester does not exist")
+	ErrOfferingOutsideSemester     = errors.New("course offering must start within the dates of its semester")
+
+	ErrCourseNotFound           = errors.New("course not found")
+	ErrCourseCodeAlreadyUsed    = errors.New("course code is already used")
+	ErrCourseHasActiveOfferings = errors.New("course still has offerings in a semester that has not ended")
+
+	ErrPrerequisiteNotFound      = errors.New("course is not a prerequisite of this course")
+	ErrPrerequisiteAlreadyAdded  = errors.New("course is already a prerequisite of this course")
+	ErrPrerequisiteCycle         = errors.New("prerequisite would make the course require itself")
+	ErrUnknownPrerequisiteCourse = errors.New("prerequisite course does not exist")
+
+	ErrCorequisiteNotFound      = errors.New("course is not a co-requisite of this course")
+	ErrCorequisiteAlreadyAdded  = errors.New("course is already a co-requisite of this course")
+	ErrCorequisiteCycle         = errors.New("co-requisite course already requires this course")
+	ErrUnknownCorequisiteCourse = errors.New("co-requisite course does not exist")
+
+	ErrExclusionNotFound     = errors.New("courses do not exclude each other")
+	ErrExclusionAlreadyAdded = errors.New("courses already exclude each other")
+	ErrUnknownExcludedCourse = errors.New("excluded course does not exist")
+
+	ErrCourseRelatedToItself  = errors.New("a course can't be related to itself")
+	ErrCourseRelationConflict = errors.New("co-requisite courses can't exclude each other")
+
+	ErrAcademicYearNotFound          = errors.New("academic year not found")
+	ErrAcademicYearCodeAlreadyUsed   = errors.New("academic year code is already used")
+	ErrAcademicYearHasSemesters      = errors.New("academic year still has semesters")
+	ErrAcademicYearExcludesSemesters = errors.New("academic year must cover the dates of its semesters")
+
+	ErrSemesterNotFound            = errors.New("semester not found")
+	ErrNoCurrentSemester           = errors.New("no semester is running now")
+	ErrSemesterCodeAlreadyUsed     = errors.New("semester code is already used in the academic year")
+	ErrSemesterOutsideAcademicYear = errors.New("semester must start and end within its academic year")
+	ErrSemesterOverlap             = errors.New("semester overlaps another semester of the academic year")
+	ErrSemesterHasOfferings        = errors.New("semester still has course offerings")
+	ErrDropDeadlineOutsideSemester = errors.New("drop deadline must fall within the semester")
+
+	ErrBuildingNotFound        = errors.New("building not found")
+	ErrBuildingCodeAlreadyUsed = errors.New("building code is already used")
+	ErrBuildingHasRooms        = errors.New("building still has rooms")
+
+	ErrRoomNotFound               = errors.New("room not found")
+	ErrRoomCodeAlreadyUsed        = errors.New("room code is already used in the building")
+	ErrRoomHasActiveOfferings     = errors.New("room still has offerings in a semester that has not ended")
+	ErrRoomCapacityBelowOfferings = errors.New("room capacity is below the capacity of its active course offerings")
+
+	ErrUnknownRoom          = errors.New("room does not exist")
+	ErrRoomCapacityTooSmall = errors.New("room capacity is below the course offering capacity")
+	ErrRoomDoubleBooked     = errors.New("room is already used by another course offering at the same time")
+
+	ErrLecturerNotFound        = errors.New("lecturer not found")
+	ErrUnknownLecturer         = errors.New("lecturer does not exist")
+	ErrLecturerAlreadyAssigned = errors.New("lecturer already teaches this course offering")
+	ErrLecturerNotAssigned     = errors.New("lecturer does not teach this course offering")
+	ErrLecturerScheduleClash   = errors.New("lecturer already teaches another course offering at the same time")
+	ErrTeachingLoadExceeded    = errors.New("lecturer would exceed the maximum teaching credits of the semester")
+
+	ErrNotAStudent  = errors.New("no student record is linked to this account")
+	ErrNotALecturer = errors.New("no lecturer record is linked to this account")
+
+	ErrStudyPlanNotFound            = errors.New("study plan not found")
+	ErrStudyPlanLineNotFound        = errors.New("study plan line not found")
+	ErrInvalidStudyPlanStatus       = errors.New("unknown study plan status")
+	ErrStudyPlanNotSubmittable      = errors.New("only a draft or rejected study plan can be submitted")
+	ErrStudyPlanEmpty               = errors.New("study plan has no course registrations to submit")
+	ErrStudyPlanNotSubmitted        = errors.New("study plan is not waiting for review")
+	ErrStudyPlanLineAlreadyReviewed = errors.New("study plan line has already been reviewed")
+)
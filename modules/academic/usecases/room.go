@@ -0,0 +1,398 @@
+package usecases
+
+import (
+	"context"
+	"math"
+	"siakad-poc/common"
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+type BuildingResponse struct {
+	ID        string         `json:"id"`
+	Code      string         `json:"code"`
+	Name      string         `json:"name"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt *time.Time     `json:"updated_at"`
+	Rooms     []RoomResponse `json:"rooms,omitempty"`
+}
+
+type RoomResponse struct {
+	ID         string     `json:"id"`
+	BuildingID string     `json:"building_id"`
+	Code       string     `json:"code"`
+	Name       string     `json:"name"`
+	Capacity   int        `json:"capacity"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  *time.Time `json:"updated_at"`
+}
+
+// BuildingRequest is the payload to create or update a building (gedung)
+type BuildingRequest struct {
+	Code string `json:"code" validate:"required,max=255"`
+	Name string `json:"name" validate:"required,max=255"`
+}
+
+// RoomRequest is the payload to create or update a room (ruang), the capacity is the number of seats
+type RoomRequest struct {
+	Code     string `json:"code" validate:"required,max=255"`
+	Name     string `json:"name" validate:"required,max=255"`
+	Capacity int    `json:"capacity" validate:"required,gte=1"`
+}
+
+type RoomUseCase struct {
+	roomRepository repositories.RoomRepository
+}
+
+func NewRoomUseCase(roomRepository repositories.RoomRepository) *RoomUseCase {
+	return &RoomUseCase{
+		roomRepository: roomRepository,
+	}
+}
+
+func (uc *RoomUseCase) ListBuildings(ctx context.Context, page, pageSize int) ([]BuildingResponse, *common.PaginationMetadata, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+
+	buildings, err := uc.roomRepository.ListBuildings(ctx, pageSize, offset)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot get buildings")
+	}
+
+	totalRecords, err := uc.roomRepository.CountBuildings(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot count buildings")
+	}
+
+	responses := make([]BuildingResponse, 0, len(buildings))
+	for _, building := range buildings {
+		responses = append(responses, toBuildingResponse(building))
+	}
+
+	pagination := &common.PaginationMetadata{
+		Page:         page,
+		PageSize:     pageSize,
+		TotalRecords: int(totalRecords),
+		TotalPages:   int(math.Ceil(float64(totalRecords) / float64(pageSize))),
+	}
+
+	return responses, pagination, nil
+}
+
+// GetBuilding returns the building with its rooms.
+func (uc *RoomUseCase) GetBuilding(ctx context.Context, id string) (BuildingResponse, error) {
+	building, err := uc.getBuilding(ctx, id)
+	if err != nil {
+		return BuildingResponse{}, err
+	}
+
+	rooms, err := uc.roomRepository.ListRoomsByBuilding(ctx, id)
+	if err != nil {
+		return BuildingResponse{}, errors.Wrap(err, "cannot get rooms")
+	}
+
+	response := toBuildingResponse(building)
+	response.Rooms = make([]RoomResponse, 0, len(rooms))
+	for _, room := range rooms {
+		response.Rooms = append(response.Rooms, toRoomResponse(room))
+	}
+
+	return response, nil
+}
+
+func (uc *RoomUseCase) CreateBuilding(ctx context.Context, req BuildingRequest) (BuildingResponse, error) {
+	err := uc.ensureBuildingCodeAvailable(ctx, "", req.Code)
+	if err != nil {
+		return BuildingResponse{}, err
+	}
+
+	building, err := uc.roomRepository.CreateBuilding(ctx, uuid.NewString(), toBuildingAttributes(req))
+	if err != nil {
+		return BuildingResponse{}, errors.Wrap(err, "cannot create building")
+	}
+
+	return toBuildingResponse(building), nil
+}
+
+func (uc *RoomUseCase) UpdateBuilding(ctx context.Context, id string, req BuildingRequest) (BuildingResponse, error) {
+	_, err := uc.getBuilding(ctx, id)
+	if err != nil {
+		return BuildingResponse{}, err
+	}
+
+	err = uc.ensureBuildingCodeAvailable(ctx, id, req.Code)
+	if err != nil {
+		return BuildingResponse{}, err
+	}
+
+	building, err := uc.roomRepository.UpdateBuilding(ctx, id, toBuildingAttributes(req))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return BuildingResponse{}, ErrBuildingNotFound
+		}
+		return BuildingResponse{}, errors.Wrap(err, "cannot update building")
+	}
+
+	return toBuildingResponse(building), nil
+}
+
+// DeleteBuilding soft-deletes a building that has no rooms left.
+func (uc *RoomUseCase) DeleteBuilding(ctx context.Context, id string) error {
+	_, err := uc.getBuilding(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	rooms, err := uc.roomRepository.ListRoomsByBuilding(ctx, id)
+	if err != nil {
+		return errors.Wrap(err, "cannot get rooms")
+	}
+	if len(rooms) > 0 {
+		return ErrBuildingHasRooms
+	}
+
+	_, err = uc.roomRepository.DeleteBuilding(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrBuildingNotFound
+		}
+		return errors.Wrap(err, "cannot delete building")
+	}
+
+	return nil
+}
+
+func (uc *RoomUseCase) ListRooms(ctx context.Context, filter repositories.RoomFilter, page, pageSize int) ([]RoomResponse, *common.PaginationMetadata, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+
+	rooms, err := uc.roomRepository.ListRooms(ctx, filter, pageSize, offset)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot get rooms")
+	}
+
+	totalRecords, err := uc.roomRepository.CountRooms(ctx, filter)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot count rooms")
+	}
+
+	responses := make([]RoomResponse, 0, len(rooms))
+	for _, room := range rooms {
+		responses = append(responses, toRoomResponse(room))
+	}
+
+	pagination := &common.PaginationMetadata{
+		Page:         page,
+		PageSize:     pageSize,
+		TotalRecords: int(totalRecords),
+		TotalPages:   int(math.Ceil(float64(totalRecords) / float64(pageSize))),
+	}
+
+	return responses, pagination, nil
+}
+
+func (uc *RoomUseCase) GetRoom(ctx context.Context, id string) (RoomResponse, error) {
+	room, err := uc.getRoom(ctx, id)
+	if err != nil {
+		return RoomResponse{}, err
+	}
+
+	return toRoomResponse(room), nil
+}
+
+// CreateRoom adds a room to a building, room codes are unique within the building.
+func (uc *RoomUseCase) CreateRoom(ctx context.Context, buildingID string, req RoomRequest) (RoomResponse, error) {
+	_, err := uc.getBuilding(ctx, buildingID)
+	if err != nil {
+		return RoomResponse{}, err
+	}
+
+	err = uc.ensureRoomCodeAvailable(ctx, buildingID, "", req.Code)
+	if err != nil {
+		return RoomResponse{}, err
+	}
+
+	room, err := uc.roomRepository.CreateRoom(ctx, uuid.NewString(), buildingID, toRoomAttributes(req))
+	if err != nil {
+		return RoomResponse{}, errors.Wrap(err, "cannot create room")
+	}
+
+	return toRoomResponse(room), nil
+}
+
+// UpdateRoom changes a room, it must still seat every course offering held there in a semester that has not ended.
+func (uc *RoomUseCase) UpdateRoom(ctx context.Context, id string, req RoomRequest) (RoomResponse, error) {
+	room, err := uc.getRoom(ctx, id)
+	if err != nil {
+		return RoomResponse{}, err
+	}
+
+	err = uc.ensureRoomCodeAvailable(ctx, room.BuildingID.String(), id, req.Code)
+	if err != nil {
+		return RoomResponse{}, err
+	}
+
+	largestCapacity, err := uc.roomRepository.GetLargestActiveCourseOfferingCapacityByRoom(ctx, id)
+	if err != nil {
+		return RoomResponse{}, errors.Wrap(err, "cannot get course offering capacities")
+	}
+	if req.Capacity < int(largestCapacity) {
+		return RoomResponse{}, errors.Wrapf(ErrRoomCapacityBelowOfferings, "an active course offering has %d seats", largestCapacity)
+	}
+
+	room, err = uc.roomRepository.UpdateRoom(ctx, id, toRoomAttributes(req))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return RoomResponse{}, ErrRoomNotFound
+		}
+		return RoomResponse{}, errors.Wrap(err, "cannot update room")
+	}
+
+	return toRoomResponse(room), nil
+}
+
+// DeleteRoom soft-deletes a room that no course offering uses in a semester that has not ended.
+func (uc *RoomUseCase) DeleteRoom(ctx context.Context, id string) error {
+	_, err := uc.getRoom(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	offerings, err := uc.roomRepository.CountActiveCourseOfferingsByRoom(ctx, id)
+	if err != nil {
+		return errors.Wrap(err, "cannot count course offerings")
+	}
+	if offerings > 0 {
+		return ErrRoomHasActiveOfferings
+	}
+
+	_, err = uc.roomRepository.DeleteRoom(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrRoomNotFound
+		}
+		return errors.Wrap(err, "cannot delete room")
+	}
+
+	return nil
+}
+
+func (uc *RoomUseCase) getBuilding(ctx context.Context, id string) (generated.Building, error) {
+	building, err := uc.roomRepository.GetBuilding(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return generated.Building{}, ErrBuildingNotFound
+		}
+		return generated.Building{}, errors.Wrap(err, "cannot get building")
+	}
+
+	return building, nil
+}
+
+func (uc *RoomUseCase) getRoom(ctx context.Context, id string) (generated.Room, error) {
+	room, err := uc.roomRepository.GetRoom(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return generated.Room{}, ErrRoomNotFound
+		}
+		return generated.Room{}, errors.Wrap(err, "cannot get room")
+	}
+
+	return room, nil
+}
+
+// ensureBuildingCodeAvailable checks the code uniqueness, id is the building being updated, if any.
+func (uc *RoomUseCase) ensureBuildingCodeAvailable(ctx context.Context, id, code string) error {
+	existing, err := uc.roomRepository.GetBuildingByCode(ctx, code)
+	if err == nil && existing.ID.String() != id {
+		return ErrBuildingCodeAlreadyUsed
+	}
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return errors.Wrap(err, "cannot check building code availability")
+	}
+
+	return nil
+}
+
+// ensureRoomCodeAvailable checks the code uniqueness within the building, id is the room being updated, if any.
+func (uc *RoomUseCase) ensureRoomCodeAvailable(ctx context.Context, buildingID, id, code string) error {
+	existing, err := uc.roomRepository.GetRoomByCode(ctx, buildingID, code)
+	if err == nil && existing.ID.String() != id {
+		return ErrRoomCodeAlreadyUsed
+	}
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return errors.Wrap(err, "cannot check room code availability")
+	}
+
+	return nil
+}
+
+func toBuildingAttributes(req BuildingRequest) repositories.BuildingAttributes {
+	return repositories.BuildingAttributes{
+		Code: req.Code,
+		Name: req.Name,
+	}
+}
+
+func toRoomAttributes(req RoomRequest) repositories.RoomAttributes {
+	return repositories.RoomAttributes{
+		Code:     req.Code,
+		Name:     req.Name,
+		Capacity: req.Capacity,
+	}
+}
+
+func toBuildingResponse(building generated.Building) BuildingResponse {
+	response := BuildingResponse{
+		ID:   building.ID.String(),
+		Code: building.Code,
+		Name: building.Name,
+	}
+
+	if building.CreatedAt.Valid {
+		response.CreatedAt = building.CreatedAt.Time
+	}
+	if building.UpdatedAt.Valid {
+		updatedAt := building.UpdatedAt.Time
+		response.UpdatedAt = &updatedAt
+	}
+
+	return response
+}
+
+func toRoomResponse(room generated.Room) RoomResponse {
+	response := RoomResponse{
+		ID:         room.ID.String(),
+		BuildingID: room.BuildingID.String(),
+		Code:       room.Code,
+		Name:       room.Name,
+		Capacity:   int(room.Capacity),
+	}
+
+	if room.CreatedAt.Valid {
+		response.CreatedAt = room.CreatedAt.Time
+	}
+	if room.UpdatedAt.Valid {
+		updatedAt := room.UpdatedAt.Time
+		response.UpdatedAt = &updatedAt
+	}
+
+	return response
+}
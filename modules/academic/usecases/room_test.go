@@ -0,0 +1,273 @@
+package usecases
+
+import (
+	"context"
+	"siakad-poc/common"
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// Mock repository for building and room tests
+type MockRoomRepository struct {
+	mock.Mock
+}
+
+func (m *MockRoomRepository) ListBuildings(ctx context.Context, limit, offset int) ([]generated.Building, error) {
+	args := m.Called(ctx, limit, offset)
+	return args.Get(0).([]generated.Building), args.Error(1)
+}
+
+func (m *MockRoomRepository) CountBuildings(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRoomRepository) GetBuilding(ctx context.Context, id string) (generated.Building, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.Building), args.Error(1)
+}
+
+func (m *MockRoomRepository) GetBuildingByCode(ctx context.Context, code string) (generated.Building, error) {
+	args := m.Called(ctx, code)
+	return args.Get(0).(generated.Building), args.Error(1)
+}
+
+func (m *MockRoomRepository) CreateBuilding(ctx context.Context, id string, attributes repositories.BuildingAttributes) (generated.Building, error) {
+	args := m.Called(ctx, id, attributes)
+	return args.Get(0).(generated.Building), args.Error(1)
+}
+
+func (m *MockRoomRepository) UpdateBuilding(ctx context.Context, id string, attributes repositories.BuildingAttributes) (generated.Building, error) {
+	args := m.Called(ctx, id, attributes)
+	return args.Get(0).(generated.Building), args.Error(1)
+}
+
+func (m *MockRoomRepository) DeleteBuilding(ctx context.Context, id string) (generated.Building, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.Building), args.Error(1)
+}
+
+func (m *MockRoomRepository) ListRooms(ctx context.Context, filter repositories.RoomFilter, limit, offset int) ([]generated.Room, error) {
+	args := m.Called(ctx, filter, limit, offset)
+	return args.Get(0).([]generated.Room), args.Error(1)
+}
+
+func (m *MockRoomRepository) CountRooms(ctx context.Context, filter repositories.RoomFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRoomRepository) ListRoomsByBuilding(ctx context.Context, buildingID string) ([]generated.Room, error) {
+	args := m.Called(ctx, buildingID)
+	return args.Get(0).([]generated.Room), args.Error(1)
+}
+
+func (m *MockRoomRepository) GetRoom(ctx context.Context, id string) (generated.Room, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.Room), args.Error(1)
+}
+
+func (m *MockRoomRepository) GetRoomForUpdateTx(txCtx *common.TxContext, id string) (generated.Room, error) {
+	args := m.Called(txCtx, id)
+	return args.Get(0).(generated.Room), args.Error(1)
+}
+
+func (m *MockRoomRepository) GetRoomByCode(ctx context.Context, buildingID, code string) (generated.Room, error) {
+	args := m.Called(ctx, buildingID, code)
+	return args.Get(0).(generated.Room), args.Error(1)
+}
+
+func (m *MockRoomRepository) CreateRoom(ctx context.Context, id, buildingID string, attributes repositories.RoomAttributes) (generated.Room, error) {
+	args := m.Called(ctx, id, buildingID, attributes)
+	return args.Get(0).(generated.Room), args.Error(1)
+}
+
+func (m *MockRoomRepository) UpdateRoom(ctx context.Context, id string, attributes repositories.RoomAttributes) (generated.Room, error) {
+	args := m.Called(ctx, id, attributes)
+	return args.Get(0).(generated.Room), args.Error(1)
+}
+
+func (m *MockRoomRepository) DeleteRoom(ctx context.Context, id string) (generated.Room, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.Room), args.Error(1)
+}
+
+func (m *MockRoomRepository) GetLargestActiveCourseOfferingCapacityByRoom(ctx context.Context, roomID string) (int32, error) {
+	args := m.Called(ctx, roomID)
+	return args.Get(0).(int32), args.Error(1)
+}
+
+func (m *MockRoomRepository) CountActiveCourseOfferingsByRoom(ctx context.Context, roomID string) (int64, error) {
+	args := m.Called(ctx, roomID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// Test Suite
+type RoomUseCaseTestSuite struct {
+	suite.Suite
+	useCase      *RoomUseCase
+	mockRepo     *MockRoomRepository
+	ctx          context.Context
+	buildingUUID pgtype.UUID
+	roomUUID     pgtype.UUID
+	building     generated.Building
+	room         generated.Room
+}
+
+func (suite *RoomUseCaseTestSuite) SetupTest() {
+	suite.mockRepo = new(MockRoomRepository)
+	suite.useCase = NewRoomUseCase(suite.mockRepo)
+	suite.ctx = context.Background()
+
+	suite.buildingUUID = pgtype.UUID{
+		Bytes: [16]byte{6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21},
+		Valid: true,
+	}
+	suite.roomUUID = pgtype.UUID{
+		Bytes: [16]byte{7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22},
+		Valid: true,
+	}
+
+	// Building GKB with a 40 seat classroom
+	suite.building = generated.Building{
+		ID:   suite.buildingUUID,
+		Code: "GKB",
+		Name: "Gedung Kuliah Bersama",
+	}
+	suite.room = generated.Room{
+		ID:         suite.roomUUID,
+		BuildingID: suite.buildingUUID,
+		Code:       "GKB-101",
+		Name:       "Ruang 101",
+		Capacity:   40,
+	}
+}
+
+func (suite *RoomUseCaseTestSuite) TearDownTest() {
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// Test successful room creation in a building
+func (suite *RoomUseCaseTestSuite) TestCreateRoom_Success() {
+	buildingID := suite.buildingUUID.String()
+	req := RoomRequest{Code: "GKB-101", Name: "Ruang 101", Capacity: 40}
+
+	suite.mockRepo.On("GetBuilding", suite.ctx, buildingID).Return(suite.building, nil)
+	suite.mockRepo.On("GetRoomByCode", suite.ctx, buildingID, req.Code).Return(generated.Room{}, pgx.ErrNoRows)
+	suite.mockRepo.On("CreateRoom", suite.ctx, mock.AnythingOfType("string"), buildingID, toRoomAttributes(req)).Return(suite.room, nil)
+
+	response, err := suite.useCase.CreateRoom(suite.ctx, buildingID, req)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "GKB-101", response.Code)
+	assert.Equal(suite.T(), buildingID, response.BuildingID)
+	assert.Equal(suite.T(), 40, response.Capacity)
+}
+
+// Test creating a room with a code already used in the building
+func (suite *RoomUseCaseTestSuite) TestCreateRoom_CodeAlreadyUsed() {
+	buildingID := suite.buildingUUID.String()
+	req := RoomRequest{Code: "GKB-101", Name: "Ruang 101", Capacity: 40}
+
+	suite.mockRepo.On("GetBuilding", suite.ctx, buildingID).Return(suite.building, nil)
+	suite.mockRepo.On("GetRoomByCode", suite.ctx, buildingID, req.Code).Return(suite.room, nil)
+
+	_, err := suite.useCase.CreateRoom(suite.ctx, buildingID, req)
+
+	assert.ErrorIs(suite.T(), err, ErrRoomCodeAlreadyUsed)
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateRoom")
+}
+
+// Test creating a room in a building that does not exist
+func (suite *RoomUseCaseTestSuite) TestCreateRoom_BuildingNotFound() {
+	buildingID := suite.buildingUUID.String()
+	req := RoomRequest{Code: "GKB-101", Name: "Ruang 101", Capacity: 40}
+
+	suite.mockRepo.On("GetBuilding", suite.ctx, buildingID).Return(generated.Building{}, pgx.ErrNoRows)
+
+	_, err := suite.useCase.CreateRoom(suite.ctx, buildingID, req)
+
+	assert.ErrorIs(suite.T(), err, ErrBuildingNotFound)
+}
+
+// Test shrinking a room below the capacity of an offering held there
+func (suite *RoomUseCaseTestSuite) TestUpdateRoom_CapacityBelowOfferings() {
+	id := suite.roomUUID.String()
+	req := RoomRequest{Code: "GKB-101", Name: "Ruang 101", Capacity: 30}
+
+	suite.mockRepo.On("GetRoom", suite.ctx, id).Return(suite.room, nil)
+	suite.mockRepo.On("GetRoomByCode", suite.ctx, suite.buildingUUID.String(), req.Code).Return(suite.room, nil)
+	suite.mockRepo.On("GetLargestActiveCourseOfferingCapacityByRoom", suite.ctx, id).Return(int32(35), nil)
+
+	_, err := suite.useCase.UpdateRoom(suite.ctx, id, req)
+
+	assert.ErrorIs(suite.T(), err, ErrRoomCapacityBelowOfferings)
+	suite.mockRepo.AssertNotCalled(suite.T(), "UpdateRoom")
+}
+
+// Test updating a room that still seats its offerings
+func (suite *RoomUseCaseTestSuite) TestUpdateRoom_Success() {
+	id := suite.roomUUID.String()
+	req := RoomRequest{Code: "GKB-101", Name: "Ruang 101", Capacity: 35}
+
+	suite.mockRepo.On("GetRoom", suite.ctx, id).Return(suite.room, nil)
+	suite.mockRepo.On("GetRoomByCode", suite.ctx, suite.buildingUUID.String(), req.Code).Return(suite.room, nil)
+	suite.mockRepo.On("GetLargestActiveCourseOfferingCapacityByRoom", suite.ctx, id).Return(int32(35), nil)
+	suite.mockRepo.On("UpdateRoom", suite.ctx, id, toRoomAttributes(req)).Return(suite.room, nil)
+
+	_, err := suite.useCase.UpdateRoom(suite.ctx, id, req)
+
+	assert.NoError(suite.T(), err)
+}
+
+// Test deleting a room still used by an offering of a running semester
+func (suite *RoomUseCaseTestSuite) TestDeleteRoom_HasActiveOfferings() {
+	id := suite.roomUUID.String()
+
+	suite.mockRepo.On("GetRoom", suite.ctx, id).Return(suite.room, nil)
+	suite.mockRepo.On("CountActiveCourseOfferingsByRoom", suite.ctx, id).Return(int64(2), nil)
+
+	err := suite.useCase.DeleteRoom(suite.ctx, id)
+
+	assert.ErrorIs(suite.T(), err, ErrRoomHasActiveOfferings)
+	suite.mockRepo.AssertNotCalled(suite.T(), "DeleteRoom")
+}
+
+// Test deleting a building that still has rooms
+func (suite *RoomUseCaseTestSuite) TestDeleteBuilding_HasRooms() {
+	id := suite.buildingUUID.String()
+
+	suite.mockRepo.On("GetBuilding", suite.ctx, id).Return(suite.building, nil)
+	suite.mockRepo.On("ListRoomsByBuilding", suite.ctx, id).Return([]generated.Room{suite.room}, nil)
+
+	err := suite.useCase.DeleteBuilding(suite.ctx, id)
+
+	assert.ErrorIs(suite.T(), err, ErrBuildingHasRooms)
+	suite.mockRepo.AssertNotCalled(suite.T(), "DeleteBuilding")
+}
+
+// Test the building detail lists its rooms
+func (suite *RoomUseCaseTestSuite) TestGetBuilding_WithRooms() {
+	id := suite.buildingUUID.String()
+
+	suite.mockRepo.On("GetBuilding", suite.ctx, id).Return(suite.building, nil)
+	suite.mockRepo.On("ListRoomsByBuilding", suite.ctx, id).Return([]generated.Room{suite.room}, nil)
+
+	response, err := suite.useCase.GetBuilding(suite.ctx, id)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "GKB", response.Code)
+	assert.Len(suite.T(), response.Rooms, 1)
+	assert.Equal(suite.T(), "GKB-101", response.Rooms[0].Code)
+}
+
+func TestRoomUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(RoomUseCaseTestSuite))
+}
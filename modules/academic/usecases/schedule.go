@@ -0,0 +1,142 @@
+package usecases
+
+import (
+	"fmt"
+	"siakad-poc/db/generated"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// weeklySlot is a weekly meeting of a course offering. Day is the ISO day of the week (1 is Monday),
+// start and end are the times of day as durations since midnight.
+type weeklySlot struct {
+	day   int
+	start time.Duration
+	end   time.Duration
+}
+
+// String formats the slot as "Monday 09:00-11:30"
+func (s weeklySlot) String() string {
+	return fmt.Sprintf("%s %s-%s", isoWeekday(s.day), formatTimeOfDay(s.start), formatTimeOfDay(s.end))
+}
+
+// recurringSchedule is the timetable of a course offering: its weekly slots repeat every week from the
+// first to the last day, both included.
+type recurringSchedule struct {
+	firstDay time.Time
+	lastDay  time.Time
+	slots    []weeklySlot
+}
+
+// newOfferingSchedule builds the timetable of a course offering, every meeting lasting the class time of its credits.
+// The weekly schedules repeat within the semester. An offering without weekly schedules or semester bounds
+// meets once, at its start time. Days are taken in loc, the time zone the weekly schedules are stored in.
+func newOfferingSchedule(startTime, semesterStart, semesterEnd pgtype.Timestamptz, schedules []generated.CourseOfferingSchedule, duration time.Duration, loc *time.Location) (recurringSchedule, error) {
+	if len(schedules) == 0 || !semesterStart.Valid || !semesterEnd.Valid {
+		start, err := convertPgTimestamp(startTime)
+		if err != nil {
+			return recurringSchedule{}, err
+		}
+
+		start = inLocation(start, loc)
+		day := startOfDay(start)
+		slotStart := start.Sub(day)
+		return recurringSchedule{
+			firstDay: day,
+			lastDay:  day,
+			slots:    []weeklySlot{{day: isoDay(start), start: slotStart, end: slotStart + duration}},
+		}, nil
+	}
+
+	slots := make([]weeklySlot, 0, len(schedules))
+	for _, schedule := range schedules {
+		if !schedule.StartTime.Valid {
+			return recurringSchedule{}, NewInvalidTimestampError("course offering schedule start time")
+		}
+		slotStart := time.Duration(schedule.StartTime.Microseconds) * time.Microsecond
+		slots = append(slots, weeklySlot{day: int(schedule.DayOfWeek), start: slotStart, end: slotStart + duration})
+	}
+
+	return recurringSchedule{
+		firstDay: startOfDay(inLocation(semesterStart.Time, loc)),
+		lastDay:  startOfDay(inLocation(semesterEnd.Time, loc)),
+		slots:    slots,
+	}, nil
+}
+
+// findScheduleOverlap returns the first pair of slots meeting at the same time on a day both schedules run.
+// Slots on the same day of the week are compared with hasTimeOverlap, so adjacent slots don't overlap.
+// Example: Monday [9:00-11:30] overlaps Monday [10:00-12:00] when both semesters share at least one Monday.
+func findScheduleOverlap(schedule1, schedule2 recurringSchedule) (weeklySlot, weeklySlot, bool) {
+	from := schedule1.firstDay
+	if schedule2.firstDay.After(from) {
+		from = schedule2.firstDay
+	}
+	to := schedule1.lastDay
+	if schedule2.lastDay.Before(to) {
+		to = schedule2.lastDay
+	}
+	if to.Before(from) {
+		return weeklySlot{}, weeklySlot{}, false
+	}
+
+	for _, slot1 := range schedule1.slots {
+		for _, slot2 := range schedule2.slots {
+			if slot1.day != slot2.day || !occursBetween(slot1.day, from, to) {
+				continue
+			}
+			// Compare the times of day on the same reference date
+			var day time.Time
+			if hasTimeOverlap(day.Add(slot1.start), day.Add(slot1.end), day.Add(slot2.start), day.Add(slot2.end)) {
+				return slot1, slot2, true
+			}
+		}
+	}
+
+	return weeklySlot{}, weeklySlot{}, false
+}
+
+// occursBetween reports whether the ISO day of the week falls between both days, both included.
+func occursBetween(isoDayOfWeek int, from, to time.Time) bool {
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if isoDay(day) == isoDayOfWeek {
+			return true
+		}
+		// Every day of the week has been checked
+		if day.Sub(from) >= 6*24*time.Hour {
+			break
+		}
+	}
+	return false
+}
+
+// isoDay returns the ISO day of the week of t, 1 is Monday and 7 is Sunday
+func isoDay(t time.Time) int {
+	if t.Weekday() == time.Sunday {
+		return 7
+	}
+	return int(t.Weekday())
+}
+
+// isoWeekday returns the weekday of an ISO day of the week
+func isoWeekday(isoDayOfWeek int) time.Weekday {
+	return time.Weekday(isoDayOfWeek % 7)
+}
+
+// inLocation returns t on the clock of loc, nil means UTC
+func inLocation(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		return t.UTC()
+	}
+	return t.In(loc)
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// formatTimeOfDay formats a duration since midnight as "15:04"
+func formatTimeOfDay(d time.Duration) string {
+	return time.Time{}.Add(d).Format("15:04")
+}
@@ -0,0 +1,593 @@
+package usecases
+
+import (
+	"context"
+	"siakad-poc/common"
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+// Statuses of a study plan (KRS), a plan is a draft until the student submits it to the academic advisor, who
+// approves it or rejects it back to the student for revision.
+const (
+	StudyPlanStatusDraft     = "draft"
+	StudyPlanStatusSubmitted = "submitted"
+	StudyPlanStatusApproved  = "approved"
+	StudyPlanStatusRejected  = "rejected"
+)
+
+// Statuses of a study plan line, rejected lines don't count as taken by the student
+const (
+	StudyPlanLineStatusPending  = "pending"
+	StudyPlanLineStatusApproved = "approved"
+	StudyPlanLineStatusRejected = "rejected"
+)
+
+// ReviewStudyPlanRequest is the payload to approve a study plan or one of its lines, the note is optional
+type ReviewStudyPlanRequest struct {
+	Note string `json:"note" validate:"max=1000"`
+}
+
+// RejectStudyPlanRequest is the payload to reject a study plan or one of its lines, the student is told why
+type RejectStudyPlanRequest struct {
+	Note string `json:"note" validate:"required,max=1000"`
+}
+
+type StudyPlanResponse struct {
+	ID           string     `json:"id"`
+	StudentID    string     `json:"student_id"`
+	NIM          string     `json:"nim"`
+	StudentName  *string    `json:"student_name"`
+	SemesterID   string     `json:"semester_id"`
+	SemesterCode string     `json:"semester_code"`
+	Status       string     `json:"status"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    *time.Time `json:"updated_at"`
+}
+
+// StudyPlanLineResponse is a course registration of a study plan (rencana studi detail)
+type StudyPlanLineResponse struct {
+	ID               string     `json:"id"`
+	CourseOfferingID string     `json:"course_offering_id"`
+	CourseCode       string     `json:"course_code"`
+	CourseName       string     `json:"course_name"`
+	SectionCode      string     `json:"section_code"`
+	Credit           int        `json:"credit"`
+	Status           string     `json:"status"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        *time.Time `json:"updated_at"`
+}
+
+// StudyPlanHistoryResponse is a status transition of the plan, or of one of its lines when line_id is set
+type StudyPlanHistoryResponse struct {
+	LineID        *string   `json:"line_id"`
+	StatusBefore  string    `json:"status_before"`
+	StatusAfter   string    `json:"status_after"`
+	Note          *string   `json:"note"`
+	ChangedBy     string    `json:"changed_by"`
+	ChangedByName *string   `json:"changed_by_name"`
+	ChangedAt     time.Time `json:"changed_at"`
+}
+
+// StudyPlanDetailResponse is a study plan with its lines and audit trail, total_credits sums the lines that are
+// not rejected.
+type StudyPlanDetailResponse struct {
+	StudyPlanResponse
+	TotalCredits int                        `json:"total_credits"`
+	Lines        []StudyPlanLineResponse    `json:"lines"`
+	Histories    []StudyPlanHistoryResponse `json:"histories"`
+}
+
+type StudyPlanUseCase struct {
+	repo         repositories.StudyPlanRepository
+	studentRepo  repositories.StudentRepository
+	lecturerRepo repositories.LecturerRepository
+	waitlist     WaitlistPromoter
+	txExecutor   common.TransactionExecutor
+}
+
+func NewStudyPlanUseCase(repo repositories.StudyPlanRepository, studentRepo repositories.StudentRepository, lecturerRepo repositories.LecturerRepository, waitlist WaitlistPromoter, txExecutor common.TransactionExecutor) *StudyPlanUseCase {
+	return &StudyPlanUseCase{
+		repo:         repo,
+		studentRepo:  studentRepo,
+		lecturerRepo: lecturerRepo,
+		waitlist:     waitlist,
+		txExecutor:   txExecutor,
+	}
+}
+
+// ListOwnStudyPlans returns the study plans of the student record linked to the user, the latest semester first.
+func (uc *StudyPlanUseCase) ListOwnStudyPlans(ctx context.Context, userID string) ([]StudyPlanResponse, error) {
+	student, err := uc.getStudentOfUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	studyPlans, err := uc.repo.ListStudyPlansByStudent(ctx, uuidToString(student.ID))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get study plans")
+	}
+
+	responses := make([]StudyPlanResponse, 0, len(studyPlans))
+	for _, studyPlan := range studyPlans {
+		responses = append(responses, toStudyPlanResponse(generated.GetStudyPlanWithStudentRow(studyPlan)))
+	}
+	return responses, nil
+}
+
+func (uc *StudyPlanUseCase) GetOwnStudyPlan(ctx context.Context, userID, id string) (StudyPlanDetailResponse, error) {
+	studyPlan, err := uc.getOwnStudyPlan(ctx, userID, id)
+	if err != nil {
+		return StudyPlanDetailResponse{}, err
+	}
+
+	return uc.toStudyPlanDetailResponse(ctx, studyPlan)
+}
+
+// SubmitStudyPlan sends a draft or rejected study plan to the academic advisor for review, it needs at least one
+// line that is not rejected.
+func (uc *StudyPlanUseCase) SubmitStudyPlan(ctx context.Context, userID, id string) (StudyPlanDetailResponse, error) {
+	studyPlan, err := uc.getOwnStudyPlan(ctx, userID, id)
+	if err != nil {
+		return StudyPlanDetailResponse{}, err
+	}
+
+	err = uc.txExecutor.WithTxContext(ctx, func(txCtx *common.TxContext) error {
+		locked, err := uc.repo.GetStudyPlanForUpdateTx(txCtx, id)
+		if err != nil {
+			return errors.Wrap(err, "cannot get study plan")
+		}
+		if !isStudyPlanEditable(locked.Status) {
+			return errors.Wrapf(ErrStudyPlanNotSubmittable, "study plan is %s", locked.Status)
+		}
+
+		lines, err := uc.repo.ListStudyPlanLinesTx(txCtx, id)
+		if err != nil {
+			return errors.Wrap(err, "cannot get study plan lines")
+		}
+		if countActiveStudyPlanLines(lines) == 0 {
+			return ErrStudyPlanEmpty
+		}
+
+		return uc.changeStudyPlanStatusTx(txCtx, locked, StudyPlanStatusSubmitted, userID, "")
+	})
+	if err != nil {
+		return StudyPlanDetailResponse{}, err
+	}
+
+	return uc.getStudyPlanDetail(ctx, uuidToString(studyPlan.ID))
+}
+
+// ListAdviseeStudyPlans returns the study plans of the students advised by the lecturer linked to the user, an empty
+// status lists every plan.
+func (uc *StudyPlanUseCase) ListAdviseeStudyPlans(ctx context.Context, userID, status string) ([]StudyPlanResponse, error) {
+	if status != "" && !isStudyPlanStatus(status) {
+		return nil, errors.Wrapf(ErrInvalidStudyPlanStatus, "status %s", status)
+	}
+
+	lecturer, err := uc.getLecturerOfUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	studyPlans, err := uc.repo.ListStudyPlansByAcademicAdvisor(ctx, uuidToString(lecturer.ID), repositories.StudyPlanFilter{Status: status})
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get study plans of the advisees")
+	}
+
+	responses := make([]StudyPlanResponse, 0, len(studyPlans))
+	for _, studyPlan := range studyPlans {
+		responses = append(responses, toStudyPlanResponse(generated.GetStudyPlanWithStudentRow(studyPlan)))
+	}
+	return responses, nil
+}
+
+func (uc *StudyPlanUseCase) GetAdviseeStudyPlan(ctx context.Context, userID, id string) (StudyPlanDetailResponse, error) {
+	studyPlan, err := uc.getAdviseeStudyPlan(ctx, userID, id)
+	if err != nil {
+		return StudyPlanDetailResponse{}, err
+	}
+
+	return uc.toStudyPlanDetailResponse(ctx, studyPlan)
+}
+
+// ApproveStudyPlan approves a submitted study plan with its pending lines, lines already rejected stay rejected.
+func (uc *StudyPlanUseCase) ApproveStudyPlan(ctx context.Context, userID, id string, req ReviewStudyPlanRequest) (StudyPlanDetailResponse, error) {
+	_, err := uc.getAdviseeStudyPlan(ctx, userID, id)
+	if err != nil {
+		return StudyPlanDetailResponse{}, err
+	}
+
+	err = uc.txExecutor.WithTxContext(ctx, func(txCtx *common.TxContext) error {
+		locked, err := uc.getSubmittedStudyPlanTx(txCtx, id)
+		if err != nil {
+			return err
+		}
+
+		lines, err := uc.repo.ListStudyPlanLinesTx(txCtx, id)
+		if err != nil {
+			return errors.Wrap(err, "cannot get study plan lines")
+		}
+		for _, line := range lines {
+			if line.Status != StudyPlanLineStatusPending {
+				continue
+			}
+			err = uc.changeStudyPlanLineStatusTx(txCtx, id, uuidToString(line.ID), line.Status, StudyPlanLineStatusApproved, userID, "")
+			if err != nil {
+				return err
+			}
+		}
+
+		return uc.changeStudyPlanStatusTx(txCtx, locked, StudyPlanStatusApproved, userID, req.Note)
+	})
+	if err != nil {
+		return StudyPlanDetailResponse{}, err
+	}
+
+	return uc.getStudyPlanDetail(ctx, id)
+}
+
+// RejectStudyPlan returns a submitted study plan to the student for revision, the lines keep their status.
+// The plan can take registrations again, so the waitlists the student was passed over in while the plan was
+// reviewed are promoted within the same transaction.
+func (uc *StudyPlanUseCase) RejectStudyPlan(ctx context.Context, userID, id string, req RejectStudyPlanRequest) (StudyPlanDetailResponse, error) {
+	_, err := uc.getAdviseeStudyPlan(ctx, userID, id)
+	if err != nil {
+		return StudyPlanDetailResponse{}, err
+	}
+
+	err = uc.txExecutor.WithTxContext(ctx, func(txCtx *common.TxContext) error {
+		locked, err := uc.getSubmittedStudyPlanTx(txCtx, id)
+		if err != nil {
+			return err
+		}
+
+		err = uc.changeStudyPlanStatusTx(txCtx, locked, StudyPlanStatusRejected, userID, req.Note)
+		if err != nil {
+			return err
+		}
+
+		courseOfferingIDs, err := uc.repo.ListStudyPlanWaitlistedCourseOfferingsTx(txCtx, id)
+		if err != nil {
+			return errors.Wrap(err, "cannot get waitlisted course offerings")
+		}
+		for _, courseOfferingID := range courseOfferingIDs {
+			err = uc.waitlist.PromoteWaitlistTx(txCtx, courseOfferingID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return StudyPlanDetailResponse{}, err
+	}
+
+	return uc.getStudyPlanDetail(ctx, id)
+}
+
+// ApproveStudyPlanLine approves a pending line of a submitted study plan.
+func (uc *StudyPlanUseCase) ApproveStudyPlanLine(ctx context.Context, userID, id, lineID string, req ReviewStudyPlanRequest) (StudyPlanDetailResponse, error) {
+	return uc.reviewStudyPlanLine(ctx, userID, id, lineID, StudyPlanLineStatusApproved, req.Note)
+}
+
+// RejectStudyPlanLine rejects a pending line of a submitted study plan, the seat of the line is freed and given to
+// the waitlist of the offering.
+func (uc *StudyPlanUseCase) RejectStudyPlanLine(ctx context.Context, userID, id, lineID string, req RejectStudyPlanRequest) (StudyPlanDetailResponse, error) {
+	return uc.reviewStudyPlanLine(ctx, userID, id, lineID, StudyPlanLineStatusRejected, req.Note)
+}
+
+func (uc *StudyPlanUseCase) reviewStudyPlanLine(ctx context.Context, userID, id, lineID, status, note string) (StudyPlanDetailResponse, error) {
+	_, err := uc.getAdviseeStudyPlan(ctx, userID, id)
+	if err != nil {
+		return StudyPlanDetailResponse{}, err
+	}
+
+	err = uc.txExecutor.WithTxContext(ctx, func(txCtx *common.TxContext) error {
+		_, err := uc.getSubmittedStudyPlanTx(txCtx, id)
+		if err != nil {
+			return err
+		}
+
+		line, err := uc.repo.GetStudyPlanLineTx(txCtx, id, lineID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrStudyPlanLineNotFound
+			}
+			return errors.Wrap(err, "cannot get study plan line")
+		}
+		if line.Status != StudyPlanLineStatusPending {
+			return errors.Wrapf(ErrStudyPlanLineAlreadyReviewed, "line is %s", line.Status)
+		}
+
+		err = uc.changeStudyPlanLineStatusTx(txCtx, id, lineID, line.Status, status, userID, note)
+		if err != nil {
+			return err
+		}
+		if status != StudyPlanLineStatusRejected {
+			return nil
+		}
+
+		// The rejected line no longer takes a seat of the offering
+		return uc.waitlist.PromoteWaitlistTx(txCtx, uuidToString(line.CourseOfferingID))
+	})
+	if err != nil {
+		return StudyPlanDetailResponse{}, err
+	}
+
+	return uc.getStudyPlanDetail(ctx, id)
+}
+
+// getSubmittedStudyPlanTx locks the study plan, which must be waiting for review.
+func (uc *StudyPlanUseCase) getSubmittedStudyPlanTx(txCtx *common.TxContext, id string) (generated.StudyPlan, error) {
+	studyPlan, err := uc.repo.GetStudyPlanForUpdateTx(txCtx, id)
+	if err != nil {
+		return generated.StudyPlan{}, errors.Wrap(err, "cannot get study plan")
+	}
+	if studyPlan.Status != StudyPlanStatusSubmitted {
+		return generated.StudyPlan{}, errors.Wrapf(ErrStudyPlanNotSubmitted, "study plan is %s", studyPlan.Status)
+	}
+
+	return studyPlan, nil
+}
+
+// changeStudyPlanStatusTx moves the study plan to the status and records the transition made by the user.
+func (uc *StudyPlanUseCase) changeStudyPlanStatusTx(txCtx *common.TxContext, studyPlan generated.StudyPlan, status, userID, note string) error {
+	id := uuidToString(studyPlan.ID)
+	_, err := uc.repo.UpdateStudyPlanStatusTx(txCtx, id, status)
+	if err != nil {
+		return errors.Wrap(err, "cannot update study plan status")
+	}
+
+	_, err = uc.repo.CreateStudyPlanHistoryTx(txCtx, uuid.NewString(), repositories.StudyPlanTransition{
+		StudyPlanID:  id,
+		StatusBefore: studyPlan.Status,
+		StatusAfter:  status,
+		Note:         note,
+		ChangedBy:    userID,
+	})
+	if err != nil {
+		return errors.Wrap(err, "cannot record study plan history")
+	}
+
+	return nil
+}
+
+// changeStudyPlanLineStatusTx moves the line to the status and records the transition made by the user.
+func (uc *StudyPlanUseCase) changeStudyPlanLineStatusTx(txCtx *common.TxContext, id, lineID, statusBefore, status, userID, note string) error {
+	_, err := uc.repo.UpdateStudyPlanLineStatusTx(txCtx, lineID, status)
+	if err != nil {
+		return errors.Wrap(err, "cannot update study plan line status")
+	}
+
+	_, err = uc.repo.CreateStudyPlanHistoryTx(txCtx, uuid.NewString(), repositories.StudyPlanTransition{
+		StudyPlanID:          id,
+		CourseRegistrationID: lineID,
+		StatusBefore:         statusBefore,
+		StatusAfter:          status,
+		Note:                 note,
+		ChangedBy:            userID,
+	})
+	if err != nil {
+		return errors.Wrap(err, "cannot record study plan history")
+	}
+
+	return nil
+}
+
+// getOwnStudyPlan returns the study plan when it belongs to the student record linked to the user, plans of other
+// students are reported as not found.
+func (uc *StudyPlanUseCase) getOwnStudyPlan(ctx context.Context, userID, id string) (generated.GetStudyPlanWithStudentRow, error) {
+	student, err := uc.getStudentOfUser(ctx, userID)
+	if err != nil {
+		return generated.GetStudyPlanWithStudentRow{}, err
+	}
+
+	studyPlan, err := uc.getStudyPlan(ctx, id)
+	if err != nil {
+		return generated.GetStudyPlanWithStudentRow{}, err
+	}
+	if studyPlan.StudentID != student.ID {
+		return generated.GetStudyPlanWithStudentRow{}, ErrStudyPlanNotFound
+	}
+
+	return studyPlan, nil
+}
+
+// getAdviseeStudyPlan returns the study plan when its student is advised by the lecturer linked to the user, plans
+// of other students are reported as not found.
+func (uc *StudyPlanUseCase) getAdviseeStudyPlan(ctx context.Context, userID, id string) (generated.GetStudyPlanWithStudentRow, error) {
+	lecturer, err := uc.getLecturerOfUser(ctx, userID)
+	if err != nil {
+		return generated.GetStudyPlanWithStudentRow{}, err
+	}
+
+	studyPlan, err := uc.getStudyPlan(ctx, id)
+	if err != nil {
+		return generated.GetStudyPlanWithStudentRow{}, err
+	}
+	if studyPlan.AcademicAdvisorID != lecturer.ID {
+		return generated.GetStudyPlanWithStudentRow{}, ErrStudyPlanNotFound
+	}
+
+	return studyPlan, nil
+}
+
+func (uc *StudyPlanUseCase) getStudyPlan(ctx context.Context, id string) (generated.GetStudyPlanWithStudentRow, error) {
+	studyPlan, err := uc.repo.GetStudyPlanWithStudent(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return generated.GetStudyPlanWithStudentRow{}, ErrStudyPlanNotFound
+		}
+		return generated.GetStudyPlanWithStudentRow{}, errors.Wrap(err, "cannot get study plan")
+	}
+
+	return studyPlan, nil
+}
+
+func (uc *StudyPlanUseCase) getStudentOfUser(ctx context.Context, userID string) (generated.Student, error) {
+	student, err := uc.studentRepo.GetStudentByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return generated.Student{}, ErrNotAStudent
+		}
+		return generated.Student{}, errors.Wrap(err, "cannot get student record")
+	}
+	if student.DeletedAt.Valid {
+		return generated.Student{}, ErrNotAStudent
+	}
+
+	return student, nil
+}
+
+func (uc *StudyPlanUseCase) getLecturerOfUser(ctx context.Context, userID string) (generated.Lecturer, error) {
+	lecturer, err := uc.lecturerRepo.GetLecturerByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return generated.Lecturer{}, ErrNotALecturer
+		}
+		return generated.Lecturer{}, errors.Wrap(err, "cannot get lecturer record")
+	}
+
+	return lecturer, nil
+}
+
+func (uc *StudyPlanUseCase) getStudyPlanDetail(ctx context.Context, id string) (StudyPlanDetailResponse, error) {
+	studyPlan, err := uc.getStudyPlan(ctx, id)
+	if err != nil {
+		return StudyPlanDetailResponse{}, err
+	}
+
+	return uc.toStudyPlanDetailResponse(ctx, studyPlan)
+}
+
+func (uc *StudyPlanUseCase) toStudyPlanDetailResponse(ctx context.Context, studyPlan generated.GetStudyPlanWithStudentRow) (StudyPlanDetailResponse, error) {
+	id := uuidToString(studyPlan.ID)
+	lines, err := uc.repo.ListStudyPlanLines(ctx, id)
+	if err != nil {
+		return StudyPlanDetailResponse{}, errors.Wrap(err, "cannot get study plan lines")
+	}
+	histories, err := uc.repo.ListStudyPlanHistories(ctx, id)
+	if err != nil {
+		return StudyPlanDetailResponse{}, errors.Wrap(err, "cannot get study plan histories")
+	}
+
+	response := StudyPlanDetailResponse{
+		StudyPlanResponse: toStudyPlanResponse(studyPlan),
+		Lines:             make([]StudyPlanLineResponse, 0, len(lines)),
+		Histories:         make([]StudyPlanHistoryResponse, 0, len(histories)),
+	}
+	for _, line := range lines {
+		if line.Status != StudyPlanLineStatusRejected {
+			response.TotalCredits += int(line.Credit)
+		}
+		response.Lines = append(response.Lines, toStudyPlanLineResponse(line))
+	}
+	for _, history := range histories {
+		response.Histories = append(response.Histories, toStudyPlanHistoryResponse(history))
+	}
+
+	return response, nil
+}
+
+// isStudyPlanEditable reports whether the student can still add lines to the plan and submit it.
+func isStudyPlanEditable(status string) bool {
+	return status == StudyPlanStatusDraft || status == StudyPlanStatusRejected
+}
+
+func isStudyPlanStatus(status string) bool {
+	switch status {
+	case StudyPlanStatusDraft, StudyPlanStatusSubmitted, StudyPlanStatusApproved, StudyPlanStatusRejected:
+		return true
+	}
+	return false
+}
+
+func countActiveStudyPlanLines(lines []generated.ListStudyPlanLinesRow) int {
+	count := 0
+	for _, line := range lines {
+		if line.Status != StudyPlanLineStatusRejected {
+			count++
+		}
+	}
+	return count
+}
+
+func toStudyPlanResponse(studyPlan generated.GetStudyPlanWithStudentRow) StudyPlanResponse {
+	response := StudyPlanResponse{
+		ID:           uuidToString(studyPlan.ID),
+		StudentID:    uuidToString(studyPlan.StudentID),
+		NIM:          studyPlan.Nim,
+		SemesterID:   uuidToString(studyPlan.SemesterID),
+		SemesterCode: studyPlan.SemesterCode,
+		Status:       studyPlan.Status,
+	}
+
+	if studyPlan.StudentName.Valid {
+		name := studyPlan.StudentName.String
+		response.StudentName = &name
+	}
+	if studyPlan.CreatedAt.Valid {
+		response.CreatedAt = studyPlan.CreatedAt.Time
+	}
+	if studyPlan.UpdatedAt.Valid {
+		updatedAt := studyPlan.UpdatedAt.Time
+		response.UpdatedAt = &updatedAt
+	}
+
+	return response
+}
+
+func toStudyPlanLineResponse(line generated.ListStudyPlanLinesRow) StudyPlanLineResponse {
+	response := StudyPlanLineResponse{
+		ID:               uuidToString(line.ID),
+		CourseOfferingID: uuidToString(line.CourseOfferingID),
+		CourseCode:       line.CourseCode,
+		CourseName:       line.CourseName,
+		SectionCode:      line.SectionCode,
+		Credit:           int(line.Credit),
+		Status:           line.Status,
+	}
+
+	if line.CreatedAt.Valid {
+		response.CreatedAt = line.CreatedAt.Time
+	}
+	if line.UpdatedAt.Valid {
+		updatedAt := line.UpdatedAt.Time
+		response.UpdatedAt = &updatedAt
+	}
+
+	return response
+}
+
+func toStudyPlanHistoryResponse(history generated.ListStudyPlanHistoriesRow) StudyPlanHistoryResponse {
+	response := StudyPlanHistoryResponse{
+		StatusBefore: history.StatusBefore,
+		StatusAfter:  history.StatusAfter,
+		ChangedBy:    uuidToString(history.ChangedBy),
+	}
+
+	if history.CourseRegistrationID.Valid {
+		lineID := uuidToString(history.CourseRegistrationID)
+		response.LineID = &lineID
+	}
+	if history.Note.Valid {
+		note := history.Note.String
+		response.Note = &note
+	}
+	if history.ChangedByName.Valid {
+		name := history.ChangedByName.String
+		response.ChangedByName = &name
+	}
+	if history.CreatedAt.Valid {
+		response.ChangedAt = history.CreatedAt.Time
+	}
+
+	return response
+}
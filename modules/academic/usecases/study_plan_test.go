@@ -0,0 +1,430 @@
+package usecases
+
+import (
+	"context"
+	"siakad-poc/common"
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// Mock repository for study plan tests
+type MockStudyPlanRepository struct {
+	mock.Mock
+}
+
+func (m *MockStudyPlanRepository) GetStudyPlanWithStudent(ctx context.Context, id string) (generated.GetStudyPlanWithStudentRow, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.GetStudyPlanWithStudentRow), args.Error(1)
+}
+
+func (m *MockStudyPlanRepository) ListStudyPlansByStudent(ctx context.Context, studentID string) ([]generated.ListStudyPlansByStudentRow, error) {
+	args := m.Called(ctx, studentID)
+	return args.Get(0).([]generated.ListStudyPlansByStudentRow), args.Error(1)
+}
+
+func (m *MockStudyPlanRepository) ListStudyPlansByAcademicAdvisor(ctx context.Context, lecturerID string, filter repositories.StudyPlanFilter) ([]generated.ListStudyPlansByAcademicAdvisorRow, error) {
+	args := m.Called(ctx, lecturerID, filter)
+	return args.Get(0).([]generated.ListStudyPlansByAcademicAdvisorRow), args.Error(1)
+}
+
+func (m *MockStudyPlanRepository) ListStudyPlanLines(ctx context.Context, id string) ([]generated.ListStudyPlanLinesRow, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]generated.ListStudyPlanLinesRow), args.Error(1)
+}
+
+func (m *MockStudyPlanRepository) ListStudyPlanHistories(ctx context.Context, id string) ([]generated.ListStudyPlanHistoriesRow, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]generated.ListStudyPlanHistoriesRow), args.Error(1)
+}
+
+func (m *MockStudyPlanRepository) GetOrCreateStudyPlanTx(txCtx *common.TxContext, id, studentID, semesterID string) (generated.StudyPlan, error) {
+	args := m.Called(txCtx, id, studentID, semesterID)
+	return args.Get(0).(generated.StudyPlan), args.Error(1)
+}
+
+func (m *MockStudyPlanRepository) GetStudyPlanForUpdateTx(txCtx *common.TxContext, id string) (generated.StudyPlan, error) {
+	args := m.Called(txCtx, id)
+	return args.Get(0).(generated.StudyPlan), args.Error(1)
+}
+
+func (m *MockStudyPlanRepository) UpdateStudyPlanStatusTx(txCtx *common.TxContext, id, status string) (generated.StudyPlan, error) {
+	args := m.Called(txCtx, id, status)
+	return args.Get(0).(generated.StudyPlan), args.Error(1)
+}
+
+func (m *MockStudyPlanRepository) ListStudyPlanLinesTx(txCtx *common.TxContext, id string) ([]generated.ListStudyPlanLinesRow, error) {
+	args := m.Called(txCtx, id)
+	return args.Get(0).([]generated.ListStudyPlanLinesRow), args.Error(1)
+}
+
+func (m *MockStudyPlanRepository) ListStudyPlanWaitlistedCourseOfferingsTx(txCtx *common.TxContext, id string) ([]string, error) {
+	args := m.Called(txCtx, id)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockStudyPlanRepository) GetStudyPlanLineTx(txCtx *common.TxContext, id, lineID string) (generated.CourseRegistration, error) {
+	args := m.Called(txCtx, id, lineID)
+	return args.Get(0).(generated.CourseRegistration), args.Error(1)
+}
+
+func (m *MockStudyPlanRepository) UpdateStudyPlanLineStatusTx(txCtx *common.TxContext, lineID, status string) (generated.CourseRegistration, error) {
+	args := m.Called(txCtx, lineID, status)
+	return args.Get(0).(generated.CourseRegistration), args.Error(1)
+}
+
+func (m *MockStudyPlanRepository) CreateStudyPlanHistoryTx(txCtx *common.TxContext, id string, transition repositories.StudyPlanTransition) (generated.StudyPlanHistory, error) {
+	args := m.Called(txCtx, id, transition)
+	return args.Get(0).(generated.StudyPlanHistory), args.Error(1)
+}
+
+// Test Suite
+type StudyPlanUseCaseTestSuite struct {
+	suite.Suite
+	useCase          *StudyPlanUseCase
+	mockRepo         *MockStudyPlanRepository
+	mockStudentRepo  *MockStudentRepository
+	mockLecturerRepo *MockLecturerRepository
+	mockWaitlist     *MockWaitlistPromoter
+	ctx              context.Context
+	studentUserID    string
+	advisorUserID    string
+	studyPlanID      string
+	lineID           string
+	otherLineID      string
+	student          generated.Student
+	advisor          generated.Lecturer
+	studyPlan        generated.GetStudyPlanWithStudentRow
+}
+
+func (suite *StudyPlanUseCaseTestSuite) SetupTest() {
+	suite.mockRepo = new(MockStudyPlanRepository)
+	suite.mockStudentRepo = new(MockStudentRepository)
+	suite.mockLecturerRepo = new(MockLecturerRepository)
+	suite.mockWaitlist = new(MockWaitlistPromoter)
+	suite.useCase = NewStudyPlanUseCase(suite.mockRepo, suite.mockStudentRepo, suite.mockLecturerRepo, suite.mockWaitlist, new(common.MockTransactionExecutor))
+	suite.ctx = context.Background()
+
+	suite.studentUserID = "550e8400-e29b-41d4-a716-446655440010"
+	suite.advisorUserID = "550e8400-e29b-41d4-a716-446655440011"
+	suite.studyPlanID = "550e8400-e29b-41d4-a716-446655440012"
+	suite.lineID = "550e8400-e29b-41d4-a716-446655440013"
+	suite.otherLineID = "550e8400-e29b-41d4-a716-446655440014"
+
+	studentUUID := pgtype.UUID{Bytes: [16]byte{8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23}, Valid: true}
+	advisorUUID := pgtype.UUID{Bytes: [16]byte{9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24}, Valid: true}
+	var studyPlanUUID pgtype.UUID
+	_ = studyPlanUUID.Scan(suite.studyPlanID)
+
+	// Student 2023010001 advised by lecturer 0012345678, with a study plan of the semester
+	suite.student = generated.Student{ID: studentUUID, Nim: "2023010001", AcademicAdvisorID: advisorUUID}
+	suite.advisor = generated.Lecturer{ID: advisorUUID, Nidn: "0012345678"}
+	suite.studyPlan = generated.GetStudyPlanWithStudentRow{
+		ID:                studyPlanUUID,
+		StudentID:         studentUUID,
+		Nim:               "2023010001",
+		AcademicAdvisorID: advisorUUID,
+		SemesterCode:      "2025-1",
+		Status:            StudyPlanStatusSubmitted,
+	}
+}
+
+func (suite *StudyPlanUseCaseTestSuite) TearDownTest() {
+	suite.mockRepo.AssertExpectations(suite.T())
+	suite.mockStudentRepo.AssertExpectations(suite.T())
+	suite.mockLecturerRepo.AssertExpectations(suite.T())
+	suite.mockWaitlist.AssertExpectations(suite.T())
+}
+
+// lockedStudyPlan is the plan as read for update in the transaction
+func (suite *StudyPlanUseCaseTestSuite) lockedStudyPlan(status string) generated.StudyPlan {
+	return generated.StudyPlan{ID: suite.studyPlan.ID, StudentID: suite.studyPlan.StudentID, Status: status}
+}
+
+func (suite *StudyPlanUseCaseTestSuite) line(id, status string, credit int32) generated.ListStudyPlanLinesRow {
+	var lineUUID pgtype.UUID
+	_ = lineUUID.Scan(id)
+	return generated.ListStudyPlanLinesRow{ID: lineUUID, CourseCode: "IF101", Credit: credit, Status: status}
+}
+
+func (suite *StudyPlanUseCaseTestSuite) expectDetail(lines []generated.ListStudyPlanLinesRow) {
+	suite.mockRepo.On("GetStudyPlanWithStudent", suite.ctx, suite.studyPlanID).Return(suite.studyPlan, nil)
+	suite.mockRepo.On("ListStudyPlanLines", suite.ctx, suite.studyPlanID).Return(lines, nil)
+	suite.mockRepo.On("ListStudyPlanHistories", suite.ctx, suite.studyPlanID).Return([]generated.ListStudyPlanHistoriesRow{}, nil)
+}
+
+// Test submitting a draft plan records the transition
+func (suite *StudyPlanUseCaseTestSuite) TestSubmitStudyPlan_Success() {
+	suite.studyPlan.Status = StudyPlanStatusDraft
+	lines := []generated.ListStudyPlanLinesRow{suite.line(suite.lineID, StudyPlanLineStatusPending, 3)}
+
+	suite.mockStudentRepo.On("GetStudentByUserID", suite.ctx, suite.studentUserID).Return(suite.student, nil)
+	suite.mockRepo.On("GetStudyPlanWithStudent", suite.ctx, suite.studyPlanID).Return(suite.studyPlan, nil).Once()
+	suite.mockRepo.On("GetStudyPlanForUpdateTx", mock.AnythingOfType("*common.TxContext"), suite.studyPlanID).Return(suite.lockedStudyPlan(StudyPlanStatusDraft), nil)
+	suite.mockRepo.On("ListStudyPlanLinesTx", mock.AnythingOfType("*common.TxContext"), suite.studyPlanID).Return(lines, nil)
+	suite.mockRepo.On("UpdateStudyPlanStatusTx", mock.AnythingOfType("*common.TxContext"), suite.studyPlanID, StudyPlanStatusSubmitted).Return(generated.StudyPlan{}, nil)
+	suite.mockRepo.On("CreateStudyPlanHistoryTx", mock.AnythingOfType("*common.TxContext"), mock.AnythingOfType("string"), repositories.StudyPlanTransition{
+		StudyPlanID:  suite.studyPlanID,
+		StatusBefore: StudyPlanStatusDraft,
+		StatusAfter:  StudyPlanStatusSubmitted,
+		ChangedBy:    suite.studentUserID,
+	}).Return(generated.StudyPlanHistory{}, nil)
+
+	submitted := suite.studyPlan
+	submitted.Status = StudyPlanStatusSubmitted
+	suite.mockRepo.On("GetStudyPlanWithStudent", suite.ctx, suite.studyPlanID).Return(submitted, nil).Once()
+	suite.mockRepo.On("ListStudyPlanLines", suite.ctx, suite.studyPlanID).Return(lines, nil)
+	suite.mockRepo.On("ListStudyPlanHistories", suite.ctx, suite.studyPlanID).Return([]generated.ListStudyPlanHistoriesRow{}, nil)
+
+	response, err := suite.useCase.SubmitStudyPlan(suite.ctx, suite.studentUserID, suite.studyPlanID)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), StudyPlanStatusSubmitted, response.Status)
+	assert.Equal(suite.T(), 3, response.TotalCredits)
+}
+
+// Test submitting a plan whose lines were all rejected
+func (suite *StudyPlanUseCaseTestSuite) TestSubmitStudyPlan_Empty() {
+	suite.studyPlan.Status = StudyPlanStatusRejected
+	lines := []generated.ListStudyPlanLinesRow{suite.line(suite.lineID, StudyPlanLineStatusRejected, 3)}
+
+	suite.mockStudentRepo.On("GetStudentByUserID", suite.ctx, suite.studentUserID).Return(suite.student, nil)
+	suite.mockRepo.On("GetStudyPlanWithStudent", suite.ctx, suite.studyPlanID).Return(suite.studyPlan, nil)
+	suite.mockRepo.On("GetStudyPlanForUpdateTx", mock.AnythingOfType("*common.TxContext"), suite.studyPlanID).Return(suite.lockedStudyPlan(StudyPlanStatusRejected), nil)
+	suite.mockRepo.On("ListStudyPlanLinesTx", mock.AnythingOfType("*common.TxContext"), suite.studyPlanID).Return(lines, nil)
+
+	_, err := suite.useCase.SubmitStudyPlan(suite.ctx, suite.studentUserID, suite.studyPlanID)
+
+	assert.ErrorIs(suite.T(), err, ErrStudyPlanEmpty)
+	suite.mockRepo.AssertNotCalled(suite.T(), "UpdateStudyPlanStatusTx")
+}
+
+// Test submitting a plan that is already under review
+func (suite *StudyPlanUseCaseTestSuite) TestSubmitStudyPlan_AlreadySubmitted() {
+	suite.mockStudentRepo.On("GetStudentByUserID", suite.ctx, suite.studentUserID).Return(suite.student, nil)
+	suite.mockRepo.On("GetStudyPlanWithStudent", suite.ctx, suite.studyPlanID).Return(suite.studyPlan, nil)
+	suite.mockRepo.On("GetStudyPlanForUpdateTx", mock.AnythingOfType("*common.TxContext"), suite.studyPlanID).Return(suite.lockedStudyPlan(StudyPlanStatusSubmitted), nil)
+
+	_, err := suite.useCase.SubmitStudyPlan(suite.ctx, suite.studentUserID, suite.studyPlanID)
+
+	assert.ErrorIs(suite.T(), err, ErrStudyPlanNotSubmittable)
+	suite.mockRepo.AssertNotCalled(suite.T(), "UpdateStudyPlanStatusTx")
+}
+
+// Test a student can't see the plan of another student
+func (suite *StudyPlanUseCaseTestSuite) TestGetOwnStudyPlan_OtherStudent() {
+	otherStudent := suite.student
+	otherStudent.ID = pgtype.UUID{Bytes: [16]byte{10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25}, Valid: true}
+
+	suite.mockStudentRepo.On("GetStudentByUserID", suite.ctx, suite.studentUserID).Return(otherStudent, nil)
+	suite.mockRepo.On("GetStudyPlanWithStudent", suite.ctx, suite.studyPlanID).Return(suite.studyPlan, nil)
+
+	_, err := suite.useCase.GetOwnStudyPlan(suite.ctx, suite.studentUserID, suite.studyPlanID)
+
+	assert.ErrorIs(suite.T(), err, ErrStudyPlanNotFound)
+}
+
+// Test approving a plan approves its pending lines and keeps rejected ones
+func (suite *StudyPlanUseCaseTestSuite) TestApproveStudyPlan_ApprovesPendingLines() {
+	lines := []generated.ListStudyPlanLinesRow{
+		suite.line(suite.lineID, StudyPlanLineStatusPending, 3),
+		suite.line(suite.otherLineID, StudyPlanLineStatusRejected, 2),
+	}
+
+	suite.mockLecturerRepo.On("GetLecturerByUserID", suite.ctx, suite.advisorUserID).Return(suite.advisor, nil)
+	suite.mockRepo.On("GetStudyPlanForUpdateTx", mock.AnythingOfType("*common.TxContext"), suite.studyPlanID).Return(suite.lockedStudyPlan(StudyPlanStatusSubmitted), nil)
+	suite.mockRepo.On("ListStudyPlanLinesTx", mock.AnythingOfType("*common.TxContext"), suite.studyPlanID).Return(lines, nil)
+	suite.mockRepo.On("UpdateStudyPlanLineStatusTx", mock.AnythingOfType("*common.TxContext"), suite.lineID, StudyPlanLineStatusApproved).Return(generated.CourseRegistration{}, nil)
+	suite.mockRepo.On("CreateStudyPlanHistoryTx", mock.AnythingOfType("*common.TxContext"), mock.AnythingOfType("string"), repositories.StudyPlanTransition{
+		StudyPlanID:          suite.studyPlanID,
+		CourseRegistrationID: suite.lineID,
+		StatusBefore:         StudyPlanLineStatusPending,
+		StatusAfter:          StudyPlanLineStatusApproved,
+		ChangedBy:            suite.advisorUserID,
+	}).Return(generated.StudyPlanHistory{}, nil)
+	suite.mockRepo.On("UpdateStudyPlanStatusTx", mock.AnythingOfType("*common.TxContext"), suite.studyPlanID, StudyPlanStatusApproved).Return(generated.StudyPlan{}, nil)
+	suite.mockRepo.On("CreateStudyPlanHistoryTx", mock.AnythingOfType("*common.TxContext"), mock.AnythingOfType("string"), repositories.StudyPlanTransition{
+		StudyPlanID:  suite.studyPlanID,
+		StatusBefore: StudyPlanStatusSubmitted,
+		StatusAfter:  StudyPlanStatusApproved,
+		Note:         "Good plan",
+		ChangedBy:    suite.advisorUserID,
+	}).Return(generated.StudyPlanHistory{}, nil)
+	suite.expectDetail(lines)
+
+	response, err := suite.useCase.ApproveStudyPlan(suite.ctx, suite.advisorUserID, suite.studyPlanID, ReviewStudyPlanRequest{Note: "Good plan"})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 3, response.TotalCredits)
+	suite.mockRepo.AssertNotCalled(suite.T(), "UpdateStudyPlanLineStatusTx", mock.Anything, suite.otherLineID, mock.Anything)
+}
+
+// Test a lecturer can't review the plan of a student they don't advise
+func (suite *StudyPlanUseCaseTestSuite) TestApproveStudyPlan_NotAdvisee() {
+	otherAdvisor := suite.advisor
+	otherAdvisor.ID = pgtype.UUID{Bytes: [16]byte{11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26}, Valid: true}
+
+	suite.mockLecturerRepo.On("GetLecturerByUserID", suite.ctx, suite.advisorUserID).Return(otherAdvisor, nil)
+	suite.mockRepo.On("GetStudyPlanWithStudent", suite.ctx, suite.studyPlanID).Return(suite.studyPlan, nil)
+
+	_, err := suite.useCase.ApproveStudyPlan(suite.ctx, suite.advisorUserID, suite.studyPlanID, ReviewStudyPlanRequest{})
+
+	assert.ErrorIs(suite.T(), err, ErrStudyPlanNotFound)
+	suite.mockRepo.AssertNotCalled(suite.T(), "UpdateStudyPlanStatusTx")
+}
+
+// Test reviewing without a lecturer record
+func (suite *StudyPlanUseCaseTestSuite) TestApproveStudyPlan_NotALecturer() {
+	suite.mockLecturerRepo.On("GetLecturerByUserID", suite.ctx, suite.advisorUserID).Return(generated.Lecturer{}, pgx.ErrNoRows)
+
+	_, err := suite.useCase.ApproveStudyPlan(suite.ctx, suite.advisorUserID, suite.studyPlanID, ReviewStudyPlanRequest{})
+
+	assert.ErrorIs(suite.T(), err, ErrNotALecturer)
+}
+
+// Test rejecting a pending line with a note gives its seat to the waitlist of the offering
+func (suite *StudyPlanUseCaseTestSuite) TestRejectStudyPlanLine_Success() {
+	courseOfferingID := "550e8400-e29b-41d4-a716-446655440015"
+	var lineUUID, courseOfferingUUID pgtype.UUID
+	_ = lineUUID.Scan(suite.lineID)
+	_ = courseOfferingUUID.Scan(courseOfferingID)
+	lines := []generated.ListStudyPlanLinesRow{suite.line(suite.lineID, StudyPlanLineStatusRejected, 3)}
+
+	suite.mockLecturerRepo.On("GetLecturerByUserID", suite.ctx, suite.advisorUserID).Return(suite.advisor, nil)
+	suite.mockRepo.On("GetStudyPlanForUpdateTx", mock.AnythingOfType("*common.TxContext"), suite.studyPlanID).Return(suite.lockedStudyPlan(StudyPlanStatusSubmitted), nil)
+	suite.mockRepo.On("GetStudyPlanLineTx", mock.AnythingOfType("*common.TxContext"), suite.studyPlanID, suite.lineID).Return(generated.CourseRegistration{ID: lineUUID, CourseOfferingID: courseOfferingUUID, Status: StudyPlanLineStatusPending}, nil)
+	suite.mockRepo.On("UpdateStudyPlanLineStatusTx", mock.AnythingOfType("*common.TxContext"), suite.lineID, StudyPlanLineStatusRejected).Return(generated.CourseRegistration{}, nil)
+	suite.mockRepo.On("CreateStudyPlanHistoryTx", mock.AnythingOfType("*common.TxContext"), mock.AnythingOfType("string"), repositories.StudyPlanTransition{
+		StudyPlanID:          suite.studyPlanID,
+		CourseRegistrationID: suite.lineID,
+		StatusBefore:         StudyPlanLineStatusPending,
+		StatusAfter:          StudyPlanLineStatusRejected,
+		Note:                 "Take IF201 next semester",
+		ChangedBy:            suite.advisorUserID,
+	}).Return(generated.StudyPlanHistory{}, nil)
+	suite.mockWaitlist.On("PromoteWaitlistTx", mock.AnythingOfType("*common.TxContext"), courseOfferingID).Run(func(mock.Arguments) {
+		// The seat is only free once the line is rejected
+		suite.mockRepo.AssertCalled(suite.T(), "UpdateStudyPlanLineStatusTx", mock.Anything, suite.lineID, StudyPlanLineStatusRejected)
+	}).Return(nil)
+	suite.expectDetail(lines)
+
+	response, err := suite.useCase.RejectStudyPlanLine(suite.ctx, suite.advisorUserID, suite.studyPlanID, suite.lineID, RejectStudyPlanRequest{Note: "Take IF201 next semester"})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, response.TotalCredits)
+	assert.Equal(suite.T(), StudyPlanLineStatusRejected, response.Lines[0].Status)
+}
+
+// Test a failing promotion fails the rejection, so the line and the waitlist are rolled back together
+func (suite *StudyPlanUseCaseTestSuite) TestRejectStudyPlanLine_PromotionError() {
+	courseOfferingID := "550e8400-e29b-41d4-a716-446655440015"
+	var courseOfferingUUID pgtype.UUID
+	_ = courseOfferingUUID.Scan(courseOfferingID)
+
+	suite.mockLecturerRepo.On("GetLecturerByUserID", suite.ctx, suite.advisorUserID).Return(suite.advisor, nil)
+	suite.mockRepo.On("GetStudyPlanWithStudent", suite.ctx, suite.studyPlanID).Return(suite.studyPlan, nil)
+	suite.mockRepo.On("GetStudyPlanForUpdateTx", mock.AnythingOfType("*common.TxContext"), suite.studyPlanID).Return(suite.lockedStudyPlan(StudyPlanStatusSubmitted), nil)
+	suite.mockRepo.On("GetStudyPlanLineTx", mock.AnythingOfType("*common.TxContext"), suite.studyPlanID, suite.lineID).Return(generated.CourseRegistration{CourseOfferingID: courseOfferingUUID, Status: StudyPlanLineStatusPending}, nil)
+	suite.mockRepo.On("UpdateStudyPlanLineStatusTx", mock.AnythingOfType("*common.TxContext"), suite.lineID, StudyPlanLineStatusRejected).Return(generated.CourseRegistration{}, nil)
+	suite.mockRepo.On("CreateStudyPlanHistoryTx", mock.AnythingOfType("*common.TxContext"), mock.AnythingOfType("string"), mock.Anything).Return(generated.StudyPlanHistory{}, nil)
+	suite.mockWaitlist.On("PromoteWaitlistTx", mock.AnythingOfType("*common.TxContext"), courseOfferingID).Return(NewDatabaseOperationError("get waitlist", errors.New("connection reset")))
+
+	_, err := suite.useCase.RejectStudyPlanLine(suite.ctx, suite.advisorUserID, suite.studyPlanID, suite.lineID, RejectStudyPlanRequest{Note: "No"})
+
+	assert.Error(suite.T(), err)
+	suite.mockRepo.AssertNotCalled(suite.T(), "ListStudyPlanLines", suite.ctx, suite.studyPlanID)
+}
+
+// Test approving a line takes no seat away and leaves the waitlist alone
+func (suite *StudyPlanUseCaseTestSuite) TestApproveStudyPlanLine_Success() {
+	suite.mockLecturerRepo.On("GetLecturerByUserID", suite.ctx, suite.advisorUserID).Return(suite.advisor, nil)
+	suite.mockRepo.On("GetStudyPlanForUpdateTx", mock.AnythingOfType("*common.TxContext"), suite.studyPlanID).Return(suite.lockedStudyPlan(StudyPlanStatusSubmitted), nil)
+	suite.mockRepo.On("GetStudyPlanLineTx", mock.AnythingOfType("*common.TxContext"), suite.studyPlanID, suite.lineID).Return(generated.CourseRegistration{Status: StudyPlanLineStatusPending}, nil)
+	suite.mockRepo.On("UpdateStudyPlanLineStatusTx", mock.AnythingOfType("*common.TxContext"), suite.lineID, StudyPlanLineStatusApproved).Return(generated.CourseRegistration{}, nil)
+	suite.mockRepo.On("CreateStudyPlanHistoryTx", mock.AnythingOfType("*common.TxContext"), mock.AnythingOfType("string"), mock.Anything).Return(generated.StudyPlanHistory{}, nil)
+	suite.expectDetail([]generated.ListStudyPlanLinesRow{suite.line(suite.lineID, StudyPlanLineStatusApproved, 3)})
+
+	_, err := suite.useCase.ApproveStudyPlanLine(suite.ctx, suite.advisorUserID, suite.studyPlanID, suite.lineID, ReviewStudyPlanRequest{})
+
+	assert.NoError(suite.T(), err)
+	suite.mockWaitlist.AssertNotCalled(suite.T(), "PromoteWaitlistTx")
+}
+
+// Test rejecting a plan promotes the waitlists the student was passed over in while the plan was reviewed
+func (suite *StudyPlanUseCaseTestSuite) TestRejectStudyPlan_PromotesWaitlists() {
+	courseOfferingID := "550e8400-e29b-41d4-a716-446655440015"
+	otherCourseOfferingID := "550e8400-e29b-41d4-a716-446655440016"
+
+	suite.mockLecturerRepo.On("GetLecturerByUserID", suite.ctx, suite.advisorUserID).Return(suite.advisor, nil)
+	suite.mockRepo.On("GetStudyPlanForUpdateTx", mock.AnythingOfType("*common.TxContext"), suite.studyPlanID).Return(suite.lockedStudyPlan(StudyPlanStatusSubmitted), nil)
+	suite.mockRepo.On("UpdateStudyPlanStatusTx", mock.AnythingOfType("*common.TxContext"), suite.studyPlanID, StudyPlanStatusRejected).Return(generated.StudyPlan{}, nil)
+	suite.mockRepo.On("CreateStudyPlanHistoryTx", mock.AnythingOfType("*common.TxContext"), mock.AnythingOfType("string"), repositories.StudyPlanTransition{
+		StudyPlanID:  suite.studyPlanID,
+		StatusBefore: StudyPlanStatusSubmitted,
+		StatusAfter:  StudyPlanStatusRejected,
+		Note:         "Too many credits",
+		ChangedBy:    suite.advisorUserID,
+	}).Return(generated.StudyPlanHistory{}, nil)
+	suite.mockRepo.On("ListStudyPlanWaitlistedCourseOfferingsTx", mock.AnythingOfType("*common.TxContext"), suite.studyPlanID).Return([]string{courseOfferingID, otherCourseOfferingID}, nil)
+	suite.mockWaitlist.On("PromoteWaitlistTx", mock.AnythingOfType("*common.TxContext"), courseOfferingID).Run(func(mock.Arguments) {
+		// The student is only eligible again once the plan is no longer under review
+		suite.mockRepo.AssertCalled(suite.T(), "UpdateStudyPlanStatusTx", mock.Anything, suite.studyPlanID, StudyPlanStatusRejected)
+	}).Return(nil).Once()
+	suite.mockWaitlist.On("PromoteWaitlistTx", mock.AnythingOfType("*common.TxContext"), otherCourseOfferingID).Return(nil).Once()
+	rejected := suite.studyPlan
+	rejected.Status = StudyPlanStatusRejected
+	suite.mockRepo.On("GetStudyPlanWithStudent", suite.ctx, suite.studyPlanID).Return(suite.studyPlan, nil).Once()
+	suite.mockRepo.On("GetStudyPlanWithStudent", suite.ctx, suite.studyPlanID).Return(rejected, nil).Once()
+	suite.mockRepo.On("ListStudyPlanLines", suite.ctx, suite.studyPlanID).Return([]generated.ListStudyPlanLinesRow{suite.line(suite.lineID, StudyPlanLineStatusPending, 3)}, nil)
+	suite.mockRepo.On("ListStudyPlanHistories", suite.ctx, suite.studyPlanID).Return([]generated.ListStudyPlanHistoriesRow{}, nil)
+
+	response, err := suite.useCase.RejectStudyPlan(suite.ctx, suite.advisorUserID, suite.studyPlanID, RejectStudyPlanRequest{Note: "Too many credits"})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), StudyPlanStatusRejected, response.Status)
+	assert.Equal(suite.T(), StudyPlanLineStatusPending, response.Lines[0].Status)
+}
+
+// Test lines can only be reviewed while the plan is submitted
+func (suite *StudyPlanUseCaseTestSuite) TestRejectStudyPlanLine_PlanNotSubmitted() {
+	suite.mockLecturerRepo.On("GetLecturerByUserID", suite.ctx, suite.advisorUserID).Return(suite.advisor, nil)
+	suite.mockRepo.On("GetStudyPlanWithStudent", suite.ctx, suite.studyPlanID).Return(suite.studyPlan, nil)
+	suite.mockRepo.On("GetStudyPlanForUpdateTx", mock.AnythingOfType("*common.TxContext"), suite.studyPlanID).Return(suite.lockedStudyPlan(StudyPlanStatusDraft), nil)
+
+	_, err := suite.useCase.RejectStudyPlanLine(suite.ctx, suite.advisorUserID, suite.studyPlanID, suite.lineID, RejectStudyPlanRequest{Note: "No"})
+
+	assert.ErrorIs(suite.T(), err, ErrStudyPlanNotSubmitted)
+	suite.mockRepo.AssertNotCalled(suite.T(), "UpdateStudyPlanLineStatusTx")
+}
+
+// Test reviewing a line twice
+func (suite *StudyPlanUseCaseTestSuite) TestApproveStudyPlanLine_AlreadyReviewed() {
+	suite.mockLecturerRepo.On("GetLecturerByUserID", suite.ctx, suite.advisorUserID).Return(suite.advisor, nil)
+	suite.mockRepo.On("GetStudyPlanWithStudent", suite.ctx, suite.studyPlanID).Return(suite.studyPlan, nil)
+	suite.mockRepo.On("GetStudyPlanForUpdateTx", mock.AnythingOfType("*common.TxContext"), suite.studyPlanID).Return(suite.lockedStudyPlan(StudyPlanStatusSubmitted), nil)
+	suite.mockRepo.On("GetStudyPlanLineTx", mock.AnythingOfType("*common.TxContext"), suite.studyPlanID, suite.lineID).Return(generated.CourseRegistration{Status: StudyPlanLineStatusApproved}, nil)
+
+	_, err := suite.useCase.ApproveStudyPlanLine(suite.ctx, suite.advisorUserID, suite.studyPlanID, suite.lineID, ReviewStudyPlanRequest{})
+
+	assert.ErrorIs(suite.T(), err, ErrStudyPlanLineAlreadyReviewed)
+	suite.mockRepo.AssertNotCalled(suite.T(), "UpdateStudyPlanLineStatusTx")
+}
+
+// Test listing advisee plans with an unknown status
+func (suite *StudyPlanUseCaseTestSuite) TestListAdviseeStudyPlans_InvalidStatus() {
+	_, err := suite.useCase.ListAdviseeStudyPlans(suite.ctx, suite.advisorUserID, "pending")
+
+	assert.ErrorIs(suite.T(), err, ErrInvalidStudyPlanStatus)
+	suite.mockLecturerRepo.AssertNotCalled(suite.T(), "GetLecturerByUserID")
+}
+
+func TestStudyPlanUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(StudyPlanUseCaseTestSuite))
+}
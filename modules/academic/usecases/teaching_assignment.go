@@ -0,0 +1,271 @@
+package usecases
+
+import (
+	"context"
+	"siakad-poc/common"
+	"siakad-poc/db/generated"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+// TeachingPolicy holds the configurable rules of teaching assignments
+type TeachingPolicy struct {
+	// MaxTeachingCredits is the most credits (SKS) a lecturer can teach in a semester
+	MaxTeachingCredits int
+	// CampusLocation is the time zone weekly schedules are taken and compared in, nil means UTC
+	CampusLocation *time.Location
+}
+
+// AssignLecturersRequest is the payload to assign lecturers (dosen pengampu) to a course offering
+type AssignLecturersRequest struct {
+	LecturerIDs []string `json:"lecturer_ids" validate:"required,min=1,unique,dive,uuid"`
+}
+
+// CourseOfferingLecturerResponse is a lecturer teaching a course offering
+type CourseOfferingLecturerResponse struct {
+	LecturerID string    `json:"lecturer_id"`
+	NIDN       string    `json:"nidn"`
+	Name       *string   `json:"name"`
+	AssignedAt time.Time `json:"assigned_at"`
+}
+
+// LecturerScheduleResponse is the teaching schedule of a lecturer in a semester, total_credits is the teaching
+// load and max_credits the most the lecturer can be assigned.
+type LecturerScheduleResponse struct {
+	LecturerID      string                   `json:"lecturer_id"`
+	NIDN            string                   `json:"nidn"`
+	SemesterID      string                   `json:"semester_id"`
+	TotalCredits    int                      `json:"total_credits"`
+	MaxCredits      int                      `json:"max_credits"`
+	CourseOfferings []CourseOfferingResponse `json:"course_offerings"`
+}
+
+// assignedLecturer identifies a lecturer in the errors of the teaching assignment checks
+type assignedLecturer struct {
+	ID   string
+	NIDN string
+}
+
+func (uc *CourseOfferingUseCase) ListCourseOfferingLecturers(ctx context.Context, scope common.StudyProgramScope, id string) ([]CourseOfferingLecturerResponse, error) {
+	_, err := uc.GetCourseOffering(ctx, scope, id)
+	if err != nil {
+		return nil, err
+	}
+
+	lecturers, err := uc.repo.ListCourseOfferingLecturers(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get course offering lecturers")
+	}
+
+	return toCourseOfferingLecturerResponses(lecturers), nil
+}
+
+// AssignLecturers adds the lecturers to the ones teaching the offering, all of them or none. A lecturer must not
+// teach another offering of the semester at the same time, nor exceed the maximum teaching credits of the semester.
+// The assignments already made are checked once the lecturers are locked, so a concurrent request assigning the
+// same lecturer fails with ErrLecturerAlreadyAssigned.
+func (uc *CourseOfferingUseCase) AssignLecturers(ctx context.Context, scope common.StudyProgramScope, id string, req AssignLecturersRequest) ([]CourseOfferingLecturerResponse, error) {
+	_, err := uc.GetCourseOffering(ctx, scope, id)
+	if err != nil {
+		return nil, err
+	}
+
+	lecturers := make([]assignedLecturer, 0, len(req.LecturerIDs))
+	for _, lecturerID := range req.LecturerIDs {
+		lecturer, err := uc.lecturerRepo.GetLecturer(ctx, lecturerID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, errors.Wrapf(ErrUnknownLecturer, "lecturer %s", lecturerID)
+			}
+			return nil, errors.Wrap(err, "cannot get lecturer")
+		}
+		lecturers = append(lecturers, assignedLecturer{ID: uuidToString(lecturer.ID), NIDN: lecturer.Nidn})
+	}
+
+	err = uc.txExecutor.WithTxContext(ctx, func(txCtx *common.TxContext) error {
+		err := uc.lockLecturersTx(txCtx, lecturers)
+		if err != nil {
+			return err
+		}
+
+		assigned, err := uc.repo.ListCourseOfferingLecturersTx(txCtx, id)
+		if err != nil {
+			return errors.Wrap(err, "cannot get course offering lecturers")
+		}
+		for _, existing := range toAssignedLecturers(assigned) {
+			for _, lecturer := range lecturers {
+				if existing.ID == lecturer.ID {
+					return errors.Wrapf(ErrLecturerAlreadyAssigned, "lecturer %s", lecturer.NIDN)
+				}
+			}
+		}
+
+		for _, lecturer := range lecturers {
+			_, err := uc.repo.CreateCourseOfferingLecturerTx(txCtx, uuid.NewString(), id, lecturer.ID)
+			if err != nil {
+				return errors.Wrap(err, "cannot assign lecturer")
+			}
+		}
+		return uc.ensureLecturersAvailableTx(txCtx, id, lecturers)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := uc.repo.ListCourseOfferingLecturers(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get course offering lecturers")
+	}
+
+	return toCourseOfferingLecturerResponses(updated), nil
+}
+
+func (uc *CourseOfferingUseCase) RemoveLecturer(ctx context.Context, scope common.StudyProgramScope, id, lecturerID string) error {
+	_, err := uc.GetCourseOffering(ctx, scope, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = uc.repo.DeleteCourseOfferingLecturer(ctx, id, lecturerID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrLecturerNotAssigned
+		}
+		return errors.Wrap(err, "cannot remove lecturer")
+	}
+
+	return nil
+}
+
+// GetLecturerSchedule returns the offerings the lecturer teaches in the semester with their weekly schedules.
+func (uc *CourseOfferingUseCase) GetLecturerSchedule(ctx context.Context, lecturerID, semesterID string) (LecturerScheduleResponse, error) {
+	lecturer, err := uc.lecturerRepo.GetLecturer(ctx, lecturerID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return LecturerScheduleResponse{}, ErrLecturerNotFound
+		}
+		return LecturerScheduleResponse{}, errors.Wrap(err, "cannot get lecturer")
+	}
+
+	courseOfferings, err := uc.repo.GetCourseOfferingsByLecturer(ctx, lecturerID, semesterID)
+	if err != nil {
+		return LecturerScheduleResponse{}, errors.Wrap(err, "cannot get course offerings of the lecturer")
+	}
+
+	response := LecturerScheduleResponse{
+		LecturerID:      uuidToString(lecturer.ID),
+		NIDN:            lecturer.Nidn,
+		SemesterID:      semesterID,
+		MaxCredits:      uc.policy.MaxTeachingCredits,
+		CourseOfferings: make([]CourseOfferingResponse, 0, len(courseOfferings)),
+	}
+	for _, co := range courseOfferings {
+		response.TotalCredits += int(co.Credit)
+		response.CourseOfferings = append(response.CourseOfferings, toCourseOfferingResponse(co))
+	}
+
+	return response, nil
+}
+
+// ensureLecturersAvailableTx rejects the lecturers teaching another offering of the semester at the same time, or
+// whose teaching load of the semester would exceed the policy. The offering and its assignments must already be
+// saved in the transaction, and the lecturers locked with lockLecturersTx.
+func (uc *CourseOfferingUseCase) ensureLecturersAvailableTx(txCtx *common.TxContext, id string, lecturers []assignedLecturer) error {
+	if len(lecturers) == 0 {
+		return nil
+	}
+
+	courseOffering, err := uc.repo.GetCourseOfferingWithCourseTx(txCtx, id)
+	if err != nil {
+		return errors.Wrap(err, "cannot get course offering")
+	}
+	schedule, err := newOfferingSchedule(courseOffering.CourseOfferingStartTime, courseOffering.SemesterStartTime,
+		courseOffering.SemesterEndTime, courseOffering.Schedules, classDuration(courseOffering.Credit, courseOffering.MinutesPerCredit), uc.policy.CampusLocation)
+	if err != nil {
+		return err
+	}
+
+	semesterID := uuidToString(courseOffering.SemesterID)
+	for _, lecturer := range lecturers {
+		teachings, err := uc.repo.GetCourseOfferingsByLecturerTx(txCtx, lecturer.ID, semesterID)
+		if err != nil {
+			return errors.Wrap(err, "cannot get course offerings of the lecturer")
+		}
+
+		credits := int(courseOffering.Credit)
+		for _, other := range teachings {
+			if other.CourseOfferingID == courseOffering.CourseOfferingID {
+				continue
+			}
+			credits += int(other.Credit)
+
+			otherSchedule, err := newOfferingSchedule(other.CourseOfferingStartTime, other.SemesterStartTime,
+				other.SemesterEndTime, other.Schedules, classDuration(other.Credit, other.MinutesPerCredit), uc.policy.CampusLocation)
+			if err != nil {
+				return err
+			}
+			if _, otherSlot, overlap := findScheduleOverlap(schedule, otherSchedule); overlap {
+				return errors.Wrapf(ErrLecturerScheduleClash, "lecturer %s teaches %s section %s on %s",
+					lecturer.NIDN, other.CourseCode, other.SectionCode, otherSlot)
+			}
+		}
+
+		if credits > uc.policy.MaxTeachingCredits {
+			return errors.Wrapf(ErrTeachingLoadExceeded, "lecturer %s would teach %d credits, at most %d",
+				lecturer.NIDN, credits, uc.policy.MaxTeachingCredits)
+		}
+	}
+
+	return nil
+}
+
+// lockLecturersTx locks the lecturers until the transaction ends, so offerings assigned to the same lecturer
+// concurrently are checked one after the other, for clashes as well as for the teaching load. It has to come before
+// their assignments are saved: the foreign key check of the insert already share locks the lecturer, two
+// transactions upgrading that lock would deadlock.
+func (uc *CourseOfferingUseCase) lockLecturersTx(txCtx *common.TxContext, lecturers []assignedLecturer) error {
+	if len(lecturers) == 0 {
+		return nil
+	}
+
+	lecturerIDs := make([]string, 0, len(lecturers))
+	for _, lecturer := range lecturers {
+		lecturerIDs = append(lecturerIDs, lecturer.ID)
+	}
+	err := uc.lecturerRepo.LockLecturersTx(txCtx, lecturerIDs)
+	if err != nil {
+		return errors.Wrap(err, "cannot lock lecturers")
+	}
+
+	return nil
+}
+
+func toAssignedLecturers(rows []generated.ListCourseOfferingLecturersRow) []assignedLecturer {
+	lecturers := make([]assignedLecturer, 0, len(rows))
+	for _, row := range rows {
+		lecturers = append(lecturers, assignedLecturer{ID: uuidToString(row.LecturerID), NIDN: row.Nidn})
+	}
+	return lecturers
+}
+
+func toCourseOfferingLecturerResponses(rows []generated.ListCourseOfferingLecturersRow) []CourseOfferingLecturerResponse {
+	responses := make([]CourseOfferingLecturerResponse, 0, len(rows))
+	for _, row := range rows {
+		response := CourseOfferingLecturerResponse{
+			LecturerID: uuidToString(row.LecturerID),
+			NIDN:       row.Nidn,
+		}
+		if row.LecturerName.Valid {
+			name := row.LecturerName.String
+			response.Name = &name
+		}
+		if row.CreatedAt.Valid {
+			response.AssignedAt = row.CreatedAt.Time
+		}
+		responses = append(responses, response)
+	}
+	return responses
+}
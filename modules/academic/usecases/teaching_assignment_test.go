@@ -0,0 +1,302 @@
+package usecases
+
+import (
+	"context"
+	"siakad-poc/common"
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock repository for teaching assignment tests
+type MockLecturerRepository struct {
+	mock.Mock
+}
+
+func (m *MockLecturerRepository) GetLecturerProfileByUserID(ctx context.Context, userID string) (generated.GetLecturerProfileByUserIDRow, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(generated.GetLecturerProfileByUserIDRow), args.Error(1)
+}
+
+func (m *MockLecturerRepository) ListLecturers(ctx context.Context, filter repositories.LecturerFilter, limit, offset int) ([]generated.Lecturer, error) {
+	args := m.Called(ctx, filter, limit, offset)
+	return args.Get(0).([]generated.Lecturer), args.Error(1)
+}
+
+func (m *MockLecturerRepository) CountLecturers(ctx context.Context, filter repositories.LecturerFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockLecturerRepository) GetLecturer(ctx context.Context, id string) (generated.Lecturer, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.Lecturer), args.Error(1)
+}
+
+func (m *MockLecturerRepository) LockLecturersTx(txCtx *common.TxContext, ids []string) error {
+	args := m.Called(txCtx, ids)
+	return args.Error(0)
+}
+
+func (m *MockLecturerRepository) GetLecturerByNIDN(ctx context.Context, nidn string) (generated.Lecturer, error) {
+	args := m.Called(ctx, nidn)
+	return args.Get(0).(generated.Lecturer), args.Error(1)
+}
+
+func (m *MockLecturerRepository) GetLecturerByUserID(ctx context.Context, userID string) (generated.Lecturer, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(generated.Lecturer), args.Error(1)
+}
+
+func (m *MockLecturerRepository) CreateLecturer(ctx context.Context, id, userID string, attributes repositories.LecturerAttributes) (generated.Lecturer, error) {
+	args := m.Called(ctx, id, userID, attributes)
+	return args.Get(0).(generated.Lecturer), args.Error(1)
+}
+
+func (m *MockLecturerRepository) UpdateLecturer(ctx context.Context, id string, attributes repositories.LecturerAttributes) (generated.Lecturer, error) {
+	args := m.Called(ctx, id, attributes)
+	return args.Get(0).(generated.Lecturer), args.Error(1)
+}
+
+func (m *MockLecturerRepository) DeleteLecturer(ctx context.Context, id string) (generated.Lecturer, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.Lecturer), args.Error(1)
+}
+
+var (
+	teachingSemesterStart = pgtype.Timestamptz{Time: time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC), Valid: true}
+	teachingSemesterEnd   = pgtype.Timestamptz{Time: time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC), Valid: true}
+	teachingLecturerUUID  = pgtype.UUID{Bytes: [16]byte{8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8}, Valid: true}
+)
+
+// newTeachingOffering returns an offering of the semester meeting weekly at the hour on the ISO day
+func (suite *CourseOfferingUseCaseTestSuite) newTeachingOffering(id pgtype.UUID, courseCode string, credit int32, day int16, hour int) repositories.CourseOfferingWithCourse {
+	firstMeeting := teachingSemesterStart.Time.AddDate(0, 0, int(day)-1).Add(time.Duration(hour) * time.Hour)
+	return repositories.CourseOfferingWithCourse{
+		CourseOfferingID:        id,
+		SemesterID:              suite.semesterUUID,
+		CourseOfferingStartTime: pgtype.Timestamptz{Time: firstMeeting, Valid: true},
+		CourseCode:              courseCode,
+		SectionCode:             "A1",
+		Credit:                  credit,
+		SemesterStartTime:       teachingSemesterStart,
+		SemesterEndTime:         teachingSemesterEnd,
+		Schedules: []generated.CourseOfferingSchedule{
+			{DayOfWeek: day, StartTime: pgtype.Time{Microseconds: int64(time.Duration(hour) * time.Hour / time.Microsecond), Valid: true}},
+		},
+	}
+}
+
+// expectAssignment mocks the offering lookup, the lecturer not assigned yet and the assignment written in the
+// transaction
+func (suite *CourseOfferingUseCaseTestSuite) expectAssignment(offering repositories.CourseOfferingWithCourse) string {
+	id := uuidToString(offering.CourseOfferingID)
+	lecturerID := uuidToString(teachingLecturerUUID)
+
+	suite.mockRepo.On("GetCourseOfferingByIDWithDetails", suite.ctx, id).Return(offering, nil)
+	suite.mockLecturerRepo.On("GetLecturer", suite.ctx, lecturerID).Return(generated.Lecturer{ID: teachingLecturerUUID, Nidn: "0011223344"}, nil)
+	suite.mockLecturerRepo.On("LockLecturersTx", mock.AnythingOfType("*common.TxContext"), []string{lecturerID}).Return(nil)
+	suite.mockRepo.On("ListCourseOfferingLecturersTx", mock.AnythingOfType("*common.TxContext"), id).Return([]generated.ListCourseOfferingLecturersRow{}, nil)
+	suite.mockRepo.On("CreateCourseOfferingLecturerTx", mock.AnythingOfType("*common.TxContext"), mock.AnythingOfType("string"), id, lecturerID).Return(generated.CourseOfferingLecturer{}, nil).
+		Run(func(mock.Arguments) {
+			// The lecturer is locked before the assignment is saved and the offerings are read
+			suite.mockLecturerRepo.AssertCalled(suite.T(), "LockLecturersTx", mock.AnythingOfType("*common.TxContext"), []string{lecturerID})
+		})
+	suite.mockRepo.On("GetCourseOfferingWithCourseTx", mock.AnythingOfType("*common.TxContext"), id).Return(offering, nil)
+
+	return lecturerID
+}
+
+// Test assigning a lecturer free at the offering's time and within the teaching load
+func (suite *CourseOfferingUseCaseTestSuite) TestAssignLecturers_Success() {
+	offering := suite.newTeachingOffering(suite.courseOfferUUID, "CS101", 3, 1, 10)
+	id := uuidToString(suite.courseOfferUUID)
+	lecturerID := suite.expectAssignment(offering)
+	otherOffering := suite.newTeachingOffering(pgtype.UUID{Bytes: [16]byte{9}, Valid: true}, "MA101", 3, 2, 10)
+
+	suite.mockRepo.On("GetCourseOfferingsByLecturerTx", mock.AnythingOfType("*common.TxContext"), lecturerID, uuidToString(suite.semesterUUID)).Return([]repositories.CourseOfferingWithCourse{offering, otherOffering}, nil)
+	suite.mockRepo.On("ListCourseOfferingLecturers", suite.ctx, id).Return([]generated.ListCourseOfferingLecturersRow{
+		{LecturerID: teachingLecturerUUID, Nidn: "0011223344", LecturerName: pgtype.Text{String: "Dr. Siti", Valid: true}},
+	}, nil).Once()
+
+	lecturers, err := suite.useCase.AssignLecturers(suite.ctx, common.GlobalStudyProgramScope(), id, AssignLecturersRequest{LecturerIDs: []string{lecturerID}})
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), lecturers, 1)
+	assert.Equal(suite.T(), "0011223344", lecturers[0].NIDN)
+	assert.Equal(suite.T(), "Dr. Siti", *lecturers[0].Name)
+}
+
+// Test assigning a lecturer teaching another offering of the semester at an overlapping weekly slot
+func (suite *CourseOfferingUseCaseTestSuite) TestAssignLecturers_ScheduleClash() {
+	offering := suite.newTeachingOffering(suite.courseOfferUUID, "CS101", 3, 1, 10)
+	id := uuidToString(suite.courseOfferUUID)
+	lecturerID := suite.expectAssignment(offering)
+	otherOffering := suite.newTeachingOffering(pgtype.UUID{Bytes: [16]byte{9}, Valid: true}, "MA101", 3, 1, 9)
+
+	// CS101 meets Monday 10:00-12:30, MA101 Monday 09:00-11:30
+	suite.mockRepo.On("GetCourseOfferingsByLecturerTx", mock.AnythingOfType("*common.TxContext"), lecturerID, uuidToString(suite.semesterUUID)).Return([]repositories.CourseOfferingWithCourse{offering, otherOffering}, nil)
+
+	_, err := suite.useCase.AssignLecturers(suite.ctx, common.GlobalStudyProgramScope(), id, AssignLecturersRequest{LecturerIDs: []string{lecturerID}})
+
+	assert.ErrorIs(suite.T(), err, ErrLecturerScheduleClash)
+	assert.Contains(suite.T(), err.Error(), "lecturer 0011223344 teaches MA101 section A1 on Monday 09:00-11:30")
+}
+
+// Test assigning a lecturer whose teaching load of the semester would exceed the maximum
+func (suite *CourseOfferingUseCaseTestSuite) TestAssignLecturers_TeachingLoadExceeded() {
+	offering := suite.newTeachingOffering(suite.courseOfferUUID, "CS101", 3, 1, 10)
+	id := uuidToString(suite.courseOfferUUID)
+	lecturerID := suite.expectAssignment(offering)
+
+	// 3 credits on top of 4 + 3 + 3 already taught, above the 12 credits of the policy
+	teachings := []repositories.CourseOfferingWithCourse{
+		offering,
+		suite.newTeachingOffering(pgtype.UUID{Bytes: [16]byte{9, 1}, Valid: true}, "MA101", 4, 2, 8),
+		suite.newTeachingOffering(pgtype.UUID{Bytes: [16]byte{9, 2}, Valid: true}, "MA102", 3, 3, 8),
+		suite.newTeachingOffering(pgtype.UUID{Bytes: [16]byte{9, 3}, Valid: true}, "MA103", 3, 4, 8),
+	}
+	suite.mockRepo.On("GetCourseOfferingsByLecturerTx", mock.AnythingOfType("*common.TxContext"), lecturerID, uuidToString(suite.semesterUUID)).Return(teachings, nil)
+
+	_, err := suite.useCase.AssignLecturers(suite.ctx, common.GlobalStudyProgramScope(), id, AssignLecturersRequest{LecturerIDs: []string{lecturerID}})
+
+	assert.ErrorIs(suite.T(), err, ErrTeachingLoadExceeded)
+	assert.Contains(suite.T(), err.Error(), "would teach 13 credits, at most 12")
+}
+
+// Test the assignment is aborted when the lecturers can't be locked
+func (suite *CourseOfferingUseCaseTestSuite) TestAssignLecturers_LockError() {
+	offering := suite.newTeachingOffering(suite.courseOfferUUID, "CS101", 3, 1, 10)
+	id := uuidToString(suite.courseOfferUUID)
+	lecturerID := uuidToString(teachingLecturerUUID)
+
+	suite.mockRepo.On("GetCourseOfferingByIDWithDetails", suite.ctx, id).Return(offering, nil)
+	suite.mockLecturerRepo.On("GetLecturer", suite.ctx, lecturerID).Return(generated.Lecturer{ID: teachingLecturerUUID, Nidn: "0011223344"}, nil)
+	suite.mockLecturerRepo.On("LockLecturersTx", mock.AnythingOfType("*common.TxContext"), []string{lecturerID}).Return(errors.New("lock timeout"))
+
+	_, err := suite.useCase.AssignLecturers(suite.ctx, common.GlobalStudyProgramScope(), id, AssignLecturersRequest{LecturerIDs: []string{lecturerID}})
+
+	assert.ErrorContains(suite.T(), err, "cannot lock lecturers")
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateCourseOfferingLecturerTx")
+	suite.mockRepo.AssertNotCalled(suite.T(), "GetCourseOfferingsByLecturerTx")
+}
+
+// Test assigning a lecturer that does not exist
+func (suite *CourseOfferingUseCaseTestSuite) TestAssignLecturers_UnknownLecturer() {
+	offering := suite.newTeachingOffering(suite.courseOfferUUID, "CS101", 3, 1, 10)
+	id := uuidToString(suite.courseOfferUUID)
+	lecturerID := uuidToString(teachingLecturerUUID)
+
+	suite.mockRepo.On("GetCourseOfferingByIDWithDetails", suite.ctx, id).Return(offering, nil)
+	suite.mockLecturerRepo.On("GetLecturer", suite.ctx, lecturerID).Return(generated.Lecturer{}, pgx.ErrNoRows)
+
+	_, err := suite.useCase.AssignLecturers(suite.ctx, common.GlobalStudyProgramScope(), id, AssignLecturersRequest{LecturerIDs: []string{lecturerID}})
+
+	assert.ErrorIs(suite.T(), err, ErrUnknownLecturer)
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateCourseOfferingLecturerTx")
+}
+
+// Test assigning a lecturer already teaching the offering, checked once the lecturer is locked so an assignment
+// committed by a concurrent request is seen
+func (suite *CourseOfferingUseCaseTestSuite) TestAssignLecturers_AlreadyAssigned() {
+	offering := suite.newTeachingOffering(suite.courseOfferUUID, "CS101", 3, 1, 10)
+	id := uuidToString(suite.courseOfferUUID)
+	lecturerID := uuidToString(teachingLecturerUUID)
+
+	suite.mockRepo.On("GetCourseOfferingByIDWithDetails", suite.ctx, id).Return(offering, nil)
+	suite.mockLecturerRepo.On("GetLecturer", suite.ctx, lecturerID).Return(generated.Lecturer{ID: teachingLecturerUUID, Nidn: "0011223344"}, nil)
+	suite.mockLecturerRepo.On("LockLecturersTx", mock.AnythingOfType("*common.TxContext"), []string{lecturerID}).Return(nil)
+	suite.mockRepo.On("ListCourseOfferingLecturersTx", mock.AnythingOfType("*common.TxContext"), id).Return([]generated.ListCourseOfferingLecturersRow{
+		{LecturerID: teachingLecturerUUID, Nidn: "0011223344"},
+	}, nil).
+		Run(func(mock.Arguments) {
+			suite.mockLecturerRepo.AssertCalled(suite.T(), "LockLecturersTx", mock.AnythingOfType("*common.TxContext"), []string{lecturerID})
+		})
+
+	_, err := suite.useCase.AssignLecturers(suite.ctx, common.GlobalStudyProgramScope(), id, AssignLecturersRequest{LecturerIDs: []string{lecturerID}})
+
+	assert.ErrorIs(suite.T(), err, ErrLecturerAlreadyAssigned)
+	assert.Contains(suite.T(), err.Error(), "lecturer 0011223344")
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateCourseOfferingLecturerTx")
+}
+
+// Test removing a lecturer not teaching the offering
+func (suite *CourseOfferingUseCaseTestSuite) TestRemoveLecturer_NotAssigned() {
+	offering := suite.newTeachingOffering(suite.courseOfferUUID, "CS101", 3, 1, 10)
+	id := uuidToString(suite.courseOfferUUID)
+	lecturerID := uuidToString(teachingLecturerUUID)
+
+	suite.mockRepo.On("GetCourseOfferingByIDWithDetails", suite.ctx, id).Return(offering, nil)
+	suite.mockRepo.On("DeleteCourseOfferingLecturer", suite.ctx, id, lecturerID).Return(generated.CourseOfferingLecturer{}, pgx.ErrNoRows)
+
+	err := suite.useCase.RemoveLecturer(suite.ctx, common.GlobalStudyProgramScope(), id, lecturerID)
+
+	assert.ErrorIs(suite.T(), err, ErrLecturerNotAssigned)
+}
+
+// Test moving an offering onto the time of another offering of its lecturer
+func (suite *CourseOfferingUseCaseTestSuite) TestUpdateCourseOffering_LecturerScheduleClash() {
+	id := uuidToString(suite.courseOfferUUID)
+	req := UpdateCourseOfferingRequest{
+		CourseID:    "course-123",
+		SemesterID:  uuidToString(suite.semesterUUID),
+		SectionCode: "A1",
+		Capacity:    25,
+		StartTime:   time.Date(2025, 2, 4, 10, 0, 0, 0, time.UTC),
+	}
+	offering := suite.newTeachingOffering(suite.courseOfferUUID, "CS101", 3, 2, 10)
+	otherOffering := suite.newTeachingOffering(pgtype.UUID{Bytes: [16]byte{9}, Valid: true}, "MA101", 2, 2, 11)
+	lecturerID := uuidToString(teachingLecturerUUID)
+
+	suite.expectSemester(req.SemesterID, req.StartTime)
+	suite.mockRepo.On("ListCourseOfferingLecturers", suite.ctx, id).Return([]generated.ListCourseOfferingLecturersRow{
+		{LecturerID: teachingLecturerUUID, Nidn: "0011223344"},
+	}, nil)
+	suite.mockLecturerRepo.On("LockLecturersTx", mock.AnythingOfType("*common.TxContext"), []string{lecturerID}).Return(nil)
+	suite.mockRepo.On("UpdateCourseOfferingTx", mock.AnythingOfType("*common.TxContext"), id, req.SemesterID, req.CourseID, req.SectionCode, req.Capacity, req.StartTime, req.RoomID).Return(generated.CourseOffering{ID: suite.courseOfferUUID}, nil)
+	suite.mockRepo.On("ReplaceCourseOfferingSchedulesTx", mock.AnythingOfType("*common.TxContext"), id, mock.Anything).Return(nil)
+	suite.mockRepo.On("GetCourseOfferingWithCourseTx", mock.AnythingOfType("*common.TxContext"), id).Return(offering, nil)
+	suite.mockRepo.On("GetCourseOfferingsByLecturerTx", mock.AnythingOfType("*common.TxContext"), lecturerID, req.SemesterID).Return([]repositories.CourseOfferingWithCourse{offering, otherOffering}, nil)
+
+	_, err := suite.useCase.UpdateCourseOffering(suite.ctx, common.GlobalStudyProgramScope(), id, req)
+
+	assert.ErrorIs(suite.T(), err, ErrLecturerScheduleClash)
+}
+
+// Test the schedule of a lecturer sums the credits taught in the semester
+func (suite *CourseOfferingUseCaseTestSuite) TestGetLecturerSchedule_TotalCredits() {
+	lecturerID := uuidToString(teachingLecturerUUID)
+	semesterID := uuidToString(suite.semesterUUID)
+	teachings := []repositories.CourseOfferingWithCourse{
+		suite.newTeachingOffering(suite.courseOfferUUID, "CS101", 3, 1, 10),
+		suite.newTeachingOffering(pgtype.UUID{Bytes: [16]byte{9}, Valid: true}, "MA101", 2, 3, 8),
+	}
+
+	suite.mockLecturerRepo.On("GetLecturer", suite.ctx, lecturerID).Return(generated.Lecturer{ID: teachingLecturerUUID, Nidn: "0011223344"}, nil)
+	suite.mockRepo.On("GetCourseOfferingsByLecturer", suite.ctx, lecturerID, semesterID).Return(teachings, nil)
+
+	schedule, err := suite.useCase.GetLecturerSchedule(suite.ctx, lecturerID, semesterID)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 5, schedule.TotalCredits)
+	assert.Equal(suite.T(), 12, schedule.MaxCredits)
+	assert.Len(suite.T(), schedule.CourseOfferings, 2)
+	assert.Equal(suite.T(), "CS101", schedule.CourseOfferings[0].CourseCode)
+}
+
+// Test the schedule of a lecturer that does not exist
+func (suite *CourseOfferingUseCaseTestSuite) TestGetLecturerSchedule_NotFound() {
+	lecturerID := uuidToString(teachingLecturerUUID)
+
+	suite.mockLecturerRepo.On("GetLecturer", suite.ctx, lecturerID).Return(generated.Lecturer{}, pgx.ErrNoRows)
+
+	_, err := suite.useCase.GetLecturerSchedule(suite.ctx, lecturerID, uuidToString(suite.semesterUUID))
+
+	assert.ErrorIs(suite.T(), err, ErrLecturerNotFound)
+}
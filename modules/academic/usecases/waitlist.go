@@ -0,0 +1,180 @@
+package usecases
+
+import (
+	"context"
+	"siakad-poc/common"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+// Statuses of waitlist entries, only waiting entries hold a place in the waitlist
+const (
+	WaitlistStatusWaiting  = "waiting"
+	WaitlistStatusEnrolled = "enrolled"
+	WaitlistStatusLeft     = "left"
+)
+
+// WaitlistPositionResponse is the place of the student in the waitlist of a course offering, position 1 gets the
+// next free seat the student is eligible for
+type WaitlistPositionResponse struct {
+	CourseOfferingID string    `json:"course_offering_id"`
+	StudentID        string    `json:"student_id"`
+	Position         int64     `json:"position"`
+	Waiting          int64     `json:"waiting"`
+	JoinedAt         time.Time `json:"joined_at"`
+}
+
+// JoinUserWaitlist puts the student record linked to the user on the waitlist, see JoinWaitlist.
+func (u *CourseEnrollmentUseCase) JoinUserWaitlist(ctx context.Context, userID, courseOfferingID string) (WaitlistPositionResponse, error) {
+	studentID, err := u.studentIDOfUser(ctx, userID)
+	if err != nil {
+		return WaitlistPositionResponse{}, err
+	}
+
+	return u.JoinWaitlist(ctx, studentID, courseOfferingID)
+}
+
+// JoinWaitlist puts the student at the end of the waitlist of a full course offering. The enrollment rules are only
+// checked when a seat frees up, see PromoteWaitlistTx.
+func (u *CourseEnrollmentUseCase) JoinWaitlist(ctx context.Context, studentID, courseOfferingID string) (WaitlistPositionResponse, error) {
+	err := u.txExecutor.WithTxContext(ctx, func(txCtx *common.TxContext) error {
+		exists, err := u.academicRepo.CheckEnrollmentExistsTx(txCtx, studentID, courseOfferingID)
+		if err != nil {
+			return NewDatabaseOperationError("check enrollment existence", err)
+		}
+		if exists {
+			return NewDuplicateEnrollmentError(studentID, courseOfferingID)
+		}
+
+		courseOffering, err := u.academicRepo.GetCourseOfferingWithCourseTx(txCtx, courseOfferingID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return NewCourseOfferingNotFoundError(courseOfferingID)
+			}
+			return NewDatabaseOperationError("get course offering details", err)
+		}
+
+		// Students enroll directly while there are free seats
+		enrollmentCount, err := u.academicRepo.CountCourseOfferingEnrollmentsTx(txCtx, courseOfferingID)
+		if err != nil {
+			return NewDatabaseOperationError("count current enrollments", err)
+		}
+		if enrollmentCount < int64(courseOffering.Capacity) {
+			return NewSeatsAvailableError(enrollmentCount, int64(courseOffering.Capacity))
+		}
+
+		_, err = u.waitlistRepo.GetWaitlistEntryTx(txCtx, courseOfferingID, studentID)
+		if err == nil {
+			return NewAlreadyWaitlistedError(studentID, courseOfferingID)
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return NewDatabaseOperationError("get waitlist entry", err)
+		}
+
+		_, err = u.waitlistRepo.CreateWaitlistEntryTx(txCtx, uuid.NewString(), courseOfferingID, studentID)
+		if err != nil {
+			return NewDatabaseOperationError("create waitlist entry", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return WaitlistPositionResponse{}, err
+	}
+
+	return u.waitlistPosition(ctx, studentID, courseOfferingID)
+}
+
+// GetUserWaitlistPosition returns the place of the student record linked to the user in the waitlist of the
+// course offering.
+func (u *CourseEnrollmentUseCase) GetUserWaitlistPosition(ctx context.Context, userID, courseOfferingID string) (WaitlistPositionResponse, error) {
+	studentID, err := u.studentIDOfUser(ctx, userID)
+	if err != nil {
+		return WaitlistPositionResponse{}, err
+	}
+
+	return u.waitlistPosition(ctx, studentID, courseOfferingID)
+}
+
+// LeaveUserWaitlist takes the student record linked to the user off the waitlist of the course offering.
+// It returns the ID of the student record.
+func (u *CourseEnrollmentUseCase) LeaveUserWaitlist(ctx context.Context, userID, courseOfferingID string) (string, error) {
+	studentID, err := u.studentIDOfUser(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = u.waitlistRepo.CloseWaitlistEntry(ctx, courseOfferingID, studentID, WaitlistStatusLeft)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return studentID, NewWaitlistEntryNotFoundError(studentID, courseOfferingID)
+		}
+		return studentID, NewDatabaseOperationError("close waitlist entry", err)
+	}
+
+	return studentID, nil
+}
+
+// PromoteWaitlistTx fills the free seats of the course offering from its waitlist, first come first served, within
+// the transaction that freed them. Each student goes through the enrollment rules of EnrollStudent again in a
+// savepoint: a student who no longer meets them, e.g. because of a schedule conflict or the credit load, keeps their
+// place and the next one is tried. A student whose study plan is submitted or approved is passed over the same way,
+// their entry stays waiting and is tried again the next time a seat frees up or once the academic advisor rejects
+// the plan, see StudyPlanUseCase.RejectStudyPlan. It stops once the offering is full or nobody is left waiting.
+func (u *CourseEnrollmentUseCase) PromoteWaitlistTx(txCtx *common.TxContext, courseOfferingID string) error {
+	entries, err := u.waitlistRepo.ListWaitlistEntriesForUpdateTx(txCtx, courseOfferingID)
+	if err != nil {
+		return NewDatabaseOperationError("get waitlist", err)
+	}
+
+	for _, entry := range entries {
+		err = txCtx.WithSavepoint(u.enrollTx(uuidToString(entry.StudentID), courseOfferingID))
+		if err == nil {
+			continue
+		}
+
+		errorType, _ := GetEnrollmentErrorType(err)
+		switch {
+		case errorType == ErrCapacityExceeded:
+			return nil
+		case errorType == ErrStudyPlanLocked:
+			// The plan can't take the registration while it is reviewed, the entry keeps its place
+			continue
+		case IsBusinessRuleViolation(err):
+			continue
+		case IsDataValidationError(err):
+			// The offering itself can't take registrations, e.g. it was deleted, nobody can be promoted
+			return nil
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (u *CourseEnrollmentUseCase) waitlistPosition(ctx context.Context, studentID, courseOfferingID string) (WaitlistPositionResponse, error) {
+	entry, err := u.waitlistRepo.GetWaitlistEntry(ctx, courseOfferingID, studentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return WaitlistPositionResponse{}, NewWaitlistEntryNotFoundError(studentID, courseOfferingID)
+		}
+		return WaitlistPositionResponse{}, NewDatabaseOperationError("get waitlist entry", err)
+	}
+
+	position, err := u.waitlistRepo.GetWaitlistPosition(ctx, courseOfferingID, entry.Position)
+	if err != nil {
+		return WaitlistPositionResponse{}, NewDatabaseOperationError("get waitlist position", err)
+	}
+
+	return WaitlistPositionResponse{
+		CourseOfferingID: courseOfferingID,
+		StudentID:        studentID,
+		Position:         position.Position,
+		Waiting:          position.Waiting,
+		JoinedAt:         entry.CreatedAt.Time,
+	}, nil
+}
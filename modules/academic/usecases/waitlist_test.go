@@ -0,0 +1,256 @@
+package usecases
+
+import (
+	"context"
+	"siakad-poc/common"
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock repository for waitlist tests
+type MockWaitlistRepository struct {
+	mock.Mock
+}
+
+func (m *MockWaitlistRepository) GetWaitlistEntry(ctx context.Context, courseOfferingID, studentID string) (generated.CourseOfferingWaitlist, error) {
+	args := m.Called(ctx, courseOfferingID, studentID)
+	return args.Get(0).(generated.CourseOfferingWaitlist), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) GetWaitlistPosition(ctx context.Context, courseOfferingID string, position int64) (generated.GetWaitlistPositionRow, error) {
+	args := m.Called(ctx, courseOfferingID, position)
+	return args.Get(0).(generated.GetWaitlistPositionRow), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) CloseWaitlistEntry(ctx context.Context, courseOfferingID, studentID, status string) (generated.CourseOfferingWaitlist, error) {
+	args := m.Called(ctx, courseOfferingID, studentID, status)
+	return args.Get(0).(generated.CourseOfferingWaitlist), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) GetWaitlistEntryTx(txCtx *common.TxContext, courseOfferingID, studentID string) (generated.CourseOfferingWaitlist, error) {
+	args := m.Called(txCtx, courseOfferingID, studentID)
+	return args.Get(0).(generated.CourseOfferingWaitlist), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) CreateWaitlistEntryTx(txCtx *common.TxContext, id, courseOfferingID, studentID string) (generated.CourseOfferingWaitlist, error) {
+	args := m.Called(txCtx, id, courseOfferingID, studentID)
+	return args.Get(0).(generated.CourseOfferingWaitlist), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) ListWaitlistEntriesForUpdateTx(txCtx *common.TxContext, courseOfferingID string) ([]generated.CourseOfferingWaitlist, error) {
+	args := m.Called(txCtx, courseOfferingID)
+	return args.Get(0).([]generated.CourseOfferingWaitlist), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) CloseWaitlistEntryTx(txCtx *common.TxContext, courseOfferingID, studentID, status, courseRegistrationID string) (generated.CourseOfferingWaitlist, error) {
+	args := m.Called(txCtx, courseOfferingID, studentID, status, courseRegistrationID)
+	return args.Get(0).(generated.CourseOfferingWaitlist), args.Error(1)
+}
+
+// waitlistEntry returns the waiting entry of the student at the given global position
+func waitlistEntry(studentID string, position int64) generated.CourseOfferingWaitlist {
+	var studentUUID pgtype.UUID
+	_ = studentUUID.Scan(studentID)
+	return generated.CourseOfferingWaitlist{
+		StudentID: studentUUID,
+		Position:  position,
+		Status:    WaitlistStatusWaiting,
+		CreatedAt: pgtype.Timestamptz{Time: time.Date(2025, 1, 10, 8, 0, 0, 0, time.UTC), Valid: true},
+	}
+}
+
+// Test joining the waitlist of a full course offering
+func (suite *EnrollmentUseCaseTestSuite) TestJoinWaitlist_Success() {
+	entry := waitlistEntry(suite.studentID, 42)
+
+	suite.mockRepo.On("CheckEnrollmentExistsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID).Return(false, nil)
+	suite.mockRepo.On("GetCourseOfferingWithCourseTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(repositories.CourseOfferingWithCourse{Capacity: 30}, nil)
+	suite.mockRepo.On("CountCourseOfferingEnrollmentsTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(int64(30), nil)
+	suite.mockWaitlistRepo.On("GetWaitlistEntryTx", mock.AnythingOfType("*common.TxContext"), suite.courseID, suite.studentID).Return(generated.CourseOfferingWaitlist{}, pgx.ErrNoRows)
+	suite.mockWaitlistRepo.On("CreateWaitlistEntryTx", mock.AnythingOfType("*common.TxContext"), mock.AnythingOfType("string"), suite.courseID, suite.studentID).Return(entry, nil)
+	suite.mockWaitlistRepo.On("GetWaitlistEntry", suite.ctx, suite.courseID, suite.studentID).Return(entry, nil)
+	suite.mockWaitlistRepo.On("GetWaitlistPosition", suite.ctx, suite.courseID, int64(42)).Return(generated.GetWaitlistPositionRow{Position: 3, Waiting: 3}, nil)
+
+	position, err := suite.useCase.JoinWaitlist(suite.ctx, suite.studentID, suite.courseID)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), suite.studentID, position.StudentID)
+	assert.Equal(suite.T(), int64(3), position.Position)
+	assert.Equal(suite.T(), int64(3), position.Waiting)
+	assert.Equal(suite.T(), entry.CreatedAt.Time, position.JoinedAt)
+}
+
+// Test students enroll directly instead of waiting while there are free seats
+func (suite *EnrollmentUseCaseTestSuite) TestJoinWaitlist_SeatsAvailable() {
+	suite.mockRepo.On("CheckEnrollmentExistsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID).Return(false, nil)
+	suite.mockRepo.On("GetCourseOfferingWithCourseTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(repositories.CourseOfferingWithCourse{Capacity: 30}, nil)
+	suite.mockRepo.On("CountCourseOfferingEnrollmentsTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(int64(29), nil)
+
+	_, err := suite.useCase.JoinWaitlist(suite.ctx, suite.studentID, suite.courseID)
+
+	assert.Error(suite.T(), err)
+	errorType, ok := GetEnrollmentErrorType(err)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), ErrSeatsAvailable, errorType)
+	assert.True(suite.T(), IsBusinessRuleViolation(err))
+	suite.mockWaitlistRepo.AssertNotCalled(suite.T(), "CreateWaitlistEntryTx")
+}
+
+// Test a student can't wait twice for the same course offering
+func (suite *EnrollmentUseCaseTestSuite) TestJoinWaitlist_AlreadyWaitlisted() {
+	suite.mockRepo.On("CheckEnrollmentExistsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID).Return(false, nil)
+	suite.mockRepo.On("GetCourseOfferingWithCourseTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(repositories.CourseOfferingWithCourse{Capacity: 30}, nil)
+	suite.mockRepo.On("CountCourseOfferingEnrollmentsTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(int64(30), nil)
+	suite.mockWaitlistRepo.On("GetWaitlistEntryTx", mock.AnythingOfType("*common.TxContext"), suite.courseID, suite.studentID).Return(waitlistEntry(suite.studentID, 42), nil)
+
+	_, err := suite.useCase.JoinWaitlist(suite.ctx, suite.studentID, suite.courseID)
+
+	assert.Error(suite.T(), err)
+	errorType, ok := GetEnrollmentErrorType(err)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), ErrAlreadyWaitlisted, errorType)
+	suite.mockWaitlistRepo.AssertNotCalled(suite.T(), "CreateWaitlistEntryTx")
+}
+
+// Test the position of a student who isn't waiting for the course offering
+func (suite *EnrollmentUseCaseTestSuite) TestGetUserWaitlistPosition_NotWaitlisted() {
+	userID := "550e8400-e29b-41d4-a716-446655440009"
+	var studentUUID pgtype.UUID
+	_ = studentUUID.Scan(suite.studentID)
+
+	suite.mockStudentRepo.On("GetStudentByUserID", suite.ctx, userID).Return(generated.Student{ID: studentUUID}, nil)
+	suite.mockWaitlistRepo.On("GetWaitlistEntry", suite.ctx, suite.courseID, suite.studentID).Return(generated.CourseOfferingWaitlist{}, pgx.ErrNoRows)
+
+	_, err := suite.useCase.GetUserWaitlistPosition(suite.ctx, userID, suite.courseID)
+
+	assert.Error(suite.T(), err)
+	errorType, ok := GetEnrollmentErrorType(err)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), ErrWaitlistEntryNotFound, errorType)
+	assert.True(suite.T(), IsDataValidationError(err))
+}
+
+// Test promotion skips a student who no longer meets the enrollment rules, enrolls the next one and stops once the
+// course offering is full again
+func (suite *EnrollmentUseCaseTestSuite) TestPromoteWaitlist_SkipsIneligibleStudent() {
+	conflictingStudentID := "550e8400-e29b-41d4-a716-446655440021"
+	lateStudentID := "550e8400-e29b-41d4-a716-446655440022"
+	courseOffering := repositories.CourseOfferingWithCourse{
+		Capacity: 10,
+		CourseOfferingStartTime: pgtype.Timestamptz{
+			Time:  time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC),
+			Valid: true,
+		},
+		Credit: 3,
+	}
+	conflictingEnrollment := []repositories.StudentEnrollmentWithDetails{
+		{
+			CourseOfferingStartTime: pgtype.Timestamptz{
+				Time:  time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC),
+				Valid: true,
+			},
+			Credit: 2,
+		},
+	}
+
+	suite.mockWaitlistRepo.On("ListWaitlistEntriesForUpdateTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return([]generated.CourseOfferingWaitlist{
+		waitlistEntry(conflictingStudentID, 1),
+		waitlistEntry(suite.studentID, 2),
+		waitlistEntry(lateStudentID, 3),
+	}, nil)
+	suite.mockRepo.On("GetCourseOfferingWithCourseTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(courseOffering, nil)
+	suite.mockRepo.On("CountCourseOfferingEnrollmentsTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(int64(9), nil).Twice()
+	suite.mockRepo.On("CountCourseOfferingEnrollmentsTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(int64(10), nil).Once()
+
+	// The first student got a class at the same time since joining the waitlist
+	suite.mockRepo.On("CheckEnrollmentExistsTx", mock.AnythingOfType("*common.TxContext"), conflictingStudentID, suite.courseID).Return(false, nil)
+	suite.mockRepo.On("GetStudentEnrollmentsWithDetailsTx", mock.AnythingOfType("*common.TxContext"), conflictingStudentID).Return(conflictingEnrollment, nil)
+
+	// The second student takes the seat
+	suite.mockRepo.On("CheckEnrollmentExistsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID).Return(false, nil)
+	suite.mockRepo.On("GetStudentEnrollmentsWithDetailsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID).Return([]repositories.StudentEnrollmentWithDetails{}, nil)
+	suite.mockRepo.On("GetMissingPrerequisitesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, passingGrades).Return([]generated.GetMissingPrerequisitesRow{}, nil)
+	suite.mockRepo.On("GetMissingCorequisitesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, mock.Anything, mock.Anything).Return([]generated.GetMissingCorequisitesRow{}, nil)
+	suite.mockRepo.On("GetEnrolledExcludedCoursesTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, mock.Anything, mock.Anything).Return([]generated.GetEnrolledExcludedCoursesRow{}, nil)
+	suite.expectStudyPlan()
+	suite.expectCreditAllowance()
+	suite.mockRepo.On("CreateEnrollmentTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID, suite.studyPlanID).Return(generated.CourseRegistration{}, nil)
+	suite.mockWaitlistRepo.On("CloseWaitlistEntryTx", mock.AnythingOfType("*common.TxContext"), suite.courseID, suite.studentID, WaitlistStatusEnrolled, mock.AnythingOfType("string")).Return(waitlistEntry(suite.studentID, 2), nil)
+
+	// The last student finds the course offering full again
+	suite.mockRepo.On("CheckEnrollmentExistsTx", mock.AnythingOfType("*common.TxContext"), lateStudentID, suite.courseID).Return(false, nil)
+
+	err := suite.useCase.PromoteWaitlistTx(common.NewTxContext(suite.ctx, &common.MockTx{}), suite.courseID)
+
+	assert.NoError(suite.T(), err)
+	suite.mockRepo.AssertNumberOfCalls(suite.T(), "CreateEnrollmentTx", 1)
+	suite.mockWaitlistRepo.AssertNumberOfCalls(suite.T(), "CloseWaitlistEntryTx", 1)
+}
+
+// Test promotion passes over a student whose study plan is under review without closing their entry, and enrolls
+// the next one
+func (suite *EnrollmentUseCaseTestSuite) TestPromoteWaitlist_StudyPlanLocked() {
+	lockedStudentID := "550e8400-e29b-41d4-a716-446655440023"
+	courseOffering := repositories.CourseOfferingWithCourse{
+		Capacity: 10,
+		CourseOfferingStartTime: pgtype.Timestamptz{
+			Time:  time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC),
+			Valid: true,
+		},
+		Credit: 3,
+	}
+	submittedPlan := suite.studyPlan
+	submittedPlan.Status = StudyPlanStatusSubmitted
+
+	suite.mockWaitlistRepo.On("ListWaitlistEntriesForUpdateTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return([]generated.CourseOfferingWaitlist{
+		waitlistEntry(lockedStudentID, 1),
+		waitlistEntry(suite.studentID, 2),
+	}, nil)
+	suite.mockRepo.On("GetCourseOfferingWithCourseTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(courseOffering, nil)
+	suite.mockRepo.On("CountCourseOfferingEnrollmentsTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return(int64(9), nil)
+	suite.mockRepo.On("GetMissingPrerequisitesTx", mock.AnythingOfType("*common.TxContext"), mock.AnythingOfType("string"), mock.Anything, passingGrades).Return([]generated.GetMissingPrerequisitesRow{}, nil)
+	suite.mockRepo.On("GetMissingCorequisitesTx", mock.AnythingOfType("*common.TxContext"), mock.AnythingOfType("string"), mock.Anything, mock.Anything, mock.Anything).Return([]generated.GetMissingCorequisitesRow{}, nil)
+	suite.mockRepo.On("GetEnrolledExcludedCoursesTx", mock.AnythingOfType("*common.TxContext"), mock.AnythingOfType("string"), mock.Anything, mock.Anything).Return([]generated.GetEnrolledExcludedCoursesRow{}, nil)
+
+	// The first student submitted their study plan to the academic advisor since joining the waitlist
+	suite.mockRepo.On("CheckEnrollmentExistsTx", mock.AnythingOfType("*common.TxContext"), lockedStudentID, suite.courseID).Return(false, nil)
+	suite.mockRepo.On("GetStudentEnrollmentsWithDetailsTx", mock.AnythingOfType("*common.TxContext"), lockedStudentID).Return([]repositories.StudentEnrollmentWithDetails{}, nil)
+	suite.mockStudyPlanRepo.On("GetOrCreateStudyPlanTx", mock.AnythingOfType("*common.TxContext"), mock.AnythingOfType("string"), lockedStudentID, mock.AnythingOfType("string")).Return(submittedPlan, nil)
+
+	// The second student takes the seat
+	suite.mockRepo.On("CheckEnrollmentExistsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID).Return(false, nil)
+	suite.mockRepo.On("GetStudentEnrollmentsWithDetailsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID).Return([]repositories.StudentEnrollmentWithDetails{}, nil)
+	suite.expectStudyPlan()
+	suite.expectCreditAllowance()
+	suite.mockRepo.On("CreateEnrollmentTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID, suite.studyPlanID).Return(generated.CourseRegistration{}, nil)
+	suite.mockWaitlistRepo.On("CloseWaitlistEntryTx", mock.AnythingOfType("*common.TxContext"), suite.courseID, suite.studentID, WaitlistStatusEnrolled, mock.AnythingOfType("string")).Return(waitlistEntry(suite.studentID, 2), nil)
+
+	err := suite.useCase.PromoteWaitlistTx(common.NewTxContext(suite.ctx, &common.MockTx{}), suite.courseID)
+
+	assert.NoError(suite.T(), err)
+	suite.mockRepo.AssertNumberOfCalls(suite.T(), "CreateEnrollmentTx", 1)
+	// The entry of the first student is neither enrolled nor left, it keeps its place in the waitlist
+	suite.mockWaitlistRepo.AssertNotCalled(suite.T(), "CloseWaitlistEntryTx", mock.Anything, suite.courseID, lockedStudentID, mock.Anything, mock.Anything)
+	suite.mockWaitlistRepo.AssertNumberOfCalls(suite.T(), "CloseWaitlistEntryTx", 1)
+}
+
+// Test promotion gives up on database errors so the transaction freeing the seat is rolled back
+func (suite *EnrollmentUseCaseTestSuite) TestPromoteWaitlist_RepositoryError() {
+	suite.mockWaitlistRepo.On("ListWaitlistEntriesForUpdateTx", mock.AnythingOfType("*common.TxContext"), suite.courseID).Return([]generated.CourseOfferingWaitlist{
+		waitlistEntry(suite.studentID, 1),
+	}, nil)
+	suite.mockRepo.On("CheckEnrollmentExistsTx", mock.AnythingOfType("*common.TxContext"), suite.studentID, suite.courseID).Return(false, pgx.ErrTxClosed)
+
+	err := suite.useCase.PromoteWaitlistTx(common.NewTxContext(suite.ctx, &common.MockTx{}), suite.courseID)
+
+	assert.Error(suite.T(), err)
+	errorType, ok := GetEnrollmentErrorType(err)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), ErrDatabaseOperation, errorType)
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"siakad-poc/common"
+	"siakad-poc/modules/admin/usecases"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type CourseCompletionImportHandler struct {
+	useCase *usecases.CourseCompletionImportUseCase
+}
+
+func NewCourseCompletionImportHandler(useCase *usecases.CourseCompletionImportUseCase) *CourseCompletionImportHandler {
+	return &CourseCompletionImportHandler{
+		useCase: useCase,
+	}
+}
+
+// HandleImportCourseCompletions accepts the CSV as the `file` field of a multipart form.
+func (h *CourseCompletionImportHandler) HandleImportCourseCompletions(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		log.Warn().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("path", c.OriginalURL()).
+			Str("method", c.Method()).
+			Msg("Course completion import file missing")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "CSV file is required",
+				Details:   []string{err.Error()},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("path", c.OriginalURL()).
+			Msg("Failed to open course completion import file")
+
+		return c.Status(fiber.StatusInternalServerError).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Cannot read CSV file",
+				Details:   []string{err.Error()},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+	defer file.Close()
+
+	report, err := h.useCase.Import(c.Context(), file)
+	if err != nil {
+		if errors.Is(err, usecases.ErrImportRejected) {
+			log.Warn().
+				Str("request_id", requestID).
+				Str("client_ip", clientIP).
+				Int("total_rows", report.TotalRows).
+				Int("rejected_rows", len(report.Errors)).
+				Str("path", c.OriginalURL()).
+				Msg("Course completion import rejected")
+
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(common.BaseResponse[usecases.CourseCompletionImportReport]{
+				Status: common.StatusError,
+				Data:   &report,
+				Error: &common.BaseResponseError{
+					Message:   "Course completion import rejected",
+					Details:   []string{err.Error()},
+					Timestamp: time.Now().UTC().Format(time.RFC3339),
+					Path:      c.OriginalURL(),
+				},
+			})
+		}
+
+		if errors.Is(err, usecases.ErrInvalidImportFile) || errors.Is(err, usecases.ErrEmptyImport) {
+			log.Warn().
+				Err(err).
+				Str("request_id", requestID).
+				Str("client_ip", clientIP).
+				Str("path", c.OriginalURL()).
+				Msg("Invalid course completion import file")
+
+			return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+				Status: common.StatusError,
+				Error: &common.BaseResponseError{
+					Message:   "Invalid CSV file",
+					Details:   []string{err.Error()},
+					Timestamp: time.Now().UTC().Format(time.RFC3339),
+					Path:      c.OriginalURL(),
+				},
+			})
+		}
+
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Int("total_rows", report.TotalRows).
+			Str("path", c.OriginalURL()).
+			Msg("Course completion import failed")
+
+		return c.Status(fiber.StatusInternalServerError).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Course completion import failed",
+				Details:   []string{err.Error()},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Int("imported_rows", report.ImportedRows).
+		Str("imported_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Course completions imported")
+
+	return c.Status(fiber.StatusCreated).JSON(common.BaseResponse[usecases.CourseCompletionImportReport]{
+		Status: common.StatusSuccess,
+		Data:   &report,
+	})
+}
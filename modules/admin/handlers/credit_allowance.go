@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"siakad-poc/common"
+	"siakad-poc/modules/admin/usecases"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+type CreditAllowanceHandler struct {
+	useCase *usecases.CreditAllowanceUseCase
+}
+
+func NewCreditAllowanceHandler(useCase *usecases.CreditAllowanceUseCase) *CreditAllowanceHandler {
+	return &CreditAllowanceHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *CreditAllowanceHandler) HandleGetCreditAllowance(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	allowance, err := h.useCase.GetCreditAllowance(c.Context(), id)
+	if err != nil {
+		return respondStudentError(c, requestID, clientIP, id, "Failed to get credit allowance", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.CreditAllowanceResponse]{
+		Status: common.StatusSuccess,
+		Data:   &allowance,
+	})
+}
+
+func (h *CreditAllowanceHandler) HandleSetCreditAllowance(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	var req usecases.SetCreditAllowanceRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("student_id", id).
+			Str("path", c.OriginalURL()).
+			Str("method", c.Method()).
+			Msg("Failed to parse set credit allowance request body")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Cannot parse request body",
+				Details:   []string{err.Error()},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	if validationErrors := common.ValidateStruct(req); validationErrors != nil {
+		log.Warn().
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("student_id", id).
+			Strs("validation_errors", validationErrors).
+			Str("path", c.OriginalURL()).
+			Msg("Set credit allowance validation failed")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Validation failed",
+				Details:   validationErrors,
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	allowance, err := h.useCase.SetCreditAllowance(c.Context(), actorID(c), id, req)
+	if err != nil {
+		return respondStudentError(c, requestID, clientIP, id, "Failed to set credit allowance", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("student_id", id).
+		Int32("max_credits", allowance.MaxCredits).
+		Str("set_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Credit allowance set")
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.CreditAllowanceResponse]{
+		Status: common.StatusSuccess,
+		Data:   &allowance,
+	})
+}
+
+func (h *CreditAllowanceHandler) HandleDeleteCreditAllowance(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	err := h.useCase.DeleteCreditAllowance(c.Context(), id)
+	if err != nil {
+		return respondStudentError(c, requestID, clientIP, id, "Failed to delete credit allowance", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("student_id", id).
+		Str("deleted_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Credit allowance deleted")
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
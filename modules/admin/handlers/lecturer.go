@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"siakad-poc/common"
+	"siakad-poc/db/repositories"
+	"siakad-poc/modules/admin/usecases"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type LecturerHandler struct {
+	useCase *usecases.LecturerUseCase
+}
+
+func NewLecturerHandler(useCase *usecases.LecturerUseCase) *LecturerHandler {
+	return &LecturerHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *LecturerHandler) HandleListLecturers(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	page := 1
+	pageSize := 10
+
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	if ps, err := strconv.Atoi(c.Query("page_size")); err == nil && ps > 0 {
+		pageSize = ps
+	}
+
+	filter := repositories.LecturerFilter{
+		HomebaseStudyProgramID: c.Query("homebase_study_program_id"),
+		NIDN:                   c.Query("nidn"),
+	}
+
+	lecturers, pagination, err := h.useCase.ListLecturers(c.Context(), filter, page, pageSize)
+	if err != nil {
+		return respondLecturerError(c, requestID, clientIP, "", "Failed to get lecturers", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.PaginatedBaseResponse[[]usecases.LecturerResponse]{
+		BaseResponse: common.BaseResponse[[]usecases.LecturerResponse]{
+			Status: common.StatusSuccess,
+			Data:   &lecturers,
+		},
+		Paging: pagination,
+	})
+}
+
+func (h *LecturerHandler) HandleGetLecturer(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	lecturer, err := h.useCase.GetLecturer(c.Context(), id)
+	if err != nil {
+		return respondLecturerError(c, requestID, clientIP, id, "Failed to get lecturer", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.LecturerResponse]{
+		Status: common.StatusSuccess,
+		Data:   &lecturer,
+	})
+}
+
+func (h *LecturerHandler) HandleCreateLecturer(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	var req usecases.CreateLecturerRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("path", c.OriginalURL()).
+			Str("method", c.Method()).
+			Msg("Failed to parse create lecturer request body")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Cannot parse request body",
+				Details:   []string{err.Error()},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	if validationErrors := common.ValidateStruct(req); validationErrors != nil {
+		log.Warn().
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("user_id", req.UserID).
+			Str("nidn", req.NIDN).
+			Strs("validation_errors", validationErrors).
+			Str("path", c.OriginalURL()).
+			Msg("Create lecturer validation failed")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Validation failed",
+				Details:   validationErrors,
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	lecturer, err := h.useCase.CreateLecturer(c.Context(), req)
+	if err != nil {
+		return respondLecturerError(c, requestID, clientIP, "", "Failed to create lecturer", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("lecturer_id", lecturer.ID).
+		Str("user_id", lecturer.UserID).
+		Str("nidn", lecturer.NIDN).
+		Str("created_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Lecturer created")
+
+	return c.Status(fiber.StatusCreated).JSON(common.BaseResponse[usecases.LecturerResponse]{
+		Status: common.StatusSuccess,
+		Data:   &lecturer,
+	})
+}
+
+func (h *LecturerHandler) HandleUpdateLecturer(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	var req usecases.UpdateLecturerRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("lecturer_id", id).
+			Str("path", c.OriginalURL()).
+			Str("method", c.Method()).
+			Msg("Failed to parse update lecturer request body")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Cannot parse request body",
+				Details:   []string{err.Error()},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	if validationErrors := common.ValidateStruct(req); validationErrors != nil {
+		log.Warn().
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("lecturer_id", id).
+			Str("nidn", req.NIDN).
+			Strs("validation_errors", validationErrors).
+			Str("path", c.OriginalURL()).
+			Msg("Update lecturer validation failed")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Validation failed",
+				Details:   validationErrors,
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	lecturer, err := h.useCase.UpdateLecturer(c.Context(), id, req)
+	if err != nil {
+		return respondLecturerError(c, requestID, clientIP, id, "Failed to update lecturer", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("lecturer_id", id).
+		Str("nidn", lecturer.NIDN).
+		Str("updated_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Lecturer updated")
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.LecturerResponse]{
+		Status: common.StatusSuccess,
+		Data:   &lecturer,
+	})
+}
+
+func (h *LecturerHandler) HandleDeleteLecturer(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	err := h.useCase.DeleteLecturer(c.Context(), id)
+	if err != nil {
+		return respondLecturerError(c, requestID, clientIP, id, "Failed to delete lecturer", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("lecturer_id", id).
+		Str("deleted_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Lecturer soft-deleted")
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// respondLecturerError maps lecturer administration errors to HTTP status codes, unknown errors become 500.
+func respondLecturerError(c *fiber.Ctx, requestID, clientIP, lecturerID, message string, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, usecases.ErrLecturerNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, usecases.ErrNIDNAlreadyUsed), errors.Is(err, usecases.ErrUserAlreadyLecturer),
+		errors.Is(err, usecases.ErrLecturerHasAdvisees):
+		status = fiber.StatusConflict
+	case errors.Is(err, usecases.ErrUnknownUser), errors.Is(err, usecases.ErrUnknownStudyProgram):
+		status = fiber.StatusUnprocessableEntity
+	}
+
+	if status == fiber.StatusInternalServerError {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("lecturer_id", lecturerID).
+			Str("path", c.OriginalURL()).
+			Msg(message)
+	} else {
+		log.Warn().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("lecturer_id", lecturerID).
+			Str("path", c.OriginalURL()).
+			Msg(message)
+	}
+
+	return c.Status(status).JSON(common.BaseResponse[any]{
+		Status: common.StatusError,
+		Error: &common.BaseResponseError{
+			Message:   message,
+			Details:   []string{err.Error()},
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Path:      c.OriginalURL(),
+		},
+	})
+}
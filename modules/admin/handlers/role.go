@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"siakad-poc/common"
+	"siakad-poc/modules/admin/usecases"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type RoleHandler struct {
+	useCase *usecases.RoleUseCase
+}
+
+func NewRoleHandler(useCase *usecases.RoleUseCase) *RoleHandler {
+	return &RoleHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *RoleHandler) HandleListPermissions(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	permissions, err := h.useCase.ListPermissions(c.Context())
+	if err != nil {
+		return respondRoleError(c, requestID, clientIP, 0, "Failed to get permissions", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[[]usecases.PermissionResponse]{
+		Status: common.StatusSuccess,
+		Data:   &permissions,
+	})
+}
+
+func (h *RoleHandler) HandleListRoles(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	roles, err := h.useCase.ListRoles(c.Context())
+	if err != nil {
+		return respondRoleError(c, requestID, clientIP, 0, "Failed to get roles", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[[]usecases.RoleResponse]{
+		Status: common.StatusSuccess,
+		Data:   &roles,
+	})
+}
+
+func (h *RoleHandler) HandleCreateRole(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	var req usecases.CreateRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("path", c.OriginalURL()).
+			Str("method", c.Method()).
+			Msg("Failed to parse create role request body")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Cannot parse request body",
+				Details:   []string{err.Error()},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	if validationErrors := common.ValidateStruct(req); validationErrors != nil {
+		log.Warn().
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Int64("role", req.ID).
+			Strs("validation_errors", validationErrors).
+			Str("path", c.OriginalURL()).
+			Msg("Create role validation failed")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Validation failed",
+				Details:   validationErrors,
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	role, err := h.useCase.CreateRole(c.Context(), req)
+	if err != nil {
+		return respondRoleError(c, requestID, clientIP, req.ID, "Failed to create role", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Int64("role", role.ID).
+		Str("role_name", role.Name).
+		Str("created_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Role created")
+
+	return c.Status(fiber.StatusCreated).JSON(common.BaseResponse[usecases.RoleResponse]{
+		Status: common.StatusSuccess,
+		Data:   &role,
+	})
+}
+
+func (h *RoleHandler) HandleUpdateRolePermissions(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		log.Warn().
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("role", c.Params("id")).
+			Str("path", c.OriginalURL()).
+			Msg("Invalid role id")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Validation failed",
+				Details:   []string{"role id must be a number"},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	var req usecases.UpdateRolePermissionsRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Int64("role", id).
+			Str("path", c.OriginalURL()).
+			Str("method", c.Method()).
+			Msg("Failed to parse update role permissions request body")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Cannot parse request body",
+				Details:   []string{err.Error()},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	if validationErrors := common.ValidateStruct(req); validationErrors != nil {
+		log.Warn().
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Int64("role", id).
+			Strs("validation_errors", validationErrors).
+			Str("path", c.OriginalURL()).
+			Msg("Update role permissions validation failed")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Validation failed",
+				Details:   validationErrors,
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	role, err := h.useCase.UpdateRolePermissions(c.Context(), actorRole(c), id, req)
+	if err != nil {
+		return respondRoleError(c, requestID, clientIP, id, "Failed to update role permissions", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Int64("role", id).
+		Strs("permissions", role.Permissions).
+		Str("updated_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Role permissions updated")
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.RoleResponse]{
+		Status: common.StatusSuccess,
+		Data:   &role,
+	})
+}
+
+// respondRoleError maps role administration errors to HTTP status codes, unknown errors become 500.
+func respondRoleError(c *fiber.Ctx, requestID, clientIP string, roleID int64, message string, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, usecases.ErrRoleNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, usecases.ErrRoleAlreadyExists):
+		status = fiber.StatusConflict
+	case errors.Is(err, usecases.ErrUnknownPermission), errors.Is(err, usecases.ErrCannotRevokeOwnRoleManage):
+		status = fiber.StatusUnprocessableEntity
+	}
+
+	if status == fiber.StatusInternalServerError {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Int64("role", roleID).
+			Str("path", c.OriginalURL()).
+			Msg(message)
+	} else {
+		log.Warn().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Int64("role", roleID).
+			Str("path", c.OriginalURL()).
+			Msg(message)
+	}
+
+	return c.Status(status).JSON(common.BaseResponse[any]{
+		Status: common.StatusError,
+		Error: &common.BaseResponseError{
+			Message:   message,
+			Details:   []string{err.Error()},
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Path:      c.OriginalURL(),
+		},
+	})
+}
@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"siakad-poc/common"
+	"siakad-poc/db/repositories"
+	"siakad-poc/modules/admin/usecases"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type StudentHandler struct {
+	useCase *usecases.StudentUseCase
+}
+
+func NewStudentHandler(useCase *usecases.StudentUseCase) *StudentHandler {
+	return &StudentHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *StudentHandler) HandleListStudents(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	page := 1
+	pageSize := 10
+
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	if ps, err := strconv.Atoi(c.Query("page_size")); err == nil && ps > 0 {
+		pageSize = ps
+	}
+
+	filter := repositories.StudentFilter{
+		StudyProgramID: c.Query("study_program_id"),
+		NIM:            c.Query("nim"),
+	}
+
+	students, pagination, err := h.useCase.ListStudents(c.Context(), filter, page, pageSize)
+	if err != nil {
+		return respondStudentError(c, requestID, clientIP, "", "Failed to get students", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.PaginatedBaseResponse[[]usecases.StudentResponse]{
+		BaseResponse: common.BaseResponse[[]usecases.StudentResponse]{
+			Status: common.StatusSuccess,
+			Data:   &students,
+		},
+		Paging: pagination,
+	})
+}
+
+func (h *StudentHandler) HandleGetStudent(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	student, err := h.useCase.GetStudent(c.Context(), id)
+	if err != nil {
+		return respondStudentError(c, requestID, clientIP, id, "Failed to get student", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.StudentResponse]{
+		Status: common.StatusSuccess,
+		Data:   &student,
+	})
+}
+
+func (h *StudentHandler) HandleCreateStudent(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	var req usecases.CreateStudentRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("path", c.OriginalURL()).
+			Str("method", c.Method()).
+			Msg("Failed to parse create student request body")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Cannot parse request body",
+				Details:   []string{err.Error()},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	if validationErrors := common.ValidateStruct(req); validationErrors != nil {
+		log.Warn().
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("user_id", req.UserID).
+			Str("nim", req.NIM).
+			Strs("validation_errors", validationErrors).
+			Str("path", c.OriginalURL()).
+			Msg("Create student validation failed")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Validation failed",
+				Details:   validationErrors,
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	student, err := h.useCase.CreateStudent(c.Context(), req)
+	if err != nil {
+		return respondStudentError(c, requestID, clientIP, "", "Failed to create student", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("student_id", student.ID).
+		Str("user_id", student.UserID).
+		Str("nim", student.NIM).
+		Str("created_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Student created")
+
+	return c.Status(fiber.StatusCreated).JSON(common.BaseResponse[usecases.StudentResponse]{
+		Status: common.StatusSuccess,
+		Data:   &student,
+	})
+}
+
+func (h *StudentHandler) HandleUpdateStudent(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	var req usecases.UpdateStudentRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("student_id", id).
+			Str("path", c.OriginalURL()).
+			Str("method", c.Method()).
+			Msg("Failed to parse update student request body")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Cannot parse request body",
+				Details:   []string{err.Error()},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	if validationErrors := common.ValidateStruct(req); validationErrors != nil {
+		log.Warn().
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("student_id", id).
+			Str("nim", req.NIM).
+			Strs("validation_errors", validationErrors).
+			Str("path", c.OriginalURL()).
+			Msg("Update student validation failed")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Validation failed",
+				Details:   validationErrors,
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	student, err := h.useCase.UpdateStudent(c.Context(), id, req)
+	if err != nil {
+		return respondStudentError(c, requestID, clientIP, id, "Failed to update student", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("student_id", id).
+		Str("nim", student.NIM).
+		Str("updated_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Student updated")
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.StudentResponse]{
+		Status: common.StatusSuccess,
+		Data:   &student,
+	})
+}
+
+func (h *StudentHandler) HandleDeleteStudent(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	err := h.useCase.DeleteStudent(c.Context(), id)
+	if err != nil {
+		return respondStudentError(c, requestID, clientIP, id, "Failed to delete student", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("student_id", id).
+		Str("deleted_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Student soft-deleted")
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// respondStudentError maps student administration errors to HTTP status codes, unknown errors become 500.
+func respondStudentError(c *fiber.Ctx, requestID, clientIP, studentID, message string, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, usecases.ErrStudentNotFound), errors.Is(err, usecases.ErrCreditAllowanceNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, usecases.ErrNIMAlreadyUsed), errors.Is(err, usecases.ErrUserAlreadyStudent):
+		status = fiber.StatusConflict
+	case errors.Is(err, usecases.ErrUnknownUser), errors.Is(err, usecases.ErrUnknownStudyProgram),
+		errors.Is(err, usecases.ErrUnknownAcademicAdvisor):
+		status = fiber.StatusUnprocessableEntity
+	}
+
+	if status == fiber.StatusInternalServerError {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("student_id", studentID).
+			Str("path", c.OriginalURL()).
+			Msg(message)
+	} else {
+		log.Warn().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("student_id", studentID).
+			Str("path", c.OriginalURL()).
+			Msg(message)
+	}
+
+	return c.Status(status).JSON(common.BaseResponse[any]{
+		Status: common.StatusError,
+		Error: &common.BaseResponseError{
+			Message:   message,
+			Details:   []string{err.Error()},
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Path:      c.OriginalURL(),
+		},
+	})
+}
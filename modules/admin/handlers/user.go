@@ -0,0 +1,509 @@
+package handlers
+
+import (
+	"siakad-poc/common"
+	"siakad-poc/db/repositories"
+	"siakad-poc/middlewares"
+	"siakad-poc/modules/admin/usecases"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type UserHandler struct {
+	useCase *usecases.UserUseCase
+}
+
+func NewUserHandler(useCase *usecases.UserUseCase) *UserHandler {
+	return &UserHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *UserHandler) HandleListUsers(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	page := 1
+	pageSize := 10
+
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	if ps, err := strconv.Atoi(c.Query("page_size")); err == nil && ps > 0 {
+		pageSize = ps
+	}
+
+	filter := repositories.UserFilter{
+		Email:          c.Query("email"),
+		IncludeDeleted: c.QueryBool("include_deleted", false),
+	}
+	if roleStr := c.Query("role"); roleStr != "" {
+		role, err := strconv.ParseInt(roleStr, 10, 64)
+		if err != nil {
+			log.Warn().
+				Str("request_id", requestID).
+				Str("client_ip", clientIP).
+				Str("role", roleStr).
+				Str("path", c.OriginalURL()).
+				Msg("Invalid role filter")
+
+			return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+				Status: common.StatusError,
+				Error: &common.BaseResponseError{
+					Message:   "Validation failed",
+					Details:   []string{"role must be a number"},
+					Timestamp: time.Now().UTC().Format(time.RFC3339),
+					Path:      c.OriginalURL(),
+				},
+			})
+		}
+		filter.Role = role
+	}
+
+	users, pagination, err := h.useCase.ListUsers(c.Context(), filter, page, pageSize)
+	if err != nil {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Int("page", page).
+			Int("page_size", pageSize).
+			Str("path", c.OriginalURL()).
+			Str("method", c.Method()).
+			Msg("Failed to get users")
+
+		return c.Status(fiber.StatusInternalServerError).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Internal server error",
+				Details:   []string{err.Error()},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.PaginatedBaseResponse[[]usecases.UserResponse]{
+		BaseResponse: common.BaseResponse[[]usecases.UserResponse]{
+			Status: common.StatusSuccess,
+			Data:   &users,
+		},
+		Paging: pagination,
+	})
+}
+
+func (h *UserHandler) HandleGetUser(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	user, err := h.useCase.GetUser(c.Context(), id)
+	if err != nil {
+		return respondUserError(c, requestID, clientIP, id, "Failed to get user", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.UserResponse]{
+		Status: common.StatusSuccess,
+		Data:   &user,
+	})
+}
+
+func (h *UserHandler) HandleCreateUser(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	var req usecases.CreateUserRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("path", c.OriginalURL()).
+			Str("method", c.Method()).
+			Msg("Failed to parse create user request body")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Cannot parse request body",
+				Details:   []string{err.Error()},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	if validationErrors := common.ValidateStruct(req); validationErrors != nil {
+		log.Warn().
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("email", req.Email).
+			Int64("role", req.Role).
+			Strs("validation_errors", validationErrors).
+			Str("path", c.OriginalURL()).
+			Msg("Create user validation failed")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Validation failed",
+				Details:   validationErrors,
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	user, err := h.useCase.CreateUser(c.Context(), req)
+	if err != nil {
+		return respondUserError(c, requestID, clientIP, "", "Failed to create user", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("user_id", user.ID).
+		Int64("role", user.Role).
+		Str("created_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("User created")
+
+	return c.Status(fiber.StatusCreated).JSON(common.BaseResponse[usecases.UserResponse]{
+		Status: common.StatusSuccess,
+		Data:   &user,
+	})
+}
+
+func (h *UserHandler) HandleUpdateUserRole(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	var req usecases.UpdateUserRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("user_id", id).
+			Str("path", c.OriginalURL()).
+			Str("method", c.Method()).
+			Msg("Failed to parse update user role request body")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Cannot parse request body",
+				Details:   []string{err.Error()},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	if validationErrors := common.ValidateStruct(req); validationErrors != nil {
+		log.Warn().
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("user_id", id).
+			Int64("role", req.Role).
+			Strs("validation_errors", validationErrors).
+			Str("path", c.OriginalURL()).
+			Msg("Update user role validation failed")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Validation failed",
+				Details:   validationErrors,
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	user, err := h.useCase.UpdateUserRole(c.Context(), actorID(c), id, req.Role)
+	if err != nil {
+		return respondUserError(c, requestID, clientIP, id, "Failed to update user role", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("user_id", id).
+		Int64("role", user.Role).
+		Str("updated_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("User role updated, all sessions revoked")
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.UserResponse]{
+		Status: common.StatusSuccess,
+		Data:   &user,
+	})
+}
+
+func (h *UserHandler) HandleUpdateUserStudyProgram(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	var req usecases.UpdateUserStudyProgramRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("user_id", id).
+			Str("path", c.OriginalURL()).
+			Str("method", c.Method()).
+			Msg("Failed to parse update user study program request body")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Cannot parse request body",
+				Details:   []string{err.Error()},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	if validationErrors := common.ValidateStruct(req); validationErrors != nil {
+		log.Warn().
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("user_id", id).
+			Str("study_program_id", req.StudyProgramID).
+			Strs("validation_errors", validationErrors).
+			Str("path", c.OriginalURL()).
+			Msg("Update user study program validation failed")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Validation failed",
+				Details:   validationErrors,
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	user, err := h.useCase.UpdateUserStudyProgram(c.Context(), id, req.StudyProgramID)
+	if err != nil {
+		return respondUserError(c, requestID, clientIP, id, "Failed to update user study program", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("user_id", id).
+		Str("study_program_id", req.StudyProgramID).
+		Str("updated_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("User study program updated")
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.UserResponse]{
+		Status: common.StatusSuccess,
+		Data:   &user,
+	})
+}
+
+func (h *UserHandler) HandleDisableUser(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	user, err := h.useCase.DisableUser(c.Context(), actorID(c), id)
+	if err != nil {
+		return respondUserError(c, requestID, clientIP, id, "Failed to disable user", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("user_id", id).
+		Str("disabled_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("User disabled, all sessions revoked")
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.UserResponse]{
+		Status: common.StatusSuccess,
+		Data:   &user,
+	})
+}
+
+func (h *UserHandler) HandleEnableUser(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	user, err := h.useCase.EnableUser(c.Context(), id)
+	if err != nil {
+		return respondUserError(c, requestID, clientIP, id, "Failed to enable user", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("user_id", id).
+		Str("enabled_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("User enabled")
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.UserResponse]{
+		Status: common.StatusSuccess,
+		Data:   &user,
+	})
+}
+
+func (h *UserHandler) HandleDeleteUser(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	err := h.useCase.DeleteUser(c.Context(), actorID(c), id)
+	if err != nil {
+		return respondUserError(c, requestID, clientIP, id, "Failed to delete user", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("user_id", id).
+		Str("deleted_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("User soft-deleted, all sessions revoked")
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *UserHandler) HandleRestoreUser(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	user, err := h.useCase.RestoreUser(c.Context(), id)
+	if err != nil {
+		return respondUserError(c, requestID, clientIP, id, "Failed to restore user", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("user_id", id).
+		Str("restored_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("User restored")
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.UserResponse]{
+		Status: common.StatusSuccess,
+		Data:   &user,
+	})
+}
+
+func (h *UserHandler) HandleUnlockUser(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	err := h.useCase.UnlockUser(c.Context(), id)
+	if err != nil {
+		return respondUserError(c, requestID, clientIP, id, "Failed to unlock user", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("user_id", id).
+		Str("unlocked_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("User login lockout lifted")
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *UserHandler) HandleUnlockIP(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	ip := c.Params("ip")
+
+	err := h.useCase.UnlockIP(c.Context(), ip)
+	if err != nil {
+		return respondUserError(c, requestID, clientIP, "", "Failed to unlock ip", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("unlocked_ip", ip).
+		Str("unlocked_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("IP login lockout lifted")
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// respondUserError maps user administration errors to HTTP status codes, unknown errors become 500.
+func respondUserError(c *fiber.Ctx, requestID, clientIP, userID, message string, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, usecases.ErrUserNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, usecases.ErrEmailAlreadyUsed), errors.Is(err, usecases.ErrUserNotDeleted):
+		status = fiber.StatusConflict
+	case errors.Is(err, usecases.ErrInvalidIPAddress):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, usecases.ErrCannotModifySelf), errors.Is(err, usecases.ErrUnknownRole),
+		errors.Is(err, usecases.ErrUnknownStudyProgram):
+		status = fiber.StatusUnprocessableEntity
+	}
+
+	if status == fiber.StatusInternalServerError {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("user_id", userID).
+			Str("path", c.OriginalURL()).
+			Msg(message)
+	} else {
+		log.Warn().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("user_id", userID).
+			Str("path", c.OriginalURL()).
+			Msg(message)
+	}
+
+	return c.Status(status).JSON(common.BaseResponse[any]{
+		Status: common.StatusError,
+		Error: &common.BaseResponseError{
+			Message:   message,
+			Details:   []string{err.Error()},
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Path:      c.OriginalURL(),
+		},
+	})
+}
+
+func actorID(c *fiber.Ctx) string {
+	id, _ := c.Locals(middlewares.UserIDKey).(string)
+	return id
+}
+
+func actorRole(c *fiber.Ctx) int64 {
+	role, _ := c.Locals(middlewares.UserRoleKey).(int64)
+	return role
+}
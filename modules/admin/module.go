@@ -0,0 +1,164 @@
+package admin
+
+import (
+	"siakad-poc/common"
+	"siakad-poc/common/jwtkeys"
+	"siakad-poc/constants"
+	"siakad-poc/db/repositories"
+	"siakad-poc/middlewares"
+	"siakad-poc/modules"
+	"siakad-poc/modules/admin/handlers"
+	"siakad-poc/modules/admin/usecases"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AdminModule struct {
+	userRepository                repositories.UserRepository
+	tokenRevocationRepository     repositories.TokenRevocationRepository
+	permissionRepository          repositories.PermissionRepository
+	keyring                       *jwtkeys.Keyring
+	studentRepository             repositories.StudentRepository
+	lecturerRepository            repositories.LecturerRepository
+	userUseCase                   *usecases.UserUseCase
+	studentImportUseCase          *usecases.StudentImportUseCase
+	courseCompletionImportUseCase *usecases.CourseCompletionImportUseCase
+	semesterGPAImportUseCase      *usecases.SemesterGPAImportUseCase
+	roleUseCase                   *usecases.RoleUseCase
+	studentUseCase                *usecases.StudentUseCase
+	lecturerUseCase               *usecases.LecturerUseCase
+	creditAllowanceUseCase        *usecases.CreditAllowanceUseCase
+	userHandler                   *handlers.UserHandler
+	studentImportHandler          *handlers.StudentImportHandler
+	courseCompletionImportHandler *handlers.CourseCompletionImportHandler
+	semesterGPAImportHandler      *handlers.SemesterGPAImportHandler
+	roleHandler                   *handlers.RoleHandler
+	studentHandler                *handlers.StudentHandler
+	lecturerHandler               *handlers.LecturerHandler
+	creditAllowanceHandler        *handlers.CreditAllowanceHandler
+}
+
+// Compile time interface conformance check
+var _ modules.RoutableModule = (*AdminModule)(nil)
+
+func NewModule(pool *pgxpool.Pool, tokenRevocationRepository repositories.TokenRevocationRepository, permissionRepository repositories.PermissionRepository, keyring *jwtkeys.Keyring) *AdminModule {
+	txExecutor := common.NewPgxTransactionExecutor(pool)
+	userRepository := repositories.NewDefaultUserRepository(pool)
+	studentRepository := repositories.NewDefaultStudentRepository(pool)
+	lecturerRepository := repositories.NewDefaultLecturerRepository(pool)
+	loginThrottleRepository := repositories.NewDefaultLoginThrottleRepository(pool)
+	courseCompletionRepository := repositories.NewDefaultCourseCompletionRepository(pool)
+	creditLoadRepository := repositories.NewDefaultCreditLoadRepository(pool)
+
+	userUseCase := usecases.NewUserUseCase(userRepository, tokenRevocationRepository, loginThrottleRepository, permissionRepository, studentRepository)
+	studentImportUseCase := usecases.NewStudentImportUseCase(userRepository, studentRepository, txExecutor)
+	courseCompletionImportUseCase := usecases.NewCourseCompletionImportUseCase(courseCompletionRepository, txExecutor)
+	semesterGPAImportUseCase := usecases.NewSemesterGPAImportUseCase(creditLoadRepository, txExecutor)
+	roleUseCase := usecases.NewRoleUseCase(permissionRepository, txExecutor)
+	studentUseCase := usecases.NewStudentUseCase(studentRepository, lecturerRepository, userRepository)
+	lecturerUseCase := usecases.NewLecturerUseCase(lecturerRepository, studentRepository, userRepository)
+	creditAllowanceUseCase := usecases.NewCreditAllowanceUseCase(creditLoadRepository, studentRepository)
+
+	userHandler := handlers.NewUserHandler(userUseCase)
+	studentImportHandler := handlers.NewStudentImportHandler(studentImportUseCase)
+	courseCompletionImportHandler := handlers.NewCourseCompletionImportHandler(courseCompletionImportUseCase)
+	semesterGPAImportHandler := handlers.NewSemesterGPAImportHandler(semesterGPAImportUseCase)
+	roleHandler := handlers.NewRoleHandler(roleUseCase)
+	studentHandler := handlers.NewStudentHandler(studentUseCase)
+	lecturerHandler := handlers.NewLecturerHandler(lecturerUseCase)
+	creditAllowanceHandler := handlers.NewCreditAllowanceHandler(creditAllowanceUseCase)
+
+	return &AdminModule{
+		userRepository:                userRepository,
+		tokenRevocationRepository:     tokenRevocationRepository,
+		permissionRepository:          permissionRepository,
+		keyring:                       keyring,
+		studentRepository:             studentRepository,
+		lecturerRepository:            lecturerRepository,
+		userUseCase:                   userUseCase,
+		studentImportUseCase:          studentImportUseCase,
+		courseCompletionImportUseCase: courseCompletionImportUseCase,
+		semesterGPAImportUseCase:      semesterGPAImportUseCase,
+		roleUseCase:                   roleUseCase,
+		studentUseCase:                studentUseCase,
+		lecturerUseCase:               lecturerUseCase,
+		creditAllowanceUseCase:        creditAllowanceUseCase,
+		userHandler:                   userHandler,
+		studentImportHandler:          studentImportHandler,
+		courseCompletionImportHandler: courseCompletionImportHandler,
+		semesterGPAImportHandler:      semesterGPAImportHandler,
+		roleHandler:                   roleHandler,
+		studentHandler:                studentHandler,
+		lecturerHandler:               lecturerHandler,
+		creditAllowanceHandler:        creditAllowanceHandler,
+	}
+}
+
+func (m *AdminModule) SetupRoutes(fiberApp *fiber.App, prefix string) {
+	// Every admin route requires a token, each route then checks its own permission
+	adminGroup := fiberApp.Group(prefix)
+	adminGroup.Use(middlewares.JWT(m.keyring, m.tokenRevocationRepository))
+	manageUsers := middlewares.RequirePermission(m.permissionRepository, constants.PermissionUserManage)
+
+	// User administration routes
+	adminGroup.Get("/users", manageUsers, m.userHandler.HandleListUsers)
+	adminGroup.Post("/users", manageUsers, m.userHandler.HandleCreateUser)
+	adminGroup.Get("/users/:id", manageUsers, m.userHandler.HandleGetUser)
+	adminGroup.Put("/users/:id/role", manageUsers, m.userHandler.HandleUpdateUserRole)
+	adminGroup.Put("/users/:id/study-program", manageUsers, m.userHandler.HandleUpdateUserStudyProgram)
+	adminGroup.Post("/users/:id/disable", manageUsers, m.userHandler.HandleDisableUser)
+	adminGroup.Post("/users/:id/enable", manageUsers, m.userHandler.HandleEnableUser)
+	adminGroup.Delete("/users/:id", manageUsers, m.userHandler.HandleDeleteUser)
+	adminGroup.Post("/users/:id/restore", manageUsers, m.userHandler.HandleRestoreUser)
+
+	// Login lockout administration
+	adminGroup.Post("/users/:id/unlock", manageUsers, m.userHandler.HandleUnlockUser)
+	adminGroup.Post("/ips/:ip/unlock", manageUsers, m.userHandler.HandleUnlockIP)
+
+	// Student account provisioning
+	adminGroup.Post(
+		"/students/import",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionStudentImport),
+		m.studentImportHandler.HandleImportStudents,
+	)
+
+	// Passed courses (nilai) from the legacy system, used to check course prerequisites on enrollment
+	adminGroup.Post(
+		"/course-completions/import",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionCourseCompletionImport),
+		m.courseCompletionImportHandler.HandleImportCourseCompletions,
+	)
+
+	// Semester GPAs (IP) from the legacy system, the previous one decides the credit load on enrollment
+	adminGroup.Post(
+		"/semester-gpas/import",
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionSemesterGPAImport),
+		m.semesterGPAImportHandler.HandleImportSemesterGPAs,
+	)
+
+	// Student and lecturer records (mahasiswa/dosen) linked to user accounts
+	manageStudents := middlewares.RequirePermission(m.permissionRepository, constants.PermissionStudentManage)
+	adminGroup.Get("/students", manageStudents, m.studentHandler.HandleListStudents)
+	adminGroup.Post("/students", manageStudents, m.studentHandler.HandleCreateStudent)
+	adminGroup.Get("/students/:id", manageStudents, m.studentHandler.HandleGetStudent)
+	adminGroup.Put("/students/:id", manageStudents, m.studentHandler.HandleUpdateStudent)
+	adminGroup.Delete("/students/:id", manageStudents, m.studentHandler.HandleDeleteStudent)
+	adminGroup.Get("/students/:id/credit-allowance", manageStudents, m.creditAllowanceHandler.HandleGetCreditAllowance)
+	adminGroup.Put("/students/:id/credit-allowance", manageStudents, m.creditAllowanceHandler.HandleSetCreditAllowance)
+	adminGroup.Delete("/students/:id/credit-allowance", manageStudents, m.creditAllowanceHandler.HandleDeleteCreditAllowance)
+
+	manageLecturers := middlewares.RequirePermission(m.permissionRepository, constants.PermissionLecturerManage)
+	adminGroup.Get("/lecturers", manageLecturers, m.lecturerHandler.HandleListLecturers)
+	adminGroup.Post("/lecturers", manageLecturers, m.lecturerHandler.HandleCreateLecturer)
+	adminGroup.Get("/lecturers/:id", manageLecturers, m.lecturerHandler.HandleGetLecturer)
+	adminGroup.Put("/lecturers/:id", manageLecturers, m.lecturerHandler.HandleUpdateLecturer)
+	adminGroup.Delete("/lecturers/:id", manageLecturers, m.lecturerHandler.HandleDeleteLecturer)
+
+	// Roles and their permissions
+	manageRoles := middlewares.RequirePermission(m.permissionRepository, constants.PermissionRoleManage)
+	adminGroup.Get("/permissions", manageRoles, m.roleHandler.HandleListPermissions)
+	adminGroup.Get("/roles", manageRoles, m.roleHandler.HandleListRoles)
+	adminGroup.Post("/roles", manageRoles, m.roleHandler.HandleCreateRole)
+	adminGroup.Put("/roles/:id/permissions", manageRoles, m.roleHandler.HandleUpdateRolePermissions)
+}
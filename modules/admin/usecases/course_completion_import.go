@@ -0,0 +1,268 @@
+package usecases
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"siakad-poc/common"
+	"siakad-poc/db/repositories"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// Columns of the course completion import CSV, the header row is required and matched case-insensitively
+const (
+	courseCompletionImportColumnNIM        = "nim"
+	courseCompletionImportColumnCourseCode = "course_code"
+	courseCompletionImportColumnGrade      = "grade"
+)
+
+type CourseCompletionImportRowError struct {
+	Row        int      `json:"row"` // line number in the CSV file, the header is line 1
+	NIM        string   `json:"nim,omitempty"`
+	CourseCode string   `json:"course_code,omitempty"`
+	Errors     []string `json:"errors"`
+}
+
+type CourseCompletionImportReport struct {
+	TotalRows    int                              `json:"total_rows"`
+	ImportedRows int                              `json:"imported_rows"`
+	Errors       []CourseCompletionImportRowError `json:"errors"`
+}
+
+type courseCompletionImportRow struct {
+	NIM        string `validate:"required,max=255"`
+	CourseCode string `validate:"required,max=255"`
+	Grade      string `validate:"required,max=2"`
+
+	line int
+}
+
+type CourseCompletionImportUseCase struct {
+	courseCompletionRepository repositories.CourseCompletionRepository
+	txExecutor                 common.TransactionExecutor
+}
+
+func NewCourseCompletionImportUseCase(
+	courseCompletionRepository repositories.CourseCompletionRepository,
+	txExecutor common.TransactionExecutor,
+) *CourseCompletionImportUseCase {
+	return &CourseCompletionImportUseCase{
+		courseCompletionRepository: courseCompletionRepository,
+		txExecutor:                 txExecutor,
+	}
+}
+
+// Import records the passed courses of students (nilai mata kuliah) exported from the legacy system, they are
+// used to check course prerequisites on enrollment. Like the student import it is all or nothing: when any row
+// is invalid, nothing is written and the report lists every rejected row together with ErrImportRejected.
+func (uc *CourseCompletionImportUseCase) Import(ctx context.Context, r io.Reader) (CourseCompletionImportReport, error) {
+	rows, err := parseCourseCompletionImportCSV(r)
+	if err != nil {
+		return CourseCompletionImportReport{}, err
+	}
+
+	report := CourseCompletionImportReport{
+		TotalRows: len(rows),
+		Errors:    []CourseCompletionImportRowError{},
+	}
+	if len(rows) == 0 {
+		return report, ErrEmptyImport
+	}
+
+	rowErrors, studentIDs, courseIDs, err := uc.validateRows(ctx, rows)
+	if err != nil {
+		return report, err
+	}
+
+	if len(rowErrors) > 0 {
+		for i, messages := range rowErrors {
+			report.Errors = append(report.Errors, CourseCompletionImportRowError{
+				Row:        rows[i].line,
+				NIM:        rows[i].NIM,
+				CourseCode: rows[i].CourseCode,
+				Errors:     messages,
+			})
+		}
+		sort.Slice(report.Errors, func(i, j int) bool {
+			return report.Errors[i].Row < report.Errors[j].Row
+		})
+		return report, ErrImportRejected
+	}
+
+	completions := make([]repositories.NewCourseCompletion, 0, len(rows))
+	for _, row := range rows {
+		completions = append(completions, repositories.NewCourseCompletion{
+			ID:        uuid.NewString(),
+			StudentID: studentIDs[row.NIM],
+			CourseID:  courseIDs[row.CourseCode],
+			Grade:     row.Grade,
+		})
+	}
+
+	err = uc.txExecutor.WithTxContext(ctx, func(txCtx *common.TxContext) error {
+		imported, err := uc.courseCompletionRepository.CreateCourseCompletionsTx(txCtx, completions)
+		if err != nil {
+			return errors.Wrap(err, "cannot insert course completions")
+		}
+
+		report.ImportedRows = int(imported)
+		return nil
+	})
+	if err != nil {
+		report.ImportedRows = 0
+		return report, err
+	}
+
+	return report, nil
+}
+
+// validateRows checks every row on its own, against the other rows of the file and against the database.
+// It returns the error messages per row index, the student IDs keyed by NIM and the course IDs keyed by code.
+func (uc *CourseCompletionImportUseCase) validateRows(ctx context.Context, rows []courseCompletionImportRow) (map[int][]string, map[string]string, map[string]string, error) {
+	rowErrors := make(map[int][]string)
+	addError := func(i int, message string) {
+		rowErrors[i] = append(rowErrors[i], message)
+	}
+
+	// A student has at most one completion per course
+	completionRows := make(map[string]int)
+	completionKey := func(studentID, courseID string) string {
+		return studentID + "/" + courseID
+	}
+
+	var nims, courseCodes []string
+	for i, row := range rows {
+		for _, message := range common.ValidateStruct(row) {
+			addError(i, message)
+		}
+		if row.Grade != "" && !common.IsValidGrade(row.Grade) {
+			addError(i, fmt.Sprintf("grade must be one of %s", strings.Join(common.Grades, " ")))
+		}
+
+		if row.NIM != "" && row.CourseCode != "" {
+			key := completionKey(row.NIM, row.CourseCode)
+			if first, ok := completionRows[key]; ok {
+				addError(i, fmt.Sprintf("course is duplicated for the student, first used in row %d", rows[first].line))
+			} else {
+				completionRows[key] = i
+			}
+		}
+		if row.NIM != "" {
+			nims = append(nims, row.NIM)
+		}
+		if row.CourseCode != "" {
+			courseCodes = append(courseCodes, row.CourseCode)
+		}
+	}
+
+	students, err := uc.courseCompletionRepository.GetStudentsByNIMs(ctx, nims)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "cannot get students")
+	}
+	studentIDs := make(map[string]string, len(students))
+	studentNIMs := make(map[string]string, len(students))
+	for _, student := range students {
+		studentIDs[student.Nim] = student.ID.String()
+		studentNIMs[student.ID.String()] = student.Nim
+	}
+
+	courses, err := uc.courseCompletionRepository.GetCoursesByCodes(ctx, courseCodes)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "cannot get courses")
+	}
+	courseIDs := make(map[string]string, len(courses))
+	courseCodesByID := make(map[string]string, len(courses))
+	for _, course := range courses {
+		courseIDs[course.Code] = course.ID.String()
+		courseCodesByID[course.ID.String()] = course.Code
+	}
+
+	for i, row := range rows {
+		if _, ok := studentIDs[row.NIM]; row.NIM != "" && !ok {
+			addError(i, fmt.Sprintf("student with nim %q does not exist", row.NIM))
+		}
+		if _, ok := courseIDs[row.CourseCode]; row.CourseCode != "" && !ok {
+			addError(i, fmt.Sprintf("course %q does not exist", row.CourseCode))
+		}
+	}
+
+	existingCompletions, err := uc.courseCompletionRepository.GetCourseCompletionsByStudents(ctx, mapValues(studentIDs))
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "cannot check existing course completions")
+	}
+	for _, completion := range existingCompletions {
+		key := completionKey(studentNIMs[completion.StudentID.String()], courseCodesByID[completion.CourseID.String()])
+		if i, ok := completionRows[key]; ok {
+			addError(i, fmt.Sprintf("course is already recorded for the student with grade %s", completion.Grade))
+		}
+	}
+
+	return rowErrors, studentIDs, courseIDs, nil
+}
+
+func parseCourseCompletionImportCSV(r io.Reader) ([]courseCompletionImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // short rows are reported per row instead of failing the whole file
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.Wrap(ErrInvalidImportFile, "missing header row")
+		}
+		return nil, errors.Wrap(ErrInvalidImportFile, err.Error())
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+		columns[column] = i
+	}
+	for _, required := range []string{courseCompletionImportColumnNIM, courseCompletionImportColumnCourseCode, courseCompletionImportColumnGrade} {
+		if _, ok := columns[required]; !ok {
+			return nil, errors.Wrapf(ErrInvalidImportFile, "missing %q column", required)
+		}
+	}
+
+	field := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var rows []courseCompletionImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidImportFile, err.Error())
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, courseCompletionImportRow{
+			NIM:        field(record, courseCompletionImportColumnNIM),
+			CourseCode: field(record, courseCompletionImportColumnCourseCode),
+			Grade:      strings.ToUpper(field(record, courseCompletionImportColumnGrade)),
+			line:       line,
+		})
+	}
+
+	return rows, nil
+}
+
+func mapValues(m map[string]string) []string {
+	values := make([]string, 0, len(m))
+	for _, value := range m {
+		values = append(values, value)
+	}
+	return values
+}
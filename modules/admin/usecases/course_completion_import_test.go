@@ -0,0 +1,176 @@
+package usecases
+
+import (
+	"context"
+	"siakad-poc/common"
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// Mock repository for course completion import tests
+type MockCourseCompletionRepository struct {
+	mock.Mock
+}
+
+func (m *MockCourseCompletionRepository) GetStudentsByNIMs(ctx context.Context, nims []string) ([]generated.GetStudentsByNimsRow, error) {
+	args := m.Called(ctx, nims)
+	return args.Get(0).([]generated.GetStudentsByNimsRow), args.Error(1)
+}
+
+func (m *MockCourseCompletionRepository) GetCoursesByCodes(ctx context.Context, codes []string) ([]generated.Course, error) {
+	args := m.Called(ctx, codes)
+	return args.Get(0).([]generated.Course), args.Error(1)
+}
+
+func (m *MockCourseCompletionRepository) GetCourseCompletionsByStudents(ctx context.Context, studentIDs []string) ([]generated.CourseCompletion, error) {
+	args := m.Called(ctx, studentIDs)
+	return args.Get(0).([]generated.CourseCompletion), args.Error(1)
+}
+
+func (m *MockCourseCompletionRepository) CreateCourseCompletionsTx(txCtx *common.TxContext, completions []repositories.NewCourseCompletion) (int64, error) {
+	args := m.Called(txCtx, completions)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// Test Suite
+type CourseCompletionImportTestSuite struct {
+	suite.Suite
+	useCase     *CourseCompletionImportUseCase
+	mockRepo    *MockCourseCompletionRepository
+	ctx         context.Context
+	studentUUID pgtype.UUID
+	courseUUID  pgtype.UUID
+}
+
+func (suite *CourseCompletionImportTestSuite) SetupTest() {
+	suite.mockRepo = new(MockCourseCompletionRepository)
+	suite.useCase = NewCourseCompletionImportUseCase(suite.mockRepo, new(common.MockTransactionExecutor))
+	suite.ctx = context.Background()
+
+	suite.studentUUID = pgtype.UUID{Bytes: [16]byte{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, Valid: true}
+	suite.courseUUID = pgtype.UUID{Bytes: [16]byte{2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2}, Valid: true}
+}
+
+func (suite *CourseCompletionImportTestSuite) TearDownTest() {
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// Test header matching is case-insensitive and grades are upper-cased
+func (suite *CourseCompletionImportTestSuite) TestParseCourseCompletionImportCSV_Success() {
+	csv := "\ufeffNIM,Course_Code,Grade\n" +
+		" 2025001 ,IF101,ab\n" +
+		"2025002,IF101,C\n"
+
+	rows, err := parseCourseCompletionImportCSV(strings.NewReader(csv))
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), rows, 2)
+	assert.Equal(suite.T(), "2025001", rows[0].NIM)
+	assert.Equal(suite.T(), "IF101", rows[0].CourseCode)
+	assert.Equal(suite.T(), "AB", rows[0].Grade)
+	assert.Equal(suite.T(), 2, rows[0].line)
+	assert.Equal(suite.T(), 3, rows[1].line)
+}
+
+// Test a missing required column rejects the whole file
+func (suite *CourseCompletionImportTestSuite) TestParseCourseCompletionImportCSV_MissingColumn() {
+	csv := "nim,course_code\n" +
+		"2025001,IF101\n"
+
+	rows, err := parseCourseCompletionImportCSV(strings.NewReader(csv))
+
+	assert.Nil(suite.T(), rows)
+	assert.True(suite.T(), errors.Is(err, ErrInvalidImportFile))
+	assert.Contains(suite.T(), err.Error(), `"grade"`)
+}
+
+// Test every invalid row is reported and nothing is written
+func (suite *CourseCompletionImportTestSuite) TestImport_Rejected() {
+	csv := "nim,course_code,grade\n" +
+		"2025001,IF101,A\n" +
+		"2025001,IF101,B\n" +
+		"2025001,IF102,F\n" +
+		"2025099,IF101,A\n"
+
+	suite.mockRepo.On("GetStudentsByNIMs", suite.ctx, mock.Anything).Return([]generated.GetStudentsByNimsRow{
+		{ID: suite.studentUUID, Nim: "2025001"},
+	}, nil)
+	suite.mockRepo.On("GetCoursesByCodes", suite.ctx, mock.Anything).Return([]generated.Course{
+		{ID: suite.courseUUID, Code: "IF101"},
+	}, nil)
+	suite.mockRepo.On("GetCourseCompletionsByStudents", suite.ctx, []string{suite.studentUUID.String()}).Return([]generated.CourseCompletion{}, nil)
+
+	report, err := suite.useCase.Import(suite.ctx, strings.NewReader(csv))
+
+	assert.ErrorIs(suite.T(), err, ErrImportRejected)
+	assert.Equal(suite.T(), 4, report.TotalRows)
+	assert.Equal(suite.T(), 0, report.ImportedRows)
+	assert.Len(suite.T(), report.Errors, 3)
+	assert.Equal(suite.T(), 3, report.Errors[0].Row)
+	assert.Contains(suite.T(), report.Errors[0].Errors[0], "first used in row 2")
+	assert.Equal(suite.T(), 4, report.Errors[1].Row)
+	assert.Len(suite.T(), report.Errors[1].Errors, 2) // invalid grade and unknown course
+	assert.Equal(suite.T(), 5, report.Errors[2].Row)
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateCourseCompletionsTx")
+}
+
+// Test a course already recorded for the student is rejected
+func (suite *CourseCompletionImportTestSuite) TestImport_AlreadyRecorded() {
+	csv := "nim,course_code,grade\n" +
+		"2025001,IF101,A\n"
+
+	suite.mockRepo.On("GetStudentsByNIMs", suite.ctx, []string{"2025001"}).Return([]generated.GetStudentsByNimsRow{
+		{ID: suite.studentUUID, Nim: "2025001"},
+	}, nil)
+	suite.mockRepo.On("GetCoursesByCodes", suite.ctx, []string{"IF101"}).Return([]generated.Course{
+		{ID: suite.courseUUID, Code: "IF101"},
+	}, nil)
+	suite.mockRepo.On("GetCourseCompletionsByStudents", suite.ctx, []string{suite.studentUUID.String()}).Return([]generated.CourseCompletion{
+		{StudentID: suite.studentUUID, CourseID: suite.courseUUID, Grade: "B"},
+	}, nil)
+
+	report, err := suite.useCase.Import(suite.ctx, strings.NewReader(csv))
+
+	assert.ErrorIs(suite.T(), err, ErrImportRejected)
+	assert.Len(suite.T(), report.Errors, 1)
+	assert.Contains(suite.T(), report.Errors[0].Errors[0], "already recorded")
+}
+
+// Test a valid file is written in one go
+func (suite *CourseCompletionImportTestSuite) TestImport_Success() {
+	csv := "nim,course_code,grade\n" +
+		"2025001,IF101,BC\n"
+
+	suite.mockRepo.On("GetStudentsByNIMs", suite.ctx, []string{"2025001"}).Return([]generated.GetStudentsByNimsRow{
+		{ID: suite.studentUUID, Nim: "2025001"},
+	}, nil)
+	suite.mockRepo.On("GetCoursesByCodes", suite.ctx, []string{"IF101"}).Return([]generated.Course{
+		{ID: suite.courseUUID, Code: "IF101"},
+	}, nil)
+	suite.mockRepo.On("GetCourseCompletionsByStudents", suite.ctx, []string{suite.studentUUID.String()}).Return([]generated.CourseCompletion{}, nil)
+	suite.mockRepo.On("CreateCourseCompletionsTx", mock.AnythingOfType("*common.TxContext"), mock.MatchedBy(func(completions []repositories.NewCourseCompletion) bool {
+		return len(completions) == 1 &&
+			completions[0].StudentID == suite.studentUUID.String() &&
+			completions[0].CourseID == suite.courseUUID.String() &&
+			completions[0].Grade == "BC"
+	})).Return(int64(1), nil)
+
+	report, err := suite.useCase.Import(suite.ctx, strings.NewReader(csv))
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, report.ImportedRows)
+	assert.Empty(suite.T(), report.Errors)
+}
+
+// Run the test suite
+func TestCourseCompletionImportTestSuite(t *testing.T) {
+	suite.Run(t, new(CourseCompletionImportTestSuite))
+}
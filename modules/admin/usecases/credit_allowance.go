@@ -0,0 +1,117 @@
+package usecases
+
+import (
+	"context"
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+// CreditAllowanceResponse is the most credits a student can take per semester as set by an admin
+type CreditAllowanceResponse struct {
+	StudentID  string     `json:"student_id"`
+	MaxCredits int32      `json:"max_credits"`
+	SetBy      string     `json:"set_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  *time.Time `json:"updated_at"`
+}
+
+type SetCreditAllowanceRequest struct {
+	MaxCredits int32 `json:"max_credits" validate:"required,min=1,max=40"`
+}
+
+type CreditAllowanceUseCase struct {
+	creditLoadRepository repositories.CreditLoadRepository
+	studentRepository    repositories.StudentRepository
+}
+
+func NewCreditAllowanceUseCase(creditLoadRepository repositories.CreditLoadRepository, studentRepository repositories.StudentRepository) *CreditAllowanceUseCase {
+	return &CreditAllowanceUseCase{
+		creditLoadRepository: creditLoadRepository,
+		studentRepository:    studentRepository,
+	}
+}
+
+func (uc *CreditAllowanceUseCase) GetCreditAllowance(ctx context.Context, studentID string) (CreditAllowanceResponse, error) {
+	err := uc.checkStudent(ctx, studentID)
+	if err != nil {
+		return CreditAllowanceResponse{}, err
+	}
+
+	allowance, err := uc.creditLoadRepository.GetStudentCreditAllowance(ctx, studentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return CreditAllowanceResponse{}, ErrCreditAllowanceNotFound
+		}
+		return CreditAllowanceResponse{}, errors.Wrap(err, "cannot get credit allowance")
+	}
+
+	return toCreditAllowanceResponse(allowance), nil
+}
+
+// SetCreditAllowance sets the most credits the student can take per semester, replacing the allowance derived
+// from the previous semester GPA until it is deleted.
+func (uc *CreditAllowanceUseCase) SetCreditAllowance(ctx context.Context, actorID, studentID string, req SetCreditAllowanceRequest) (CreditAllowanceResponse, error) {
+	err := uc.checkStudent(ctx, studentID)
+	if err != nil {
+		return CreditAllowanceResponse{}, err
+	}
+
+	allowance, err := uc.creditLoadRepository.SetStudentCreditAllowance(ctx, studentID, req.MaxCredits, actorID)
+	if err != nil {
+		return CreditAllowanceResponse{}, errors.Wrap(err, "cannot set credit allowance")
+	}
+
+	return toCreditAllowanceResponse(allowance), nil
+}
+
+// DeleteCreditAllowance returns the student to the allowance derived from the previous semester GPA.
+func (uc *CreditAllowanceUseCase) DeleteCreditAllowance(ctx context.Context, studentID string) error {
+	err := uc.checkStudent(ctx, studentID)
+	if err != nil {
+		return err
+	}
+
+	_, err = uc.creditLoadRepository.DeleteStudentCreditAllowance(ctx, studentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrCreditAllowanceNotFound
+		}
+		return errors.Wrap(err, "cannot delete credit allowance")
+	}
+
+	return nil
+}
+
+// checkStudent returns ErrStudentNotFound unless the student exists and is not deleted.
+func (uc *CreditAllowanceUseCase) checkStudent(ctx context.Context, studentID string) error {
+	_, err := uc.studentRepository.GetStudent(ctx, studentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrStudentNotFound
+		}
+		return errors.Wrap(err, "cannot get student")
+	}
+	return nil
+}
+
+func toCreditAllowanceResponse(allowance generated.StudentCreditAllowance) CreditAllowanceResponse {
+	response := CreditAllowanceResponse{
+		StudentID:  allowance.StudentID.String(),
+		MaxCredits: allowance.MaxCredits,
+		SetBy:      allowance.SetBy.String(),
+	}
+
+	if allowance.CreatedAt.Valid {
+		response.CreatedAt = allowance.CreatedAt.Time
+	}
+	if allowance.UpdatedAt.Valid {
+		updatedAt := allowance.UpdatedAt.Time
+		response.UpdatedAt = &updatedAt
+	}
+
+	return response
+}
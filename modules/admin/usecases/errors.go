@@ -0,0 +1,42 @@
+package usecases
+
+import "github.com/pkg/errors"
+
+var (
+	ErrCannotModifySelf    = errors.New("admins can't change the role or status of their own account")
+	ErrEmailAlreadyUsed    = errors.New("email is already used by another account")
+	ErrInvalidIPAddress    = errors.New("invalid ip address")
+	ErrUnknownRole         = errors.New("role does not exist")
+	ErrUnknownStudyProgram = errors.New("study program does not exist")
+	ErrUserNotDeleted      = errors.New("user is not deleted")
+	ErrUserNotFound        = errors.New("user not found")
+)
+
+var (
+	ErrCannotRevokeOwnRoleManage = errors.New("role:manage can't be revoked from your own role")
+	ErrRoleAlreadyExists         = errors.New("role id or name is already used")
+	ErrRoleNotFound              = errors.New("role not found")
+	ErrUnknownPermission         = errors.New("unknown permission")
+)
+
+var (
+	ErrEmptyImport       = errors.New("import file has no data rows")
+	ErrImportRejected    = errors.New("import rejected, no rows were imported")
+	ErrInvalidImportFile = errors.New("invalid import file")
+)
+
+var (
+	ErrCreditAllowanceNotFound = errors.New("student has no credit allowance set by an admin")
+)
+
+var (
+	ErrLecturerHasAdvisees    = errors.New("lecturer is still the academic advisor of active students")
+	ErrLecturerNotFound       = errors.New("lecturer not found")
+	ErrNIDNAlreadyUsed        = errors.New("nidn is already used by another lecturer")
+	ErrNIMAlreadyUsed         = errors.New("nim is already used by another student")
+	ErrStudentNotFound        = errors.New("student not found")
+	ErrUnknownAcademicAdvisor = errors.New("academic advisor does not exist")
+	ErrUnknownUser            = errors.New("user does not exist")
+	ErrUserAlreadyLecturer    = errors.New("user already has a lecturer record")
+	ErrUserAlreadyStudent     = errors.New("user already has a student record")
+)
@@ -0,0 +1,242 @@
+package usecases
+
+import (
+	"context"
+	"math"
+	"siakad-poc/common"
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+type LecturerResponse struct {
+	ID                     string     `json:"id"`
+	UserID                 string     `json:"user_id"`
+	NIDN                   string     `json:"nidn"`
+	NUPTK                  *string    `json:"nuptk"`
+	HomebaseStudyProgramID string     `json:"homebase_study_program_id"`
+	CreatedAt              time.Time  `json:"created_at"`
+	UpdatedAt              *time.Time `json:"updated_at"`
+}
+
+type CreateLecturerRequest struct {
+	UserID                 string `json:"user_id" validate:"required,uuid"`
+	NIDN                   string `json:"nidn" validate:"required,max=255"`
+	NUPTK                  string `json:"nuptk" validate:"max=255"`
+	HomebaseStudyProgramID string `json:"homebase_study_program_id" validate:"required,uuid"`
+}
+
+type UpdateLecturerRequest struct {
+	NIDN                   string `json:"nidn" validate:"required,max=255"`
+	NUPTK                  string `json:"nuptk" validate:"max=255"`
+	HomebaseStudyProgramID string `json:"homebase_study_program_id" validate:"required,uuid"`
+}
+
+type LecturerUseCase struct {
+	lecturerRepository repositories.LecturerRepository
+	studentRepository  repositories.StudentRepository
+	userRepository     repositories.UserRepository
+}
+
+func NewLecturerUseCase(lecturerRepository repositories.LecturerRepository, studentRepository repositories.StudentRepository, userRepository repositories.UserRepository) *LecturerUseCase {
+	return &LecturerUseCase{
+		lecturerRepository: lecturerRepository,
+		studentRepository:  studentRepository,
+		userRepository:     userRepository,
+	}
+}
+
+func (uc *LecturerUseCase) ListLecturers(ctx context.Context, filter repositories.LecturerFilter, page, pageSize int) ([]LecturerResponse, *common.PaginationMetadata, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+
+	lecturers, err := uc.lecturerRepository.ListLecturers(ctx, filter, pageSize, offset)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot get lecturers")
+	}
+
+	totalRecords, err := uc.lecturerRepository.CountLecturers(ctx, filter)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot count lecturers")
+	}
+
+	responses := make([]LecturerResponse, 0, len(lecturers))
+	for _, lecturer := range lecturers {
+		responses = append(responses, toLecturerResponse(lecturer))
+	}
+
+	pagination := &common.PaginationMetadata{
+		Page:         page,
+		PageSize:     pageSize,
+		TotalRecords: int(totalRecords),
+		TotalPages:   int(math.Ceil(float64(totalRecords) / float64(pageSize))),
+	}
+
+	return responses, pagination, nil
+}
+
+func (uc *LecturerUseCase) GetLecturer(ctx context.Context, id string) (LecturerResponse, error) {
+	lecturer, err := uc.lecturerRepository.GetLecturer(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return LecturerResponse{}, ErrLecturerNotFound
+		}
+		return LecturerResponse{}, errors.Wrap(err, "cannot get lecturer")
+	}
+
+	return toLecturerResponse(lecturer), nil
+}
+
+// CreateLecturer links an existing user account to a new lecturer record. A user has at most one
+// lecturer record and NIDNs are unique, soft-deleted records included.
+func (uc *LecturerUseCase) CreateLecturer(ctx context.Context, req CreateLecturerRequest) (LecturerResponse, error) {
+	user, err := uc.userRepository.GetUser(ctx, req.UserID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return LecturerResponse{}, ErrUnknownUser
+		}
+		return LecturerResponse{}, errors.Wrap(err, "cannot get user")
+	}
+	if user.DeletedAt.Valid {
+		return LecturerResponse{}, ErrUnknownUser
+	}
+
+	_, err = uc.lecturerRepository.GetLecturerByUserID(ctx, req.UserID)
+	if err == nil {
+		return LecturerResponse{}, ErrUserAlreadyLecturer
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return LecturerResponse{}, errors.Wrap(err, "cannot get lecturer of user")
+	}
+
+	attributes := repositories.LecturerAttributes{
+		HomebaseStudyProgramID: req.HomebaseStudyProgramID,
+		NIDN:                   req.NIDN,
+		NUPTK:                  req.NUPTK,
+	}
+	err = uc.validateAttributes(ctx, "", attributes)
+	if err != nil {
+		return LecturerResponse{}, err
+	}
+
+	lecturer, err := uc.lecturerRepository.CreateLecturer(ctx, uuid.NewString(), req.UserID, attributes)
+	if err != nil {
+		return LecturerResponse{}, errors.Wrap(err, "cannot create lecturer")
+	}
+
+	return toLecturerResponse(lecturer), nil
+}
+
+func (uc *LecturerUseCase) UpdateLecturer(ctx context.Context, id string, req UpdateLecturerRequest) (LecturerResponse, error) {
+	_, err := uc.lecturerRepository.GetLecturer(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return LecturerResponse{}, ErrLecturerNotFound
+		}
+		return LecturerResponse{}, errors.Wrap(err, "cannot get lecturer")
+	}
+
+	attributes := repositories.LecturerAttributes{
+		HomebaseStudyProgramID: req.HomebaseStudyProgramID,
+		NIDN:                   req.NIDN,
+		NUPTK:                  req.NUPTK,
+	}
+	err = uc.validateAttributes(ctx, id, attributes)
+	if err != nil {
+		return LecturerResponse{}, err
+	}
+
+	lecturer, err := uc.lecturerRepository.UpdateLecturer(ctx, id, attributes)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return LecturerResponse{}, ErrLecturerNotFound
+		}
+		return LecturerResponse{}, errors.Wrap(err, "cannot update lecturer")
+	}
+
+	return toLecturerResponse(lecturer), nil
+}
+
+// DeleteLecturer soft-deletes the lecturer record. Lecturers still advising active students are kept,
+// their students have to be assigned another academic advisor first.
+func (uc *LecturerUseCase) DeleteLecturer(ctx context.Context, id string) error {
+	_, err := uc.lecturerRepository.GetLecturer(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrLecturerNotFound
+		}
+		return errors.Wrap(err, "cannot get lecturer")
+	}
+
+	advisees, err := uc.studentRepository.CountStudentsByAcademicAdvisor(ctx, id)
+	if err != nil {
+		return errors.Wrap(err, "cannot count advised students")
+	}
+	if advisees > 0 {
+		return errors.Wrapf(ErrLecturerHasAdvisees, "%d students", advisees)
+	}
+
+	_, err = uc.lecturerRepository.DeleteLecturer(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrLecturerNotFound
+		}
+		return errors.Wrap(err, "cannot delete lecturer")
+	}
+
+	return nil
+}
+
+// validateAttributes checks the homebase and the NIDN uniqueness, id is the lecturer being updated, if any.
+func (uc *LecturerUseCase) validateAttributes(ctx context.Context, id string, attributes repositories.LecturerAttributes) error {
+	existing, err := uc.lecturerRepository.GetLecturerByNIDN(ctx, attributes.NIDN)
+	if err == nil && existing.ID.String() != id {
+		return ErrNIDNAlreadyUsed
+	}
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return errors.Wrap(err, "cannot check nidn availability")
+	}
+
+	_, err = uc.studentRepository.GetStudyProgram(ctx, attributes.HomebaseStudyProgramID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrUnknownStudyProgram
+		}
+		return errors.Wrap(err, "cannot get study program")
+	}
+
+	return nil
+}
+
+func toLecturerResponse(lecturer generated.Lecturer) LecturerResponse {
+	response := LecturerResponse{
+		ID:                     lecturer.ID.String(),
+		UserID:                 lecturer.UserID.String(),
+		NIDN:                   lecturer.Nidn,
+		HomebaseStudyProgramID: lecturer.HomebaseStudyProgramID.String(),
+	}
+
+	if lecturer.Nuptk.Valid {
+		nuptk := lecturer.Nuptk.String
+		response.NUPTK = &nuptk
+	}
+	if lecturer.CreatedAt.Valid {
+		response.CreatedAt = lecturer.CreatedAt.Time
+	}
+	if lecturer.UpdatedAt.Valid {
+		updatedAt := lecturer.UpdatedAt.Time
+		response.UpdatedAt = &updatedAt
+	}
+
+	return response
+}
@@ -0,0 +1,255 @@
+package usecases
+
+import (
+	"context"
+	"siakad-poc/common"
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// Mock lecturer repository for the admin use cases
+type MockLecturerRepository struct {
+	mock.Mock
+}
+
+func (m *MockLecturerRepository) GetLecturerProfileByUserID(ctx context.Context, userID string) (generated.GetLecturerProfileByUserIDRow, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(generated.GetLecturerProfileByUserIDRow), args.Error(1)
+}
+
+func (m *MockLecturerRepository) ListLecturers(ctx context.Context, filter repositories.LecturerFilter, limit, offset int) ([]generated.Lecturer, error) {
+	args := m.Called(ctx, filter, limit, offset)
+	return args.Get(0).([]generated.Lecturer), args.Error(1)
+}
+
+func (m *MockLecturerRepository) CountLecturers(ctx context.Context, filter repositories.LecturerFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockLecturerRepository) GetLecturer(ctx context.Context, id string) (generated.Lecturer, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.Lecturer), args.Error(1)
+}
+
+func (m *MockLecturerRepository) LockLecturersTx(txCtx *common.TxContext, ids []string) error {
+	args := m.Called(txCtx, ids)
+	return args.Error(0)
+}
+
+func (m *MockLecturerRepository) GetLecturerByNIDN(ctx context.Context, nidn string) (generated.Lecturer, error) {
+	args := m.Called(ctx, nidn)
+	return args.Get(0).(generated.Lecturer), args.Error(1)
+}
+
+func (m *MockLecturerRepository) GetLecturerByUserID(ctx context.Context, userID string) (generated.Lecturer, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(generated.Lecturer), args.Error(1)
+}
+
+func (m *MockLecturerRepository) CreateLecturer(ctx context.Context, id, userID string, attributes repositories.LecturerAttributes) (generated.Lecturer, error) {
+	args := m.Called(ctx, id, userID, attributes)
+	return args.Get(0).(generated.Lecturer), args.Error(1)
+}
+
+func (m *MockLecturerRepository) UpdateLecturer(ctx context.Context, id string, attributes repositories.LecturerAttributes) (generated.Lecturer, error) {
+	args := m.Called(ctx, id, attributes)
+	return args.Get(0).(generated.Lecturer), args.Error(1)
+}
+
+func (m *MockLecturerRepository) DeleteLecturer(ctx context.Context, id string) (generated.Lecturer, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.Lecturer), args.Error(1)
+}
+
+const (
+	lecturerID       = "550e8400-e29b-41d4-a716-446655440071"
+	otherLecturerID  = "550e8400-e29b-41d4-a716-446655440072"
+	lecturerUserID   = "550e8400-e29b-41d4-a716-446655440073"
+	homebaseID       = "550e8400-e29b-41d4-a716-446655440074"
+	lecturerNIDN     = "0012345678"
+	lecturerNewNIDN  = "0087654321"
+	lecturerNUPTK    = "1234567890123456"
+	lecturerAdvisees = 3
+)
+
+// Test Suite
+type LecturerUseCaseTestSuite struct {
+	suite.Suite
+	useCase             *LecturerUseCase
+	mockLecturerRepo    *MockLecturerRepository
+	mockStudentRepo     *MockStudentRepository
+	mockUserRepo        *MockUserRepository
+	ctx                 context.Context
+	createRequest       CreateLecturerRequest
+	requestedAttributes repositories.LecturerAttributes
+}
+
+func (suite *LecturerUseCaseTestSuite) SetupTest() {
+	suite.mockLecturerRepo = new(MockLecturerRepository)
+	suite.mockStudentRepo = new(MockStudentRepository)
+	suite.mockUserRepo = new(MockUserRepository)
+	suite.useCase = NewLecturerUseCase(suite.mockLecturerRepo, suite.mockStudentRepo, suite.mockUserRepo)
+	suite.ctx = context.Background()
+	suite.createRequest = CreateLecturerRequest{
+		UserID:                 lecturerUserID,
+		NIDN:                   lecturerNIDN,
+		NUPTK:                  lecturerNUPTK,
+		HomebaseStudyProgramID: homebaseID,
+	}
+	suite.requestedAttributes = repositories.LecturerAttributes{
+		HomebaseStudyProgramID: homebaseID,
+		NIDN:                   lecturerNIDN,
+		NUPTK:                  lecturerNUPTK,
+	}
+}
+
+func (suite *LecturerUseCaseTestSuite) TearDownTest() {
+	suite.mockLecturerRepo.AssertExpectations(suite.T())
+	suite.mockStudentRepo.AssertExpectations(suite.T())
+	suite.mockUserRepo.AssertExpectations(suite.T())
+}
+
+// lecturer returns a lecturer record holding the NIDN, soft-deleted when deletedAt is set
+func lecturer(id, nidn string, deletedAt *time.Time) generated.Lecturer {
+	record := generated.Lecturer{
+		ID:                     testUUID(id),
+		UserID:                 testUUID(lecturerUserID),
+		Nidn:                   nidn,
+		HomebaseStudyProgramID: testUUID(homebaseID),
+	}
+	if deletedAt != nil {
+		record.DeletedAt = pgtype.Timestamptz{Time: *deletedAt, Valid: true}
+	}
+	return record
+}
+
+// expectUserWithoutLecturer sets up an active user that has no lecturer record yet
+func (suite *LecturerUseCaseTestSuite) expectUserWithoutLecturer() {
+	suite.mockUserRepo.On("GetUser", suite.ctx, lecturerUserID).Return(generated.User{ID: testUUID(lecturerUserID)}, nil)
+	suite.mockLecturerRepo.On("GetLecturerByUserID", suite.ctx, lecturerUserID).Return(generated.Lecturer{}, pgx.ErrNoRows)
+}
+
+// Test a lecturer record is created for a user with a free NIDN
+func (suite *LecturerUseCaseTestSuite) TestCreateLecturer_Success() {
+	suite.expectUserWithoutLecturer()
+	suite.mockLecturerRepo.On("GetLecturerByNIDN", suite.ctx, lecturerNIDN).Return(generated.Lecturer{}, pgx.ErrNoRows)
+	suite.mockStudentRepo.On("GetStudyProgram", suite.ctx, homebaseID).Return(generated.StudyProgram{}, nil)
+	suite.mockLecturerRepo.On("CreateLecturer", suite.ctx, mock.AnythingOfType("string"), lecturerUserID, suite.requestedAttributes).
+		Return(lecturer(lecturerID, lecturerNIDN, nil), nil)
+
+	response, err := suite.useCase.CreateLecturer(suite.ctx, suite.createRequest)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), lecturerID, response.ID)
+	assert.Equal(suite.T(), lecturerNIDN, response.NIDN)
+}
+
+// Test an NIDN can't be reused, not even the NIDN of a soft-deleted lecturer
+func (suite *LecturerUseCaseTestSuite) TestCreateLecturer_NIDNAlreadyUsed() {
+	deletedAt := time.Now().Add(-24 * time.Hour)
+	testCases := []struct {
+		name     string
+		existing generated.Lecturer
+	}{
+		{"active lecturer", lecturer(otherLecturerID, lecturerNIDN, nil)},
+		{"soft-deleted lecturer", lecturer(otherLecturerID, lecturerNIDN, &deletedAt)},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+			defer suite.TearDownTest()
+
+			suite.expectUserWithoutLecturer()
+			suite.mockLecturerRepo.On("GetLecturerByNIDN", suite.ctx, lecturerNIDN).Return(tc.existing, nil)
+
+			_, err := suite.useCase.CreateLecturer(suite.ctx, suite.createRequest)
+
+			assert.True(suite.T(), errors.Is(err, ErrNIDNAlreadyUsed))
+			suite.mockLecturerRepo.AssertNotCalled(suite.T(), "CreateLecturer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// Test a user can only be linked to one lecturer record, a soft-deleted one included
+func (suite *LecturerUseCaseTestSuite) TestCreateLecturer_UserAlreadyLinked() {
+	deletedAt := time.Now().Add(-24 * time.Hour)
+	suite.mockUserRepo.On("GetUser", suite.ctx, lecturerUserID).Return(generated.User{ID: testUUID(lecturerUserID)}, nil)
+	suite.mockLecturerRepo.On("GetLecturerByUserID", suite.ctx, lecturerUserID).Return(lecturer(otherLecturerID, lecturerNIDN, &deletedAt), nil)
+
+	_, err := suite.useCase.CreateLecturer(suite.ctx, suite.createRequest)
+
+	assert.True(suite.T(), errors.Is(err, ErrUserAlreadyLecturer))
+	suite.mockLecturerRepo.AssertNotCalled(suite.T(), "GetLecturerByNIDN", mock.Anything, mock.Anything)
+}
+
+// Test a soft-deleted user can't be linked to a lecturer record
+func (suite *LecturerUseCaseTestSuite) TestCreateLecturer_DeletedUser() {
+	deletedUser := generated.User{
+		ID:        testUUID(lecturerUserID),
+		DeletedAt: pgtype.Timestamptz{Time: time.Now().Add(-time.Hour), Valid: true},
+	}
+	suite.mockUserRepo.On("GetUser", suite.ctx, lecturerUserID).Return(deletedUser, nil)
+
+	_, err := suite.useCase.CreateLecturer(suite.ctx, suite.createRequest)
+
+	assert.True(suite.T(), errors.Is(err, ErrUnknownUser))
+	suite.mockLecturerRepo.AssertNotCalled(suite.T(), "GetLecturerByUserID", mock.Anything, mock.Anything)
+}
+
+// Test a lecturer keeps their own NIDN on update
+func (suite *LecturerUseCaseTestSuite) TestUpdateLecturer_KeepsOwnNIDN() {
+	suite.mockLecturerRepo.On("GetLecturer", suite.ctx, lecturerID).Return(lecturer(lecturerID, lecturerNIDN, nil), nil)
+	suite.mockLecturerRepo.On("GetLecturerByNIDN", suite.ctx, lecturerNIDN).Return(lecturer(lecturerID, lecturerNIDN, nil), nil)
+	suite.mockStudentRepo.On("GetStudyProgram", suite.ctx, homebaseID).Return(generated.StudyProgram{}, nil)
+	suite.mockLecturerRepo.On("UpdateLecturer", suite.ctx, lecturerID, suite.requestedAttributes).Return(lecturer(lecturerID, lecturerNIDN, nil), nil)
+
+	_, err := suite.useCase.UpdateLecturer(suite.ctx, lecturerID, UpdateLecturerRequest{
+		NIDN:                   lecturerNIDN,
+		NUPTK:                  lecturerNUPTK,
+		HomebaseStudyProgramID: homebaseID,
+	})
+
+	assert.NoError(suite.T(), err)
+}
+
+// Test a lecturer can't take over the NIDN of a soft-deleted lecturer on update
+func (suite *LecturerUseCaseTestSuite) TestUpdateLecturer_NIDNOfDeletedLecturer() {
+	deletedAt := time.Now().Add(-24 * time.Hour)
+	suite.mockLecturerRepo.On("GetLecturer", suite.ctx, lecturerID).Return(lecturer(lecturerID, lecturerNIDN, nil), nil)
+	suite.mockLecturerRepo.On("GetLecturerByNIDN", suite.ctx, lecturerNewNIDN).Return(lecturer(otherLecturerID, lecturerNewNIDN, &deletedAt), nil)
+
+	_, err := suite.useCase.UpdateLecturer(suite.ctx, lecturerID, UpdateLecturerRequest{
+		NIDN:                   lecturerNewNIDN,
+		HomebaseStudyProgramID: homebaseID,
+	})
+
+	assert.True(suite.T(), errors.Is(err, ErrNIDNAlreadyUsed))
+	suite.mockLecturerRepo.AssertNotCalled(suite.T(), "UpdateLecturer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Test a lecturer still advising active students is kept
+func (suite *LecturerUseCaseTestSuite) TestDeleteLecturer_HasAdvisees() {
+	suite.mockLecturerRepo.On("GetLecturer", suite.ctx, lecturerID).Return(lecturer(lecturerID, lecturerNIDN, nil), nil)
+	suite.mockStudentRepo.On("CountStudentsByAcademicAdvisor", suite.ctx, lecturerID).Return(int64(lecturerAdvisees), nil)
+
+	err := suite.useCase.DeleteLecturer(suite.ctx, lecturerID)
+
+	assert.True(suite.T(), errors.Is(err, ErrLecturerHasAdvisees))
+	suite.mockLecturerRepo.AssertNotCalled(suite.T(), "DeleteLecturer", mock.Anything, mock.Anything)
+}
+
+// Run the test suite
+func TestLecturerUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(LecturerUseCaseTestSuite))
+}
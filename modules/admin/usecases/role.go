@@ -0,0 +1,171 @@
+package usecases
+
+import (
+	"context"
+	"siakad-poc/common"
+	"siakad-poc/constants"
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+type PermissionResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type RoleResponse struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type CreateRoleRequest struct {
+	ID   int64  `json:"id" validate:"required,min=1,max=99"`
+	Name string `json:"name" validate:"required,max=255"`
+}
+
+type UpdateRolePermissionsRequest struct {
+	Permissions []string `json:"permissions" validate:"required,dive,required"`
+}
+
+type RoleUseCase struct {
+	permissionRepository repositories.PermissionRepository
+	txExecutor           common.TransactionExecutor
+}
+
+func NewRoleUseCase(permissionRepository repositories.PermissionRepository, txExecutor common.TransactionExecutor) *RoleUseCase {
+	return &RoleUseCase{
+		permissionRepository: permissionRepository,
+		txExecutor:           txExecutor,
+	}
+}
+
+func (uc *RoleUseCase) ListPermissions(ctx context.Context) ([]PermissionResponse, error) {
+	permissions, err := uc.permissionRepository.ListPermissions(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get permissions")
+	}
+
+	responses := make([]PermissionResponse, 0, len(permissions))
+	for _, permission := range permissions {
+		responses = append(responses, PermissionResponse{
+			Name:        permission.Name,
+			Description: permission.Description,
+		})
+	}
+
+	return responses, nil
+}
+
+// ListRoles returns every role together with the permissions granted to it.
+func (uc *RoleUseCase) ListRoles(ctx context.Context) ([]RoleResponse, error) {
+	roles, err := uc.permissionRepository.ListRoles(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get roles")
+	}
+
+	rolePermissions, err := uc.permissionRepository.ListRolePermissions(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get role permissions")
+	}
+
+	permissionsByRole := make(map[int64][]string)
+	for _, rolePermission := range rolePermissions {
+		roleID := rolePermission.RoleID.Int.Int64()
+		permissionsByRole[roleID] = append(permissionsByRole[roleID], rolePermission.Permission)
+	}
+
+	responses := make([]RoleResponse, 0, len(roles))
+	for _, role := range roles {
+		responses = append(responses, toRoleResponse(role, permissionsByRole[role.ID.Int.Int64()]))
+	}
+
+	return responses, nil
+}
+
+// CreateRole adds a role without any permission, they are granted with UpdateRolePermissions.
+func (uc *RoleUseCase) CreateRole(ctx context.Context, req CreateRoleRequest) (RoleResponse, error) {
+	roles, err := uc.permissionRepository.ListRoles(ctx)
+	if err != nil {
+		return RoleResponse{}, errors.Wrap(err, "cannot get roles")
+	}
+	for _, role := range roles {
+		if role.ID.Int.Int64() == req.ID || strings.EqualFold(role.Name, req.Name) {
+			return RoleResponse{}, ErrRoleAlreadyExists
+		}
+	}
+
+	role, err := uc.permissionRepository.CreateRole(ctx, req.ID, req.Name)
+	if err != nil {
+		return RoleResponse{}, errors.Wrap(err, "cannot create role")
+	}
+
+	return toRoleResponse(role, nil), nil
+}
+
+// UpdateRolePermissions replaces the permissions of the role with the given set. The change applies to
+// existing sessions as well, since permissions are resolved per request instead of being embedded in tokens.
+// The actor can't revoke role:manage from their own role, nobody could give it back through the API.
+func (uc *RoleUseCase) UpdateRolePermissions(ctx context.Context, actorRole, id int64, req UpdateRolePermissionsRequest) (RoleResponse, error) {
+	role, err := uc.permissionRepository.GetRole(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return RoleResponse{}, ErrRoleNotFound
+		}
+		return RoleResponse{}, errors.Wrap(err, "cannot get role")
+	}
+
+	knownPermissions, err := uc.permissionRepository.ListPermissions(ctx)
+	if err != nil {
+		return RoleResponse{}, errors.Wrap(err, "cannot get permissions")
+	}
+
+	permissions := slices.Clone(req.Permissions)
+	slices.Sort(permissions)
+	permissions = slices.Compact(permissions)
+	if id == actorRole && !slices.Contains(permissions, constants.PermissionRoleManage) {
+		return RoleResponse{}, ErrCannotRevokeOwnRoleManage
+	}
+	for _, permission := range permissions {
+		known := slices.ContainsFunc(knownPermissions, func(p generated.Permission) bool {
+			return p.Name == permission
+		})
+		if !known {
+			return RoleResponse{}, errors.Wrapf(ErrUnknownPermission, "%q", permission)
+		}
+	}
+
+	err = uc.txExecutor.WithTxContext(ctx, func(txCtx *common.TxContext) error {
+		return uc.permissionRepository.ReplaceRolePermissionsTx(txCtx, id, permissions)
+	})
+	if err != nil {
+		return RoleResponse{}, errors.Wrap(err, "cannot update role permissions")
+	}
+	uc.permissionRepository.InvalidatePermissionCache()
+
+	return toRoleResponse(role, permissions), nil
+}
+
+func toRoleResponse(role generated.Role, permissions []string) RoleResponse {
+	if permissions == nil {
+		permissions = []string{}
+	}
+
+	response := RoleResponse{
+		ID:          role.ID.Int.Int64(),
+		Name:        role.Name,
+		Permissions: permissions,
+	}
+	if role.CreatedAt.Valid {
+		response.CreatedAt = role.CreatedAt.Time
+	}
+
+	return response
+}
@@ -0,0 +1,286 @@
+package usecases
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"siakad-poc/common"
+	"siakad-poc/db/repositories"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// Columns of the semester GPA import CSV, the header row is required and matched case-insensitively
+const (
+	semesterGPAImportColumnNIM              = "nim"
+	semesterGPAImportColumnAcademicYearCode = "academic_year_code"
+	semesterGPAImportColumnSemesterCode     = "semester_code"
+	semesterGPAImportColumnGPA              = "gpa"
+)
+
+// gpaPattern matches a GPA between 0 and 4 with at most two decimals, the range is checked separately
+var gpaPattern = regexp.MustCompile(`^[0-4](\.[0-9]{1,2})?$`)
+
+type SemesterGPAImportRowError struct {
+	Row              int      `json:"row"` // line number in the CSV file, the header is line 1
+	NIM              string   `json:"nim,omitempty"`
+	AcademicYearCode string   `json:"academic_year_code,omitempty"`
+	SemesterCode     string   `json:"semester_code,omitempty"`
+	Errors           []string `json:"errors"`
+}
+
+type SemesterGPAImportReport struct {
+	TotalRows    int                         `json:"total_rows"`
+	ImportedRows int                         `json:"imported_rows"`
+	Errors       []SemesterGPAImportRowError `json:"errors"`
+}
+
+type semesterGPAImportRow struct {
+	NIM              string `validate:"required,max=255"`
+	AcademicYearCode string `validate:"required,max=255"`
+	SemesterCode     string `validate:"required,max=255"`
+	GPA              string `validate:"required"`
+
+	line int
+}
+
+type SemesterGPAImportUseCase struct {
+	creditLoadRepository repositories.CreditLoadRepository
+	txExecutor           common.TransactionExecutor
+}
+
+func NewSemesterGPAImportUseCase(
+	creditLoadRepository repositories.CreditLoadRepository,
+	txExecutor common.TransactionExecutor,
+) *SemesterGPAImportUseCase {
+	return &SemesterGPAImportUseCase{
+		creditLoadRepository: creditLoadRepository,
+		txExecutor:           txExecutor,
+	}
+}
+
+// Import records the semester GPAs (IP semester) of students exported from the legacy system, the GPA of the
+// previous semester decides how many credits a student can take on enrollment. Like the other imports it is all
+// or nothing: when any row is invalid, nothing is written and the report lists every rejected row together with
+// ErrImportRejected.
+func (uc *SemesterGPAImportUseCase) Import(ctx context.Context, r io.Reader) (SemesterGPAImportReport, error) {
+	rows, err := parseSemesterGPAImportCSV(r)
+	if err != nil {
+		return SemesterGPAImportReport{}, err
+	}
+
+	report := SemesterGPAImportReport{
+		TotalRows: len(rows),
+		Errors:    []SemesterGPAImportRowError{},
+	}
+	if len(rows) == 0 {
+		return report, ErrEmptyImport
+	}
+
+	rowErrors, studentIDs, semesterIDs, err := uc.validateRows(ctx, rows)
+	if err != nil {
+		return report, err
+	}
+
+	if len(rowErrors) > 0 {
+		for i, messages := range rowErrors {
+			report.Errors = append(report.Errors, SemesterGPAImportRowError{
+				Row:              rows[i].line,
+				NIM:              rows[i].NIM,
+				AcademicYearCode: rows[i].AcademicYearCode,
+				SemesterCode:     rows[i].SemesterCode,
+				Errors:           messages,
+			})
+		}
+		sort.Slice(report.Errors, func(i, j int) bool {
+			return report.Errors[i].Row < report.Errors[j].Row
+		})
+		return report, ErrImportRejected
+	}
+
+	gpas := make([]repositories.NewSemesterGPA, 0, len(rows))
+	for _, row := range rows {
+		gpas = append(gpas, repositories.NewSemesterGPA{
+			ID:         uuid.NewString(),
+			StudentID:  studentIDs[row.NIM],
+			SemesterID: semesterIDs[semesterKey(row.AcademicYearCode, row.SemesterCode)],
+			GPA:        row.GPA,
+		})
+	}
+
+	err = uc.txExecutor.WithTxContext(ctx, func(txCtx *common.TxContext) error {
+		imported, err := uc.creditLoadRepository.CreateSemesterGPAsTx(txCtx, gpas)
+		if err != nil {
+			return errors.Wrap(err, "cannot insert semester gpas")
+		}
+
+		report.ImportedRows = int(imported)
+		return nil
+	})
+	if err != nil {
+		report.ImportedRows = 0
+		return report, err
+	}
+
+	return report, nil
+}
+
+// validateRows checks every row on its own, against the other rows of the file and against the database.
+// It returns the error messages per row index, the student IDs keyed by NIM and the semester IDs keyed by
+// semesterKey.
+func (uc *SemesterGPAImportUseCase) validateRows(ctx context.Context, rows []semesterGPAImportRow) (map[int][]string, map[string]string, map[string]string, error) {
+	rowErrors := make(map[int][]string)
+	addError := func(i int, message string) {
+		rowErrors[i] = append(rowErrors[i], message)
+	}
+
+	// A student has at most one GPA per semester
+	gpaRows := make(map[string]int)
+	gpaKey := func(nim, semester string) string {
+		return nim + "/" + semester
+	}
+
+	var nims, academicYearCodes []string
+	for i, row := range rows {
+		for _, message := range common.ValidateStruct(row) {
+			addError(i, message)
+		}
+		if row.GPA != "" && !isValidGPA(row.GPA) {
+			addError(i, "gpa must be a number between 0.00 and 4.00 with at most two decimals")
+		}
+
+		if row.NIM != "" && row.AcademicYearCode != "" && row.SemesterCode != "" {
+			key := gpaKey(row.NIM, semesterKey(row.AcademicYearCode, row.SemesterCode))
+			if first, ok := gpaRows[key]; ok {
+				addError(i, fmt.Sprintf("semester is duplicated for the student, first used in row %d", rows[first].line))
+			} else {
+				gpaRows[key] = i
+			}
+		}
+		if row.NIM != "" {
+			nims = append(nims, row.NIM)
+		}
+		if row.AcademicYearCode != "" {
+			academicYearCodes = append(academicYearCodes, row.AcademicYearCode)
+		}
+	}
+
+	students, err := uc.creditLoadRepository.GetStudentsByNIMs(ctx, nims)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "cannot get students")
+	}
+	studentIDs := make(map[string]string, len(students))
+	studentNIMs := make(map[string]string, len(students))
+	for _, student := range students {
+		studentIDs[student.Nim] = student.ID.String()
+		studentNIMs[student.ID.String()] = student.Nim
+	}
+
+	semesters, err := uc.creditLoadRepository.GetSemestersByAcademicYearCodes(ctx, academicYearCodes)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "cannot get semesters")
+	}
+	semesterIDs := make(map[string]string, len(semesters))
+	semesterKeys := make(map[string]string, len(semesters))
+	for _, semester := range semesters {
+		key := semesterKey(semester.AcademicYearCode, semester.Code)
+		semesterIDs[key] = semester.ID.String()
+		semesterKeys[semester.ID.String()] = key
+	}
+
+	for i, row := range rows {
+		if _, ok := studentIDs[row.NIM]; row.NIM != "" && !ok {
+			addError(i, fmt.Sprintf("student with nim %q does not exist", row.NIM))
+		}
+		if row.AcademicYearCode != "" && row.SemesterCode != "" {
+			if _, ok := semesterIDs[semesterKey(row.AcademicYearCode, row.SemesterCode)]; !ok {
+				addError(i, fmt.Sprintf("semester %q of academic year %q does not exist", row.SemesterCode, row.AcademicYearCode))
+			}
+		}
+	}
+
+	existingGPAs, err := uc.creditLoadRepository.GetSemesterGPAsByStudents(ctx, mapValues(studentIDs))
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "cannot check existing semester gpas")
+	}
+	for _, existing := range existingGPAs {
+		key := gpaKey(studentNIMs[existing.StudentID.String()], semesterKeys[existing.SemesterID.String()])
+		if i, ok := gpaRows[key]; ok {
+			gpa, _ := existing.Gpa.Float64Value()
+			addError(i, fmt.Sprintf("gpa is already recorded for the student in the semester as %.2f", gpa.Float64))
+		}
+	}
+
+	return rowErrors, studentIDs, semesterIDs, nil
+}
+
+func parseSemesterGPAImportCSV(r io.Reader) ([]semesterGPAImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // short rows are reported per row instead of failing the whole file
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.Wrap(ErrInvalidImportFile, "missing header row")
+		}
+		return nil, errors.Wrap(ErrInvalidImportFile, err.Error())
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+		columns[column] = i
+	}
+	for _, required := range []string{semesterGPAImportColumnNIM, semesterGPAImportColumnAcademicYearCode, semesterGPAImportColumnSemesterCode, semesterGPAImportColumnGPA} {
+		if _, ok := columns[required]; !ok {
+			return nil, errors.Wrapf(ErrInvalidImportFile, "missing %q column", required)
+		}
+	}
+
+	field := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var rows []semesterGPAImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidImportFile, err.Error())
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, semesterGPAImportRow{
+			NIM:              field(record, semesterGPAImportColumnNIM),
+			AcademicYearCode: field(record, semesterGPAImportColumnAcademicYearCode),
+			SemesterCode:     field(record, semesterGPAImportColumnSemesterCode),
+			// The legacy system exports decimal commas, e.g. "3,25"
+			GPA:  strings.Replace(field(record, semesterGPAImportColumnGPA), ",", ".", 1),
+			line: line,
+		})
+	}
+
+	return rows, nil
+}
+
+// isValidGPA checks the GPA is between 0.00 and 4.00 with at most two decimals.
+func isValidGPA(gpa string) bool {
+	return gpaPattern.MatchString(gpa) && (gpa[0] != '4' || strings.Trim(gpa[1:], ".0") == "")
+}
+
+// semesterKey identifies a semester by its code, which is only unique within the academic year.
+func semesterKey(academicYearCode, semesterCode string) string {
+	return academicYearCode + "/" + semesterCode
+}
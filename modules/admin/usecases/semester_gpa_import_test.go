@@ -0,0 +1,204 @@
+package usecases
+
+import (
+	"context"
+	"siakad-poc/common"
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// Mock repository for semester GPA import tests
+type MockCreditLoadRepository struct {
+	mock.Mock
+}
+
+func (m *MockCreditLoadRepository) GetStudentsByNIMs(ctx context.Context, nims []string) ([]generated.GetStudentsByNimsRow, error) {
+	args := m.Called(ctx, nims)
+	return args.Get(0).([]generated.GetStudentsByNimsRow), args.Error(1)
+}
+
+func (m *MockCreditLoadRepository) GetSemestersByAcademicYearCodes(ctx context.Context, academicYearCodes []string) ([]generated.GetSemestersByAcademicYearCodesRow, error) {
+	args := m.Called(ctx, academicYearCodes)
+	return args.Get(0).([]generated.GetSemestersByAcademicYearCodesRow), args.Error(1)
+}
+
+func (m *MockCreditLoadRepository) GetSemesterGPAsByStudents(ctx context.Context, studentIDs []string) ([]generated.SemesterGpa, error) {
+	args := m.Called(ctx, studentIDs)
+	return args.Get(0).([]generated.SemesterGpa), args.Error(1)
+}
+
+func (m *MockCreditLoadRepository) GetStudentCreditAllowance(ctx context.Context, studentID string) (generated.StudentCreditAllowance, error) {
+	args := m.Called(ctx, studentID)
+	return args.Get(0).(generated.StudentCreditAllowance), args.Error(1)
+}
+
+func (m *MockCreditLoadRepository) SetStudentCreditAllowance(ctx context.Context, studentID string, maxCredits int32, setBy string) (generated.StudentCreditAllowance, error) {
+	args := m.Called(ctx, studentID, maxCredits, setBy)
+	return args.Get(0).(generated.StudentCreditAllowance), args.Error(1)
+}
+
+func (m *MockCreditLoadRepository) DeleteStudentCreditAllowance(ctx context.Context, studentID string) (generated.StudentCreditAllowance, error) {
+	args := m.Called(ctx, studentID)
+	return args.Get(0).(generated.StudentCreditAllowance), args.Error(1)
+}
+
+func (m *MockCreditLoadRepository) CreateSemesterGPAsTx(txCtx *common.TxContext, gpas []repositories.NewSemesterGPA) (int64, error) {
+	args := m.Called(txCtx, gpas)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCreditLoadRepository) GetStudentCreditAllowanceTx(txCtx *common.TxContext, studentID string) (generated.StudentCreditAllowance, error) {
+	args := m.Called(txCtx, studentID)
+	return args.Get(0).(generated.StudentCreditAllowance), args.Error(1)
+}
+
+func (m *MockCreditLoadRepository) GetPreviousSemesterGPATx(txCtx *common.TxContext, studentID, semesterID string) (generated.GetPreviousSemesterGPARow, error) {
+	args := m.Called(txCtx, studentID, semesterID)
+	return args.Get(0).(generated.GetPreviousSemesterGPARow), args.Error(1)
+}
+
+// Test Suite
+type SemesterGPAImportTestSuite struct {
+	suite.Suite
+	useCase      *SemesterGPAImportUseCase
+	mockRepo     *MockCreditLoadRepository
+	ctx          context.Context
+	studentUUID  pgtype.UUID
+	semesterUUID pgtype.UUID
+}
+
+func (suite *SemesterGPAImportTestSuite) SetupTest() {
+	suite.mockRepo = new(MockCreditLoadRepository)
+	suite.useCase = NewSemesterGPAImportUseCase(suite.mockRepo, new(common.MockTransactionExecutor))
+	suite.ctx = context.Background()
+
+	suite.studentUUID = pgtype.UUID{Bytes: [16]byte{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, Valid: true}
+	suite.semesterUUID = pgtype.UUID{Bytes: [16]byte{3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3}, Valid: true}
+}
+
+func (suite *SemesterGPAImportTestSuite) TearDownTest() {
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// expectLookups expects the student 2025001 and the semester GANJIL of 2024/2025
+func (suite *SemesterGPAImportTestSuite) expectLookups(existing []generated.SemesterGpa) {
+	suite.mockRepo.On("GetStudentsByNIMs", suite.ctx, mock.Anything).Return([]generated.GetStudentsByNimsRow{
+		{ID: suite.studentUUID, Nim: "2025001"},
+	}, nil)
+	suite.mockRepo.On("GetSemestersByAcademicYearCodes", suite.ctx, mock.Anything).Return([]generated.GetSemestersByAcademicYearCodesRow{
+		{ID: suite.semesterUUID, AcademicYearCode: "2024/2025", Code: "GANJIL"},
+	}, nil)
+	suite.mockRepo.On("GetSemesterGPAsByStudents", suite.ctx, []string{suite.studentUUID.String()}).Return(existing, nil)
+}
+
+// Test header matching is case-insensitive and decimal commas are accepted
+func (suite *SemesterGPAImportTestSuite) TestParseSemesterGPAImportCSV_Success() {
+	csv := "\ufeffNIM,Academic_Year_Code,Semester_Code,GPA\n" +
+		" 2025001 ,2024/2025,GANJIL,\"3,25\"\n"
+
+	rows, err := parseSemesterGPAImportCSV(strings.NewReader(csv))
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), rows, 1)
+	assert.Equal(suite.T(), "2025001", rows[0].NIM)
+	assert.Equal(suite.T(), "2024/2025", rows[0].AcademicYearCode)
+	assert.Equal(suite.T(), "GANJIL", rows[0].SemesterCode)
+	assert.Equal(suite.T(), "3.25", rows[0].GPA)
+	assert.Equal(suite.T(), 2, rows[0].line)
+}
+
+// Test a missing required column rejects the whole file
+func (suite *SemesterGPAImportTestSuite) TestParseSemesterGPAImportCSV_MissingColumn() {
+	csv := "nim,semester_code,gpa\n" +
+		"2025001,GANJIL,3.25\n"
+
+	rows, err := parseSemesterGPAImportCSV(strings.NewReader(csv))
+
+	assert.Nil(suite.T(), rows)
+	assert.True(suite.T(), errors.Is(err, ErrInvalidImportFile))
+	assert.Contains(suite.T(), err.Error(), `"academic_year_code"`)
+}
+
+func (suite *SemesterGPAImportTestSuite) TestIsValidGPA() {
+	for _, gpa := range []string{"0", "2.5", "3.25", "4", "4.00"} {
+		assert.True(suite.T(), isValidGPA(gpa), gpa)
+	}
+	for _, gpa := range []string{"4.01", "4.5", "5", "-1", "3.255", "3.", "abc"} {
+		assert.False(suite.T(), isValidGPA(gpa), gpa)
+	}
+}
+
+// Test every invalid row is reported and nothing is written
+func (suite *SemesterGPAImportTestSuite) TestImport_Rejected() {
+	csv := "nim,academic_year_code,semester_code,gpa\n" +
+		"2025001,2024/2025,GANJIL,3.25\n" +
+		"2025001,2024/2025,GANJIL,3.50\n" +
+		"2025001,2024/2025,PENDEK,4.50\n" +
+		"2025099,2024/2025,GANJIL,3.00\n"
+
+	suite.expectLookups([]generated.SemesterGpa{})
+
+	report, err := suite.useCase.Import(suite.ctx, strings.NewReader(csv))
+
+	assert.ErrorIs(suite.T(), err, ErrImportRejected)
+	assert.Equal(suite.T(), 4, report.TotalRows)
+	assert.Equal(suite.T(), 0, report.ImportedRows)
+	assert.Len(suite.T(), report.Errors, 3)
+	assert.Equal(suite.T(), 3, report.Errors[0].Row)
+	assert.Contains(suite.T(), report.Errors[0].Errors[0], "first used in row 2")
+	assert.Equal(suite.T(), 4, report.Errors[1].Row)
+	assert.Len(suite.T(), report.Errors[1].Errors, 2) // invalid gpa and unknown semester
+	assert.Equal(suite.T(), 5, report.Errors[2].Row)
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateSemesterGPAsTx")
+}
+
+// Test a GPA already recorded for the student in the semester is rejected
+func (suite *SemesterGPAImportTestSuite) TestImport_AlreadyRecorded() {
+	csv := "nim,academic_year_code,semester_code,gpa\n" +
+		"2025001,2024/2025,GANJIL,3.25\n"
+
+	var gpa pgtype.Numeric
+	_ = gpa.Scan("3.10")
+	suite.expectLookups([]generated.SemesterGpa{
+		{StudentID: suite.studentUUID, SemesterID: suite.semesterUUID, Gpa: gpa},
+	})
+
+	report, err := suite.useCase.Import(suite.ctx, strings.NewReader(csv))
+
+	assert.ErrorIs(suite.T(), err, ErrImportRejected)
+	assert.Len(suite.T(), report.Errors, 1)
+	assert.Contains(suite.T(), report.Errors[0].Errors[0], "already recorded for the student in the semester as 3.10")
+}
+
+// Test a valid file is written in one go
+func (suite *SemesterGPAImportTestSuite) TestImport_Success() {
+	csv := "nim,academic_year_code,semester_code,gpa\n" +
+		"2025001,2024/2025,GANJIL,3.25\n"
+
+	suite.expectLookups([]generated.SemesterGpa{})
+	suite.mockRepo.On("CreateSemesterGPAsTx", mock.AnythingOfType("*common.TxContext"), mock.MatchedBy(func(gpas []repositories.NewSemesterGPA) bool {
+		return len(gpas) == 1 &&
+			gpas[0].StudentID == suite.studentUUID.String() &&
+			gpas[0].SemesterID == suite.semesterUUID.String() &&
+			gpas[0].GPA == "3.25"
+	})).Return(int64(1), nil)
+
+	report, err := suite.useCase.Import(suite.ctx, strings.NewReader(csv))
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, report.ImportedRows)
+	assert.Empty(suite.T(), report.Errors)
+}
+
+// Run the test suite
+func TestSemesterGPAImportTestSuite(t *testing.T) {
+	suite.Run(t, new(SemesterGPAImportTestSuite))
+}
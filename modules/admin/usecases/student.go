@@ -0,0 +1,235 @@
+package usecases
+
+import (
+	"context"
+	"math"
+	"siakad-poc/common"
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+type StudentResponse struct {
+	ID                string     `json:"id"`
+	UserID            string     `json:"user_id"`
+	NIM               string     `json:"nim"`
+	StudyProgramID    string     `json:"study_program_id"`
+	AcademicAdvisorID *string    `json:"academic_advisor_id"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         *time.Time `json:"updated_at"`
+}
+
+type CreateStudentRequest struct {
+	UserID            string `json:"user_id" validate:"required,uuid"`
+	NIM               string `json:"nim" validate:"required,max=255"`
+	StudyProgramID    string `json:"study_program_id" validate:"required,uuid"`
+	AcademicAdvisorID string `json:"academic_advisor_id" validate:"omitempty,uuid"`
+}
+
+type UpdateStudentRequest struct {
+	NIM               string `json:"nim" validate:"required,max=255"`
+	StudyProgramID    string `json:"study_program_id" validate:"required,uuid"`
+	AcademicAdvisorID string `json:"academic_advisor_id" validate:"omitempty,uuid"`
+}
+
+type StudentUseCase struct {
+	studentRepository  repositories.StudentRepository
+	lecturerRepository repositories.LecturerRepository
+	userRepository     repositories.UserRepository
+}
+
+func NewStudentUseCase(studentRepository repositories.StudentRepository, lecturerRepository repositories.LecturerRepository, userRepository repositories.UserRepository) *StudentUseCase {
+	return &StudentUseCase{
+		studentRepository:  studentRepository,
+		lecturerRepository: lecturerRepository,
+		userRepository:     userRepository,
+	}
+}
+
+func (uc *StudentUseCase) ListStudents(ctx context.Context, filter repositories.StudentFilter, page, pageSize int) ([]StudentResponse, *common.PaginationMetadata, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+
+	students, err := uc.studentRepository.ListStudents(ctx, filter, pageSize, offset)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot get students")
+	}
+
+	totalRecords, err := uc.studentRepository.CountStudents(ctx, filter)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot count students")
+	}
+
+	responses := make([]StudentResponse, 0, len(students))
+	for _, student := range students {
+		responses = append(responses, toStudentResponse(student))
+	}
+
+	pagination := &common.PaginationMetadata{
+		Page:         page,
+		PageSize:     pageSize,
+		TotalRecords: int(totalRecords),
+		TotalPages:   int(math.Ceil(float64(totalRecords) / float64(pageSize))),
+	}
+
+	return responses, pagination, nil
+}
+
+func (uc *StudentUseCase) GetStudent(ctx context.Context, id string) (StudentResponse, error) {
+	student, err := uc.studentRepository.GetStudent(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return StudentResponse{}, ErrStudentNotFound
+		}
+		return StudentResponse{}, errors.Wrap(err, "cannot get student")
+	}
+
+	return toStudentResponse(student), nil
+}
+
+// CreateStudent links an existing user account to a new student record. A user has at most one
+// student record and NIMs are unique, soft-deleted records included.
+func (uc *StudentUseCase) CreateStudent(ctx context.Context, req CreateStudentRequest) (StudentResponse, error) {
+	user, err := uc.userRepository.GetUser(ctx, req.UserID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return StudentResponse{}, ErrUnknownUser
+		}
+		return StudentResponse{}, errors.Wrap(err, "cannot get user")
+	}
+	if user.DeletedAt.Valid {
+		return StudentResponse{}, ErrUnknownUser
+	}
+
+	_, err = uc.studentRepository.GetStudentByUserID(ctx, req.UserID)
+	if err == nil {
+		return StudentResponse{}, ErrUserAlreadyStudent
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return StudentResponse{}, errors.Wrap(err, "cannot get student of user")
+	}
+
+	attributes := repositories.StudentAttributes{
+		StudyProgramID:    req.StudyProgramID,
+		NIM:               req.NIM,
+		AcademicAdvisorID: req.AcademicAdvisorID,
+	}
+	err = uc.validateAttributes(ctx, "", attributes)
+	if err != nil {
+		return StudentResponse{}, err
+	}
+
+	student, err := uc.studentRepository.CreateStudent(ctx, uuid.NewString(), req.UserID, attributes)
+	if err != nil {
+		return StudentResponse{}, errors.Wrap(err, "cannot create student")
+	}
+
+	return toStudentResponse(student), nil
+}
+
+func (uc *StudentUseCase) UpdateStudent(ctx context.Context, id string, req UpdateStudentRequest) (StudentResponse, error) {
+	_, err := uc.studentRepository.GetStudent(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return StudentResponse{}, ErrStudentNotFound
+		}
+		return StudentResponse{}, errors.Wrap(err, "cannot get student")
+	}
+
+	attributes := repositories.StudentAttributes{
+		StudyProgramID:    req.StudyProgramID,
+		NIM:               req.NIM,
+		AcademicAdvisorID: req.AcademicAdvisorID,
+	}
+	err = uc.validateAttributes(ctx, id, attributes)
+	if err != nil {
+		return StudentResponse{}, err
+	}
+
+	student, err := uc.studentRepository.UpdateStudent(ctx, id, attributes)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return StudentResponse{}, ErrStudentNotFound
+		}
+		return StudentResponse{}, errors.Wrap(err, "cannot update student")
+	}
+
+	return toStudentResponse(student), nil
+}
+
+// DeleteStudent soft-deletes the student record, the user account itself is left untouched.
+func (uc *StudentUseCase) DeleteStudent(ctx context.Context, id string) error {
+	_, err := uc.studentRepository.DeleteStudent(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrStudentNotFound
+		}
+		return errors.Wrap(err, "cannot delete student")
+	}
+
+	return nil
+}
+
+// validateAttributes checks the references and the NIM uniqueness, id is the student being updated, if any.
+func (uc *StudentUseCase) validateAttributes(ctx context.Context, id string, attributes repositories.StudentAttributes) error {
+	existing, err := uc.studentRepository.GetStudentByNIM(ctx, attributes.NIM)
+	if err == nil && existing.ID.String() != id {
+		return ErrNIMAlreadyUsed
+	}
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return errors.Wrap(err, "cannot check nim availability")
+	}
+
+	_, err = uc.studentRepository.GetStudyProgram(ctx, attributes.StudyProgramID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrUnknownStudyProgram
+		}
+		return errors.Wrap(err, "cannot get study program")
+	}
+
+	if attributes.AcademicAdvisorID != "" {
+		_, err = uc.lecturerRepository.GetLecturer(ctx, attributes.AcademicAdvisorID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrUnknownAcademicAdvisor
+			}
+			return errors.Wrap(err, "cannot get academic advisor")
+		}
+	}
+
+	return nil
+}
+
+func toStudentResponse(student generated.Student) StudentResponse {
+	response := StudentResponse{
+		ID:             student.ID.String(),
+		UserID:         student.UserID.String(),
+		NIM:            student.Nim,
+		StudyProgramID: student.StudyProgramID.String(),
+	}
+
+	if student.AcademicAdvisorID.Valid {
+		academicAdvisorID := student.AcademicAdvisorID.String()
+		response.AcademicAdvisorID = &academicAdvisorID
+	}
+	if student.CreatedAt.Valid {
+		response.CreatedAt = student.CreatedAt.Time
+	}
+	if student.UpdatedAt.Valid {
+		updatedAt := student.UpdatedAt.Time
+		response.UpdatedAt = &updatedAt
+	}
+
+	return response
+}
@@ -0,0 +1,353 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"runtime"
+	"siakad-poc/common"
+	"siakad-poc/constants"
+	"siakad-poc/db/repositories"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Columns of the student import CSV, the header row is required and matched case-insensitively
+const (
+	studentImportColumnEmail    = "email"
+	studentImportColumnName     = "name"
+	studentImportColumnNIM      = "nim"
+	studentImportColumnProdi    = "prodi"
+	studentImportColumnPassword = "password" // optional column
+)
+
+type StudentImportOptions struct {
+	// GeneratePasswords assigns a random initial password to rows with an empty password cell
+	GeneratePasswords bool
+}
+
+type StudentImportRowError struct {
+	Row    int      `json:"row"` // line number in the CSV file, the header is line 1
+	Email  string   `json:"email,omitempty"`
+	Errors []string `json:"errors"`
+}
+
+type StudentImportCredential struct {
+	Row      int    `json:"row"`
+	Email    string `json:"email"`
+	NIM      string `json:"nim"`
+	Password string `json:"password"`
+}
+
+type StudentImportReport struct {
+	TotalRows          int                       `json:"total_rows"`
+	ImportedRows       int                       `json:"imported_rows"`
+	Errors             []StudentImportRowError   `json:"errors"`
+	GeneratedPasswords []StudentImportCredential `json:"generated_passwords,omitempty"`
+}
+
+type studentImportRow struct {
+	Email    string `validate:"required,email,max=255"`
+	Name     string `validate:"required,max=255"`
+	NIM      string `validate:"required,max=255"`
+	Prodi    string `validate:"required,max=255"`
+	Password string `validate:"omitempty,min=6,max=72"`
+
+	line int
+}
+
+type StudentImportUseCase struct {
+	userRepository    repositories.UserRepository
+	studentRepository repositories.StudentRepository
+	txExecutor        common.TransactionExecutor
+}
+
+func NewStudentImportUseCase(
+	userRepository repositories.UserRepository,
+	studentRepository repositories.StudentRepository,
+	txExecutor common.TransactionExecutor,
+) *StudentImportUseCase {
+	return &StudentImportUseCase{
+		userRepository:    userRepository,
+		studentRepository: studentRepository,
+		txExecutor:        txExecutor,
+	}
+}
+
+// Import creates a student account (user with the student role plus its student record) for every CSV row.
+// The import is all or nothing: when any row is invalid, nothing is written and the report lists every
+// rejected row together with ErrImportRejected.
+func (uc *StudentImportUseCase) Import(ctx context.Context, r io.Reader, opts StudentImportOptions) (StudentImportReport, error) {
+	rows, err := parseStudentImportCSV(r)
+	if err != nil {
+		return StudentImportReport{}, err
+	}
+
+	report := StudentImportReport{
+		TotalRows: len(rows),
+		Errors:    []StudentImportRowError{},
+	}
+	if len(rows) == 0 {
+		return report, ErrEmptyImport
+	}
+
+	rowErrors, studyProgramIDs, err := uc.validateRows(ctx, rows, opts)
+	if err != nil {
+		return report, err
+	}
+
+	if len(rowErrors) > 0 {
+		for i, messages := range rowErrors {
+			report.Errors = append(report.Errors, StudentImportRowError{
+				Row:    rows[i].line,
+				Email:  rows[i].Email,
+				Errors: messages,
+			})
+		}
+		sort.Slice(report.Errors, func(i, j int) bool {
+			return report.Errors[i].Row < report.Errors[j].Row
+		})
+		return report, ErrImportRejected
+	}
+
+	passwords := make([]string, len(rows))
+	for i, row := range rows {
+		passwords[i] = row.Password
+		if row.Password != "" {
+			continue
+		}
+
+		passwords[i], err = generatePassword()
+		if err != nil {
+			return report, err
+		}
+		report.GeneratedPasswords = append(report.GeneratedPasswords, StudentImportCredential{
+			Row:      row.line,
+			Email:    row.Email,
+			NIM:      row.NIM,
+			Password: passwords[i],
+		})
+	}
+
+	hashedPasswords, err := hashPasswords(passwords)
+	if err != nil {
+		return report, err
+	}
+
+	users := make([]repositories.NewUser, 0, len(rows))
+	students := make([]repositories.NewStudent, 0, len(rows))
+	for i, row := range rows {
+		userID := uuid.NewString()
+		users = append(users, repositories.NewUser{
+			ID:       userID,
+			Email:    row.Email,
+			Password: hashedPasswords[i],
+			Name:     row.Name,
+			Role:     constants.RoleStudent,
+		})
+		students = append(students, repositories.NewStudent{
+			ID:             uuid.NewString(),
+			UserID:         userID,
+			StudyProgramID: studyProgramIDs[row.Prodi],
+			NIM:            row.NIM,
+		})
+	}
+
+	err = uc.txExecutor.WithTxContext(ctx, func(txCtx *common.TxContext) error {
+		_, err := uc.userRepository.CreateUsersTx(txCtx, users)
+		if err != nil {
+			return errors.Wrap(err, "cannot insert users")
+		}
+
+		imported, err := uc.studentRepository.CreateStudentsTx(txCtx, students)
+		if err != nil {
+			return errors.Wrap(err, "cannot insert students")
+		}
+
+		report.ImportedRows = int(imported)
+		return nil
+	})
+	if err != nil {
+		report.ImportedRows = 0
+		report.GeneratedPasswords = nil
+		return report, err
+	}
+
+	return report, nil
+}
+
+// validateRows checks every row on its own, against the other rows of the file and against the database.
+// It returns the error messages per row index and the study program IDs keyed by prodi code.
+func (uc *StudentImportUseCase) validateRows(ctx context.Context, rows []studentImportRow, opts StudentImportOptions) (map[int][]string, map[string]string, error) {
+	rowErrors := make(map[int][]string)
+	addError := func(i int, message string) {
+		rowErrors[i] = append(rowErrors[i], message)
+	}
+
+	emailRows := make(map[string]int)
+	nimRows := make(map[string]int)
+	var emails, nims, prodiCodes []string
+	for i, row := range rows {
+		for _, message := range common.ValidateStruct(row) {
+			addError(i, message)
+		}
+		if row.Password == "" && !opts.GeneratePasswords {
+			addError(i, "password is required unless password generation is enabled")
+		}
+
+		if row.Email != "" {
+			if first, ok := emailRows[row.Email]; ok {
+				addError(i, fmt.Sprintf("email is duplicated, first used in row %d", rows[first].line))
+			} else {
+				emailRows[row.Email] = i
+				emails = append(emails, row.Email)
+			}
+		}
+		if row.NIM != "" {
+			if first, ok := nimRows[row.NIM]; ok {
+				addError(i, fmt.Sprintf("nim is duplicated, first used in row %d", rows[first].line))
+			} else {
+				nimRows[row.NIM] = i
+				nims = append(nims, row.NIM)
+			}
+		}
+		if row.Prodi != "" {
+			prodiCodes = append(prodiCodes, row.Prodi)
+		}
+	}
+
+	existingEmails, err := uc.userRepository.GetActiveUserEmails(ctx, emails)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot check existing emails")
+	}
+	for _, email := range existingEmails {
+		addError(emailRows[email], "email is already used by another account")
+	}
+
+	existingNIMs, err := uc.studentRepository.GetExistingStudentNIMs(ctx, nims)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot check existing nims")
+	}
+	for _, nim := range existingNIMs {
+		addError(nimRows[nim], "nim is already used by another student")
+	}
+
+	studyPrograms, err := uc.studentRepository.GetStudyProgramsByCodes(ctx, prodiCodes)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot get study programs")
+	}
+	studyProgramIDs := make(map[string]string, len(studyPrograms))
+	for _, studyProgram := range studyPrograms {
+		studyProgramIDs[studyProgram.Code] = studyProgram.ID.String()
+	}
+	for i, row := range rows {
+		if _, ok := studyProgramIDs[row.Prodi]; row.Prodi != "" && !ok {
+			addError(i, fmt.Sprintf("prodi %q does not exist", row.Prodi))
+		}
+	}
+
+	return rowErrors, studyProgramIDs, nil
+}
+
+func parseStudentImportCSV(r io.Reader) ([]studentImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // short rows are reported per row instead of failing the whole file
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.Wrap(ErrInvalidImportFile, "missing header row")
+		}
+		return nil, errors.Wrap(ErrInvalidImportFile, err.Error())
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+		columns[column] = i
+	}
+	for _, required := range []string{studentImportColumnEmail, studentImportColumnName, studentImportColumnNIM, studentImportColumnProdi} {
+		if _, ok := columns[required]; !ok {
+			return nil, errors.Wrapf(ErrInvalidImportFile, "missing %q column", required)
+		}
+	}
+
+	field := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var rows []studentImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidImportFile, err.Error())
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, studentImportRow{
+			Email:    strings.ToLower(field(record, studentImportColumnEmail)),
+			Name:     field(record, studentImportColumnName),
+			NIM:      field(record, studentImportColumnNIM),
+			Prodi:    field(record, studentImportColumnProdi),
+			Password: field(record, studentImportColumnPassword),
+			line:     line,
+		})
+	}
+
+	return rows, nil
+}
+
+// hashPasswords bcrypt-hashes the passwords on all CPUs, bcrypt is by far the slowest part of an import.
+func hashPasswords(passwords []string) ([]string, error) {
+	hashed := make([]string, len(passwords))
+	hashErrors := make([]error, len(passwords))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < runtime.NumCPU(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				hash, err := bcrypt.GenerateFromPassword([]byte(passwords[i]), bcrypt.DefaultCost)
+				hashed[i], hashErrors[i] = string(hash), err
+			}
+		}()
+	}
+	for i := range passwords {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, err := range hashErrors {
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot hash password")
+		}
+	}
+	return hashed, nil
+}
+
+func generatePassword() (string, error) {
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return "", errors.Wrap(err, "cannot generate password")
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
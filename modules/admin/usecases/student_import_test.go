@@ -0,0 +1,454 @@
+package usecases
+
+import (
+	"context"
+	"siakad-poc/common"
+	"siakad-poc/constants"
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// Mock user repository for the admin use cases
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) GetUser(ctx context.Context, id string) (generated.User, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUserByEmail(ctx context.Context, email string) (generated.User, error) {
+	args := m.Called(ctx, email)
+	return args.Get(0).(generated.User), args.Error(1)
+}
+
+func (m *MockUserRepository) CreateUser(ctx context.Context, email, password, name string, role int64, studyProgramID string) (generated.User, error) {
+	args := m.Called(ctx, email, password, name, role, studyProgramID)
+	return args.Get(0).(generated.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetActiveUserEmails(ctx context.Context, emails []string) ([]string, error) {
+	args := m.Called(ctx, emails)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockUserRepository) ListUsers(ctx context.Context, filter repositories.UserFilter, limit, offset int) ([]generated.User, error) {
+	args := m.Called(ctx, filter, limit, offset)
+	return args.Get(0).([]generated.User), args.Error(1)
+}
+
+func (m *MockUserRepository) CountUsers(ctx context.Context, filter repositories.UserFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateUserRole(ctx context.Context, id string, role int64) (generated.User, error) {
+	args := m.Called(ctx, id, role)
+	return args.Get(0).(generated.User), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateUserStudyProgram(ctx context.Context, id, studyProgramID string) (generated.User, error) {
+	args := m.Called(ctx, id, studyProgramID)
+	return args.Get(0).(generated.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUserStudyProgramID(ctx context.Context, id string) (string, error) {
+	args := m.Called(ctx, id)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockUserRepository) DisableUser(ctx context.Context, id string) (generated.User, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.User), args.Error(1)
+}
+
+func (m *MockUserRepository) EnableUser(ctx context.Context, id string) (generated.User, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.User), args.Error(1)
+}
+
+func (m *MockUserRepository) DeleteUser(ctx context.Context, id string) (generated.User, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.User), args.Error(1)
+}
+
+func (m *MockUserRepository) RestoreUser(ctx context.Context, id string) (generated.User, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.User), args.Error(1)
+}
+
+func (m *MockUserRepository) CreateRefreshToken(ctx context.Context, userID, familyID, tokenHash string, expiresAt time.Time) (generated.RefreshToken, error) {
+	args := m.Called(ctx, userID, familyID, tokenHash, expiresAt)
+	return args.Get(0).(generated.RefreshToken), args.Error(1)
+}
+
+func (m *MockUserRepository) RevokeRefreshTokenFamilyByHash(ctx context.Context, userID, tokenHash string) error {
+	args := m.Called(ctx, userID, tokenHash)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) RevokeUserRefreshTokens(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateUserPassword(ctx context.Context, id, password string) (generated.User, error) {
+	args := m.Called(ctx, id, password)
+	return args.Get(0).(generated.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUserTx(txCtx *common.TxContext, id string) (generated.User, error) {
+	args := m.Called(txCtx, id)
+	return args.Get(0).(generated.User), args.Error(1)
+}
+
+func (m *MockUserRepository) CreateUsersTx(txCtx *common.TxContext, users []repositories.NewUser) (int64, error) {
+	args := m.Called(txCtx, users)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserRepository) GetRefreshTokenByHashTx(txCtx *common.TxContext, tokenHash string) (generated.RefreshToken, error) {
+	args := m.Called(txCtx, tokenHash)
+	return args.Get(0).(generated.RefreshToken), args.Error(1)
+}
+
+func (m *MockUserRepository) CreateRefreshTokenTx(txCtx *common.TxContext, userID, familyID, tokenHash string, expiresAt time.Time) (generated.RefreshToken, error) {
+	args := m.Called(txCtx, userID, familyID, tokenHash, expiresAt)
+	return args.Get(0).(generated.RefreshToken), args.Error(1)
+}
+
+func (m *MockUserRepository) RotateRefreshTokenTx(txCtx *common.TxContext, id, replacedByID string) (generated.RefreshToken, error) {
+	args := m.Called(txCtx, id, replacedByID)
+	return args.Get(0).(generated.RefreshToken), args.Error(1)
+}
+
+func (m *MockUserRepository) RevokeRefreshTokenFamilyTx(txCtx *common.TxContext, familyID string) error {
+	args := m.Called(txCtx, familyID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateUserPasswordTx(txCtx *common.TxContext, id, password string) (generated.User, error) {
+	args := m.Called(txCtx, id, password)
+	return args.Get(0).(generated.User), args.Error(1)
+}
+
+func (m *MockUserRepository) CreatePasswordResetTokenTx(txCtx *common.TxContext, userID, tokenHash string, expiresAt time.Time, createdBy string) (generated.PasswordResetToken, error) {
+	args := m.Called(txCtx, userID, tokenHash, expiresAt, createdBy)
+	return args.Get(0).(generated.PasswordResetToken), args.Error(1)
+}
+
+func (m *MockUserRepository) InvalidatePasswordResetTokensTx(txCtx *common.TxContext, userID string) error {
+	args := m.Called(txCtx, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetPasswordResetTokenByHashTx(txCtx *common.TxContext, tokenHash string) (generated.PasswordResetToken, error) {
+	args := m.Called(txCtx, tokenHash)
+	return args.Get(0).(generated.PasswordResetToken), args.Error(1)
+}
+
+func (m *MockUserRepository) MarkPasswordResetTokenUsedTx(txCtx *common.TxContext, id string) (generated.PasswordResetToken, error) {
+	args := m.Called(txCtx, id)
+	return args.Get(0).(generated.PasswordResetToken), args.Error(1)
+}
+
+// Mock student repository for the admin use cases
+type MockStudentRepository struct {
+	mock.Mock
+}
+
+func (m *MockStudentRepository) GetStudyProgram(ctx context.Context, id string) (generated.StudyProgram, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.StudyProgram), args.Error(1)
+}
+
+func (m *MockStudentRepository) GetStudyProgramsByCodes(ctx context.Context, codes []string) ([]generated.StudyProgram, error) {
+	args := m.Called(ctx, codes)
+	return args.Get(0).([]generated.StudyProgram), args.Error(1)
+}
+
+func (m *MockStudentRepository) GetExistingStudentNIMs(ctx context.Context, nims []string) ([]string, error) {
+	args := m.Called(ctx, nims)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockStudentRepository) GetStudentProfileByUserID(ctx context.Context, userID string) (generated.GetStudentProfileByUserIDRow, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(generated.GetStudentProfileByUserIDRow), args.Error(1)
+}
+
+func (m *MockStudentRepository) ListStudents(ctx context.Context, filter repositories.StudentFilter, limit, offset int) ([]generated.Student, error) {
+	args := m.Called(ctx, filter, limit, offset)
+	return args.Get(0).([]generated.Student), args.Error(1)
+}
+
+func (m *MockStudentRepository) CountStudents(ctx context.Context, filter repositories.StudentFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockStudentRepository) GetStudent(ctx context.Context, id string) (generated.Student, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.Student), args.Error(1)
+}
+
+func (m *MockStudentRepository) GetStudentByNIM(ctx context.Context, nim string) (generated.Student, error) {
+	args := m.Called(ctx, nim)
+	return args.Get(0).(generated.Student), args.Error(1)
+}
+
+func (m *MockStudentRepository) GetStudentByUserID(ctx context.Context, userID string) (generated.Student, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(generated.Student), args.Error(1)
+}
+
+func (m *MockStudentRepository) CreateStudent(ctx context.Context, id, userID string, attributes repositories.StudentAttributes) (generated.Student, error) {
+	args := m.Called(ctx, id, userID, attributes)
+	return args.Get(0).(generated.Student), args.Error(1)
+}
+
+func (m *MockStudentRepository) UpdateStudent(ctx context.Context, id string, attributes repositories.StudentAttributes) (generated.Student, error) {
+	args := m.Called(ctx, id, attributes)
+	return args.Get(0).(generated.Student), args.Error(1)
+}
+
+func (m *MockStudentRepository) DeleteStudent(ctx context.Context, id string) (generated.Student, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.Student), args.Error(1)
+}
+
+func (m *MockStudentRepository) CountStudentsByAcademicAdvisor(ctx context.Context, lecturerID string) (int64, error) {
+	args := m.Called(ctx, lecturerID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockStudentRepository) CreateStudentsTx(txCtx *common.TxContext, students []repositories.NewStudent) (int64, error) {
+	args := m.Called(txCtx, students)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// rollbackRecordingTxExecutor runs the function like common.MockTransactionExecutor and records
+// whether the transaction would have been rolled back, which happens whenever the function fails
+type rollbackRecordingTxExecutor struct {
+	common.MockTransactionExecutor
+	rolledBack bool
+}
+
+func (e *rollbackRecordingTxExecutor) WithTxContext(ctx context.Context, fn func(*common.TxContext) error) error {
+	err := e.MockTransactionExecutor.WithTxContext(ctx, fn)
+	e.rolledBack = err != nil
+	return err
+}
+
+// Test Suite
+type StudentImportCSVTestSuite struct {
+	suite.Suite
+}
+
+// Test header matching is case-insensitive and the optional password column is honoured
+func (suite *StudentImportCSVTestSuite) TestParseStudentImportCSV_Success() {
+	csv := "\ufeffEmail,Name,NIM,Prodi,Password\n" +
+		" Budi@Example.com ,Budi Santoso,2025001,IF,secret123\n" +
+		"siti@example.com,Siti Aminah,2025002,SI,\n"
+
+	rows, err := parseStudentImportCSV(strings.NewReader(csv))
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), rows, 2)
+	assert.Equal(suite.T(), "budi@example.com", rows[0].Email)
+	assert.Equal(suite.T(), "Budi Santoso", rows[0].Name)
+	assert.Equal(suite.T(), "2025001", rows[0].NIM)
+	assert.Equal(suite.T(), "IF", rows[0].Prodi)
+	assert.Equal(suite.T(), "secret123", rows[0].Password)
+	assert.Equal(suite.T(), 2, rows[0].line)
+	assert.Equal(suite.T(), "", rows[1].Password)
+	assert.Equal(suite.T(), 3, rows[1].line)
+}
+
+// Test short rows are kept so they can be reported per row
+func (suite *StudentImportCSVTestSuite) TestParseStudentImportCSV_ShortRow() {
+	csv := "email,name,nim,prodi\n" +
+		"budi@example.com,Budi Santoso\n"
+
+	rows, err := parseStudentImportCSV(strings.NewReader(csv))
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), rows, 1)
+	assert.Equal(suite.T(), "", rows[0].NIM)
+	assert.Equal(suite.T(), "", rows[0].Prodi)
+}
+
+// Test a missing required column rejects the whole file
+func (suite *StudentImportCSVTestSuite) TestParseStudentImportCSV_MissingColumn() {
+	csv := "email,name,prodi\n" +
+		"budi@example.com,Budi Santoso,IF\n"
+
+	rows, err := parseStudentImportCSV(strings.NewReader(csv))
+
+	assert.Nil(suite.T(), rows)
+	assert.True(suite.T(), errors.Is(err, ErrInvalidImportFile))
+	assert.Contains(suite.T(), err.Error(), `"nim"`)
+}
+
+// Test an empty file is rejected
+func (suite *StudentImportCSVTestSuite) TestParseStudentImportCSV_Empty() {
+	rows, err := parseStudentImportCSV(strings.NewReader(""))
+
+	assert.Nil(suite.T(), rows)
+	assert.True(suite.T(), errors.Is(err, ErrInvalidImportFile))
+}
+
+// Run the test suite
+func TestStudentImportCSVTestSuite(t *testing.T) {
+	suite.Run(t, new(StudentImportCSVTestSuite))
+}
+
+// Test Suite
+type StudentImportTestSuite struct {
+	suite.Suite
+	useCase         *StudentImportUseCase
+	mockUserRepo    *MockUserRepository
+	mockStudentRepo *MockStudentRepository
+	txExecutor      *rollbackRecordingTxExecutor
+	ctx             context.Context
+	prodiUUID       pgtype.UUID
+}
+
+func (suite *StudentImportTestSuite) SetupTest() {
+	suite.mockUserRepo = new(MockUserRepository)
+	suite.mockStudentRepo = new(MockStudentRepository)
+	suite.txExecutor = new(rollbackRecordingTxExecutor)
+	suite.useCase = NewStudentImportUseCase(suite.mockUserRepo, suite.mockStudentRepo, suite.txExecutor)
+	suite.ctx = context.Background()
+
+	suite.prodiUUID = pgtype.UUID{Bytes: [16]byte{5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5}, Valid: true}
+}
+
+func (suite *StudentImportTestSuite) TearDownTest() {
+	suite.mockUserRepo.AssertExpectations(suite.T())
+	suite.mockStudentRepo.AssertExpectations(suite.T())
+}
+
+// expectLookups expects the emails and NIMs to be checked against the database, with the
+// given ones already taken, and the study program IF to exist
+func (suite *StudentImportTestSuite) expectLookups(emails, nims, existingEmails, existingNIMs []string) {
+	suite.mockUserRepo.On("GetActiveUserEmails", suite.ctx, emails).Return(existingEmails, nil)
+	suite.mockStudentRepo.On("GetExistingStudentNIMs", suite.ctx, nims).Return(existingNIMs, nil)
+	suite.mockStudentRepo.On("GetStudyProgramsByCodes", suite.ctx, mock.Anything).Return([]generated.StudyProgram{
+		{ID: suite.prodiUUID, Code: "IF"},
+	}, nil)
+}
+
+// Test duplicates within the file, emails and NIMs already in use and invalid cells are all
+// reported on their own row, and nothing is written
+func (suite *StudentImportTestSuite) TestImport_RejectedRows() {
+	csv := "email,name,nim,prodi,password\n" +
+		"budi@example.com,Budi Santoso,2025001,IF,secret123\n" +
+		"BUDI@example.com,Budi Lain,2025002,IF,secret123\n" +
+		"siti@example.com,Siti Aminah,2025001,IF,secret123\n" +
+		"taken@example.com,Andi Wijaya,2025003,IF,secret123\n" +
+		"dewi@example.com,Dewi Lestari,2024999,IF,secret123\n" +
+		"rina@example.com,,2025004,XX,secret123\n"
+
+	suite.expectLookups(
+		[]string{"budi@example.com", "siti@example.com", "taken@example.com", "dewi@example.com", "rina@example.com"},
+		[]string{"2025001", "2025002", "2025003", "2024999", "2025004"},
+		[]string{"taken@example.com"},
+		[]string{"2024999"},
+	)
+
+	report, err := suite.useCase.Import(suite.ctx, strings.NewReader(csv), StudentImportOptions{})
+
+	assert.ErrorIs(suite.T(), err, ErrImportRejected)
+	assert.Equal(suite.T(), 6, report.TotalRows)
+	assert.Equal(suite.T(), 0, report.ImportedRows)
+	assert.Len(suite.T(), report.Errors, 5)
+	assert.Equal(suite.T(), StudentImportRowError{Row: 3, Email: "budi@example.com", Errors: []string{"email is duplicated, first used in row 2"}}, report.Errors[0])
+	assert.Equal(suite.T(), StudentImportRowError{Row: 4, Email: "siti@example.com", Errors: []string{"nim is duplicated, first used in row 2"}}, report.Errors[1])
+	assert.Equal(suite.T(), StudentImportRowError{Row: 5, Email: "taken@example.com", Errors: []string{"email is already used by another account"}}, report.Errors[2])
+	assert.Equal(suite.T(), StudentImportRowError{Row: 6, Email: "dewi@example.com", Errors: []string{"nim is already used by another student"}}, report.Errors[3])
+	assert.Equal(suite.T(), 7, report.Errors[4].Row)
+	assert.Len(suite.T(), report.Errors[4].Errors, 2) // missing name and unknown prodi
+	assert.Contains(suite.T(), report.Errors[4].Errors[1], `prodi "XX" does not exist`)
+	suite.mockUserRepo.AssertNotCalled(suite.T(), "CreateUsersTx")
+	suite.mockStudentRepo.AssertNotCalled(suite.T(), "CreateStudentsTx")
+}
+
+// Test a row without password is rejected unless password generation is enabled
+func (suite *StudentImportTestSuite) TestImport_PasswordRequired() {
+	csv := "email,name,nim,prodi\n" +
+		"budi@example.com,Budi Santoso,2025001,IF\n"
+
+	suite.expectLookups([]string{"budi@example.com"}, []string{"2025001"}, []string{}, []string{})
+
+	report, err := suite.useCase.Import(suite.ctx, strings.NewReader(csv), StudentImportOptions{})
+
+	assert.ErrorIs(suite.T(), err, ErrImportRejected)
+	assert.Len(suite.T(), report.Errors, 1)
+	assert.Equal(suite.T(), []string{"password is required unless password generation is enabled"}, report.Errors[0].Errors)
+}
+
+// Test every row becomes a student account linked to its student record, generated passwords are reported
+func (suite *StudentImportTestSuite) TestImport_Success() {
+	csv := "email,name,nim,prodi,password\n" +
+		"budi@example.com,Budi Santoso,2025001,IF,secret123\n" +
+		"siti@example.com,Siti Aminah,2025002,IF,\n"
+
+	suite.expectLookups([]string{"budi@example.com", "siti@example.com"}, []string{"2025001", "2025002"}, []string{}, []string{})
+
+	var users []repositories.NewUser
+	suite.mockUserRepo.On("CreateUsersTx", mock.AnythingOfType("*common.TxContext"), mock.Anything).Run(func(args mock.Arguments) {
+		users = args.Get(1).([]repositories.NewUser)
+	}).Return(int64(2), nil)
+	suite.mockStudentRepo.On("CreateStudentsTx", mock.AnythingOfType("*common.TxContext"), mock.MatchedBy(func(students []repositories.NewStudent) bool {
+		return len(students) == 2 &&
+			students[0].UserID == users[0].ID && students[0].NIM == "2025001" &&
+			students[1].UserID == users[1].ID && students[1].NIM == "2025002" &&
+			students[0].StudyProgramID == suite.prodiUUID.String()
+	})).Return(int64(2), nil)
+
+	report, err := suite.useCase.Import(suite.ctx, strings.NewReader(csv), StudentImportOptions{GeneratePasswords: true})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, report.ImportedRows)
+	assert.Empty(suite.T(), report.Errors)
+	assert.Equal(suite.T(), constants.RoleStudent, users[0].Role)
+	assert.Len(suite.T(), report.GeneratedPasswords, 1)
+	assert.Equal(suite.T(), "siti@example.com", report.GeneratedPasswords[0].Email)
+	assert.NotEmpty(suite.T(), report.GeneratedPasswords[0].Password)
+	assert.False(suite.T(), suite.txExecutor.rolledBack)
+}
+
+// Test a row failing in the database, e.g. an email taken by a concurrent import, rolls back the whole batch
+func (suite *StudentImportTestSuite) TestImport_InsertErrorRollsBack() {
+	csv := "email,name,nim,prodi\n" +
+		"budi@example.com,Budi Santoso,2025001,IF\n" +
+		"siti@example.com,Siti Aminah,2025002,IF\n"
+
+	suite.expectLookups([]string{"budi@example.com", "siti@example.com"}, []string{"2025001", "2025002"}, []string{}, []string{})
+	suite.mockUserRepo.On("CreateUsersTx", mock.AnythingOfType("*common.TxContext"), mock.Anything).Return(int64(2), nil)
+	suite.mockStudentRepo.On("CreateStudentsTx", mock.AnythingOfType("*common.TxContext"), mock.Anything).Return(int64(0), errors.New("duplicate key value violates unique constraint"))
+
+	report, err := suite.useCase.Import(suite.ctx, strings.NewReader(csv), StudentImportOptions{GeneratePasswords: true})
+
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "cannot insert students")
+	assert.True(suite.T(), suite.txExecutor.rolledBack)
+	assert.Equal(suite.T(), 0, report.ImportedRows)
+	assert.Nil(suite.T(), report.GeneratedPasswords)
+}
+
+// Run the test suite
+func TestStudentImportTestSuite(t *testing.T) {
+	suite.Run(t, new(StudentImportTestSuite))
+}
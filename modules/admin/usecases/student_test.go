@@ -0,0 +1,188 @@
+package usecases
+
+import (
+	"context"
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	studentRecordID      = "550e8400-e29b-41d4-a716-446655440081"
+	otherStudentRecordID = "550e8400-e29b-41d4-a716-446655440082"
+	studentUserID        = "550e8400-e29b-41d4-a716-446655440083"
+	studentProgramID     = "550e8400-e29b-41d4-a716-446655440084"
+	advisorID            = "550e8400-e29b-41d4-a716-446655440085"
+	studentNIM           = "2024010001"
+	studentNewNIM        = "2024010002"
+)
+
+// Test Suite
+type StudentUseCaseTestSuite struct {
+	suite.Suite
+	useCase             *StudentUseCase
+	mockStudentRepo     *MockStudentRepository
+	mockLecturerRepo    *MockLecturerRepository
+	mockUserRepo        *MockUserRepository
+	ctx                 context.Context
+	createRequest       CreateStudentRequest
+	requestedAttributes repositories.StudentAttributes
+}
+
+func (suite *StudentUseCaseTestSuite) SetupTest() {
+	suite.mockStudentRepo = new(MockStudentRepository)
+	suite.mockLecturerRepo = new(MockLecturerRepository)
+	suite.mockUserRepo = new(MockUserRepository)
+	suite.useCase = NewStudentUseCase(suite.mockStudentRepo, suite.mockLecturerRepo, suite.mockUserRepo)
+	suite.ctx = context.Background()
+	suite.createRequest = CreateStudentRequest{
+		UserID:            studentUserID,
+		NIM:               studentNIM,
+		StudyProgramID:    studentProgramID,
+		AcademicAdvisorID: advisorID,
+	}
+	suite.requestedAttributes = repositories.StudentAttributes{
+		StudyProgramID:    studentProgramID,
+		NIM:               studentNIM,
+		AcademicAdvisorID: advisorID,
+	}
+}
+
+func (suite *StudentUseCaseTestSuite) TearDownTest() {
+	suite.mockStudentRepo.AssertExpectations(suite.T())
+	suite.mockLecturerRepo.AssertExpectations(suite.T())
+	suite.mockUserRepo.AssertExpectations(suite.T())
+}
+
+// student returns a student record holding the NIM, soft-deleted when deletedAt is set
+func student(id, nim string, deletedAt *time.Time) generated.Student {
+	record := generated.Student{
+		ID:             testUUID(id),
+		UserID:         testUUID(studentUserID),
+		Nim:            nim,
+		StudyProgramID: testUUID(studentProgramID),
+	}
+	if deletedAt != nil {
+		record.DeletedAt = pgtype.Timestamptz{Time: *deletedAt, Valid: true}
+	}
+	return record
+}
+
+// expectUserWithoutStudent sets up an active user that has no student record yet
+func (suite *StudentUseCaseTestSuite) expectUserWithoutStudent() {
+	suite.mockUserRepo.On("GetUser", suite.ctx, studentUserID).Return(generated.User{ID: testUUID(studentUserID)}, nil)
+	suite.mockStudentRepo.On("GetStudentByUserID", suite.ctx, studentUserID).Return(generated.Student{}, pgx.ErrNoRows)
+}
+
+// Test a student record is created for a user with a free NIM, checking the study program and the advisor
+func (suite *StudentUseCaseTestSuite) TestCreateStudent_Success() {
+	suite.expectUserWithoutStudent()
+	suite.mockStudentRepo.On("GetStudentByNIM", suite.ctx, studentNIM).Return(generated.Student{}, pgx.ErrNoRows)
+	suite.mockStudentRepo.On("GetStudyProgram", suite.ctx, studentProgramID).Return(generated.StudyProgram{}, nil)
+	suite.mockLecturerRepo.On("GetLecturer", suite.ctx, advisorID).Return(generated.Lecturer{ID: testUUID(advisorID)}, nil)
+	suite.mockStudentRepo.On("CreateStudent", suite.ctx, mock.AnythingOfType("string"), studentUserID, suite.requestedAttributes).
+		Return(student(studentRecordID, studentNIM, nil), nil)
+
+	response, err := suite.useCase.CreateStudent(suite.ctx, suite.createRequest)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), studentRecordID, response.ID)
+	assert.Equal(suite.T(), studentUserID, response.UserID)
+}
+
+// Test a NIM can't be reused, not even the NIM of a soft-deleted student
+func (suite *StudentUseCaseTestSuite) TestCreateStudent_NIMAlreadyUsed() {
+	deletedAt := time.Now().Add(-24 * time.Hour)
+	testCases := []struct {
+		name     string
+		existing generated.Student
+	}{
+		{"active student", student(otherStudentRecordID, studentNIM, nil)},
+		{"soft-deleted student", student(otherStudentRecordID, studentNIM, &deletedAt)},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+			defer suite.TearDownTest()
+
+			suite.expectUserWithoutStudent()
+			suite.mockStudentRepo.On("GetStudentByNIM", suite.ctx, studentNIM).Return(tc.existing, nil)
+
+			_, err := suite.useCase.CreateStudent(suite.ctx, suite.createRequest)
+
+			assert.True(suite.T(), errors.Is(err, ErrNIMAlreadyUsed))
+			suite.mockStudentRepo.AssertNotCalled(suite.T(), "CreateStudent", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// Test a user can only be linked to one student record, a soft-deleted one included
+func (suite *StudentUseCaseTestSuite) TestCreateStudent_UserAlreadyLinked() {
+	deletedAt := time.Now().Add(-24 * time.Hour)
+	suite.mockUserRepo.On("GetUser", suite.ctx, studentUserID).Return(generated.User{ID: testUUID(studentUserID)}, nil)
+	suite.mockStudentRepo.On("GetStudentByUserID", suite.ctx, studentUserID).Return(student(otherStudentRecordID, studentNIM, &deletedAt), nil)
+
+	_, err := suite.useCase.CreateStudent(suite.ctx, suite.createRequest)
+
+	assert.True(suite.T(), errors.Is(err, ErrUserAlreadyStudent))
+	suite.mockStudentRepo.AssertNotCalled(suite.T(), "GetStudentByNIM", mock.Anything, mock.Anything)
+}
+
+// Test an unknown academic advisor is rejected
+func (suite *StudentUseCaseTestSuite) TestCreateStudent_UnknownAdvisor() {
+	suite.expectUserWithoutStudent()
+	suite.mockStudentRepo.On("GetStudentByNIM", suite.ctx, studentNIM).Return(generated.Student{}, pgx.ErrNoRows)
+	suite.mockStudentRepo.On("GetStudyProgram", suite.ctx, studentProgramID).Return(generated.StudyProgram{}, nil)
+	suite.mockLecturerRepo.On("GetLecturer", suite.ctx, advisorID).Return(generated.Lecturer{}, pgx.ErrNoRows)
+
+	_, err := suite.useCase.CreateStudent(suite.ctx, suite.createRequest)
+
+	assert.True(suite.T(), errors.Is(err, ErrUnknownAcademicAdvisor))
+	suite.mockStudentRepo.AssertNotCalled(suite.T(), "CreateStudent", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Test a student keeps their own NIM on update
+func (suite *StudentUseCaseTestSuite) TestUpdateStudent_KeepsOwnNIM() {
+	suite.mockStudentRepo.On("GetStudent", suite.ctx, studentRecordID).Return(student(studentRecordID, studentNIM, nil), nil)
+	suite.mockStudentRepo.On("GetStudentByNIM", suite.ctx, studentNIM).Return(student(studentRecordID, studentNIM, nil), nil)
+	suite.mockStudentRepo.On("GetStudyProgram", suite.ctx, studentProgramID).Return(generated.StudyProgram{}, nil)
+	suite.mockLecturerRepo.On("GetLecturer", suite.ctx, advisorID).Return(generated.Lecturer{ID: testUUID(advisorID)}, nil)
+	suite.mockStudentRepo.On("UpdateStudent", suite.ctx, studentRecordID, suite.requestedAttributes).Return(student(studentRecordID, studentNIM, nil), nil)
+
+	_, err := suite.useCase.UpdateStudent(suite.ctx, studentRecordID, UpdateStudentRequest{
+		NIM:               studentNIM,
+		StudyProgramID:    studentProgramID,
+		AcademicAdvisorID: advisorID,
+	})
+
+	assert.NoError(suite.T(), err)
+}
+
+// Test a student can't take over the NIM of a soft-deleted student on update
+func (suite *StudentUseCaseTestSuite) TestUpdateStudent_NIMOfDeletedStudent() {
+	deletedAt := time.Now().Add(-24 * time.Hour)
+	suite.mockStudentRepo.On("GetStudent", suite.ctx, studentRecordID).Return(student(studentRecordID, studentNIM, nil), nil)
+	suite.mockStudentRepo.On("GetStudentByNIM", suite.ctx, studentNewNIM).Return(student(otherStudentRecordID, studentNewNIM, &deletedAt), nil)
+
+	_, err := suite.useCase.UpdateStudent(suite.ctx, studentRecordID, UpdateStudentRequest{
+		NIM:            studentNewNIM,
+		StudyProgramID: studentProgramID,
+	})
+
+	assert.True(suite.T(), errors.Is(err, ErrNIMAlreadyUsed))
+	suite.mockStudentRepo.AssertNotCalled(suite.T(), "UpdateStudent", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Run the test suite
+func TestStudentUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(StudentUseCaseTestSuite))
+}
@@ -0,0 +1,389 @@
+package usecases
+
+import (
+	"context"
+	"math"
+	"net"
+	"siakad-poc/common"
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type UserResponse struct {
+	ID             string     `json:"id"`
+	Email          string     `json:"email"`
+	Name           string     `json:"name"`
+	Role           int64      `json:"role"`
+	StudyProgramID *string    `json:"study_program_id"`
+	CreatedAt      time.Time  `json:"created_at"`
+	DisabledAt     *time.Time `json:"disabled_at"`
+	DeletedAt      *time.Time `json:"deleted_at"`
+}
+
+type CreateUserRequest struct {
+	Email          string `json:"email" validate:"required,email"`
+	Name           string `json:"name" validate:"max=255"`
+	Password       string `json:"password" validate:"required,min=6,max=72"`
+	Role           int64  `json:"role" validate:"required,min=1,max=99"`
+	StudyProgramID string `json:"study_program_id" validate:"omitempty,uuid"`
+}
+
+type UpdateUserRoleRequest struct {
+	Role int64 `json:"role" validate:"required,min=1,max=99"`
+}
+
+// UpdateUserStudyProgramRequest links a user to a study program, an empty ID removes the link
+type UpdateUserStudyProgramRequest struct {
+	StudyProgramID string `json:"study_program_id" validate:"omitempty,uuid"`
+}
+
+type UserUseCase struct {
+	userRepository            repositories.UserRepository
+	tokenRevocationRepository repositories.TokenRevocationRepository
+	loginThrottleRepository   repositories.LoginThrottleRepository
+	permissionRepository      repositories.PermissionRepository
+	studentRepository         repositories.StudentRepository
+}
+
+func NewUserUseCase(
+	userRepository repositories.UserRepository,
+	tokenRevocationRepository repositories.TokenRevocationRepository,
+	loginThrottleRepository repositories.LoginThrottleRepository,
+	permissionRepository repositories.PermissionRepository,
+	studentRepository repositories.StudentRepository,
+) *UserUseCase {
+	return &UserUseCase{
+		userRepository:            userRepository,
+		tokenRevocationRepository: tokenRevocationRepository,
+		loginThrottleRepository:   loginThrottleRepository,
+		permissionRepository:      permissionRepository,
+		studentRepository:         studentRepository,
+	}
+}
+
+func (uc *UserUseCase) ListUsers(ctx context.Context, filter repositories.UserFilter, page, pageSize int) ([]UserResponse, *common.PaginationMetadata, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+
+	users, err := uc.userRepository.ListUsers(ctx, filter, pageSize, offset)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot get users")
+	}
+
+	totalRecords, err := uc.userRepository.CountUsers(ctx, filter)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot count users")
+	}
+
+	responses := make([]UserResponse, 0, len(users))
+	for _, user := range users {
+		responses = append(responses, toUserResponse(user))
+	}
+
+	pagination := &common.PaginationMetadata{
+		Page:         page,
+		PageSize:     pageSize,
+		TotalRecords: int(totalRecords),
+		TotalPages:   int(math.Ceil(float64(totalRecords) / float64(pageSize))),
+	}
+
+	return responses, pagination, nil
+}
+
+// GetUser returns the user regardless of its status, soft-deleted users included.
+func (uc *UserUseCase) GetUser(ctx context.Context, id string) (UserResponse, error) {
+	user, err := uc.userRepository.GetUser(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return UserResponse{}, ErrUserNotFound
+		}
+		return UserResponse{}, errors.Wrap(err, "cannot get user")
+	}
+
+	return toUserResponse(user), nil
+}
+
+// CreateUser stores the email lowercased and trimmed, the same way the student import does,
+// so an email is unique regardless of its case.
+func (uc *UserUseCase) CreateUser(ctx context.Context, req CreateUserRequest) (UserResponse, error) {
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+
+	err := uc.ensureRoleExists(ctx, req.Role)
+	if err != nil {
+		return UserResponse{}, err
+	}
+
+	err = uc.ensureStudyProgramExists(ctx, req.StudyProgramID)
+	if err != nil {
+		return UserResponse{}, err
+	}
+
+	err = uc.ensureEmailAvailable(ctx, req.Email)
+	if err != nil {
+		return UserResponse{}, err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return UserResponse{}, errors.Wrap(err, "cannot hash password")
+	}
+
+	user, err := uc.userRepository.CreateUser(ctx, req.Email, string(hashedPassword), req.Name, req.Role, req.StudyProgramID)
+	if err != nil {
+		return UserResponse{}, errors.Wrap(err, "cannot create user")
+	}
+
+	return toUserResponse(user), nil
+}
+
+// UpdateUserRole changes the role of the user. The role is embedded in issued access tokens,
+// so all sessions of the user are revoked to make the change effective immediately.
+func (uc *UserUseCase) UpdateUserRole(ctx context.Context, actorID, id string, role int64) (UserResponse, error) {
+	if actorID == id {
+		return UserResponse{}, ErrCannotModifySelf
+	}
+
+	err := uc.ensureRoleExists(ctx, role)
+	if err != nil {
+		return UserResponse{}, err
+	}
+
+	user, err := uc.userRepository.UpdateUserRole(ctx, id, role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return UserResponse{}, ErrUserNotFound
+		}
+		return UserResponse{}, errors.Wrap(err, "cannot update user role")
+	}
+
+	err = uc.revokeSessions(ctx, id)
+	if err != nil {
+		return UserResponse{}, err
+	}
+
+	return toUserResponse(user), nil
+}
+
+// UpdateUserStudyProgram links the user to a study program, which limits the academic data a
+// koorprodi can manage to that study program. Unlike the role it is resolved per request,
+// so sessions are left untouched.
+func (uc *UserUseCase) UpdateUserStudyProgram(ctx context.Context, id, studyProgramID string) (UserResponse, error) {
+	err := uc.ensureStudyProgramExists(ctx, studyProgramID)
+	if err != nil {
+		return UserResponse{}, err
+	}
+
+	user, err := uc.userRepository.UpdateUserStudyProgram(ctx, id, studyProgramID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return UserResponse{}, ErrUserNotFound
+		}
+		return UserResponse{}, errors.Wrap(err, "cannot update user study program")
+	}
+
+	return toUserResponse(user), nil
+}
+
+// DisableUser blocks the user from logging in and revokes all of their sessions.
+func (uc *UserUseCase) DisableUser(ctx context.Context, actorID, id string) (UserResponse, error) {
+	if actorID == id {
+		return UserResponse{}, ErrCannotModifySelf
+	}
+
+	user, err := uc.userRepository.DisableUser(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return UserResponse{}, ErrUserNotFound
+		}
+		return UserResponse{}, errors.Wrap(err, "cannot disable user")
+	}
+
+	err = uc.revokeSessions(ctx, id)
+	if err != nil {
+		return UserResponse{}, err
+	}
+
+	return toUserResponse(user), nil
+}
+
+func (uc *UserUseCase) EnableUser(ctx context.Context, id string) (UserResponse, error) {
+	user, err := uc.userRepository.EnableUser(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return UserResponse{}, ErrUserNotFound
+		}
+		return UserResponse{}, errors.Wrap(err, "cannot enable user")
+	}
+
+	return toUserResponse(user), nil
+}
+
+// DeleteUser soft-deletes the user and revokes all of their sessions.
+func (uc *UserUseCase) DeleteUser(ctx context.Context, actorID, id string) error {
+	if actorID == id {
+		return ErrCannotModifySelf
+	}
+
+	_, err := uc.userRepository.DeleteUser(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return errors.Wrap(err, "cannot delete user")
+	}
+
+	return uc.revokeSessions(ctx, id)
+}
+
+// RestoreUser brings a soft-deleted user back, as long as no active account took over its email meanwhile.
+func (uc *UserUseCase) RestoreUser(ctx context.Context, id string) (UserResponse, error) {
+	user, err := uc.userRepository.GetUser(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return UserResponse{}, ErrUserNotFound
+		}
+		return UserResponse{}, errors.Wrap(err, "cannot get user")
+	}
+
+	if !user.DeletedAt.Valid {
+		return UserResponse{}, ErrUserNotDeleted
+	}
+
+	err = uc.ensureEmailAvailable(ctx, user.Email)
+	if err != nil {
+		return UserResponse{}, err
+	}
+
+	user, err = uc.userRepository.RestoreUser(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return UserResponse{}, ErrUserNotDeleted
+		}
+		return UserResponse{}, errors.Wrap(err, "cannot restore user")
+	}
+
+	return toUserResponse(user), nil
+}
+
+// UnlockUser lifts the login lockout of the user and clears their failed attempts.
+func (uc *UserUseCase) UnlockUser(ctx context.Context, id string) error {
+	user, err := uc.userRepository.GetUser(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return errors.Wrap(err, "cannot get user")
+	}
+
+	err = uc.loginThrottleRepository.DeleteLoginThrottle(ctx, repositories.LoginThrottleScopeAccount, repositories.LoginThrottleAccountKey(user.Email))
+	if err != nil {
+		return errors.Wrap(err, "cannot unlock user")
+	}
+
+	return nil
+}
+
+// UnlockIP lifts the login lockout of a client IP and clears its failed attempts.
+func (uc *UserUseCase) UnlockIP(ctx context.Context, ip string) error {
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return ErrInvalidIPAddress
+	}
+
+	err := uc.loginThrottleRepository.DeleteLoginThrottle(ctx, repositories.LoginThrottleScopeIP, parsedIP.String())
+	if err != nil {
+		return errors.Wrap(err, "cannot unlock ip")
+	}
+
+	return nil
+}
+
+func (uc *UserUseCase) ensureEmailAvailable(ctx context.Context, email string) error {
+	_, err := uc.userRepository.GetUserByEmail(ctx, email)
+	if err == nil {
+		return ErrEmailAlreadyUsed
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return errors.Wrap(err, "cannot check email availability")
+	}
+	return nil
+}
+
+func (uc *UserUseCase) ensureRoleExists(ctx context.Context, role int64) error {
+	_, err := uc.permissionRepository.GetRole(ctx, role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrUnknownRole
+		}
+		return errors.Wrap(err, "cannot get role")
+	}
+	return nil
+}
+
+func (uc *UserUseCase) ensureStudyProgramExists(ctx context.Context, studyProgramID string) error {
+	if studyProgramID == "" {
+		return nil
+	}
+
+	_, err := uc.studentRepository.GetStudyProgram(ctx, studyProgramID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrUnknownStudyProgram
+		}
+		return errors.Wrap(err, "cannot get study program")
+	}
+	return nil
+}
+
+func (uc *UserUseCase) revokeSessions(ctx context.Context, id string) error {
+	err := uc.userRepository.RevokeUserRefreshTokens(ctx, id)
+	if err != nil {
+		return errors.Wrap(err, "cannot revoke refresh tokens")
+	}
+
+	_, err = uc.tokenRevocationRepository.RevokeUserSessions(ctx, id)
+	if err != nil {
+		return errors.Wrap(err, "cannot revoke user sessions")
+	}
+
+	return nil
+}
+
+func toUserResponse(user generated.User) UserResponse {
+	response := UserResponse{
+		ID:    user.ID.String(),
+		Email: user.Email,
+		Name:  user.Name.String,
+		Role:  user.Role.Int.Int64(),
+	}
+
+	if user.CreatedAt.Valid {
+		response.CreatedAt = user.CreatedAt.Time
+	}
+	if user.StudyProgramID.Valid {
+		studyProgramID := user.StudyProgramID.String()
+		response.StudyProgramID = &studyProgramID
+	}
+	if user.DisabledAt.Valid {
+		disabledAt := user.DisabledAt.Time
+		response.DisabledAt = &disabledAt
+	}
+	if user.DeletedAt.Valid {
+		deletedAt := user.DeletedAt.Time
+		response.DeletedAt = &deletedAt
+	}
+
+	return response
+}
@@ -0,0 +1,193 @@
+package usecases
+
+import (
+	"context"
+	"math/big"
+	"siakad-poc/constants"
+	"siakad-poc/db/generated"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// Mock token revocation repository for the admin use cases
+type MockTokenRevocationRepository struct {
+	mock.Mock
+}
+
+func (m *MockTokenRevocationRepository) RevokeAccessToken(ctx context.Context, jti, userID string, expiresAt time.Time) error {
+	args := m.Called(ctx, jti, userID, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockTokenRevocationRepository) RevokeUserSessions(ctx context.Context, userID string) (generated.UserSessionRevocation, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(generated.UserSessionRevocation), args.Error(1)
+}
+
+func (m *MockTokenRevocationRepository) IsTokenRevoked(ctx context.Context, jti, userID string, issuedAt time.Time) (bool, error) {
+	args := m.Called(ctx, jti, userID, issuedAt)
+	return args.Bool(0), args.Error(1)
+}
+
+const (
+	adminActorID  = "550e8400-e29b-41d4-a716-446655440061"
+	managedUserID = "550e8400-e29b-41d4-a716-446655440062"
+	managedEmail  = "lecturer@example.com"
+)
+
+func testUUID(id string) pgtype.UUID {
+	var uuid pgtype.UUID
+	_ = uuid.Scan(id)
+	return uuid
+}
+
+// Test Suite
+type UserUseCaseTestSuite struct {
+	suite.Suite
+	useCase            *UserUseCase
+	mockUserRepo       *MockUserRepository
+	mockRevocationRepo *MockTokenRevocationRepository
+	ctx                context.Context
+}
+
+func (suite *UserUseCaseTestSuite) SetupTest() {
+	suite.mockUserRepo = new(MockUserRepository)
+	suite.mockRevocationRepo = new(MockTokenRevocationRepository)
+	suite.useCase = NewUserUseCase(suite.mockUserRepo, suite.mockRevocationRepo, nil, nil, nil)
+	suite.ctx = context.Background()
+}
+
+func (suite *UserUseCaseTestSuite) TearDownTest() {
+	suite.mockUserRepo.AssertExpectations(suite.T())
+	suite.mockRevocationRepo.AssertExpectations(suite.T())
+}
+
+// managedUser returns the user managed by the admin, disabled or deleted when the timestamps are set
+func (suite *UserUseCaseTestSuite) managedUser(disabledAt, deletedAt *time.Time) generated.User {
+	user := generated.User{
+		ID:    testUUID(managedUserID),
+		Email: managedEmail,
+		Role:  pgtype.Numeric{Int: big.NewInt(constants.RoleKoorprodi), Valid: true},
+	}
+	if disabledAt != nil {
+		user.DisabledAt = pgtype.Timestamptz{Time: *disabledAt, Valid: true}
+	}
+	if deletedAt != nil {
+		user.DeletedAt = pgtype.Timestamptz{Time: *deletedAt, Valid: true}
+	}
+	return user
+}
+
+// expectSessionsRevoked sets up the revocation of every refresh and access token of the managed user
+func (suite *UserUseCaseTestSuite) expectSessionsRevoked() {
+	suite.mockUserRepo.On("RevokeUserRefreshTokens", suite.ctx, managedUserID).Return(nil)
+	suite.mockRevocationRepo.On("RevokeUserSessions", suite.ctx, managedUserID).Return(generated.UserSessionRevocation{}, nil)
+}
+
+// Test disabling a user revokes all of their sessions
+func (suite *UserUseCaseTestSuite) TestDisableUser_Success() {
+	disabledAt := time.Now()
+	suite.mockUserRepo.On("DisableUser", suite.ctx, managedUserID).Return(suite.managedUser(&disabledAt, nil), nil)
+	suite.expectSessionsRevoked()
+
+	response, err := suite.useCase.DisableUser(suite.ctx, adminActorID, managedUserID)
+
+	assert.NoError(suite.T(), err)
+	suite.Require().NotNil(response.DisabledAt)
+	assert.Equal(suite.T(), disabledAt, *response.DisabledAt)
+	assert.Nil(suite.T(), response.DeletedAt)
+}
+
+// Test disabling an unknown or already deleted user
+func (suite *UserUseCaseTestSuite) TestDisableUser_NotFound() {
+	suite.mockUserRepo.On("DisableUser", suite.ctx, managedUserID).Return(generated.User{}, pgx.ErrNoRows)
+
+	_, err := suite.useCase.DisableUser(suite.ctx, adminActorID, managedUserID)
+
+	assert.True(suite.T(), errors.Is(err, ErrUserNotFound))
+	suite.mockRevocationRepo.AssertNotCalled(suite.T(), "RevokeUserSessions", mock.Anything, mock.Anything)
+}
+
+// Test an admin can't disable, delete or demote their own account
+func (suite *UserUseCaseTestSuite) TestSelfModification_Rejected() {
+	_, err := suite.useCase.DisableUser(suite.ctx, adminActorID, adminActorID)
+	assert.True(suite.T(), errors.Is(err, ErrCannotModifySelf))
+
+	err = suite.useCase.DeleteUser(suite.ctx, adminActorID, adminActorID)
+	assert.True(suite.T(), errors.Is(err, ErrCannotModifySelf))
+
+	_, err = suite.useCase.UpdateUserRole(suite.ctx, adminActorID, adminActorID, constants.RoleStudent)
+	assert.True(suite.T(), errors.Is(err, ErrCannotModifySelf))
+
+	suite.mockUserRepo.AssertNotCalled(suite.T(), "DisableUser", mock.Anything, mock.Anything)
+	suite.mockUserRepo.AssertNotCalled(suite.T(), "DeleteUser", mock.Anything, mock.Anything)
+	suite.mockUserRepo.AssertNotCalled(suite.T(), "UpdateUserRole", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Test soft-deleting a user revokes all of their sessions
+func (suite *UserUseCaseTestSuite) TestDeleteUser_Success() {
+	deletedAt := time.Now()
+	suite.mockUserRepo.On("DeleteUser", suite.ctx, managedUserID).Return(suite.managedUser(nil, &deletedAt), nil)
+	suite.expectSessionsRevoked()
+
+	err := suite.useCase.DeleteUser(suite.ctx, adminActorID, managedUserID)
+
+	assert.NoError(suite.T(), err)
+}
+
+// Test deleting an unknown or already deleted user
+func (suite *UserUseCaseTestSuite) TestDeleteUser_NotFound() {
+	suite.mockUserRepo.On("DeleteUser", suite.ctx, managedUserID).Return(generated.User{}, pgx.ErrNoRows)
+
+	err := suite.useCase.DeleteUser(suite.ctx, adminActorID, managedUserID)
+
+	assert.True(suite.T(), errors.Is(err, ErrUserNotFound))
+	suite.mockUserRepo.AssertNotCalled(suite.T(), "RevokeUserRefreshTokens", mock.Anything, mock.Anything)
+}
+
+// Test a soft-deleted user is restored when their email is still free
+func (suite *UserUseCaseTestSuite) TestRestoreUser_Success() {
+	deletedAt := time.Now().Add(-time.Hour)
+	suite.mockUserRepo.On("GetUser", suite.ctx, managedUserID).Return(suite.managedUser(nil, &deletedAt), nil)
+	suite.mockUserRepo.On("GetUserByEmail", suite.ctx, managedEmail).Return(generated.User{}, pgx.ErrNoRows)
+	suite.mockUserRepo.On("RestoreUser", suite.ctx, managedUserID).Return(suite.managedUser(nil, nil), nil)
+
+	response, err := suite.useCase.RestoreUser(suite.ctx, managedUserID)
+
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), response.DeletedAt)
+}
+
+// Test a user can't be restored once an active account took over their email
+func (suite *UserUseCaseTestSuite) TestRestoreUser_EmailTakenOver() {
+	deletedAt := time.Now().Add(-time.Hour)
+	suite.mockUserRepo.On("GetUser", suite.ctx, managedUserID).Return(suite.managedUser(nil, &deletedAt), nil)
+	suite.mockUserRepo.On("GetUserByEmail", suite.ctx, managedEmail).Return(generated.User{ID: testUUID(adminActorID), Email: managedEmail}, nil)
+
+	_, err := suite.useCase.RestoreUser(suite.ctx, managedUserID)
+
+	assert.True(suite.T(), errors.Is(err, ErrEmailAlreadyUsed))
+	suite.mockUserRepo.AssertNotCalled(suite.T(), "RestoreUser", mock.Anything, mock.Anything)
+}
+
+// Test restoring a user that isn't deleted
+func (suite *UserUseCaseTestSuite) TestRestoreUser_NotDeleted() {
+	suite.mockUserRepo.On("GetUser", suite.ctx, managedUserID).Return(suite.managedUser(nil, nil), nil)
+
+	_, err := suite.useCase.RestoreUser(suite.ctx, managedUserID)
+
+	assert.True(suite.T(), errors.Is(err, ErrUserNotDeleted))
+	suite.mockUserRepo.AssertNotCalled(suite.T(), "RestoreUser", mock.Anything, mock.Anything)
+}
+
+// Run the test suite
+func TestUserUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(UserUseCaseTestSuite))
+}
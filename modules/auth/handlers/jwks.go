@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"siakad-poc/common/jwtkeys"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type JWKSHandler struct {
+	keyring *jwtkeys.Keyring
+}
+
+func NewJWKSHandler(keyring *jwtkeys.Keyring) *JWKSHandler {
+	return &JWKSHandler{keyring: keyring}
+}
+
+// HandleJWKS publishes the public verification keys as a plain JWK set, without the usual response
+// envelope, so standard JWT libraries can consume it directly.
+func (h *JWKSHandler) HandleJWKS(c *fiber.Ctx) error {
+	// Verifiers may cache the keys briefly, a rotation has to keep the retired key published for longer
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return c.JSON(h.keyring.JWKS())
+}
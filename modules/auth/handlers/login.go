@@ -1,11 +1,14 @@
 package handlers
 
 import (
+	"math"
 	"siakad-poc/common"
 	"siakad-poc/modules/auth/usecases"
+	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 )
 
@@ -19,7 +22,19 @@ type LoginRequestData struct {
 }
 
 type LoginResponseData struct {
-	AccessToken string `json:"access_token"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+// MFAChallengeResponseData replaces the token pair when the login needs a second factor, the client
+// continues with POST /auth/login/mfa, or POST /auth/login/mfa/enroll when enrollment is required.
+type MFAChallengeResponseData struct {
+	MFARequired           bool   `json:"mfa_required"`
+	MFAEnrollmentRequired bool   `json:"mfa_enrollment_required"`
+	MFAToken              string `json:"mfa_token"`
+	ExpiresIn             int64  `json:"expires_in"`
 }
 
 func NewLoginHandler(usecase *usecases.LoginUseCase) *LoginHandler {
@@ -74,8 +89,54 @@ func (h *LoginHandler) HandleLogin(c *fiber.Ctx) error {
 		})
 	}
 
-	token, err := h.usecase.Login(c.Context(), loginRequest.Email, loginRequest.Password)
+	loginResult, err := h.usecase.Login(c.Context(), loginRequest.Email, loginRequest.Password, clientIP)
 	if err != nil {
+		var throttledErr *usecases.LoginThrottledError
+		if errors.As(err, &throttledErr) {
+			logEvent := log.Warn()
+			message := "Login throttled"
+			if throttledErr.LockoutStarted {
+				// Lockouts are logged at error level so they stand out from regular failed logins
+				logEvent = log.Error()
+				message = "Login lockout started"
+			}
+			logEvent.
+				Str("request_id", requestID).
+				Str("client_ip", clientIP).
+				Str("email", loginRequest.Email).
+				Str("throttle_scope", throttledErr.Scope).
+				Bool("locked", throttledErr.Locked).
+				Dur("retry_after", throttledErr.RetryAfter).
+				Str("path", c.OriginalURL()).
+				Msg(message)
+
+			return respondLoginThrottled(c, throttledErr)
+		}
+
+		if errors.Is(err, usecases.ErrInvalidCredentials) || errors.Is(err, usecases.ErrAccountDisabled) {
+			status := fiber.StatusUnauthorized
+			if errors.Is(err, usecases.ErrAccountDisabled) {
+				status = fiber.StatusForbidden
+			}
+
+			log.Warn().
+				Str("request_id", requestID).
+				Str("client_ip", clientIP).
+				Str("email", loginRequest.Email).
+				Str("path", c.OriginalURL()).
+				Msg("Login rejected")
+
+			return c.Status(status).JSON(common.BaseResponse[any]{
+				Status: common.StatusError,
+				Error: &common.BaseResponseError{
+					Message:   "Cannot proceed login",
+					Details:   []string{err.Error()},
+					Timestamp: time.Now().UTC().Format(time.RFC3339),
+					Path:      c.OriginalURL(),
+				},
+			})
+		}
+
 		log.Error().
 			Stack().
 			Err(err).
@@ -96,10 +157,51 @@ func (h *LoginHandler) HandleLogin(c *fiber.Ctx) error {
 		})
 	}
 
+	if challenge := loginResult.MFAChallenge; challenge != nil {
+		log.Info().
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("email", loginRequest.Email).
+			Bool("mfa_enrollment_required", challenge.EnrollmentRequired).
+			Str("path", c.OriginalURL()).
+			Msg("Login password step passed, second factor required")
+
+		return c.Status(fiber.StatusOK).JSON(common.BaseResponse[MFAChallengeResponseData]{
+			Status: common.StatusSuccess,
+			Data: &MFAChallengeResponseData{
+				MFARequired:           true,
+				MFAEnrollmentRequired: challenge.EnrollmentRequired,
+				MFAToken:              challenge.Token,
+				ExpiresIn:             challenge.ExpiresIn,
+			},
+		})
+	}
+
 	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[LoginResponseData]{
 		Status: common.StatusSuccess,
-		Data: &LoginResponseData{
-			AccessToken: token,
+		Data:   newLoginResponseData(loginResult.Tokens),
+	})
+}
+
+func newLoginResponseData(tokenPair usecases.TokenPair) *LoginResponseData {
+	return &LoginResponseData{
+		AccessToken:  tokenPair.AccessToken,
+		RefreshToken: tokenPair.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    tokenPair.ExpiresIn,
+	}
+}
+
+// respondLoginThrottled rejects a throttled login attempt with 429, Retry-After tells the client when to try again.
+func respondLoginThrottled(c *fiber.Ctx, throttledErr *usecases.LoginThrottledError) error {
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(throttledErr.RetryAfter.Seconds()))))
+	return c.Status(fiber.StatusTooManyRequests).JSON(common.BaseResponse[any]{
+		Status: common.StatusError,
+		Error: &common.BaseResponseError{
+			Message:   "Too many failed login attempts",
+			Details:   []string{throttledErr.Error()},
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Path:      c.OriginalURL(),
 		},
 	})
 }
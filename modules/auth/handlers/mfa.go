@@ -0,0 +1,394 @@
+package handlers
+
+import (
+	"siakad-poc/common"
+	"siakad-poc/middlewares"
+	"siakad-poc/modules/auth/usecases"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type MFAHandler struct {
+	usecase *usecases.MFAUseCase
+}
+
+type MFALoginRequestData struct {
+	MFAToken     string `json:"mfa_token" validate:"required"`
+	Code         string `json:"code" validate:"omitempty,len=6,numeric"`
+	RecoveryCode string `json:"recovery_code" validate:"omitempty,max=16"`
+}
+
+type MFALoginEnrollRequestData struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+}
+
+type MFALoginEnrollConfirmRequestData struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+	Code     string `json:"code" validate:"required,len=6,numeric"`
+}
+
+type MFACodeRequestData struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+type MFARecoveryCodesResponseData struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type MFAEnrollmentLoginResponseData struct {
+	LoginResponseData
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func NewMFAHandler(usecase *usecases.MFAUseCase) *MFAHandler {
+	return &MFAHandler{usecase: usecase}
+}
+
+// HandleLoginMFA completes a login with a TOTP code or, when the device is lost, a recovery code.
+func (h *MFAHandler) HandleLoginMFA(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	var loginRequest MFALoginRequestData
+	if handled, err := parseMFARequest(c, &loginRequest, "second login step"); handled {
+		return err
+	}
+
+	if (loginRequest.Code == "") == (loginRequest.RecoveryCode == "") {
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Validation failed",
+				Details:   []string{"exactly one of code or recovery_code is required"},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	tokenPair, err := h.usecase.CompleteLogin(c.Context(), loginRequest.MFAToken, loginRequest.Code, loginRequest.RecoveryCode, clientIP)
+	if err != nil {
+		return respondMFAError(c, requestID, clientIP, "", "Cannot proceed login", err, true)
+	}
+
+	if loginRequest.RecoveryCode != "" {
+		log.Warn().
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("path", c.OriginalURL()).
+			Msg("Login completed with a recovery code")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[LoginResponseData]{
+		Status: common.StatusSuccess,
+		Data:   newLoginResponseData(tokenPair),
+	})
+}
+
+// HandleLoginMFAEnroll starts the mandatory TOTP enrollment of a login that returned mfa_enrollment_required.
+func (h *MFAHandler) HandleLoginMFAEnroll(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	var enrollRequest MFALoginEnrollRequestData
+	if handled, err := parseMFARequest(c, &enrollRequest, "mfa enrollment"); handled {
+		return err
+	}
+
+	enrollment, err := h.usecase.StartLoginEnrollment(c.Context(), enrollRequest.MFAToken)
+	if err != nil {
+		return respondMFAError(c, requestID, clientIP, "", "Cannot start mfa enrollment", err, true)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.MFAEnrollment]{
+		Status: common.StatusSuccess,
+		Data:   &enrollment,
+	})
+}
+
+// HandleLoginMFAEnrollConfirm confirms the mandatory enrollment with a first code and completes the login.
+func (h *MFAHandler) HandleLoginMFAEnrollConfirm(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	var confirmRequest MFALoginEnrollConfirmRequestData
+	if handled, err := parseMFARequest(c, &confirmRequest, "mfa enrollment confirmation"); handled {
+		return err
+	}
+
+	tokenPair, recoveryCodes, err := h.usecase.ConfirmLoginEnrollment(c.Context(), confirmRequest.MFAToken, confirmRequest.Code, clientIP)
+	if err != nil {
+		return respondMFAError(c, requestID, clientIP, "", "Cannot confirm mfa enrollment", err, true)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("path", c.OriginalURL()).
+		Msg("MFA enrolled during login")
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[MFAEnrollmentLoginResponseData]{
+		Status: common.StatusSuccess,
+		Data: &MFAEnrollmentLoginResponseData{
+			LoginResponseData: *newLoginResponseData(tokenPair),
+			RecoveryCodes:     recoveryCodes,
+		},
+	})
+}
+
+func (h *MFAHandler) HandleGetMFAStatus(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	userID, _ := c.Locals(middlewares.UserIDKey).(string)
+
+	status, err := h.usecase.GetStatus(c.Context(), userID)
+	if err != nil {
+		return respondMFAError(c, requestID, clientIP, userID, "Cannot get mfa status", err, false)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.MFAStatus]{
+		Status: common.StatusSuccess,
+		Data:   &status,
+	})
+}
+
+func (h *MFAHandler) HandleStartEnrollment(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	userID, _ := c.Locals(middlewares.UserIDKey).(string)
+
+	enrollment, err := h.usecase.StartEnrollment(c.Context(), userID)
+	if err != nil {
+		return respondMFAError(c, requestID, clientIP, userID, "Cannot start mfa enrollment", err, false)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.MFAEnrollment]{
+		Status: common.StatusSuccess,
+		Data:   &enrollment,
+	})
+}
+
+func (h *MFAHandler) HandleConfirmEnrollment(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	userID, _ := c.Locals(middlewares.UserIDKey).(string)
+
+	var codeRequest MFACodeRequestData
+	if handled, err := parseMFARequest(c, &codeRequest, "mfa enrollment confirmation"); handled {
+		return err
+	}
+
+	recoveryCodes, err := h.usecase.ConfirmEnrollment(c.Context(), userID, codeRequest.Code)
+	if err != nil {
+		return respondMFAError(c, requestID, clientIP, userID, "Cannot confirm mfa enrollment", err, false)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("user_id", userID).
+		Str("path", c.OriginalURL()).
+		Msg("MFA enabled")
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[MFARecoveryCodesResponseData]{
+		Status: common.StatusSuccess,
+		Data:   &MFARecoveryCodesResponseData{RecoveryCodes: recoveryCodes},
+	})
+}
+
+func (h *MFAHandler) HandleRegenerateRecoveryCodes(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	userID, _ := c.Locals(middlewares.UserIDKey).(string)
+
+	var codeRequest MFACodeRequestData
+	if handled, err := parseMFARequest(c, &codeRequest, "recovery codes"); handled {
+		return err
+	}
+
+	recoveryCodes, err := h.usecase.RegenerateRecoveryCodes(c.Context(), userID, codeRequest.Code)
+	if err != nil {
+		return respondMFAError(c, requestID, clientIP, userID, "Cannot regenerate recovery codes", err, false)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("user_id", userID).
+		Str("path", c.OriginalURL()).
+		Msg("MFA recovery codes regenerated")
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[MFARecoveryCodesResponseData]{
+		Status: common.StatusSuccess,
+		Data:   &MFARecoveryCodesResponseData{RecoveryCodes: recoveryCodes},
+	})
+}
+
+func (h *MFAHandler) HandleDisable(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	userID, _ := c.Locals(middlewares.UserIDKey).(string)
+
+	var codeRequest MFACodeRequestData
+	if handled, err := parseMFARequest(c, &codeRequest, "disable mfa"); handled {
+		return err
+	}
+
+	err := h.usecase.Disable(c.Context(), userID, codeRequest.Code)
+	if err != nil {
+		return respondMFAError(c, requestID, clientIP, userID, "Cannot disable mfa", err, false)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("user_id", userID).
+		Str("path", c.OriginalURL()).
+		Msg("MFA disabled")
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// HandleResetUserMFA lets an admin remove the MFA of a user who lost their device.
+func (h *MFAHandler) HandleResetUserMFA(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	userID := c.Params("id")
+	adminID, _ := c.Locals(middlewares.UserIDKey).(string)
+
+	err := h.usecase.ResetUserMFA(c.Context(), userID)
+	if err != nil {
+		return respondMFAError(c, requestID, clientIP, userID, "Cannot reset mfa", err, false)
+	}
+
+	log.Warn().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("user_id", userID).
+		Str("reset_by", adminID).
+		Str("path", c.OriginalURL()).
+		Msg("MFA reset by admin")
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// parseMFARequest parses and validates the request body, it reports whether an error response was sent.
+func parseMFARequest(c *fiber.Ctx, data any, requestName string) (bool, error) {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	if err := c.BodyParser(data); err != nil {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("path", c.OriginalURL()).
+			Str("method", c.Method()).
+			Msgf("Failed to parse %s request body", requestName)
+
+		return true, c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Cannot parse " + requestName + " request body",
+				Details:   []string{err.Error()},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	if validationErrors := common.ValidateStruct(data); validationErrors != nil {
+		log.Warn().
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Strs("validation_errors", validationErrors).
+			Str("path", c.OriginalURL()).
+			Msgf("%s validation failed", requestName)
+
+		return true, c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Validation failed",
+				Details:   validationErrors,
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	return false, nil
+}
+
+// respondMFAError maps MFA errors to HTTP status codes. A wrong code is a 401 during login and a 422 for
+// an authenticated user, whose session itself is still valid.
+func respondMFAError(c *fiber.Ctx, requestID, clientIP, userID, message string, err error, loginStep bool) error {
+	var throttledErr *usecases.LoginThrottledError
+	if errors.As(err, &throttledErr) {
+		logEvent := log.Warn()
+		logMessage := "MFA login step throttled"
+		if throttledErr.LockoutStarted {
+			logEvent = log.Error()
+			logMessage = "Login lockout started"
+		}
+		logEvent.
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("throttle_scope", throttledErr.Scope).
+			Bool("locked", throttledErr.Locked).
+			Dur("retry_after", throttledErr.RetryAfter).
+			Str("path", c.OriginalURL()).
+			Msg(logMessage)
+
+		return respondLoginThrottled(c, throttledErr)
+	}
+
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, usecases.ErrInvalidMFAToken):
+		status = fiber.StatusUnauthorized
+	case errors.Is(err, usecases.ErrInvalidMFACode):
+		status = fiber.StatusUnprocessableEntity
+		if loginStep {
+			status = fiber.StatusUnauthorized
+		}
+	case errors.Is(err, usecases.ErrMFAAlreadyEnabled), errors.Is(err, usecases.ErrMFANotEnabled), errors.Is(err, usecases.ErrMFAEnrollmentRequired):
+		status = fiber.StatusConflict
+	case errors.Is(err, usecases.ErrMFARequired):
+		status = fiber.StatusForbidden
+	case errors.Is(err, usecases.ErrUserNotFound):
+		status = fiber.StatusNotFound
+	}
+
+	if status == fiber.StatusInternalServerError {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("user_id", userID).
+			Str("path", c.OriginalURL()).
+			Msg(message)
+	} else {
+		log.Warn().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("user_id", userID).
+			Str("path", c.OriginalURL()).
+			Msg(message)
+	}
+
+	return c.Status(status).JSON(common.BaseResponse[any]{
+		Status: common.StatusError,
+		Error: &common.BaseResponseError{
+			Message:   message,
+			Details:   []string{err.Error()},
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Path:      c.OriginalURL(),
+		},
+	})
+}
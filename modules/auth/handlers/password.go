@@ -0,0 +1,334 @@
+package handlers
+
+import (
+	"siakad-poc/common"
+	"siakad-poc/middlewares"
+	"siakad-poc/modules/auth/usecases"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type PasswordHandler struct {
+	usecase *usecases.PasswordUseCase
+}
+
+type ChangePasswordRequestData struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=6,max=72"`
+	ConfirmPassword string `json:"confirm_password" validate:"required,eqfield=NewPassword"`
+}
+
+type ResetPasswordRequestData struct {
+	Token           string `json:"token" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=6,max=72"`
+	ConfirmPassword string `json:"confirm_password" validate:"required,eqfield=NewPassword"`
+}
+
+type PasswordResetTokenResponseData struct {
+	ResetToken string    `json:"reset_token"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+func NewPasswordHandler(usecase *usecases.PasswordUseCase) *PasswordHandler {
+	return &PasswordHandler{usecase: usecase}
+}
+
+func (h *PasswordHandler) HandleChangePassword(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	var changeRequest ChangePasswordRequestData
+	err := c.BodyParser(&changeRequest)
+	if err != nil {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("path", c.OriginalURL()).
+			Str("method", c.Method()).
+			Msg("Failed to parse change password request body")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Cannot parse change password request body",
+				Details:   []string{err.Error()},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	if validationErrors := common.ValidateStruct(&changeRequest); validationErrors != nil {
+		log.Warn().
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Strs("validation_errors", validationErrors).
+			Str("path", c.OriginalURL()).
+			Msg("Change password validation failed")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Validation failed",
+				Details:   validationErrors,
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	userID, _ := c.Locals(middlewares.UserIDKey).(string)
+
+	err = h.usecase.ChangePassword(c.Context(), userID, changeRequest.CurrentPassword, changeRequest.NewPassword)
+	if err != nil {
+		if errors.Is(err, usecases.ErrInvalidCurrentPassword) {
+			log.Warn().
+				Str("request_id", requestID).
+				Str("client_ip", clientIP).
+				Str("user_id", userID).
+				Str("path", c.OriginalURL()).
+				Msg("Password change rejected, current password mismatch")
+
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(common.BaseResponse[any]{
+				Status: common.StatusError,
+				Error: &common.BaseResponseError{
+					Message:   "Cannot change password",
+					Details:   []string{err.Error()},
+					Timestamp: time.Now().UTC().Format(time.RFC3339),
+					Path:      c.OriginalURL(),
+				},
+			})
+		}
+
+		if errors.Is(err, usecases.ErrUserNotFound) {
+			log.Warn().
+				Str("request_id", requestID).
+				Str("client_ip", clientIP).
+				Str("user_id", userID).
+				Str("path", c.OriginalURL()).
+				Msg("User not found for password change")
+
+			return c.Status(fiber.StatusNotFound).JSON(common.BaseResponse[any]{
+				Status: common.StatusError,
+				Error: &common.BaseResponseError{
+					Message:   "User not found",
+					Details:   []string{err.Error()},
+					Timestamp: time.Now().UTC().Format(time.RFC3339),
+					Path:      c.OriginalURL(),
+				},
+			})
+		}
+
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("user_id", userID).
+			Str("path", c.OriginalURL()).
+			Msg("Password change failed")
+
+		return c.Status(fiber.StatusInternalServerError).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Cannot change password",
+				Details:   []string{err.Error()},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("user_id", userID).
+		Str("path", c.OriginalURL()).
+		Msg("Password changed, all sessions revoked")
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *PasswordHandler) HandleIssuePasswordResetToken(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	userID := c.Params("id")
+	if userID == "" {
+		log.Warn().
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("path", c.OriginalURL()).
+			Str("method", c.Method()).
+			Msg("User ID missing from URL parameter")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "User ID is required",
+				Details:   []string{"ID parameter is missing"},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	adminID, _ := c.Locals(middlewares.UserIDKey).(string)
+
+	resetToken, err := h.usecase.IssuePasswordResetToken(c.Context(), userID, adminID)
+	if err != nil {
+		if errors.Is(err, usecases.ErrUserNotFound) {
+			log.Warn().
+				Str("request_id", requestID).
+				Str("client_ip", clientIP).
+				Str("user_id", userID).
+				Str("path", c.OriginalURL()).
+				Msg("User not found for password reset")
+
+			return c.Status(fiber.StatusNotFound).JSON(common.BaseResponse[any]{
+				Status: common.StatusError,
+				Error: &common.BaseResponseError{
+					Message:   "User not found",
+					Details:   []string{err.Error()},
+					Timestamp: time.Now().UTC().Format(time.RFC3339),
+					Path:      c.OriginalURL(),
+				},
+			})
+		}
+
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("user_id", userID).
+			Str("path", c.OriginalURL()).
+			Msg("Failed to issue password reset token")
+
+		return c.Status(fiber.StatusInternalServerError).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Failed to issue password reset token",
+				Details:   []string{err.Error()},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("user_id", userID).
+		Str("issued_by", adminID).
+		Time("expires_at", resetToken.ExpiresAt).
+		Str("path", c.OriginalURL()).
+		Msg("Password reset token issued")
+
+	return c.Status(fiber.StatusCreated).JSON(common.BaseResponse[PasswordResetTokenResponseData]{
+		Status: common.StatusSuccess,
+		Data: &PasswordResetTokenResponseData{
+			ResetToken: resetToken.Token,
+			ExpiresAt:  resetToken.ExpiresAt.UTC(),
+		},
+	})
+}
+
+func (h *PasswordHandler) HandleResetPassword(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	var resetRequest ResetPasswordRequestData
+	err := c.BodyParser(&resetRequest)
+	if err != nil {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("path", c.OriginalURL()).
+			Str("method", c.Method()).
+			Msg("Failed to parse reset password request body")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Cannot parse reset password request body",
+				Details:   []string{err.Error()},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	if validationErrors := common.ValidateStruct(&resetRequest); validationErrors != nil {
+		log.Warn().
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Strs("validation_errors", validationErrors).
+			Str("path", c.OriginalURL()).
+			Msg("Reset password validation failed")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Validation failed",
+				Details:   validationErrors,
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	err = h.usecase.ResetPassword(c.Context(), resetRequest.Token, resetRequest.NewPassword)
+	if err != nil {
+		if errors.Is(err, usecases.ErrInvalidPasswordResetToken) {
+			log.Warn().
+				Str("request_id", requestID).
+				Str("client_ip", clientIP).
+				Str("path", c.OriginalURL()).
+				Msg("Invalid password reset token presented")
+
+			return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+				Status: common.StatusError,
+				Error: &common.BaseResponseError{
+					Message:   "Cannot reset password",
+					Details:   []string{err.Error()},
+					Timestamp: time.Now().UTC().Format(time.RFC3339),
+					Path:      c.OriginalURL(),
+				},
+			})
+		}
+
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("path", c.OriginalURL()).
+			Msg("Password reset failed")
+
+		return c.Status(fiber.StatusInternalServerError).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Cannot reset password",
+				Details:   []string{err.Error()},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("path", c.OriginalURL()).
+		Msg("Password reset completed, all sessions revoked")
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"siakad-poc/common"
+	"siakad-poc/middlewares"
+	"siakad-poc/modules/auth/usecases"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type ProfileHandler struct {
+	usecase *usecases.ProfileUseCase
+}
+
+func NewProfileHandler(usecase *usecases.ProfileUseCase) *ProfileHandler {
+	return &ProfileHandler{usecase: usecase}
+}
+
+func (h *ProfileHandler) HandleGetProfile(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	userID, _ := c.Locals(middlewares.UserIDKey).(string)
+
+	profile, err := h.usecase.GetProfile(c.Context(), userID)
+	if err != nil {
+		// The account can be deleted while one of its tokens is still valid
+		if errors.Is(err, usecases.ErrUserNotFound) {
+			log.Warn().
+				Str("request_id", requestID).
+				Str("client_ip", clientIP).
+				Str("user_id", userID).
+				Str("path", c.OriginalURL()).
+				Msg("Profile requested for an unknown user")
+
+			return c.Status(fiber.StatusNotFound).JSON(common.BaseResponse[any]{
+				Status: common.StatusError,
+				Error: &common.BaseResponseError{
+					Message:   "User not found",
+					Details:   []string{err.Error()},
+					Timestamp: time.Now().UTC().Format(time.RFC3339),
+					Path:      c.OriginalURL(),
+				},
+			})
+		}
+
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("user_id", userID).
+			Str("path", c.OriginalURL()).
+			Msg("Failed to get profile")
+
+		return c.Status(fiber.StatusInternalServerError).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Cannot get profile",
+				Details:   []string{err.Error()},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.ProfileResponse]{
+		Status: common.StatusSuccess,
+		Data:   &profile,
+	})
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"siakad-poc/common"
+	"siakad-poc/modules/auth/usecases"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type RefreshTokenHandler struct {
+	usecase *usecases.RefreshTokenUseCase
+}
+
+type RefreshTokenRequestData struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+func NewRefreshTokenHandler(usecase *usecases.RefreshTokenUseCase) *RefreshTokenHandler {
+	return &RefreshTokenHandler{usecase: usecase}
+}
+
+func (h *RefreshTokenHandler) HandleRefresh(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	var refreshRequest RefreshTokenRequestData
+	err := c.BodyParser(&refreshRequest)
+	if err != nil {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("path", c.OriginalURL()).
+			Str("method", c.Method()).
+			Msg("Failed to parse refresh token request body")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Cannot parse refresh token request body",
+				Details:   []string{err.Error()},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	if validationErrors := common.ValidateStruct(&refreshRequest); validationErrors != nil {
+		log.Warn().
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Strs("validation_errors", validationErrors).
+			Str("path", c.OriginalURL()).
+			Msg("Refresh token validation failed")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Validation failed",
+				Details:   validationErrors,
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	tokenPair, err := h.usecase.Refresh(c.Context(), refreshRequest.RefreshToken)
+	if err != nil {
+		if errors.Is(err, usecases.ErrRefreshTokenReused) {
+			log.Warn().
+				Str("request_id", requestID).
+				Str("client_ip", clientIP).
+				Str("path", c.OriginalURL()).
+				Msg("Refresh token reuse detected, token family revoked")
+
+			return c.Status(fiber.StatusUnauthorized).JSON(common.BaseResponse[any]{
+				Status: common.StatusError,
+				Error: &common.BaseResponseError{
+					Message:   "Refresh token is no longer valid",
+					Details:   []string{err.Error(), "all sessions started from this login have been revoked, please log in again"},
+					Timestamp: time.Now().UTC().Format(time.RFC3339),
+					Path:      c.OriginalURL(),
+				},
+			})
+		}
+
+		if errors.Is(err, usecases.ErrInvalidRefreshToken) {
+			log.Warn().
+				Str("request_id", requestID).
+				Str("client_ip", clientIP).
+				Str("path", c.OriginalURL()).
+				Msg("Invalid refresh token presented")
+
+			return c.Status(fiber.StatusUnauthorized).JSON(common.BaseResponse[any]{
+				Status: common.StatusError,
+				Error: &common.BaseResponseError{
+					Message:   "Refresh token is no longer valid",
+					Details:   []string{err.Error()},
+					Timestamp: time.Now().UTC().Format(time.RFC3339),
+					Path:      c.OriginalURL(),
+				},
+			})
+		}
+
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("path", c.OriginalURL()).
+			Msg("Token refresh failed")
+
+		return c.Status(fiber.StatusInternalServerError).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Cannot refresh token",
+				Details:   []string{err.Error()},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[LoginResponseData]{
+		Status: common.StatusSuccess,
+		Data: &LoginResponseData{
+			AccessToken:  tokenPair.AccessToken,
+			RefreshToken: tokenPair.RefreshToken,
+			TokenType:    "Bearer",
+			ExpiresIn:    tokenPair.ExpiresIn,
+		},
+	})
+}
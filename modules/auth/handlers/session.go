@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"siakad-poc/common"
+	"siakad-poc/middlewares"
+	"siakad-poc/modules/auth/usecases"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type SessionHandler struct {
+	usecase *usecases.SessionUseCase
+}
+
+type LogoutRequestData struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+func NewSessionHandler(usecase *usecases.SessionUseCase) *SessionHandler {
+	return &SessionHandler{usecase: usecase}
+}
+
+func (h *SessionHandler) HandleLogout(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	// The refresh token is optional, clients that only hold an access token can still log out
+	var logoutRequest LogoutRequestData
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&logoutRequest); err != nil {
+			log.Error().
+				Stack().
+				Err(err).
+				Str("request_id", requestID).
+				Str("client_ip", clientIP).
+				Str("path", c.OriginalURL()).
+				Str("method", c.Method()).
+				Msg("Failed to parse logout request body")
+
+			return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+				Status: common.StatusError,
+				Error: &common.BaseResponseError{
+					Message:   "Cannot parse logout request body",
+					Details:   []string{err.Error()},
+					Timestamp: time.Now().UTC().Format(time.RFC3339),
+					Path:      c.OriginalURL(),
+				},
+			})
+		}
+	}
+
+	userID, _ := c.Locals(middlewares.UserIDKey).(string)
+	tokenID, _ := c.Locals(middlewares.TokenIDKey).(string)
+	tokenExpiresAt, _ := c.Locals(middlewares.TokenExpiresAtKey).(time.Time)
+
+	err := h.usecase.Logout(c.Context(), userID, tokenID, tokenExpiresAt, logoutRequest.RefreshToken)
+	if err != nil {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("user_id", userID).
+			Str("path", c.OriginalURL()).
+			Msg("Logout failed")
+
+		return c.Status(fiber.StatusInternalServerError).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Cannot proceed logout",
+				Details:   []string{err.Error()},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("user_id", userID).
+		Str("path", c.OriginalURL()).
+		Msg("User logged out")
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *SessionHandler) HandleRevokeUserSessions(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	userID := c.Params("id")
+	if userID == "" {
+		log.Warn().
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("path", c.OriginalURL()).
+			Str("method", c.Method()).
+			Msg("User ID missing from URL parameter")
+
+		return c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "User ID is required",
+				Details:   []string{"ID parameter is missing"},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	adminID, _ := c.Locals(middlewares.UserIDKey).(string)
+
+	err := h.usecase.RevokeAllUserSessions(c.Context(), userID)
+	if err != nil {
+		if errors.Is(err, usecases.ErrUserNotFound) {
+			log.Warn().
+				Str("request_id", requestID).
+				Str("client_ip", clientIP).
+				Str("user_id", userID).
+				Str("path", c.OriginalURL()).
+				Msg("User not found for session revocation")
+
+			return c.Status(fiber.StatusNotFound).JSON(common.BaseResponse[any]{
+				Status: common.StatusError,
+				Error: &common.BaseResponseError{
+					Message:   "User not found",
+					Details:   []string{err.Error()},
+					Timestamp: time.Now().UTC().Format(time.RFC3339),
+					Path:      c.OriginalURL(),
+				},
+			})
+		}
+
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("user_id", userID).
+			Str("path", c.OriginalURL()).
+			Msg("Failed to revoke user sessions")
+
+		return c.Status(fiber.StatusInternalServerError).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Failed to revoke user sessions",
+				Details:   []string{err.Error()},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("user_id", userID).
+		Str("revoked_by", adminID).
+		Str("path", c.OriginalURL()).
+		Msg("All user sessions revoked")
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -1,7 +1,12 @@
 package auth
 
 import (
+	"siakad-poc/common"
+	"siakad-poc/common/jwtkeys"
+	"siakad-poc/config"
+	"siakad-poc/constants"
 	"siakad-poc/db/repositories"
+	"siakad-poc/middlewares"
 	"siakad-poc/modules"
 	"siakad-poc/modules/auth/handlers"
 	"siakad-poc/modules/auth/usecases"
@@ -11,28 +16,123 @@ import (
 )
 
 type AuthModule struct {
-	userRepository repositories.UserRepository
-	loginUseCase   *usecases.LoginUseCase
-	loginHandler   *handlers.LoginHandler
+	userRepository            repositories.UserRepository
+	tokenRevocationRepository repositories.TokenRevocationRepository
+	permissionRepository      repositories.PermissionRepository
+	keyring                   *jwtkeys.Keyring
+	loginUseCase              *usecases.LoginUseCase
+	refreshTokenUseCase       *usecases.RefreshTokenUseCase
+	sessionUseCase            *usecases.SessionUseCase
+	passwordUseCase           *usecases.PasswordUseCase
+	mfaUseCase                *usecases.MFAUseCase
+	profileUseCase            *usecases.ProfileUseCase
+	loginHandler              *handlers.LoginHandler
+	refreshTokenHandler       *handlers.RefreshTokenHandler
+	sessionHandler            *handlers.SessionHandler
+	passwordHandler           *handlers.PasswordHandler
+	jwksHandler               *handlers.JWKSHandler
+	mfaHandler                *handlers.MFAHandler
+	profileHandler            *handlers.ProfileHandler
 }
 
 // Compile time interface conformance check
 var _ modules.RoutableModule = (*AuthModule)(nil)
 
-func NewModule(pool *pgxpool.Pool) *AuthModule {
+func NewModule(pool *pgxpool.Pool, tokenRevocationRepository repositories.TokenRevocationRepository, permissionRepository repositories.PermissionRepository, keyring *jwtkeys.Keyring) *AuthModule {
+	txExecutor := common.NewPgxTransactionExecutor(pool)
 	usersRepository := repositories.NewDefaultUserRepository(pool)
+	loginThrottleRepository := repositories.NewDefaultLoginThrottleRepository(pool)
+	mfaRepository := repositories.NewDefaultMFARepository(pool)
+	studentRepository := repositories.NewDefaultStudentRepository(pool)
+	lecturerRepository := repositories.NewDefaultLecturerRepository(pool)
+
+	loginUseCase := usecases.NewLoginUseCase(usersRepository, mfaRepository, loginThrottleRepository, keyring, config.CurrentConfig.Auth)
+	refreshTokenUseCase := usecases.NewRefreshTokenUseCase(usersRepository, txExecutor, keyring)
+	sessionUseCase := usecases.NewSessionUseCase(usersRepository, tokenRevocationRepository)
+	passwordUseCase := usecases.NewPasswordUseCase(usersRepository, tokenRevocationRepository, txExecutor)
+	mfaUseCase := usecases.NewMFAUseCase(usersRepository, mfaRepository, tokenRevocationRepository, loginThrottleRepository, txExecutor, keyring, config.CurrentConfig.Auth)
+	profileUseCase := usecases.NewProfileUseCase(usersRepository, studentRepository, lecturerRepository)
 
-	loginUseCase := usecases.NewLoginUseCase(usersRepository)
 	loginHandler := handlers.NewLoginHandler(loginUseCase)
+	refreshTokenHandler := handlers.NewRefreshTokenHandler(refreshTokenUseCase)
+	sessionHandler := handlers.NewSessionHandler(sessionUseCase)
+	passwordHandler := handlers.NewPasswordHandler(passwordUseCase)
+	jwksHandler := handlers.NewJWKSHandler(keyring)
+	mfaHandler := handlers.NewMFAHandler(mfaUseCase)
+	profileHandler := handlers.NewProfileHandler(profileUseCase)
 
 	return &AuthModule{
-		userRepository: usersRepository,
-		loginUseCase:   loginUseCase,
-		loginHandler:   loginHandler,
+		userRepository:            usersRepository,
+		tokenRevocationRepository: tokenRevocationRepository,
+		permissionRepository:      permissionRepository,
+		keyring:                   keyring,
+		loginUseCase:              loginUseCase,
+		refreshTokenUseCase:       refreshTokenUseCase,
+		sessionUseCase:            sessionUseCase,
+		passwordUseCase:           passwordUseCase,
+		mfaUseCase:                mfaUseCase,
+		profileUseCase:            profileUseCase,
+		loginHandler:              loginHandler,
+		refreshTokenHandler:       refreshTokenHandler,
+		sessionHandler:            sessionHandler,
+		passwordHandler:           passwordHandler,
+		jwksHandler:               jwksHandler,
+		mfaHandler:                mfaHandler,
+		profileHandler:            profileHandler,
 	}
 }
 
 func (m *AuthModule) SetupRoutes(fiberApp *fiber.App, prefix string) {
 	authRoutes := fiberApp.Group(prefix)
 	authRoutes.Post("/login", m.loginHandler.HandleLogin)
+	authRoutes.Post("/login/mfa", m.mfaHandler.HandleLoginMFA)
+	authRoutes.Post("/login/mfa/enroll", m.mfaHandler.HandleLoginMFAEnroll)
+	authRoutes.Post("/login/mfa/enroll/confirm", m.mfaHandler.HandleLoginMFAEnrollConfirm)
+	authRoutes.Post("/refresh", m.refreshTokenHandler.HandleRefresh)
+	authRoutes.Post(
+		"/logout",
+		middlewares.JWT(m.keyring, m.tokenRevocationRepository),
+		m.sessionHandler.HandleLogout,
+	)
+	authRoutes.Post(
+		"/password",
+		middlewares.JWT(m.keyring, m.tokenRevocationRepository),
+		m.passwordHandler.HandleChangePassword,
+	)
+	authRoutes.Post("/password/reset", m.passwordHandler.HandleResetPassword)
+	authRoutes.Get(
+		"/me",
+		middlewares.JWT(m.keyring, m.tokenRevocationRepository),
+		m.profileHandler.HandleGetProfile,
+	)
+
+	// Two-factor authentication of the current user
+	mfaRoutes := authRoutes.Group("/mfa", middlewares.JWT(m.keyring, m.tokenRevocationRepository))
+	mfaRoutes.Get("/", m.mfaHandler.HandleGetMFAStatus)
+	mfaRoutes.Post("/totp", m.mfaHandler.HandleStartEnrollment)
+	mfaRoutes.Post("/totp/confirm", m.mfaHandler.HandleConfirmEnrollment)
+	mfaRoutes.Delete("/totp", m.mfaHandler.HandleDisable)
+	mfaRoutes.Post("/recovery-codes", m.mfaHandler.HandleRegenerateRecoveryCodes)
+
+	authRoutes.Get("/.well-known/jwks.json", m.jwksHandler.HandleJWKS)
+
+	// Session and credential administration
+	authRoutes.Delete(
+		"/users/:id/sessions",
+		middlewares.JWT(m.keyring, m.tokenRevocationRepository),
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionSessionRevoke),
+		m.sessionHandler.HandleRevokeUserSessions,
+	)
+	authRoutes.Post(
+		"/users/:id/password-reset",
+		middlewares.JWT(m.keyring, m.tokenRevocationRepository),
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionPasswordResetIssue),
+		m.passwordHandler.HandleIssuePasswordResetToken,
+	)
+	authRoutes.Delete(
+		"/users/:id/mfa",
+		middlewares.JWT(m.keyring, m.tokenRevocationRepository),
+		middlewares.RequirePermission(m.permissionRepository, constants.PermissionMFAReset),
+		m.mfaHandler.HandleResetUserMFA,
+	)
 }
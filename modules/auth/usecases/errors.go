@@ -0,0 +1,19 @@
+package usecases
+
+import "github.com/pkg/errors"
+
+var (
+	ErrAccountDisabled           = errors.New("account is disabled")
+	ErrInvalidCredentials        = errors.New("invalid credentials")
+	ErrInvalidCurrentPassword    = errors.New("current password is incorrect")
+	ErrInvalidMFACode            = errors.New("invalid two-factor authentication code")
+	ErrInvalidMFAToken           = errors.New("invalid or expired mfa token")
+	ErrInvalidPasswordResetToken = errors.New("invalid or expired password reset token")
+	ErrInvalidRefreshToken       = errors.New("invalid refresh token")
+	ErrMFAAlreadyEnabled         = errors.New("two-factor authentication is already enabled")
+	ErrMFAEnrollmentRequired     = errors.New("two-factor authentication has to be set up first")
+	ErrMFANotEnabled             = errors.New("two-factor authentication is not enabled")
+	ErrMFARequired               = errors.New("two-factor authentication is mandatory for this role")
+	ErrRefreshTokenReused        = errors.New("refresh token has already been used")
+	ErrUserNotFound              = errors.New("user not found")
+)
@@ -5,7 +5,6 @@ import (
 	"siakad-poc/config"
 	"siakad-poc/constants"
 	"siakad-poc/db/repositories"
-	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/jackc/pgx/v5"
@@ -14,51 +13,117 @@ import (
 )
 
 type LoginUseCase struct {
-	repository repositories.UserRepository
+	repository    repositories.UserRepository
+	mfaRepository repositories.MFARepository
+	signer        TokenSigner
+	throttle      loginThrottle
+	mfaConfig     config.MFAConfigParams
 }
 
+// JWTClaims carries the token ID as the registered `jti` claim (RegisteredClaims.ID)
 type JWTClaims struct {
 	UserID string             `json:"user_id"`
 	Role   constants.RoleType `json:"role"`
 	jwt.RegisteredClaims
 }
 
-func NewLoginUseCase(repository repositories.UserRepository) *LoginUseCase {
-	return &LoginUseCase{repository: repository}
+// LoginResult holds either the token pair or, when a second factor is needed, the MFA challenge.
+type LoginResult struct {
+	Tokens       TokenPair
+	MFAChallenge *MFAChallenge
 }
 
-func (u *LoginUseCase) Login(ctx context.Context, email, password string) (string, error) {
-	// Get user by email
+// MFAChallenge is handed out after a successful password check when the user has to complete the
+// second login step, see MFAUseCase.
+type MFAChallenge struct {
+	Token              string
+	EnrollmentRequired bool  // the user has no TOTP yet but their role requires one
+	ExpiresIn          int64 // MFA pending token lifetime in seconds
+}
+
+func NewLoginUseCase(
+	repository repositories.UserRepository,
+	mfaRepository repositories.MFARepository,
+	loginThrottleRepository repositories.LoginThrottleRepository,
+	signer TokenSigner,
+	authConfig config.AuthConfigParams,
+) *LoginUseCase {
+	return &LoginUseCase{
+		repository:    repository,
+		mfaRepository: mfaRepository,
+		signer:        signer,
+		throttle: loginThrottle{
+			repository: loginThrottleRepository,
+			cfg:        authConfig.LoginThrottle,
+		},
+		mfaConfig: authConfig.MFA,
+	}
+}
+
+// Login authenticates the user by email and password. Failed attempts are throttled per account and per
+// client IP, a throttled attempt is rejected with a LoginThrottledError before the password is checked.
+// Users with TOTP enabled, or whose role requires it, get an MFA challenge instead of the token pair.
+func (u *LoginUseCase) Login(ctx context.Context, email, password, clientIP string) (LoginResult, error) {
+	err := u.throttle.check(ctx, email, clientIP)
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	// Get user by email, soft-deleted accounts are never returned
 	user, err := u.repository.GetUserByEmail(ctx, email)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return "", errors.New("invalid credentials")
+			// Unknown emails are counted too, so throttling doesn't reveal which accounts exist
+			return LoginResult{}, u.throttle.recordFailure(ctx, email, clientIP)
 		}
-		return "", errors.Wrap(err, "failed to get user")
+		return LoginResult{}, errors.Wrap(err, "failed to get user")
 	}
 
 	// Verify password
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
 	if err != nil {
-		return "", errors.New("invalid credentials")
+		return LoginResult{}, u.throttle.recordFailure(ctx, email, clientIP)
 	}
 
-	// Generate JWT token
-	claims := JWTClaims{
-		UserID: user.ID.String(),
-		Role:   user.Role.Int.Int64(),
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			Subject:   user.ID.String(),
-		},
+	err = u.throttle.recordSuccess(ctx, email)
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	// Only reveal the account state to someone who knows the password
+	if user.DisabledAt.Valid {
+		return LoginResult{}, ErrAccountDisabled
+	}
+
+	mfaEnabled := true
+	userMFA, err := u.mfaRepository.GetUserMFA(ctx, user.ID.String())
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return LoginResult{}, errors.Wrap(err, "failed to get user mfa")
+		}
+		mfaEnabled = false
+	}
+	mfaEnabled = mfaEnabled && userMFA.ConfirmedAt.Valid
+
+	if mfaEnabled || mfaRequired(u.mfaConfig, user.Role.Int.Int64()) {
+		mfaToken, err := signMFAPendingToken(u.signer, user.ID.String(), !mfaEnabled, u.mfaConfig.PendingTokenTTL())
+		if err != nil {
+			return LoginResult{}, err
+		}
+
+		return LoginResult{
+			MFAChallenge: &MFAChallenge{
+				Token:              mfaToken,
+				EnrollmentRequired: !mfaEnabled,
+				ExpiresIn:          int64(u.mfaConfig.PendingTokenTTL().Seconds()),
+			},
+		}, nil
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(config.CurrentConfig.JWT.Secret))
+	tokenPair, err := issueTokenPair(ctx, u.repository, u.signer, user.ID.String(), user.Role.Int.Int64())
 	if err != nil {
-		return "", errors.Wrap(err, "failed to generate token")
+		return LoginResult{}, err
 	}
 
-	return tokenString, nil
+	return LoginResult{Tokens: tokenPair}, nil
 }
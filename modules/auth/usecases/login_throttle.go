@@ -0,0 +1,146 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"siakad-poc/config"
+	"siakad-poc/db/repositories"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+// LoginThrottledError is returned while an account or client IP has to wait before the next login attempt,
+// either because of the progressive delay after a failure or because it is locked out.
+type LoginThrottledError struct {
+	Scope          string // repositories.LoginThrottleScopeAccount or repositories.LoginThrottleScopeIP
+	Locked         bool
+	LockoutStarted bool // the rejected attempt itself triggered the lockout
+	RetryAfter     time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	if e.Locked {
+		return fmt.Sprintf("too many failed login attempts, %s is locked for %s", e.Scope, e.RetryAfter.Round(time.Second))
+	}
+	return fmt.Sprintf("too many failed login attempts, retry in %s", e.RetryAfter.Round(time.Second))
+}
+
+// loginThrottle applies the brute-force protection of the login endpoint:
+// 1. Every failure waits a progressive delay (doubling per failure) before the account can be tried again
+// 2. An account is locked after MaxAccountFailures consecutive failures within the failure window
+// 3. A client IP is locked after MaxIPFailures failures within the failure window, whatever account they target
+type loginThrottle struct {
+	repository repositories.LoginThrottleRepository
+	cfg        config.LoginThrottleConfigParams
+}
+
+type loginThrottleKey struct {
+	scope string
+	key   string
+}
+
+func throttleKeys(email, clientIP string) []loginThrottleKey {
+	return []loginThrottleKey{
+		{scope: repositories.LoginThrottleScopeAccount, key: repositories.LoginThrottleAccountKey(email)},
+		{scope: repositories.LoginThrottleScopeIP, key: clientIP},
+	}
+}
+
+// check rejects the attempt when the account or IP is locked, or the account is still within its delay.
+func (t loginThrottle) check(ctx context.Context, email, clientIP string) error {
+	now := time.Now()
+	for _, k := range throttleKeys(email, clientIP) {
+		throttle, err := t.repository.GetLoginThrottle(ctx, k.scope, k.key)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			return errors.Wrap(err, "failed to get login throttle")
+		}
+
+		if throttle.LockedUntil.Valid && throttle.LockedUntil.Time.After(now) {
+			return &LoginThrottledError{
+				Scope:      k.scope,
+				Locked:     true,
+				RetryAfter: throttle.LockedUntil.Time.Sub(now),
+			}
+		}
+
+		// The delay only applies per account, a shared IP would otherwise slow down every user behind it
+		if k.scope != repositories.LoginThrottleScopeAccount || throttle.FailedAttempts == 0 {
+			continue
+		}
+		retryAt := throttle.LastFailedAt.Time.Add(t.delay(int(throttle.FailedAttempts)))
+		if retryAt.After(now) {
+			return &LoginThrottledError{
+				Scope:      k.scope,
+				RetryAfter: retryAt.Sub(now),
+			}
+		}
+	}
+
+	return nil
+}
+
+// recordFailure counts the failed attempt against the account and the IP. It returns a LoginThrottledError
+// when this failure locked either of them and ErrInvalidCredentials otherwise.
+func (t loginThrottle) recordFailure(ctx context.Context, email, clientIP string) error {
+	now := time.Now()
+	windowStart := now.Add(-t.cfg.FailureWindow())
+
+	var lockout *LoginThrottledError
+	for _, k := range throttleKeys(email, clientIP) {
+		throttle, err := t.repository.RecordLoginFailure(ctx, k.scope, k.key, now, windowStart)
+		if err != nil {
+			return errors.Wrap(err, "failed to record login failure")
+		}
+
+		maxFailures := t.cfg.MaxAccountFailures()
+		if k.scope == repositories.LoginThrottleScopeIP {
+			maxFailures = t.cfg.MaxIPFailures()
+		}
+		if int(throttle.FailedAttempts) < maxFailures {
+			continue
+		}
+
+		err = t.repository.LockLoginThrottle(ctx, k.scope, k.key, now.Add(t.cfg.LockoutDuration()))
+		if err != nil {
+			return errors.Wrap(err, "failed to lock login")
+		}
+		if lockout == nil {
+			lockout = &LoginThrottledError{
+				Scope:          k.scope,
+				Locked:         true,
+				LockoutStarted: true,
+				RetryAfter:     t.cfg.LockoutDuration(),
+			}
+		}
+	}
+
+	if lockout != nil {
+		return lockout
+	}
+	return ErrInvalidCredentials
+}
+
+// recordSuccess clears the failures of the account. The IP keeps its count, otherwise an attacker could
+// reset it by logging into their own account between guesses.
+func (t loginThrottle) recordSuccess(ctx context.Context, email string) error {
+	err := t.repository.DeleteLoginThrottle(ctx, repositories.LoginThrottleScopeAccount, repositories.LoginThrottleAccountKey(email))
+	if err != nil {
+		return errors.Wrap(err, "failed to clear login throttle")
+	}
+	return nil
+}
+
+// delay returns the wait after the given number of consecutive failures: BaseDelay, doubled per failure, capped at MaxDelay.
+func (t loginThrottle) delay(failedAttempts int) time.Duration {
+	delay := float64(t.cfg.BaseDelay()) * math.Pow(2, float64(failedAttempts-1))
+	if delay > float64(t.cfg.MaxDelay()) {
+		return t.cfg.MaxDelay()
+	}
+	return time.Duration(delay)
+}
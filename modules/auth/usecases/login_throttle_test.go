@@ -0,0 +1,328 @@
+package usecases
+
+import (
+	"context"
+	"siakad-poc/config"
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Mock login throttle repository
+type MockLoginThrottleRepository struct {
+	mock.Mock
+}
+
+func (m *MockLoginThrottleRepository) GetLoginThrottle(ctx context.Context, scope, key string) (generated.LoginThrottle, error) {
+	args := m.Called(ctx, scope, key)
+	return args.Get(0).(generated.LoginThrottle), args.Error(1)
+}
+
+func (m *MockLoginThrottleRepository) RecordLoginFailure(ctx context.Context, scope, key string, failedAt, windowStart time.Time) (generated.LoginThrottle, error) {
+	args := m.Called(ctx, scope, key, failedAt, windowStart)
+	return args.Get(0).(generated.LoginThrottle), args.Error(1)
+}
+
+func (m *MockLoginThrottleRepository) LockLoginThrottle(ctx context.Context, scope, key string, lockedUntil time.Time) error {
+	args := m.Called(ctx, scope, key, lockedUntil)
+	return args.Error(0)
+}
+
+func (m *MockLoginThrottleRepository) DeleteLoginThrottle(ctx context.Context, scope, key string) error {
+	args := m.Called(ctx, scope, key)
+	return args.Error(0)
+}
+
+const (
+	throttleEmail    = "Student@Example.com"
+	throttleClientIP = "203.0.113.7"
+	throttlePassword = "c0rrect-Passw0rd"
+)
+
+// Test Suite
+type LoginThrottleTestSuite struct {
+	suite.Suite
+	throttle     loginThrottle
+	mockRepo     *MockLoginThrottleRepository
+	mockUserRepo *MockUserRepository
+	ctx          context.Context
+	accountKey   string
+}
+
+func (suite *LoginThrottleTestSuite) SetupTest() {
+	suite.mockRepo = new(MockLoginThrottleRepository)
+	suite.mockUserRepo = new(MockUserRepository)
+	suite.throttle = loginThrottle{repository: suite.mockRepo}
+	suite.ctx = context.Background()
+	suite.accountKey = repositories.LoginThrottleAccountKey(throttleEmail)
+}
+
+func (suite *LoginThrottleTestSuite) TearDownTest() {
+	suite.mockRepo.AssertExpectations(suite.T())
+	suite.mockUserRepo.AssertExpectations(suite.T())
+}
+
+// failures returns the throttle row after the given number of failures, the last one at lastFailedAt
+func failures(scope, key string, failedAttempts int32, lastFailedAt time.Time) generated.LoginThrottle {
+	return generated.LoginThrottle{
+		Scope:          scope,
+		Key:            key,
+		FailedAttempts: failedAttempts,
+		LastFailedAt:   pgtype.Timestamptz{Time: lastFailedAt, Valid: true},
+	}
+}
+
+// Test the delay doubles per failure from the base delay and is capped at the max delay
+func (suite *LoginThrottleTestSuite) TestDelay() {
+	testCases := []struct {
+		name           string
+		cfg            config.LoginThrottleConfigParams
+		failedAttempts int
+		expected       time.Duration
+	}{
+		{"first failure waits the base delay", config.LoginThrottleConfigParams{}, 1, time.Second},
+		{"second failure doubles", config.LoginThrottleConfigParams{}, 2, 2 * time.Second},
+		{"fifth failure", config.LoginThrottleConfigParams{}, 5, 16 * time.Second},
+		{"capped at the default max delay", config.LoginThrottleConfigParams{}, 6, 30 * time.Second},
+		{"far past the cap", config.LoginThrottleConfigParams{}, 64, 30 * time.Second},
+		{"configured base delay", config.LoginThrottleConfigParams{BaseDelaySeconds: 3}, 3, 12 * time.Second},
+		{"configured max delay", config.LoginThrottleConfigParams{BaseDelaySeconds: 2, MaxDelaySeconds: 5}, 3, 5 * time.Second},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			throttle := loginThrottle{cfg: tc.cfg}
+			assert.Equal(suite.T(), tc.expected, throttle.delay(tc.failedAttempts))
+		})
+	}
+}
+
+// Test the failure reaching the account threshold locks the account and reports the lockout
+func (suite *LoginThrottleTestSuite) TestRecordFailure_AccountLockout() {
+	suite.mockRepo.On("RecordLoginFailure", suite.ctx, repositories.LoginThrottleScopeAccount, suite.accountKey, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(failures(repositories.LoginThrottleScopeAccount, suite.accountKey, 5, time.Now()), nil)
+	suite.mockRepo.On("RecordLoginFailure", suite.ctx, repositories.LoginThrottleScopeIP, throttleClientIP, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(failures(repositories.LoginThrottleScopeIP, throttleClientIP, 5, time.Now()), nil)
+	suite.mockRepo.On("LockLoginThrottle", suite.ctx, repositories.LoginThrottleScopeAccount, suite.accountKey, mock.AnythingOfType("time.Time")).Return(nil)
+
+	before := time.Now()
+	err := suite.throttle.recordFailure(suite.ctx, throttleEmail, throttleClientIP)
+
+	var throttledErr *LoginThrottledError
+	suite.Require().True(errors.As(err, &throttledErr))
+	assert.Equal(suite.T(), repositories.LoginThrottleScopeAccount, throttledErr.Scope)
+	assert.True(suite.T(), throttledErr.Locked)
+	assert.True(suite.T(), throttledErr.LockoutStarted)
+	assert.Equal(suite.T(), 15*time.Minute, throttledErr.RetryAfter)
+
+	// The lockout lasts the lockout duration and the failures are counted within the failure window
+	lockedUntil := suite.mockRepo.Calls[1].Arguments.Get(3).(time.Time)
+	assert.WithinDuration(suite.T(), before.Add(15*time.Minute), lockedUntil, time.Second)
+	windowStart := suite.mockRepo.Calls[0].Arguments.Get(4).(time.Time)
+	assert.WithinDuration(suite.T(), before.Add(-15*time.Minute), windowStart, time.Second)
+
+	// The IP is still far from its own threshold
+	suite.mockRepo.AssertNotCalled(suite.T(), "LockLoginThrottle", suite.ctx, repositories.LoginThrottleScopeIP, throttleClientIP, mock.Anything)
+}
+
+// Test failures below the account threshold only report invalid credentials
+func (suite *LoginThrottleTestSuite) TestRecordFailure_BelowThreshold() {
+	suite.mockRepo.On("RecordLoginFailure", suite.ctx, repositories.LoginThrottleScopeAccount, suite.accountKey, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(failures(repositories.LoginThrottleScopeAccount, suite.accountKey, 4, time.Now()), nil)
+	suite.mockRepo.On("RecordLoginFailure", suite.ctx, repositories.LoginThrottleScopeIP, throttleClientIP, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(failures(repositories.LoginThrottleScopeIP, throttleClientIP, 49, time.Now()), nil)
+
+	err := suite.throttle.recordFailure(suite.ctx, throttleEmail, throttleClientIP)
+
+	assert.True(suite.T(), errors.Is(err, ErrInvalidCredentials))
+	suite.mockRepo.AssertNotCalled(suite.T(), "LockLoginThrottle", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Test the IP is counted under its own key and locked at the IP threshold, whatever account it targets
+func (suite *LoginThrottleTestSuite) TestRecordFailure_IPLockout() {
+	suite.throttle.cfg = config.LoginThrottleConfigParams{MaxFailedAttemptsPerIP: 20}
+
+	suite.mockRepo.On("RecordLoginFailure", suite.ctx, repositories.LoginThrottleScopeAccount, suite.accountKey, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(failures(repositories.LoginThrottleScopeAccount, suite.accountKey, 1, time.Now()), nil)
+	suite.mockRepo.On("RecordLoginFailure", suite.ctx, repositories.LoginThrottleScopeIP, throttleClientIP, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(failures(repositories.LoginThrottleScopeIP, throttleClientIP, 20, time.Now()), nil)
+	suite.mockRepo.On("LockLoginThrottle", suite.ctx, repositories.LoginThrottleScopeIP, throttleClientIP, mock.AnythingOfType("time.Time")).Return(nil)
+
+	err := suite.throttle.recordFailure(suite.ctx, throttleEmail, throttleClientIP)
+
+	var throttledErr *LoginThrottledError
+	suite.Require().True(errors.As(err, &throttledErr))
+	assert.Equal(suite.T(), repositories.LoginThrottleScopeIP, throttledErr.Scope)
+	assert.True(suite.T(), throttledErr.LockoutStarted)
+	suite.mockRepo.AssertNotCalled(suite.T(), "LockLoginThrottle", suite.ctx, repositories.LoginThrottleScopeAccount, suite.accountKey, mock.Anything)
+}
+
+// Test a locked account is rejected until the lockout ends
+func (suite *LoginThrottleTestSuite) TestCheck_AccountLocked() {
+	throttle := failures(repositories.LoginThrottleScopeAccount, suite.accountKey, 5, time.Now().Add(-time.Minute))
+	throttle.LockedUntil = pgtype.Timestamptz{Time: time.Now().Add(10 * time.Minute), Valid: true}
+	suite.mockRepo.On("GetLoginThrottle", suite.ctx, repositories.LoginThrottleScopeAccount, suite.accountKey).Return(throttle, nil)
+
+	err := suite.throttle.check(suite.ctx, throttleEmail, throttleClientIP)
+
+	var throttledErr *LoginThrottledError
+	suite.Require().True(errors.As(err, &throttledErr))
+	assert.Equal(suite.T(), repositories.LoginThrottleScopeAccount, throttledErr.Scope)
+	assert.True(suite.T(), throttledErr.Locked)
+	assert.False(suite.T(), throttledErr.LockoutStarted)
+	assert.InDelta(suite.T(), float64(10*time.Minute), float64(throttledErr.RetryAfter), float64(time.Second))
+}
+
+// Test a locked IP is rejected even for an account without failures
+func (suite *LoginThrottleTestSuite) TestCheck_IPLocked() {
+	throttle := failures(repositories.LoginThrottleScopeIP, throttleClientIP, 50, time.Now())
+	throttle.LockedUntil = pgtype.Timestamptz{Time: time.Now().Add(5 * time.Minute), Valid: true}
+	suite.mockRepo.On("GetLoginThrottle", suite.ctx, repositories.LoginThrottleScopeAccount, suite.accountKey).Return(generated.LoginThrottle{}, pgx.ErrNoRows)
+	suite.mockRepo.On("GetLoginThrottle", suite.ctx, repositories.LoginThrottleScopeIP, throttleClientIP).Return(throttle, nil)
+
+	err := suite.throttle.check(suite.ctx, throttleEmail, throttleClientIP)
+
+	var throttledErr *LoginThrottledError
+	suite.Require().True(errors.As(err, &throttledErr))
+	assert.Equal(suite.T(), repositories.LoginThrottleScopeIP, throttledErr.Scope)
+	assert.True(suite.T(), throttledErr.Locked)
+}
+
+// Test the account waits the progressive delay after a failure, and may retry once it has passed
+func (suite *LoginThrottleTestSuite) TestCheck_AccountDelay() {
+	// 3 failures wait 4 seconds, the last one was 1 second ago
+	suite.mockRepo.On("GetLoginThrottle", suite.ctx, repositories.LoginThrottleScopeAccount, suite.accountKey).
+		Return(failures(repositories.LoginThrottleScopeAccount, suite.accountKey, 3, time.Now().Add(-time.Second)), nil).Once()
+
+	err := suite.throttle.check(suite.ctx, throttleEmail, throttleClientIP)
+
+	var throttledErr *LoginThrottledError
+	suite.Require().True(errors.As(err, &throttledErr))
+	assert.False(suite.T(), throttledErr.Locked)
+	assert.InDelta(suite.T(), float64(3*time.Second), float64(throttledErr.RetryAfter), float64(time.Second))
+
+	// The delay has passed, the IP failures never delay an attempt
+	suite.mockRepo.On("GetLoginThrottle", suite.ctx, repositories.LoginThrottleScopeAccount, suite.accountKey).
+		Return(failures(repositories.LoginThrottleScopeAccount, suite.accountKey, 3, time.Now().Add(-5*time.Second)), nil).Once()
+	suite.mockRepo.On("GetLoginThrottle", suite.ctx, repositories.LoginThrottleScopeIP, throttleClientIP).
+		Return(failures(repositories.LoginThrottleScopeIP, throttleClientIP, 30, time.Now()), nil).Once()
+
+	err = suite.throttle.check(suite.ctx, throttleEmail, throttleClientIP)
+
+	assert.NoError(suite.T(), err)
+}
+
+// Test a successful login resets the account counter and keeps the IP counter
+func (suite *LoginThrottleTestSuite) TestRecordSuccess_ResetsAccountOnly() {
+	suite.mockRepo.On("DeleteLoginThrottle", suite.ctx, repositories.LoginThrottleScopeAccount, "student@example.com").Return(nil)
+
+	err := suite.throttle.recordSuccess(suite.ctx, throttleEmail)
+
+	assert.NoError(suite.T(), err)
+	suite.mockRepo.AssertNotCalled(suite.T(), "DeleteLoginThrottle", suite.ctx, repositories.LoginThrottleScopeIP, mock.Anything)
+}
+
+// Test a throttled login is rejected before the user is looked up and the password checked
+func (suite *LoginThrottleTestSuite) TestLogin_Throttled() {
+	throttle := failures(repositories.LoginThrottleScopeAccount, suite.accountKey, 5, time.Now())
+	throttle.LockedUntil = pgtype.Timestamptz{Time: time.Now().Add(time.Minute), Valid: true}
+	suite.mockRepo.On("GetLoginThrottle", suite.ctx, repositories.LoginThrottleScopeAccount, suite.accountKey).Return(throttle, nil)
+
+	useCase := NewLoginUseCase(suite.mockUserRepo, nil, suite.mockRepo, new(MockTokenSigner), config.AuthConfigParams{})
+	_, err := useCase.Login(suite.ctx, throttleEmail, throttlePassword, throttleClientIP)
+
+	var throttledErr *LoginThrottledError
+	assert.True(suite.T(), errors.As(err, &throttledErr))
+	suite.mockUserRepo.AssertNotCalled(suite.T(), "GetUserByEmail", mock.Anything, mock.Anything)
+}
+
+// Test the wrong password reaching the account threshold locks the account through Login
+func (suite *LoginThrottleTestSuite) TestLogin_WrongPasswordLocksAccount() {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(throttlePassword), bcrypt.MinCost)
+	suite.Require().NoError(err)
+
+	suite.mockRepo.On("GetLoginThrottle", suite.ctx, repositories.LoginThrottleScopeAccount, suite.accountKey).
+		Return(failures(repositories.LoginThrottleScopeAccount, suite.accountKey, 4, time.Now().Add(-time.Minute)), nil)
+	suite.mockRepo.On("GetLoginThrottle", suite.ctx, repositories.LoginThrottleScopeIP, throttleClientIP).Return(generated.LoginThrottle{}, pgx.ErrNoRows)
+	suite.mockUserRepo.On("GetUserByEmail", suite.ctx, throttleEmail).Return(generated.User{Email: throttleEmail, Password: string(hashedPassword)}, nil)
+	suite.mockRepo.On("RecordLoginFailure", suite.ctx, repositories.LoginThrottleScopeAccount, suite.accountKey, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(failures(repositories.LoginThrottleScopeAccount, suite.accountKey, 5, time.Now()), nil)
+	suite.mockRepo.On("RecordLoginFailure", suite.ctx, repositories.LoginThrottleScopeIP, throttleClientIP, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(failures(repositories.LoginThrottleScopeIP, throttleClientIP, 1, time.Now()), nil)
+	suite.mockRepo.On("LockLoginThrottle", suite.ctx, repositories.LoginThrottleScopeAccount, suite.accountKey, mock.AnythingOfType("time.Time")).Return(nil)
+
+	useCase := NewLoginUseCase(suite.mockUserRepo, nil, suite.mockRepo, new(MockTokenSigner), config.AuthConfigParams{})
+	_, err = useCase.Login(suite.ctx, throttleEmail, "wr0ng-Passw0rd", throttleClientIP)
+
+	var throttledErr *LoginThrottledError
+	suite.Require().True(errors.As(err, &throttledErr))
+	assert.True(suite.T(), throttledErr.LockoutStarted)
+}
+
+// Test an unknown email is counted like a wrong password, so throttling doesn't reveal which accounts exist
+func (suite *LoginThrottleTestSuite) TestLogin_UnknownEmailCounted() {
+	suite.mockRepo.On("GetLoginThrottle", suite.ctx, mock.Anything, mock.Anything).Return(generated.LoginThrottle{}, pgx.ErrNoRows)
+	suite.mockUserRepo.On("GetUserByEmail", suite.ctx, throttleEmail).Return(generated.User{}, pgx.ErrNoRows)
+	suite.mockRepo.On("RecordLoginFailure", suite.ctx, repositories.LoginThrottleScopeAccount, suite.accountKey, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(failures(repositories.LoginThrottleScopeAccount, suite.accountKey, 1, time.Now()), nil)
+	suite.mockRepo.On("RecordLoginFailure", suite.ctx, repositories.LoginThrottleScopeIP, throttleClientIP, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(failures(repositories.LoginThrottleScopeIP, throttleClientIP, 1, time.Now()), nil)
+
+	useCase := NewLoginUseCase(suite.mockUserRepo, nil, suite.mockRepo, new(MockTokenSigner), config.AuthConfigParams{})
+	_, err := useCase.Login(suite.ctx, throttleEmail, throttlePassword, throttleClientIP)
+
+	assert.True(suite.T(), errors.Is(err, ErrInvalidCredentials))
+}
+
+// Test a disabled account is rejected once the password is verified, without issuing tokens
+func (suite *LoginThrottleTestSuite) TestLogin_DisabledAccount() {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(throttlePassword), bcrypt.MinCost)
+	suite.Require().NoError(err)
+	user := generated.User{
+		Email:      throttleEmail,
+		Password:   string(hashedPassword),
+		DisabledAt: pgtype.Timestamptz{Time: time.Now().Add(-time.Hour), Valid: true},
+	}
+
+	suite.mockRepo.On("GetLoginThrottle", suite.ctx, mock.Anything, mock.Anything).Return(generated.LoginThrottle{}, pgx.ErrNoRows)
+	suite.mockUserRepo.On("GetUserByEmail", suite.ctx, throttleEmail).Return(user, nil)
+	suite.mockRepo.On("DeleteLoginThrottle", suite.ctx, repositories.LoginThrottleScopeAccount, suite.accountKey).Return(nil)
+
+	signer := new(MockTokenSigner)
+	useCase := NewLoginUseCase(suite.mockUserRepo, nil, suite.mockRepo, signer, config.AuthConfigParams{})
+	result, err := useCase.Login(suite.ctx, throttleEmail, throttlePassword, throttleClientIP)
+
+	assert.True(suite.T(), errors.Is(err, ErrAccountDisabled))
+	assert.Empty(suite.T(), result.Tokens.AccessToken)
+	signer.AssertNotCalled(suite.T(), "Sign", mock.Anything)
+	suite.mockUserRepo.AssertNotCalled(suite.T(), "CreateRefreshToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Test a deleted account can't log in, the lookup by email never returns soft-deleted users so it is
+// handled like an unknown email
+func (suite *LoginThrottleTestSuite) TestLogin_DeletedAccount() {
+	suite.mockRepo.On("GetLoginThrottle", suite.ctx, mock.Anything, mock.Anything).Return(generated.LoginThrottle{}, pgx.ErrNoRows)
+	suite.mockUserRepo.On("GetUserByEmail", suite.ctx, throttleEmail).Return(generated.User{}, pgx.ErrNoRows)
+	suite.mockRepo.On("RecordLoginFailure", suite.ctx, mock.Anything, mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(generated.LoginThrottle{FailedAttempts: 1}, nil)
+
+	useCase := NewLoginUseCase(suite.mockUserRepo, nil, suite.mockRepo, new(MockTokenSigner), config.AuthConfigParams{})
+	_, err := useCase.Login(suite.ctx, throttleEmail, throttlePassword, throttleClientIP)
+
+	assert.True(suite.T(), errors.Is(err, ErrInvalidCredentials))
+	assert.False(suite.T(), errors.Is(err, ErrAccountDisabled))
+}
+
+// Run the test suite
+func TestLoginThrottleTestSuite(t *testing.T) {
+	suite.Run(t, new(LoginThrottleTestSuite))
+}
@@ -0,0 +1,510 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"siakad-poc/common"
+	"siakad-poc/common/totp"
+	"siakad-poc/config"
+	"siakad-poc/constants"
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+const recoveryCodeCount = 10
+
+// TokenKeyring signs tokens and resolves their verification keys, it is implemented by jwtkeys.Keyring.
+type TokenKeyring interface {
+	TokenSigner
+	Keyfunc(token *jwt.Token) (interface{}, error)
+	ValidMethods() []string
+}
+
+// MFAPendingClaims are the claims of the short-lived token returned by the password step of a login.
+// The token only proves the password check, the JWT middleware never accepts it as an access token.
+type MFAPendingClaims struct {
+	UserID             string `json:"user_id"`
+	MFAPending         bool   `json:"mfa_pending"`
+	EnrollmentRequired bool   `json:"mfa_enrollment_required,omitempty"`
+	jwt.RegisteredClaims
+}
+
+type MFAEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"` // render as a QR code for authenticator apps
+}
+
+type MFAStatus struct {
+	Enabled                bool       `json:"enabled"`
+	Required               bool       `json:"required"`
+	ConfirmedAt            *time.Time `json:"confirmed_at"`
+	RecoveryCodesRemaining int64      `json:"recovery_codes_remaining"`
+}
+
+type MFAUseCase struct {
+	userRepository            repositories.UserRepository
+	mfaRepository             repositories.MFARepository
+	tokenRevocationRepository repositories.TokenRevocationRepository
+	txExecutor                common.TransactionExecutor
+	keyring                   TokenKeyring
+	throttle                  loginThrottle
+	cfg                       config.MFAConfigParams
+}
+
+func NewMFAUseCase(
+	userRepository repositories.UserRepository,
+	mfaRepository repositories.MFARepository,
+	tokenRevocationRepository repositories.TokenRevocationRepository,
+	loginThrottleRepository repositories.LoginThrottleRepository,
+	txExecutor common.TransactionExecutor,
+	keyring TokenKeyring,
+	authConfig config.AuthConfigParams,
+) *MFAUseCase {
+	return &MFAUseCase{
+		userRepository:            userRepository,
+		mfaRepository:             mfaRepository,
+		tokenRevocationRepository: tokenRevocationRepository,
+		txExecutor:                txExecutor,
+		keyring:                   keyring,
+		throttle: loginThrottle{
+			repository: loginThrottleRepository,
+			cfg:        authConfig.LoginThrottle,
+		},
+		cfg: authConfig.MFA,
+	}
+}
+
+// CompleteLogin finishes a login of a user with TOTP enabled, using either a TOTP code or a recovery code.
+// Wrong codes are throttled like wrong passwords. The MFA pending token can only be used once.
+func (uc *MFAUseCase) CompleteLogin(ctx context.Context, mfaToken, code, recoveryCode, clientIP string) (TokenPair, error) {
+	claims, err := uc.parsePendingToken(ctx, mfaToken)
+	if err != nil {
+		return TokenPair{}, err
+	}
+	if claims.EnrollmentRequired {
+		return TokenPair{}, ErrMFAEnrollmentRequired
+	}
+
+	user, err := uc.getActiveUser(ctx, claims.UserID)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	err = uc.throttle.check(ctx, user.Email, clientIP)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	if recoveryCode != "" {
+		_, err = uc.mfaRepository.UseMFARecoveryCode(ctx, claims.UserID, hashOpaqueToken(normalizeRecoveryCode(recoveryCode)))
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = ErrInvalidMFACode
+		}
+	} else {
+		err = uc.verifyCode(ctx, claims.UserID, code)
+	}
+	if errors.Is(err, ErrInvalidMFACode) {
+		return TokenPair{}, uc.recordFailure(ctx, user.Email, clientIP)
+	}
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	err = uc.throttle.recordSuccess(ctx, user.Email)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	err = uc.consumePendingToken(ctx, claims)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	return issueTokenPair(ctx, uc.userRepository, uc.keyring, user.ID.String(), user.Role.Int.Int64())
+}
+
+// StartLoginEnrollment starts the TOTP enrollment of a user whose role requires it, during their login.
+func (uc *MFAUseCase) StartLoginEnrollment(ctx context.Context, mfaToken string) (MFAEnrollment, error) {
+	claims, err := uc.parsePendingToken(ctx, mfaToken)
+	if err != nil {
+		return MFAEnrollment{}, err
+	}
+	if !claims.EnrollmentRequired {
+		return MFAEnrollment{}, ErrMFAAlreadyEnabled
+	}
+
+	return uc.StartEnrollment(ctx, claims.UserID)
+}
+
+// ConfirmLoginEnrollment confirms the enrollment started by StartLoginEnrollment and completes the login.
+func (uc *MFAUseCase) ConfirmLoginEnrollment(ctx context.Context, mfaToken, code, clientIP string) (TokenPair, []string, error) {
+	claims, err := uc.parsePendingToken(ctx, mfaToken)
+	if err != nil {
+		return TokenPair{}, nil, err
+	}
+	if !claims.EnrollmentRequired {
+		return TokenPair{}, nil, ErrMFAAlreadyEnabled
+	}
+
+	user, err := uc.getActiveUser(ctx, claims.UserID)
+	if err != nil {
+		return TokenPair{}, nil, err
+	}
+
+	err = uc.throttle.check(ctx, user.Email, clientIP)
+	if err != nil {
+		return TokenPair{}, nil, err
+	}
+
+	recoveryCodes, err := uc.ConfirmEnrollment(ctx, claims.UserID, code)
+	if errors.Is(err, ErrInvalidMFACode) {
+		return TokenPair{}, nil, uc.recordFailure(ctx, user.Email, clientIP)
+	}
+	if err != nil {
+		return TokenPair{}, nil, err
+	}
+
+	err = uc.consumePendingToken(ctx, claims)
+	if err != nil {
+		return TokenPair{}, nil, err
+	}
+
+	tokenPair, err := issueTokenPair(ctx, uc.userRepository, uc.keyring, user.ID.String(), user.Role.Int.Int64())
+	if err != nil {
+		return TokenPair{}, nil, err
+	}
+
+	return tokenPair, recoveryCodes, nil
+}
+
+func (uc *MFAUseCase) GetStatus(ctx context.Context, userID string) (MFAStatus, error) {
+	user, err := uc.userRepository.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return MFAStatus{}, ErrUserNotFound
+		}
+		return MFAStatus{}, errors.Wrap(err, "failed to get user")
+	}
+
+	status := MFAStatus{
+		Required: mfaRequired(uc.cfg, user.Role.Int.Int64()),
+	}
+
+	userMFA, err := uc.mfaRepository.GetUserMFA(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return status, nil
+		}
+		return MFAStatus{}, errors.Wrap(err, "failed to get user mfa")
+	}
+	if !userMFA.ConfirmedAt.Valid {
+		return status, nil
+	}
+
+	status.Enabled = true
+	confirmedAt := userMFA.ConfirmedAt.Time
+	status.ConfirmedAt = &confirmedAt
+
+	status.RecoveryCodesRemaining, err = uc.mfaRepository.CountUnusedMFARecoveryCodes(ctx, userID)
+	if err != nil {
+		return MFAStatus{}, errors.Wrap(err, "failed to count recovery codes")
+	}
+
+	return status, nil
+}
+
+// StartEnrollment generates a new TOTP secret. It only becomes active once confirmed with a first code,
+// starting over replaces a previous unconfirmed secret.
+func (uc *MFAUseCase) StartEnrollment(ctx context.Context, userID string) (MFAEnrollment, error) {
+	user, err := uc.userRepository.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return MFAEnrollment{}, ErrUserNotFound
+		}
+		return MFAEnrollment{}, errors.Wrap(err, "failed to get user")
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return MFAEnrollment{}, err
+	}
+
+	_, err = uc.mfaRepository.StartUserMFAEnrollment(ctx, userID, secret)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return MFAEnrollment{}, ErrMFAAlreadyEnabled
+		}
+		return MFAEnrollment{}, errors.Wrap(err, "failed to store mfa secret")
+	}
+
+	return MFAEnrollment{
+		Secret: secret,
+		URI:    totp.KeyURI(uc.cfg.IssuerName(), user.Email, secret),
+	}, nil
+}
+
+// ConfirmEnrollment activates the pending TOTP secret and returns the recovery codes, they are only shown once.
+func (uc *MFAUseCase) ConfirmEnrollment(ctx context.Context, userID, code string) ([]string, error) {
+	userMFA, err := uc.mfaRepository.GetUserMFA(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrMFANotEnabled
+		}
+		return nil, errors.Wrap(err, "failed to get user mfa")
+	}
+	if userMFA.ConfirmedAt.Valid {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	step, ok, err := totp.Validate(userMFA.Secret, code, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+
+	recoveryCodes, codeHashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.txExecutor.WithTxContext(ctx, func(txCtx *common.TxContext) error {
+		_, err := uc.mfaRepository.ConfirmUserMFATx(txCtx, userID, step)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrMFAAlreadyEnabled
+			}
+			return errors.Wrap(err, "failed to confirm mfa")
+		}
+
+		err = uc.mfaRepository.ReplaceMFARecoveryCodesTx(txCtx, userID, codeHashes)
+		if err != nil {
+			return errors.Wrap(err, "failed to store recovery codes")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return recoveryCodes, nil
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes of the user, the previous ones stop working.
+func (uc *MFAUseCase) RegenerateRecoveryCodes(ctx context.Context, userID, code string) ([]string, error) {
+	err := uc.verifyCode(ctx, userID, code)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, codeHashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.txExecutor.WithTxContext(ctx, func(txCtx *common.TxContext) error {
+		err := uc.mfaRepository.ReplaceMFARecoveryCodesTx(txCtx, userID, codeHashes)
+		if err != nil {
+			return errors.Wrap(err, "failed to store recovery codes")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return recoveryCodes, nil
+}
+
+// Disable turns TOTP off for the user, which is refused when their role requires it.
+func (uc *MFAUseCase) Disable(ctx context.Context, userID, code string) error {
+	user, err := uc.userRepository.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return errors.Wrap(err, "failed to get user")
+	}
+	if mfaRequired(uc.cfg, user.Role.Int.Int64()) {
+		return ErrMFARequired
+	}
+
+	err = uc.verifyCode(ctx, userID, code)
+	if err != nil {
+		return err
+	}
+
+	return uc.deleteUserMFA(ctx, userID)
+}
+
+// ResetUserMFA removes the TOTP secret and recovery codes of a user who lost their device. When their role
+// requires MFA, they have to enroll again on their next login.
+func (uc *MFAUseCase) ResetUserMFA(ctx context.Context, userID string) error {
+	_, err := uc.userRepository.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return errors.Wrap(err, "failed to get user")
+	}
+
+	return uc.deleteUserMFA(ctx, userID)
+}
+
+func (uc *MFAUseCase) deleteUserMFA(ctx context.Context, userID string) error {
+	return uc.txExecutor.WithTxContext(ctx, func(txCtx *common.TxContext) error {
+		err := uc.mfaRepository.DeleteUserMFATx(txCtx, userID)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete user mfa")
+		}
+		return nil
+	})
+}
+
+// verifyCode checks a TOTP code of a confirmed enrollment, a code can't be used twice.
+func (uc *MFAUseCase) verifyCode(ctx context.Context, userID, code string) error {
+	userMFA, err := uc.mfaRepository.GetUserMFA(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrMFANotEnabled
+		}
+		return errors.Wrap(err, "failed to get user mfa")
+	}
+	if !userMFA.ConfirmedAt.Valid {
+		return ErrMFANotEnabled
+	}
+
+	step, ok, err := totp.Validate(userMFA.Secret, code, time.Now())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidMFACode
+	}
+
+	// Only a step newer than the last accepted one is recorded, so an intercepted code can't be replayed
+	_, err = uc.mfaRepository.UseUserMFAStep(ctx, userID, step)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrInvalidMFACode
+		}
+		return errors.Wrap(err, "failed to record mfa code usage")
+	}
+
+	return nil
+}
+
+// recordFailure counts a wrong code like a wrong password, so the second step can't be brute-forced.
+func (uc *MFAUseCase) recordFailure(ctx context.Context, email, clientIP string) error {
+	err := uc.throttle.recordFailure(ctx, email, clientIP)
+	if errors.Is(err, ErrInvalidCredentials) {
+		return ErrInvalidMFACode
+	}
+	return err
+}
+
+func (uc *MFAUseCase) getActiveUser(ctx context.Context, userID string) (generated.User, error) {
+	user, err := uc.userRepository.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return generated.User{}, ErrInvalidMFAToken
+		}
+		return generated.User{}, errors.Wrap(err, "failed to get user")
+	}
+
+	// The account may have been disabled or deleted since the password step
+	if user.DeletedAt.Valid || user.DisabledAt.Valid {
+		return generated.User{}, ErrInvalidMFAToken
+	}
+
+	return user, nil
+}
+
+func (uc *MFAUseCase) parsePendingToken(ctx context.Context, mfaToken string) (*MFAPendingClaims, error) {
+	claims := &MFAPendingClaims{}
+	token, err := jwt.ParseWithClaims(mfaToken, claims, uc.keyring.Keyfunc, jwt.WithValidMethods(uc.keyring.ValidMethods()))
+	if err != nil || !token.Valid || !claims.MFAPending || claims.UserID == "" || claims.ID == "" {
+		return nil, ErrInvalidMFAToken
+	}
+
+	revoked, err := uc.tokenRevocationRepository.IsTokenRevoked(ctx, claims.ID, claims.UserID, claims.IssuedAt.Time)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check mfa token revocation")
+	}
+	if revoked {
+		return nil, ErrInvalidMFAToken
+	}
+
+	return claims, nil
+}
+
+// consumePendingToken revokes the MFA pending token once it completed a login.
+func (uc *MFAUseCase) consumePendingToken(ctx context.Context, claims *MFAPendingClaims) error {
+	err := uc.tokenRevocationRepository.RevokeAccessToken(ctx, claims.ID, claims.UserID, claims.ExpiresAt.Time)
+	if err != nil {
+		return errors.Wrap(err, "failed to revoke mfa token")
+	}
+	return nil
+}
+
+// signMFAPendingToken generates the token proving the password step of a login, see MFAPendingClaims.
+func signMFAPendingToken(signer TokenSigner, userID string, enrollmentRequired bool, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := MFAPendingClaims{
+		UserID:             userID,
+		MFAPending:         true,
+		EnrollmentRequired: enrollmentRequired,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			Subject:   userID,
+			ID:        uuid.NewString(),
+		},
+	}
+
+	tokenString, err := signer.Sign(claims)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate mfa token")
+	}
+
+	return tokenString, nil
+}
+
+func mfaRequired(cfg config.MFAConfigParams, role constants.RoleType) bool {
+	return cfg.RequiredForPrivilegedRoles && (role == constants.RoleAdmin || role == constants.RoleKoorprodi)
+}
+
+// generateRecoveryCodes returns the recovery codes formatted as "xxxx-xxxx" together with their hashes.
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+
+	for i := 0; i < recoveryCodeCount; i++ {
+		buf := make([]byte, 5)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to generate recovery code")
+		}
+
+		code := strings.ToLower(base32.StdEncoding.EncodeToString(buf))
+		codes = append(codes, code[:4]+"-"+code[4:])
+		hashes = append(hashes, hashOpaqueToken(code))
+	}
+
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode accepts recovery codes typed with or without the dash, in any case.
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.ReplaceAll(code, "-", "")
+}
@@ -0,0 +1,364 @@
+package usecases
+
+import (
+	"context"
+	"math/big"
+	"regexp"
+	"siakad-poc/common"
+	"siakad-poc/common/jwtkeys"
+	"siakad-poc/common/totp"
+	"siakad-poc/config"
+	"siakad-poc/constants"
+	"siakad-poc/db/generated"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Mock MFA repository
+type MockMFARepository struct {
+	mock.Mock
+}
+
+func (m *MockMFARepository) GetUserMFA(ctx context.Context, userID string) (generated.UserMfa, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(generated.UserMfa), args.Error(1)
+}
+
+func (m *MockMFARepository) StartUserMFAEnrollment(ctx context.Context, userID, secret string) (generated.UserMfa, error) {
+	args := m.Called(ctx, userID, secret)
+	return args.Get(0).(generated.UserMfa), args.Error(1)
+}
+
+func (m *MockMFARepository) UseUserMFAStep(ctx context.Context, userID string, step int64) (generated.UserMfa, error) {
+	args := m.Called(ctx, userID, step)
+	return args.Get(0).(generated.UserMfa), args.Error(1)
+}
+
+func (m *MockMFARepository) UseMFARecoveryCode(ctx context.Context, userID, codeHash string) (generated.MfaRecoveryCode, error) {
+	args := m.Called(ctx, userID, codeHash)
+	return args.Get(0).(generated.MfaRecoveryCode), args.Error(1)
+}
+
+func (m *MockMFARepository) CountUnusedMFARecoveryCodes(ctx context.Context, userID string) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockMFARepository) ConfirmUserMFATx(txCtx *common.TxContext, userID string, step int64) (generated.UserMfa, error) {
+	args := m.Called(txCtx, userID, step)
+	return args.Get(0).(generated.UserMfa), args.Error(1)
+}
+
+func (m *MockMFARepository) ReplaceMFARecoveryCodesTx(txCtx *common.TxContext, userID string, codeHashes []string) error {
+	args := m.Called(txCtx, userID, codeHashes)
+	return args.Error(0)
+}
+
+func (m *MockMFARepository) DeleteUserMFATx(txCtx *common.TxContext, userID string) error {
+	args := m.Called(txCtx, userID)
+	return args.Error(0)
+}
+
+const (
+	mfaUserID       = "550e8400-e29b-41d4-a716-446655440051"
+	mfaEmail        = "admin@example.com"
+	mfaClientIP     = "203.0.113.9"
+	mfaUserPassword = "c0rrect-Passw0rd"
+)
+
+// Test Suite
+type MFAUseCaseTestSuite struct {
+	suite.Suite
+	useCase            *MFAUseCase
+	mockUserRepo       *MockUserRepository
+	mockMFARepo        *MockMFARepository
+	mockRevocationRepo *MockTokenRevocationRepository
+	mockThrottleRepo   *MockLoginThrottleRepository
+	keyring            *jwtkeys.Keyring
+	ctx                context.Context
+	secret             string
+}
+
+func (suite *MFAUseCaseTestSuite) SetupTest() {
+	keyring, err := jwtkeys.Load(config.JWTConfigParams{Secret: "test-secret"})
+	suite.Require().NoError(err)
+	secret, err := totp.GenerateSecret()
+	suite.Require().NoError(err)
+
+	suite.keyring = keyring
+	suite.secret = secret
+	suite.mockUserRepo = new(MockUserRepository)
+	suite.mockMFARepo = new(MockMFARepository)
+	suite.mockRevocationRepo = new(MockTokenRevocationRepository)
+	suite.mockThrottleRepo = new(MockLoginThrottleRepository)
+	suite.useCase = NewMFAUseCase(suite.mockUserRepo, suite.mockMFARepo, suite.mockRevocationRepo, suite.mockThrottleRepo,
+		new(common.MockTransactionExecutor), suite.keyring, config.AuthConfigParams{MFA: config.MFAConfigParams{RequiredForPrivilegedRoles: true}})
+	suite.ctx = context.Background()
+}
+
+func (suite *MFAUseCaseTestSuite) TearDownTest() {
+	suite.mockUserRepo.AssertExpectations(suite.T())
+	suite.mockMFARepo.AssertExpectations(suite.T())
+	suite.mockRevocationRepo.AssertExpectations(suite.T())
+	suite.mockThrottleRepo.AssertExpectations(suite.T())
+}
+
+func (suite *MFAUseCaseTestSuite) user(role constants.RoleType) generated.User {
+	return generated.User{
+		ID:    testUUID(mfaUserID),
+		Email: mfaEmail,
+		Role:  pgtype.Numeric{Int: big.NewInt(role), Valid: true},
+	}
+}
+
+// storedMFA returns the TOTP enrollment of the user, either confirmed or still pending
+func (suite *MFAUseCaseTestSuite) storedMFA(confirmed bool) generated.UserMfa {
+	return generated.UserMfa{
+		UserID:      testUUID(mfaUserID),
+		Secret:      suite.secret,
+		ConfirmedAt: pgtype.Timestamptz{Time: time.Now().Add(-24 * time.Hour), Valid: confirmed},
+	}
+}
+
+// currentCode returns the TOTP code of the current time step together with the step
+func (suite *MFAUseCaseTestSuite) currentCode() (string, int64) {
+	step := totp.Step(time.Now())
+	code, err := totp.Code(suite.secret, step)
+	suite.Require().NoError(err)
+	return code, step
+}
+
+// pendingToken signs the MFA pending token handed out by the password step and returns it with its claims
+func (suite *MFAUseCaseTestSuite) pendingToken(enrollmentRequired bool) (string, *MFAPendingClaims) {
+	tokenString, err := signMFAPendingToken(suite.keyring, mfaUserID, enrollmentRequired, 5*time.Minute)
+	suite.Require().NoError(err)
+
+	claims := &MFAPendingClaims{}
+	_, err = jwt.ParseWithClaims(tokenString, claims, suite.keyring.Keyfunc)
+	suite.Require().NoError(err)
+	return tokenString, claims
+}
+
+// expectPendingTokenAccepted sets up the pending token check and the lookup of its active user
+func (suite *MFAUseCaseTestSuite) expectPendingTokenAccepted(claims *MFAPendingClaims, role constants.RoleType) {
+	suite.mockRevocationRepo.On("IsTokenRevoked", suite.ctx, claims.ID, mfaUserID, claims.IssuedAt.Time).Return(false, nil)
+	suite.mockUserRepo.On("GetUser", suite.ctx, mfaUserID).Return(suite.user(role), nil)
+	suite.mockThrottleRepo.On("GetLoginThrottle", suite.ctx, mock.Anything, mock.Anything).Return(generated.LoginThrottle{}, pgx.ErrNoRows)
+}
+
+// expectLoginCompleted sets up the throttle reset, the pending token consumption and the new token pair
+func (suite *MFAUseCaseTestSuite) expectLoginCompleted(claims *MFAPendingClaims) {
+	suite.mockThrottleRepo.On("DeleteLoginThrottle", suite.ctx, mock.Anything, mfaEmail).Return(nil)
+	suite.mockRevocationRepo.On("RevokeAccessToken", suite.ctx, claims.ID, mfaUserID, claims.ExpiresAt.Time).Return(nil)
+	suite.mockUserRepo.On("CreateRefreshToken", suite.ctx, mfaUserID, mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(generated.RefreshToken{}, nil)
+}
+
+// expectFailureRecorded sets up a wrong code being counted against the account and the IP
+func (suite *MFAUseCaseTestSuite) expectFailureRecorded() {
+	suite.mockThrottleRepo.On("RecordLoginFailure", suite.ctx, mock.Anything, mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(generated.LoginThrottle{FailedAttempts: 1}, nil)
+}
+
+// Test confirming the enrollment with a valid code activates the secret at the code's step and stores the
+// hashes of the recovery codes handed out
+func (suite *MFAUseCaseTestSuite) TestConfirmEnrollment_Success() {
+	code, step := suite.currentCode()
+	suite.mockMFARepo.On("GetUserMFA", suite.ctx, mfaUserID).Return(suite.storedMFA(false), nil)
+	suite.mockMFARepo.On("ConfirmUserMFATx", mock.AnythingOfType("*common.TxContext"), mfaUserID, step).Return(suite.storedMFA(true), nil)
+	suite.mockMFARepo.On("ReplaceMFARecoveryCodesTx", mock.AnythingOfType("*common.TxContext"), mfaUserID, mock.AnythingOfType("[]string")).Return(nil)
+
+	recoveryCodes, err := suite.useCase.ConfirmEnrollment(suite.ctx, mfaUserID, code)
+
+	assert.NoError(suite.T(), err)
+	suite.Require().Len(recoveryCodes, recoveryCodeCount)
+	codeHashes := suite.mockMFARepo.Calls[2].Arguments.Get(2).([]string)
+	for i, recoveryCode := range recoveryCodes {
+		assert.Regexp(suite.T(), regexp.MustCompile(`^[a-z2-7]{4}-[a-z2-7]{4}$`), recoveryCode)
+		assert.Equal(suite.T(), hashOpaqueToken(normalizeRecoveryCode(recoveryCode)), codeHashes[i])
+	}
+}
+
+// Test a wrong code doesn't activate the secret
+func (suite *MFAUseCaseTestSuite) TestConfirmEnrollment_WrongCode() {
+	suite.mockMFARepo.On("GetUserMFA", suite.ctx, mfaUserID).Return(suite.storedMFA(false), nil)
+
+	_, err := suite.useCase.ConfirmEnrollment(suite.ctx, mfaUserID, "abcdef")
+
+	assert.True(suite.T(), errors.Is(err, ErrInvalidMFACode))
+	suite.mockMFARepo.AssertNotCalled(suite.T(), "ConfirmUserMFATx", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Test an enrollment can't be confirmed twice
+func (suite *MFAUseCaseTestSuite) TestConfirmEnrollment_AlreadyConfirmed() {
+	code, _ := suite.currentCode()
+	suite.mockMFARepo.On("GetUserMFA", suite.ctx, mfaUserID).Return(suite.storedMFA(true), nil)
+
+	_, err := suite.useCase.ConfirmEnrollment(suite.ctx, mfaUserID, code)
+
+	assert.True(suite.T(), errors.Is(err, ErrMFAAlreadyEnabled))
+	suite.mockMFARepo.AssertNotCalled(suite.T(), "ConfirmUserMFATx", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Test a valid TOTP code completes the login, records its step and consumes the pending token
+func (suite *MFAUseCaseTestSuite) TestCompleteLogin_TOTP() {
+	mfaToken, claims := suite.pendingToken(false)
+	code, step := suite.currentCode()
+	suite.expectPendingTokenAccepted(claims, constants.RoleAdmin)
+	suite.mockMFARepo.On("GetUserMFA", suite.ctx, mfaUserID).Return(suite.storedMFA(true), nil)
+	suite.mockMFARepo.On("UseUserMFAStep", suite.ctx, mfaUserID, step).Return(suite.storedMFA(true), nil)
+	suite.expectLoginCompleted(claims)
+
+	tokenPair, err := suite.useCase.CompleteLogin(suite.ctx, mfaToken, code, "", mfaClientIP)
+
+	assert.NoError(suite.T(), err)
+	assert.NotEmpty(suite.T(), tokenPair.AccessToken)
+	assert.NotEmpty(suite.T(), tokenPair.RefreshToken)
+}
+
+// Test a TOTP code whose step was already used is rejected as a replay and counted as a failure
+func (suite *MFAUseCaseTestSuite) TestCompleteLogin_ReplayedCode() {
+	mfaToken, claims := suite.pendingToken(false)
+	code, step := suite.currentCode()
+	suite.expectPendingTokenAccepted(claims, constants.RoleAdmin)
+	suite.mockMFARepo.On("GetUserMFA", suite.ctx, mfaUserID).Return(suite.storedMFA(true), nil)
+	suite.mockMFARepo.On("UseUserMFAStep", suite.ctx, mfaUserID, step).Return(generated.UserMfa{}, pgx.ErrNoRows)
+	suite.expectFailureRecorded()
+
+	_, err := suite.useCase.CompleteLogin(suite.ctx, mfaToken, code, "", mfaClientIP)
+
+	assert.True(suite.T(), errors.Is(err, ErrInvalidMFACode))
+	suite.mockRevocationRepo.AssertNotCalled(suite.T(), "RevokeAccessToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	suite.mockUserRepo.AssertNotCalled(suite.T(), "CreateRefreshToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Test a recovery code completes the login, typed in any case and with or without the dash
+func (suite *MFAUseCaseTestSuite) TestCompleteLogin_RecoveryCode() {
+	mfaToken, claims := suite.pendingToken(false)
+	suite.expectPendingTokenAccepted(claims, constants.RoleAdmin)
+	suite.mockMFARepo.On("UseMFARecoveryCode", suite.ctx, mfaUserID, hashOpaqueToken("abcd2345")).Return(generated.MfaRecoveryCode{}, nil)
+	suite.expectLoginCompleted(claims)
+
+	_, err := suite.useCase.CompleteLogin(suite.ctx, mfaToken, "", " ABCD-2345 ", mfaClientIP)
+
+	assert.NoError(suite.T(), err)
+	suite.mockMFARepo.AssertNotCalled(suite.T(), "UseUserMFAStep", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Test a recovery code can only be used once
+func (suite *MFAUseCaseTestSuite) TestCompleteLogin_RecoveryCodeAlreadyUsed() {
+	mfaToken, claims := suite.pendingToken(false)
+	suite.expectPendingTokenAccepted(claims, constants.RoleAdmin)
+	suite.mockMFARepo.On("UseMFARecoveryCode", suite.ctx, mfaUserID, hashOpaqueToken("abcd2345")).Return(generated.MfaRecoveryCode{}, pgx.ErrNoRows)
+	suite.expectFailureRecorded()
+
+	_, err := suite.useCase.CompleteLogin(suite.ctx, mfaToken, "", "abcd-2345", mfaClientIP)
+
+	assert.True(suite.T(), errors.Is(err, ErrInvalidMFACode))
+	suite.mockRevocationRepo.AssertNotCalled(suite.T(), "RevokeAccessToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Test an MFA pending token that already completed a login can't be used again
+func (suite *MFAUseCaseTestSuite) TestCompleteLogin_PendingTokenReused() {
+	mfaToken, claims := suite.pendingToken(false)
+	code, _ := suite.currentCode()
+	suite.mockRevocationRepo.On("IsTokenRevoked", suite.ctx, claims.ID, mfaUserID, claims.IssuedAt.Time).Return(true, nil)
+
+	_, err := suite.useCase.CompleteLogin(suite.ctx, mfaToken, code, "", mfaClientIP)
+
+	assert.True(suite.T(), errors.Is(err, ErrInvalidMFAToken))
+	suite.mockUserRepo.AssertNotCalled(suite.T(), "GetUser", mock.Anything, mock.Anything)
+}
+
+// Test an access token can't stand in for the MFA pending token
+func (suite *MFAUseCaseTestSuite) TestCompleteLogin_AccessToken() {
+	accessToken, err := signAccessToken(suite.keyring, mfaUserID, constants.RoleAdmin)
+	suite.Require().NoError(err)
+	code, _ := suite.currentCode()
+
+	_, err = suite.useCase.CompleteLogin(suite.ctx, accessToken, code, "", mfaClientIP)
+
+	assert.True(suite.T(), errors.Is(err, ErrInvalidMFAToken))
+}
+
+// Test a user who still has to enroll can't complete the login with a code
+func (suite *MFAUseCaseTestSuite) TestCompleteLogin_EnrollmentRequired() {
+	mfaToken, claims := suite.pendingToken(true)
+	code, _ := suite.currentCode()
+	suite.mockRevocationRepo.On("IsTokenRevoked", suite.ctx, claims.ID, mfaUserID, claims.IssuedAt.Time).Return(false, nil)
+
+	_, err := suite.useCase.CompleteLogin(suite.ctx, mfaToken, code, "", mfaClientIP)
+
+	assert.True(suite.T(), errors.Is(err, ErrMFAEnrollmentRequired))
+}
+
+// Test the password step of a privileged user without TOTP asks for an enrollment instead of issuing tokens,
+// while other roles are logged in directly
+func (suite *MFAUseCaseTestSuite) TestLogin_MandatoryForPrivilegedRoles() {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(mfaUserPassword), bcrypt.MinCost)
+	suite.Require().NoError(err)
+
+	testCases := []struct {
+		name               string
+		role               constants.RoleType
+		enrollmentRequired bool
+	}{
+		{"admin", constants.RoleAdmin, true},
+		{"koorprodi", constants.RoleKoorprodi, true},
+		{"student", constants.RoleStudent, false},
+	}
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+			defer suite.TearDownTest()
+			loginUseCase := NewLoginUseCase(suite.mockUserRepo, suite.mockMFARepo, suite.mockThrottleRepo, suite.keyring,
+				config.AuthConfigParams{MFA: config.MFAConfigParams{RequiredForPrivilegedRoles: true}})
+
+			user := suite.user(tc.role)
+			user.Password = string(hashedPassword)
+			suite.mockThrottleRepo.On("GetLoginThrottle", suite.ctx, mock.Anything, mock.Anything).Return(generated.LoginThrottle{}, pgx.ErrNoRows)
+			suite.mockUserRepo.On("GetUserByEmail", suite.ctx, mfaEmail).Return(user, nil)
+			suite.mockThrottleRepo.On("DeleteLoginThrottle", suite.ctx, mock.Anything, mfaEmail).Return(nil)
+			suite.mockMFARepo.On("GetUserMFA", suite.ctx, mfaUserID).Return(generated.UserMfa{}, pgx.ErrNoRows)
+			if !tc.enrollmentRequired {
+				suite.mockUserRepo.On("CreateRefreshToken", suite.ctx, mfaUserID, mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(generated.RefreshToken{}, nil)
+			}
+
+			result, err := loginUseCase.Login(suite.ctx, mfaEmail, mfaUserPassword, mfaClientIP)
+
+			suite.Require().NoError(err)
+			if tc.enrollmentRequired {
+				suite.Require().NotNil(result.MFAChallenge)
+				assert.True(suite.T(), result.MFAChallenge.EnrollmentRequired)
+				assert.Empty(suite.T(), result.Tokens.AccessToken)
+			} else {
+				assert.Nil(suite.T(), result.MFAChallenge)
+				assert.NotEmpty(suite.T(), result.Tokens.AccessToken)
+			}
+		})
+	}
+}
+
+// Test a privileged user can't turn TOTP off while it is mandatory for their role
+func (suite *MFAUseCaseTestSuite) TestDisable_MandatoryForRole() {
+	code, _ := suite.currentCode()
+	suite.mockUserRepo.On("GetUser", suite.ctx, mfaUserID).Return(suite.user(constants.RoleKoorprodi), nil)
+
+	err := suite.useCase.Disable(suite.ctx, mfaUserID, code)
+
+	assert.True(suite.T(), errors.Is(err, ErrMFARequired))
+	suite.mockMFARepo.AssertNotCalled(suite.T(), "DeleteUserMFATx", mock.Anything, mock.Anything)
+}
+
+// Run the test suite
+func TestMFAUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(MFAUseCaseTestSuite))
+}
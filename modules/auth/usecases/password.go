@@ -0,0 +1,163 @@
+package usecases
+
+import (
+	"context"
+	"siakad-poc/common"
+	"siakad-poc/config"
+	"siakad-poc/db/repositories"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type PasswordUseCase struct {
+	userRepository            repositories.UserRepository
+	tokenRevocationRepository repositories.TokenRevocationRepository
+	txExecutor                common.TransactionExecutor
+}
+
+// PasswordResetToken is the one-time token handed to an admin, who passes it on to the user out of band.
+type PasswordResetToken struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+func NewPasswordUseCase(
+	userRepository repositories.UserRepository,
+	tokenRevocationRepository repositories.TokenRevocationRepository,
+	txExecutor common.TransactionExecutor,
+) *PasswordUseCase {
+	return &PasswordUseCase{
+		userRepository:            userRepository,
+		tokenRevocationRepository: tokenRevocationRepository,
+		txExecutor:                txExecutor,
+	}
+}
+
+// ChangePassword replaces the password of the authenticated user after verifying the current one.
+// All existing sessions, including the one used for this request, are revoked afterwards.
+func (u *PasswordUseCase) ChangePassword(ctx context.Context, userID, currentPassword, newPassword string) error {
+	user, err := u.userRepository.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return errors.Wrap(err, "failed to get user")
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(currentPassword))
+	if err != nil {
+		return ErrInvalidCurrentPassword
+	}
+
+	hashedPassword, err := hashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	_, err = u.userRepository.UpdateUserPassword(ctx, userID, hashedPassword)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return errors.Wrap(err, "failed to update password")
+	}
+
+	return revokeUserSessions(ctx, u.userRepository, u.tokenRevocationRepository, userID)
+}
+
+// IssuePasswordResetToken creates a one-time reset token for the user, superseding any token issued earlier.
+func (u *PasswordUseCase) IssuePasswordResetToken(ctx context.Context, userID, issuedBy string) (PasswordResetToken, error) {
+	token, tokenHash, err := generateOpaqueToken()
+	if err != nil {
+		return PasswordResetToken{}, err
+	}
+
+	expiresAt := time.Now().Add(config.CurrentConfig.Auth.PasswordResetTokenTTL())
+
+	err = u.txExecutor.WithTxContext(ctx, func(txCtx *common.TxContext) error {
+		_, err := u.userRepository.GetUserTx(txCtx, userID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrUserNotFound
+			}
+			return errors.Wrap(err, "failed to get user")
+		}
+
+		err = u.userRepository.InvalidatePasswordResetTokensTx(txCtx, userID)
+		if err != nil {
+			return errors.Wrap(err, "failed to invalidate previous password reset tokens")
+		}
+
+		_, err = u.userRepository.CreatePasswordResetTokenTx(txCtx, userID, tokenHash, expiresAt, issuedBy)
+		if err != nil {
+			return errors.Wrap(err, "failed to store password reset token")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return PasswordResetToken{}, err
+	}
+
+	return PasswordResetToken{
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// ResetPassword consumes a reset token and sets the new password. The token can only be used once,
+// and all existing sessions of the user are revoked afterwards.
+func (u *PasswordUseCase) ResetPassword(ctx context.Context, token, newPassword string) error {
+	hashedPassword, err := hashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	var userID string
+	err = u.txExecutor.WithTxContext(ctx, func(txCtx *common.TxContext) error {
+		// Lock the token row so concurrent resets can't consume it twice
+		resetToken, err := u.userRepository.GetPasswordResetTokenByHashTx(txCtx, hashOpaqueToken(token))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrInvalidPasswordResetToken
+			}
+			return errors.Wrap(err, "failed to get password reset token")
+		}
+
+		if resetToken.UsedAt.Valid || !resetToken.ExpiresAt.Valid || time.Now().After(resetToken.ExpiresAt.Time) {
+			return ErrInvalidPasswordResetToken
+		}
+
+		_, err = u.userRepository.MarkPasswordResetTokenUsedTx(txCtx, resetToken.ID.String())
+		if err != nil {
+			return errors.Wrap(err, "failed to mark password reset token as used")
+		}
+
+		userID = resetToken.UserID.String()
+		_, err = u.userRepository.UpdateUserPasswordTx(txCtx, userID, hashedPassword)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrInvalidPasswordResetToken
+			}
+			return errors.Wrap(err, "failed to update password")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return revokeUserSessions(ctx, u.userRepository, u.tokenRevocationRepository, userID)
+}
+
+func hashPassword(password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to hash password")
+	}
+	return string(hashed), nil
+}
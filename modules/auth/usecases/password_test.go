@@ -0,0 +1,133 @@
+package usecases
+
+import (
+	"context"
+	"siakad-poc/common"
+	"siakad-poc/db/generated"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Mock token revocation repository for the auth use cases
+type MockTokenRevocationRepository struct {
+	mock.Mock
+}
+
+func (m *MockTokenRevocationRepository) RevokeAccessToken(ctx context.Context, jti, userID string, expiresAt time.Time) error {
+	args := m.Called(ctx, jti, userID, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockTokenRevocationRepository) RevokeUserSessions(ctx context.Context, userID string) (generated.UserSessionRevocation, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(generated.UserSessionRevocation), args.Error(1)
+}
+
+func (m *MockTokenRevocationRepository) IsTokenRevoked(ctx context.Context, jti, userID string, issuedAt time.Time) (bool, error) {
+	args := m.Called(ctx, jti, userID, issuedAt)
+	return args.Bool(0), args.Error(1)
+}
+
+const (
+	resetTokenID    = "550e8400-e29b-41d4-a716-446655440011"
+	resetUserID     = "550e8400-e29b-41d4-a716-446655440012"
+	presentedReset  = "presented-reset-token"
+	newPasswordText = "n3w-Secret-passw0rd"
+)
+
+// Test Suite
+type PasswordUseCaseTestSuite struct {
+	suite.Suite
+	useCase            *PasswordUseCase
+	mockRepo           *MockUserRepository
+	mockRevocationRepo *MockTokenRevocationRepository
+	ctx                context.Context
+}
+
+func (suite *PasswordUseCaseTestSuite) SetupTest() {
+	suite.mockRepo = new(MockUserRepository)
+	suite.mockRevocationRepo = new(MockTokenRevocationRepository)
+	suite.useCase = NewPasswordUseCase(suite.mockRepo, suite.mockRevocationRepo, new(common.MockTransactionExecutor))
+	suite.ctx = context.Background()
+}
+
+func (suite *PasswordUseCaseTestSuite) TearDownTest() {
+	suite.mockRepo.AssertExpectations(suite.T())
+	suite.mockRevocationRepo.AssertExpectations(suite.T())
+}
+
+// resetToken returns the stored row of the presented reset token, expiring after the given time
+func (suite *PasswordUseCaseTestSuite) resetToken(expiresIn time.Duration) generated.PasswordResetToken {
+	return generated.PasswordResetToken{
+		ID:        testUUID(resetTokenID),
+		UserID:    testUUID(resetUserID),
+		TokenHash: hashOpaqueToken(presentedReset),
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(expiresIn), Valid: true},
+	}
+}
+
+// Test a valid reset token sets the password, is consumed and revokes every session of the user
+func (suite *PasswordUseCaseTestSuite) TestResetPassword_Success() {
+	suite.mockRepo.On("GetPasswordResetTokenByHashTx", mock.AnythingOfType("*common.TxContext"), hashOpaqueToken(presentedReset)).Return(suite.resetToken(time.Hour), nil)
+	suite.mockRepo.On("MarkPasswordResetTokenUsedTx", mock.AnythingOfType("*common.TxContext"), resetTokenID).Return(generated.PasswordResetToken{}, nil)
+	suite.mockRepo.On("UpdateUserPasswordTx", mock.AnythingOfType("*common.TxContext"), resetUserID, mock.AnythingOfType("string")).Return(generated.User{}, nil)
+	suite.mockRepo.On("RevokeUserRefreshTokens", suite.ctx, resetUserID).Return(nil)
+	suite.mockRevocationRepo.On("RevokeUserSessions", suite.ctx, resetUserID).Return(generated.UserSessionRevocation{}, nil)
+
+	err := suite.useCase.ResetPassword(suite.ctx, presentedReset, newPasswordText)
+
+	assert.NoError(suite.T(), err)
+
+	// The new password is stored hashed
+	hashedPassword := suite.mockRepo.Calls[2].Arguments.String(2)
+	assert.NoError(suite.T(), bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(newPasswordText)))
+}
+
+// Test a reset token can only be used once
+func (suite *PasswordUseCaseTestSuite) TestResetPassword_TokenAlreadyUsed() {
+	resetToken := suite.resetToken(time.Hour)
+	resetToken.UsedAt = pgtype.Timestamptz{Time: time.Now().Add(-time.Minute), Valid: true}
+
+	suite.mockRepo.On("GetPasswordResetTokenByHashTx", mock.AnythingOfType("*common.TxContext"), hashOpaqueToken(presentedReset)).Return(resetToken, nil)
+
+	err := suite.useCase.ResetPassword(suite.ctx, presentedReset, newPasswordText)
+
+	assert.True(suite.T(), errors.Is(err, ErrInvalidPasswordResetToken))
+	suite.mockRepo.AssertNotCalled(suite.T(), "MarkPasswordResetTokenUsedTx")
+	suite.mockRepo.AssertNotCalled(suite.T(), "UpdateUserPasswordTx")
+	suite.mockRevocationRepo.AssertNotCalled(suite.T(), "RevokeUserSessions")
+}
+
+// Test an expired reset token is rejected
+func (suite *PasswordUseCaseTestSuite) TestResetPassword_TokenExpired() {
+	suite.mockRepo.On("GetPasswordResetTokenByHashTx", mock.AnythingOfType("*common.TxContext"), hashOpaqueToken(presentedReset)).Return(suite.resetToken(-time.Minute), nil)
+
+	err := suite.useCase.ResetPassword(suite.ctx, presentedReset, newPasswordText)
+
+	assert.True(suite.T(), errors.Is(err, ErrInvalidPasswordResetToken))
+	suite.mockRepo.AssertNotCalled(suite.T(), "MarkPasswordResetTokenUsedTx")
+	suite.mockRepo.AssertNotCalled(suite.T(), "UpdateUserPasswordTx")
+}
+
+// Test an unknown reset token is rejected
+func (suite *PasswordUseCaseTestSuite) TestResetPassword_UnknownToken() {
+	suite.mockRepo.On("GetPasswordResetTokenByHashTx", mock.AnythingOfType("*common.TxContext"), hashOpaqueToken(presentedReset)).Return(generated.PasswordResetToken{}, pgx.ErrNoRows)
+
+	err := suite.useCase.ResetPassword(suite.ctx, presentedReset, newPasswordText)
+
+	assert.True(suite.T(), errors.Is(err, ErrInvalidPasswordResetToken))
+	suite.mockRepo.AssertNotCalled(suite.T(), "UpdateUserPasswordTx")
+}
+
+// Run the test suite
+func TestPasswordUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(PasswordUseCaseTestSuite))
+}
@@ -0,0 +1,149 @@
+package usecases
+
+import (
+	"context"
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+type StudyProgramResponse struct {
+	ID    string `json:"id"`
+	Code  string `json:"code"`
+	Name  string `json:"name"`
+	Level string `json:"level"`
+}
+
+type AcademicAdvisorResponse struct {
+	ID   string `json:"id"`
+	NIDN string `json:"nidn"`
+	Name string `json:"name"`
+}
+
+type StudentProfileResponse struct {
+	ID              string                   `json:"id"`
+	NIM             string                   `json:"nim"`
+	StudyProgram    StudyProgramResponse     `json:"study_program"`
+	AcademicAdvisor *AcademicAdvisorResponse `json:"academic_advisor"`
+}
+
+type LecturerProfileResponse struct {
+	ID                   string               `json:"id"`
+	NIDN                 string               `json:"nidn"`
+	NUPTK                *string              `json:"nuptk"`
+	HomebaseStudyProgram StudyProgramResponse `json:"homebase_study_program"`
+}
+
+// ProfileResponse is the current user, Student or Lecturer is set when the user is linked to such a record
+type ProfileResponse struct {
+	ID             string                   `json:"id"`
+	Email          string                   `json:"email"`
+	Name           string                   `json:"name"`
+	Role           int64                    `json:"role"`
+	StudyProgramID *string                  `json:"study_program_id"`
+	CreatedAt      time.Time                `json:"created_at"`
+	Student        *StudentProfileResponse  `json:"student"`
+	Lecturer       *LecturerProfileResponse `json:"lecturer"`
+}
+
+type ProfileUseCase struct {
+	userRepository     repositories.UserRepository
+	studentRepository  repositories.StudentRepository
+	lecturerRepository repositories.LecturerRepository
+}
+
+func NewProfileUseCase(userRepository repositories.UserRepository, studentRepository repositories.StudentRepository, lecturerRepository repositories.LecturerRepository) *ProfileUseCase {
+	return &ProfileUseCase{
+		userRepository:     userRepository,
+		studentRepository:  studentRepository,
+		lecturerRepository: lecturerRepository,
+	}
+}
+
+// GetProfile returns the user of the token together with their student or lecturer record.
+func (u *ProfileUseCase) GetProfile(ctx context.Context, userID string) (ProfileResponse, error) {
+	user, err := u.userRepository.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ProfileResponse{}, ErrUserNotFound
+		}
+		return ProfileResponse{}, errors.Wrap(err, "failed to get user")
+	}
+	if user.DeletedAt.Valid {
+		return ProfileResponse{}, ErrUserNotFound
+	}
+
+	response := ProfileResponse{
+		ID:    user.ID.String(),
+		Email: user.Email,
+		Name:  user.Name.String,
+		Role:  user.Role.Int.Int64(),
+	}
+	if user.StudyProgramID.Valid {
+		studyProgramID := user.StudyProgramID.String()
+		response.StudyProgramID = &studyProgramID
+	}
+	if user.CreatedAt.Valid {
+		response.CreatedAt = user.CreatedAt.Time
+	}
+
+	student, err := u.studentRepository.GetStudentProfileByUserID(ctx, userID)
+	if err == nil {
+		response.Student = toStudentProfileResponse(student)
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return ProfileResponse{}, errors.Wrap(err, "failed to get student")
+	}
+
+	lecturer, err := u.lecturerRepository.GetLecturerProfileByUserID(ctx, userID)
+	if err == nil {
+		response.Lecturer = toLecturerProfileResponse(lecturer)
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return ProfileResponse{}, errors.Wrap(err, "failed to get lecturer")
+	}
+
+	return response, nil
+}
+
+func toStudentProfileResponse(student generated.GetStudentProfileByUserIDRow) *StudentProfileResponse {
+	response := &StudentProfileResponse{
+		ID:  student.ID.String(),
+		NIM: student.Nim,
+		StudyProgram: StudyProgramResponse{
+			ID:    student.StudyProgramID.String(),
+			Code:  student.StudyProgramCode,
+			Name:  student.StudyProgramName,
+			Level: student.StudyProgramLevel,
+		},
+	}
+	if student.AcademicAdvisorID.Valid {
+		response.AcademicAdvisor = &AcademicAdvisorResponse{
+			ID:   student.AcademicAdvisorID.String(),
+			NIDN: student.AcademicAdvisorNidn.String,
+			Name: student.AcademicAdvisorName.String,
+		}
+	}
+
+	return response
+}
+
+func toLecturerProfileResponse(lecturer generated.GetLecturerProfileByUserIDRow) *LecturerProfileResponse {
+	response := &LecturerProfileResponse{
+		ID:   lecturer.ID.String(),
+		NIDN: lecturer.Nidn,
+		HomebaseStudyProgram: StudyProgramResponse{
+			ID:    lecturer.HomebaseStudyProgramID.String(),
+			Code:  lecturer.HomebaseStudyProgramCode,
+			Name:  lecturer.HomebaseStudyProgramName,
+			Level: lecturer.HomebaseStudyProgramLevel,
+		},
+	}
+	if lecturer.Nuptk.Valid {
+		nuptk := lecturer.Nuptk.String
+		response.NUPTK = &nuptk
+	}
+
+	return response
+}
@@ -0,0 +1,323 @@
+package usecases
+
+import (
+	"context"
+	"math/big"
+	"siakad-poc/common"
+	"siakad-poc/constants"
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// Mock student repository, only GetStudentProfileByUserID is used by the profile use case
+type MockStudentRepository struct {
+	mock.Mock
+}
+
+func (m *MockStudentRepository) GetStudyProgram(ctx context.Context, id string) (generated.StudyProgram, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.StudyProgram), args.Error(1)
+}
+
+func (m *MockStudentRepository) GetStudyProgramsByCodes(ctx context.Context, codes []string) ([]generated.StudyProgram, error) {
+	args := m.Called(ctx, codes)
+	return args.Get(0).([]generated.StudyProgram), args.Error(1)
+}
+
+func (m *MockStudentRepository) GetExistingStudentNIMs(ctx context.Context, nims []string) ([]string, error) {
+	args := m.Called(ctx, nims)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockStudentRepository) GetStudentProfileByUserID(ctx context.Context, userID string) (generated.GetStudentProfileByUserIDRow, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(generated.GetStudentProfileByUserIDRow), args.Error(1)
+}
+
+func (m *MockStudentRepository) ListStudents(ctx context.Context, filter repositories.StudentFilter, limit, offset int) ([]generated.Student, error) {
+	args := m.Called(ctx, filter, limit, offset)
+	return args.Get(0).([]generated.Student), args.Error(1)
+}
+
+func (m *MockStudentRepository) CountStudents(ctx context.Context, filter repositories.StudentFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockStudentRepository) GetStudent(ctx context.Context, id string) (generated.Student, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.Student), args.Error(1)
+}
+
+func (m *MockStudentRepository) GetStudentByNIM(ctx context.Context, nim string) (generated.Student, error) {
+	args := m.Called(ctx, nim)
+	return args.Get(0).(generated.Student), args.Error(1)
+}
+
+func (m *MockStudentRepository) GetStudentByUserID(ctx context.Context, userID string) (generated.Student, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(generated.Student), args.Error(1)
+}
+
+func (m *MockStudentRepository) CreateStudent(ctx context.Context, id, userID string, attributes repositories.StudentAttributes) (generated.Student, error) {
+	args := m.Called(ctx, id, userID, attributes)
+	return args.Get(0).(generated.Student), args.Error(1)
+}
+
+func (m *MockStudentRepository) UpdateStudent(ctx context.Context, id string, attributes repositories.StudentAttributes) (generated.Student, error) {
+	args := m.Called(ctx, id, attributes)
+	return args.Get(0).(generated.Student), args.Error(1)
+}
+
+func (m *MockStudentRepository) DeleteStudent(ctx context.Context, id string) (generated.Student, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.Student), args.Error(1)
+}
+
+func (m *MockStudentRepository) CountStudentsByAcademicAdvisor(ctx context.Context, lecturerID string) (int64, error) {
+	args := m.Called(ctx, lecturerID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockStudentRepository) CreateStudentsTx(txCtx *common.TxContext, students []repositories.NewStudent) (int64, error) {
+	args := m.Called(txCtx, students)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// Mock lecturer repository, only GetLecturerProfileByUserID is used by the profile use case
+type MockLecturerRepository struct {
+	mock.Mock
+}
+
+func (m *MockLecturerRepository) GetLecturerProfileByUserID(ctx context.Context, userID string) (generated.GetLecturerProfileByUserIDRow, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(generated.GetLecturerProfileByUserIDRow), args.Error(1)
+}
+
+func (m *MockLecturerRepository) ListLecturers(ctx context.Context, filter repositories.LecturerFilter, limit, offset int) ([]generated.Lecturer, error) {
+	args := m.Called(ctx, filter, limit, offset)
+	return args.Get(0).([]generated.Lecturer), args.Error(1)
+}
+
+func (m *MockLecturerRepository) CountLecturers(ctx context.Context, filter repositories.LecturerFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockLecturerRepository) GetLecturer(ctx context.Context, id string) (generated.Lecturer, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.Lecturer), args.Error(1)
+}
+
+func (m *MockLecturerRepository) LockLecturersTx(txCtx *common.TxContext, ids []string) error {
+	args := m.Called(txCtx, ids)
+	return args.Error(0)
+}
+
+func (m *MockLecturerRepository) GetLecturerByNIDN(ctx context.Context, nidn string) (generated.Lecturer, error) {
+	args := m.Called(ctx, nidn)
+	return args.Get(0).(generated.Lecturer), args.Error(1)
+}
+
+func (m *MockLecturerRepository) GetLecturerByUserID(ctx context.Context, userID string) (generated.Lecturer, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(generated.Lecturer), args.Error(1)
+}
+
+func (m *MockLecturerRepository) CreateLecturer(ctx context.Context, id, userID string, attributes repositories.LecturerAttributes) (generated.Lecturer, error) {
+	args := m.Called(ctx, id, userID, attributes)
+	return args.Get(0).(generated.Lecturer), args.Error(1)
+}
+
+func (m *MockLecturerRepository) UpdateLecturer(ctx context.Context, id string, attributes repositories.LecturerAttributes) (generated.Lecturer, error) {
+	args := m.Called(ctx, id, attributes)
+	return args.Get(0).(generated.Lecturer), args.Error(1)
+}
+
+func (m *MockLecturerRepository) DeleteLecturer(ctx context.Context, id string) (generated.Lecturer, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.Lecturer), args.Error(1)
+}
+
+const (
+	profileUserID       = "550e8400-e29b-41d4-a716-446655440091"
+	profileStudentID    = "550e8400-e29b-41d4-a716-446655440092"
+	profileLecturerID   = "550e8400-e29b-41d4-a716-446655440093"
+	profileProgramID    = "550e8400-e29b-41d4-a716-446655440094"
+	profileAdvisorID    = "550e8400-e29b-41d4-a716-446655440095"
+	profileEmail        = "profile@example.com"
+	profileStudyProgram = "Informatika"
+)
+
+// Test Suite
+type ProfileUseCaseTestSuite struct {
+	suite.Suite
+	useCase          *ProfileUseCase
+	mockUserRepo     *MockUserRepository
+	mockStudentRepo  *MockStudentRepository
+	mockLecturerRepo *MockLecturerRepository
+	ctx              context.Context
+}
+
+func (suite *ProfileUseCaseTestSuite) SetupTest() {
+	suite.mockUserRepo = new(MockUserRepository)
+	suite.mockStudentRepo = new(MockStudentRepository)
+	suite.mockLecturerRepo = new(MockLecturerRepository)
+	suite.useCase = NewProfileUseCase(suite.mockUserRepo, suite.mockStudentRepo, suite.mockLecturerRepo)
+	suite.ctx = context.Background()
+}
+
+func (suite *ProfileUseCaseTestSuite) TearDownTest() {
+	suite.mockUserRepo.AssertExpectations(suite.T())
+	suite.mockStudentRepo.AssertExpectations(suite.T())
+	suite.mockLecturerRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ProfileUseCaseTestSuite) user(role constants.RoleType) generated.User {
+	return generated.User{
+		ID:        testUUID(profileUserID),
+		Email:     profileEmail,
+		Name:      pgtype.Text{String: "Profile User", Valid: true},
+		Role:      pgtype.Numeric{Int: big.NewInt(role), Valid: true},
+		CreatedAt: pgtype.Timestamptz{Time: time.Now().Add(-24 * time.Hour), Valid: true},
+	}
+}
+
+// Test a student gets their student record with the study program and academic advisor
+func (suite *ProfileUseCaseTestSuite) TestGetProfile_Student() {
+	suite.mockUserRepo.On("GetUser", suite.ctx, profileUserID).Return(suite.user(constants.RoleStudent), nil)
+	suite.mockStudentRepo.On("GetStudentProfileByUserID", suite.ctx, profileUserID).Return(generated.GetStudentProfileByUserIDRow{
+		ID:                  testUUID(profileStudentID),
+		Nim:                 "2024010001",
+		StudyProgramID:      testUUID(profileProgramID),
+		StudyProgramCode:    "IF",
+		StudyProgramName:    profileStudyProgram,
+		StudyProgramLevel:   "S1",
+		AcademicAdvisorID:   testUUID(profileAdvisorID),
+		AcademicAdvisorNidn: pgtype.Text{String: "0012345678", Valid: true},
+		AcademicAdvisorName: pgtype.Text{String: "Advisor", Valid: true},
+	}, nil)
+	suite.mockLecturerRepo.On("GetLecturerProfileByUserID", suite.ctx, profileUserID).Return(generated.GetLecturerProfileByUserIDRow{}, pgx.ErrNoRows)
+
+	profile, err := suite.useCase.GetProfile(suite.ctx, profileUserID)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), profileUserID, profile.ID)
+	assert.Equal(suite.T(), constants.RoleStudent, profile.Role)
+	suite.Require().NotNil(profile.Student)
+	assert.Equal(suite.T(), profileStudentID, profile.Student.ID)
+	assert.Equal(suite.T(), profileStudyProgram, profile.Student.StudyProgram.Name)
+	suite.Require().NotNil(profile.Student.AcademicAdvisor)
+	assert.Equal(suite.T(), profileAdvisorID, profile.Student.AcademicAdvisor.ID)
+	assert.Nil(suite.T(), profile.Lecturer)
+}
+
+// Test a student without an academic advisor has none in their profile
+func (suite *ProfileUseCaseTestSuite) TestGetProfile_StudentWithoutAdvisor() {
+	suite.mockUserRepo.On("GetUser", suite.ctx, profileUserID).Return(suite.user(constants.RoleStudent), nil)
+	suite.mockStudentRepo.On("GetStudentProfileByUserID", suite.ctx, profileUserID).Return(generated.GetStudentProfileByUserIDRow{
+		ID:             testUUID(profileStudentID),
+		StudyProgramID: testUUID(profileProgramID),
+	}, nil)
+	suite.mockLecturerRepo.On("GetLecturerProfileByUserID", suite.ctx, profileUserID).Return(generated.GetLecturerProfileByUserIDRow{}, pgx.ErrNoRows)
+
+	profile, err := suite.useCase.GetProfile(suite.ctx, profileUserID)
+
+	assert.NoError(suite.T(), err)
+	suite.Require().NotNil(profile.Student)
+	assert.Nil(suite.T(), profile.Student.AcademicAdvisor)
+}
+
+// Test a lecturer gets their lecturer record with the homebase study program
+func (suite *ProfileUseCaseTestSuite) TestGetProfile_Lecturer() {
+	suite.mockUserRepo.On("GetUser", suite.ctx, profileUserID).Return(suite.user(constants.RoleKoorprodi), nil)
+	suite.mockStudentRepo.On("GetStudentProfileByUserID", suite.ctx, profileUserID).Return(generated.GetStudentProfileByUserIDRow{}, pgx.ErrNoRows)
+	suite.mockLecturerRepo.On("GetLecturerProfileByUserID", suite.ctx, profileUserID).Return(generated.GetLecturerProfileByUserIDRow{
+		ID:                       testUUID(profileLecturerID),
+		Nidn:                     "0012345678",
+		Nuptk:                    pgtype.Text{String: "1234567890123456", Valid: true},
+		HomebaseStudyProgramID:   testUUID(profileProgramID),
+		HomebaseStudyProgramName: profileStudyProgram,
+	}, nil)
+
+	profile, err := suite.useCase.GetProfile(suite.ctx, profileUserID)
+
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), profile.Student)
+	suite.Require().NotNil(profile.Lecturer)
+	assert.Equal(suite.T(), profileLecturerID, profile.Lecturer.ID)
+	suite.Require().NotNil(profile.Lecturer.NUPTK)
+	assert.Equal(suite.T(), "1234567890123456", *profile.Lecturer.NUPTK)
+	assert.Equal(suite.T(), profileProgramID, profile.Lecturer.HomebaseStudyProgram.ID)
+}
+
+// Test a user linked to neither record, such as an admin, only gets their account
+func (suite *ProfileUseCaseTestSuite) TestGetProfile_NeitherStudentNorLecturer() {
+	suite.mockUserRepo.On("GetUser", suite.ctx, profileUserID).Return(suite.user(constants.RoleAdmin), nil)
+	suite.mockStudentRepo.On("GetStudentProfileByUserID", suite.ctx, profileUserID).Return(generated.GetStudentProfileByUserIDRow{}, pgx.ErrNoRows)
+	suite.mockLecturerRepo.On("GetLecturerProfileByUserID", suite.ctx, profileUserID).Return(generated.GetLecturerProfileByUserIDRow{}, pgx.ErrNoRows)
+
+	profile, err := suite.useCase.GetProfile(suite.ctx, profileUserID)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), profileEmail, profile.Email)
+	assert.Equal(suite.T(), "Profile User", profile.Name)
+	assert.Nil(suite.T(), profile.StudyProgramID)
+	assert.Nil(suite.T(), profile.Student)
+	assert.Nil(suite.T(), profile.Lecturer)
+}
+
+// Test the profile of an unknown or soft-deleted user
+func (suite *ProfileUseCaseTestSuite) TestGetProfile_NotFound() {
+	deletedUser := suite.user(constants.RoleStudent)
+	deletedUser.DeletedAt = pgtype.Timestamptz{Time: time.Now().Add(-time.Hour), Valid: true}
+
+	testCases := []struct {
+		name string
+		user generated.User
+		err  error
+	}{
+		{"unknown", generated.User{}, pgx.ErrNoRows},
+		{"soft-deleted", deletedUser, nil},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+			defer suite.TearDownTest()
+
+			suite.mockUserRepo.On("GetUser", suite.ctx, profileUserID).Return(tc.user, tc.err)
+
+			_, err := suite.useCase.GetProfile(suite.ctx, profileUserID)
+
+			assert.True(suite.T(), errors.Is(err, ErrUserNotFound))
+			suite.mockStudentRepo.AssertNotCalled(suite.T(), "GetStudentProfileByUserID", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// Test a failing lookup of the student record is reported instead of an incomplete profile
+func (suite *ProfileUseCaseTestSuite) TestGetProfile_StudentLookupError() {
+	suite.mockUserRepo.On("GetUser", suite.ctx, profileUserID).Return(suite.user(constants.RoleStudent), nil)
+	suite.mockStudentRepo.On("GetStudentProfileByUserID", suite.ctx, profileUserID).Return(generated.GetStudentProfileByUserIDRow{}, errors.New("connection refused"))
+
+	_, err := suite.useCase.GetProfile(suite.ctx, profileUserID)
+
+	assert.Error(suite.T(), err)
+	assert.False(suite.T(), errors.Is(err, ErrUserNotFound))
+	suite.mockLecturerRepo.AssertNotCalled(suite.T(), "GetLecturerProfileByUserID", mock.Anything, mock.Anything)
+}
+
+// Run the test suite
+func TestProfileUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(ProfileUseCaseTestSuite))
+}
@@ -0,0 +1,113 @@
+package usecases
+
+import (
+	"context"
+	"siakad-poc/common"
+	"siakad-poc/config"
+	"siakad-poc/db/repositories"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+type RefreshTokenUseCase struct {
+	repository repositories.UserRepository
+	txExecutor common.TransactionExecutor
+	signer     TokenSigner
+}
+
+func NewRefreshTokenUseCase(repository repositories.UserRepository, txExecutor common.TransactionExecutor, signer TokenSigner) *RefreshTokenUseCase {
+	return &RefreshTokenUseCase{
+		repository: repository,
+		txExecutor: txExecutor,
+		signer:     signer,
+	}
+}
+
+// Refresh exchanges a refresh token for a new token pair.
+// Rules:
+// 1. The presented token must exist, must not be expired and must not be revoked
+// 2. The presented token is revoked and replaced by the newly issued one (rotation)
+// 3. Presenting an already rotated token is treated as theft - the whole token family is revoked
+func (u *RefreshTokenUseCase) Refresh(ctx context.Context, refreshToken string) (TokenPair, error) {
+	var (
+		tokenPair     TokenPair
+		reuseDetected bool
+	)
+
+	err := u.txExecutor.WithTxContext(ctx, func(txCtx *common.TxContext) error {
+		// Lock the presented token row so concurrent refreshes can't rotate it twice
+		storedToken, err := u.repository.GetRefreshTokenByHashTx(txCtx, hashOpaqueToken(refreshToken))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrInvalidRefreshToken
+			}
+			return errors.Wrap(err, "failed to get refresh token")
+		}
+
+		// Reuse detection: revoke the family and commit, the error is reported after the transaction
+		if storedToken.RevokedAt.Valid {
+			err = u.repository.RevokeRefreshTokenFamilyTx(txCtx, storedToken.FamilyID.String())
+			if err != nil {
+				return errors.Wrap(err, "failed to revoke refresh token family")
+			}
+			reuseDetected = true
+			return nil
+		}
+
+		if !storedToken.ExpiresAt.Valid || time.Now().After(storedToken.ExpiresAt.Time) {
+			return ErrInvalidRefreshToken
+		}
+
+		user, err := u.repository.GetUserTx(txCtx, storedToken.UserID.String())
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrInvalidRefreshToken
+			}
+			return errors.Wrap(err, "failed to get user")
+		}
+
+		// Disabled or deleted accounts can't extend their sessions
+		if user.DeletedAt.Valid || user.DisabledAt.Valid {
+			return ErrInvalidRefreshToken
+		}
+
+		newRefreshToken, newRefreshTokenHash, err := generateOpaqueToken()
+		if err != nil {
+			return err
+		}
+
+		expiresAt := time.Now().Add(config.CurrentConfig.JWT.RefreshTokenTTL())
+		createdToken, err := u.repository.CreateRefreshTokenTx(txCtx, user.ID.String(), storedToken.FamilyID.String(), newRefreshTokenHash, expiresAt)
+		if err != nil {
+			return errors.Wrap(err, "failed to store refresh token")
+		}
+
+		_, err = u.repository.RotateRefreshTokenTx(txCtx, storedToken.ID.String(), createdToken.ID.String())
+		if err != nil {
+			return errors.Wrap(err, "failed to rotate refresh token")
+		}
+
+		accessToken, err := signAccessToken(u.signer, user.ID.String(), user.Role.Int.Int64())
+		if err != nil {
+			return err
+		}
+
+		tokenPair = TokenPair{
+			AccessToken:  accessToken,
+			RefreshToken: newRefreshToken,
+			ExpiresIn:    int64(config.CurrentConfig.JWT.AccessTokenTTL().Seconds()),
+		}
+		return nil
+	})
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	if reuseDetected {
+		return TokenPair{}, ErrRefreshTokenReused
+	}
+
+	return tokenPair, nil
+}
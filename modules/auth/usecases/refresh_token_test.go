@@ -0,0 +1,319 @@
+package usecases
+
+import (
+	"context"
+	"math/big"
+	"siakad-poc/common"
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// Mock user repository for the auth use cases
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) GetUser(ctx context.Context, id string) (generated.User, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUserByEmail(ctx context.Context, email string) (generated.User, error) {
+	args := m.Called(ctx, email)
+	return args.Get(0).(generated.User), args.Error(1)
+}
+
+func (m *MockUserRepository) CreateUser(ctx context.Context, email, password, name string, role int64, studyProgramID string) (generated.User, error) {
+	args := m.Called(ctx, email, password, name, role, studyProgramID)
+	return args.Get(0).(generated.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetActiveUserEmails(ctx context.Context, emails []string) ([]string, error) {
+	args := m.Called(ctx, emails)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockUserRepository) ListUsers(ctx context.Context, filter repositories.UserFilter, limit, offset int) ([]generated.User, error) {
+	args := m.Called(ctx, filter, limit, offset)
+	return args.Get(0).([]generated.User), args.Error(1)
+}
+
+func (m *MockUserRepository) CountUsers(ctx context.Context, filter repositories.UserFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateUserRole(ctx context.Context, id string, role int64) (generated.User, error) {
+	args := m.Called(ctx, id, role)
+	return args.Get(0).(generated.User), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateUserStudyProgram(ctx context.Context, id, studyProgramID string) (generated.User, error) {
+	args := m.Called(ctx, id, studyProgramID)
+	return args.Get(0).(generated.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUserStudyProgramID(ctx context.Context, id string) (string, error) {
+	args := m.Called(ctx, id)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockUserRepository) DisableUser(ctx context.Context, id string) (generated.User, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.User), args.Error(1)
+}
+
+func (m *MockUserRepository) EnableUser(ctx context.Context, id string) (generated.User, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.User), args.Error(1)
+}
+
+func (m *MockUserRepository) DeleteUser(ctx context.Context, id string) (generated.User, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.User), args.Error(1)
+}
+
+func (m *MockUserRepository) RestoreUser(ctx context.Context, id string) (generated.User, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.User), args.Error(1)
+}
+
+func (m *MockUserRepository) CreateRefreshToken(ctx context.Context, userID, familyID, tokenHash string, expiresAt time.Time) (generated.RefreshToken, error) {
+	args := m.Called(ctx, userID, familyID, tokenHash, expiresAt)
+	return args.Get(0).(generated.RefreshToken), args.Error(1)
+}
+
+func (m *MockUserRepository) RevokeRefreshTokenFamilyByHash(ctx context.Context, userID, tokenHash string) error {
+	args := m.Called(ctx, userID, tokenHash)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) RevokeUserRefreshTokens(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateUserPassword(ctx context.Context, id, password string) (generated.User, error) {
+	args := m.Called(ctx, id, password)
+	return args.Get(0).(generated.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUserTx(txCtx *common.TxContext, id string) (generated.User, error) {
+	args := m.Called(txCtx, id)
+	return args.Get(0).(generated.User), args.Error(1)
+}
+
+func (m *MockUserRepository) CreateUsersTx(txCtx *common.TxContext, users []repositories.NewUser) (int64, error) {
+	args := m.Called(txCtx, users)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserRepository) GetRefreshTokenByHashTx(txCtx *common.TxContext, tokenHash string) (generated.RefreshToken, error) {
+	args := m.Called(txCtx, tokenHash)
+	return args.Get(0).(generated.RefreshToken), args.Error(1)
+}
+
+func (m *MockUserRepository) CreateRefreshTokenTx(txCtx *common.TxContext, userID, familyID, tokenHash string, expiresAt time.Time) (generated.RefreshToken, error) {
+	args := m.Called(txCtx, userID, familyID, tokenHash, expiresAt)
+	return args.Get(0).(generated.RefreshToken), args.Error(1)
+}
+
+func (m *MockUserRepository) RotateRefreshTokenTx(txCtx *common.TxContext, id, replacedByID string) (generated.RefreshToken, error) {
+	args := m.Called(txCtx, id, replacedByID)
+	return args.Get(0).(generated.RefreshToken), args.Error(1)
+}
+
+func (m *MockUserRepository) RevokeRefreshTokenFamilyTx(txCtx *common.TxContext, familyID string) error {
+	args := m.Called(txCtx, familyID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateUserPasswordTx(txCtx *common.TxContext, id, password string) (generated.User, error) {
+	args := m.Called(txCtx, id, password)
+	return args.Get(0).(generated.User), args.Error(1)
+}
+
+func (m *MockUserRepository) CreatePasswordResetTokenTx(txCtx *common.TxContext, userID, tokenHash string, expiresAt time.Time, createdBy string) (generated.PasswordResetToken, error) {
+	args := m.Called(txCtx, userID, tokenHash, expiresAt, createdBy)
+	return args.Get(0).(generated.PasswordResetToken), args.Error(1)
+}
+
+func (m *MockUserRepository) InvalidatePasswordResetTokensTx(txCtx *common.TxContext, userID string) error {
+	args := m.Called(txCtx, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetPasswordResetTokenByHashTx(txCtx *common.TxContext, tokenHash string) (generated.PasswordResetToken, error) {
+	args := m.Called(txCtx, tokenHash)
+	return args.Get(0).(generated.PasswordResetToken), args.Error(1)
+}
+
+func (m *MockUserRepository) MarkPasswordResetTokenUsedTx(txCtx *common.TxContext, id string) (generated.PasswordResetToken, error) {
+	args := m.Called(txCtx, id)
+	return args.Get(0).(generated.PasswordResetToken), args.Error(1)
+}
+
+// Mock token signer, the access token is signed by jwtkeys.Keyring in production
+type MockTokenSigner struct {
+	mock.Mock
+}
+
+func (m *MockTokenSigner) Sign(claims jwt.Claims) (string, error) {
+	args := m.Called(claims)
+	return args.String(0), args.Error(1)
+}
+
+const (
+	refreshUserID        = "550e8400-e29b-41d4-a716-446655440001"
+	refreshTokenID       = "550e8400-e29b-41d4-a716-446655440002"
+	refreshFamilyID      = "550e8400-e29b-41d4-a716-446655440003"
+	refreshRotatedID     = "550e8400-e29b-41d4-a716-446655440004"
+	presentedRefresh     = "presented-refresh-token"
+	signedAccessTokenStr = "signed-access-token"
+)
+
+// testUUID parses a fixed UUID of the tests
+func testUUID(id string) pgtype.UUID {
+	var uuid pgtype.UUID
+	_ = uuid.Scan(id)
+	return uuid
+}
+
+// Test Suite
+type RefreshTokenUseCaseTestSuite struct {
+	suite.Suite
+	useCase    *RefreshTokenUseCase
+	mockRepo   *MockUserRepository
+	mockSigner *MockTokenSigner
+	ctx        context.Context
+}
+
+func (suite *RefreshTokenUseCaseTestSuite) SetupTest() {
+	suite.mockRepo = new(MockUserRepository)
+	suite.mockSigner = new(MockTokenSigner)
+	suite.useCase = NewRefreshTokenUseCase(suite.mockRepo, new(common.MockTransactionExecutor), suite.mockSigner)
+	suite.ctx = context.Background()
+}
+
+func (suite *RefreshTokenUseCaseTestSuite) TearDownTest() {
+	suite.mockRepo.AssertExpectations(suite.T())
+	suite.mockSigner.AssertExpectations(suite.T())
+}
+
+// storedToken returns the stored row of the presented refresh token, expiring after the given time
+func (suite *RefreshTokenUseCaseTestSuite) storedToken(expiresIn time.Duration) generated.RefreshToken {
+	return generated.RefreshToken{
+		ID:        testUUID(refreshTokenID),
+		UserID:    testUUID(refreshUserID),
+		FamilyID:  testUUID(refreshFamilyID),
+		TokenHash: hashOpaqueToken(presentedRefresh),
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(expiresIn), Valid: true},
+	}
+}
+
+// Test a valid refresh token is rotated within its family
+func (suite *RefreshTokenUseCaseTestSuite) TestRefresh_Rotation() {
+	user := generated.User{
+		ID:   testUUID(refreshUserID),
+		Role: pgtype.Numeric{Int: big.NewInt(3), Valid: true},
+	}
+
+	suite.mockRepo.On("GetRefreshTokenByHashTx", mock.AnythingOfType("*common.TxContext"), hashOpaqueToken(presentedRefresh)).Return(suite.storedToken(time.Hour), nil)
+	suite.mockRepo.On("GetUserTx", mock.AnythingOfType("*common.TxContext"), refreshUserID).Return(user, nil)
+	suite.mockRepo.On("CreateRefreshTokenTx", mock.AnythingOfType("*common.TxContext"), refreshUserID, refreshFamilyID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(generated.RefreshToken{ID: testUUID(refreshRotatedID)}, nil)
+	suite.mockRepo.On("RotateRefreshTokenTx", mock.AnythingOfType("*common.TxContext"), refreshTokenID, refreshRotatedID).Return(generated.RefreshToken{}, nil)
+	suite.mockSigner.On("Sign", mock.AnythingOfType("usecases.JWTClaims")).Return(signedAccessTokenStr, nil)
+
+	tokenPair, err := suite.useCase.Refresh(suite.ctx, presentedRefresh)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), signedAccessTokenStr, tokenPair.AccessToken)
+	assert.NotEmpty(suite.T(), tokenPair.RefreshToken)
+	assert.NotEqual(suite.T(), presentedRefresh, tokenPair.RefreshToken)
+
+	// Only the hash of the new token is stored
+	storedHash := suite.mockRepo.Calls[2].Arguments.String(3)
+	assert.Equal(suite.T(), hashOpaqueToken(tokenPair.RefreshToken), storedHash)
+	suite.mockRepo.AssertNotCalled(suite.T(), "RevokeRefreshTokenFamilyTx")
+}
+
+// Test presenting an already rotated token revokes the whole family
+func (suite *RefreshTokenUseCaseTestSuite) TestRefresh_ReuseRevokesFamily() {
+	storedToken := suite.storedToken(time.Hour)
+	storedToken.RevokedAt = pgtype.Timestamptz{Time: time.Now().Add(-time.Minute), Valid: true}
+	storedToken.ReplacedBy = testUUID(refreshRotatedID)
+
+	suite.mockRepo.On("GetRefreshTokenByHashTx", mock.AnythingOfType("*common.TxContext"), hashOpaqueToken(presentedRefresh)).Return(storedToken, nil)
+	suite.mockRepo.On("RevokeRefreshTokenFamilyTx", mock.AnythingOfType("*common.TxContext"), refreshFamilyID).Return(nil)
+
+	tokenPair, err := suite.useCase.Refresh(suite.ctx, presentedRefresh)
+
+	assert.True(suite.T(), errors.Is(err, ErrRefreshTokenReused))
+	assert.Empty(suite.T(), tokenPair.AccessToken)
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateRefreshTokenTx")
+	suite.mockSigner.AssertNotCalled(suite.T(), "Sign")
+}
+
+// Test an expired token can't be refreshed
+func (suite *RefreshTokenUseCaseTestSuite) TestRefresh_Expired() {
+	suite.mockRepo.On("GetRefreshTokenByHashTx", mock.AnythingOfType("*common.TxContext"), hashOpaqueToken(presentedRefresh)).Return(suite.storedToken(-time.Minute), nil)
+
+	_, err := suite.useCase.Refresh(suite.ctx, presentedRefresh)
+
+	assert.True(suite.T(), errors.Is(err, ErrInvalidRefreshToken))
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateRefreshTokenTx")
+	suite.mockRepo.AssertNotCalled(suite.T(), "RevokeRefreshTokenFamilyTx")
+}
+
+// Test an unknown token is rejected
+func (suite *RefreshTokenUseCaseTestSuite) TestRefresh_UnknownToken() {
+	suite.mockRepo.On("GetRefreshTokenByHashTx", mock.AnythingOfType("*common.TxContext"), hashOpaqueToken(presentedRefresh)).Return(generated.RefreshToken{}, pgx.ErrNoRows)
+
+	_, err := suite.useCase.Refresh(suite.ctx, presentedRefresh)
+
+	assert.True(suite.T(), errors.Is(err, ErrInvalidRefreshToken))
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateRefreshTokenTx")
+}
+
+// Test a disabled or deleted account can't extend its session, the presented token isn't rotated
+func (suite *RefreshTokenUseCaseTestSuite) TestRefresh_InactiveAccount() {
+	testCases := []struct {
+		name string
+		user generated.User
+	}{
+		{"disabled", generated.User{ID: testUUID(refreshUserID), DisabledAt: pgtype.Timestamptz{Time: time.Now().Add(-time.Minute), Valid: true}}},
+		{"deleted", generated.User{ID: testUUID(refreshUserID), DeletedAt: pgtype.Timestamptz{Time: time.Now().Add(-time.Minute), Valid: true}}},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+			defer suite.TearDownTest()
+
+			suite.mockRepo.On("GetRefreshTokenByHashTx", mock.AnythingOfType("*common.TxContext"), hashOpaqueToken(presentedRefresh)).Return(suite.storedToken(time.Hour), nil)
+			suite.mockRepo.On("GetUserTx", mock.AnythingOfType("*common.TxContext"), refreshUserID).Return(tc.user, nil)
+
+			_, err := suite.useCase.Refresh(suite.ctx, presentedRefresh)
+
+			assert.True(suite.T(), errors.Is(err, ErrInvalidRefreshToken))
+			suite.mockRepo.AssertNotCalled(suite.T(), "CreateRefreshTokenTx")
+			suite.mockRepo.AssertNotCalled(suite.T(), "RotateRefreshTokenTx")
+			suite.mockSigner.AssertNotCalled(suite.T(), "Sign")
+		})
+	}
+}
+
+// Run the test suite
+func TestRefreshTokenUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(RefreshTokenUseCaseTestSuite))
+}
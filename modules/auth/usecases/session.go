@@ -0,0 +1,78 @@
+package usecases
+
+import (
+	"context"
+	"siakad-poc/db/repositories"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+type SessionUseCase struct {
+	userRepository            repositories.UserRepository
+	tokenRevocationRepository repositories.TokenRevocationRepository
+}
+
+func NewSessionUseCase(userRepository repositories.UserRepository, tokenRevocationRepository repositories.TokenRevocationRepository) *SessionUseCase {
+	return &SessionUseCase{
+		userRepository:            userRepository,
+		tokenRevocationRepository: tokenRevocationRepository,
+	}
+}
+
+// Logout revokes the access token used for the request and, when given, the refresh token family
+// it belongs to, so neither can be used again.
+func (u *SessionUseCase) Logout(ctx context.Context, userID, tokenID string, tokenExpiresAt time.Time, refreshToken string) error {
+	if refreshToken != "" {
+		err := u.userRepository.RevokeRefreshTokenFamilyByHash(ctx, userID, hashOpaqueToken(refreshToken))
+		if err != nil {
+			return errors.Wrap(err, "failed to revoke refresh token")
+		}
+	}
+
+	// Tokens issued before jti was introduced can't be revoked individually, they simply expire
+	if tokenID == "" {
+		return nil
+	}
+
+	err := u.tokenRevocationRepository.RevokeAccessToken(ctx, tokenID, userID, tokenExpiresAt)
+	if err != nil {
+		return errors.Wrap(err, "failed to revoke access token")
+	}
+
+	return nil
+}
+
+// RevokeAllUserSessions invalidates every access token issued to the user so far and all of their refresh tokens.
+func (u *SessionUseCase) RevokeAllUserSessions(ctx context.Context, userID string) error {
+	_, err := u.userRepository.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return errors.Wrap(err, "failed to get user")
+	}
+
+	return revokeUserSessions(ctx, u.userRepository, u.tokenRevocationRepository, userID)
+}
+
+// revokeUserSessions revokes all refresh tokens of the user and every access token issued before now.
+func revokeUserSessions(
+	ctx context.Context,
+	userRepository repositories.UserRepository,
+	tokenRevocationRepository repositories.TokenRevocationRepository,
+	userID string,
+) error {
+	err := userRepository.RevokeUserRefreshTokens(ctx, userID)
+	if err != nil {
+		return errors.Wrap(err, "failed to revoke refresh tokens")
+	}
+
+	_, err = tokenRevocationRepository.RevokeUserSessions(ctx, userID)
+	if err != nil {
+		return errors.Wrap(err, "failed to revoke user sessions")
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package usecases
+
+import (
+	"context"
+	"siakad-poc/db/generated"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	sessionUserID        = "550e8400-e29b-41d4-a716-446655440021"
+	sessionTokenID       = "550e8400-e29b-41d4-a716-446655440022"
+	presentedLogoutToken = "presented-logout-refresh-token"
+)
+
+// Test Suite
+type SessionUseCaseTestSuite struct {
+	suite.Suite
+	useCase            *SessionUseCase
+	mockUserRepo       *MockUserRepository
+	mockRevocationRepo *MockTokenRevocationRepository
+	ctx                context.Context
+	expiresAt          time.Time
+}
+
+func (suite *SessionUseCaseTestSuite) SetupTest() {
+	suite.mockUserRepo = new(MockUserRepository)
+	suite.mockRevocationRepo = new(MockTokenRevocationRepository)
+	suite.useCase = NewSessionUseCase(suite.mockUserRepo, suite.mockRevocationRepo)
+	suite.ctx = context.Background()
+	suite.expiresAt = time.Now().Add(15 * time.Minute)
+}
+
+func (suite *SessionUseCaseTestSuite) TearDownTest() {
+	suite.mockUserRepo.AssertExpectations(suite.T())
+	suite.mockRevocationRepo.AssertExpectations(suite.T())
+}
+
+// Test logout revokes the access token until it expires and the refresh token family by its hash
+func (suite *SessionUseCaseTestSuite) TestLogout_Success() {
+	suite.mockUserRepo.On("RevokeRefreshTokenFamilyByHash", suite.ctx, sessionUserID, hashOpaqueToken(presentedLogoutToken)).Return(nil)
+	suite.mockRevocationRepo.On("RevokeAccessToken", suite.ctx, sessionTokenID, sessionUserID, suite.expiresAt).Return(nil)
+
+	err := suite.useCase.Logout(suite.ctx, sessionUserID, sessionTokenID, suite.expiresAt, presentedLogoutToken)
+
+	assert.NoError(suite.T(), err)
+}
+
+// Test logout without a refresh token only revokes the access token
+func (suite *SessionUseCaseTestSuite) TestLogout_WithoutRefreshToken() {
+	suite.mockRevocationRepo.On("RevokeAccessToken", suite.ctx, sessionTokenID, sessionUserID, suite.expiresAt).Return(nil)
+
+	err := suite.useCase.Logout(suite.ctx, sessionUserID, sessionTokenID, suite.expiresAt, "")
+
+	assert.NoError(suite.T(), err)
+	suite.mockUserRepo.AssertNotCalled(suite.T(), "RevokeRefreshTokenFamilyByHash")
+}
+
+// Test a token issued before jti existed can't be revoked on its own, logout still succeeds
+func (suite *SessionUseCaseTestSuite) TestLogout_TokenWithoutID() {
+	suite.mockUserRepo.On("RevokeRefreshTokenFamilyByHash", suite.ctx, sessionUserID, hashOpaqueToken(presentedLogoutToken)).Return(nil)
+
+	err := suite.useCase.Logout(suite.ctx, sessionUserID, "", suite.expiresAt, presentedLogoutToken)
+
+	assert.NoError(suite.T(), err)
+	suite.mockRevocationRepo.AssertNotCalled(suite.T(), "RevokeAccessToken")
+}
+
+// Test a failing refresh token revocation leaves the access token alone and is reported
+func (suite *SessionUseCaseTestSuite) TestLogout_RefreshTokenRevocationError() {
+	suite.mockUserRepo.On("RevokeRefreshTokenFamilyByHash", suite.ctx, sessionUserID, hashOpaqueToken(presentedLogoutToken)).Return(errors.New("connection refused"))
+
+	err := suite.useCase.Logout(suite.ctx, sessionUserID, sessionTokenID, suite.expiresAt, presentedLogoutToken)
+
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "failed to revoke refresh token")
+	suite.mockRevocationRepo.AssertNotCalled(suite.T(), "RevokeAccessToken")
+}
+
+// Test revoking all sessions revokes every refresh token and the access tokens issued so far
+func (suite *SessionUseCaseTestSuite) TestRevokeAllUserSessions_Success() {
+	suite.mockUserRepo.On("GetUser", suite.ctx, sessionUserID).Return(generated.User{ID: testUUID(sessionUserID)}, nil)
+	suite.mockUserRepo.On("RevokeUserRefreshTokens", suite.ctx, sessionUserID).Return(nil)
+	suite.mockRevocationRepo.On("RevokeUserSessions", suite.ctx, sessionUserID).Return(generated.UserSessionRevocation{}, nil)
+
+	err := suite.useCase.RevokeAllUserSessions(suite.ctx, sessionUserID)
+
+	assert.NoError(suite.T(), err)
+}
+
+// Test revoking the sessions of an unknown user
+func (suite *SessionUseCaseTestSuite) TestRevokeAllUserSessions_UserNotFound() {
+	suite.mockUserRepo.On("GetUser", suite.ctx, sessionUserID).Return(generated.User{}, pgx.ErrNoRows)
+
+	err := suite.useCase.RevokeAllUserSessions(suite.ctx, sessionUserID)
+
+	assert.ErrorIs(suite.T(), err, ErrUserNotFound)
+	suite.mockUserRepo.AssertNotCalled(suite.T(), "RevokeUserRefreshTokens", mock.Anything, mock.Anything)
+	suite.mockRevocationRepo.AssertNotCalled(suite.T(), "RevokeUserSessions", mock.Anything, mock.Anything)
+}
+
+// Test the access tokens stay valid when the refresh tokens can't be revoked, so the call can be retried
+func (suite *SessionUseCaseTestSuite) TestRevokeAllUserSessions_RefreshTokenRevocationError() {
+	suite.mockUserRepo.On("GetUser", suite.ctx, sessionUserID).Return(generated.User{ID: testUUID(sessionUserID)}, nil)
+	suite.mockUserRepo.On("RevokeUserRefreshTokens", suite.ctx, sessionUserID).Return(errors.New("connection refused"))
+
+	err := suite.useCase.RevokeAllUserSessions(suite.ctx, sessionUserID)
+
+	assert.Error(suite.T(), err)
+	suite.mockRevocationRepo.AssertNotCalled(suite.T(), "RevokeUserSessions", mock.Anything, mock.Anything)
+}
+
+// Run the test suite
+func TestSessionUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(SessionUseCaseTestSuite))
+}
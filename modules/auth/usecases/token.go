@@ -0,0 +1,94 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"siakad-poc/config"
+	"siakad-poc/constants"
+	"siakad-poc/db/repositories"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// TokenPair is the set of credentials handed to a client after a successful login or refresh.
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int64 // access token lifetime in seconds
+}
+
+// TokenSigner signs access tokens with the active signing key, it is implemented by jwtkeys.Keyring.
+type TokenSigner interface {
+	Sign(claims jwt.Claims) (string, error)
+}
+
+// signAccessToken generates a short-lived JWT access token for the given user.
+func signAccessToken(signer TokenSigner, userID string, role constants.RoleType) (string, error) {
+	now := time.Now()
+	claims := JWTClaims{
+		UserID: userID,
+		Role:   role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(config.CurrentConfig.JWT.AccessTokenTTL())),
+			IssuedAt:  jwt.NewNumericDate(now),
+			Subject:   userID,
+			ID:        uuid.NewString(), // jti, used to revoke this token on logout
+		},
+	}
+
+	tokenString, err := signer.Sign(claims)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate token")
+	}
+
+	return tokenString, nil
+}
+
+// issueTokenPair signs an access token and starts a new refresh token family, as done on every completed login.
+func issueTokenPair(ctx context.Context, repository repositories.UserRepository, signer TokenSigner, userID string, role constants.RoleType) (TokenPair, error) {
+	accessToken, err := signAccessToken(signer, userID, role)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	// Every login starts a new refresh token family, rotations stay within it
+	refreshToken, refreshTokenHash, err := generateOpaqueToken()
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	expiresAt := time.Now().Add(config.CurrentConfig.JWT.RefreshTokenTTL())
+	_, err = repository.CreateRefreshToken(ctx, userID, uuid.NewString(), refreshTokenHash, expiresAt)
+	if err != nil {
+		return TokenPair{}, errors.Wrap(err, "failed to store refresh token")
+	}
+
+	return TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(config.CurrentConfig.JWT.AccessTokenTTL().Seconds()),
+	}, nil
+}
+
+// generateOpaqueToken creates a random opaque token (refresh or password reset) together with the hash that
+// gets persisted. Only the hash is stored, so a database leak does not expose usable tokens.
+func generateOpaqueToken() (token string, tokenHash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", errors.Wrap(err, "failed to generate token")
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(buf)
+	return token, hashOpaqueToken(token), nil
+}
+
+func hashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
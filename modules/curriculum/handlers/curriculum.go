@@ -0,0 +1,312 @@
+package handlers
+
+import (
+	"siakad-poc/common"
+	"siakad-poc/db/repositories"
+	"siakad-poc/middlewares"
+	"siakad-poc/modules/curriculum/usecases"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type CurriculumHandler struct {
+	useCase *usecases.CurriculumUseCase
+}
+
+func NewCurriculumHandler(useCase *usecases.CurriculumUseCase) *CurriculumHandler {
+	return &CurriculumHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *CurriculumHandler) HandleListCurricula(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	page := 1
+	pageSize := 10
+
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	if ps, err := strconv.Atoi(c.Query("page_size")); err == nil && ps > 0 {
+		pageSize = ps
+	}
+
+	filter := repositories.CurriculumFilter{
+		StudyProgramID: c.Query("study_program_id"),
+	}
+	if isActive, err := strconv.ParseBool(c.Query("is_active")); err == nil {
+		filter.IsActive = &isActive
+	}
+
+	curricula, pagination, err := h.useCase.ListCurricula(c.Context(), filter, page, pageSize)
+	if err != nil {
+		return respondCurriculumError(c, requestID, clientIP, "", "Failed to get curricula", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.PaginatedBaseResponse[[]usecases.CurriculumResponse]{
+		BaseResponse: common.BaseResponse[[]usecases.CurriculumResponse]{
+			Status: common.StatusSuccess,
+			Data:   &curricula,
+		},
+		Paging: pagination,
+	})
+}
+
+func (h *CurriculumHandler) HandleGetCurriculum(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	curriculum, err := h.useCase.GetCurriculum(c.Context(), id)
+	if err != nil {
+		return respondCurriculumError(c, requestID, clientIP, id, "Failed to get curriculum", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.CurriculumResponse]{
+		Status: common.StatusSuccess,
+		Data:   &curriculum,
+	})
+}
+
+func (h *CurriculumHandler) HandleCreateCurriculum(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	var req usecases.CreateCurriculumRequest
+	if handled, err := parseRequest(c, &req, "create curriculum"); handled {
+		return err
+	}
+
+	curriculum, err := h.useCase.CreateCurriculum(c.Context(), studyProgramScope(c), req)
+	if err != nil {
+		return respondCurriculumError(c, requestID, clientIP, "", "Failed to create curriculum", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("curriculum_id", curriculum.ID).
+		Str("study_program_id", curriculum.StudyProgramID).
+		Str("code", curriculum.Code).
+		Bool("is_active", curriculum.IsActive).
+		Str("created_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Curriculum created")
+
+	return c.Status(fiber.StatusCreated).JSON(common.BaseResponse[usecases.CurriculumResponse]{
+		Status: common.StatusSuccess,
+		Data:   &curriculum,
+	})
+}
+
+func (h *CurriculumHandler) HandleUpdateCurriculum(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	var req usecases.UpdateCurriculumRequest
+	if handled, err := parseRequest(c, &req, "update curriculum"); handled {
+		return err
+	}
+
+	curriculum, err := h.useCase.UpdateCurriculum(c.Context(), studyProgramScope(c), id, req)
+	if err != nil {
+		return respondCurriculumError(c, requestID, clientIP, id, "Failed to update curriculum", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("curriculum_id", id).
+		Str("code", curriculum.Code).
+		Bool("is_active", curriculum.IsActive).
+		Str("updated_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Curriculum updated")
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.CurriculumResponse]{
+		Status: common.StatusSuccess,
+		Data:   &curriculum,
+	})
+}
+
+func (h *CurriculumHandler) HandleDeleteCurriculum(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	err := h.useCase.DeleteCurriculum(c.Context(), studyProgramScope(c), id)
+	if err != nil {
+		return respondCurriculumError(c, requestID, clientIP, id, "Failed to delete curriculum", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("curriculum_id", id).
+		Str("deleted_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Curriculum soft-deleted")
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *CurriculumHandler) HandleAddCurriculumCourse(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	var req usecases.AddCurriculumCourseRequest
+	if handled, err := parseRequest(c, &req, "add curriculum course"); handled {
+		return err
+	}
+
+	course, err := h.useCase.AddCourse(c.Context(), studyProgramScope(c), id, req)
+	if err != nil {
+		return respondCurriculumError(c, requestID, clientIP, id, "Failed to add course to curriculum", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("curriculum_id", id).
+		Str("course_id", course.CourseID).
+		Str("added_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Course added to curriculum")
+
+	return c.Status(fiber.StatusCreated).JSON(common.BaseResponse[usecases.CurriculumCourseResponse]{
+		Status: common.StatusSuccess,
+		Data:   &course,
+	})
+}
+
+func (h *CurriculumHandler) HandleRemoveCurriculumCourse(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+	courseID := c.Params("courseId")
+
+	err := h.useCase.RemoveCourse(c.Context(), studyProgramScope(c), id, courseID)
+	if err != nil {
+		return respondCurriculumError(c, requestID, clientIP, id, "Failed to remove course from curriculum", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("curriculum_id", id).
+		Str("course_id", courseID).
+		Str("removed_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Course removed from curriculum")
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// respondCurriculumError maps curriculum errors to HTTP status codes, unknown errors become 500.
+func respondCurriculumError(c *fiber.Ctx, requestID, clientIP, curriculumID, message string, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, usecases.ErrCurriculumNotFound), errors.Is(err, usecases.ErrCourseNotInCurriculum):
+		status = fiber.StatusNotFound
+	case errors.Is(err, usecases.ErrNoStudyProgram), errors.Is(err, usecases.ErrStudyProgramOutOfScope):
+		status = fiber.StatusForbidden
+	case errors.Is(err, usecases.ErrCurriculumCodeAlreadyUsed), errors.Is(err, usecases.ErrCourseAlreadyInCurriculum):
+		status = fiber.StatusConflict
+	case errors.Is(err, usecases.ErrUnknownStudyProgram), errors.Is(err, usecases.ErrUnknownCourse):
+		status = fiber.StatusUnprocessableEntity
+	}
+
+	if status == fiber.StatusInternalServerError {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("curriculum_id", curriculumID).
+			Str("path", c.OriginalURL()).
+			Msg(message)
+	} else {
+		log.Warn().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("curriculum_id", curriculumID).
+			Str("path", c.OriginalURL()).
+			Msg(message)
+	}
+
+	return c.Status(status).JSON(common.BaseResponse[any]{
+		Status: common.StatusError,
+		Error: &common.BaseResponseError{
+			Message:   message,
+			Details:   []string{err.Error()},
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Path:      c.OriginalURL(),
+		},
+	})
+}
+
+// parseRequest parses and validates the request body, it reports whether an error response was sent.
+func parseRequest(c *fiber.Ctx, data any, requestName string) (bool, error) {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	if err := c.BodyParser(data); err != nil {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("path", c.OriginalURL()).
+			Str("method", c.Method()).
+			Msgf("Failed to parse %s request body", requestName)
+
+		return true, c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Cannot parse request body",
+				Details:   []string{err.Error()},
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	if validationErrors := common.ValidateStruct(data); validationErrors != nil {
+		log.Warn().
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Strs("validation_errors", validationErrors).
+			Str("path", c.OriginalURL()).
+			Msgf("%s validation failed", requestName)
+
+		return true, c.Status(fiber.StatusBadRequest).JSON(common.BaseResponse[any]{
+			Status: common.StatusError,
+			Error: &common.BaseResponseError{
+				Message:   "Validation failed",
+				Details:   validationErrors,
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Path:      c.OriginalURL(),
+			},
+		})
+	}
+
+	return false, nil
+}
+
+func studyProgramScope(c *fiber.Ctx) common.StudyProgramScope {
+	return c.Locals(middlewares.StudyProgramScopeKey).(common.StudyProgramScope)
+}
+
+func actorID(c *fiber.Ctx) string {
+	id, _ := c.Locals(middlewares.UserIDKey).(string)
+	return id
+}
@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"siakad-poc/common"
+	"siakad-poc/db/repositories"
+	"siakad-poc/modules/curriculum/usecases"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type StudyProgramHandler struct {
+	useCase *usecases.StudyProgramUseCase
+}
+
+func NewStudyProgramHandler(useCase *usecases.StudyProgramUseCase) *StudyProgramHandler {
+	return &StudyProgramHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *StudyProgramHandler) HandleListStudyPrograms(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	page := 1
+	pageSize := 10
+
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	if ps, err := strconv.Atoi(c.Query("page_size")); err == nil && ps > 0 {
+		pageSize = ps
+	}
+
+	filter := repositories.StudyProgramFilter{
+		Level: c.Query("level"),
+	}
+
+	studyPrograms, pagination, err := h.useCase.ListStudyPrograms(c.Context(), filter, page, pageSize)
+	if err != nil {
+		return respondStudyProgramError(c, requestID, clientIP, "", "Failed to get study programs", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.PaginatedBaseResponse[[]usecases.StudyProgramResponse]{
+		BaseResponse: common.BaseResponse[[]usecases.StudyProgramResponse]{
+			Status: common.StatusSuccess,
+			Data:   &studyPrograms,
+		},
+		Paging: pagination,
+	})
+}
+
+func (h *StudyProgramHandler) HandleGetStudyProgram(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	studyProgram, err := h.useCase.GetStudyProgram(c.Context(), id)
+	if err != nil {
+		return respondStudyProgramError(c, requestID, clientIP, id, "Failed to get study program", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.StudyProgramResponse]{
+		Status: common.StatusSuccess,
+		Data:   &studyProgram,
+	})
+}
+
+func (h *StudyProgramHandler) HandleCreateStudyProgram(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+
+	var req usecases.StudyProgramRequest
+	if handled, err := parseRequest(c, &req, "study program"); handled {
+		return err
+	}
+
+	studyProgram, err := h.useCase.CreateStudyProgram(c.Context(), req)
+	if err != nil {
+		return respondStudyProgramError(c, requestID, clientIP, "", "Failed to create study program", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("study_program_id", studyProgram.ID).
+		Str("code", studyProgram.Code).
+		Str("created_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Study program created")
+
+	return c.Status(fiber.StatusCreated).JSON(common.BaseResponse[usecases.StudyProgramResponse]{
+		Status: common.StatusSuccess,
+		Data:   &studyProgram,
+	})
+}
+
+func (h *StudyProgramHandler) HandleUpdateStudyProgram(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	var req usecases.StudyProgramRequest
+	if handled, err := parseRequest(c, &req, "study program"); handled {
+		return err
+	}
+
+	studyProgram, err := h.useCase.UpdateStudyProgram(c.Context(), id, req)
+	if err != nil {
+		return respondStudyProgramError(c, requestID, clientIP, id, "Failed to update study program", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("study_program_id", id).
+		Str("code", studyProgram.Code).
+		Str("updated_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Study program updated")
+
+	return c.Status(fiber.StatusOK).JSON(common.BaseResponse[usecases.StudyProgramResponse]{
+		Status: common.StatusSuccess,
+		Data:   &studyProgram,
+	})
+}
+
+func (h *StudyProgramHandler) HandleDeleteStudyProgram(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	clientIP := c.IP()
+	id := c.Params("id")
+
+	err := h.useCase.DeleteStudyProgram(c.Context(), id)
+	if err != nil {
+		return respondStudyProgramError(c, requestID, clientIP, id, "Failed to delete study program", err)
+	}
+
+	log.Info().
+		Str("request_id", requestID).
+		Str("client_ip", clientIP).
+		Str("study_program_id", id).
+		Str("deleted_by", actorID(c)).
+		Str("path", c.OriginalURL()).
+		Msg("Study program soft-deleted")
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// respondStudyProgramError maps study program errors to HTTP status codes, unknown errors become 500.
+func respondStudyProgramError(c *fiber.Ctx, requestID, clientIP, studyProgramID, message string, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, usecases.ErrStudyProgramNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, usecases.ErrStudyProgramCodeAlreadyUsed), errors.Is(err, usecases.ErrStudyProgramInUse):
+		status = fiber.StatusConflict
+	}
+
+	if status == fiber.StatusInternalServerError {
+		log.Error().
+			Stack().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("study_program_id", studyProgramID).
+			Str("path", c.OriginalURL()).
+			Msg(message)
+	} else {
+		log.Warn().
+			Err(err).
+			Str("request_id", requestID).
+			Str("client_ip", clientIP).
+			Str("study_program_id", studyProgramID).
+			Str("path", c.OriginalURL()).
+			Msg(message)
+	}
+
+	return c.Status(status).JSON(common.BaseResponse[any]{
+		Status: common.StatusError,
+		Error: &common.BaseResponseError{
+			Message:   message,
+			Details:   []string{err.Error()},
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Path:      c.OriginalURL(),
+		},
+	})
+}
@@ -0,0 +1,78 @@
+package curriculum
+
+import (
+	"siakad-poc/common/jwtkeys"
+	"siakad-poc/constants"
+	"siakad-poc/db/repositories"
+	"siakad-poc/middlewares"
+	"siakad-poc/modules"
+	"siakad-poc/modules/curriculum/handlers"
+	"siakad-poc/modules/curriculum/usecases"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CurriculumModule struct {
+	curriculumRepository      repositories.CurriculumRepository
+	userRepository            repositories.UserRepository
+	tokenRevocationRepository repositories.TokenRevocationRepository
+	permissionRepository      repositories.PermissionRepository
+	keyring                   *jwtkeys.Keyring
+	studyProgramUseCase       *usecases.StudyProgramUseCase
+	curriculumUseCase         *usecases.CurriculumUseCase
+	studyProgramHandler       *handlers.StudyProgramHandler
+	curriculumHandler         *handlers.CurriculumHandler
+}
+
+// Compile time interface conformance check
+var _ modules.RoutableModule = (*CurriculumModule)(nil)
+
+func NewModule(pool *pgxpool.Pool, tokenRevocationRepository repositories.TokenRevocationRepository, permissionRepository repositories.PermissionRepository, keyring *jwtkeys.Keyring) *CurriculumModule {
+	curriculumRepository := repositories.NewDefaultCurriculumRepository(pool)
+	academicRepository := repositories.NewDefaultAcademicRepository(pool)
+	userRepository := repositories.NewDefaultUserRepository(pool)
+
+	studyProgramUseCase := usecases.NewStudyProgramUseCase(curriculumRepository)
+	curriculumUseCase := usecases.NewCurriculumUseCase(curriculumRepository, academicRepository)
+
+	studyProgramHandler := handlers.NewStudyProgramHandler(studyProgramUseCase)
+	curriculumHandler := handlers.NewCurriculumHandler(curriculumUseCase)
+
+	return &CurriculumModule{
+		curriculumRepository:      curriculumRepository,
+		userRepository:            userRepository,
+		tokenRevocationRepository: tokenRevocationRepository,
+		permissionRepository:      permissionRepository,
+		keyring:                   keyring,
+		studyProgramUseCase:       studyProgramUseCase,
+		curriculumUseCase:         curriculumUseCase,
+		studyProgramHandler:       studyProgramHandler,
+		curriculumHandler:         curriculumHandler,
+	}
+}
+
+func (m *CurriculumModule) SetupRoutes(fiberApp *fiber.App, prefix string) {
+	// Study programs and curricula are readable by every authenticated user, changes need a permission
+	curriculumGroup := fiberApp.Group(prefix)
+	curriculumGroup.Use(middlewares.JWT(m.keyring, m.tokenRevocationRepository))
+
+	// Study program (prodi) routes
+	manageStudyPrograms := middlewares.RequirePermission(m.permissionRepository, constants.PermissionStudyProgramManage)
+	curriculumGroup.Get("/study-programs", m.studyProgramHandler.HandleListStudyPrograms)
+	curriculumGroup.Post("/study-programs", manageStudyPrograms, m.studyProgramHandler.HandleCreateStudyProgram)
+	curriculumGroup.Get("/study-programs/:id", m.studyProgramHandler.HandleGetStudyProgram)
+	curriculumGroup.Put("/study-programs/:id", manageStudyPrograms, m.studyProgramHandler.HandleUpdateStudyProgram)
+	curriculumGroup.Delete("/study-programs/:id", manageStudyPrograms, m.studyProgramHandler.HandleDeleteStudyProgram)
+
+	// Curriculum (kurikulum) routes, changes are scoped to the study program of the caller
+	manageCurricula := middlewares.RequirePermission(m.permissionRepository, constants.PermissionCurriculumManage)
+	studyProgramScope := middlewares.StudyProgramScope(m.permissionRepository, m.userRepository)
+	curriculumGroup.Get("/curricula", m.curriculumHandler.HandleListCurricula)
+	curriculumGroup.Post("/curricula", manageCurricula, studyProgramScope, m.curriculumHandler.HandleCreateCurriculum)
+	curriculumGroup.Get("/curricula/:id", m.curriculumHandler.HandleGetCurriculum)
+	curriculumGroup.Put("/curricula/:id", manageCurricula, studyProgramScope, m.curriculumHandler.HandleUpdateCurriculum)
+	curriculumGroup.Delete("/curricula/:id", manageCurricula, studyProgramScope, m.curriculumHandler.HandleDeleteCurriculum)
+	curriculumGroup.Post("/curricula/:id/courses", manageCurricula, studyProgramScope, m.curriculumHandler.HandleAddCurriculumCourse)
+	curriculumGroup.Delete("/curricula/:id/courses/:courseId", manageCurricula, studyProgramScope, m.curriculumHandler.HandleRemoveCurriculumCourse)
+}
@@ -0,0 +1,347 @@
+package usecases
+
+import (
+	"context"
+	"math"
+	"siakad-poc/common"
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+type CurriculumResponse struct {
+	ID             string                     `json:"id"`
+	StudyProgramID string                     `json:"study_program_id"`
+	Code           string                     `json:"code"`
+	Name           string                     `json:"name"`
+	IsActive       bool                       `json:"is_active"`
+	CreatedAt      time.Time                  `json:"created_at"`
+	UpdatedAt      *time.Time                 `json:"updated_at"`
+	Courses        []CurriculumCourseResponse `json:"courses,omitempty"`
+}
+
+type CurriculumCourseResponse struct {
+	CourseID   string    `json:"course_id"`
+	CourseCode string    `json:"course_code"`
+	CourseName string    `json:"course_name"`
+	Credit     int32     `json:"credit"`
+	AddedAt    time.Time `json:"added_at"`
+}
+
+type CreateCurriculumRequest struct {
+	StudyProgramID string `json:"study_program_id" validate:"required,uuid"`
+	Code           string `json:"code" validate:"required,max=255"`
+	Name           string `json:"name" validate:"required,max=255"`
+	IsActive       bool   `json:"is_active"`
+}
+
+type UpdateCurriculumRequest struct {
+	Code     string `json:"code" validate:"required,max=255"`
+	Name     string `json:"name" validate:"required,max=255"`
+	IsActive bool   `json:"is_active"`
+}
+
+type AddCurriculumCourseRequest struct {
+	CourseID string `json:"course_id" validate:"required,uuid"`
+}
+
+type CurriculumUseCase struct {
+	curriculumRepository repositories.CurriculumRepository
+	academicRepository   repositories.AcademicRepository
+}
+
+func NewCurriculumUseCase(curriculumRepository repositories.CurriculumRepository, academicRepository repositories.AcademicRepository) *CurriculumUseCase {
+	return &CurriculumUseCase{
+		curriculumRepository: curriculumRepository,
+		academicRepository:   academicRepository,
+	}
+}
+
+func (uc *CurriculumUseCase) ListCurricula(ctx context.Context, filter repositories.CurriculumFilter, page, pageSize int) ([]CurriculumResponse, *common.PaginationMetadata, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+
+	curricula, err := uc.curriculumRepository.ListCurricula(ctx, filter, pageSize, offset)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot get curricula")
+	}
+
+	totalRecords, err := uc.curriculumRepository.CountCurricula(ctx, filter)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot count curricula")
+	}
+
+	responses := make([]CurriculumResponse, 0, len(curricula))
+	for _, curriculum := range curricula {
+		responses = append(responses, toCurriculumResponse(curriculum))
+	}
+
+	pagination := &common.PaginationMetadata{
+		Page:         page,
+		PageSize:     pageSize,
+		TotalRecords: int(totalRecords),
+		TotalPages:   int(math.Ceil(float64(totalRecords) / float64(pageSize))),
+	}
+
+	return responses, pagination, nil
+}
+
+// GetCurriculum returns the curriculum together with its courses.
+func (uc *CurriculumUseCase) GetCurriculum(ctx context.Context, id string) (CurriculumResponse, error) {
+	curriculum, err := uc.getCurriculum(ctx, id)
+	if err != nil {
+		return CurriculumResponse{}, err
+	}
+
+	courses, err := uc.curriculumRepository.ListCurriculumCourses(ctx, id)
+	if err != nil {
+		return CurriculumResponse{}, errors.Wrap(err, "cannot get curriculum courses")
+	}
+
+	response := toCurriculumResponse(curriculum)
+	response.Courses = make([]CurriculumCourseResponse, 0, len(courses))
+	for _, course := range courses {
+		response.Courses = append(response.Courses, toCurriculumCourseResponse(course))
+	}
+
+	return response, nil
+}
+
+// CreateCurriculum adds a curriculum to a study program, scoped users can only add curricula to their own one.
+func (uc *CurriculumUseCase) CreateCurriculum(ctx context.Context, scope common.StudyProgramScope, req CreateCurriculumRequest) (CurriculumResponse, error) {
+	err := ensureStudyProgramInScope(scope, req.StudyProgramID)
+	if err != nil {
+		return CurriculumResponse{}, err
+	}
+
+	_, err = uc.curriculumRepository.GetStudyProgram(ctx, req.StudyProgramID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return CurriculumResponse{}, ErrUnknownStudyProgram
+		}
+		return CurriculumResponse{}, errors.Wrap(err, "cannot get study program")
+	}
+
+	err = uc.ensureCodeAvailable(ctx, "", req.StudyProgramID, req.Code)
+	if err != nil {
+		return CurriculumResponse{}, err
+	}
+
+	attributes := repositories.CurriculumAttributes{
+		Code:     req.Code,
+		Name:     req.Name,
+		IsActive: req.IsActive,
+	}
+	curriculum, err := uc.curriculumRepository.CreateCurriculum(ctx, uuid.NewString(), req.StudyProgramID, attributes)
+	if err != nil {
+		return CurriculumResponse{}, errors.Wrap(err, "cannot create curriculum")
+	}
+
+	return toCurriculumResponse(curriculum), nil
+}
+
+// UpdateCurriculum changes the code, name and activation of a curriculum. Deactivating a curriculum
+// keeps existing course offerings, new offerings need another active curriculum containing the course.
+func (uc *CurriculumUseCase) UpdateCurriculum(ctx context.Context, scope common.StudyProgramScope, id string, req UpdateCurriculumRequest) (CurriculumResponse, error) {
+	curriculum, err := uc.getCurriculumInScope(ctx, scope, id)
+	if err != nil {
+		return CurriculumResponse{}, err
+	}
+
+	err = uc.ensureCodeAvailable(ctx, id, curriculum.StudyProgramID.String(), req.Code)
+	if err != nil {
+		return CurriculumResponse{}, err
+	}
+
+	attributes := repositories.CurriculumAttributes{
+		Code:     req.Code,
+		Name:     req.Name,
+		IsActive: req.IsActive,
+	}
+	curriculum, err = uc.curriculumRepository.UpdateCurriculum(ctx, id, attributes)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return CurriculumResponse{}, ErrCurriculumNotFound
+		}
+		return CurriculumResponse{}, errors.Wrap(err, "cannot update curriculum")
+	}
+
+	return toCurriculumResponse(curriculum), nil
+}
+
+func (uc *CurriculumUseCase) DeleteCurriculum(ctx context.Context, scope common.StudyProgramScope, id string) error {
+	_, err := uc.getCurriculumInScope(ctx, scope, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = uc.curriculumRepository.DeleteCurriculum(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrCurriculumNotFound
+		}
+		return errors.Wrap(err, "cannot delete curriculum")
+	}
+
+	return nil
+}
+
+func (uc *CurriculumUseCase) AddCourse(ctx context.Context, scope common.StudyProgramScope, id string, req AddCurriculumCourseRequest) (CurriculumCourseResponse, error) {
+	_, err := uc.getCurriculumInScope(ctx, scope, id)
+	if err != nil {
+		return CurriculumCourseResponse{}, err
+	}
+
+	course, err := uc.academicRepository.GetCourse(ctx, req.CourseID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return CurriculumCourseResponse{}, ErrUnknownCourse
+		}
+		return CurriculumCourseResponse{}, errors.Wrap(err, "cannot get course")
+	}
+	if course.DeletedAt.Valid {
+		return CurriculumCourseResponse{}, ErrUnknownCourse
+	}
+
+	courses, err := uc.curriculumRepository.ListCurriculumCourses(ctx, id)
+	if err != nil {
+		return CurriculumCourseResponse{}, errors.Wrap(err, "cannot get curriculum courses")
+	}
+	for _, existing := range courses {
+		if existing.CourseID == course.ID {
+			return CurriculumCourseResponse{}, ErrCourseAlreadyInCurriculum
+		}
+	}
+
+	curriculumCourse, err := uc.curriculumRepository.CreateCurriculumCourse(ctx, uuid.NewString(), id, req.CourseID)
+	if err != nil {
+		return CurriculumCourseResponse{}, errors.Wrap(err, "cannot add course to curriculum")
+	}
+
+	response := CurriculumCourseResponse{
+		CourseID:   course.ID.String(),
+		CourseCode: course.Code,
+		CourseName: course.Name,
+		Credit:     course.Credit,
+	}
+	if curriculumCourse.CreatedAt.Valid {
+		response.AddedAt = curriculumCourse.CreatedAt.Time
+	}
+
+	return response, nil
+}
+
+// RemoveCourse takes a course out of the curriculum, existing course offerings of the course are kept.
+func (uc *CurriculumUseCase) RemoveCourse(ctx context.Context, scope common.StudyProgramScope, id, courseID string) error {
+	_, err := uc.getCurriculumInScope(ctx, scope, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = uc.curriculumRepository.DeleteCurriculumCourse(ctx, id, courseID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrCourseNotInCurriculum
+		}
+		return errors.Wrap(err, "cannot remove course from curriculum")
+	}
+
+	return nil
+}
+
+func (uc *CurriculumUseCase) getCurriculum(ctx context.Context, id string) (generated.Curriculum, error) {
+	curriculum, err := uc.curriculumRepository.GetCurriculum(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return generated.Curriculum{}, ErrCurriculumNotFound
+		}
+		return generated.Curriculum{}, errors.Wrap(err, "cannot get curriculum")
+	}
+
+	return curriculum, nil
+}
+
+func (uc *CurriculumUseCase) getCurriculumInScope(ctx context.Context, scope common.StudyProgramScope, id string) (generated.Curriculum, error) {
+	curriculum, err := uc.getCurriculum(ctx, id)
+	if err != nil {
+		return generated.Curriculum{}, err
+	}
+
+	err = ensureStudyProgramInScope(scope, curriculum.StudyProgramID.String())
+	if err != nil {
+		return generated.Curriculum{}, err
+	}
+
+	return curriculum, nil
+}
+
+// ensureCodeAvailable checks the code uniqueness within the study program, id is the curriculum being updated, if any.
+func (uc *CurriculumUseCase) ensureCodeAvailable(ctx context.Context, id, studyProgramID, code string) error {
+	existing, err := uc.curriculumRepository.GetCurriculumByCode(ctx, studyProgramID, code)
+	if err == nil && existing.ID.String() != id {
+		return ErrCurriculumCodeAlreadyUsed
+	}
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return errors.Wrap(err, "cannot check curriculum code availability")
+	}
+
+	return nil
+}
+
+func ensureStudyProgramInScope(scope common.StudyProgramScope, studyProgramID string) error {
+	if scope.All {
+		return nil
+	}
+	if scope.StudyProgramID == "" {
+		return ErrNoStudyProgram
+	}
+	if scope.StudyProgramID != studyProgramID {
+		return ErrStudyProgramOutOfScope
+	}
+	return nil
+}
+
+func toCurriculumResponse(curriculum generated.Curriculum) CurriculumResponse {
+	response := CurriculumResponse{
+		ID:             curriculum.ID.String(),
+		StudyProgramID: curriculum.StudyProgramID.String(),
+		Code:           curriculum.Code,
+		Name:           curriculum.Name,
+		IsActive:       curriculum.IsActive,
+	}
+
+	if curriculum.CreatedAt.Valid {
+		response.CreatedAt = curriculum.CreatedAt.Time
+	}
+	if curriculum.UpdatedAt.Valid {
+		updatedAt := curriculum.UpdatedAt.Time
+		response.UpdatedAt = &updatedAt
+	}
+
+	return response
+}
+
+func toCurriculumCourseResponse(course generated.ListCurriculumCoursesRow) CurriculumCourseResponse {
+	response := CurriculumCourseResponse{
+		CourseID:   course.CourseID.String(),
+		CourseCode: course.CourseCode,
+		CourseName: course.CourseName,
+		Credit:     course.Credit,
+	}
+
+	if course.CreatedAt.Valid {
+		response.AddedAt = course.CreatedAt.Time
+	}
+
+	return response
+}
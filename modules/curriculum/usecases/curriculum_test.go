@@ -0,0 +1,265 @@
+package usecases
+
+import (
+	"context"
+	"siakad-poc/common"
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// Mock repository for curriculum and study program tests
+type MockCurriculumRepository struct {
+	mock.Mock
+}
+
+func (m *MockCurriculumRepository) ListStudyPrograms(ctx context.Context, filter repositories.StudyProgramFilter, limit, offset int) ([]generated.StudyProgram, error) {
+	args := m.Called(ctx, filter, limit, offset)
+	return args.Get(0).([]generated.StudyProgram), args.Error(1)
+}
+
+func (m *MockCurriculumRepository) CountStudyPrograms(ctx context.Context, filter repositories.StudyProgramFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCurriculumRepository) GetStudyProgram(ctx context.Context, id string) (generated.StudyProgram, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.StudyProgram), args.Error(1)
+}
+
+func (m *MockCurriculumRepository) GetStudyProgramByCode(ctx context.Context, code string) (generated.StudyProgram, error) {
+	args := m.Called(ctx, code)
+	return args.Get(0).(generated.StudyProgram), args.Error(1)
+}
+
+func (m *MockCurriculumRepository) CreateStudyProgram(ctx context.Context, id string, attributes repositories.StudyProgramAttributes) (generated.StudyProgram, error) {
+	args := m.Called(ctx, id, attributes)
+	return args.Get(0).(generated.StudyProgram), args.Error(1)
+}
+
+func (m *MockCurriculumRepository) UpdateStudyProgram(ctx context.Context, id string, attributes repositories.StudyProgramAttributes) (generated.StudyProgram, error) {
+	args := m.Called(ctx, id, attributes)
+	return args.Get(0).(generated.StudyProgram), args.Error(1)
+}
+
+func (m *MockCurriculumRepository) DeleteStudyProgram(ctx context.Context, id string) (generated.StudyProgram, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.StudyProgram), args.Error(1)
+}
+
+func (m *MockCurriculumRepository) IsStudyProgramInUse(ctx context.Context, id string) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(bool), args.Error(1)
+}
+
+func (m *MockCurriculumRepository) ListCurricula(ctx context.Context, filter repositories.CurriculumFilter, limit, offset int) ([]generated.Curriculum, error) {
+	args := m.Called(ctx, filter, limit, offset)
+	return args.Get(0).([]generated.Curriculum), args.Error(1)
+}
+
+func (m *MockCurriculumRepository) CountCurricula(ctx context.Context, filter repositories.CurriculumFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCurriculumRepository) GetCurriculum(ctx context.Context, id string) (generated.Curriculum, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.Curriculum), args.Error(1)
+}
+
+func (m *MockCurriculumRepository) GetCurriculumByCode(ctx context.Context, studyProgramID, code string) (generated.Curriculum, error) {
+	args := m.Called(ctx, studyProgramID, code)
+	return args.Get(0).(generated.Curriculum), args.Error(1)
+}
+
+func (m *MockCurriculumRepository) CreateCurriculum(ctx context.Context, id, studyProgramID string, attributes repositories.CurriculumAttributes) (generated.Curriculum, error) {
+	args := m.Called(ctx, id, studyProgramID, attributes)
+	return args.Get(0).(generated.Curriculum), args.Error(1)
+}
+
+func (m *MockCurriculumRepository) UpdateCurriculum(ctx context.Context, id string, attributes repositories.CurriculumAttributes) (generated.Curriculum, error) {
+	args := m.Called(ctx, id, attributes)
+	return args.Get(0).(generated.Curriculum), args.Error(1)
+}
+
+func (m *MockCurriculumRepository) DeleteCurriculum(ctx context.Context, id string) (generated.Curriculum, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(generated.Curriculum), args.Error(1)
+}
+
+func (m *MockCurriculumRepository) ListCurriculumCourses(ctx context.Context, curriculumID string) ([]generated.ListCurriculumCoursesRow, error) {
+	args := m.Called(ctx, curriculumID)
+	return args.Get(0).([]generated.ListCurriculumCoursesRow), args.Error(1)
+}
+
+func (m *MockCurriculumRepository) CreateCurriculumCourse(ctx context.Context, id, curriculumID, courseID string) (generated.CurriculumCourse, error) {
+	args := m.Called(ctx, id, curriculumID, courseID)
+	return args.Get(0).(generated.CurriculumCourse), args.Error(1)
+}
+
+func (m *MockCurriculumRepository) DeleteCurriculumCourse(ctx context.Context, curriculumID, courseID string) (generated.CurriculumCourse, error) {
+	args := m.Called(ctx, curriculumID, courseID)
+	return args.Get(0).(generated.CurriculumCourse), args.Error(1)
+}
+
+// Test Suite
+type CurriculumUseCaseTestSuite struct {
+	suite.Suite
+	useCase          *CurriculumUseCase
+	mockRepo         *MockCurriculumRepository
+	ctx              context.Context
+	curriculumUUID   pgtype.UUID
+	studyProgramUUID pgtype.UUID
+}
+
+func (suite *CurriculumUseCaseTestSuite) SetupTest() {
+	suite.mockRepo = new(MockCurriculumRepository)
+	suite.useCase = NewCurriculumUseCase(suite.mockRepo, nil)
+	suite.ctx = context.Background()
+
+	suite.curriculumUUID = pgtype.UUID{
+		Bytes: [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		Valid: true,
+	}
+	suite.studyProgramUUID = pgtype.UUID{
+		Bytes: [16]byte{2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17},
+		Valid: true,
+	}
+}
+
+func (suite *CurriculumUseCaseTestSuite) TearDownTest() {
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// Test successful curriculum creation by a user scoped to the study program
+func (suite *CurriculumUseCaseTestSuite) TestCreateCurriculum_Success() {
+	studyProgramID := suite.studyProgramUUID.String()
+	scope := common.StudyProgramScope{StudyProgramID: studyProgramID}
+	req := CreateCurriculumRequest{
+		StudyProgramID: studyProgramID,
+		Code:           "K2025",
+		Name:           "Kurikulum 2025",
+		IsActive:       true,
+	}
+	attributes := repositories.CurriculumAttributes{Code: req.Code, Name: req.Name, IsActive: true}
+
+	suite.mockRepo.On("GetStudyProgram", suite.ctx, studyProgramID).Return(generated.StudyProgram{ID: suite.studyProgramUUID}, nil)
+	suite.mockRepo.On("GetCurriculumByCode", suite.ctx, studyProgramID, req.Code).Return(generated.Curriculum{}, pgx.ErrNoRows)
+	suite.mockRepo.On("CreateCurriculum", suite.ctx, mock.AnythingOfType("string"), studyProgramID, attributes).Return(generated.Curriculum{
+		ID:             suite.curriculumUUID,
+		StudyProgramID: suite.studyProgramUUID,
+		Code:           req.Code,
+		Name:           req.Name,
+		IsActive:       true,
+	}, nil)
+
+	response, err := suite.useCase.CreateCurriculum(suite.ctx, scope, req)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), suite.curriculumUUID.String(), response.ID)
+	assert.Equal(suite.T(), studyProgramID, response.StudyProgramID)
+	assert.True(suite.T(), response.IsActive)
+}
+
+// Test creating a curriculum for another study program
+func (suite *CurriculumUseCaseTestSuite) TestCreateCurriculum_StudyProgramOutOfScope() {
+	scope := common.StudyProgramScope{StudyProgramID: "prodi-123"}
+	req := CreateCurriculumRequest{
+		StudyProgramID: suite.studyProgramUUID.String(),
+		Code:           "K2025",
+		Name:           "Kurikulum 2025",
+	}
+
+	_, err := suite.useCase.CreateCurriculum(suite.ctx, scope, req)
+
+	assert.ErrorIs(suite.T(), err, ErrStudyProgramOutOfScope)
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateCurriculum")
+}
+
+// Test creating a curriculum with a code already used within the study program
+func (suite *CurriculumUseCaseTestSuite) TestCreateCurriculum_CodeAlreadyUsed() {
+	studyProgramID := suite.studyProgramUUID.String()
+	req := CreateCurriculumRequest{
+		StudyProgramID: studyProgramID,
+		Code:           "K2025",
+		Name:           "Kurikulum 2025",
+	}
+
+	suite.mockRepo.On("GetStudyProgram", suite.ctx, studyProgramID).Return(generated.StudyProgram{ID: suite.studyProgramUUID}, nil)
+	suite.mockRepo.On("GetCurriculumByCode", suite.ctx, studyProgramID, req.Code).Return(generated.Curriculum{ID: suite.curriculumUUID}, nil)
+
+	_, err := suite.useCase.CreateCurriculum(suite.ctx, common.GlobalStudyProgramScope(), req)
+
+	assert.ErrorIs(suite.T(), err, ErrCurriculumCodeAlreadyUsed)
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateCurriculum")
+}
+
+// Test deactivating a curriculum while keeping its code
+func (suite *CurriculumUseCaseTestSuite) TestUpdateCurriculum_Deactivate() {
+	id := suite.curriculumUUID.String()
+	existing := generated.Curriculum{ID: suite.curriculumUUID, StudyProgramID: suite.studyProgramUUID, Code: "K2025", IsActive: true}
+	req := UpdateCurriculumRequest{Code: "K2025", Name: "Kurikulum 2025", IsActive: false}
+	attributes := repositories.CurriculumAttributes{Code: req.Code, Name: req.Name, IsActive: false}
+
+	suite.mockRepo.On("GetCurriculum", suite.ctx, id).Return(existing, nil)
+	suite.mockRepo.On("GetCurriculumByCode", suite.ctx, suite.studyProgramUUID.String(), req.Code).Return(existing, nil)
+	suite.mockRepo.On("UpdateCurriculum", suite.ctx, id, attributes).Return(generated.Curriculum{
+		ID:             suite.curriculumUUID,
+		StudyProgramID: suite.studyProgramUUID,
+		Code:           req.Code,
+		Name:           req.Name,
+	}, nil)
+
+	response, err := suite.useCase.UpdateCurriculum(suite.ctx, common.GlobalStudyProgramScope(), id, req)
+
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), response.IsActive)
+}
+
+// Test updating a curriculum of another study program
+func (suite *CurriculumUseCaseTestSuite) TestUpdateCurriculum_StudyProgramOutOfScope() {
+	id := suite.curriculumUUID.String()
+	scope := common.StudyProgramScope{StudyProgramID: "prodi-123"}
+
+	suite.mockRepo.On("GetCurriculum", suite.ctx, id).Return(generated.Curriculum{ID: suite.curriculumUUID, StudyProgramID: suite.studyProgramUUID}, nil)
+
+	_, err := suite.useCase.UpdateCurriculum(suite.ctx, scope, id, UpdateCurriculumRequest{Code: "K2025", Name: "Kurikulum 2025"})
+
+	assert.ErrorIs(suite.T(), err, ErrStudyProgramOutOfScope)
+	suite.mockRepo.AssertNotCalled(suite.T(), "UpdateCurriculum")
+}
+
+// Test getting a curriculum that does not exist
+func (suite *CurriculumUseCaseTestSuite) TestGetCurriculum_NotFound() {
+	id := suite.curriculumUUID.String()
+
+	suite.mockRepo.On("GetCurriculum", suite.ctx, id).Return(generated.Curriculum{}, pgx.ErrNoRows)
+
+	_, err := suite.useCase.GetCurriculum(suite.ctx, id)
+
+	assert.ErrorIs(suite.T(), err, ErrCurriculumNotFound)
+}
+
+// Test removing a course that is not part of the curriculum
+func (suite *CurriculumUseCaseTestSuite) TestRemoveCourse_NotInCurriculum() {
+	id := suite.curriculumUUID.String()
+	courseID := "3a4b5c6d-7e8f-4a0b-9c1d-2e3f4a5b6c7d"
+
+	suite.mockRepo.On("GetCurriculum", suite.ctx, id).Return(generated.Curriculum{ID: suite.curriculumUUID, StudyProgramID: suite.studyProgramUUID}, nil)
+	suite.mockRepo.On("DeleteCurriculumCourse", suite.ctx, id, courseID).Return(generated.CurriculumCourse{}, pgx.ErrNoRows)
+
+	err := suite.useCase.RemoveCourse(suite.ctx, common.GlobalStudyProgramScope(), id, courseID)
+
+	assert.ErrorIs(suite.T(), err, ErrCourseNotInCurriculum)
+}
+
+func TestCurriculumUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(CurriculumUseCaseTestSuite))
+}
@@ -0,0 +1,23 @@
+package usecases
+
+import "github.com/pkg/errors"
+
+var (
+	ErrStudyProgramCodeAlreadyUsed = errors.New("study program code is already used")
+	ErrStudyProgramInUse           = errors.New("study program is still referenced by curricula, students, lecturers or users")
+	ErrStudyProgramNotFound        = errors.New("study program not found")
+)
+
+var (
+	ErrCourseAlreadyInCurriculum = errors.New("course is already part of the curriculum")
+	ErrCourseNotInCurriculum     = errors.New("course is not part of the curriculum")
+	ErrCurriculumCodeAlreadyUsed = errors.New("curriculum code is already used within the study program")
+	ErrCurriculumNotFound        = errors.New("curriculum not found")
+	ErrUnknownCourse             = errors.New("course does not exist")
+	ErrUnknownStudyProgram       = errors.New("study program does not exist")
+)
+
+var (
+	ErrNoStudyProgram         = errors.New("your account is not linked to a study program")
+	ErrStudyProgramOutOfScope = errors.New("curriculum is not part of your study program")
+)
@@ -0,0 +1,196 @@
+package usecases
+
+import (
+	"context"
+	"math"
+	"siakad-poc/common"
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+type StudyProgramResponse struct {
+	ID        string     `json:"id"`
+	Code      string     `json:"code"`
+	Name      string     `json:"name"`
+	Level     string     `json:"level"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+// StudyProgramRequest is the payload to create or update a study program, the level is the jenjang
+type StudyProgramRequest struct {
+	Code  string `json:"code" validate:"required,max=255"`
+	Name  string `json:"name" validate:"required,max=255"`
+	Level string `json:"level" validate:"required,oneof=D1 D2 D3 D4 S1 S2 S3"`
+}
+
+type StudyProgramUseCase struct {
+	curriculumRepository repositories.CurriculumRepository
+}
+
+func NewStudyProgramUseCase(curriculumRepository repositories.CurriculumRepository) *StudyProgramUseCase {
+	return &StudyProgramUseCase{
+		curriculumRepository: curriculumRepository,
+	}
+}
+
+func (uc *StudyProgramUseCase) ListStudyPrograms(ctx context.Context, filter repositories.StudyProgramFilter, page, pageSize int) ([]StudyProgramResponse, *common.PaginationMetadata, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+
+	studyPrograms, err := uc.curriculumRepository.ListStudyPrograms(ctx, filter, pageSize, offset)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot get study programs")
+	}
+
+	totalRecords, err := uc.curriculumRepository.CountStudyPrograms(ctx, filter)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot count study programs")
+	}
+
+	responses := make([]StudyProgramResponse, 0, len(studyPrograms))
+	for _, studyProgram := range studyPrograms {
+		responses = append(responses, toStudyProgramResponse(studyProgram))
+	}
+
+	pagination := &common.PaginationMetadata{
+		Page:         page,
+		PageSize:     pageSize,
+		TotalRecords: int(totalRecords),
+		TotalPages:   int(math.Ceil(float64(totalRecords) / float64(pageSize))),
+	}
+
+	return responses, pagination, nil
+}
+
+func (uc *StudyProgramUseCase) GetStudyProgram(ctx context.Context, id string) (StudyProgramResponse, error) {
+	studyProgram, err := uc.curriculumRepository.GetStudyProgram(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return StudyProgramResponse{}, ErrStudyProgramNotFound
+		}
+		return StudyProgramResponse{}, errors.Wrap(err, "cannot get study program")
+	}
+
+	return toStudyProgramResponse(studyProgram), nil
+}
+
+// CreateStudyProgram adds a study program, codes are unique, soft-deleted study programs included.
+func (uc *StudyProgramUseCase) CreateStudyProgram(ctx context.Context, req StudyProgramRequest) (StudyProgramResponse, error) {
+	err := uc.ensureCodeAvailable(ctx, "", req.Code)
+	if err != nil {
+		return StudyProgramResponse{}, err
+	}
+
+	studyProgram, err := uc.curriculumRepository.CreateStudyProgram(ctx, uuid.NewString(), toStudyProgramAttributes(req))
+	if err != nil {
+		return StudyProgramResponse{}, errors.Wrap(err, "cannot create study program")
+	}
+
+	return toStudyProgramResponse(studyProgram), nil
+}
+
+func (uc *StudyProgramUseCase) UpdateStudyProgram(ctx context.Context, id string, req StudyProgramRequest) (StudyProgramResponse, error) {
+	_, err := uc.curriculumRepository.GetStudyProgram(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return StudyProgramResponse{}, ErrStudyProgramNotFound
+		}
+		return StudyProgramResponse{}, errors.Wrap(err, "cannot get study program")
+	}
+
+	err = uc.ensureCodeAvailable(ctx, id, req.Code)
+	if err != nil {
+		return StudyProgramResponse{}, err
+	}
+
+	studyProgram, err := uc.curriculumRepository.UpdateStudyProgram(ctx, id, toStudyProgramAttributes(req))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return StudyProgramResponse{}, ErrStudyProgramNotFound
+		}
+		return StudyProgramResponse{}, errors.Wrap(err, "cannot update study program")
+	}
+
+	return toStudyProgramResponse(studyProgram), nil
+}
+
+// DeleteStudyProgram soft-deletes a study program that is no longer referenced by any active record.
+func (uc *StudyProgramUseCase) DeleteStudyProgram(ctx context.Context, id string) error {
+	_, err := uc.curriculumRepository.GetStudyProgram(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrStudyProgramNotFound
+		}
+		return errors.Wrap(err, "cannot get study program")
+	}
+
+	inUse, err := uc.curriculumRepository.IsStudyProgramInUse(ctx, id)
+	if err != nil {
+		return errors.Wrap(err, "cannot check study program references")
+	}
+	if inUse {
+		return ErrStudyProgramInUse
+	}
+
+	_, err = uc.curriculumRepository.DeleteStudyProgram(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrStudyProgramNotFound
+		}
+		return errors.Wrap(err, "cannot delete study program")
+	}
+
+	return nil
+}
+
+// ensureCodeAvailable checks the code uniqueness, id is the study program being updated, if any.
+func (uc *StudyProgramUseCase) ensureCodeAvailable(ctx context.Context, id, code string) error {
+	existing, err := uc.curriculumRepository.GetStudyProgramByCode(ctx, code)
+	if err == nil && existing.ID.String() != id {
+		return ErrStudyProgramCodeAlreadyUsed
+	}
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return errors.Wrap(err, "cannot check study program code availability")
+	}
+
+	return nil
+}
+
+func toStudyProgramAttributes(req StudyProgramRequest) repositories.StudyProgramAttributes {
+	return repositories.StudyProgramAttributes{
+		Code:  req.Code,
+		Name:  req.Name,
+		Level: req.Level,
+	}
+}
+
+func toStudyProgramResponse(studyProgram generated.StudyProgram) StudyProgramResponse {
+	response := StudyProgramResponse{
+		ID:    studyProgram.ID.String(),
+		Code:  studyProgram.Code,
+		Name:  studyProgram.Name,
+		Level: studyProgram.Level,
+	}
+
+	if studyProgram.CreatedAt.Valid {
+		response.CreatedAt = studyProgram.CreatedAt.Time
+	}
+	if studyProgram.UpdatedAt.Valid {
+		updatedAt := studyProgram.UpdatedAt.Time
+		response.UpdatedAt = &updatedAt
+	}
+
+	return response
+}
@@ -0,0 +1,119 @@
+package usecases
+
+import (
+	"context"
+	"siakad-poc/db/generated"
+	"siakad-poc/db/repositories"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// Test Suite
+type StudyProgramUseCaseTestSuite struct {
+	suite.Suite
+	useCase          *StudyProgramUseCase
+	mockRepo         *MockCurriculumRepository
+	ctx              context.Context
+	studyProgramUUID pgtype.UUID
+}
+
+func (suite *StudyProgramUseCaseTestSuite) SetupTest() {
+	suite.mockRepo = new(MockCurriculumRepository)
+	suite.useCase = NewStudyProgramUseCase(suite.mockRepo)
+	suite.ctx = context.Background()
+
+	suite.studyProgramUUID = pgtype.UUID{
+		Bytes: [16]byte{2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17},
+		Valid: true,
+	}
+}
+
+func (suite *StudyProgramUseCaseTestSuite) TearDownTest() {
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// Test successful study program creation
+func (suite *StudyProgramUseCaseTestSuite) TestCreateStudyProgram_Success() {
+	req := StudyProgramRequest{Code: "IF", Name: "Informatika", Level: "S1"}
+	attributes := repositories.StudyProgramAttributes{Code: "IF", Name: "Informatika", Level: "S1"}
+
+	suite.mockRepo.On("GetStudyProgramByCode", suite.ctx, req.Code).Return(generated.StudyProgram{}, pgx.ErrNoRows)
+	suite.mockRepo.On("CreateStudyProgram", suite.ctx, mock.AnythingOfType("string"), attributes).Return(generated.StudyProgram{
+		ID:    suite.studyProgramUUID,
+		Code:  req.Code,
+		Name:  req.Name,
+		Level: req.Level,
+	}, nil)
+
+	response, err := suite.useCase.CreateStudyProgram(suite.ctx, req)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), suite.studyProgramUUID.String(), response.ID)
+	assert.Equal(suite.T(), "S1", response.Level)
+}
+
+// Test creating a study program with a code already used, soft-deleted study programs included
+func (suite *StudyProgramUseCaseTestSuite) TestCreateStudyProgram_CodeAlreadyUsed() {
+	req := StudyProgramRequest{Code: "IF", Name: "Informatika", Level: "S1"}
+
+	suite.mockRepo.On("GetStudyProgramByCode", suite.ctx, req.Code).Return(generated.StudyProgram{ID: suite.studyProgramUUID}, nil)
+
+	_, err := suite.useCase.CreateStudyProgram(suite.ctx, req)
+
+	assert.ErrorIs(suite.T(), err, ErrStudyProgramCodeAlreadyUsed)
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateStudyProgram")
+}
+
+// Test updating a study program while keeping its own code
+func (suite *StudyProgramUseCaseTestSuite) TestUpdateStudyProgram_KeepsOwnCode() {
+	id := suite.studyProgramUUID.String()
+	req := StudyProgramRequest{Code: "IF", Name: "Teknik Informatika", Level: "S1"}
+	existing := generated.StudyProgram{ID: suite.studyProgramUUID, Code: "IF", Name: "Informatika", Level: "S1"}
+
+	suite.mockRepo.On("GetStudyProgram", suite.ctx, id).Return(existing, nil)
+	suite.mockRepo.On("GetStudyProgramByCode", suite.ctx, req.Code).Return(existing, nil)
+	suite.mockRepo.On("UpdateStudyProgram", suite.ctx, id, toStudyProgramAttributes(req)).Return(generated.StudyProgram{
+		ID:    suite.studyProgramUUID,
+		Code:  req.Code,
+		Name:  req.Name,
+		Level: req.Level,
+	}, nil)
+
+	response, err := suite.useCase.UpdateStudyProgram(suite.ctx, id, req)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Teknik Informatika", response.Name)
+}
+
+// Test deleting a study program that is still referenced
+func (suite *StudyProgramUseCaseTestSuite) TestDeleteStudyProgram_InUse() {
+	id := suite.studyProgramUUID.String()
+
+	suite.mockRepo.On("GetStudyProgram", suite.ctx, id).Return(generated.StudyProgram{ID: suite.studyProgramUUID}, nil)
+	suite.mockRepo.On("IsStudyProgramInUse", suite.ctx, id).Return(true, nil)
+
+	err := suite.useCase.DeleteStudyProgram(suite.ctx, id)
+
+	assert.ErrorIs(suite.T(), err, ErrStudyProgramInUse)
+	suite.mockRepo.AssertNotCalled(suite.T(), "DeleteStudyProgram")
+}
+
+// Test deleting a study program that does not exist
+func (suite *StudyProgramUseCaseTestSuite) TestDeleteStudyProgram_NotFound() {
+	id := suite.studyProgramUUID.String()
+
+	suite.mockRepo.On("GetStudyProgram", suite.ctx, id).Return(generated.StudyProgram{}, pgx.ErrNoRows)
+
+	err := suite.useCase.DeleteStudyProgram(suite.ctx, id)
+
+	assert.ErrorIs(suite.T(), err, ErrStudyProgramNotFound)
+}
+
+func TestStudyProgramUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(StudyProgramUseCaseTestSuite))
+}